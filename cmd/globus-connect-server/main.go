@@ -8,18 +8,27 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
 	auditcmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/audit"
 	authcmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/auth"
 	authpolicycmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/authpolicy"
 	collectioncmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/collection"
+	configcmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/config"
 	endpointcmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/endpoint"
+	examplescmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/examples"
+	historycmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/history"
 	nodecmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/node"
 	oidccmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/oidc"
+	precheckcmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/precheck"
 	rolecmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/role"
+	selftestcmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/selftest"
 	sessioncmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/session"
 	sharingpolicycmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/sharingpolicy"
 	storagegatewaycmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/storagegateway"
+	supportcmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/support"
+	trashcmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/trash"
 	usercredentialcmd "github.com/scttfrdmn/globus-go-gcs/internal/commands/usercredential"
+	"github.com/scttfrdmn/globus-go-gcs/internal/i18n"
 	"github.com/spf13/cobra"
 )
 
@@ -31,6 +40,9 @@ var (
 )
 
 func main() {
+	// Help text is translated before cobra parses the command line
+	langErr := i18n.SetLanguage(i18n.Detect(os.Args[1:], os.Getenv))
+
 	rootCmd := &cobra.Command{
 		Use:   "globus-connect-server",
 		Short: "Globus Connect Server command-line interface",
@@ -39,18 +51,50 @@ func main() {
 This is a complete Go port of the Python globus-connect-server CLI with 100% feature parity.
 
 For more information, see: https://docs.globus.org/globus-connect-server/v5/`,
-		Version: fmt.Sprintf("%s (commit: %s, built: %s)", version, commit, date),
+		Version:           fmt.Sprintf("%s (commit: %s, built: %s)", version, commit, date),
+		PersistentPreRunE: cli.Prepare,
+		// Errors are printed by cli.PrintError, as JSON in JSON mode
+		SilenceErrors: true,
 	}
 
 	// Global flags
-	rootCmd.PersistentFlags().String("format", "text", "Output format (text, json)")
+	rootCmd.PersistentFlags().String("format", "text", "Output format (text, json, yaml)")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
-	rootCmd.PersistentFlags().Bool("debug", false, "Enable debug logging")
+	rootCmd.PersistentFlags().Bool(cli.DebugFlag, false, "Log each GCS API request to stderr (method, path, status, duration, request ID)")
+	rootCmd.PersistentFlags().Bool(cli.TraceFlag, false, "Like --debug, and also log request and response headers and bodies, with tokens redacted")
+	rootCmd.PersistentFlags().Bool(cli.TimingFlag, false, "Print the DNS, connect, TLS, and time-to-first-byte timing of each GCS API request to stderr")
+	rootCmd.PersistentFlags().Bool(cli.NoHistoryFlag, false, "Do not record this command in the activity log")
+	rootCmd.PersistentFlags().Bool(cli.RawNumbersFlag, false, "Print exact byte counts and durations in seconds instead of 1.2 GiB, 3m42s")
+	rootCmd.PersistentFlags().String(cli.TemplateFlag, "", "Go template for each item of output, e.g. '{{.ID}} {{.DisplayName}}' (implies --format template)")
+	rootCmd.PersistentFlags().String(cli.QueryFlag, "", "JMESPath expression to filter JSON or YAML output, e.g. 'data[?public].id' (implies --format json)")
+	rootCmd.PersistentFlags().String(cli.ConfirmWithFlag, "", "Confirm a high-risk command by naming the resource it acts on (e.g., the storage gateway ID)")
+	rootCmd.PersistentFlags().Bool(cli.NoPagerFlag, false, "Do not page long output on a terminal (also disabled by PAGER=cat)")
+	rootCmd.PersistentFlags().Bool(cli.NoColorFlag, false, "Disable colored output (also set by NO_COLOR)")
+	rootCmd.PersistentFlags().String(cli.OutputStyleFlag, "", "Text output style: default, or plain for screen readers (no color, box drawing, or padding)")
+	rootCmd.PersistentFlags().String(i18n.LangFlag, "", "Language for messages and help (en, es)")
+	rootCmd.PersistentFlags().Bool(cli.DryRunFlag, false, "Print the requests that would change the endpoint (method, path, body) instead of sending them")
+	rootCmd.PersistentFlags().String(cli.TimeoutFlag, "", "Timeout of each GCS API request, e.g. 10s or 2m (0 for none; default 30s)")
+	rootCmd.PersistentFlags().String(cli.RateLimitFlag, "", "Limit GCS API requests per second, e.g. 5 or 0.5 (0 for no limit)")
+	rootCmd.PersistentFlags().String(cli.ClientCertFlag, "", "PEM file of a TLS client certificate for endpoints behind proxies that require one")
+	rootCmd.PersistentFlags().String(cli.ClientKeyFlag, "", "PEM file of the client certificate's private key (default: the --client-cert file)")
+	rootCmd.PersistentFlags().String(cli.ProxyFlag, "", "Proxy for GCS API requests, e.g. http://proxy:3128 or socks5://127.0.0.1:1080 (default: HTTPS_PROXY)")
+	rootCmd.PersistentFlags().String(cli.CABundleFlag, "", "PEM file of CA certificates to trust in addition to the system's, for endpoints with internal certificates")
+	rootCmd.PersistentFlags().Bool(cli.InsecureSkipTLSVerifyFlag, false, "Do not verify endpoint TLS certificates (INSECURE: for test endpoints only)")
+	rootCmd.PersistentFlags().String(cli.UserAgentSuffixFlag, "", "Text appended to the User-Agent of GCS API requests, e.g. nightly-sync/2")
+	rootCmd.PersistentFlags().Bool(cli.AccessTokenStdinFlag, false, "Read the access token from the first line of stdin instead of the stored token (also "+cli.AccessTokenEnv+")")
+	rootCmd.PersistentFlags().StringArray(cli.AnnotateFlag, nil, "Annotate requests with key=value (e.g., ticket=CHG12345); sent as a header and recorded in the activity log")
+
+	// Developer-only fault injection for testing automation
+	rootCmd.PersistentFlags().StringArray(cli.InjectFaultFlag, nil, "Fail a fraction of GCS API requests, e.g. 503:0.1 or reset:0.05 (for testing)")
+	rootCmd.PersistentFlags().Duration(cli.InjectLatencyFlag, 0, "Delay every GCS API request, e.g. 2s (for testing)")
+	_ = rootCmd.PersistentFlags().MarkHidden(cli.InjectFaultFlag)
+	_ = rootCmd.PersistentFlags().MarkHidden(cli.InjectLatencyFlag)
 
 	// Authentication commands
 	rootCmd.AddCommand(authcmd.NewLoginCmd())
 	rootCmd.AddCommand(authcmd.NewLogoutCmd())
 	rootCmd.AddCommand(authcmd.NewWhoamiCmd())
+	rootCmd.AddCommand(authcmd.NewAuthCmd())
 
 	// Endpoint commands
 	rootCmd.AddCommand(endpointcmd.NewEndpointCmd())
@@ -85,8 +129,43 @@ For more information, see: https://docs.globus.org/globus-connect-server/v5/`,
 	// Audit commands
 	rootCmd.AddCommand(auditcmd.NewAuditCmd())
 
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	// Configuration commands
+	rootCmd.AddCommand(configcmd.NewConfigCmd())
+
+	// Network requirements before setup
+	rootCmd.AddCommand(precheckcmd.NewPrecheckCmd())
+
+	// Self-test commands
+	rootCmd.AddCommand(selftestcmd.NewSelftestCmd())
+
+	// Activity log
+	rootCmd.AddCommand(historycmd.NewHistoryCmd())
+
+	// Diagnostics for support tickets
+	rootCmd.AddCommand(supportcmd.NewSupportCmd())
+
+	// Deleted roles and policies
+	rootCmd.AddCommand(trashcmd.NewTrashCmd())
+
+	// Recipes for common tasks
+	rootCmd.AddCommand(examplescmd.NewExamplesCmd())
+
+	// Offer to log in again when a token is revoked mid-command
+	cli.Relogin = authcmd.Relogin
+	cli.UserAgent = "globus-connect-server/" + version
+
+	if langErr != nil {
+		cli.Warnf("%v", langErr)
+	}
+	i18n.LocalizeCommand(rootCmd)
+
+	err := rootCmd.Execute()
+	cli.ClosePager()
+	cli.RunPostHooks(err)
+	cli.RecordHistory(err)
+	cli.ShutdownTracing()
+	cli.PrintError(os.Stderr, err)
+	if err != nil {
+		os.Exit(cli.ExitCode(err))
 	}
 }
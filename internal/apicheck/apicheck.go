@@ -0,0 +1,198 @@
+// Package apicheck records the exported API of the supported public
+// packages so that incompatible changes are caught in review.
+//
+// Each exported function, method, type, struct field, interface method,
+// constant and variable is rendered as one line. A change is compatible
+// when every previously recorded line is still present; additions are
+// allowed.
+package apicheck
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PublicPackages lists the supported public packages, relative to the
+// module root.
+var PublicPackages = []string{
+	"pkg/gcs",
+	"pkg/gcsauth",
+	"pkg/output",
+}
+
+// Features returns the sorted exported API of the package in dir, one
+// feature per line, each prefixed with pkgPath.
+func Features(dir, pkgPath string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", dir, err)
+	}
+
+	fset := token.NewFileSet()
+	w := &walker{fset: fset, prefix: pkgPath + ": "}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", name, err)
+		}
+		for _, decl := range file.Decls {
+			w.decl(decl)
+		}
+	}
+
+	sort.Strings(w.features)
+	return w.features, nil
+}
+
+// Missing returns the features in want that are not in got.
+func Missing(want, got []string) []string {
+	have := make(map[string]bool, len(got))
+	for _, f := range got {
+		have[f] = true
+	}
+
+	var missing []string
+	for _, f := range want {
+		if !have[f] {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
+
+// walker collects the exported features of a package.
+type walker struct {
+	fset     *token.FileSet
+	prefix   string
+	features []string
+}
+
+func (w *walker) add(format string, args ...interface{}) {
+	w.features = append(w.features, w.prefix+fmt.Sprintf(format, args...))
+}
+
+func (w *walker) decl(decl ast.Decl) {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		w.funcDecl(d)
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				w.typeSpec(s)
+			case *ast.ValueSpec:
+				w.valueSpec(d.Tok, s)
+			}
+		}
+	}
+}
+
+func (w *walker) funcDecl(d *ast.FuncDecl) {
+	if !d.Name.IsExported() {
+		return
+	}
+
+	sig := strings.TrimPrefix(w.node(d.Type), "func")
+	if d.Recv == nil {
+		w.add("func %s%s", d.Name.Name, sig)
+		return
+	}
+
+	recv := w.node(d.Recv.List[0].Type)
+	if !ast.IsExported(strings.TrimLeft(strings.SplitN(recv, "[", 2)[0], "*")) {
+		return
+	}
+	w.add("method (%s) %s%s", recv, d.Name.Name, sig)
+}
+
+func (w *walker) typeSpec(s *ast.TypeSpec) {
+	if !s.Name.IsExported() {
+		return
+	}
+
+	name := s.Name.Name + w.typeParams(s.TypeParams)
+	switch t := s.Type.(type) {
+	case *ast.StructType:
+		w.add("type %s struct", name)
+		for _, field := range t.Fields.List {
+			typ := w.node(field.Type)
+			tag := ""
+			if field.Tag != nil {
+				tag = " " + field.Tag.Value
+			}
+			if len(field.Names) == 0 {
+				w.add("type %s struct, embedded %s", name, typ)
+				continue
+			}
+			for _, n := range field.Names {
+				if n.IsExported() {
+					w.add("type %s struct, %s %s%s", name, n.Name, typ, tag)
+				}
+			}
+		}
+	case *ast.InterfaceType:
+		w.add("type %s interface", name)
+		for _, m := range t.Methods.List {
+			for _, n := range m.Names {
+				w.add("type %s interface, %s%s", name, n.Name, strings.TrimPrefix(w.node(m.Type), "func"))
+			}
+			if len(m.Names) == 0 {
+				w.add("type %s interface, embedded %s", name, w.node(m.Type))
+			}
+		}
+	default:
+		assign := " "
+		if s.Assign.IsValid() {
+			assign = " = "
+		}
+		w.add("type %s%s%s", name, assign, w.node(s.Type))
+	}
+}
+
+func (w *walker) valueSpec(tok token.Token, s *ast.ValueSpec) {
+	for _, n := range s.Names {
+		if !n.IsExported() {
+			continue
+		}
+		if s.Type != nil {
+			w.add("%s %s %s", tok, n.Name, w.node(s.Type))
+		} else {
+			w.add("%s %s", tok, n.Name)
+		}
+	}
+}
+
+// typeParams renders a type parameter list, or "" if there is none.
+func (w *walker) typeParams(fl *ast.FieldList) string {
+	if fl == nil || len(fl.List) == 0 {
+		return ""
+	}
+
+	var parts []string
+	for _, f := range fl.List {
+		for _, n := range f.Names {
+			parts = append(parts, n.Name+" "+w.node(f.Type))
+		}
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// node renders an AST node on a single line.
+func (w *walker) node(n ast.Node) string {
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, w.fset, n)
+	return strings.Join(strings.Fields(buf.String()), " ")
+}
@@ -0,0 +1,106 @@
+package apicheck
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/api.txt with the current API")
+
+// goldenFile records the public API of the last release. Regenerate it
+// with 'go test ./internal/apicheck -update' after an intentional,
+// backwards-compatible addition.
+const goldenFile = "testdata/api.txt"
+
+func currentAPI(t *testing.T) []string {
+	t.Helper()
+
+	var features []string
+	for _, pkg := range PublicPackages {
+		f, err := Features(filepath.Join("..", "..", pkg), pkg)
+		if err != nil {
+			t.Fatalf("Features(%s) error = %v", pkg, err)
+		}
+		features = append(features, f...)
+	}
+	return features
+}
+
+func TestPublicAPICompatible(t *testing.T) {
+	got := currentAPI(t)
+
+	if *update {
+		if err := os.WriteFile(goldenFile, []byte(strings.Join(got, "\n")+"\n"), 0o644); err != nil {
+			t.Fatalf("write %s: %v", goldenFile, err)
+		}
+		return
+	}
+
+	data, err := os.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatalf("read %s: %v", goldenFile, err)
+	}
+	want := strings.Split(strings.TrimSpace(string(data)), "\n")
+
+	for _, f := range Missing(want, got) {
+		t.Errorf("incompatible API change: %s was removed or changed", f)
+	}
+}
+
+func TestFeatures(t *testing.T) {
+	dir := t.TempDir()
+	src := `package sample
+
+type Client struct {
+	Name  string ` + "`json:\"name\"`" + `
+	inner int
+}
+
+func (c *Client) Get(id string) (string, error) { return "", nil }
+func (c *Client) get() {}
+
+type Source interface {
+	Token() (string, error)
+}
+
+const Version = "1.0"
+
+var ErrMissing error
+
+func New[T any](v T) *Client { return nil }
+
+func helper() {}
+`
+	if err := os.WriteFile(filepath.Join(dir, "sample.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Features(dir, "sample")
+	if err != nil {
+		t.Fatalf("Features() error = %v", err)
+	}
+
+	want := []string{
+		"sample: const Version",
+		"sample: func New[T any](v T) *Client",
+		"sample: method (*Client) Get(id string) (string, error)",
+		"sample: type Client struct",
+		"sample: type Client struct, Name string `json:\"name\"`",
+		"sample: type Source interface",
+		"sample: type Source interface, Token() (string, error)",
+		"sample: var ErrMissing error",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Features() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestMissing(t *testing.T) {
+	missing := Missing([]string{"a", "b", "c"}, []string{"a", "c", "d"})
+	if len(missing) != 1 || missing[0] != "b" {
+		t.Errorf("Missing() = %v, want [b]", missing)
+	}
+}
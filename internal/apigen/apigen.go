@@ -0,0 +1,483 @@
+// Package apigen generates the GCSAPI interface of pkg/gcs and the mock
+// client of pkg/gcs/gcstest from the exported methods of *gcs.Client, so
+// that both stay in step with the client as methods are added.
+package apigen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Output file names, in the gcs package directory and its gcstest
+// subdirectory.
+const (
+	APIFile  = "zz_generated_api.go"
+	MockFile = "zz_generated_client.go"
+)
+
+// gcsImport is the import path of the gcs package, for the mock.
+const gcsImport = "github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+
+// receiver is the receiver name of the mock's methods.
+const receiver = "m"
+
+// method is an exported method of *gcs.Client.
+type method struct {
+	name    string
+	doc     string
+	params  []param
+	results []ast.Expr
+}
+
+// param is a method parameter. name is never empty or "_".
+type param struct {
+	name     string
+	typ      ast.Expr
+	variadic bool
+}
+
+// Generate returns the source of the GCSAPI interface and of the gcstest
+// mock client for the gcs package in dir.
+func Generate(dir string) (api, mock []byte, err error) {
+	methods, imports, err := clientMethods(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if api, err = render(apiSource(methods, imports)); err != nil {
+		return nil, nil, fmt.Errorf("format %s: %w", APIFile, err)
+	}
+	if mock, err = render(mockSource(methods, imports)); err != nil {
+		return nil, nil, fmt.Errorf("format %s: %w", MockFile, err)
+	}
+	return api, mock, nil
+}
+
+// clientMethods returns the exported methods of *Client declared in the
+// package in dir, sorted by name, and the import paths of the package
+// names its files use.
+func clientMethods(dir string) ([]method, map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read package directory: %w", err)
+	}
+
+	fset := token.NewFileSet()
+	imports := map[string]string{}
+	var methods []method
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || name == APIFile || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse %s: %w", name, err)
+		}
+		for _, spec := range file.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			imports[importName(spec, path)] = path
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || !fn.Name.IsExported() || !isClientReceiver(fn.Recv) {
+				continue
+			}
+			methods = append(methods, newMethod(fn))
+		}
+	}
+
+	sort.Slice(methods, func(i, j int) bool { return methods[i].name < methods[j].name })
+	return methods, imports, nil
+}
+
+// importName returns the name under which a file refers to the import
+// spec of path.
+func importName(spec *ast.ImportSpec, path string) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	name := filepath.Base(path)
+	if len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		// Major version suffix, e.g. math/rand/v2
+		name = filepath.Base(filepath.Dir(path))
+	}
+	return name
+}
+
+// isClientReceiver reports whether recv is a *Client receiver.
+func isClientReceiver(recv *ast.FieldList) bool {
+	if recv == nil || len(recv.List) != 1 {
+		return false
+	}
+	star, ok := recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := star.X.(*ast.Ident)
+	return ok && ident.Name == "Client"
+}
+
+// newMethod describes fn, naming its unnamed parameters.
+func newMethod(fn *ast.FuncDecl) method {
+	m := method{name: fn.Name.Name, doc: firstParagraph(fn.Doc)}
+	for _, field := range fn.Type.Params.List {
+		typ, variadic := field.Type, false
+		if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+			typ, variadic = ellipsis.Elt, true
+		}
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{{Name: "_"}}
+		}
+		for _, name := range names {
+			n := name.Name
+			if n == "_" || n == receiver {
+				n = fmt.Sprintf("p%d", len(m.params))
+			}
+			m.params = append(m.params, param{name: n, typ: typ, variadic: variadic})
+		}
+	}
+	if fn.Type.Results != nil {
+		for _, field := range fn.Type.Results.List {
+			for range max(len(field.Names), 1) {
+				m.results = append(m.results, field.Type)
+			}
+		}
+	}
+	return m
+}
+
+// firstParagraph returns the first paragraph of a doc comment.
+func firstParagraph(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	text, _, _ := strings.Cut(doc.Text(), "\n\n")
+	return strings.TrimSpace(text)
+}
+
+// apiSource renders the interface file.
+func apiSource(methods []method, imports map[string]string) []byte {
+	var body bytes.Buffer
+	used := map[string]bool{}
+
+	body.WriteString("// GCSAPI is the GCS Manager API as implemented by *Client. Code that\n")
+	body.WriteString("// depends on GCSAPI rather than *Client can be tested with the mock\n")
+	body.WriteString("// client of package gcstest.\n")
+	body.WriteString("type GCSAPI interface {\n")
+	for i, m := range methods {
+		if i > 0 {
+			body.WriteString("\n")
+		}
+		writeComment(&body, "\t", m.doc)
+		fmt.Fprintf(&body, "\t%s%s\n", m.name, signature(m, "", used))
+	}
+	body.WriteString("}\n\n")
+	body.WriteString("// Client implements GCSAPI.\n")
+	body.WriteString("var _ GCSAPI = (*Client)(nil)\n")
+
+	return header("gcs", imports, used, body.Bytes())
+}
+
+// mockSource renders the mock client file.
+func mockSource(methods []method, imports map[string]string) []byte {
+	var body bytes.Buffer
+	used := map[string]bool{"gcs": true}
+	imports["gcs"] = gcsImport
+
+	body.WriteString("// Client is a mock gcs.GCSAPI. Each method calls the function in the\n")
+	body.WriteString("// field of the same name with a Func suffix, after recording the call;\n")
+	body.WriteString("// if the field is nil, the method fails with ErrNotMocked.\n")
+	body.WriteString("type Client struct {\n")
+	body.WriteString("\trecorder\n\n")
+	for _, m := range methods {
+		fmt.Fprintf(&body, "\t%sFunc func%s\n", m.name, signature(m, "gcs", used))
+	}
+	body.WriteString("}\n\n")
+	body.WriteString("// Client implements gcs.GCSAPI.\n")
+	body.WriteString("var _ gcs.GCSAPI = (*Client)(nil)\n")
+
+	for _, m := range methods {
+		body.WriteString("\n")
+		writeMockMethod(&body, m, used)
+	}
+
+	return header("gcstest", imports, used, body.Bytes())
+}
+
+// writeMockMethod renders the mock implementation of m.
+func writeMockMethod(w *bytes.Buffer, m method, used map[string]bool) {
+	args := make([]string, len(m.params))
+	callArgs := make([]string, len(m.params))
+	for i, p := range m.params {
+		args[i] = p.name
+		callArgs[i] = p.name
+		if p.variadic {
+			callArgs[i] += "..."
+		}
+	}
+	field := m.name + "Func"
+
+	fmt.Fprintf(w, "// %s calls %s.\n", m.name, field)
+	fmt.Fprintf(w, "func (%s *Client) %s%s {\n", receiver, m.name, signature(m, "gcs", used))
+	fmt.Fprintf(w, "\t%s.record(%q%s)\n", receiver, m.name, prefixComma(args))
+
+	if len(m.results) == 0 {
+		fmt.Fprintf(w, "\tif %s.%s != nil {\n", receiver, field)
+		fmt.Fprintf(w, "\t\t%s.%s(%s)\n", receiver, field, strings.Join(callArgs, ", "))
+		w.WriteString("\t}\n}\n")
+		return
+	}
+
+	fmt.Fprintf(w, "\tif %s.%s == nil {\n", receiver, field)
+	if elem, ok := errorSeq(m.results); ok {
+		fmt.Fprintf(w, "\t\treturn notMockedSeq[%s](%q)\n", typeString(elem, "gcs", used), m.name)
+	} else {
+		results := make([]string, len(m.results))
+		for i, r := range m.results {
+			if isError(r) && i == len(m.results)-1 {
+				results[i] = fmt.Sprintf("notMocked(%q)", m.name)
+				continue
+			}
+			results[i] = fmt.Sprintf("r%d", i)
+			fmt.Fprintf(w, "\t\tvar r%d %s\n", i, typeString(r, "gcs", used))
+		}
+		fmt.Fprintf(w, "\t\treturn %s\n", strings.Join(results, ", "))
+	}
+	w.WriteString("\t}\n")
+	fmt.Fprintf(w, "\treturn %s.%s(%s)\n}\n", receiver, field, strings.Join(callArgs, ", "))
+}
+
+// errorSeq reports whether results is a single iter.Seq2[T, error], and
+// returns T.
+func errorSeq(results []ast.Expr) (ast.Expr, bool) {
+	if len(results) != 1 {
+		return nil, false
+	}
+	index, ok := results[0].(*ast.IndexListExpr)
+	if !ok || len(index.Indices) != 2 || !isError(index.Indices[1]) {
+		return nil, false
+	}
+	sel, ok := index.X.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Seq2" {
+		return nil, false
+	}
+	if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "iter" {
+		return nil, false
+	}
+	return index.Indices[0], true
+}
+
+// isError reports whether expr is the predeclared error type.
+func isError(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "error"
+}
+
+// signature renders the parameters and results of m, qualifying the
+// package's own types with qualifier and noting the imports used.
+func signature(m method, qualifier string, used map[string]bool) string {
+	params := make([]string, len(m.params))
+	for i, p := range m.params {
+		typ := typeString(p.typ, qualifier, used)
+		if p.variadic {
+			typ = "..." + typ
+		}
+		params[i] = p.name + " " + typ
+	}
+
+	results := make([]string, len(m.results))
+	for i, r := range m.results {
+		results[i] = typeString(r, qualifier, used)
+	}
+
+	sig := "(" + strings.Join(params, ", ") + ")"
+	switch len(results) {
+	case 0:
+	case 1:
+		sig += " " + results[0]
+	default:
+		sig += " (" + strings.Join(results, ", ") + ")"
+	}
+	return sig
+}
+
+// typeString renders a type expression, qualifying exported identifiers
+// with qualifier (if not empty) and noting the packages it refers to.
+func typeString(expr ast.Expr, qualifier string, used map[string]bool) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if qualifier != "" && t.IsExported() {
+			return qualifier + "." + t.Name
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		pkg := t.X.(*ast.Ident).Name
+		used[pkg] = true
+		return pkg + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X, qualifier, used)
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "[]" + typeString(t.Elt, qualifier, used)
+		}
+		return "[" + typeString(t.Len, qualifier, used) + "]" + typeString(t.Elt, qualifier, used)
+	case *ast.BasicLit:
+		return t.Value
+	case *ast.MapType:
+		return "map[" + typeString(t.Key, qualifier, used) + "]" + typeString(t.Value, qualifier, used)
+	case *ast.Ellipsis:
+		return "..." + typeString(t.Elt, qualifier, used)
+	case *ast.ChanType:
+		prefix := "chan "
+		switch t.Dir {
+		case ast.SEND:
+			prefix = "chan<- "
+		case ast.RECV:
+			prefix = "<-chan "
+		}
+		return prefix + typeString(t.Value, qualifier, used)
+	case *ast.IndexExpr:
+		return typeString(t.X, qualifier, used) + "[" + typeString(t.Index, qualifier, used) + "]"
+	case *ast.IndexListExpr:
+		indices := make([]string, len(t.Indices))
+		for i, index := range t.Indices {
+			indices[i] = typeString(index, qualifier, used)
+		}
+		return typeString(t.X, qualifier, used) + "[" + strings.Join(indices, ", ") + "]"
+	case *ast.FuncType:
+		return "func" + funcSignature(t, qualifier, used)
+	case *ast.InterfaceType:
+		if t.Methods == nil || len(t.Methods.List) == 0 {
+			return "interface{}"
+		}
+		var methods []string
+		for _, field := range t.Methods.List {
+			fn := field.Type.(*ast.FuncType)
+			methods = append(methods, field.Names[0].Name+funcSignature(fn, qualifier, used))
+		}
+		return "interface{ " + strings.Join(methods, "; ") + " }"
+	case *ast.StructType:
+		if t.Fields == nil || len(t.Fields.List) == 0 {
+			return "struct{}"
+		}
+	}
+	panic(fmt.Sprintf("apigen: unsupported type expression %T", expr))
+}
+
+// funcSignature renders the parameters and results of a function type.
+func funcSignature(fn *ast.FuncType, qualifier string, used map[string]bool) string {
+	list := func(fields *ast.FieldList) []string {
+		var out []string
+		if fields == nil {
+			return out
+		}
+		for _, field := range fields.List {
+			typ := typeString(field.Type, qualifier, used)
+			if len(field.Names) == 0 {
+				out = append(out, typ)
+				continue
+			}
+			for _, name := range field.Names {
+				out = append(out, name.Name+" "+typ)
+			}
+		}
+		return out
+	}
+
+	sig := "(" + strings.Join(list(fn.Params), ", ") + ")"
+	results := list(fn.Results)
+	switch {
+	case len(results) == 1 && !strings.Contains(results[0], " "):
+		sig += " " + results[0]
+	case len(results) > 0:
+		sig += " (" + strings.Join(results, ", ") + ")"
+	}
+	return sig
+}
+
+// writeComment writes text as a // comment with the given indent.
+func writeComment(w *bytes.Buffer, indent, text string) {
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if line == "" {
+			fmt.Fprintf(w, "%s//\n", indent)
+			continue
+		}
+		fmt.Fprintf(w, "%s// %s\n", indent, line)
+	}
+}
+
+// prefixComma returns ", a, b" for args a and b, or "" for none.
+func prefixComma(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return ", " + strings.Join(args, ", ")
+}
+
+// header prepends the generated-file comment, package clause, and the
+// imports of the used package names to body.
+func header(pkg string, imports map[string]string, used map[string]bool, body []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gcsapigen from the methods of *gcs.Client; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		pi, pj := imports[names[i]], imports[names[j]]
+		if isStdlib(pi) != isStdlib(pj) {
+			return isStdlib(pi)
+		}
+		return pi < pj
+	})
+	if len(names) > 0 {
+		buf.WriteString("import (\n")
+		for i, name := range names {
+			path := imports[name]
+			if i > 0 && isStdlib(imports[names[i-1]]) && !isStdlib(path) {
+				buf.WriteString("\n")
+			}
+			if importName(&ast.ImportSpec{}, path) == name {
+				fmt.Fprintf(&buf, "\t%q\n", path)
+			} else {
+				fmt.Fprintf(&buf, "\t%s %q\n", name, path)
+			}
+		}
+		buf.WriteString(")\n\n")
+	}
+
+	buf.Write(body)
+	return buf.Bytes()
+}
+
+// isStdlib reports whether path is a standard library import path.
+func isStdlib(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// render formats generated source.
+func render(src []byte) ([]byte, error) {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("%w\n%s", err, src)
+	}
+	return formatted, nil
+}
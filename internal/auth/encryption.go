@@ -8,8 +8,6 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
-
-	"github.com/zalando/go-keyring"
 )
 
 const (
@@ -19,7 +17,8 @@ const (
 	// KeyringUser is the username used for keyring storage
 	KeyringUser = "encryption-key"
 
-	// KeyVersion is the current encryption key version for rotation support
+	// KeyVersion is the version of the first encryption key. Each
+	// RotateEncryptionKey creates the next version (v2, v3, ...).
 	KeyVersion = "v1"
 
 	// EncryptionKeySize is the size of the AES-256 key in bytes
@@ -41,8 +40,8 @@ type EncryptedData struct {
 	Ciphertext []byte
 }
 
-// GetOrCreateEncryptionKey retrieves the encryption key from the system keyring,
-// or creates a new one if it doesn't exist.
+// GetOrCreateEncryptionKey retrieves the current encryption key from the
+// system keyring, or creates a new one if it doesn't exist.
 //
 // The key is stored in the system keyring:
 //   - macOS: Keychain
@@ -51,8 +50,19 @@ type EncryptedData struct {
 //
 // If the keyring is not available, returns an error with instructions.
 func GetOrCreateEncryptionKey() ([]byte, error) {
+	version, err := currentKeyVersion()
+	if err != nil {
+		return nil, err
+	}
+	return encryptionKey(version)
+}
+
+// encryptionKey retrieves the key of version from the system keyring. The
+// first key version is created if it doesn't exist; later versions are
+// only created by RotateEncryptionKey.
+func encryptionKey(version string) ([]byte, error) {
 	// Try to get existing key
-	keyString, err := keyring.Get(KeyringService, KeyringUser)
+	keyString, err := keyringGet(KeyringService, keyringUser(version))
 	if err == nil {
 		// Decode existing key from base64
 		key, err := base64.StdEncoding.DecodeString(keyString)
@@ -68,7 +78,11 @@ func GetOrCreateEncryptionKey() ([]byte, error) {
 	}
 
 	// If key doesn't exist, create a new one
-	if err == keyring.ErrNotFound {
+	if err == errKeyNotFound {
+		if version != KeyVersion {
+			return nil, fmt.Errorf("encryption key %s not found in keyring", version)
+		}
+
 		key, err := generateEncryptionKey()
 		if err != nil {
 			return nil, fmt.Errorf("generate encryption key: %w", err)
@@ -76,7 +90,7 @@ func GetOrCreateEncryptionKey() ([]byte, error) {
 
 		// Store in keyring (base64 encoded for safe storage)
 		keyString := base64.StdEncoding.EncodeToString(key)
-		if err := keyring.Set(KeyringService, KeyringUser, keyString); err != nil {
+		if err := keyringSet(KeyringService, keyringUser(version), keyString); err != nil {
 			return nil, fmt.Errorf("store encryption key in keyring: %w\n\n"+
 				"Keyring storage is required for secure token encryption.\n"+
 				"Please ensure your system keyring is available:\n"+
@@ -88,13 +102,20 @@ func GetOrCreateEncryptionKey() ([]byte, error) {
 		return key, nil
 	}
 
-	// Keyring not available or other error
-	return nil, fmt.Errorf("access system keyring: %w\n\n"+
+	return nil, keyringUnavailable(err)
+}
+
+// keyringUnavailable wraps an error accessing the keyring with instructions.
+func keyringUnavailable(err error) error {
+	return fmt.Errorf("access system keyring: %w\n\n"+
 		"Keyring storage is required for secure token encryption.\n"+
 		"Please ensure your system keyring is available:\n"+
 		"  - macOS: Keychain (built-in)\n"+
 		"  - Linux: Install gnome-keyring or kwallet\n"+
-		"  - Windows: Credential Manager (built-in)", err)
+		"  - Windows: Credential Manager (built-in)\n"+
+		"On hosts without a keyring, set 'token_encryption: passphrase' in config.yaml\n"+
+		"to encrypt tokens with a passphrase instead (see "+PassphraseEnv+"), or keep\n"+
+		"the encryption key elsewhere with 'secret_store: file' or 'secret_store: vault'.", err)
 }
 
 // generateEncryptionKey generates a new 256-bit (32-byte) encryption key
@@ -116,12 +137,27 @@ func generateEncryptionKey() ([]byte, error) {
 //
 // Returns encrypted data with version and nonce for decryption.
 func Encrypt(plaintext []byte) (*EncryptedData, error) {
-	// Get or create encryption key
-	key, err := GetOrCreateEncryptionKey()
+	// Get or create the current encryption key
+	version, err := currentKeyVersion()
+	if err != nil {
+		return nil, err
+	}
+	key, err := encryptionKey(version)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := encryptWithKey(key, plaintext)
 	if err != nil {
 		return nil, err
 	}
+	encrypted.Version = version
+	return encrypted, nil
+}
 
+// encryptWithKey encrypts plaintext with key using AES-256-GCM. The
+// Version of the result is left to the caller.
+func encryptWithKey(key, plaintext []byte) (*EncryptedData, error) {
 	// Create AES cipher
 	block, err := aes.NewCipher(key)
 	if err != nil {
@@ -144,7 +180,6 @@ func Encrypt(plaintext []byte) (*EncryptedData, error) {
 	ciphertext := gcm.Seal(nil, nonce, plaintext, nil)
 
 	return &EncryptedData{
-		Version:    KeyVersion,
 		Nonce:      nonce,
 		Ciphertext: ciphertext,
 	}, nil
@@ -163,18 +198,22 @@ func Decrypt(encrypted *EncryptedData) ([]byte, error) {
 		return nil, fmt.Errorf("invalid nonce size: %d (expected %d)", len(encrypted.Nonce), NonceSize)
 	}
 
-	// Get encryption key
-	// TODO: For key rotation, we'd look up the key by version
-	// For now, we only support the current version
-	if encrypted.Version != KeyVersion {
-		return nil, fmt.Errorf("unsupported encryption version: %s (current: %s)", encrypted.Version, KeyVersion)
+	// Get the encryption key the data was encrypted with, which may
+	// predate a key rotation
+	if _, ok := keyVersionNumber(encrypted.Version); !ok {
+		return nil, fmt.Errorf("unsupported encryption version: %s", encrypted.Version)
 	}
 
-	key, err := GetOrCreateEncryptionKey()
+	key, err := encryptionKey(encrypted.Version)
 	if err != nil {
 		return nil, err
 	}
 
+	return decryptWithKey(key, encrypted)
+}
+
+// decryptWithKey decrypts and verifies encrypted with key.
+func decryptWithKey(key []byte, encrypted *EncryptedData) ([]byte, error) {
 	// Create AES cipher
 	block, err := aes.NewCipher(key)
 	if err != nil {
@@ -213,38 +252,26 @@ func DeriveKeyFromPassphrase(passphrase string) []byte {
 	return hash[:]
 }
 
-// RotateEncryptionKey generates a new encryption key and re-encrypts all tokens.
-//
-// This is used for key rotation to maintain security hygiene.
-// The old key is kept temporarily to decrypt existing tokens,
-// then all tokens are re-encrypted with the new key.
-//
-// Key rotation should be performed periodically (e.g., every 90 days).
-func RotateEncryptionKey() error {
-	// TODO: Implement key rotation
-	// Steps:
-	// 1. Generate new key with new version
-	// 2. Store new key in keyring with versioned identifier
-	// 3. List all token files
-	// 4. For each token:
-	//    a. Decrypt with old key
-	//    b. Re-encrypt with new key
-	//    c. Save updated token
-	// 5. Mark old key as deprecated (but keep for rollback)
-
-	return fmt.Errorf("key rotation not yet implemented")
-}
-
-// ClearEncryptionKey removes the encryption key from the system keyring.
+// ClearEncryptionKey removes all encryption key versions from the system
+// keyring.
 //
 // WARNING: This will make all encrypted tokens unreadable!
 // Only use this if you're sure you want to delete all encrypted data.
 func ClearEncryptionKey() error {
-	if err := keyring.Delete(KeyringService, KeyringUser); err != nil {
-		if err == keyring.ErrNotFound {
-			return nil // Already deleted
+	current, err := currentKeyVersion()
+	if err != nil {
+		return err
+	}
+	n, _ := keyVersionNumber(current)
+
+	users := []string{currentKeyUser}
+	for i := n; i >= 1; i-- {
+		users = append(users, keyringUser(fmt.Sprintf("v%d", i)))
+	}
+	for _, user := range users {
+		if err := keyringDelete(KeyringService, user); err != nil && err != errKeyNotFound {
+			return fmt.Errorf("delete encryption key from keyring: %w", err)
 		}
-		return fmt.Errorf("delete encryption key from keyring: %w", err)
 	}
 	return nil
 }
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// passphraseIterations is the PBKDF2-SHA256 iteration count for new
+	// passphrase-encrypted tokens (the OWASP recommendation as of 2023).
+	passphraseIterations = 600000
+
+	// passphraseSaltSize is the size of the PBKDF2 salt in bytes.
+	passphraseSaltSize = 16
+)
+
+// ExportToken encrypts token for moving it to another machine.
+//
+// Token files are encrypted with a key that never leaves the local
+// keyring, so they cannot be copied as they are. The bundle returned here
+// is an EncryptedTokenFile in PassphraseTokenFormat instead: the key is
+// derived from passphrase with PBKDF2-SHA256 and a random salt.
+func ExportToken(token *TokenInfo, passphrase string) (*EncryptedTokenFile, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase is required")
+	}
+
+	plaintext, err := json.Marshal(token)
+	if err != nil {
+		return nil, fmt.Errorf("marshal token: %w", err)
+	}
+
+	return SealWithPassphrase(plaintext, passphrase)
+}
+
+// SealWithPassphrase encrypts plaintext with a key derived from passphrase
+// and a new random salt.
+func SealWithPassphrase(plaintext []byte, passphrase string) (*EncryptedTokenFile, error) {
+	salt := make([]byte, passphraseSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, passphraseIterations, EncryptionKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+
+	encrypted, err := encryptWithKey(key, plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedTokenFile{
+		Format:        PassphraseTokenFormat,
+		EncryptedData: encrypted,
+		Salt:          salt,
+		Iterations:    passphraseIterations,
+	}, nil
+}
+
+// OpenWithPassphrase decrypts a file written by SealWithPassphrase.
+func OpenWithPassphrase(file *EncryptedTokenFile, passphrase string) ([]byte, error) {
+	if file.EncryptedData == nil || len(file.Salt) == 0 || file.Iterations <= 0 {
+		return nil, errors.New("token bundle is incomplete")
+	}
+	if len(file.EncryptedData.Nonce) != NonceSize {
+		return nil, fmt.Errorf("invalid nonce size: %d (expected %d)", len(file.EncryptedData.Nonce), NonceSize)
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, file.Salt, file.Iterations, EncryptionKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+	plaintext, err := decryptWithKey(key, file.EncryptedData)
+	if err != nil {
+		return nil, fmt.Errorf("%w (wrong passphrase?)", err)
+	}
+	return plaintext, nil
+}
+
+// ImportToken reads a token from data: a bundle written by ExportToken,
+// in which case passphrase is called for its passphrase; a token file of
+// this machine (EncryptedTokenFormat); or a plaintext TokenInfo document.
+func ImportToken(data []byte, passphrase func() (string, error)) (*TokenInfo, error) {
+	var file EncryptedTokenFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse token: %w", err)
+	}
+
+	var plaintext []byte
+	switch file.Format {
+	case PassphraseTokenFormat:
+		if file.EncryptedData == nil || len(file.Salt) == 0 || file.Iterations <= 0 {
+			return nil, errors.New("token bundle is incomplete")
+		}
+		secret, err := passphrase()
+		if err != nil {
+			return nil, err
+		}
+		if plaintext, err = OpenWithPassphrase(&file, secret); err != nil {
+			return nil, err
+		}
+	case EncryptedTokenFormat:
+		var err error
+		if plaintext, err = Decrypt(file.EncryptedData); err != nil {
+			return nil, fmt.Errorf("decrypt token: %w (token files only decrypt on the machine that wrote them; use 'auth token export')", err)
+		}
+	case "":
+		plaintext = data
+	default:
+		return nil, fmt.Errorf("unsupported token format %q", file.Format)
+	}
+
+	var token TokenInfo
+	if err := json.Unmarshal(plaintext, &token); err != nil {
+		return nil, fmt.Errorf("parse token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, errors.New("token has no access_token")
+	}
+	return &token, nil
+}
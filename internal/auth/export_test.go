@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportImportToken(t *testing.T) {
+	token := &TokenInfo{
+		AccessToken:  "access123",
+		RefreshToken: "refresh456",
+		ExpiresAt:    time.Now().Add(time.Hour).Truncate(time.Second),
+		Scopes:       []string{"openid"},
+	}
+
+	bundle, err := ExportToken(token, "correct horse")
+	if err != nil {
+		t.Fatalf("ExportToken() error = %v", err)
+	}
+	if bundle.Format != PassphraseTokenFormat {
+		t.Errorf("Format = %q, want %q", bundle.Format, PassphraseTokenFormat)
+	}
+
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "access123") {
+		t.Error("exported bundle contains the access token in plaintext")
+	}
+
+	got, err := ImportToken(data, func() (string, error) { return "correct horse", nil })
+	if err != nil {
+		t.Fatalf("ImportToken() error = %v", err)
+	}
+	if got.AccessToken != token.AccessToken || got.RefreshToken != token.RefreshToken || !got.ExpiresAt.Equal(token.ExpiresAt) {
+		t.Errorf("ImportToken() = %+v, want %+v", got, token)
+	}
+
+	if _, err := ImportToken(data, func() (string, error) { return "wrong", nil }); err == nil {
+		t.Error("ImportToken() with the wrong passphrase succeeded")
+	}
+}
+
+func TestExportToken_EmptyPassphrase(t *testing.T) {
+	if _, err := ExportToken(&TokenInfo{AccessToken: "a"}, ""); err == nil {
+		t.Error("ExportToken() with an empty passphrase succeeded")
+	}
+}
+
+func TestImportToken_Plaintext(t *testing.T) {
+	called := false
+	passphrase := func() (string, error) {
+		called = true
+		return "", errors.New("not expected")
+	}
+
+	got, err := ImportToken([]byte(`{"access_token":"abc","expires_at":"2030-01-01T00:00:00Z"}`), passphrase)
+	if err != nil {
+		t.Fatalf("ImportToken() error = %v", err)
+	}
+	if got.AccessToken != "abc" {
+		t.Errorf("AccessToken = %q, want %q", got.AccessToken, "abc")
+	}
+	if called {
+		t.Error("ImportToken() asked for a passphrase for plaintext tokens")
+	}
+}
+
+func TestImportToken_Invalid(t *testing.T) {
+	passphrase := func() (string, error) { return "x", nil }
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"not json", "nope"},
+		{"no access token", `{"refresh_token":"r"}`},
+		{"unknown format", `{"format":"encrypted-v9"}`},
+		{"incomplete bundle", `{"format":"passphrase-v1"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ImportToken([]byte(tt.data), passphrase); err == nil {
+				t.Error("ImportToken() succeeded, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+)
+
+// tokenLockTimeout bounds how long a command waits for another process
+// holding a token file's lock. It is a test hook.
+var tokenLockTimeout = 30 * time.Second
+
+// tokenLockPoll is how often a held lock is tried again.
+const tokenLockPoll = 25 * time.Millisecond
+
+// withTokenLock runs fn holding an exclusive advisory lock on the profile's
+// token file, so that parallel invocations (an Ansible loop, for example)
+// neither interleave writes nor refresh the same token twice.
+//
+// The lock is taken on a separate "<token file>.lock" file because token
+// files are replaced by rename, which would orphan a lock on the file
+// itself. Locks are not reentrant: fn must not take the lock again.
+func withTokenLock(profile string, fn func(tokenPath string) error) error {
+	if err := config.EnsureTokensDir(); err != nil {
+		return fmt.Errorf("ensure tokens directory: %w", err)
+	}
+	tokenPath, err := config.GetTokenFilePath(profile)
+	if err != nil {
+		return fmt.Errorf("get token file path: %w", err)
+	}
+
+	lockFile, err := os.OpenFile(tokenPath+".lock", os.O_RDWR|os.O_CREATE, 0600) //nolint:gosec // Path is in the tokens directory
+	if err != nil {
+		return fmt.Errorf("open token lock: %w", err)
+	}
+	defer func() { _ = lockFile.Close() }()
+
+	deadline := time.Now().Add(tokenLockTimeout)
+	for {
+		locked, err := tryLockFile(lockFile)
+		if err != nil {
+			return fmt.Errorf("lock token file: %w", err)
+		}
+		if locked {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("token file of profile %q is locked by another process (waited %s)", profile, tokenLockTimeout)
+		}
+		time.Sleep(tokenLockPoll)
+	}
+	defer func() { _ = unlockFile(lockFile) }()
+
+	return fn(tokenPath)
+}
+
+// withTokenLocks runs fn holding the token locks of all profiles. The
+// locks are taken in the order given, which callers keep sorted so that
+// two of them never wait on each other.
+func withTokenLocks(profiles []string, fn func() error) error {
+	if len(profiles) == 0 {
+		return fn()
+	}
+	return withTokenLock(profiles[0], func(string) error {
+		return withTokenLocks(profiles[1:], fn)
+	})
+}
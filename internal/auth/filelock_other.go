@@ -0,0 +1,17 @@
+//go:build !unix
+
+package auth
+
+import "os"
+
+// tryLockFile does not lock where flock is unavailable. Token files are
+// still written atomically, so parallel invocations cannot corrupt them,
+// but two may refresh the same token.
+func tryLockFile(_ *os.File) (bool, error) {
+	return true, nil
+}
+
+// unlockFile releases the lock taken by tryLockFile.
+func unlockFile(_ *os.File) error {
+	return nil
+}
@@ -0,0 +1,71 @@
+//go:build unix
+
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithTokenLock_Held(t *testing.T) {
+	useMemoryKeyring(t)
+
+	timeout := tokenLockTimeout
+	tokenLockTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { tokenLockTimeout = timeout })
+
+	err := withTokenLock("test", func(string) error {
+		err := SaveToken("test", &TokenInfo{AccessToken: "token"})
+		if err == nil || !strings.Contains(err.Error(), "locked by another process") {
+			t.Errorf("SaveToken() with the lock held error = %v, want locked", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withTokenLock() error = %v", err)
+	}
+
+	if err := SaveToken("test", &TokenInfo{AccessToken: "token"}); err != nil {
+		t.Errorf("SaveToken() after the lock was released error = %v", err)
+	}
+}
+
+func TestRotateEncryptionKey_WaitsForTokenLock(t *testing.T) {
+	secrets := useMemoryKeyring(t)
+
+	timeout := tokenLockTimeout
+	tokenLockTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { tokenLockTimeout = timeout })
+
+	for _, profile := range []string{"default", "other"} {
+		if err := SaveToken(profile, &TokenInfo{AccessToken: profile}); err != nil {
+			t.Fatalf("SaveToken(%q) error = %v", profile, err)
+		}
+	}
+
+	err := withTokenLock("other", func(string) error {
+		_, err := RotateEncryptionKey()
+		if err == nil || !strings.Contains(err.Error(), "locked by another process") {
+			t.Errorf("RotateEncryptionKey() with a token lock held error = %v, want locked", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withTokenLock() error = %v", err)
+	}
+	if _, ok := secrets[currentKeyUser]; ok {
+		t.Error("new key became current although the rotation failed")
+	}
+	if _, ok := secrets[keyringUser("v2")]; ok {
+		t.Error("new key left in the keyring after the rotation failed")
+	}
+
+	rotation, err := RotateEncryptionKey()
+	if err != nil || len(rotation.Files) != 2 {
+		t.Fatalf("RotateEncryptionKey() after the lock was released = %+v, %v, want 2 files", rotation, err)
+	}
+	if loaded, err := LoadToken("other"); err != nil || loaded.AccessToken != "other" {
+		t.Errorf("LoadToken() after rotation = %v, %v", loaded, err)
+	}
+}
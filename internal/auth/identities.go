@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// identitiesClient is the HTTP client used for identity lookups.
+var identitiesClient = &http.Client{Timeout: 30 * time.Second}
+
+// Identity is a Globus Auth identity.
+type Identity struct {
+	ID               string `json:"id"`
+	Username         string `json:"username"`
+	Name             string `json:"name,omitempty"`
+	Email            string `json:"email,omitempty"`
+	Organization     string `json:"organization,omitempty"`
+	IdentityProvider string `json:"identity_provider,omitempty"`
+	Status           string `json:"status,omitempty"`
+}
+
+// GetIdentities looks up Globus Auth identities by ID with the Globus Auth
+// API at authURL (see config.Environment).
+//
+// The access token must include the
+// urn:globus:auth:scope:auth.globus.org:view_identities scope, which the
+// 'login' command requests by default. IDs that do not exist are omitted
+// from the result.
+func GetIdentities(ctx context.Context, authURL, accessToken string, ids ...string) ([]Identity, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	reqURL := authURL + "api/identities?" + url.Values{"ids": {strings.Join(ids, ",")}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := identitiesClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get identities: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get identities: HTTP %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Identities []Identity `json:"identities"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode identities: %w", err)
+	}
+
+	return body.Identities, nil
+}
+
+// IdentityProvider is a Globus Auth identity provider, such as an
+// institution's login service or Globus ID.
+type IdentityProvider struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	ShortName string   `json:"short_name,omitempty"`
+	Domains   []string `json:"domains,omitempty"`
+}
+
+// GetIdentityProviders looks up Globus Auth identity providers by ID with
+// the Globus Auth API at authURL. IDs that do not exist are omitted from
+// the result.
+func GetIdentityProviders(ctx context.Context, authURL, accessToken string, ids ...string) ([]IdentityProvider, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	reqURL := authURL + "api/identity_providers?" + url.Values{"ids": {strings.Join(ids, ",")}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := identitiesClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get identity providers: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get identity providers: HTTP %d", resp.StatusCode)
+	}
+
+	var body struct {
+		IdentityProviders []IdentityProvider `json:"identity_providers"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode identity providers: %w", err)
+	}
+
+	return body.IdentityProviders, nil
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetIdentities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("ids"); got != "id-1,id-2" {
+			t.Errorf("ids = %q, want %q", got, "id-1,id-2")
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer test-token")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"identities":[{"id":"id-1","username":"alice@example.edu","email":"alice@example.edu"}]}`))
+	}))
+	defer server.Close()
+
+	identities, err := GetIdentities(context.Background(), server.URL+"/", "test-token", "id-1", "id-2")
+	if err != nil {
+		t.Fatalf("GetIdentities() error = %v", err)
+	}
+	if len(identities) != 1 || identities[0].Username != "alice@example.edu" {
+		t.Errorf("GetIdentities() = %+v, want alice@example.edu", identities)
+	}
+}
+
+func TestGetIdentities_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	if _, err := GetIdentities(context.Background(), server.URL+"/", "test-token", "id-1"); err == nil {
+		t.Error("GetIdentities() expected error for HTTP 403, got nil")
+	}
+}
+
+func TestGetIdentities_NoIDs(t *testing.T) {
+	identities, err := GetIdentities(context.Background(), "https://auth.example.org/v2/", "test-token")
+	if err != nil || identities != nil {
+		t.Errorf("GetIdentities() = %v, %v, want nil, nil", identities, err)
+	}
+}
+
+func TestGetIdentityProviders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/identity_providers" || r.URL.Query().Get("ids") != "idp-1" {
+			t.Errorf("request = %s, want identity_providers?ids=idp-1", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"identity_providers":[{"id":"idp-1","name":"Globus ID","short_name":"globusid"}]}`))
+	}))
+	defer server.Close()
+
+	providers, err := GetIdentityProviders(context.Background(), server.URL+"/", "test-token", "idp-1")
+	if err != nil {
+		t.Fatalf("GetIdentityProviders() error = %v", err)
+	}
+	if len(providers) != 1 || providers[0].Name != "Globus ID" {
+		t.Errorf("GetIdentityProviders() = %+v, want Globus ID", providers)
+	}
+}
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+)
+
+// ErrKeyringTrustUnsupported is returned by TrustKeyring on platforms whose
+// keyring does not ask before each access.
+var ErrKeyringTrustUnsupported = errors.New("keyring access control is only needed for the macOS Keychain")
+
+// keyringTrust rewrites a keyring item so that this program can read it
+// without an authorization prompt. It is nil where the keyring has no
+// per-application access control. It is a test hook.
+var keyringTrust func(service, user, secret string) error
+
+// KeyringTrust describes the keyring items TrustKeyring updated.
+type KeyringTrust struct {
+	// Items are the keyring users whose access control was updated.
+	Items []string `json:"items"`
+}
+
+// TrustKeyring allows this program to read the encryption keys in the
+// keyring without asking, fixing keyrings written before the access
+// control was set on key creation.
+//
+// Each item is read (which may prompt one last time), then stored again
+// with the new access control. Items that do not exist are skipped.
+func TrustKeyring() (*KeyringTrust, error) {
+	if keyringTrust == nil || secretStore != SecretStoreKeyring {
+		return nil, ErrKeyringTrustUnsupported
+	}
+
+	version, err := currentKeyVersion()
+	if err != nil {
+		return nil, err
+	}
+	n, _ := keyVersionNumber(version)
+	users := []string{currentKeyUser}
+	for i := 1; i <= n; i++ {
+		users = append(users, keyringUser(fmt.Sprintf("v%d", i)))
+	}
+
+	trust := &KeyringTrust{Items: []string{}}
+	for _, user := range users {
+		secret, err := keyringGet(KeyringService, user)
+		if err == errKeyNotFound {
+			continue
+		}
+		if err != nil {
+			return trust, keyringUnavailable(err)
+		}
+		if err := keyringTrust(KeyringService, user, secret); err != nil {
+			return trust, fmt.Errorf("update access control of %s: %w", user, err)
+		}
+		trust.Items = append(trust.Items, user)
+	}
+	return trust, nil
+}
+
+// A keyring read slower than slowKeyringRead most likely waited for the
+// user to answer an authorization prompt. promptStormCount such reads
+// within promptStormWindow are reported as a prompt storm.
+const (
+	slowKeyringRead   = time.Second
+	promptStormCount  = 3
+	promptStormWindow = 10 * time.Minute
+)
+
+// keyringPromptsFile records the times of recent slow keyring reads, so
+// that prompts are counted across invocations of a batch script.
+const keyringPromptsFile = "keyring-prompts.json"
+
+// keyringNow returns the current time. It is a test hook.
+var keyringNow = time.Now
+
+// promptStormWarned makes sure a prompt storm is reported once per process.
+var promptStormWarned sync.Once
+
+// timedKeyringGet wraps get to detect authorization prompt storms.
+func timedKeyringGet(get func(service, user string) (string, error)) func(service, user string) (string, error) {
+	return func(service, user string) (string, error) {
+		start := keyringNow()
+		secret, err := get(service, user)
+		if err == nil {
+			if promptStorm(keyringNow().Sub(start)) {
+				promptStormWarned.Do(func() {
+					fmt.Fprintf(os.Stderr, "Warning: the %s keyring asked for access %d times in %s; "+
+						"run 'globus-connect-server auth keyring-trust' to allow this program without prompting\n",
+						keyringName(), promptStormCount, promptStormWindow)
+				})
+			}
+		}
+		return secret, err
+	}
+}
+
+// keyringName names the system keyring in messages.
+func keyringName() string {
+	if runtime.GOOS == "darwin" {
+		return "macOS Keychain"
+	}
+	return "system"
+}
+
+// promptStorm records a keyring read that took elapsed, and reports
+// whether enough slow reads happened recently to be a prompt storm.
+// Failing to record a read is not an error: detection is best effort.
+func promptStorm(elapsed time.Duration) bool {
+	if elapsed < slowKeyringRead {
+		return false
+	}
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return false
+	}
+	path := filepath.Join(dir, keyringPromptsFile)
+
+	var prompts []time.Time
+	if data, err := os.ReadFile(path); err == nil { //nolint:gosec // Path is in the config directory
+		_ = json.Unmarshal(data, &prompts)
+	}
+
+	now := keyringNow()
+	recent := []time.Time{now}
+	for _, t := range prompts {
+		if now.Sub(t) < promptStormWindow {
+			recent = append(recent, t)
+		}
+	}
+
+	if data, err := json.Marshal(recent); err == nil {
+		if err := os.MkdirAll(dir, 0700); err == nil {
+			_ = os.WriteFile(path, data, 0600)
+		}
+	}
+	return len(recent) >= promptStormCount
+}
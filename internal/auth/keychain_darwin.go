@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+)
+
+// securityPath is the macOS keychain command line tool. go-keyring reads
+// items through it, so it is the application the Keychain asks about.
+const securityPath = "/usr/bin/security"
+
+// keychainEncodingPrefix marks a secret encoded the way go-keyring's Get
+// expects.
+const keychainEncodingPrefix = "go-keyring-base64:"
+
+func init() {
+	systemKeyring = funcStore{get: timedKeyringGet(keyring.Get), set: keychainSet, del: keyring.Delete}
+	keyringGet, keyringSet = systemKeyring.Get, systemKeyring.Set
+	keyringTrust = keychainTrust
+}
+
+// keychainSet stores a secret in the Keychain, trusting the security tool
+// and this executable to read it without an authorization prompt.
+func keychainSet(service, user, secret string) error {
+	apps := []string{securityPath}
+	if exe, err := os.Executable(); err == nil {
+		if exe, err := filepath.EvalSymlinks(exe); err == nil {
+			apps = append(apps, exe)
+		}
+	}
+
+	command := []string{"add-generic-password", "-U", "-s", keychainQuote(service), "-a", keychainQuote(user)}
+	for _, app := range apps {
+		command = append(command, "-T", keychainQuote(app))
+	}
+	command = append(command, "-w", keychainQuote(keychainEncodingPrefix+base64.StdEncoding.EncodeToString([]byte(secret))))
+
+	// The secret goes through standard input, not the process arguments
+	cmd := exec.Command(securityPath, "-i")
+	cmd.Stdin = strings.NewReader(strings.Join(command, " ") + "\n")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// keychainTrust replaces an item with one that has the access control
+// keychainSet creates. Updating an item in place keeps its old access
+// control, so the item is deleted first; if it cannot be added back, the
+// secret is stored again without the access control rather than lost.
+func keychainTrust(service, user, secret string) error {
+	if err := keyring.Delete(service, user); err != nil && err != keyring.ErrNotFound {
+		return err
+	}
+	if err := keychainSet(service, user, secret); err != nil {
+		if restoreErr := keyring.Set(service, user, secret); restoreErr != nil {
+			return fmt.Errorf("%w (restoring the item also failed: %v)", err, restoreErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// keychainQuote quotes an argument for security's interactive mode.
+func keychainQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTrustKeyring(t *testing.T) {
+	secrets := useMemoryKeyring(t)
+	secrets[KeyringUser] = "key1"
+	secrets[keyringUser("v2")] = "key2"
+	secrets[currentKeyUser] = "v2"
+
+	trusted := map[string]string{}
+	prev := keyringTrust
+	keyringTrust = func(_, user, secret string) error {
+		trusted[user] = secret
+		return nil
+	}
+	t.Cleanup(func() { keyringTrust = prev })
+
+	trust, err := TrustKeyring()
+	if err != nil {
+		t.Fatalf("TrustKeyring() error = %v", err)
+	}
+	if len(trust.Items) != 3 {
+		t.Errorf("Items = %v, want the current pointer and both keys", trust.Items)
+	}
+	for user, secret := range secrets {
+		if trusted[user] != secret {
+			t.Errorf("trusted %s = %q, want %q", user, trusted[user], secret)
+		}
+	}
+}
+
+func TestTrustKeyring_Failure(t *testing.T) {
+	secrets := useMemoryKeyring(t)
+	secrets[KeyringUser] = "key1"
+
+	prev := keyringTrust
+	keyringTrust = func(_, _, _ string) error { return errors.New("denied") }
+	t.Cleanup(func() { keyringTrust = prev })
+
+	if _, err := TrustKeyring(); err == nil {
+		t.Error("TrustKeyring() expected error")
+	}
+}
+
+func TestTrustKeyring_Unsupported(t *testing.T) {
+	useMemoryKeyring(t)
+	prev := keyringTrust
+	keyringTrust = nil
+	t.Cleanup(func() { keyringTrust = prev })
+
+	if _, err := TrustKeyring(); !errors.Is(err, ErrKeyringTrustUnsupported) {
+		t.Errorf("TrustKeyring() error = %v, want ErrKeyringTrustUnsupported", err)
+	}
+}
+
+func TestPromptStorm(t *testing.T) {
+	t.Setenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR", t.TempDir())
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	prev := keyringNow
+	keyringNow = func() time.Time { return now }
+	t.Cleanup(func() { keyringNow = prev })
+
+	if promptStorm(10 * time.Millisecond) {
+		t.Error("a fast read counted as a prompt")
+	}
+	for i := 1; i < promptStormCount; i++ {
+		if promptStorm(5 * time.Second) {
+			t.Fatalf("prompt storm after %d slow reads", i)
+		}
+		now = now.Add(time.Minute)
+	}
+	if !promptStorm(5 * time.Second) {
+		t.Errorf("no prompt storm after %d slow reads", promptStormCount)
+	}
+
+	// Prompts outside the window are forgotten
+	now = now.Add(promptStormWindow)
+	if promptStorm(5 * time.Second) {
+		t.Error("prompt storm counted prompts outside the window")
+	}
+}
@@ -0,0 +1,24 @@
+//go:build !js
+
+package auth
+
+import "github.com/zalando/go-keyring"
+
+// errKeyNotFound is returned by keyringGet when no secret is stored.
+var errKeyNotFound = keyring.ErrNotFound
+
+// systemKeyring is the OS keyring, the default secret store.
+var systemKeyring SecretStore = funcStore{get: keyring.Get, set: keyring.Set, del: keyring.Delete}
+
+// The keyring functions wrap the selected secret store (see
+// SetSecretStore), the system keyring by default. They are test hooks.
+var (
+	// keyringGet reads a secret from the secret store.
+	keyringGet = keyring.Get
+
+	// keyringSet stores a secret in the secret store.
+	keyringSet = keyring.Set
+
+	// keyringDelete removes a secret from the secret store.
+	keyringDelete = keyring.Delete
+)
@@ -0,0 +1,29 @@
+//go:build js
+
+package auth
+
+import "errors"
+
+// errKeyNotFound is never returned on js: there is no keyring to look in.
+var errKeyNotFound = errors.New("secret not found in keyring")
+
+// errNoKeyring is returned by the keyring functions in the browser, where
+// stored CLI tokens cannot be decrypted. Use gcsauth.StaticToken there.
+var errNoKeyring = errors.New("no system keyring on js/wasm")
+
+// systemKeyring is the missing keyring.
+var systemKeyring SecretStore = funcStore{get: keyringGet, set: keyringSet, del: keyringDelete}
+
+var (
+	keyringGet = func(_, _ string) (string, error) {
+		return "", errNoKeyring
+	}
+
+	keyringSet = func(_, _, _ string) error {
+		return errNoKeyring
+	}
+
+	keyringDelete = func(_, _ string) error {
+		return errNoKeyring
+	}
+)
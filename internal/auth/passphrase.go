@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/secureinput"
+	"golang.org/x/term"
+)
+
+// Token encryption modes, selected with the token_encryption setting.
+const (
+	// TokenEncryptionKeyring encrypts token files with a key kept in the
+	// system keyring. It is the default.
+	TokenEncryptionKeyring = "keyring"
+
+	// TokenEncryptionPassphrase encrypts token files with a key derived
+	// from a passphrase, for hosts without a keyring.
+	TokenEncryptionPassphrase = "passphrase"
+)
+
+// PassphraseEnv is the environment variable holding the token passphrase
+// in passphrase mode. Without it, the passphrase is prompted for.
+const PassphraseEnv = "GLOBUS_GCS_TOKEN_PASSPHRASE"
+
+// tokenEncryption is the mode SaveToken encrypts new token files with.
+var tokenEncryption = TokenEncryptionKeyring
+
+// cachedPassphrase holds the passphrase for the rest of the process, so
+// that it is asked for at most once per command.
+var cachedPassphrase string
+
+// SetTokenEncryption selects how SaveToken encrypts token files. Token
+// files are read according to their own format, whatever the mode.
+func SetTokenEncryption(mode string) error {
+	switch mode {
+	case TokenEncryptionKeyring, TokenEncryptionPassphrase:
+		tokenEncryption = mode
+		return nil
+	default:
+		return fmt.Errorf("unknown token encryption %q (expected %s or %s)", mode, TokenEncryptionKeyring, TokenEncryptionPassphrase)
+	}
+}
+
+// readPassphrase reads the token passphrase from PassphraseEnv or a
+// terminal prompt. It is a test hook.
+var readPassphrase = func() (string, error) {
+	if passphrase, ok := os.LookupEnv(PassphraseEnv); ok {
+		if passphrase == "" {
+			return "", fmt.Errorf("%s is empty", PassphraseEnv)
+		}
+		return passphrase, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", errors.New("tokens are encrypted with a passphrase: set " + PassphraseEnv + " or run on a terminal")
+	}
+	return secureinput.ReadSecret(secureinput.ReadSecretOptions{
+		PromptMessage: "Token passphrase",
+	})
+}
+
+// tokenPassphrase returns the token passphrase, reading it on first use.
+func tokenPassphrase() (string, error) {
+	if cachedPassphrase != "" {
+		return cachedPassphrase, nil
+	}
+	secret, err := readPassphrase()
+	if err != nil {
+		return "", err
+	}
+	cachedPassphrase = secret
+	return secret, nil
+}
+
+// encryptTokenFile encrypts the token data plaintext in the current mode.
+func encryptTokenFile(plaintext []byte) (*EncryptedTokenFile, error) {
+	if tokenEncryption == TokenEncryptionPassphrase {
+		secret, err := tokenPassphrase()
+		if err != nil {
+			return nil, err
+		}
+		return SealWithPassphrase(plaintext, secret)
+	}
+
+	encryptedData, err := Encrypt(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptedTokenFile{
+		Format:        EncryptedTokenFormat,
+		EncryptedData: encryptedData,
+	}, nil
+}
+
+// decryptTokenFile decrypts a token file in either encrypted format.
+func decryptTokenFile(file *EncryptedTokenFile) ([]byte, error) {
+	if file.Format == PassphraseTokenFormat {
+		secret, err := tokenPassphrase()
+		if err != nil {
+			return nil, err
+		}
+		return OpenWithPassphrase(file, secret)
+	}
+	return Decrypt(file.EncryptedData)
+}
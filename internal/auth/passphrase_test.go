@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+)
+
+// usePassphraseMode switches SaveToken to passphrase mode for the test,
+// with readPassphrase returning secret.
+func usePassphraseMode(t *testing.T, secret string) {
+	t.Helper()
+
+	read := readPassphrase
+	readPassphrase = func() (string, error) { return secret, nil }
+	t.Cleanup(func() {
+		readPassphrase = read
+		cachedPassphrase = ""
+		tokenEncryption = TokenEncryptionKeyring
+	})
+	cachedPassphrase = ""
+
+	if err := SetTokenEncryption(TokenEncryptionPassphrase); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR", t.TempDir())
+}
+
+func TestSaveToken_PassphraseMode(t *testing.T) {
+	usePassphraseMode(t, "correct horse")
+
+	// The keyring must not be used
+	get := keyringGet
+	keyringGet = func(_, _ string) (string, error) { return "", errors.New("no keyring") }
+	t.Cleanup(func() { keyringGet = get })
+
+	token := &TokenInfo{AccessToken: "access", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := SaveToken("default", token); err != nil {
+		t.Fatalf("SaveToken() error = %v", err)
+	}
+
+	path, err := config.GetTokenFilePath("default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // Test file
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file EncryptedTokenFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Format != PassphraseTokenFormat {
+		t.Errorf("token file format = %q (err %v), want %q", file.Format, err, PassphraseTokenFormat)
+	}
+
+	// A new process asks for the passphrase again
+	cachedPassphrase = ""
+	loaded, err := LoadToken("default")
+	if err != nil || loaded.AccessToken != "access" {
+		t.Fatalf("LoadToken() = %v, %v", loaded, err)
+	}
+
+	cachedPassphrase = ""
+	readPassphrase = func() (string, error) { return "wrong", nil }
+	if _, err := LoadToken("default"); err == nil {
+		t.Error("LoadToken() with the wrong passphrase succeeded")
+	}
+}
+
+func TestSetTokenEncryption_Invalid(t *testing.T) {
+	if err := SetTokenEncryption("plaintext"); err == nil {
+		t.Error("SetTokenEncryption(plaintext) succeeded, want error")
+	}
+	if tokenEncryption != TokenEncryptionKeyring {
+		t.Errorf("tokenEncryption = %q after invalid mode, want %q", tokenEncryption, TokenEncryptionKeyring)
+	}
+}
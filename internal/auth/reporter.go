@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+)
+
+// ReporterProfileSuffix is appended to a profile name to form the name
+// under which that profile's reporter token is stored.
+//
+// A reporter token is a second, reduced-scope token kept alongside the
+// profile's regular token. Read-only commands use it automatically when
+// present, so hosts that only run reports (for example, cron jobs) never
+// need to hold a mutation-capable credential.
+const ReporterProfileSuffix = ".reporter"
+
+// ReporterProfile returns the storage name of the reporter token for profile.
+func ReporterProfile(profile string) string {
+	return profile + ReporterProfileSuffix
+}
+
+// SaveReporterToken saves the reporter token for a profile.
+func SaveReporterToken(profile string, token *TokenInfo) error {
+	return SaveToken(ReporterProfile(profile), token)
+}
+
+// LoadReporterToken loads the reporter token for a profile.
+func LoadReporterToken(profile string) (*TokenInfo, error) {
+	return LoadToken(ReporterProfile(profile))
+}
+
+// DeleteReporterToken deletes the reporter token for a profile.
+func DeleteReporterToken(profile string) error {
+	return DeleteToken(ReporterProfile(profile))
+}
+
+// HasReporterToken reports whether a reporter token is stored for profile.
+func HasReporterToken(profile string) (bool, error) {
+	tokenPath, err := config.GetTokenFilePath(ReporterProfile(profile))
+	if err != nil {
+		return false, fmt.Errorf("get token file path: %w", err)
+	}
+
+	if _, err := os.Stat(tokenPath); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("stat token file: %w", err)
+	}
+
+	return true, nil
+}
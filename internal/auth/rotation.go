@@ -0,0 +1,245 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+)
+
+// currentKeyUser is the keyring user under which the version of the
+// current encryption key is stored. Without it, KeyVersion is current.
+const currentKeyUser = KeyringUser + "-current"
+
+// keyringUser returns the keyring user of encryption key version. The
+// first version keeps the original, unversioned user so that keyrings
+// written before key rotation existed are still read.
+func keyringUser(version string) string {
+	if version == KeyVersion {
+		return KeyringUser
+	}
+	return KeyringUser + "-" + version
+}
+
+// keyVersionNumber parses a key version of the form "vN".
+func keyVersionNumber(version string) (int, bool) {
+	digits, ok := strings.CutPrefix(version, "v")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(digits)
+	return n, err == nil && n >= 1
+}
+
+// currentKeyVersion returns the version of the key new data is encrypted
+// with.
+func currentKeyVersion() (string, error) {
+	version, err := keyringGet(KeyringService, currentKeyUser)
+	if err == errKeyNotFound {
+		return KeyVersion, nil
+	}
+	if err != nil {
+		return "", keyringUnavailable(err)
+	}
+	if _, ok := keyVersionNumber(version); !ok {
+		return "", fmt.Errorf("invalid current encryption key version %q in keyring", version)
+	}
+	return version, nil
+}
+
+// KeyRotation describes a completed encryption key rotation.
+type KeyRotation struct {
+	// OldVersion and NewVersion are the key versions before and after.
+	OldVersion string `json:"old_version"`
+	NewVersion string `json:"new_version"`
+
+	// Files are the token files that were re-encrypted.
+	Files []string `json:"files"`
+}
+
+// rewrite is a token file to be re-encrypted.
+type rewrite struct {
+	path     string
+	old, new []byte
+}
+
+// RotateEncryptionKey generates a new encryption key and re-encrypts all
+// token files with it.
+//
+// The new key is stored in the keyring under the next version, and every
+// token file (including reporter tokens) is decrypted with the key it was
+// written with and encrypted with the new one. Only when all files are
+// rewritten does the new key become current. If any file cannot be
+// decrypted or written, the files already rewritten are restored, the new
+// key is removed, and the old key stays current. The token files stay
+// locked until then, so concurrent saves and refreshes wait for the
+// rotation.
+//
+// Old keys are kept in the keyring, so that a token file restored from a
+// backup can still be read.
+func RotateEncryptionKey() (*KeyRotation, error) {
+	oldVersion, err := currentKeyVersion()
+	if err != nil {
+		return nil, err
+	}
+	n, _ := keyVersionNumber(oldVersion)
+	newVersion := fmt.Sprintf("v%d", n+1)
+
+	// Make sure the current key exists: token files may not have been
+	// written yet
+	if _, err := encryptionKey(oldVersion); err != nil {
+		return nil, err
+	}
+
+	newKey, err := generateEncryptionKey()
+	if err != nil {
+		return nil, fmt.Errorf("generate encryption key: %w", err)
+	}
+	if err := keyringSet(KeyringService, keyringUser(newVersion), base64.StdEncoding.EncodeToString(newKey)); err != nil {
+		return nil, fmt.Errorf("store encryption key %s in keyring: %w", newVersion, err)
+	}
+	removeNewKey := func() {
+		_ = keyringDelete(KeyringService, keyringUser(newVersion))
+	}
+
+	profiles, err := tokenProfiles()
+	if err != nil {
+		removeNewKey()
+		return nil, err
+	}
+
+	// Hold every token lock from reading the files until the new key is
+	// current, so that a concurrent SaveToken or RefreshToken neither is
+	// overwritten by a stale copy nor writes with the old key afterwards
+	var rotation *KeyRotation
+	err = withTokenLocks(profiles, func() error {
+		rewrites, err := reencryptTokenFiles(profiles, newVersion, newKey)
+		if err != nil {
+			return err
+		}
+
+		for i, r := range rewrites {
+			if err := writeFileAtomic(r.path, r.new); err != nil {
+				restoreTokenFiles(rewrites[:i])
+				return fmt.Errorf("re-encrypt %s: %w (rotation rolled back)", r.path, err)
+			}
+		}
+
+		if err := keyringSet(KeyringService, currentKeyUser, newVersion); err != nil {
+			restoreTokenFiles(rewrites)
+			return fmt.Errorf("activate encryption key %s: %w (rotation rolled back)", newVersion, err)
+		}
+
+		rotation = &KeyRotation{OldVersion: oldVersion, NewVersion: newVersion, Files: []string{}}
+		for _, r := range rewrites {
+			rotation.Files = append(rotation.Files, r.path)
+		}
+		return nil
+	})
+	if err != nil {
+		removeNewKey()
+		return nil, err
+	}
+	return rotation, nil
+}
+
+// tokenProfiles returns the names under which token files are stored
+// (profiles and their reporter tokens), in sorted order.
+func tokenProfiles() ([]string, error) {
+	tokensDir, err := config.GetTokensDir()
+	if err != nil {
+		return nil, fmt.Errorf("get tokens directory: %w", err)
+	}
+	paths, err := filepath.Glob(filepath.Join(tokensDir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("list token files: %w", err)
+	}
+
+	profiles := make([]string, len(paths))
+	for i, path := range paths {
+		profiles[i] = strings.TrimSuffix(filepath.Base(path), ".json")
+	}
+	return profiles, nil
+}
+
+// reencryptTokenFiles decrypts the encrypted token file of each profile
+// and encrypts it with key, without writing anything. Plaintext token
+// files from v1.x are left alone: they are encrypted when next loaded.
+// The caller holds the token locks.
+func reencryptTokenFiles(profiles []string, version string, key []byte) ([]rewrite, error) {
+	var rewrites []rewrite
+	for _, profile := range profiles {
+		path, err := config.GetTokenFilePath(profile)
+		if err != nil {
+			return nil, fmt.Errorf("get token file path: %w", err)
+		}
+		data, err := os.ReadFile(path) //nolint:gosec // Token files in the config directory
+		if os.IsNotExist(err) {
+			// Deleted (logout) before the lock was taken
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", path, err)
+		}
+
+		var file EncryptedTokenFile
+		if err := json.Unmarshal(data, &file); err != nil || file.Format != EncryptedTokenFormat {
+			continue
+		}
+
+		plaintext, err := Decrypt(file.EncryptedData)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt %s: %w (no files were changed)", path, err)
+		}
+		if file.EncryptedData, err = encryptWithKey(key, plaintext); err != nil {
+			return nil, err
+		}
+		file.EncryptedData.Version = version
+
+		newData, err := json.MarshalIndent(&file, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("marshal encrypted token file: %w", err)
+		}
+		rewrites = append(rewrites, rewrite{path: path, old: data, new: newData})
+	}
+	return rewrites, nil
+}
+
+// restoreTokenFiles puts back the original contents of rewritten files.
+func restoreTokenFiles(rewrites []rewrite) {
+	for _, r := range rewrites {
+		if err := writeFileAtomic(r.path, r.old); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not restore %s: %v\n", r.path, err)
+		}
+	}
+}
+
+// writeFileAtomic replaces the file at path with data, with user-only
+// permissions, so that a failed write never leaves a truncated token file.
+func writeFileAtomic(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := file.Name()
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(tmpPath)
+	}()
+
+	if err := file.Chmod(0600); err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
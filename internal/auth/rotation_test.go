@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+)
+
+// useMemoryKeyring replaces the system keyring with a map for the test.
+func useMemoryKeyring(t *testing.T) map[string]string {
+	t.Helper()
+
+	secrets := map[string]string{}
+	get, set, del := keyringGet, keyringSet, keyringDelete
+	keyringGet = func(_, user string) (string, error) {
+		secret, ok := secrets[user]
+		if !ok {
+			return "", errKeyNotFound
+		}
+		return secret, nil
+	}
+	keyringSet = func(_, user, secret string) error {
+		secrets[user] = secret
+		return nil
+	}
+	keyringDelete = func(_, user string) error {
+		if _, ok := secrets[user]; !ok {
+			return errKeyNotFound
+		}
+		delete(secrets, user)
+		return nil
+	}
+	t.Cleanup(func() { keyringGet, keyringSet, keyringDelete = get, set, del })
+
+	t.Setenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR", t.TempDir())
+	return secrets
+}
+
+func TestRotateEncryptionKey(t *testing.T) {
+	secrets := useMemoryKeyring(t)
+
+	token := &TokenInfo{AccessToken: "access", ExpiresAt: time.Now().Add(time.Hour)}
+	for _, profile := range []string{"default", ReporterProfile("default")} {
+		if err := SaveToken(profile, token); err != nil {
+			t.Fatalf("SaveToken(%q) error = %v", profile, err)
+		}
+	}
+
+	rotation, err := RotateEncryptionKey()
+	if err != nil {
+		t.Fatalf("RotateEncryptionKey() error = %v", err)
+	}
+	if rotation.OldVersion != "v1" || rotation.NewVersion != "v2" || len(rotation.Files) != 2 {
+		t.Errorf("RotateEncryptionKey() = %+v, want v1 to v2 with 2 files", rotation)
+	}
+
+	// Tokens are readable, and new data uses the new key
+	loaded, err := LoadToken("default")
+	if err != nil || loaded.AccessToken != "access" {
+		t.Fatalf("LoadToken() = %v, %v after rotation", loaded, err)
+	}
+	encrypted, err := Encrypt([]byte("x"))
+	if err != nil || encrypted.Version != "v2" {
+		t.Errorf("Encrypt() version = %v (err %v), want v2", encrypted, err)
+	}
+	if _, ok := secrets[KeyringUser]; !ok {
+		t.Error("old key removed from keyring")
+	}
+
+	if rotation, err = RotateEncryptionKey(); err != nil || rotation.NewVersion != "v3" {
+		t.Errorf("second RotateEncryptionKey() = %+v, %v, want v3", rotation, err)
+	}
+
+	if err := ClearEncryptionKey(); err != nil {
+		t.Fatalf("ClearEncryptionKey() error = %v", err)
+	}
+	if len(secrets) != 0 {
+		t.Errorf("ClearEncryptionKey() left %v", secrets)
+	}
+}
+
+func TestRotateEncryptionKey_RollsBack(t *testing.T) {
+	secrets := useMemoryKeyring(t)
+
+	token := &TokenInfo{AccessToken: "access", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := SaveToken("default", token); err != nil {
+		t.Fatalf("SaveToken() error = %v", err)
+	}
+	path, err := config.GetTokenFilePath("default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(path) //nolint:gosec // Test file
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Activating the new key fails after the files were rewritten
+	set := keyringSet
+	keyringSet = func(service, user, secret string) error {
+		if user == currentKeyUser {
+			return errors.New("keyring locked")
+		}
+		return set(service, user, secret)
+	}
+
+	if _, err := RotateEncryptionKey(); err == nil {
+		t.Fatal("RotateEncryptionKey() succeeded, want error")
+	}
+
+	after, err := os.ReadFile(path) //nolint:gosec // Test file
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != string(before) {
+		t.Error("token file not restored after failed rotation")
+	}
+	if _, ok := secrets[keyringUser("v2")]; ok {
+		t.Error("new key left in keyring after failed rotation")
+	}
+	if loaded, err := LoadToken("default"); err != nil || loaded.AccessToken != "access" {
+		t.Errorf("LoadToken() = %v, %v after failed rotation", loaded, err)
+	}
+}
+
+func TestRotateEncryptionKey_UnreadableToken(t *testing.T) {
+	secrets := useMemoryKeyring(t)
+
+	if err := SaveToken("default", &TokenInfo{AccessToken: "access"}); err != nil {
+		t.Fatalf("SaveToken() error = %v", err)
+	}
+	// Replace the key, so the token file can no longer be decrypted
+	delete(secrets, KeyringUser)
+
+	if _, err := RotateEncryptionKey(); err == nil {
+		t.Fatal("RotateEncryptionKey() succeeded, want error")
+	}
+	if _, ok := secrets[currentKeyUser]; ok {
+		t.Error("new key activated after failed rotation")
+	}
+}
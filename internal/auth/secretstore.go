@@ -0,0 +1,86 @@
+package auth
+
+import "fmt"
+
+// Secret stores selectable with SetSecretStore.
+const (
+	// SecretStoreKeyring keeps secrets in the system keyring.
+	SecretStoreKeyring = "keyring"
+
+	// SecretStoreFile keeps secrets in a file encrypted with the key in
+	// SecretKeyEnv, for hosts without a keyring.
+	SecretStoreFile = "file"
+
+	// SecretStoreVault keeps secrets in a HashiCorp Vault KV secrets
+	// engine, so that the nodes of a fleet share them.
+	SecretStoreVault = "vault"
+)
+
+// SecretStore stores the secrets tokens are encrypted with, such as the
+// encryption key versions, by service and user.
+//
+// Get returns ErrSecretNotFound when no secret is stored for the service
+// and user.
+type SecretStore interface {
+	Get(service, user string) (string, error)
+	Set(service, user, secret string) error
+	Delete(service, user string) error
+}
+
+// ErrSecretNotFound is returned by SecretStore.Get when no secret is
+// stored.
+var ErrSecretNotFound = errKeyNotFound
+
+// secretStore is the name of the selected secret store.
+var secretStore = SecretStoreKeyring
+
+// SetSecretStore selects where GetOrCreateEncryptionKey and key rotation
+// keep encryption keys. Moving to another store does not move existing
+// keys: token files written with keys in the old store can no longer be
+// read, so log in again afterwards.
+func SetSecretStore(name string) error {
+	var (
+		store SecretStore
+		err   error
+	)
+	switch name {
+	case SecretStoreKeyring:
+		store = systemKeyring
+	case SecretStoreFile:
+		store, err = newFileStore()
+	case SecretStoreVault:
+		store, err = newVaultStore()
+	default:
+		return fmt.Errorf("unknown secret store %q (expected %s, %s, or %s)", name, SecretStoreKeyring, SecretStoreFile, SecretStoreVault)
+	}
+	if err != nil {
+		// Reported when a key is needed, so that commands that do not
+		// touch tokens still run
+		err = fmt.Errorf("%s secret store: %w", name, err)
+		store = funcStore{
+			get: func(_, _ string) (string, error) { return "", err },
+			set: func(_, _, _ string) error { return err },
+			del: func(_, _ string) error { return err },
+		}
+	}
+	UseSecretStore(name, store)
+	return nil
+}
+
+// UseSecretStore makes store the secret store, under name. It lets
+// programs embedding the CLI's token handling supply their own store.
+func UseSecretStore(name string, store SecretStore) {
+	secretStore = name
+	keyringGet, keyringSet, keyringDelete = store.Get, store.Set, store.Delete
+}
+
+// funcStore is a SecretStore made of functions.
+type funcStore struct {
+	get func(service, user string) (string, error)
+	set func(service, user, secret string) error
+	del func(service, user string) error
+}
+
+func (s funcStore) Get(service, user string) (string, error) { return s.get(service, user) }
+func (s funcStore) Set(service, user, secret string) error   { return s.set(service, user, secret) }
+func (s funcStore) Delete(service, user string) error        { return s.del(service, user) }
@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+)
+
+// SecretKeyEnv is the environment variable holding the key of the file
+// secret store. Any string works; a long random one is best.
+const SecretKeyEnv = "GLOBUS_GCS_SECRET_KEY"
+
+// secretsFileName is the file of the file secret store, in the
+// configuration directory.
+const secretsFileName = "secrets.json"
+
+// SecretStoreFileFormat identifies the file of the file secret store.
+const SecretStoreFileFormat = "secret-store-v1"
+
+// fileStore keeps secrets in one file, encrypted with a key derived from
+// SecretKeyEnv with PBKDF2.
+type fileStore struct {
+	path string
+	key  string
+
+	// derived caches the key derived for a salt, so that PBKDF2 runs once
+	// per process rather than once per secret.
+	salt       []byte
+	iterations int
+	derived    []byte
+}
+
+// newFileStore creates the file secret store of the configuration
+// directory.
+func newFileStore() (*fileStore, error) {
+	key := os.Getenv(SecretKeyEnv)
+	if key == "" {
+		return nil, fmt.Errorf("%s is not set", SecretKeyEnv)
+	}
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return &fileStore{path: filepath.Join(dir, secretsFileName), key: key}, nil
+}
+
+// deriveKey returns the encryption key for salt.
+func (s *fileStore) deriveKey(salt []byte, iterations int) ([]byte, error) {
+	if s.derived != nil && string(s.salt) == string(salt) && s.iterations == iterations {
+		return s.derived, nil
+	}
+	key, err := pbkdf2.Key(sha256.New, s.key, salt, iterations, EncryptionKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+	s.salt, s.iterations, s.derived = salt, iterations, key
+	return key, nil
+}
+
+// load reads and decrypts the secrets, keyed by service and user. A
+// missing file holds no secrets.
+func (s *fileStore) load() (map[string]string, error) {
+	secrets := map[string]string{}
+	data, err := os.ReadFile(s.path) //nolint:gosec // Path is in the config directory
+	if errors.Is(err, os.ErrNotExist) {
+		return secrets, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file EncryptedTokenFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Format != SecretStoreFileFormat ||
+		file.EncryptedData == nil || len(file.Salt) == 0 || file.Iterations <= 0 {
+		return nil, fmt.Errorf("%s is not a secret store file", s.path)
+	}
+	key, err := s.deriveKey(file.Salt, file.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := decryptWithKey(key, file.EncryptedData)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt %s: %w (wrong %s?)", s.path, err, SecretKeyEnv)
+	}
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", s.path, err)
+	}
+	return secrets, nil
+}
+
+// save encrypts and writes the secrets.
+func (s *fileStore) save(secrets map[string]string) error {
+	salt := s.salt
+	if salt == nil || s.iterations != passphraseIterations {
+		salt = make([]byte, passphraseSaltSize)
+		if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+			return fmt.Errorf("generate salt: %w", err)
+		}
+	}
+	key, err := s.deriveKey(salt, passphraseIterations)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+	encrypted, err := encryptWithKey(key, plaintext)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(&EncryptedTokenFile{
+		Format:        SecretStoreFileFormat,
+		EncryptedData: encrypted,
+		Salt:          salt,
+		Iterations:    passphraseIterations,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path, data)
+}
+
+// secretKey is the key of a secret in the file.
+func secretKey(service, user string) string {
+	return service + "/" + user
+}
+
+func (s *fileStore) Get(service, user string) (string, error) {
+	secrets, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	secret, ok := secrets[secretKey(service, user)]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return secret, nil
+}
+
+func (s *fileStore) Set(service, user, secret string) error {
+	secrets, err := s.load()
+	if err != nil {
+		return err
+	}
+	secrets[secretKey(service, user)] = secret
+	return s.save(secrets)
+}
+
+func (s *fileStore) Delete(service, user string) error {
+	secrets, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[secretKey(service, user)]; !ok {
+		return ErrSecretNotFound
+	}
+	delete(secrets, secretKey(service, user))
+	return s.save(secrets)
+}
@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// restoreSecretStore puts back the secret store selected before the test.
+func restoreSecretStore(t *testing.T) {
+	t.Helper()
+	name, get, set, del := secretStore, keyringGet, keyringSet, keyringDelete
+	t.Cleanup(func() {
+		secretStore, keyringGet, keyringSet, keyringDelete = name, get, set, del
+	})
+}
+
+func TestSetSecretStore_File(t *testing.T) {
+	restoreSecretStore(t)
+	dir := t.TempDir()
+	t.Setenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR", dir)
+	t.Setenv(SecretKeyEnv, "fleet-secret")
+
+	if err := SetSecretStore(SecretStoreFile); err != nil {
+		t.Fatalf("SetSecretStore() error = %v", err)
+	}
+	key, err := GetOrCreateEncryptionKey()
+	if err != nil {
+		t.Fatalf("GetOrCreateEncryptionKey() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, secretsFileName))
+	if err != nil {
+		t.Fatalf("secret store file not written: %v", err)
+	}
+	if strings.Contains(string(data), KeyringUser) {
+		t.Error("secret store file is not encrypted")
+	}
+
+	// Another process with the same key reads the same encryption key
+	if err := SetSecretStore(SecretStoreFile); err != nil {
+		t.Fatal(err)
+	}
+	again, err := GetOrCreateEncryptionKey()
+	if err != nil || string(again) != string(key) {
+		t.Errorf("GetOrCreateEncryptionKey() = %x, %v; want the stored key", again, err)
+	}
+
+	t.Setenv(SecretKeyEnv, "wrong")
+	if err := SetSecretStore(SecretStoreFile); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetOrCreateEncryptionKey(); err == nil {
+		t.Error("GetOrCreateEncryptionKey() with the wrong key expected error")
+	}
+}
+
+func TestFileStore_Delete(t *testing.T) {
+	store := &fileStore{path: filepath.Join(t.TempDir(), "secrets.json"), key: "k"}
+	if err := store.Set("svc", "user", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete("svc", "user"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get("svc", "user"); err != ErrSecretNotFound {
+		t.Errorf("Get() after Delete() error = %v, want ErrSecretNotFound", err)
+	}
+	if err := store.Delete("svc", "user"); err != ErrSecretNotFound {
+		t.Errorf("Delete() of a missing secret error = %v, want ErrSecretNotFound", err)
+	}
+}
+
+func TestSetSecretStore_MissingConfiguration(t *testing.T) {
+	restoreSecretStore(t)
+	t.Setenv(SecretKeyEnv, "")
+	t.Setenv("VAULT_ADDR", "")
+
+	for _, name := range []string{SecretStoreFile, SecretStoreVault} {
+		// Only reported when a key is needed
+		if err := SetSecretStore(name); err != nil {
+			t.Fatalf("SetSecretStore(%q) error = %v", name, err)
+		}
+		if _, err := GetOrCreateEncryptionKey(); err == nil || !strings.Contains(err.Error(), name+" secret store") {
+			t.Errorf("GetOrCreateEncryptionKey() with %s error = %v", name, err)
+		}
+	}
+
+	if err := SetSecretStore("etcd"); err == nil {
+		t.Error("SetSecretStore() with an unknown store expected error")
+	}
+}
+
+// fakeVault is a Vault KV version 2 secrets engine mounted at "kv".
+type fakeVault struct {
+	mu      sync.Mutex
+	secrets map[string]string
+}
+
+func newFakeVault(t *testing.T) *fakeVault {
+	t.Helper()
+	f := &fakeVault{secrets: map[string]string{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if r.Header.Get("X-Vault-Token") != "s.test" || r.Header.Get("X-Vault-Namespace") != "research" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		if path, ok := strings.CutPrefix(r.URL.Path, "/v1/kv/data/"); ok {
+			switch r.Method {
+			case http.MethodGet:
+				value, ok := f.secrets[path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"data": map[string]string{"value": value}}})
+			case http.MethodPost:
+				var body struct {
+					Data map[string]string `json:"data"`
+				}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				f.secrets[path] = body.Data["value"]
+			}
+			return
+		}
+		if path, ok := strings.CutPrefix(r.URL.Path, "/v1/kv/metadata/"); ok && r.Method == http.MethodDelete {
+			delete(f.secrets, path)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("VAULT_ADDR", server.URL+"/")
+	t.Setenv("VAULT_TOKEN", "s.test")
+	t.Setenv("VAULT_NAMESPACE", "research")
+	t.Setenv(VaultPathEnv, "kv/gcs/prod")
+	return f
+}
+
+func TestVaultStore(t *testing.T) {
+	fake := newFakeVault(t)
+
+	store, err := newVaultStore()
+	if err != nil {
+		t.Fatalf("newVaultStore() error = %v", err)
+	}
+	if _, err := store.Get(KeyringService, KeyringUser); err != ErrSecretNotFound {
+		t.Errorf("Get() of a missing secret error = %v, want ErrSecretNotFound", err)
+	}
+	if err := store.Set(KeyringService, KeyringUser, "key"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if got := fake.secrets["gcs/prod/"+KeyringService+"/"+KeyringUser]; got != "key" {
+		t.Errorf("stored secrets = %v", fake.secrets)
+	}
+	if got, err := store.Get(KeyringService, KeyringUser); err != nil || got != "key" {
+		t.Errorf("Get() = %q, %v; want key", got, err)
+	}
+	if err := store.Delete(KeyringService, KeyringUser); err != nil || len(fake.secrets) != 0 {
+		t.Errorf("Delete() error = %v, secrets = %v", err, fake.secrets)
+	}
+
+	t.Setenv("VAULT_TOKEN", "s.wrong")
+	store, err = newVaultStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(KeyringService, KeyringUser); err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("Get() with a wrong token error = %v, want Vault's error", err)
+	}
+}
+
+func TestVaultStore_MissingMount(t *testing.T) {
+	newFakeVault(t)
+	t.Setenv(VaultPathEnv, "secret")
+
+	store, err := newVaultStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set(KeyringService, KeyringUser, "key"); err == nil || !strings.Contains(err.Error(), "no KV version 2 secrets engine") {
+		t.Errorf("Set() error = %v, want a missing mount error", err)
+	}
+}
@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// VaultPathEnv is the environment variable holding the path under which
+// the Vault secret store keeps secrets: the mount of a KV version 2
+// secrets engine, optionally followed by a path prefix (e.g.
+// "secret/gcs/prod"). The default is "secret".
+const VaultPathEnv = "GLOBUS_GCS_VAULT_PATH"
+
+// vaultStore keeps secrets in a HashiCorp Vault KV version 2 secrets
+// engine, one Vault secret per service and user, in its "value" field.
+//
+// It is configured with the variables of the Vault CLI: VAULT_ADDR,
+// VAULT_TOKEN (or ~/.vault-token), VAULT_NAMESPACE, and VAULT_CACERT.
+type vaultStore struct {
+	client    *http.Client
+	addr      string
+	token     string
+	namespace string
+	mount     string
+	prefix    string
+}
+
+// newVaultStore creates a Vault secret store from the environment.
+func newVaultStore() (*vaultStore, error) {
+	addr := strings.TrimSuffix(os.Getenv("VAULT_ADDR"), "/")
+	if addr == "" {
+		return nil, errors.New("VAULT_ADDR is not set")
+	}
+
+	token := os.Getenv("VAULT_TOKEN")
+	if token == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			data, _ := os.ReadFile(filepath.Join(home, ".vault-token")) //nolint:gosec // The Vault CLI's token file
+			token = strings.TrimSpace(string(data))
+		}
+	}
+	if token == "" {
+		return nil, errors.New("no Vault token (set VAULT_TOKEN or run 'vault login')")
+	}
+
+	path := strings.Trim(os.Getenv(VaultPathEnv), "/")
+	if path == "" {
+		path = "secret"
+	}
+	mount, prefix, _ := strings.Cut(path, "/")
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if caFile := os.Getenv("VAULT_CACERT"); caFile != "" {
+		pem, err := os.ReadFile(caFile) //nolint:gosec // User-specified CA certificate
+		if err != nil {
+			return nil, fmt.Errorf("read VAULT_CACERT: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in VAULT_CACERT %s", caFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	return &vaultStore{
+		client:    &http.Client{Timeout: 30 * time.Second, Transport: transport},
+		addr:      addr,
+		token:     token,
+		namespace: os.Getenv("VAULT_NAMESPACE"),
+		mount:     mount,
+		prefix:    prefix,
+	}, nil
+}
+
+// url returns the URL of the secret of service and user under kind
+// ("data" or "metadata").
+func (s *vaultStore) url(kind, service, user string) string {
+	path := service + "/" + user
+	if s.prefix != "" {
+		path = s.prefix + "/" + path
+	}
+	return fmt.Sprintf("%s/v1/%s/%s/%s", s.addr, s.mount, kind, path)
+}
+
+// do sends a request to Vault and returns the response body.
+func (s *vaultStore) do(method, url string, body any) (int, []byte, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("X-Vault-Token", s.token)
+	if s.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, nil, err
+	}
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotFound {
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		if json.Unmarshal(data, &vaultErr) == nil && len(vaultErr.Errors) > 0 {
+			return resp.StatusCode, nil, fmt.Errorf("vault: HTTP %d: %s", resp.StatusCode, strings.Join(vaultErr.Errors, "; "))
+		}
+		return resp.StatusCode, nil, fmt.Errorf("vault: HTTP %d", resp.StatusCode)
+	}
+	return resp.StatusCode, data, nil
+}
+
+func (s *vaultStore) Get(service, user string) (string, error) {
+	status, data, err := s.do(http.MethodGet, s.url("data", service, user), nil)
+	if err != nil {
+		return "", err
+	}
+	if status == http.StatusNotFound {
+		return "", ErrSecretNotFound
+	}
+
+	var secret struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &secret); err != nil {
+		return "", fmt.Errorf("vault: parse secret: %w", err)
+	}
+	value, ok := secret.Data.Data["value"]
+	if !ok {
+		// The latest version was deleted
+		return "", ErrSecretNotFound
+	}
+	return value, nil
+}
+
+func (s *vaultStore) Set(service, user, secret string) error {
+	body := map[string]any{"data": map[string]string{"value": secret}}
+	status, _, err := s.do(http.MethodPost, s.url("data", service, user), body)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound {
+		return fmt.Errorf("vault: no KV version 2 secrets engine mounted at %s/ (see %s)", s.mount, VaultPathEnv)
+	}
+	return nil
+}
+
+// Delete removes all versions of the secret, so that a deleted encryption
+// key cannot be restored from Vault's history.
+func (s *vaultStore) Delete(service, user string) error {
+	if _, err := s.Get(service, user); err != nil {
+		return err
+	}
+	_, _, err := s.do(http.MethodDelete, s.url("metadata", service, user), nil)
+	return err
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+)
+
+// PlaintextTokenFormat describes token files from v1.x, which are not
+// encrypted until they are next loaded.
+const PlaintextTokenFormat = "plaintext"
+
+// TokenStatus describes the stored token of one profile, without the
+// token itself.
+type TokenStatus struct {
+	Profile string `json:"profile"`
+
+	// Reporter is set for the read-only token of a profile (see
+	// SaveReporterToken); Profile is then the profile it belongs to.
+	Reporter bool `json:"reporter,omitempty"`
+
+	File        string `json:"file"`
+	Permissions string `json:"permissions"`
+
+	// Encryption is EncryptedTokenFormat, PassphraseTokenFormat, or
+	// PlaintextTokenFormat. KeyVersion is the keyring key version of
+	// EncryptedTokenFormat files.
+	Encryption string `json:"encryption"`
+	KeyVersion string `json:"key_version,omitempty"`
+
+	// The fields below are only set if the token could be read.
+	ExpiresAt      time.Time `json:"expires_at,omitempty"`
+	Valid          bool      `json:"valid"`
+	Refreshable    bool      `json:"refreshable"`
+	Scopes         []string  `json:"scopes,omitempty"`
+	ResourceServer string    `json:"resource_server,omitempty"`
+
+	// OtherResourceServers are the resource servers of the token's
+	// OtherTokens, sorted.
+	OtherResourceServers []string `json:"other_resource_servers,omitempty"`
+
+	// Endpoints maps endpoint FQDNs to the endpoint IDs of their tokens.
+	Endpoints map[string]string `json:"endpoints,omitempty"`
+
+	// Error explains why the token could not be read.
+	Error string `json:"error,omitempty"`
+}
+
+// InspectTokens describes the token files of all profiles, sorted by
+// profile. Unlike LoadToken, it never rewrites a file, so plaintext
+// token files are reported as such rather than migrated. A token that
+// cannot be decrypted is reported with Error set.
+func InspectTokens() ([]TokenStatus, error) {
+	tokensDir, err := config.GetTokensDir()
+	if err != nil {
+		return nil, fmt.Errorf("get tokens directory: %w", err)
+	}
+	paths, err := filepath.Glob(filepath.Join(tokensDir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("list token files: %w", err)
+	}
+
+	statuses := make([]TokenStatus, 0, len(paths))
+	for _, path := range paths {
+		statuses = append(statuses, inspectTokenFile(path))
+	}
+	sort.SliceStable(statuses, func(i, j int) bool {
+		if statuses[i].Profile != statuses[j].Profile {
+			return statuses[i].Profile < statuses[j].Profile
+		}
+		return !statuses[i].Reporter && statuses[j].Reporter
+	})
+	return statuses, nil
+}
+
+// inspectTokenFile describes the token file at path.
+func inspectTokenFile(path string) TokenStatus {
+	profile := strings.TrimSuffix(filepath.Base(path), ".json")
+	status := TokenStatus{Profile: profile, File: path}
+	if base, ok := strings.CutSuffix(profile, ReporterProfileSuffix); ok {
+		status.Profile, status.Reporter = base, true
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Permissions = fmt.Sprintf("%04o", info.Mode().Perm())
+
+	data, err := os.ReadFile(path) //nolint:gosec // Token files in the config directory
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	plaintext := data
+	var file EncryptedTokenFile
+	if err := json.Unmarshal(data, &file); err == nil &&
+		(file.Format == EncryptedTokenFormat || file.Format == PassphraseTokenFormat) {
+		status.Encryption = file.Format
+		if file.Format == EncryptedTokenFormat && file.EncryptedData != nil {
+			status.KeyVersion = file.EncryptedData.Version
+		}
+		if plaintext, err = decryptTokenFile(&file); err != nil {
+			status.Error = fmt.Sprintf("decrypt token: %v", err)
+			return status
+		}
+	} else {
+		status.Encryption = PlaintextTokenFormat
+	}
+
+	var token TokenInfo
+	if err := json.Unmarshal(plaintext, &token); err != nil {
+		status.Error = fmt.Sprintf("parse token: %v", err)
+		return status
+	}
+	status.ExpiresAt = token.ExpiresAt
+	status.Valid = token.IsValid()
+	status.Refreshable = token.CanRefresh()
+	status.Scopes = token.Scopes
+	status.ResourceServer = token.ResourceServer
+	status.OtherResourceServers = slices.Sorted(maps.Keys(token.OtherTokens))
+	status.Endpoints = token.Endpoints
+	return status
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+)
+
+func TestInspectTokens(t *testing.T) {
+	useMemoryKeyring(t)
+
+	token := &TokenInfo{
+		AccessToken:    "access",
+		RefreshToken:   "refresh",
+		ExpiresAt:      time.Now().Add(time.Hour),
+		Scopes:         []string{"openid"},
+		ResourceServer: "gcs",
+	}
+	if err := SaveToken("prod", token); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveReporterToken("prod", &TokenInfo{AccessToken: "ro", ExpiresAt: time.Now().Add(-time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A v1.x token file must be reported, not migrated
+	legacyPath, err := config.GetTokenFilePath("legacy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy, _ := json.Marshal(&TokenInfo{AccessToken: "old", ExpiresAt: time.Now().Add(time.Hour)})
+	if err := os.WriteFile(legacyPath, legacy, 0644); err != nil { //nolint:gosec // Test of permission reporting
+		t.Fatal(err)
+	}
+
+	statuses, err := InspectTokens()
+	if err != nil {
+		t.Fatalf("InspectTokens() error = %v", err)
+	}
+	if len(statuses) != 3 {
+		t.Fatalf("InspectTokens() returned %d statuses, want 3: %+v", len(statuses), statuses)
+	}
+
+	legacyStatus, prod, reporter := statuses[0], statuses[1], statuses[2]
+	if legacyStatus.Profile != "legacy" || legacyStatus.Encryption != PlaintextTokenFormat || legacyStatus.Permissions != "0644" {
+		t.Errorf("legacy status = %+v, want plaintext with 0644", legacyStatus)
+	}
+	if prod.Profile != "prod" || prod.Reporter || prod.Encryption != EncryptedTokenFormat || prod.KeyVersion != KeyVersion {
+		t.Errorf("prod status = %+v, want encrypted-v1 with key v1", prod)
+	}
+	if !prod.Valid || !prod.Refreshable || prod.ResourceServer != "gcs" || len(prod.Scopes) != 1 || prod.Permissions != "0600" {
+		t.Errorf("prod status = %+v, want valid, refreshable token details", prod)
+	}
+	if reporter.Profile != "prod" || !reporter.Reporter || reporter.Valid || reporter.Refreshable {
+		t.Errorf("reporter status = %+v, want expired reporter token of prod", reporter)
+	}
+
+	data, err := os.ReadFile(legacyPath) //nolint:gosec // Test file
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, legacy) {
+		t.Error("InspectTokens() rewrote a plaintext token file")
+	}
+}
+
+func TestInspectTokens_Undecryptable(t *testing.T) {
+	secrets := useMemoryKeyring(t)
+
+	if err := SaveToken("prod", &TokenInfo{AccessToken: "access", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	for user := range secrets {
+		delete(secrets, user)
+	}
+
+	statuses, err := InspectTokens()
+	if err != nil {
+		t.Fatalf("InspectTokens() error = %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].Error == "" || statuses[0].Encryption != EncryptedTokenFormat {
+		t.Errorf("InspectTokens() = %+v, want one encrypted-v1 token with an error", statuses)
+	}
+}
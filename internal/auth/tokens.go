@@ -18,10 +18,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"time"
 
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-sdk/v3/pkg/services/auth"
 )
 
@@ -47,6 +49,78 @@ type TokenInfo struct {
 
 	// ResourceServer is the resource server this token is for.
 	ResourceServer string `json:"resource_server,omitempty"`
+
+	// OtherTokens are the tokens Globus Auth issued for the other resource
+	// servers of the login (transfer, a GCS endpoint's manager API, ...),
+	// keyed by resource server.
+	OtherTokens map[string]*TokenInfo `json:"other_tokens,omitempty"`
+
+	// Endpoints maps the FQDNs of the endpoints the login requested
+	// manage_collections scopes for to their endpoint IDs, the resource
+	// servers of their tokens.
+	Endpoints map[string]string `json:"endpoints,omitempty"`
+}
+
+// EndpointScope returns the scope for managing the collections of the
+// endpoint with endpointID through its GCS Manager API.
+func EndpointScope(endpointID string) string {
+	return "urn:globus:auth:scope:" + endpointID + ":manage_collections"
+}
+
+// KeepEndpoints adds the endpoint tokens of previous, an earlier login of
+// the same profile, that t has no token for, so that endpoints can be
+// added to a profile one login at a time.
+func (t *TokenInfo) KeepEndpoints(previous *TokenInfo) {
+	if previous == nil {
+		return
+	}
+	for fqdn, endpointID := range previous.Endpoints {
+		if _, ok := t.Endpoints[fqdn]; ok {
+			continue
+		}
+		other := previous.ForResourceServer(endpointID)
+		if other == nil {
+			continue
+		}
+		if t.ForResourceServer(endpointID) == nil {
+			t.setOther(endpointID, other)
+		}
+		t.SetEndpoint(fqdn, endpointID)
+	}
+}
+
+// SetEndpoint records that the token for the endpoint at fqdn is that of
+// resource server endpointID.
+func (t *TokenInfo) SetEndpoint(fqdn, endpointID string) {
+	if t.Endpoints == nil {
+		t.Endpoints = map[string]string{}
+	}
+	t.Endpoints[fqdn] = endpointID
+}
+
+// ForResourceServer returns the token for resourceServer, or nil if the
+// login did not include one.
+func (t *TokenInfo) ForResourceServer(resourceServer string) *TokenInfo {
+	if t == nil {
+		return nil
+	}
+	if t.ResourceServer == resourceServer {
+		return t
+	}
+	return t.OtherTokens[resourceServer]
+}
+
+// AccessTokens returns the access tokens of all resource servers, keyed by
+// resource server.
+func (t *TokenInfo) AccessTokens() map[string]string {
+	tokens := make(map[string]string, len(t.OtherTokens)+1)
+	for resourceServer, other := range t.OtherTokens {
+		tokens[resourceServer] = other.AccessToken
+	}
+	if t.ResourceServer != "" {
+		tokens[t.ResourceServer] = t.AccessToken
+	}
+	return tokens
 }
 
 // EncryptedTokenFile represents the on-disk format of encrypted token files.
@@ -59,11 +133,21 @@ type EncryptedTokenFile struct {
 
 	// EncryptedData contains the encrypted TokenInfo
 	EncryptedData *EncryptedData `json:"encrypted_data"`
+
+	// Salt and Iterations are the PBKDF2 parameters of passphrase-
+	// encrypted files (PassphraseTokenFormat).
+	Salt       []byte `json:"salt,omitempty"`
+	Iterations int    `json:"iterations,omitempty"`
 }
 
 const (
 	// EncryptedTokenFormat is the format identifier for encrypted tokens
 	EncryptedTokenFormat = "encrypted-v1"
+
+	// PassphraseTokenFormat is the format identifier for tokens encrypted
+	// with a passphrase: bundles written by ExportToken, and token files in
+	// passphrase mode (see SetTokenEncryption)
+	PassphraseTokenFormat = "passphrase-v1"
 )
 
 // IsValid returns true if the token is valid (not expired with buffer).
@@ -92,17 +176,14 @@ func (t *TokenInfo) CanRefresh() bool {
 // The token file is created with 0600 permissions (user-only read/write)
 // for additional security.
 func SaveToken(profile string, token *TokenInfo) error {
-	// Ensure tokens directory exists
-	if err := config.EnsureTokensDir(); err != nil {
-		return fmt.Errorf("ensure tokens directory: %w", err)
-	}
-
-	// Get token file path
-	tokenPath, err := config.GetTokenFilePath(profile)
-	if err != nil {
-		return fmt.Errorf("get token file path: %w", err)
-	}
+	return withTokenLock(profile, func(tokenPath string) error {
+		return writeToken(tokenPath, token)
+	})
+}
 
+// writeToken encrypts token and replaces the file at tokenPath with it.
+// The caller holds the token lock.
+func writeToken(tokenPath string, token *TokenInfo) error {
 	// Marshal token to JSON (plaintext, will be encrypted)
 	plaintext, err := json.Marshal(token)
 	if err != nil {
@@ -110,25 +191,20 @@ func SaveToken(profile string, token *TokenInfo) error {
 	}
 
 	// Encrypt the token data
-	encryptedData, err := Encrypt(plaintext)
+	encryptedFile, err := encryptTokenFile(plaintext)
 	if err != nil {
 		return fmt.Errorf("encrypt token: %w", err)
 	}
 
-	// Wrap in encrypted file format
-	encryptedFile := &EncryptedTokenFile{
-		Format:        EncryptedTokenFormat,
-		EncryptedData: encryptedData,
-	}
-
 	// Marshal encrypted file to JSON
 	fileData, err := json.MarshalIndent(encryptedFile, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshal encrypted token file: %w", err)
 	}
 
-	// Write with user-only permissions
-	if err := os.WriteFile(tokenPath, fileData, 0600); err != nil {
+	// Write with user-only permissions through a temporary file, so that
+	// readers never see a partly written token
+	if err := writeFileAtomic(tokenPath, fileData); err != nil {
 		return fmt.Errorf("write token file: %w", err)
 	}
 
@@ -148,49 +224,67 @@ func LoadToken(profile string) (*TokenInfo, error) {
 		return nil, fmt.Errorf("get token file path: %w", err)
 	}
 
+	token, plaintext, err := readToken(profile, tokenPath)
+	if err != nil || !plaintext {
+		return token, err
+	}
+
+	// Automatically migrate plaintext token to encrypted format
+	// This is transparent to the user and happens on first load
+	err = withTokenLock(profile, func(tokenPath string) error {
+		// Another process may have migrated it meanwhile
+		current, plaintext, err := readToken(profile, tokenPath)
+		if err != nil || !plaintext {
+			return err
+		}
+		return writeToken(tokenPath, current)
+	})
+	if err != nil {
+		// Migration failed - log warning but still return the token
+		// This allows the CLI to continue working even if keyring is unavailable
+		fmt.Fprintf(os.Stderr, "Warning: Could not migrate token to encrypted format: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Your token is still stored in plaintext. To enable encryption, ensure your system keyring is available,\n"+
+			"or set 'token_encryption: passphrase' in config.yaml.\n")
+	}
+
+	return token, nil
+}
+
+// readToken reads and decrypts the token file at tokenPath. plaintext
+// reports a v1.x token file that has not been encrypted yet.
+func readToken(profile, tokenPath string) (token *TokenInfo, plaintext bool, err error) {
 	// Read token file
 	data, err := os.ReadFile(tokenPath) //nolint:gosec // Intentional file read from config directory
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("not logged in (no token found for profile %q)", profile)
+			return nil, false, fmt.Errorf("not logged in (no token found for profile %q)", profile)
 		}
-		return nil, fmt.Errorf("read token file: %w", err)
+		return nil, false, fmt.Errorf("read token file: %w", err)
 	}
 
 	// Try to parse as encrypted token file first
 	var encryptedFile EncryptedTokenFile
-	if err := json.Unmarshal(data, &encryptedFile); err == nil && encryptedFile.Format == EncryptedTokenFormat {
+	if err := json.Unmarshal(data, &encryptedFile); err == nil &&
+		(encryptedFile.Format == EncryptedTokenFormat || encryptedFile.Format == PassphraseTokenFormat) {
 		// This is an encrypted token - decrypt it
-		plaintext, err := Decrypt(encryptedFile.EncryptedData)
+		decrypted, err := decryptTokenFile(&encryptedFile)
 		if err != nil {
-			return nil, fmt.Errorf("decrypt token: %w", err)
+			return nil, false, fmt.Errorf("decrypt token: %w", err)
 		}
 
 		// Parse decrypted token
-		var token TokenInfo
-		if err := json.Unmarshal(plaintext, &token); err != nil {
-			return nil, fmt.Errorf("parse decrypted token: %w", err)
+		if err := json.Unmarshal(decrypted, &token); err != nil {
+			return nil, false, fmt.Errorf("parse decrypted token: %w", err)
 		}
 
-		return &token, nil
+		return token, false, nil
 	}
 
 	// Not encrypted - try to parse as plaintext token (v1.x format)
-	var token TokenInfo
 	if err := json.Unmarshal(data, &token); err != nil {
-		return nil, fmt.Errorf("parse token file: %w (file may be corrupted)", err)
+		return nil, false, fmt.Errorf("parse token file: %w (file may be corrupted)", err)
 	}
-
-	// Automatically migrate plaintext token to encrypted format
-	// This is transparent to the user and happens on first load
-	if err := SaveToken(profile, &token); err != nil {
-		// Migration failed - log warning but still return the token
-		// This allows the CLI to continue working even if keyring is unavailable
-		fmt.Fprintf(os.Stderr, "Warning: Could not migrate token to encrypted format: %v\n", err)
-		fmt.Fprintf(os.Stderr, "Your token is still stored in plaintext. To enable encryption, ensure your system keyring is available.\n")
-	}
-
-	return &token, nil
+	return token, true, nil
 }
 
 // DeleteToken deletes the token file for a given profile.
@@ -199,12 +293,16 @@ func DeleteToken(profile string) error {
 	if err != nil {
 		return fmt.Errorf("get token file path: %w", err)
 	}
-
-	if err := os.Remove(tokenPath); err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("delete token file: %w", err)
+	if _, err := os.Stat(tokenPath); os.IsNotExist(err) {
+		return nil
 	}
 
-	return nil
+	return withTokenLock(profile, func(tokenPath string) error {
+		if err := os.Remove(tokenPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("delete token file: %w", err)
+		}
+		return nil
+	})
 }
 
 // RefreshTokenIfNeeded refreshes the token if it's expired or will expire soon.
@@ -222,15 +320,129 @@ func RefreshTokenIfNeeded(ctx context.Context, profile string, authClient *auth.
 		return false, nil
 	}
 
-	// Cannot refresh without refresh token
-	if !token.CanRefresh() {
-		return false, fmt.Errorf("token expired and cannot be refreshed (no refresh token)")
+	refreshed := false
+	err = withTokenLock(profile, func(tokenPath string) error {
+		// Another process may have refreshed it while we waited for the lock
+		token, _, err := readToken(profile, tokenPath)
+		if err != nil {
+			return err
+		}
+		if token.IsValid() {
+			return nil
+		}
+
+		// Cannot refresh without refresh token
+		if !token.CanRefresh() {
+			return fmt.Errorf("token expired and cannot be refreshed (no refresh token)")
+		}
+
+		if _, err := refreshToken(ctx, tokenPath, token, authClient); err != nil {
+			return err
+		}
+		refreshed = true
+		return nil
+	})
+	return refreshed, err
+}
+
+// RefreshToken refreshes the profile's token unconditionally and saves the
+// result. It is used when a server rejects a token that has not expired,
+// for example because it was revoked.
+func RefreshToken(ctx context.Context, profile string, authClient *auth.Client) (*TokenInfo, error) {
+	token, err := LoadToken(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	var newToken *TokenInfo
+	err = withTokenLock(profile, func(tokenPath string) error {
+		current, _, err := readToken(profile, tokenPath)
+		if err != nil {
+			return err
+		}
+		// A token other than the rejected one was saved by another process
+		// while we waited for the lock
+		if current.AccessToken != token.AccessToken && current.IsValid() {
+			newToken = current
+			return nil
+		}
+
+		if !current.CanRefresh() {
+			return fmt.Errorf("token cannot be refreshed (no refresh token)")
+		}
+		newToken, err = refreshToken(ctx, tokenPath, current, authClient)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newToken, nil
+}
+
+// RevokeToken revokes the access and refresh tokens of token and of its
+// other resource servers with Globus Auth, so that they stop working even
+// if a copy of the token file survives. All tokens are tried; the first
+// error is returned.
+func RevokeToken(ctx context.Context, token *TokenInfo, authClient *auth.Client) error {
+	var firstErr error
+	revoke := func(label, secret string) {
+		if secret == "" {
+			return
+		}
+		if err := authClient.RevokeToken(ctx, secret); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("revoke %s: %w", label, err)
+		}
 	}
 
+	for _, t := range append([]*TokenInfo{token}, slices.Collect(maps.Values(token.OtherTokens))...) {
+		label := "token"
+		if t.ResourceServer != "" {
+			label = t.ResourceServer + " token"
+		}
+		revoke("refresh "+label, t.RefreshToken)
+		revoke("access "+label, t.AccessToken)
+	}
+	return firstErr
+}
+
+// refreshToken exchanges the refresh tokens of token and of its other
+// resource servers for new tokens and saves them to tokenPath. The caller
+// holds the token lock.
+func refreshToken(ctx context.Context, tokenPath string, token *TokenInfo, authClient *auth.Client) (*TokenInfo, error) {
+	newToken, err := refreshOne(ctx, token, authClient)
+	if err != nil {
+		return nil, err
+	}
+
+	newToken.Endpoints = token.Endpoints
+	for resourceServer, other := range token.OtherTokens {
+		if !other.CanRefresh() {
+			// Kept until it expires; a new login replaces it
+			newToken.setOther(resourceServer, other)
+			continue
+		}
+		refreshed, err := refreshOne(ctx, other, authClient)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", resourceServer, err)
+		}
+		newToken.setOther(resourceServer, refreshed)
+	}
+
+	// Save updated token
+	if err := writeToken(tokenPath, newToken); err != nil {
+		return nil, fmt.Errorf("save refreshed token: %w", err)
+	}
+
+	return newToken, nil
+}
+
+// refreshOne exchanges token's refresh token for a new token of the same
+// resource server.
+func refreshOne(ctx context.Context, token *TokenInfo, authClient *auth.Client) (*TokenInfo, error) {
 	// Refresh the token
 	tokenResp, err := authClient.RefreshToken(ctx, token.RefreshToken)
 	if err != nil {
-		return false, fmt.Errorf("refresh token: %w", err)
+		return nil, fmt.Errorf("refresh token: %w", err)
 	}
 
 	// Update token info
@@ -241,22 +453,45 @@ func RefreshTokenIfNeeded(ctx context.Context, profile string, authClient *auth.
 		Scopes:         token.Scopes,
 		ResourceServer: tokenResp.ResourceServer,
 	}
+	if newToken.ResourceServer == "" {
+		newToken.ResourceServer = token.ResourceServer
+	}
 
 	// If refresh token not returned, keep the old one
 	if newToken.RefreshToken == "" {
 		newToken.RefreshToken = token.RefreshToken
 	}
 
-	// Save updated token
-	if err := SaveToken(profile, newToken); err != nil {
-		return false, fmt.Errorf("save refreshed token: %w", err)
-	}
+	return newToken, nil
+}
 
-	return true, nil
+// setOther stores the token of another resource server.
+func (t *TokenInfo) setOther(resourceServer string, other *TokenInfo) {
+	if t.OtherTokens == nil {
+		t.OtherTokens = map[string]*TokenInfo{}
+	}
+	t.OtherTokens[resourceServer] = other
 }
 
-// TokenFromAuthResponse converts an auth.TokenResponse to TokenInfo.
+// TokenFromAuthResponse converts an auth.TokenResponse to TokenInfo,
+// including the tokens for other resource servers.
 func TokenFromAuthResponse(resp *auth.TokenResponse) *TokenInfo {
+	token := tokenFromResponse(resp)
+
+	// Malformed entries are skipped; the primary token is still usable
+	others, _ := resp.GetOtherTokens()
+	for _, other := range others {
+		if other.ResourceServer == "" || other.ResourceServer == token.ResourceServer {
+			continue
+		}
+		token.setOther(other.ResourceServer, tokenFromResponse(other))
+	}
+
+	return token
+}
+
+// tokenFromResponse converts a single token of an auth.TokenResponse.
+func tokenFromResponse(resp *auth.TokenResponse) *TokenInfo {
 	return &TokenInfo{
 		AccessToken:    resp.AccessToken,
 		RefreshToken:   resp.RefreshToken,
@@ -1,10 +1,20 @@
 package auth
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/scttfrdmn/globus-go-sdk/v3/pkg/services/auth"
 )
 
 func TestTokenInfo_IsValid(t *testing.T) {
@@ -406,3 +416,167 @@ func contains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestTokenFromAuthResponse_OtherTokens(t *testing.T) {
+	resp := &auth.TokenResponse{
+		AccessToken:    "auth-token",
+		RefreshToken:   "auth-refresh",
+		ExpiresIn:      3600,
+		ResourceServer: "auth.globus.org",
+		Scope:          "openid",
+		OtherTokens: []json.RawMessage{
+			json.RawMessage(`{"access_token": "transfer-token", "expires_in": 3600, "resource_server": "transfer.api.globus.org", "scope": "urn:globus:auth:scope:transfer.api.globus.org:all"}`),
+			json.RawMessage(`{"access_token": "gcs-token", "refresh_token": "gcs-refresh", "expires_in": 3600, "resource_server": "ep-1"}`),
+		},
+	}
+
+	token := TokenFromAuthResponse(resp)
+	if len(token.OtherTokens) != 2 {
+		t.Fatalf("OtherTokens = %v, want transfer and ep-1", token.OtherTokens)
+	}
+	if got := token.ForResourceServer("ep-1"); got == nil || got.AccessToken != "gcs-token" || got.RefreshToken != "gcs-refresh" {
+		t.Errorf("ForResourceServer(ep-1) = %+v", got)
+	}
+	if got := token.ForResourceServer("auth.globus.org"); got != token {
+		t.Errorf("ForResourceServer(auth.globus.org) = %+v, want the primary token", got)
+	}
+	if got := token.ForResourceServer("other"); got != nil {
+		t.Errorf("ForResourceServer(other) = %+v, want nil", got)
+	}
+
+	want := map[string]string{
+		"auth.globus.org":         "auth-token",
+		"transfer.api.globus.org": "transfer-token",
+		"ep-1":                    "gcs-token",
+	}
+	if got := token.AccessTokens(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AccessTokens() = %v, want %v", got, want)
+	}
+}
+
+func TestSaveAndLoadToken_OtherTokens(t *testing.T) {
+	useMemoryKeyring(t)
+
+	token := &TokenInfo{
+		AccessToken:    "auth-token",
+		ExpiresAt:      time.Now().Add(time.Hour),
+		ResourceServer: "auth.globus.org",
+		OtherTokens: map[string]*TokenInfo{
+			"ep-1": {AccessToken: "gcs-token", ResourceServer: "ep-1", ExpiresAt: time.Now().Add(time.Hour)},
+		},
+	}
+	if err := SaveToken("test", token); err != nil {
+		t.Fatalf("SaveToken() error = %v", err)
+	}
+
+	loaded, err := LoadToken("test")
+	if err != nil {
+		t.Fatalf("LoadToken() error = %v", err)
+	}
+	if got := loaded.ForResourceServer("ep-1"); got == nil || got.AccessToken != "gcs-token" {
+		t.Errorf("loaded token for ep-1 = %+v, want gcs-token", got)
+	}
+}
+
+func TestSaveAndLoadToken_Concurrent(t *testing.T) {
+	useMemoryKeyring(t)
+
+	if err := SaveToken("test", &TokenInfo{AccessToken: "token-0", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("SaveToken() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 40)
+	for i := range 20 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			errs <- SaveToken("test", &TokenInfo{AccessToken: fmt.Sprintf("token-%d", i), ExpiresAt: time.Now().Add(time.Hour)})
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := LoadToken("test")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("concurrent token access error = %v", err)
+		}
+	}
+	if _, err := LoadToken("test"); err != nil {
+		t.Errorf("LoadToken() after concurrent writes error = %v", err)
+	}
+}
+
+func TestTokenInfo_KeepEndpoints(t *testing.T) {
+	previous := &TokenInfo{
+		AccessToken:    "old-auth-token",
+		ResourceServer: "auth.globus.org",
+		OtherTokens: map[string]*TokenInfo{
+			"ep-1": {AccessToken: "old-gcs-token-1", ResourceServer: "ep-1"},
+			"ep-2": {AccessToken: "old-gcs-token-2", ResourceServer: "ep-2"},
+		},
+		Endpoints: map[string]string{"one.example.org": "ep-1", "two.example.org": "ep-2"},
+	}
+	token := &TokenInfo{
+		AccessToken:    "auth-token",
+		ResourceServer: "auth.globus.org",
+		OtherTokens: map[string]*TokenInfo{
+			"ep-2": {AccessToken: "gcs-token-2", ResourceServer: "ep-2"},
+		},
+		Endpoints: map[string]string{"two.example.org": "ep-2"},
+	}
+
+	token.KeepEndpoints(previous)
+
+	want := map[string]string{"auth.globus.org": "auth-token", "ep-1": "old-gcs-token-1", "ep-2": "gcs-token-2"}
+	if got := token.AccessTokens(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AccessTokens() = %v, want %v", got, want)
+	}
+	if got := token.Endpoints["one.example.org"]; got != "ep-1" {
+		t.Errorf("Endpoints[one.example.org] = %q, want ep-1", got)
+	}
+}
+
+func TestEndpointScope(t *testing.T) {
+	if got, want := EndpointScope("ep-1"), "urn:globus:auth:scope:ep-1:manage_collections"; got != want {
+		t.Errorf("EndpointScope() = %q, want %q", got, want)
+	}
+}
+
+func TestRevokeToken(t *testing.T) {
+	var revoked []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		token := r.PostForm.Get("token")
+		revoked = append(revoked, token)
+		if token == "transfer-access" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	authClient, err := auth.NewClient(auth.WithClientID("cli"), auth.WithBaseURL(server.URL+"/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := &TokenInfo{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		OtherTokens: map[string]*TokenInfo{
+			"transfer.api.globus.org": {AccessToken: "transfer-access", ResourceServer: "transfer.api.globus.org"},
+		},
+	}
+
+	if err := RevokeToken(context.Background(), token, authClient); err == nil {
+		t.Error("RevokeToken() expected the transfer token's error")
+	}
+	sort.Strings(revoked)
+	if want := []string{"access", "refresh", "transfer-access"}; !reflect.DeepEqual(revoked, want) {
+		t.Errorf("revoked %v, want %v (every token, despite the failure)", revoked, want)
+	}
+}
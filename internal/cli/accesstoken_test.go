@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+)
+
+func TestPrepare_AccessTokenStdin(t *testing.T) {
+	setupConfigDir(t, "")
+	t.Setenv(AccessTokenEnv, "env-token")
+	t.Cleanup(func() { suppliedToken, suppliedFrom = "", "" })
+
+	stdin := strings.NewReader("  stdin-token\nrest of the input\n")
+	prev := accessTokenIn
+	accessTokenIn = stdin
+	t.Cleanup(func() { accessTokenIn = prev })
+
+	_, cmd := newTestTree("list")
+	cmd.Flags().Bool(AccessTokenStdinFlag, false, "")
+	if err := cmd.Flags().Set(AccessTokenStdinFlag, "true"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	token, err := LoadToken(config.DefaultProfile)
+	if err != nil {
+		t.Fatalf("LoadToken() error = %v", err)
+	}
+	if token.AccessToken != "stdin-token" || !token.IsValid() || token.CanRefresh() {
+		t.Errorf("LoadToken() = %+v, want a valid, non-refreshable stdin-token", token)
+	}
+
+	rest, _ := io.ReadAll(stdin)
+	if string(rest) != "rest of the input\n" {
+		t.Errorf("stdin after the token = %q, want the rest of the input", rest)
+	}
+
+	_, err = reauthenticate(context.Background(), "")
+	if err == nil || !strings.Contains(err.Error(), "--"+AccessTokenStdinFlag) {
+		t.Errorf("reauthenticate() error = %v, want the supplied token rejected", err)
+	}
+}
+
+func TestPrepare_AccessTokenEnv(t *testing.T) {
+	setupConfigDir(t, "")
+	t.Setenv(AccessTokenEnv, "env-token")
+	t.Cleanup(func() { suppliedToken, suppliedFrom = "", "" })
+
+	_, cmd := newTestTree("list")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	token, err := LoadToken(config.DefaultProfile)
+	if err != nil {
+		t.Fatalf("LoadToken() error = %v (nothing is stored for the profile)", err)
+	}
+	if token.AccessToken != "env-token" {
+		t.Errorf("AccessToken = %q, want env-token", token.AccessToken)
+	}
+}
+
+func TestPrepare_AccessTokenStdinEmpty(t *testing.T) {
+	setupConfigDir(t, "")
+	t.Cleanup(func() { suppliedToken, suppliedFrom = "", "" })
+
+	prev := accessTokenIn
+	accessTokenIn = strings.NewReader("\n")
+	t.Cleanup(func() { accessTokenIn = prev })
+
+	_, cmd := newTestTree("list")
+	cmd.Flags().Bool(AccessTokenStdinFlag, true, "")
+	if err := Prepare(cmd, nil); err == nil {
+		t.Error("Prepare() with an empty stdin: expected error, got nil")
+	}
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// AnnotateFlag is the root persistent flag that adds request annotations.
+const AnnotateFlag = "annotate"
+
+// AnnotationHeaderPrefix prefixes the request header of each annotation:
+// --annotate ticket=CHG12345 is sent as "X-GCS-Annotation-Ticket: CHG12345".
+const AnnotationHeaderPrefix = "X-GCS-Annotation-"
+
+// ParseAnnotations parses --annotate values of the form key=value.
+func ParseAnnotations(values []string) (map[string]string, error) {
+	annotations := map[string]string{}
+	for _, entry := range values {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --%s %q: expected key=value", AnnotateFlag, entry)
+		}
+		key = strings.TrimSpace(key)
+		if err := validateAnnotation(key, value); err != nil {
+			return nil, err
+		}
+		annotations[key] = value
+	}
+	return annotations, nil
+}
+
+// validateAnnotation checks that an annotation can be sent as a header.
+func validateAnnotation(key, value string) error {
+	if key == "" {
+		return fmt.Errorf("invalid annotation: empty key")
+	}
+	for _, r := range key {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return fmt.Errorf("invalid annotation key %q: use letters, digits, '-' and '_'", key)
+		}
+	}
+	if value == "" {
+		return fmt.Errorf("invalid annotation %q: empty value", key)
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("invalid annotation %q: value contains a line break", key)
+	}
+	return nil
+}
+
+// flagAnnotations returns the annotations given with --annotate on cmd.
+func flagAnnotations(cmd *cobra.Command) (map[string]string, error) {
+	flag := cmd.Flags().Lookup(AnnotateFlag)
+	if flag == nil || !flag.Changed {
+		return nil, nil
+	}
+
+	values, err := cmd.Flags().GetStringArray(AnnotateFlag)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAnnotations(values)
+}
+
+// effectiveAnnotations returns the annotations of the effective
+// configuration, or nil before Prepare has run.
+func effectiveAnnotations() map[string]string {
+	if effective == nil {
+		return nil
+	}
+	return effective.Annotations
+}
+
+// annotationHeader returns the request header for an annotation key.
+func annotationHeader(key string) string {
+	return AnnotationHeaderPrefix + http.CanonicalHeaderKey(strings.ReplaceAll(key, "_", "-"))
+}
+
+// sortedAnnotationKeys returns the annotation keys in sorted order.
+func sortedAnnotationKeys(annotations map[string]string) []string {
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"testing"
+)
+
+func TestParseAnnotations(t *testing.T) {
+	got, err := ParseAnnotations([]string{"ticket=CHG12345", "operator=jdoe", "note=a=b"})
+	if err != nil {
+		t.Fatalf("ParseAnnotations() error = %v", err)
+	}
+	want := map[string]string{"ticket": "CHG12345", "operator": "jdoe", "note": "a=b"}
+	if len(got) != len(want) {
+		t.Fatalf("ParseAnnotations() = %v, want %v", got, want)
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("annotation %q = %q, want %q", key, got[key], value)
+		}
+	}
+}
+
+func TestParseAnnotations_Invalid(t *testing.T) {
+	for _, value := range []string{"ticket", "=CHG1", "ticket=", "bad key=x", "ticket=a\r\nX-Evil: 1"} {
+		if _, err := ParseAnnotations([]string{value}); err == nil {
+			t.Errorf("ParseAnnotations(%q) expected error", value)
+		}
+	}
+}
+
+func TestAnnotationHeader(t *testing.T) {
+	if got := annotationHeader("change_ticket"); got != "X-GCS-Annotation-Change-Ticket" {
+		t.Errorf("annotationHeader() = %q", got)
+	}
+}
+
+func TestPrepare_Annotations(t *testing.T) {
+	setupConfigDir(t, "annotations:\n  operator: ops-team\n  ticket: CHG00001\n")
+
+	_, cmd := newTestTree("list")
+	cmd.Flags().StringArray(AnnotateFlag, nil, "")
+	if err := cmd.Flags().Set(AnnotateFlag, "ticket=CHG12345"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	got := Effective().Annotations
+	if got["ticket"] != "CHG12345" {
+		t.Errorf("ticket = %q, want flag value %q", got["ticket"], "CHG12345")
+	}
+	if got["operator"] != "ops-team" {
+		t.Errorf("operator = %q, want config value %q", got["operator"], "ops-team")
+	}
+
+	opts, err := ClientOptions()
+	if err != nil {
+		t.Fatalf("ClientOptions() error = %v", err)
+	}
+	// Timeout, user agent, retry, ETag cache, and one header per annotation
+	if len(opts) != 6 {
+		t.Errorf("ClientOptions() returned %d options, want 6", len(opts))
+	}
+}
+
+func TestPrepare_InvalidAnnotation(t *testing.T) {
+	setupConfigDir(t, "")
+
+	_, cmd := newTestTree("list")
+	cmd.Flags().StringArray(AnnotateFlag, nil, "")
+	if err := cmd.Flags().Set(AnnotateFlag, "ticket"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Prepare(cmd, nil); err == nil {
+		t.Error("Prepare() expected error for invalid annotation")
+	}
+}
@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/spf13/cobra"
+)
+
+// Developer-only root persistent flags that make GCS API requests fail or
+// slow down, to test automation built on the CLI against an unreliable
+// endpoint. They are hidden from help.
+const (
+	// InjectFaultFlag fails a fraction of requests, e.g. "503:0.1".
+	InjectFaultFlag = "inject-fault"
+
+	// InjectLatencyFlag delays every request, e.g. "2s".
+	InjectLatencyFlag = "inject-latency"
+)
+
+// faultReset is the fault that drops the connection instead of returning
+// an HTTP status.
+const faultReset = "reset"
+
+// fault is one --inject-fault: requests fail with status (or, if status
+// is 0, a connection error) with the given probability.
+type fault struct {
+	status      int
+	probability float64
+}
+
+// chaos holds the faults and latency set by Prepare.
+var chaos struct {
+	faults  []fault
+	latency time.Duration
+}
+
+// chaosRand returns a number in [0, 1) to decide whether a fault is
+// injected. It is a test hook.
+var chaosRand = rand.Float64
+
+// parseFault parses an --inject-fault value: an HTTP status from 400 to
+// 599, or "reset", optionally followed by ":" and the probability of the
+// fault (default 1).
+func parseFault(value string) (fault, error) {
+	kind, prob, hasProb := strings.Cut(value, ":")
+
+	var f fault
+	if kind != faultReset {
+		status, err := strconv.Atoi(kind)
+		if err != nil || status < 400 || status > 599 {
+			return fault{}, fmt.Errorf("invalid --%s %q: expected an HTTP status from 400 to 599 or %q, e.g. 503:0.1", InjectFaultFlag, value, faultReset)
+		}
+		f.status = status
+	}
+
+	f.probability = 1
+	if hasProb {
+		p, err := strconv.ParseFloat(prob, 64)
+		if err != nil || p <= 0 || p > 1 {
+			return fault{}, fmt.Errorf("invalid --%s %q: probability must be greater than 0 and at most 1", InjectFaultFlag, value)
+		}
+		f.probability = p
+	}
+	return f, nil
+}
+
+// applyChaos sets the faults and latency from --inject-fault and
+// --inject-latency, and warns that they are active.
+func applyChaos(cmd *cobra.Command) error {
+	chaos.faults, chaos.latency = nil, 0
+
+	if flag := cmd.Flags().Lookup(InjectFaultFlag); flag != nil {
+		values, _ := cmd.Flags().GetStringArray(InjectFaultFlag)
+		for _, value := range values {
+			f, err := parseFault(value)
+			if err != nil {
+				return err
+			}
+			chaos.faults = append(chaos.faults, f)
+		}
+	}
+	if flag := cmd.Flags().Lookup(InjectLatencyFlag); flag != nil {
+		latency, _ := cmd.Flags().GetDuration(InjectLatencyFlag)
+		if latency < 0 {
+			return fmt.Errorf("invalid --%s %s: must not be negative", InjectLatencyFlag, latency)
+		}
+		chaos.latency = latency
+	}
+
+	if len(chaos.faults) > 0 || chaos.latency > 0 {
+		Warnf("injecting faults into GCS API requests (--%s, --%s)", InjectFaultFlag, InjectLatencyFlag)
+	}
+	return nil
+}
+
+// chaosOptions returns the client option that injects the configured
+// faults and latency, if any.
+func chaosOptions() []gcs.ClientOption {
+	if len(chaos.faults) == 0 && chaos.latency == 0 {
+		return nil
+	}
+	faults, latency := chaos.faults, chaos.latency
+	return []gcs.ClientOption{gcs.WithTransportWrapper(func(next http.RoundTripper) http.RoundTripper {
+		return &faultTransport{next: next, faults: faults, latency: latency}
+	})}
+}
+
+// faultTransport is an http.RoundTripper that delays requests and fails
+// some of them before they are sent.
+type faultTransport struct {
+	next    http.RoundTripper
+	faults  []fault
+	latency time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.latency > 0 {
+		timer := time.NewTimer(t.latency)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			closeRequestBody(req)
+			return nil, req.Context().Err()
+		}
+	}
+
+	for _, f := range t.faults {
+		if chaosRand() >= f.probability {
+			continue
+		}
+		closeRequestBody(req)
+		if f.status == 0 {
+			return nil, errors.New("connection reset by peer (injected by --" + InjectFaultFlag + ")")
+		}
+		return faultResponse(req, f.status), nil
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// faultResponse returns a GCS-style error response with status.
+func faultResponse(req *http.Request, status int) *http.Response {
+	body := fmt.Sprintf(`{"DATA_TYPE":"result#1.0.0","code":"InjectedFault","http_response_code":%d,"detail":"Fault injected by --%s"}`, status, InjectFaultFlag)
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	if status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable {
+		header.Set("Retry-After", "1")
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// closeRequestBody closes the body of a request that is not sent, as
+// http.RoundTripper requires.
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
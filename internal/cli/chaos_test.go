@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFault(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    fault
+		wantErr bool
+	}{
+		{value: "503:0.1", want: fault{status: 503, probability: 0.1}},
+		{value: "429", want: fault{status: 429, probability: 1}},
+		{value: "reset:0.5", want: fault{probability: 0.5}},
+		{value: "200:0.1", wantErr: true},
+		{value: "oops", wantErr: true},
+		{value: "503:0", wantErr: true},
+		{value: "503:1.5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseFault(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFault() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseFault() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFaultTransport(t *testing.T) {
+	var sent int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		sent++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	rolls := []float64{0.05, 0.5}
+	oldRand := chaosRand
+	t.Cleanup(func() { chaosRand = oldRand })
+	chaosRand = func() float64 {
+		r := rolls[0]
+		rolls = rolls[1:]
+		return r
+	}
+
+	transport := &faultTransport{next: http.DefaultTransport, faults: []fault{{status: 503, probability: 0.1}}}
+	client := &http.Client{Transport: transport}
+
+	// First roll is below the probability: the fault is injected
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || resp.Header.Get("Retry-After") == "" {
+		t.Errorf("status = %d, Retry-After = %q; want 503 with Retry-After", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+	if !strings.Contains(string(body), "InjectedFault") {
+		t.Errorf("body = %s", body)
+	}
+	if sent != 0 {
+		t.Errorf("server received %d requests, want 0", sent)
+	}
+
+	// Second roll is above it: the request goes through
+	resp, err = client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || sent != 1 {
+		t.Errorf("status = %d, sent = %d; want 200, 1", resp.StatusCode, sent)
+	}
+}
+
+func TestFaultTransport_ResetAndLatency(t *testing.T) {
+	oldRand := chaosRand
+	t.Cleanup(func() { chaosRand = oldRand })
+	chaosRand = func() float64 { return 0 }
+
+	transport := &faultTransport{next: http.DefaultTransport, faults: []fault{{probability: 1}}, latency: 20 * time.Millisecond}
+	req, _ := http.NewRequest(http.MethodGet, "https://unused.example.org/api/info", nil)
+
+	start := time.Now()
+	_, err := transport.RoundTrip(req)
+	if err == nil || !strings.Contains(err.Error(), "connection reset") {
+		t.Errorf("RoundTrip() error = %v, want injected connection reset", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("RoundTrip() took %v, want at least the injected latency", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	transport.latency = time.Hour
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, "https://unused.example.org/api/info", nil)
+	if _, err := transport.RoundTrip(req); err != context.Canceled {
+		t.Errorf("RoundTrip() with cancelled context error = %v, want context.Canceled", err)
+	}
+}
+
+func TestPrepare_Chaos(t *testing.T) {
+	setupConfigDir(t, "")
+	t.Cleanup(func() { chaos.faults, chaos.latency = nil, 0 })
+
+	_, cmd := newTestTree("list")
+	cmd.Flags().StringArray(InjectFaultFlag, nil, "")
+	cmd.Flags().Duration(InjectLatencyFlag, 0, "")
+	if err := cmd.Flags().Set(InjectFaultFlag, "503:0.1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Flags().Set(InjectLatencyFlag, "2s"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if len(chaos.faults) != 1 || chaos.latency != 2*time.Second {
+		t.Errorf("chaos = %+v, want one fault and 2s latency", chaos)
+	}
+	if len(chaosOptions()) != 1 {
+		t.Error("chaosOptions() returned no client option")
+	}
+
+	if err := cmd.Flags().Set(InjectFaultFlag, "bogus"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Prepare(cmd, nil); err == nil {
+		t.Error("Prepare() accepted an invalid --inject-fault")
+	}
+}
@@ -0,0 +1,404 @@
+// Package cli provides shared plumbing for the GCS CLI commands.
+//
+// The root command calls Prepare before any subcommand runs. Prepare
+// resolves the effective configuration (flags, environment, config file,
+// defaults), fills in any per-command --profile, --endpoint, and --format
+// flags the user did not set, and records the result so that commands can
+// build GCS clients consistently via NewGCSClient and load tokens via
+// LoadToken. It also runs the site-configured command hooks and starts
+// the pager for long output (see ClosePager).
+package cli
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/spf13/cobra"
+)
+
+// resolvedFlags are the per-command flags whose defaults come from the
+// effective configuration.
+var resolvedFlags = []string{config.KeyProfile, config.KeyEndpoint, config.KeyFormat}
+
+// effective holds the configuration resolved for the running command.
+var effective = config.Resolve(nil, nil)
+
+// Prepare resolves the effective configuration for cmd and applies it.
+//
+// It is intended to be used as the root command's PersistentPreRunE.
+// Flags explicitly given on the command line always win; unset flags are
+// populated from the environment or config.yaml so that, for example, a
+// default endpoint in config.yaml satisfies a required --endpoint flag.
+//
+// Prepare first refuses commands forbidden by the site restriction file
+// (see RestrictionsFile). It then runs any pre-hooks configured for the
+// command; a failing pre-hook aborts the command. Before the pre-hooks,
+// medium- and high-risk commands are confirmed (see ClassifyRisk). Every invocation,
+// including refused ones, is noted for RecordHistory.
+func Prepare(cmd *cobra.Command, args []string) error {
+	startHistory(cmd, args)
+	recordOutputFormat(cmd)
+
+	if err := enforceRestrictions(cmd); err != nil {
+		return err
+	}
+
+	eff, err := Resolve(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Before the format default is applied, so --template and --query
+	// win over a format from config.yaml
+	if err := applyTemplate(cmd); err != nil {
+		return err
+	}
+	if err := applyQuery(cmd); err != nil {
+		return err
+	}
+
+	for _, name := range resolvedFlags {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || flag.Changed {
+			continue
+		}
+
+		// Commands such as 'audit dump' use --format to select an export
+		// format (json, csv) rather than the output format; leave those alone.
+		if name == config.KeyFormat && flag.DefValue != config.DefaultFormat {
+			continue
+		}
+
+		setting, _ := eff.Lookup(name)
+		if setting.Source == config.SourceDefault || setting.Value == "" {
+			continue
+		}
+		if err := cmd.Flags().Set(name, setting.Value); err != nil {
+			return fmt.Errorf("apply %s from %s: %w", name, setting.Origin, err)
+		}
+	}
+
+	// Again, now that the format may come from config.yaml
+	recordOutputFormat(cmd)
+
+	if err := ValidateHooks(eff.Hooks); err != nil {
+		return fmt.Errorf("%w (in %s)", err, eff.ConfigFile)
+	}
+
+	applyPager(cmd)
+	if err := applyOutputStyle(cmd, eff); err != nil {
+		return err
+	}
+	applyRawNumbers(cmd)
+	applyNoColor(cmd)
+	if err := applyTokenEncryption(eff); err != nil {
+		return err
+	}
+	if err := applySecretStore(eff); err != nil {
+		return err
+	}
+	applyDebug(cmd)
+	applyDryRun(cmd)
+	applyTracing()
+	applyInsecureTLS(cmd)
+	if err := applyChaos(cmd); err != nil {
+		return err
+	}
+	if err := applyAccessToken(cmd); err != nil {
+		return err
+	}
+
+	effective = eff
+	currentCommand = CommandPath(cmd)
+
+	if err := confirmRisk(cmd, args); err != nil {
+		return err
+	}
+	return runPreHooks(cmd, args)
+}
+
+// Resolve computes the effective configuration for cmd without applying it.
+func Resolve(cmd *cobra.Command) (*config.Effective, error) {
+	file, err := config.LoadFileConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	flags := map[string]string{}
+	for _, name := range resolvedFlags {
+		flag := cmd.Flags().Lookup(name)
+		if flag != nil && flag.Changed {
+			flags[name] = flag.Value.String()
+		}
+	}
+	if flag := cmd.Flags().Lookup(OutputStyleFlag); flag != nil && flag.Changed {
+		flags[config.KeyOutputStyle] = flag.Value.String()
+	}
+	if flag := cmd.Flags().Lookup(RateLimitFlag); flag != nil && flag.Changed {
+		flags[config.KeyRateLimit] = flag.Value.String()
+	}
+	if flag := cmd.Flags().Lookup(ClientCertFlag); flag != nil && flag.Changed {
+		flags[config.KeyClientCert] = flag.Value.String()
+	}
+	if flag := cmd.Flags().Lookup(ClientKeyFlag); flag != nil && flag.Changed {
+		flags[config.KeyClientKey] = flag.Value.String()
+	}
+	if flag := cmd.Flags().Lookup(ProxyFlag); flag != nil && flag.Changed {
+		flags[config.KeyProxy] = flag.Value.String()
+	}
+	if flag := cmd.Flags().Lookup(CABundleFlag); flag != nil && flag.Changed {
+		flags[config.KeyCABundle] = flag.Value.String()
+	}
+	if flag := cmd.Flags().Lookup(UserAgentSuffixFlag); flag != nil && flag.Changed {
+		flags[config.KeyUserAgentSuffix] = flag.Value.String()
+	}
+	// Read from the root because commands such as 'precheck' have a
+	// --timeout of their own that shadows it
+	if flag := cmd.Root().PersistentFlags().Lookup(TimeoutFlag); flag != nil && flag.Changed {
+		flags[config.KeyTimeout] = flag.Value.String()
+	}
+
+	eff := config.Resolve(flags, file)
+	for _, key := range sortedAnnotationKeys(eff.Annotations) {
+		if err := validateAnnotation(key, eff.Annotations[key]); err != nil {
+			return nil, fmt.Errorf("config file: %w", err)
+		}
+	}
+
+	annotations, err := flagAnnotations(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if len(annotations) > 0 && eff.Annotations == nil {
+		eff.Annotations = map[string]string{}
+	}
+	for key, value := range annotations {
+		eff.Annotations[key] = value
+	}
+
+	if path, err := config.GetConfigFilePath(); err == nil {
+		eff.ConfigFile = path
+		eff.ConfigFileFound = fileExists(path)
+	}
+
+	return eff, nil
+}
+
+// Effective returns the configuration resolved by the last call to Prepare.
+func Effective() *config.Effective {
+	return effective
+}
+
+// TimeoutFlag is the root persistent flag that sets the timeout of each
+// GCS Manager API request.
+const TimeoutFlag = "timeout"
+
+// Timeout returns the effective HTTP timeout. Zero means none.
+func Timeout() (time.Duration, error) {
+	setting, _ := effective.Lookup(config.KeyTimeout)
+	timeout, err := time.ParseDuration(setting.Value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q (from %s): %w", setting.Value, setting.Source, err)
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("invalid timeout %q (from %s): must not be negative", setting.Value, setting.Source)
+	}
+	return timeout, nil
+}
+
+// RateLimitFlag is the root persistent flag that caps GCS Manager API
+// requests per second.
+const RateLimitFlag = "rate-limit"
+
+// sharedLimiter paces the requests of every GCS client of the process,
+// so that commands using several clients stay within one limit. It is
+// created for the rate in sharedRate.
+var (
+	sharedLimiter *gcs.RateLimiter
+	sharedRate    float64
+)
+
+// rateLimiter returns the limiter for the effective rate_limit setting,
+// or nil if requests are not limited.
+func rateLimiter() (*gcs.RateLimiter, error) {
+	setting, _ := effective.Lookup(config.KeyRateLimit)
+	if setting.Value == "" {
+		return nil, nil
+	}
+	rate, err := strconv.ParseFloat(setting.Value, 64)
+	if err != nil || rate < 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+		return nil, fmt.Errorf("invalid rate limit %q (from %s): expected requests per second, 0 for no limit", setting.Value, setting.Origin)
+	}
+	if rate == 0 {
+		return nil, nil
+	}
+	if sharedLimiter == nil || sharedRate != rate {
+		sharedLimiter, sharedRate = gcs.NewRateLimiter(rate, 0), rate
+	}
+	return sharedLimiter, nil
+}
+
+// UserAgent identifies the CLI in GCS Manager API requests. main sets it
+// to include the build version.
+var UserAgent = "globus-connect-server/dev"
+
+// UserAgentSuffixFlag is the root persistent flag that is appended to the
+// User-Agent of GCS Manager API requests.
+const UserAgentSuffixFlag = "user-agent-suffix"
+
+// userAgent returns UserAgent followed by the effective
+// user_agent_suffix setting.
+func userAgent() (string, error) {
+	setting, _ := effective.Lookup(config.KeyUserAgentSuffix)
+	suffix := strings.TrimSpace(setting.Value)
+	if suffix == "" {
+		return UserAgent, nil
+	}
+	for _, r := range suffix {
+		if r < ' ' || r > '~' {
+			return "", fmt.Errorf("invalid user agent suffix %q (from %s): only printable ASCII is allowed", setting.Value, setting.Origin)
+		}
+	}
+	return UserAgent + " " + suffix, nil
+}
+
+// ClientOptions returns the gcs.ClientOptions implied by the effective
+// configuration.
+func ClientOptions() ([]gcs.ClientOption, error) {
+	timeout, err := Timeout()
+	if err != nil {
+		return nil, err
+	}
+
+	userAgent, err := userAgent()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []gcs.ClientOption{
+		gcs.WithTimeout(timeout),
+		gcs.WithUserAgent(userAgent),
+		gcs.WithRetry(gcs.DefaultRetryPolicy()),
+		// One cache per client, as clients may hold different tokens
+		gcs.WithETagCache(gcs.NewETagCache(0)),
+	}
+	if limiter, err := rateLimiter(); err != nil {
+		return nil, err
+	} else if limiter != nil {
+		opts = append(opts, gcs.WithRateLimiter(limiter))
+	}
+	tlsOpts, err := tlsOptions()
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, tlsOpts...)
+	proxyOpts, err := proxyOptions()
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, proxyOpts...)
+	for _, key := range sortedAnnotationKeys(effective.Annotations) {
+		opts = append(opts, gcs.WithHeader(annotationHeader(key), effective.Annotations[key]))
+	}
+	if debugLogger != nil {
+		opts = append(opts, gcs.WithLogger(debugLogger))
+	}
+	if timingOn {
+		opts = append(opts, gcs.WithTiming(printTiming))
+	}
+	opts = append(opts, tracingOptions()...)
+	opts = append(opts, dryRunOptions()...)
+	opts = append(opts, chaosOptions()...)
+	return opts, nil
+}
+
+// Circuit breaker settings: after breakerThreshold consecutive failed
+// requests to an endpoint, further requests fail at once for
+// breakerCooldown.
+const (
+	breakerThreshold = 5
+	breakerCooldown  = 30 * time.Second
+)
+
+// breakers holds the circuit breaker of each endpoint, shared by all
+// clients of the process so that bulk commands stop together.
+var (
+	breakersMu sync.Mutex
+	breakers   = map[string]*gcs.CircuitBreaker{}
+)
+
+// circuitBreaker returns the shared circuit breaker for endpointFQDN.
+func circuitBreaker(endpointFQDN string) *gcs.CircuitBreaker {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+	breaker, ok := breakers[endpointFQDN]
+	if !ok {
+		breaker = gcs.NewCircuitBreaker(breakerThreshold, breakerCooldown)
+		breakers[endpointFQDN] = breaker
+	}
+	return breaker
+}
+
+// NewGCSClient creates a GCS Manager API client for endpointFQDN using the
+// given access token and the options from the effective configuration.
+// If accessToken came from LoadToken and the login also issued a token
+// for the endpoint's own resource server, the client uses that one, from
+// the first request if the login was for endpointFQDN (login --endpoint).
+// If the server rejects the token, the token last returned by LoadToken
+// is refreshed (or, on a terminal, replaced by logging in again) and the
+// request is retried.
+//
+// Commands depend on the gcs.GCSAPI it returns rather than *gcs.Client, so
+// that their tests can substitute a gcstest.Client via GCSClientFactory.
+func NewGCSClient(endpointFQDN, accessToken string) (gcs.GCSAPI, error) {
+	return GCSClientFactory(endpointFQDN, accessToken)
+}
+
+// GCSClientFactory creates the clients returned by NewGCSClient. It is a
+// test hook: command tests set it to return a *gcstest.Client.
+var GCSClientFactory = newGCSClient
+
+// newGCSClient creates a *gcs.Client as described for NewGCSClient.
+func newGCSClient(endpointFQDN, accessToken string) (gcs.GCSAPI, error) {
+	opts, err := ClientOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	var client *gcs.Client
+	refresh := func(ctx context.Context) (string, error) {
+		return reauthenticate(ctx, client.ResourceServer())
+	}
+	opts = append(opts, gcs.WithCircuitBreaker(circuitBreaker(endpointFQDN)))
+	opts = append(opts, gcs.WithAccessToken(accessToken), gcs.WithTokenRefresher(refresh))
+	if slices.Contains(slices.Collect(maps.Values(loadedToken.accessTokens)), accessToken) {
+		opts = append(opts, gcs.WithResourceServerTokens(loadedToken.accessTokens))
+		opts = append(opts, gcs.WithTokenProvider(func(ctx context.Context) (string, error) {
+			return liveAccessToken(ctx, client.ResourceServer()), nil
+		}))
+		if endpointID, ok := loadedToken.endpoints[endpointFQDN]; ok {
+			opts = append(opts, gcs.WithResourceServer(endpointID))
+		}
+	}
+
+	if client, err = gcs.NewClient(endpointFQDN, opts...); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// fileExists reports whether path exists.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
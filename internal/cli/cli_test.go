@@ -0,0 +1,435 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/spf13/cobra"
+)
+
+// newTestTree builds a root command with one subcommand that has the
+// standard --profile, --format, and --endpoint flags.
+func newTestTree(use string) (*cobra.Command, *cobra.Command) {
+	root := &cobra.Command{Use: "globus-connect-server"}
+	group := &cobra.Command{Use: "collection"}
+	cmd := &cobra.Command{Use: use, RunE: func(*cobra.Command, []string) error { return nil }}
+	cmd.Flags().StringP("profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringP("format", "f", "text", "Output format")
+	cmd.Flags().String("endpoint", "", "Endpoint FQDN")
+	group.AddCommand(cmd)
+	root.AddCommand(group)
+	return root, cmd
+}
+
+func setupConfigDir(t *testing.T, contents string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR", dir)
+	t.Setenv(config.EnvProfile, "")
+	t.Setenv(config.EnvEndpoint, "")
+	t.Setenv(config.EnvFormat, "")
+	t.Setenv(config.EnvTimeout, "")
+	t.Setenv(config.EnvEnvironment, "")
+
+	if contents != "" {
+		if err := os.WriteFile(filepath.Join(dir, config.ConfigFileName), []byte(contents), 0600); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestPrepare_AppliesConfig(t *testing.T) {
+	setupConfigDir(t, "endpoint: cfg.example.org\nformat: json\n")
+
+	_, cmd := newTestTree("list")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	if got := cmd.Flags().Lookup("endpoint").Value.String(); got != "cfg.example.org" {
+		t.Errorf("endpoint = %q, want %q", got, "cfg.example.org")
+	}
+	if got := cmd.Flags().Lookup("format").Value.String(); got != "json" {
+		t.Errorf("format = %q, want %q", got, "json")
+	}
+	if got := CurrentCommand(); got != "collection list" {
+		t.Errorf("CurrentCommand() = %q, want %q", got, "collection list")
+	}
+}
+
+func TestPrepare_FlagWins(t *testing.T) {
+	setupConfigDir(t, "endpoint: cfg.example.org\n")
+
+	_, cmd := newTestTree("list")
+	if err := cmd.Flags().Set("endpoint", "flag.example.org"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	if got := cmd.Flags().Lookup("endpoint").Value.String(); got != "flag.example.org" {
+		t.Errorf("endpoint = %q, want %q", got, "flag.example.org")
+	}
+	if got := Effective().Get(config.KeyEndpoint); got != "flag.example.org" {
+		t.Errorf("Effective() endpoint = %q, want %q", got, "flag.example.org")
+	}
+}
+
+func TestPrepare_ProfileEnvironment(t *testing.T) {
+	setupConfigDir(t, "profiles:\n  preview:\n    environment: preview\n  typo:\n    environment: prevew\n")
+
+	_, cmd := newTestTree("list")
+	if err := cmd.Flags().Set("profile", "preview"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	env, err := GlobusEnvironment()
+	if err != nil {
+		t.Fatalf("GlobusEnvironment() error = %v", err)
+	}
+	if env.AuthURL != "https://auth.preview.globus.org/v2/" {
+		t.Errorf("AuthURL = %q, want preview", env.AuthURL)
+	}
+
+	_, cmd = newTestTree("list")
+	if err := cmd.Flags().Set("profile", "typo"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if _, err := GlobusEnvironment(); err == nil || !strings.Contains(err.Error(), "profiles.typo.environment") {
+		t.Errorf("GlobusEnvironment() error = %v, want origin of the bad value", err)
+	}
+}
+
+func TestPrepare_SkipsExportFormat(t *testing.T) {
+	setupConfigDir(t, "format: json\n")
+
+	cmd := &cobra.Command{Use: "dump"}
+	cmd.Flags().String("format", "csv", "Export format")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	if got := cmd.Flags().Lookup("format").Value.String(); got != "csv" {
+		t.Errorf("format = %q, want %q", got, "csv")
+	}
+}
+
+func TestIsReadOnly(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"collection list", true},
+		{"endpoint show", true},
+		{"audit metrics", true},
+		{"collection permission list", true},
+		{"storage-gateway check", true},
+		{"endpoint limits", true},
+		{"endpoint features", true},
+		{"collection delete", false},
+		{"endpoint update", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsReadOnly(tt.path); got != tt.want {
+			t.Errorf("IsReadOnly(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestLoadToken_Reporter(t *testing.T) {
+	dir := setupConfigDir(t, "")
+
+	tokensDir := filepath.Join(dir, "tokens")
+	if err := os.MkdirAll(tokensDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	reporter := `{"access_token":"reporter-token","expires_at":"2099-01-01T00:00:00Z"}`
+	if err := os.WriteFile(filepath.Join(tokensDir, "default.reporter.json"), []byte(reporter), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(prev string) { currentCommand = prev }(currentCommand)
+
+	// Read-only commands use the reporter token.
+	currentCommand = "collection list"
+	token, err := LoadToken(config.DefaultProfile)
+	if err != nil {
+		t.Fatalf("LoadToken() error = %v", err)
+	}
+	if token.AccessToken != "reporter-token" {
+		t.Errorf("AccessToken = %q, want %q", token.AccessToken, "reporter-token")
+	}
+
+	// Mutating commands never fall back to the reporter token.
+	currentCommand = "collection delete"
+	if _, err := LoadToken(config.DefaultProfile); err == nil {
+		t.Error("LoadToken() expected error for mutating command without a regular token, got nil")
+	}
+}
+
+func TestClientOptions_RateLimit(t *testing.T) {
+	setupConfigDir(t, "rate_limit: fast\n")
+	t.Setenv(config.EnvRateLimit, "")
+
+	_, cmd := newTestTree("list")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if _, err := ClientOptions(); err == nil || !strings.Contains(err.Error(), "invalid rate limit") {
+		t.Errorf("ClientOptions() error = %v, want invalid rate limit", err)
+	}
+
+	t.Setenv(config.EnvRateLimit, "2.5")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	first, err := rateLimiter()
+	if err != nil || first == nil {
+		t.Fatalf("rateLimiter() = %v, %v, want a limiter", first, err)
+	}
+	if second, _ := rateLimiter(); second != first {
+		t.Error("rateLimiter() returned a new limiter; clients must share one")
+	}
+}
+
+func TestClientOptions_ClientCert(t *testing.T) {
+	setupConfigDir(t, "client_key: /etc/gcs/client.key\n")
+	t.Setenv(config.EnvClientCert, "")
+	t.Setenv(config.EnvClientKey, "")
+
+	_, cmd := newTestTree("list")
+	cmd.Flags().String(ClientCertFlag, "", "")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if _, err := ClientOptions(); err == nil || !strings.Contains(err.Error(), "without a client certificate") {
+		t.Errorf("ClientOptions() error = %v, want a key without certificate error", err)
+	}
+
+	missing := filepath.Join(t.TempDir(), "client.pem")
+	if err := cmd.Flags().Set(ClientCertFlag, missing); err != nil {
+		t.Fatal(err)
+	}
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if got := Effective().Get(config.KeyClientCert); got != missing {
+		t.Errorf("client_cert = %q, want the flag value", got)
+	}
+	if _, err := NewGCSClient("example.org", "token"); err == nil || !strings.Contains(err.Error(), "load client certificate") {
+		t.Errorf("NewGCSClient() error = %v, want a load error", err)
+	}
+}
+
+func TestClientOptions_Proxy(t *testing.T) {
+	setupConfigDir(t, "proxy: ftp://proxy.example.org\nprofiles:\n  campus:\n    proxy: socks5://127.0.0.1:1080\n")
+	t.Setenv(config.EnvProxy, "")
+	t.Setenv(config.EnvProfile, "")
+
+	_, cmd := newTestTree("list")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if _, err := ClientOptions(); err == nil || !strings.Contains(err.Error(), "(from proxy)") {
+		t.Errorf("ClientOptions() error = %v, want an invalid proxy error naming its origin", err)
+	}
+
+	t.Setenv(config.EnvProfile, "campus")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if got := Effective().Get(config.KeyProxy); got != "socks5://127.0.0.1:1080" {
+		t.Errorf("proxy = %q, want the campus profile's", got)
+	}
+	if _, err := ClientOptions(); err != nil {
+		t.Errorf("ClientOptions() error = %v", err)
+	}
+}
+
+func TestClientOptions_TLSAndUserAgent(t *testing.T) {
+	var userAgents []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+		_, _ = w.Write([]byte(`{"api_version": "1.30.0"}`))
+	}))
+	defer server.Close()
+	fqdn := strings.TrimPrefix(server.URL, "https://")
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundle, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	setupConfigDir(t, "user_agent_suffix: nightly-sync/2\n")
+	t.Setenv(config.EnvCABundle, "")
+	t.Setenv(config.EnvUserAgentSuffix, "")
+	var warnings bytes.Buffer
+	warnOut = &warnings
+	t.Cleanup(func() { warnOut = os.Stderr; insecureTLS = false })
+
+	_, cmd := newTestTree("list")
+	cmd.Flags().String(CABundleFlag, "", "")
+	cmd.Flags().Bool(InsecureSkipTLSVerifyFlag, false, "")
+
+	getInfo := func() error {
+		t.Helper()
+		if err := Prepare(cmd, nil); err != nil {
+			t.Fatalf("Prepare() error = %v", err)
+		}
+		client, err := NewGCSClient(fqdn, "token")
+		if err != nil {
+			return err
+		}
+		_, err = client.GetInfo(context.Background())
+		return err
+	}
+
+	if err := getInfo(); err == nil {
+		t.Error("GetInfo() trusted an unknown CA")
+	}
+
+	if err := cmd.Flags().Set(CABundleFlag, bundle); err != nil {
+		t.Fatal(err)
+	}
+	if err := getInfo(); err != nil {
+		t.Errorf("GetInfo() with --ca-bundle error = %v", err)
+	}
+	if want := UserAgent + " nightly-sync/2"; len(userAgents) == 0 || userAgents[0] != want {
+		t.Errorf("User-Agent = %q, want %q", userAgents, want)
+	}
+
+	if err := cmd.Flags().Set(CABundleFlag, filepath.Join(t.TempDir(), "missing.pem")); err != nil {
+		t.Fatal(err)
+	}
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if _, err := ClientOptions(); err == nil {
+		t.Error("ClientOptions() error = nil, want an error for a missing CA bundle")
+	}
+	if err := cmd.Flags().Set(InsecureSkipTLSVerifyFlag, "true"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Flags().Set(CABundleFlag, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := getInfo(); err != nil {
+		t.Errorf("GetInfo() with --insecure-skip-tls-verify error = %v", err)
+	}
+	if !strings.Contains(warnings.String(), "TLS certificate verification is DISABLED") {
+		t.Errorf("warnings = %q, want the insecure TLS warning", warnings.String())
+	}
+}
+
+func TestUserAgent_Invalid(t *testing.T) {
+	setupConfigDir(t, "")
+	t.Setenv(config.EnvUserAgentSuffix, "bad\r\nX-Injected: 1")
+
+	_, cmd := newTestTree("list")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if _, err := ClientOptions(); err == nil || !strings.Contains(err.Error(), "invalid user agent suffix") {
+		t.Errorf("ClientOptions() error = %v, want an invalid suffix error", err)
+	}
+}
+
+func TestClientOptions_Debug(t *testing.T) {
+	setupConfigDir(t, "")
+	var buf bytes.Buffer
+	debugOut = &buf
+	t.Cleanup(func() { debugOut = os.Stderr; debugLogger = nil })
+
+	_, cmd := newTestTree("list")
+	cmd.Flags().Bool(DebugFlag, false, "")
+	cmd.Flags().Bool(TraceFlag, false, "")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if debugLogger != nil {
+		t.Fatal("debugLogger set without --debug")
+	}
+
+	_ = cmd.Flags().Set(TraceFlag, "true")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if debugLogger == nil || !debugLogger.Enabled(context.Background(), gcs.LevelTrace) {
+		t.Fatal("--trace did not enable trace logging")
+	}
+	debugLogger.Log(context.Background(), gcs.LevelTrace, "x")
+	if !strings.Contains(buf.String(), "level=TRACE") {
+		t.Errorf("log = %q, want level=TRACE", buf.String())
+	}
+}
+
+func TestPrintTiming(t *testing.T) {
+	var buf bytes.Buffer
+	debugOut = &buf
+	t.Cleanup(func() { debugOut = os.Stderr })
+
+	printTiming(gcs.RequestTiming{
+		Method: "GET", Path: "/api/info", Status: 200,
+		DNSLookup: 2400 * time.Microsecond, Connect: 21 * time.Millisecond,
+		TLSHandshake: 45 * time.Millisecond, TimeToFirstByte: 120 * time.Millisecond,
+		Total: 121 * time.Millisecond,
+	})
+	printTiming(gcs.RequestTiming{Method: "GET", Path: "/api/info", ConnectionReused: true, Total: 500 * time.Microsecond})
+
+	want := "GET /api/info 200 total=121ms dns=2ms connect=21ms tls=45ms ttfb=120ms\n" +
+		"GET /api/info failed total=500µs dns=0s connect=0s tls=0s ttfb=0s reused\n"
+	if buf.String() != want {
+		t.Errorf("printTiming() wrote %q, want %q", buf.String(), want)
+	}
+}
+
+func TestTimeout_Flag(t *testing.T) {
+	setupConfigDir(t, "timeout: 45s\n")
+	t.Setenv(config.EnvTimeout, "")
+
+	root, cmd := newTestTree("list")
+	root.PersistentFlags().String(TimeoutFlag, "", "")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if timeout, err := Timeout(); err != nil || timeout != 45*time.Second {
+		t.Errorf("Timeout() = %v, %v, want 45s from config.yaml", timeout, err)
+	}
+
+	_ = root.PersistentFlags().Set(TimeoutFlag, "2h")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if timeout, err := Timeout(); err != nil || timeout != 2*time.Hour {
+		t.Errorf("Timeout() = %v, %v, want 2h from --timeout", timeout, err)
+	}
+
+	_ = root.PersistentFlags().Set(TimeoutFlag, "-1s")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if _, err := Timeout(); err == nil {
+		t.Error("Timeout() error = nil for a negative timeout")
+	}
+}
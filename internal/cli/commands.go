@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// readOnlyCommands lists the commands that never modify endpoint state,
+// keyed by command path without the root command name.
+var readOnlyCommands = map[string]bool{
+	"audit dump":                 true,
+	"audit metrics":              true,
+	"audit query":                true,
+	"auth status":                true,
+	"auth token export":          true,
+	"auth-policy list":           true,
+	"auth-policy show":           true,
+	"collection check":           true,
+	"collection diff":            true,
+	"collection domain show":     true,
+	"collection export":          true,
+	"collection list":            true,
+	"collection permission list": true,
+	"collection show":            true,
+	"config effective":           true,
+	"endpoint domain show":       true,
+	"endpoint features":          true,
+	"endpoint limits":            true,
+	"endpoint show":              true,
+	"endpoint banner show":       true,
+	"examples":                   true,
+	"history":                    true,
+	"node list":                  true,
+	"node show":                  true,
+	"oidc show":                  true,
+	"role list":                  true,
+	"role show":                  true,
+	"session show":               true,
+	"sharing-policy list":        true,
+	"sharing-policy show":        true,
+	"storage-gateway check":      true,
+	"storage-gateway list":       true,
+	"storage-gateway show":       true,
+	"support bundle":             true,
+	"trash list":                 true,
+	"user-credential list":       true,
+	"user-credential show":       true,
+	"whoami":                     true,
+}
+
+// currentCommand is the path of the command being executed, as recorded
+// by Prepare.
+var currentCommand string
+
+// CommandPath returns the path of cmd without the root command name
+// (e.g., "collection list").
+func CommandPath(cmd *cobra.Command) string {
+	path := cmd.CommandPath()
+	if root := cmd.Root(); root != nil && root != cmd {
+		path = strings.TrimPrefix(path, root.Name()+" ")
+	}
+	return path
+}
+
+// IsReadOnly reports whether the command at path never modifies state.
+func IsReadOnly(path string) bool {
+	return readOnlyCommands[path]
+}
+
+// CurrentCommand returns the path of the command being executed, or ""
+// if Prepare has not run.
+func CurrentCommand() string {
+	return currentCommand
+}
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/i18n"
+)
+
+// Interactive reports whether the user can be prompted.
+func Interactive() bool {
+	return interactive()
+}
+
+// Confirm asks a yes/no question, which should end in "[y/N]: ", and
+// reports whether the user answered yes. It returns false without asking
+// if the user cannot be prompted.
+func Confirm(question string) bool {
+	if !interactive() {
+		return false
+	}
+	fmt.Fprint(promptOut, question)
+	answer, _ := bufio.NewReader(promptIn).ReadString('\n')
+	return i18n.IsYes(answer)
+}
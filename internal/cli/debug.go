@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/spf13/cobra"
+)
+
+// Flags that log GCS API requests.
+const (
+	DebugFlag  = "debug"
+	TraceFlag  = "trace"
+	TimingFlag = "timing"
+)
+
+// debugOut receives the request log of --debug and --trace, and the
+// timings of --timing. It is a test hook.
+var debugOut io.Writer = os.Stderr
+
+// debugLogger logs GCS API requests, or is nil if neither --debug nor
+// --trace is set.
+var debugLogger *slog.Logger
+
+// timingOn records --timing; timingMu serializes the timing lines of
+// parallel requests.
+var (
+	timingOn bool
+	timingMu sync.Mutex
+)
+
+// applyDebug sets up the request log for --debug, or --trace for the
+// bodies as well.
+func applyDebug(cmd *cobra.Command) {
+	debugLogger = nil
+	timingOn, _ = cmd.Flags().GetBool(TimingFlag)
+
+	level := slog.LevelDebug
+	if trace, _ := cmd.Flags().GetBool(TraceFlag); trace {
+		level = gcs.LevelTrace
+	} else if debug, _ := cmd.Flags().GetBool(DebugFlag); !debug {
+		return
+	}
+
+	debugLogger = slog.New(slog.NewTextHandler(debugOut, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.LevelKey && attr.Value.Any() == gcs.LevelTrace {
+				attr.Value = slog.StringValue("TRACE")
+			}
+			return attr
+		},
+	}))
+}
+
+// printTiming writes the timing of one request for --timing, e.g.
+// "GET /api/collections 200 total=130ms dns=3ms connect=20ms tls=45ms
+// ttfb=120ms".
+func printTiming(t gcs.RequestTiming) {
+	status := strconv.Itoa(t.Status)
+	if t.Status == 0 {
+		status = "failed"
+	}
+	line := fmt.Sprintf("%s %s %s total=%s dns=%s connect=%s tls=%s ttfb=%s",
+		t.Method, t.Path, status, roundTiming(t.Total), roundTiming(t.DNSLookup),
+		roundTiming(t.Connect), roundTiming(t.TLSHandshake), roundTiming(t.TimeToFirstByte))
+	if t.ConnectionReused {
+		line += " reused"
+	}
+
+	timingMu.Lock()
+	defer timingMu.Unlock()
+	fmt.Fprintln(debugOut, line)
+}
+
+// roundTiming rounds d for display, to the microsecond below 1ms and to
+// the millisecond otherwise.
+func roundTiming(d time.Duration) time.Duration {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond)
+	}
+	return d.Round(time.Millisecond)
+}
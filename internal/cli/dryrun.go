@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// DryRunFlag is the root persistent flag that prints the requests that
+// would change the endpoint instead of sending them.
+const DryRunFlag = "dry-run"
+
+// dryRunOut receives the requests not sent because of --dry-run, or is
+// nil if --dry-run is not set.
+var dryRunOut io.Writer
+
+// dryRunDocument is the structured output of a request not sent.
+type dryRunDocument struct {
+	DryRun bool            `json:"dry_run"`
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// applyDryRun records --dry-run, and warns that it is active.
+func applyDryRun(cmd *cobra.Command) {
+	dryRunOut = nil
+	if dryRun, _ := cmd.Flags().GetBool(DryRunFlag); dryRun {
+		dryRunOut = cmd.OutOrStdout()
+		// A skipped request ends the command early, which is not a misuse
+		cmd.SilenceUsage = true
+		Warnf("dry run: requests that change the endpoint are printed, not sent")
+	}
+}
+
+// dryRunOptions returns the client option of --dry-run, if set.
+func dryRunOptions() []gcs.ClientOption {
+	if dryRunOut == nil {
+		return nil
+	}
+	return []gcs.ClientOption{gcs.WithDryRun(printDryRun)}
+}
+
+// printDryRun prints a request that was not sent, in the output format of
+// the command: a document in JSON and YAML, and otherwise the method and
+// path followed by the indented body.
+func printDryRun(req gcs.DryRunRequest) {
+	if outputFormat == output.FormatJSON || outputFormat == output.FormatYAML {
+		doc := dryRunDocument{DryRun: true, Method: req.Method, Path: req.Path}
+		if json.Valid(req.Body) {
+			doc.Body = req.Body
+		}
+		if err := output.NewFormatter(outputFormat, dryRunOut).PrintData(doc); err == nil {
+			return
+		}
+	}
+
+	fmt.Fprintf(dryRunOut, "%s %s\n", req.Method, req.Path)
+	var body bytes.Buffer
+	if len(req.Body) > 0 && json.Indent(&body, req.Body, "", "  ") == nil {
+		fmt.Fprintln(dryRunOut, body.String())
+	} else if len(req.Body) > 0 {
+		fmt.Fprintln(dryRunOut, string(req.Body))
+	}
+}
+
+// isDryRun reports whether err only says that a request was not sent
+// because of --dry-run.
+func isDryRun(err error) bool {
+	return errors.Is(err, gcs.ErrDryRun)
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+)
+
+func TestPrintDryRun(t *testing.T) {
+	req := gcs.DryRunRequest{Method: "PATCH", Path: "/api/collections/c1", Body: []byte(`{"public":false}`)}
+	tests := []struct {
+		format output.Format
+		want   string
+	}{
+		{output.FormatText, "PATCH /api/collections/c1\n{\n  \"public\": false\n}\n"},
+		{output.FormatJSON, "{\n  \"dry_run\": true,\n  \"method\": \"PATCH\",\n  \"path\": \"/api/collections/c1\",\n  \"body\": {\n    \"public\": false\n  }\n}\n"},
+	}
+
+	t.Cleanup(func() { dryRunOut, outputFormat = nil, output.FormatText })
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			dryRunOut, outputFormat = &buf, tt.format
+
+			printDryRun(req)
+			if buf.String() != tt.want {
+				t.Errorf("printDryRun() wrote %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDryRun_NotAFailure(t *testing.T) {
+	err := fmt.Errorf("delete role: %w", fmt.Errorf("%w: DELETE /api/roles/r1 not sent", gcs.ErrDryRun))
+
+	if IsFailure(err) {
+		t.Error("IsFailure() = true for a dry run")
+	}
+	if code := ExitCode(err); code != 0 {
+		t.Errorf("ExitCode() = %d, want 0", code)
+	}
+	var buf bytes.Buffer
+	PrintError(&buf, err)
+	if buf.Len() != 0 {
+		t.Errorf("PrintError() wrote %q for a dry run", buf.String())
+	}
+}
+
+func TestPrepare_DryRun(t *testing.T) {
+	setupConfigDir(t, "")
+	var warnings bytes.Buffer
+	warnOut = &warnings
+	t.Cleanup(func() { warnOut = os.Stderr; dryRunOut = nil })
+
+	_, cmd := newTestTree("delete")
+	cmd.Flags().Bool(DryRunFlag, false, "")
+	_ = cmd.Flags().Set(DryRunFlag, "true")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	if dryRunOut == nil || len(dryRunOptions()) != 1 {
+		t.Error("--dry-run did not enable the dry-run client option")
+	}
+	if !cmd.SilenceUsage {
+		t.Error("--dry-run should silence the usage text")
+	}
+	if !bytes.Contains(warnings.Bytes(), []byte("dry run")) {
+		t.Errorf("warnings = %q, want a dry-run warning", warnings.String())
+	}
+}
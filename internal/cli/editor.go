@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"go.yaml.in/yaml/v3"
+)
+
+// defaultEditor is run when neither VISUAL nor EDITOR is set.
+const defaultEditor = "vi"
+
+// immutableFields are document fields an edit may not change.
+var immutableFields = []string{"id", "DATA_TYPE"}
+
+// editorCommand returns the editor command line from VISUAL or EDITOR.
+func editorCommand() []string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(name)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{defaultEditor}
+}
+
+// EditDocument opens doc as YAML in the user's editor and returns the
+// edited document, with values as encoding/json decodes them. It returns
+// nil if the file is saved with no content, which cancels the edit. If the
+// result cannot be parsed, the error names the file holding the edits.
+func EditDocument(doc map[string]interface{}, name string) (map[string]interface{}, error) {
+	body, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s: %w", name, err)
+	}
+
+	file, err := os.CreateTemp("", "gcs-edit-*.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("create edit file: %w", err)
+	}
+	path := file.Name()
+	header := fmt.Sprintf("# Editing %s. Lines starting with '#' are ignored.\n"+
+		"# Only the fields you change are updated. Delete everything to cancel.\n\n", name)
+	if _, err := file.WriteString(header + string(body)); err != nil {
+		_ = file.Close()
+		_ = os.Remove(path)
+		return nil, fmt.Errorf("write edit file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(path)
+		return nil, fmt.Errorf("write edit file: %w", err)
+	}
+
+	editor := editorCommand()
+	cmd := exec.Command(editor[0], append(editor[1:], path)...) //nolint:gosec // The editor is chosen by the user
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		_ = os.Remove(path)
+		return nil, fmt.Errorf("run editor %s: %w", editor[0], err)
+	}
+
+	edited, err := os.ReadFile(path) //nolint:gosec // The file created above
+	if err != nil {
+		return nil, fmt.Errorf("read edit file: %w", err)
+	}
+
+	result, err := parseEditedDocument(edited)
+	if err != nil {
+		return nil, fmt.Errorf("%w (your edits are saved in %s)", err, path)
+	}
+	_ = os.Remove(path)
+	return result, nil
+}
+
+// parseEditedDocument decodes the YAML written by the editor. It returns
+// nil for a document without content.
+func parseEditedDocument(data []byte) (map[string]interface{}, error) {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse edited document: %w", err)
+	}
+	if doc == nil {
+		return nil, nil
+	}
+
+	// Round-trip through JSON so values compare equal to the fetched
+	// document (e.g., numbers as float64)
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("parse edited document: %w", err)
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(body, &normalized); err != nil {
+		return nil, fmt.Errorf("parse edited document: %w", err)
+	}
+	return normalized, nil
+}
+
+// DiffDocuments returns the top-level fields of edited that differ from
+// original, as a partial update: changed and added fields with their new
+// values, and removed fields as nil.
+func DiffDocuments(original, edited map[string]interface{}) map[string]interface{} {
+	changes := map[string]interface{}{}
+	for key, value := range edited {
+		if old, ok := original[key]; !ok || !reflect.DeepEqual(old, value) {
+			changes[key] = value
+		}
+	}
+	for key := range original {
+		if _, ok := edited[key]; !ok {
+			changes[key] = nil
+		}
+	}
+	return changes
+}
+
+// EditResource runs the edit flow of an 'edit' command: it fetches a
+// resource with get, opens it in the user's editor, shows the fields that
+// were changed, and sends only those with patch. name describes the
+// resource, e.g. "collection 1234". If the server reports that the
+// resource changed in the meantime (HTTP 412, from the If-Match of the
+// client's ETag cache), nothing is updated.
+func EditResource(ctx context.Context, name string,
+	get func(context.Context) (map[string]interface{}, error),
+	patch func(context.Context, map[string]interface{}) error,
+	formatter *output.Formatter) error {
+	original, err := get(ctx)
+	if err != nil {
+		return err
+	}
+
+	edited, err := EditDocument(original, name)
+	if err != nil {
+		return err
+	}
+	if edited == nil {
+		return formatter.Println("Edit cancelled, no changes made.")
+	}
+
+	changes := DiffDocuments(original, edited)
+	if len(changes) == 0 {
+		return formatter.Println("No changes made.")
+	}
+	for _, field := range immutableFields {
+		if _, ok := changes[field]; ok {
+			return fmt.Errorf("field %q cannot be changed", field)
+		}
+	}
+
+	if err := patch(ctx, changes); err != nil {
+		if gcs.IsPreconditionFailed(err) {
+			return fmt.Errorf("%s was changed by someone else while you edited it; run the command again to edit the current version: %w", name, err)
+		}
+		return err
+	}
+
+	return PrintChanges(formatter, name, original, changes)
+}
+
+// PrintChanges reports a partial update of the resource described by
+// name: the changes themselves in structured formats, or otherwise each
+// changed field with its value in original and its new value.
+func PrintChanges(formatter *output.Formatter, name string, original, changes map[string]interface{}) error {
+	if formatter.IsStructured() {
+		return formatter.PrintData(changes)
+	}
+
+	keys := make([]string, 0, len(changes))
+	for key := range changes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if err := formatter.PrintText("%s Updated %s:\n", formatter.Success("✓"), name); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		old, hadOld := original[key]
+		line := fmt.Sprintf("  %s: %s → %s", key, compactJSON(old), compactJSON(changes[key]))
+		switch {
+		case !hadOld:
+			line = fmt.Sprintf("  %s: added %s", key, compactJSON(changes[key]))
+		case changes[key] == nil:
+			line = fmt.Sprintf("  %s: removed", key)
+		}
+		if err := formatter.Println(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compactJSON renders a document value on one line.
+func compactJSON(value interface{}) string {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(bytes.TrimSpace(body))
+}
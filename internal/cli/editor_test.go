@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+)
+
+// useEditor makes EditDocument run a shell script that edits the file.
+func useEditor(t *testing.T, script string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "editor.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0700); err != nil { //nolint:gosec // Test editor script
+		t.Fatal(err)
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", path)
+}
+
+func TestDiffDocuments(t *testing.T) {
+	original := map[string]interface{}{
+		"display_name": "Old",
+		"public":       true,
+		"keywords":     []interface{}{"a"},
+		"description":  "gone",
+	}
+	edited := map[string]interface{}{
+		"display_name": "New",
+		"public":       true,
+		"keywords":     []interface{}{"a"},
+		"contact":      "x@example.org",
+	}
+
+	got := DiffDocuments(original, edited)
+	want := map[string]interface{}{
+		"display_name": "New",
+		"contact":      "x@example.org",
+		"description":  nil,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffDocuments() = %v, want %v", got, want)
+	}
+}
+
+func TestParseEditedDocument(t *testing.T) {
+	doc, err := parseEditedDocument([]byte("# comment\nname: x\ntimeout: 30\npublic: false\n"))
+	if err != nil {
+		t.Fatalf("parseEditedDocument() error = %v", err)
+	}
+	want := map[string]interface{}{"name": "x", "timeout": float64(30), "public": false}
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("parseEditedDocument() = %v, want %v", doc, want)
+	}
+
+	if doc, err := parseEditedDocument([]byte("# only comments\n\n")); err != nil || doc != nil {
+		t.Errorf("parseEditedDocument(empty) = %v, %v, want nil", doc, err)
+	}
+}
+
+func TestEditResource(t *testing.T) {
+	useEditor(t, `sed -e 's/^public: true/public: false/' -e '/^description:/d' "$1" > "$1.new" && mv "$1.new" "$1"`)
+
+	original := map[string]interface{}{"id": "c1", "public": true, "description": "d", "display_name": "n"}
+	var sent map[string]interface{}
+	get := func(context.Context) (map[string]interface{}, error) { return original, nil }
+	patch := func(_ context.Context, fields map[string]interface{}) error {
+		sent = fields
+		return nil
+	}
+
+	buf := &bytes.Buffer{}
+	formatter := output.NewFormatter(output.FormatText, buf)
+	if err := EditResource(context.Background(), "collection c1", get, patch, formatter); err != nil {
+		t.Fatalf("EditResource() error = %v", err)
+	}
+
+	want := map[string]interface{}{"public": false, "description": nil}
+	if !reflect.DeepEqual(sent, want) {
+		t.Errorf("patch = %v, want %v", sent, want)
+	}
+	if got := buf.String(); !strings.Contains(got, "public: true → false") || !strings.Contains(got, "description: removed") {
+		t.Errorf("output = %q", got)
+	}
+}
+
+func TestEditResource_ImmutableField(t *testing.T) {
+	useEditor(t, `sed -e 's/^id: c1/id: c2/' "$1" > "$1.new" && mv "$1.new" "$1"`)
+
+	get := func(context.Context) (map[string]interface{}, error) {
+		return map[string]interface{}{"id": "c1"}, nil
+	}
+	patch := func(context.Context, map[string]interface{}) error {
+		t.Error("patch called for an immutable field")
+		return nil
+	}
+
+	formatter := output.NewFormatter(output.FormatText, &bytes.Buffer{})
+	err := EditResource(context.Background(), "collection c1", get, patch, formatter)
+	if err == nil || !strings.Contains(err.Error(), `"id"`) {
+		t.Errorf("EditResource() error = %v, want id cannot be changed", err)
+	}
+}
+
+func TestEditResource_ChangedMeanwhile(t *testing.T) {
+	useEditor(t, `sed -e 's/^public: true/public: false/' "$1" > "$1.new" && mv "$1.new" "$1"`)
+
+	get := func(context.Context) (map[string]interface{}, error) {
+		return map[string]interface{}{"id": "c1", "public": true}, nil
+	}
+	patch := func(context.Context, map[string]interface{}) error {
+		return &gcs.APIError{StatusCode: 412, Code: "PreconditionFailed"}
+	}
+
+	formatter := output.NewFormatter(output.FormatText, &bytes.Buffer{})
+	err := EditResource(context.Background(), "collection c1", get, patch, formatter)
+	if !gcs.IsPreconditionFailed(err) || !strings.Contains(err.Error(), "changed by someone else") {
+		t.Errorf("EditResource() error = %v, want a changed-meanwhile error", err)
+	}
+}
+
+func TestEditResource_Cancelled(t *testing.T) {
+	useEditor(t, `: > "$1"`)
+
+	get := func(context.Context) (map[string]interface{}, error) {
+		return map[string]interface{}{"id": "c1"}, nil
+	}
+	patch := func(context.Context, map[string]interface{}) error {
+		t.Error("patch called for a cancelled edit")
+		return nil
+	}
+
+	buf := &bytes.Buffer{}
+	formatter := output.NewFormatter(output.FormatText, buf)
+	if err := EditResource(context.Background(), "collection c1", get, patch, formatter); err != nil {
+		t.Fatalf("EditResource() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "cancelled") {
+		t.Errorf("output = %q, want cancelled", buf.String())
+	}
+}
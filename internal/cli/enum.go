@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// enumValue is a string flag value restricted to a fixed set of choices.
+// Its type is "string", so it can be read with GetString.
+type enumValue struct {
+	value   *string
+	choices []string
+}
+
+func (e *enumValue) String() string {
+	return *e.value
+}
+
+func (e *enumValue) Set(s string) error {
+	for _, choice := range e.choices {
+		if s == choice {
+			*e.value = s
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of: %s", strings.Join(e.choices, ", "))
+}
+
+func (e *enumValue) Type() string {
+	return "string"
+}
+
+// EnumVar defines a string flag on cmd that only accepts one of choices,
+// stored in p. An invalid value is rejected when the command line is
+// parsed, listing the choices, and the choices are offered by shell
+// completion. The default value need not be one of the choices, so an
+// empty default can mean "not given".
+func EnumVar(cmd *cobra.Command, p *string, name, value, usage string, choices ...string) {
+	*p = value
+	cmd.Flags().Var(&enumValue{value: p, choices: choices}, name, usage)
+	_ = cmd.RegisterFlagCompletionFunc(name, cobra.FixedCompletions(choices, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// Enum is like EnumVar but returns a pointer to the flag's value.
+func Enum(cmd *cobra.Command, name, value, usage string, choices ...string) *string {
+	p := new(string)
+	EnumVar(cmd, p, name, value, usage, choices...)
+	return p
+}
+
+// BoolChoices are the choices of string flags that may be left unset to
+// keep a setting unchanged.
+var BoolChoices = []string{"true", "false"}
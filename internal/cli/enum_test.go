@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestEnumVar(t *testing.T) {
+	var networkUse string
+	cmd := &cobra.Command{Use: "update"}
+	EnumVar(cmd, &networkUse, "network-use", "", "Network use policy", "normal", "minimal")
+
+	if err := cmd.ParseFlags([]string{"--network-use", "minimal"}); err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if networkUse != "minimal" {
+		t.Errorf("networkUse = %q, want minimal", networkUse)
+	}
+	if got, _ := cmd.Flags().GetString("network-use"); got != "minimal" {
+		t.Errorf("GetString() = %q, want minimal", got)
+	}
+
+	err := cmd.ParseFlags([]string{"--network-use", "fast"})
+	if err == nil || !strings.Contains(err.Error(), "must be one of: normal, minimal") {
+		t.Errorf("ParseFlags() with invalid value error = %v", err)
+	}
+}
+
+func TestEnum_Default(t *testing.T) {
+	cmd := &cobra.Command{Use: "update"}
+	public := Enum(cmd, "public", "", "Make public", BoolChoices...)
+
+	if err := cmd.ParseFlags(nil); err != nil {
+		t.Fatal(err)
+	}
+	if *public != "" {
+		t.Errorf("default = %q, want empty", *public)
+	}
+	if err := cmd.ParseFlags([]string{"--public", "yes"}); err == nil {
+		t.Error("ParseFlags() accepted --public yes")
+	}
+}
+
+func TestEnumVar_Completion(t *testing.T) {
+	var collectionType string
+	cmd := &cobra.Command{Use: "create", Run: func(*cobra.Command, []string) {}}
+	EnumVar(cmd, &collectionType, "collection-type", "mapped", "Collection type", "mapped", "guest")
+
+	complete, ok := cmd.GetFlagCompletionFunc("collection-type")
+	if !ok {
+		t.Fatal("no completion registered")
+	}
+	choices, directive := complete(cmd, nil, "")
+	if strings.Join(choices, ",") != "mapped,guest" || directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("completion = %v, %v", choices, directive)
+	}
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/transfer"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	globusauth "github.com/scttfrdmn/globus-go-sdk/v3/pkg/services/auth"
+)
+
+// GlobusEnvironment returns the Globus environment selected by the
+// effective configuration.
+func GlobusEnvironment() (config.Environment, error) {
+	setting, _ := effective.Lookup(config.KeyEnvironment)
+	env, err := config.LookupEnvironment(setting.Value)
+	if err != nil && setting.Origin != "" {
+		return config.Environment{}, fmt.Errorf("%w (from %s)", err, setting.Origin)
+	}
+	return env, err
+}
+
+// NewAuthClient creates a Globus Auth client for the CLI's OAuth client
+// (see config.LoadClientConfig) in the effective Globus environment.
+func NewAuthClient() (*globusauth.Client, error) {
+	cfg, err := config.LoadClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	env, err := GlobusEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
+	authClient, err := globusauth.NewClient(
+		globusauth.WithClientID(cfg.ClientID),
+		globusauth.WithClientSecret(cfg.ClientSecret),
+		globusauth.WithBaseURL(env.AuthURL),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create auth client: %w", err)
+	}
+	return authClient, nil
+}
+
+// TransferResourceServer is the resource server of Transfer API tokens.
+const TransferResourceServer = "transfer.api.globus.org"
+
+// NewTransferClient creates a Transfer API client in the effective Globus
+// environment. It uses the Transfer token of the login, or the main access
+// token if the login has no separate one, and connects with the timeout,
+// TLS, and proxy settings of NewGCSClient. --annotate, --debug, and
+// --dry-run apply to its requests as to GCS API requests.
+func NewTransferClient(token *auth.TokenInfo) (*transfer.Client, error) {
+	env, err := GlobusEnvironment()
+	if err != nil {
+		return nil, err
+	}
+	accessToken := token.AccessToken
+	if other := token.ForResourceServer(TransferResourceServer); other != nil {
+		accessToken = other.AccessToken
+	}
+	opts, err := transferOptions()
+	if err != nil {
+		return nil, err
+	}
+	return TransferClientFactory(env.TransferURL, accessToken, opts...), nil
+}
+
+// TransferClientFactory creates the clients returned by NewTransferClient.
+// It is a test hook: tests set it to use a local server.
+var TransferClientFactory = transfer.NewClient
+
+// transferOptions returns the Transfer client options from the effective
+// configuration.
+func transferOptions() ([]transfer.Option, error) {
+	timeout, err := Timeout()
+	if err != nil {
+		return nil, err
+	}
+	userAgent, err := userAgent()
+	if err != nil {
+		return nil, err
+	}
+	gcsOpts := []gcs.ClientOption{gcs.WithTimeout(timeout)}
+	tlsOpts, err := tlsOptions()
+	if err != nil {
+		return nil, err
+	}
+	proxyOpts, err := proxyOptions()
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := gcs.NewHTTPClient(append(append(gcsOpts, tlsOpts...), proxyOpts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []transfer.Option{
+		transfer.WithHTTPClient(httpClient),
+		transfer.WithHeader("User-Agent", userAgent),
+	}
+	for _, key := range sortedAnnotationKeys(effective.Annotations) {
+		opts = append(opts, transfer.WithHeader(annotationHeader(key), effective.Annotations[key]))
+	}
+	if debugLogger != nil {
+		opts = append(opts, transfer.WithLogger(debugLogger))
+	}
+	if dryRunOut != nil {
+		opts = append(opts, transfer.WithDryRun(printDryRun))
+	}
+	return opts, nil
+}
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/i18n"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// Error codes reported in ErrorInfo.Code.
+const (
+	ErrorCodeGeneric          = "error"
+	ErrorCodeNotLoggedIn      = "not_logged_in"
+	ErrorCodeTokenExpired     = "token_expired"
+	ErrorCodeTimeout          = "timeout"
+	ErrorCodeCanceled         = "canceled"
+	ErrorCodeBadRequest       = "bad_request"
+	ErrorCodeUnauthenticated  = "unauthenticated"
+	ErrorCodePermissionDenied = "permission_denied"
+	ErrorCodeNotFound         = "not_found"
+	ErrorCodeConflict         = "conflict"
+	ErrorCodeRateLimited      = "rate_limited"
+	ErrorCodeServerError      = "server_error"
+	ErrorCodeUnreachable      = "endpoint_unreachable"
+	ErrorCodeUnsupported      = "unsupported_version"
+	ErrorCodeHTTP             = "http_error"
+)
+
+// ErrorEnvelope is the document printed for a failed command in JSON and
+// YAML formats.
+type ErrorEnvelope struct {
+	Error ErrorInfo `json:"error"`
+}
+
+// ErrorInfo describes why a command failed.
+type ErrorInfo struct {
+	// Code is one of the ErrorCode constants.
+	Code string `json:"code"`
+
+	// Message is the error message printed in text format.
+	Message string `json:"message"`
+
+	// HTTPStatus is the status of the failed GCS Manager API request, if
+	// any.
+	HTTPStatus int `json:"http_status,omitempty"`
+
+	// APICode is the GCS error code of the failed API request, such as
+	// "NotFound", if the server returned an error document.
+	APICode string `json:"api_code,omitempty"`
+
+	// RequestID identifies the failed API request for support tickets,
+	// when the server reported one.
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// outputFormat is the output format of the running command, as recorded
+// by Prepare.
+var outputFormat output.Format
+
+// httpStatusPattern matches the status in errors that are not a
+// gcs.APIError but were formatted like one, e.g. "HTTP 404: ...".
+var httpStatusPattern = regexp.MustCompile(`HTTP (\d{3}):`)
+
+// recordOutputFormat notes cmd's output format for PrintError. In JSON
+// and YAML formats, cobra's usage text is not printed on failure, so that
+// stderr holds only the error document.
+func recordOutputFormat(cmd *cobra.Command) {
+	outputFormat = output.FormatText
+	if flag := cmd.Flags().Lookup(config.KeyFormat); flag != nil {
+		outputFormat = output.Format(flag.Value.String())
+	}
+	if outputFormat == output.FormatJSON || outputFormat == output.FormatYAML {
+		cmd.SilenceUsage = true
+	}
+}
+
+// NewErrorInfo classifies err.
+func NewErrorInfo(err error) ErrorInfo {
+	info := ErrorInfo{Code: ErrorCodeGeneric, Message: err.Error()}
+
+	var apiErr *gcs.APIError
+	if errors.As(err, &apiErr) {
+		info.HTTPStatus = apiErr.StatusCode
+		info.APICode = apiErr.Code
+		info.RequestID = apiErr.RequestID
+	} else if m := httpStatusPattern.FindStringSubmatch(info.Message); m != nil {
+		info.HTTPStatus, _ = strconv.Atoi(m[1])
+	}
+
+	switch {
+	case errors.Is(err, gcs.ErrCircuitOpen):
+		info.Code = ErrorCodeUnreachable
+	case errors.Is(err, gcs.ErrUnsupportedVersion):
+		info.Code = ErrorCodeUnsupported
+	case errors.Is(err, context.DeadlineExceeded):
+		info.Code = ErrorCodeTimeout
+	case errors.Is(err, context.Canceled):
+		info.Code = ErrorCodeCanceled
+	case info.HTTPStatus != 0:
+		info.Code = httpErrorCode(info.HTTPStatus)
+	case strings.HasPrefix(info.Message, "not logged in"):
+		info.Code = ErrorCodeNotLoggedIn
+	case strings.HasPrefix(info.Message, "token expired"):
+		info.Code = ErrorCodeTokenExpired
+	}
+
+	return info
+}
+
+// httpErrorCode returns the error code for an HTTP status.
+func httpErrorCode(status int) string {
+	switch {
+	case status == 400:
+		return ErrorCodeBadRequest
+	case status == 401:
+		return ErrorCodeUnauthenticated
+	case status == 403:
+		return ErrorCodePermissionDenied
+	case status == 404:
+		return ErrorCodeNotFound
+	case status == 409:
+		return ErrorCodeConflict
+	case status == 429:
+		return ErrorCodeRateLimited
+	case status >= 500:
+		return ErrorCodeServerError
+	}
+	return ErrorCodeHTTP
+}
+
+// PrintError writes the error of a failed command to w: an ErrorEnvelope
+// if the command was run with --format json or yaml, and otherwise an
+// "Error: ..." line. Status-only ExitErrors print nothing.
+func PrintError(w io.Writer, err error) {
+	if !IsFailure(err) {
+		return
+	}
+
+	if outputFormat == output.FormatJSON || outputFormat == output.FormatYAML {
+		envelope := ErrorEnvelope{Error: NewErrorInfo(err)}
+		if printErr := output.NewFormatter(outputFormat, w).PrintData(envelope); printErr == nil {
+			return
+		}
+	}
+	fmt.Fprint(w, i18n.Sprintf("Error: %v\n", err))
+}
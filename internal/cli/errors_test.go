@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+)
+
+func TestNewErrorInfo(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   string
+		wantStatus int
+	}{
+		{name: "generic", err: errors.New("boom"), wantCode: ErrorCodeGeneric},
+		{name: "not found", err: errors.New(`get collection: HTTP 404: {"code":"not_found"}`), wantCode: ErrorCodeNotFound, wantStatus: 404},
+		{name: "forbidden", err: errors.New("delete role: HTTP 403: denied"), wantCode: ErrorCodePermissionDenied, wantStatus: 403},
+		{name: "server error", err: errors.New("HTTP 503: unavailable"), wantCode: ErrorCodeServerError, wantStatus: 503},
+		{name: "other status", err: errors.New("HTTP 418: teapot"), wantCode: ErrorCodeHTTP, wantStatus: 418},
+		{name: "circuit open", err: fmt.Errorf("list roles: %w: 5 consecutive requests failed", gcs.ErrCircuitOpen), wantCode: ErrorCodeUnreachable},
+		{name: "unsupported version", err: fmt.Errorf("set subscription admin verified: %w: requires API 1.23.0", gcs.ErrUnsupportedVersion), wantCode: ErrorCodeUnsupported},
+		{name: "timeout", err: fmt.Errorf("list roles: %w", context.DeadlineExceeded), wantCode: ErrorCodeTimeout},
+		{name: "canceled", err: fmt.Errorf("query: %w", context.Canceled), wantCode: ErrorCodeCanceled},
+		{name: "not logged in", err: errors.New("not logged in: no token (use 'login' command first)"), wantCode: ErrorCodeNotLoggedIn},
+		{name: "token expired", err: errors.New("token expired, please login again"), wantCode: ErrorCodeTokenExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := NewErrorInfo(tt.err)
+			if info.Code != tt.wantCode || info.HTTPStatus != tt.wantStatus {
+				t.Errorf("NewErrorInfo() = %+v, want code %q status %d", info, tt.wantCode, tt.wantStatus)
+			}
+			if info.Message != tt.err.Error() {
+				t.Errorf("Message = %q, want %q", info.Message, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestNewErrorInfo_APIError(t *testing.T) {
+	err := fmt.Errorf("get collection: %w", &gcs.APIError{
+		StatusCode: 403, Code: "PermissionDenied", Message: "no role", RequestID: "req-1",
+	})
+
+	info := NewErrorInfo(err)
+	want := ErrorInfo{
+		Code:       ErrorCodePermissionDenied,
+		Message:    err.Error(),
+		HTTPStatus: 403,
+		APICode:    "PermissionDenied",
+		RequestID:  "req-1",
+	}
+	if info != want {
+		t.Errorf("NewErrorInfo() = %+v, want %+v", info, want)
+	}
+}
+
+func TestPrintError(t *testing.T) {
+	t.Cleanup(func() { outputFormat = "" })
+	err := errors.New("get role: HTTP 404: not found")
+
+	outputFormat = output.FormatJSON
+	var buf bytes.Buffer
+	PrintError(&buf, err)
+	var envelope ErrorEnvelope
+	if jsonErr := json.Unmarshal(buf.Bytes(), &envelope); jsonErr != nil {
+		t.Fatalf("PrintError() in JSON mode wrote %q: %v", buf.String(), jsonErr)
+	}
+	if envelope.Error.Code != ErrorCodeNotFound || envelope.Error.HTTPStatus != 404 {
+		t.Errorf("envelope = %+v", envelope.Error)
+	}
+
+	outputFormat = output.FormatText
+	buf.Reset()
+	PrintError(&buf, err)
+	if got, want := buf.String(), "Error: get role: HTTP 404: not found\n"; got != want {
+		t.Errorf("PrintError() in text mode = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	PrintError(&buf, &ExitError{Code: 2})
+	PrintError(&buf, nil)
+	if buf.Len() != 0 {
+		t.Errorf("PrintError() for a status-only result wrote %q", buf.String())
+	}
+}
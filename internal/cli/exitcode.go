@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ExitError makes the process exit with a specific status.
+//
+// With a nil Err it reports a result rather than a failure: commands use
+// it to give monitoring wrappers a status they can test without parsing
+// output. Such results print no error message and are recorded as
+// successful in the activity log and post-hooks.
+type ExitError struct {
+	// Code is the process exit status.
+	Code int
+
+	// Err is the underlying error, or nil for a status-only result.
+	Err error
+}
+
+// Error returns the underlying error's message, or "exit status N".
+func (e *ExitError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// Unwrap returns the underlying error.
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the process exit status for the error returned by the
+// root command: 0 for nil or a request skipped by --dry-run, the code of
+// an ExitError, and 1 otherwise.
+func ExitCode(err error) int {
+	if err == nil || isDryRun(err) {
+		return 0
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return 1
+}
+
+// IsFailure reports whether err is a command failure, as opposed to nil,
+// a status-only ExitError, or a request skipped by --dry-run.
+func IsFailure(err error) bool {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) && exitErr.Err == nil {
+		return false
+	}
+	return err != nil && !isDryRun(err)
+}
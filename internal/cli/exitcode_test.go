@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	failure := errors.New("boom")
+
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    int
+		wantFailure bool
+	}{
+		{name: "nil", err: nil, wantCode: 0, wantFailure: false},
+		{name: "plain error", err: failure, wantCode: 1, wantFailure: true},
+		{name: "status only", err: &ExitError{Code: 10}, wantCode: 10, wantFailure: false},
+		{name: "wrapped status", err: fmt.Errorf("check: %w", &ExitError{Code: 11}), wantCode: 11, wantFailure: false},
+		{name: "error with code", err: &ExitError{Code: 3, Err: failure}, wantCode: 3, wantFailure: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.wantCode {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.wantCode)
+			}
+			if got := IsFailure(tt.err); got != tt.wantFailure {
+				t.Errorf("IsFailure() = %v, want %v", got, tt.wantFailure)
+			}
+		})
+	}
+
+	if got := (&ExitError{Code: 10}).Error(); got != "exit status 10" {
+		t.Errorf("ExitError.Error() = %q, want %q", got, "exit status 10")
+	}
+}
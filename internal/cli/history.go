@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/history"
+	"github.com/spf13/cobra"
+)
+
+// NoHistoryFlag is the root flag that disables the activity log for one
+// invocation.
+const NoHistoryFlag = "no-history"
+
+// historyPath returns the activity log location (replaced in tests).
+var historyPath = history.GetFilePath
+
+// historyRun is the command execution being recorded.
+var historyRun *historyRecord
+
+// historyRecord is a command execution in progress.
+type historyRecord struct {
+	cmd   *cobra.Command
+	args  []string
+	start time.Time
+}
+
+// startHistory notes the start of cmd for RecordHistory.
+func startHistory(cmd *cobra.Command, args []string) {
+	historyRun = nil
+	if skipHooks(cmd) || CommandPath(cmd) == "history" {
+		return
+	}
+	if off, err := cmd.Flags().GetBool(NoHistoryFlag); err == nil && off {
+		return
+	}
+
+	historyRun = &historyRecord{cmd: cmd, args: args, start: time.Now()}
+}
+
+// RecordHistory appends the command that was executed, and its result,
+// to the activity log. Failure to write the log is reported as a warning.
+//
+// It is intended to be called by main after the root command returns.
+func RecordHistory(cmdErr error) {
+	run := historyRun
+	if run == nil {
+		return
+	}
+	historyRun = nil
+
+	entry := &history.Entry{
+		Time:        run.start.UTC(),
+		Command:     CommandPath(run.cmd),
+		Args:        run.args,
+		Flags:       redactedFlags(run.cmd),
+		Profile:     flagOrEffective(run.cmd, config.KeyProfile),
+		Endpoint:    flagOrEffective(run.cmd, config.KeyEndpoint),
+		User:        currentUsername(),
+		Annotations: effectiveAnnotations(),
+		Result:      history.ResultSuccess,
+		DurationMS:  time.Since(run.start).Milliseconds(),
+	}
+	if IsFailure(cmdErr) {
+		entry.Result = history.ResultFailure
+		entry.Error = cmdErr.Error()
+	}
+
+	path, err := historyPath()
+	if err == nil {
+		err = history.Append(path, entry)
+	}
+	if err != nil {
+		Warnf("could not record command history: %v (use --%s to disable)", err, NoHistoryFlag)
+	}
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/history"
+)
+
+func setupHistory(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), history.FileName)
+	old := historyPath
+	historyPath = func() (string, error) { return path, nil }
+	t.Cleanup(func() { historyPath = old })
+	return path
+}
+
+func TestRecordHistory(t *testing.T) {
+	setupConfigDir(t, "")
+	path := setupHistory(t)
+
+	_, cmd := newTestTree("delete")
+	cmd.Flags().String("client-secret", "", "secret")
+	cmd.Flags().String("old-key", "", "deployment key")
+	if err := cmd.Flags().Set("client-secret", "hunter2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Flags().Set("old-key", "deployment-key-contents"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Prepare(cmd, []string{"abc123"}); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	RecordHistory(errors.New("HTTP 403: forbidden"))
+
+	entries, err := history.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("recorded %d entries, want 1", len(entries))
+	}
+
+	e := entries[0]
+	if e.Command != "collection delete" || len(e.Args) != 1 || e.Args[0] != "abc123" {
+		t.Errorf("Command, Args = %q, %v, want %q, [abc123]", e.Command, e.Args, "collection delete")
+	}
+	if e.Result != history.ResultFailure || e.Error != "HTTP 403: forbidden" {
+		t.Errorf("Result, Error = %q, %q, want failure with the command error", e.Result, e.Error)
+	}
+	if got := e.Flags["client-secret"]; got != "REDACTED" {
+		t.Errorf("Flags[client-secret] = %q, want REDACTED", got)
+	}
+	if got := e.Flags["old-key"]; got != "REDACTED" {
+		t.Errorf("Flags[old-key] = %q, want REDACTED", got)
+	}
+	if e.Profile == "" {
+		t.Error("Profile is empty")
+	}
+}
+
+func TestRecordHistory_NoHistory(t *testing.T) {
+	setupConfigDir(t, "")
+	path := setupHistory(t)
+
+	_, cmd := newTestTree("delete")
+	cmd.Flags().Bool(NoHistoryFlag, false, "")
+	if err := cmd.Flags().Set(NoHistoryFlag, "true"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	RecordHistory(nil)
+
+	entries, err := history.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("recorded %d entries with --%s, want 0", len(entries), NoHistoryFlag)
+	}
+}
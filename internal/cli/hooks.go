@@ -0,0 +1,281 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/user"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Hook phases. A hook name is "<phase>-<target>", where target is a
+// command name ("delete" matches every delete command), a full command
+// path joined with hyphens ("collection-delete"), or "*" for every command.
+const (
+	HookPre  = "pre"
+	HookPost = "post"
+)
+
+// hookTimeout bounds how long a single hook may run.
+const hookTimeout = 2 * time.Minute
+
+// hookOut receives the output of hook programs. Hooks never write to
+// stdout so that they do not corrupt JSON output.
+var hookOut io.Writer = os.Stderr
+
+// hookCommand is the command whose pre-hooks ran, for RunPostHooks.
+var hookCommand *cobra.Command
+
+// HookContext is the JSON document written to a hook's stdin.
+type HookContext struct {
+	// Hook is the configured hook name (e.g., "pre-delete").
+	Hook string `json:"hook"`
+
+	// Phase is "pre" or "post".
+	Phase string `json:"phase"`
+
+	// Command is the command path without the root name.
+	Command string `json:"command"`
+
+	// Args holds the positional arguments.
+	Args []string `json:"args"`
+
+	// Flags holds the flags that were set. Values of flags that look
+	// like secrets are redacted.
+	Flags map[string]string `json:"flags"`
+
+	// Profile and Endpoint are the effective profile and endpoint.
+	Profile  string `json:"profile,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// User is the local user running the command.
+	User string `json:"user,omitempty"`
+
+	// Annotations holds the request annotations (see --annotate).
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Time is when the hook was started.
+	Time time.Time `json:"time"`
+
+	// Status is "success" or "failure" (post-hooks only).
+	Status string `json:"status,omitempty"`
+
+	// Error is the command's error message (post-hooks only).
+	Error string `json:"error,omitempty"`
+}
+
+// ValidateHooks checks that every hook name has a known phase and a
+// non-empty program.
+func ValidateHooks(hooks map[string]string) error {
+	for _, name := range sortedHookNames(hooks) {
+		phase, target, ok := strings.Cut(name, "-")
+		if !ok || target == "" || (phase != HookPre && phase != HookPost) {
+			return fmt.Errorf("invalid hook %q: name must be pre-<command> or post-<command>", name)
+		}
+		if strings.TrimSpace(hooks[name]) == "" {
+			return fmt.Errorf("invalid hook %q: no program configured", name)
+		}
+	}
+	return nil
+}
+
+// MatchHooks returns the names of the hooks for phase that apply to the
+// command at path, in the order they run: "*" first, then the command
+// name, then the full command path.
+func MatchHooks(hooks map[string]string, phase, path string) []string {
+	fields := strings.Fields(path)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	targets := []string{"*", fields[len(fields)-1]}
+	if full := strings.Join(fields, "-"); full != targets[1] {
+		targets = append(targets, full)
+	}
+
+	var names []string
+	for _, target := range targets {
+		name := phase + "-" + target
+		if _, ok := hooks[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// runPreHooks runs the pre-hooks that apply to cmd. A hook that exits
+// non-zero aborts the command.
+func runPreHooks(cmd *cobra.Command, args []string) error {
+	if skipHooks(cmd) {
+		return nil
+	}
+	hookCommand = cmd
+
+	path := CommandPath(cmd)
+	for _, name := range MatchHooks(effective.Hooks, HookPre, path) {
+		hc := newHookContext(cmd, args, name, HookPre)
+		if err := runHook(cmd.Context(), effective.Hooks[name], hc); err != nil {
+			return fmt.Errorf("%s aborted by %s hook: %w", path, name, err)
+		}
+	}
+	return nil
+}
+
+// RunPostHooks runs the post-hooks for the command that was executed,
+// passing the command's result. Post-hook failures are reported as
+// warnings and do not change the command's outcome.
+//
+// It is intended to be called by main after the root command returns.
+func RunPostHooks(cmdErr error) {
+	cmd := hookCommand
+	if cmd == nil {
+		return
+	}
+	hookCommand = nil
+
+	for _, name := range MatchHooks(effective.Hooks, HookPost, CommandPath(cmd)) {
+		hc := newHookContext(cmd, cmd.Flags().Args(), name, HookPost)
+		hc.Status = "success"
+		if IsFailure(cmdErr) {
+			hc.Status = "failure"
+			hc.Error = cmdErr.Error()
+		}
+		if err := runHook(cmd.Context(), effective.Hooks[name], hc); err != nil {
+			Warnf("%s hook failed: %v", name, err)
+		}
+	}
+}
+
+// runHook runs program with hc as JSON on stdin.
+func runHook(ctx context.Context, program string, hc *HookContext) error {
+	argv := strings.Fields(program)
+	if len(argv) == 0 {
+		return fmt.Errorf("no program configured")
+	}
+
+	input, err := json.Marshal(hc)
+	if err != nil {
+		return fmt.Errorf("encode hook context: %w", err)
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, hookTimeout)
+	defer cancel()
+
+	//nolint:gosec // Hook programs are configured by the site administrator
+	c := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	c.Stdin = bytes.NewReader(input)
+	c.Stdout = hookOut
+	c.Stderr = hookOut
+	c.Env = append(os.Environ(),
+		"GLOBUS_GCS_HOOK="+hc.Hook,
+		"GLOBUS_GCS_COMMAND="+hc.Command,
+	)
+
+	if err := c.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("%s exited with status %d", argv[0], exitErr.ExitCode())
+		}
+		return fmt.Errorf("run %s: %w", argv[0], err)
+	}
+	return nil
+}
+
+// newHookContext builds the hook input for cmd.
+func newHookContext(cmd *cobra.Command, args []string, name, phase string) *HookContext {
+	hc := &HookContext{
+		Hook:        name,
+		Phase:       phase,
+		Command:     CommandPath(cmd),
+		Args:        args,
+		Flags:       redactedFlags(cmd),
+		Profile:     flagOrEffective(cmd, config.KeyProfile),
+		Endpoint:    flagOrEffective(cmd, config.KeyEndpoint),
+		User:        currentUsername(),
+		Annotations: effectiveAnnotations(),
+		Time:        time.Now().UTC(),
+	}
+	if hc.Args == nil {
+		hc.Args = []string{}
+	}
+
+	return hc
+}
+
+// redactedFlags returns the flags set on cmd, with the values of flags
+// that look like secrets redacted.
+func redactedFlags(cmd *cobra.Command) map[string]string {
+	flags := map[string]string{}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		value := f.Value.String()
+		if isSecretFlag(f.Name) {
+			value = "REDACTED"
+		}
+		flags[f.Name] = value
+	})
+	return flags
+}
+
+// currentUsername returns the name of the local user, or "" if unknown.
+func currentUsername() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+}
+
+// flagOrEffective returns the value of the named flag on cmd, falling
+// back to the effective configuration.
+func flagOrEffective(cmd *cobra.Command, name string) string {
+	if f := cmd.Flags().Lookup(name); f != nil && f.Value.String() != "" {
+		return f.Value.String()
+	}
+	return effective.Get(name)
+}
+
+// isSecretFlag reports whether a flag's value must not be passed to hooks
+// or recorded in the activity log. Key flags (--old-key, --private-key)
+// are secret, but key ID flags (--access-key-id) are not.
+func isSecretFlag(name string) bool {
+	for _, s := range []string{"secret", "password", "passphrase", "token"} {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	words := strings.Split(name, "-")
+	return slices.Contains(words, "key") && words[len(words)-1] != "id"
+}
+
+// skipHooks reports whether hooks do not apply to cmd (help and shell
+// completion).
+func skipHooks(cmd *cobra.Command) bool {
+	path := CommandPath(cmd)
+	return cmd.Name() == "help" ||
+		strings.HasPrefix(path, "completion") ||
+		strings.HasPrefix(path, cobra.ShellCompRequestCmd)
+}
+
+// sortedHookNames returns the hook names in sorted order.
+func sortedHookNames(hooks map[string]string) []string {
+	names := make([]string, 0, len(hooks))
+	for name := range hooks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,243 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// writeHookScript writes a shell script that saves its stdin to a file in
+// dir and exits with the given status. It returns the script path and the
+// path its input is saved to.
+func writeHookScript(t *testing.T, dir, name string, status int) (string, string) {
+	t.Helper()
+
+	input := filepath.Join(dir, name+".json")
+	script := filepath.Join(dir, name+".sh")
+	body := "#!/bin/sh\ncat > " + input + "\necho hook ran\nexit " + strconv.Itoa(status) + "\n"
+	if err := os.WriteFile(script, []byte(body), 0700); err != nil {
+		t.Fatalf("write hook script: %v", err)
+	}
+	return script, input
+}
+
+func captureHookOutput(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	old := hookOut
+	hookOut = buf
+	t.Cleanup(func() { hookOut = old })
+	return buf
+}
+
+func TestValidateHooks(t *testing.T) {
+	tests := []struct {
+		name    string
+		hooks   map[string]string
+		wantErr bool
+	}{
+		{name: "none", hooks: nil},
+		{name: "valid", hooks: map[string]string{"pre-delete": "/bin/true", "post-*": "/bin/true"}},
+		{name: "unknown phase", hooks: map[string]string{"during-delete": "/bin/true"}, wantErr: true},
+		{name: "no target", hooks: map[string]string{"pre-": "/bin/true"}, wantErr: true},
+		{name: "no dash", hooks: map[string]string{"pre": "/bin/true"}, wantErr: true},
+		{name: "empty program", hooks: map[string]string{"pre-delete": " "}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHooks(tt.hooks)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateHooks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatchHooks(t *testing.T) {
+	hooks := map[string]string{
+		"pre-*":                 "a",
+		"pre-delete":            "b",
+		"pre-collection-delete": "c",
+		"post-delete":           "d",
+		"pre-create":            "e",
+	}
+
+	tests := []struct {
+		phase string
+		path  string
+		want  []string
+	}{
+		{HookPre, "collection delete", []string{"pre-*", "pre-delete", "pre-collection-delete"}},
+		{HookPre, "node delete", []string{"pre-*", "pre-delete"}},
+		{HookPost, "node delete", []string{"post-delete"}},
+		{HookPre, "collection list", []string{"pre-*"}},
+		{HookPost, "collection list", nil},
+		{HookPre, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phase+" "+tt.path, func(t *testing.T) {
+			if got := MatchHooks(hooks, tt.phase, tt.path); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MatchHooks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSecretFlag(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"client-secret", true},
+		{"password", true},
+		{"access-token-stdin", true},
+		{"passphrase-env", true},
+		{"old-key", true},
+		{"private-key", true},
+		{"access-key-id", false},
+		{"sse-kms-key-id", false},
+		{"keywords", false},
+		{"endpoint", false},
+	}
+
+	for _, tt := range tests {
+		if got := isSecretFlag(tt.name); got != tt.want {
+			t.Errorf("isSecretFlag(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPrepare_PreHook(t *testing.T) {
+	dir := t.TempDir()
+	script, input := writeHookScript(t, dir, "pre", 0)
+	setupConfigDir(t, "hooks:\n  pre-delete: "+script+"\n")
+	out := captureHookOutput(t)
+
+	_, cmd := newTestTree("delete")
+	cmd.Flags().String("client-secret", "", "secret")
+	if err := cmd.Flags().Set("endpoint", "ep.example.org"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Flags().Set("client-secret", "hunter2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Prepare(cmd, []string{"abc123"}); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	t.Cleanup(func() { hookCommand = nil })
+
+	if !strings.Contains(out.String(), "hook ran") {
+		t.Errorf("hook output = %q, want it to contain %q", out.String(), "hook ran")
+	}
+
+	data, err := os.ReadFile(input)
+	if err != nil {
+		t.Fatalf("hook did not run: %v", err)
+	}
+	var hc HookContext
+	if err := json.Unmarshal(data, &hc); err != nil {
+		t.Fatalf("hook input is not JSON: %v", err)
+	}
+
+	if hc.Hook != "pre-delete" || hc.Phase != HookPre {
+		t.Errorf("Hook, Phase = %q, %q, want %q, %q", hc.Hook, hc.Phase, "pre-delete", HookPre)
+	}
+	if hc.Command != "collection delete" {
+		t.Errorf("Command = %q, want %q", hc.Command, "collection delete")
+	}
+	if !reflect.DeepEqual(hc.Args, []string{"abc123"}) {
+		t.Errorf("Args = %v, want [abc123]", hc.Args)
+	}
+	if hc.Endpoint != "ep.example.org" {
+		t.Errorf("Endpoint = %q, want %q", hc.Endpoint, "ep.example.org")
+	}
+	if got := hc.Flags["client-secret"]; got != "REDACTED" {
+		t.Errorf("Flags[client-secret] = %q, want REDACTED", got)
+	}
+}
+
+func TestPrepare_PreHookAborts(t *testing.T) {
+	dir := t.TempDir()
+	script, _ := writeHookScript(t, dir, "pre", 1)
+	setupConfigDir(t, "hooks:\n  pre-delete: "+script+"\n")
+	captureHookOutput(t)
+
+	_, cmd := newTestTree("delete")
+	err := Prepare(cmd, nil)
+	t.Cleanup(func() { hookCommand = nil })
+	if err == nil {
+		t.Fatal("Prepare() expected error from failing pre-hook, got nil")
+	}
+	if !strings.Contains(err.Error(), "pre-delete") {
+		t.Errorf("Prepare() error = %v, want it to name the hook", err)
+	}
+}
+
+func TestPrepare_InvalidHooks(t *testing.T) {
+	setupConfigDir(t, "hooks:\n  before-delete: /bin/true\n")
+
+	_, cmd := newTestTree("delete")
+	if err := Prepare(cmd, nil); err == nil {
+		t.Error("Prepare() expected error for invalid hook name, got nil")
+	}
+}
+
+func TestRunPostHooks(t *testing.T) {
+	dir := t.TempDir()
+	script, input := writeHookScript(t, dir, "post", 0)
+	setupConfigDir(t, "hooks:\n  post-delete: "+script+"\n")
+	captureHookOutput(t)
+
+	_, cmd := newTestTree("delete")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	RunPostHooks(errors.New("delete collection: HTTP 404: not found"))
+
+	data, err := os.ReadFile(input)
+	if err != nil {
+		t.Fatalf("post-hook did not run: %v", err)
+	}
+	var hc HookContext
+	if err := json.Unmarshal(data, &hc); err != nil {
+		t.Fatalf("hook input is not JSON: %v", err)
+	}
+	if hc.Status != "failure" || !strings.Contains(hc.Error, "HTTP 404") {
+		t.Errorf("Status, Error = %q, %q, want failure with the command error", hc.Status, hc.Error)
+	}
+	if hookCommand != nil {
+		t.Error("RunPostHooks() did not reset hookCommand")
+	}
+}
+
+func TestRunPostHooks_FailureIsWarning(t *testing.T) {
+	dir := t.TempDir()
+	script, _ := writeHookScript(t, dir, "post", 3)
+	setupConfigDir(t, "hooks:\n  post-delete: "+script+"\n")
+	captureHookOutput(t)
+
+	warnings := &bytes.Buffer{}
+	old := warnOut
+	warnOut = warnings
+	t.Cleanup(func() { warnOut = old })
+
+	_, cmd := newTestTree("delete")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	RunPostHooks(nil)
+
+	if !strings.Contains(warnings.String(), "post-delete hook failed") {
+		t.Errorf("warnings = %q, want post-hook failure warning", warnings.String())
+	}
+}
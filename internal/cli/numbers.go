@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// RawNumbersFlag is the root persistent flag that disables human
+// formatting of byte counts and durations in text output.
+const RawNumbersFlag = "raw-numbers"
+
+// rawNumbers is set by Prepare from --raw-numbers.
+var rawNumbers bool
+
+// Bytes formats a byte count for text output: "1.2 GiB", or the exact
+// count with --raw-numbers.
+func Bytes(n int64) string {
+	if rawNumbers {
+		return strconv.FormatInt(n, 10)
+	}
+	return output.FormatBytes(n)
+}
+
+// Duration formats a duration for text output: "3m42s", or the number of
+// seconds (e.g., "222.318") with --raw-numbers.
+func Duration(d time.Duration) string {
+	if rawNumbers {
+		return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+	}
+	return output.FormatDuration(d)
+}
+
+// applyRawNumbers reads --raw-numbers from cmd.
+func applyRawNumbers(cmd *cobra.Command) {
+	rawNumbers = false
+	if flag := cmd.Flags().Lookup(RawNumbersFlag); flag != nil {
+		rawNumbers, _ = cmd.Flags().GetBool(RawNumbersFlag)
+	}
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBytesAndDuration(t *testing.T) {
+	t.Cleanup(func() { rawNumbers = false })
+
+	rawNumbers = false
+	if got := Bytes(1536); got != "1.5 KiB" {
+		t.Errorf("Bytes() = %q, want %q", got, "1.5 KiB")
+	}
+	if got := Duration(222318 * time.Millisecond); got != "3m42s" {
+		t.Errorf("Duration() = %q, want %q", got, "3m42s")
+	}
+
+	rawNumbers = true
+	if got := Bytes(1536); got != "1536" {
+		t.Errorf("Bytes() with --raw-numbers = %q, want %q", got, "1536")
+	}
+	if got := Duration(222318 * time.Millisecond); got != "222.318" {
+		t.Errorf("Duration() with --raw-numbers = %q, want %q", got, "222.318")
+	}
+}
+
+func TestPrepare_RawNumbers(t *testing.T) {
+	setupConfigDir(t, "")
+	t.Cleanup(func() { rawNumbers = false })
+
+	_, cmd := newTestTree("list")
+	cmd.Flags().Bool(RawNumbersFlag, false, "")
+	if err := cmd.Flags().Set(RawNumbersFlag, "true"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if got := Bytes(2048); got != "2048" {
+		t.Errorf("Bytes() = %q after --raw-numbers, want %q", got, "2048")
+	}
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// Root persistent flags that shape structured output.
+const (
+	// TemplateFlag sets the text/template for --format template.
+	TemplateFlag = "template"
+
+	// QueryFlag sets a JMESPath expression that filters JSON and YAML
+	// output.
+	QueryFlag = "query"
+)
+
+// outputTemplate and outputQuery are set by Prepare from --template and
+// --query.
+var outputTemplate, outputQuery string
+
+// FormatterOptions returns the output.Options implied by the root flags,
+// followed by opts. Commands pass them to output.NewFormatter.
+func FormatterOptions(opts ...output.Option) []output.Option {
+	var root []output.Option
+	if outputTemplate != "" {
+		root = append(root, output.WithTemplate(outputTemplate))
+	}
+	if outputQuery != "" {
+		root = append(root, output.WithQuery(outputQuery))
+	}
+	if noColor {
+		root = append(root, output.WithColor(false))
+	}
+	return append(root, opts...)
+}
+
+// applyTemplate reads --template from cmd. A template implies --format
+// template unless another format was given explicitly, which is an error.
+func applyTemplate(cmd *cobra.Command) error {
+	outputTemplate = ""
+	if flag := cmd.Flags().Lookup(TemplateFlag); flag == nil || !flag.Changed {
+		return nil
+	}
+	text, _ := cmd.Flags().GetString(TemplateFlag)
+	if _, err := output.ParseTemplate(text); err != nil {
+		return fmt.Errorf("--template: %w", err)
+	}
+
+	format := cmd.Flags().Lookup(config.KeyFormat)
+	if format == nil || format.DefValue != config.DefaultFormat {
+		return fmt.Errorf("--template is not supported by %s", CommandPath(cmd))
+	}
+	if !format.Changed {
+		if err := cmd.Flags().Set(config.KeyFormat, string(output.FormatTemplate)); err != nil {
+			return err
+		}
+	} else if format.Value.String() != string(output.FormatTemplate) {
+		return fmt.Errorf("--template requires --format template, not %s", format.Value.String())
+	}
+
+	outputTemplate = text
+	return nil
+}
+
+// applyQuery reads --query from cmd. A query implies --format json unless
+// JSON or YAML was given explicitly; other formats are an error.
+func applyQuery(cmd *cobra.Command) error {
+	outputQuery = ""
+	if flag := cmd.Flags().Lookup(QueryFlag); flag == nil || !flag.Changed {
+		return nil
+	}
+	expression, _ := cmd.Flags().GetString(QueryFlag)
+	if _, err := output.ParseQuery(expression); err != nil {
+		return fmt.Errorf("--query: %w", err)
+	}
+
+	format := cmd.Flags().Lookup(config.KeyFormat)
+	if format == nil || format.DefValue != config.DefaultFormat {
+		return fmt.Errorf("--query is not supported by %s", CommandPath(cmd))
+	}
+	switch {
+	case !format.Changed:
+		if err := cmd.Flags().Set(config.KeyFormat, string(output.FormatJSON)); err != nil {
+			return err
+		}
+	case format.Value.String() == string(output.FormatTemplate):
+		return fmt.Errorf("--query cannot be combined with --template")
+	case format.Value.String() != string(output.FormatJSON) && format.Value.String() != string(output.FormatYAML):
+		return fmt.Errorf("--query requires --format json or yaml, not %s", format.Value.String())
+	}
+
+	outputQuery = expression
+	return nil
+}
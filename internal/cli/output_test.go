@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrepare_Template(t *testing.T) {
+	setupConfigDir(t, "format: json\n")
+	t.Cleanup(func() { outputTemplate = "" })
+
+	_, cmd := newTestTree("list")
+	cmd.Flags().String(TemplateFlag, "", "")
+	if err := cmd.Flags().Set(TemplateFlag, "{{.ID}}"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	// --template implies --format template, even over config.yaml
+	if got := cmd.Flags().Lookup("format").Value.String(); got != "template" {
+		t.Errorf("--format = %q, want template", got)
+	}
+	if got := len(FormatterOptions()); got != 1 {
+		t.Errorf("FormatterOptions() returned %d options, want 1", got)
+	}
+}
+
+func TestPrepare_TemplateErrors(t *testing.T) {
+	setupConfigDir(t, "")
+	t.Cleanup(func() { outputTemplate = "" })
+
+	tests := []struct {
+		name     string
+		format   string
+		template string
+		wantErr  string
+	}{
+		{name: "other format", format: "json", template: "{{.ID}}", wantErr: "requires --format template"},
+		{name: "bad syntax", template: "{{.ID", wantErr: "--template: parse template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, cmd := newTestTree("list")
+			cmd.Flags().String(TemplateFlag, "", "")
+			_ = cmd.Flags().Set(TemplateFlag, tt.template)
+			if tt.format != "" {
+				_ = cmd.Flags().Set("format", tt.format)
+			}
+
+			err := Prepare(cmd, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Prepare() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPrepare_Query(t *testing.T) {
+	setupConfigDir(t, "")
+	t.Cleanup(func() { outputQuery = "" })
+
+	_, cmd := newTestTree("list")
+	cmd.Flags().String(QueryFlag, "", "")
+	if err := cmd.Flags().Set(QueryFlag, "data[*].id"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	// --query implies --format json
+	if got := cmd.Flags().Lookup("format").Value.String(); got != "json" {
+		t.Errorf("--format = %q, want json", got)
+	}
+	if got := len(FormatterOptions()); got != 1 {
+		t.Errorf("FormatterOptions() returned %d options, want 1", got)
+	}
+
+	_, cmd = newTestTree("list")
+	cmd.Flags().String(QueryFlag, "", "")
+	_ = cmd.Flags().Set(QueryFlag, "data[*].id")
+	_ = cmd.Flags().Set("format", "table")
+	if err := Prepare(cmd, nil); err == nil || !strings.Contains(err.Error(), "requires --format json or yaml") {
+		t.Errorf("Prepare() with --format table error = %v", err)
+	}
+}
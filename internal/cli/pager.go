@@ -0,0 +1,205 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// NoPagerFlag is the root persistent flag that turns off the pager.
+const NoPagerFlag = "no-pager"
+
+// defaultPager is run when PAGER is not set.
+const defaultPager = "less"
+
+// stdoutHeight returns the height of the terminal on stdout. It is a test
+// hook.
+var stdoutHeight = terminalHeight
+
+// activePager is the pager installed by Prepare, closed by ClosePager.
+var activePager *pagerWriter
+
+// terminalHeight returns the number of rows of the terminal on stdout, or
+// 0 if stdout is not a terminal.
+func terminalHeight() int {
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return 0
+	}
+	_, height, err := term.GetSize(fd)
+	if err != nil {
+		return 0
+	}
+	return height
+}
+
+// pagerCommand returns the pager command line from PAGER, or nil if
+// paging is disabled by an empty PAGER or PAGER=cat.
+func pagerCommand() []string {
+	pager, ok := os.LookupEnv("PAGER")
+	if !ok {
+		pager = defaultPager
+	}
+	fields := strings.Fields(pager)
+	if len(fields) == 0 || fields[0] == "cat" {
+		return nil
+	}
+	return fields
+}
+
+// applyPager routes the text output of read-only commands through the
+// pager when stdout is a terminal, unless --no-pager is given. Output is
+// held back until it fills the terminal, so short output is printed
+// directly.
+func applyPager(cmd *cobra.Command) {
+	activePager = nil
+	if noPager, _ := cmd.Flags().GetBool(NoPagerFlag); noPager {
+		return
+	}
+	// Commands that change state may prompt or run for a long time
+	if !IsReadOnly(CommandPath(cmd)) || cmd.OutOrStdout() != os.Stdout {
+		return
+	}
+	if flag := cmd.Flags().Lookup(config.KeyFormat); flag != nil {
+		if format := output.Format(flag.Value.String()); format != output.FormatText && format != output.FormatTable && format != output.FormatWide {
+			return
+		}
+	}
+
+	height := stdoutHeight()
+	command := pagerCommand()
+	if height <= 0 || command == nil {
+		return
+	}
+
+	activePager = newPagerWriter(os.Stdout, height, command)
+	cmd.SetOut(activePager)
+}
+
+// ClosePager prints output still held back for the pager, or waits for
+// the user to quit the pager. main calls it after the command has run.
+func ClosePager() {
+	if activePager == nil {
+		return
+	}
+	if err := activePager.Close(); err != nil {
+		Warnf("pager: %v", err)
+	}
+	activePager = nil
+}
+
+// pagerWriter holds back output until it no longer fits in height rows,
+// then starts the pager and writes everything to it.
+type pagerWriter struct {
+	out     io.Writer
+	height  int
+	command []string
+
+	buf   bytes.Buffer
+	lines int
+
+	pager *exec.Cmd
+	stdin io.WriteCloser
+
+	// direct is set when the pager could not be started; output is then
+	// written to out as usual.
+	direct bool
+}
+
+// newPagerWriter returns a pagerWriter for a terminal of height rows that
+// runs command as the pager.
+func newPagerWriter(out io.Writer, height int, command []string) *pagerWriter {
+	return &pagerWriter{out: out, height: height, command: command}
+}
+
+// Write buffers p or passes it to the pager.
+func (w *pagerWriter) Write(p []byte) (int, error) {
+	switch {
+	case w.stdin != nil:
+		if _, err := w.stdin.Write(p); err != nil && !errors.Is(err, syscall.EPIPE) {
+			return 0, err
+		}
+		// The user quit the pager; drop the rest
+		return len(p), nil
+	case w.direct:
+		return w.out.Write(p)
+	}
+
+	w.buf.Write(p)
+	w.lines += bytes.Count(p, []byte("\n"))
+	// Leave a row for the shell prompt
+	if w.lines < w.height-1 {
+		return len(p), nil
+	}
+
+	if err := w.start(); err != nil {
+		Warnf("could not start pager %q: %v (use --%s to disable)", strings.Join(w.command, " "), err, NoPagerFlag)
+		w.direct = true
+	}
+	if err := w.flushBuffer(); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// start runs the pager with its input connected to the writer.
+func (w *pagerWriter) start() error {
+	pager := exec.Command(w.command[0], w.command[1:]...) //nolint:gosec // The pager is chosen by the user
+	pager.Stdout = w.out
+	pager.Stderr = os.Stderr
+	pager.Env = os.Environ()
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		// Quit if the output fits after all, keep it on screen, and pass
+		// color through
+		pager.Env = append(pager.Env, "LESS=FRX")
+	}
+
+	stdin, err := pager.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := pager.Start(); err != nil {
+		return err
+	}
+	w.pager = pager
+	w.stdin = stdin
+	return nil
+}
+
+// flushBuffer writes the held-back output to the pager or out.
+func (w *pagerWriter) flushBuffer() error {
+	data := w.buf.Bytes()
+	w.buf.Reset()
+	_, err := w.Write(data)
+	return err
+}
+
+// Close prints output that fit on the terminal, or waits for the pager to
+// exit.
+func (w *pagerWriter) Close() error {
+	if w.stdin == nil {
+		w.direct = true
+		return w.flushBuffer()
+	}
+
+	_ = w.stdin.Close()
+	if err := w.pager.Wait(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			// Pagers may exit non-zero when quit early
+			return nil
+		}
+		return fmt.Errorf("wait for pager: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestPagerWriter_ShortOutput(t *testing.T) {
+	var out bytes.Buffer
+	w := newPagerWriter(&out, 10, []string{"tr", "a-z", "A-Z"})
+
+	fmt.Fprintln(w, "first")
+	fmt.Fprintln(w, "second")
+	if out.Len() != 0 {
+		t.Errorf("output written before Close: %q", out.String())
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got, want := out.String(), "first\nsecond\n"; got != want {
+		t.Errorf("output = %q, want %q (not paged)", got, want)
+	}
+}
+
+func TestPagerWriter_LongOutput(t *testing.T) {
+	var out bytes.Buffer
+	w := newPagerWriter(&out, 3, []string{"tr", "a-z", "A-Z"})
+
+	for i := 0; i < 5; i++ {
+		fmt.Fprintf(w, "line %d\n", i)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got, want := out.String(), "LINE 0\nLINE 1\nLINE 2\nLINE 3\nLINE 4\n"; got != want {
+		t.Errorf("output = %q, want %q (paged)", got, want)
+	}
+}
+
+func TestPagerWriter_PagerMissing(t *testing.T) {
+	var out bytes.Buffer
+	w := newPagerWriter(&out, 2, []string{"globus-gcs-no-such-pager"})
+
+	for i := 0; i < 3; i++ {
+		fmt.Fprintf(w, "line %d\n", i)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got, want := out.String(), "line 0\nline 1\nline 2\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestPagerCommand(t *testing.T) {
+	tests := []struct {
+		pager string
+		unset bool
+		want  string
+	}{
+		{unset: true, want: defaultPager},
+		{pager: "most -s", want: "most -s"},
+		{pager: "cat", want: ""},
+		{pager: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pager, func(t *testing.T) {
+			t.Setenv("PAGER", tt.pager)
+			if tt.unset {
+				_ = os.Unsetenv("PAGER")
+			}
+			if got := strings.Join(pagerCommand(), " "); got != tt.want {
+				t.Errorf("pagerCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyPager(t *testing.T) {
+	t.Setenv("PAGER", "less")
+	stdoutHeight = func() int { return 24 }
+	t.Cleanup(func() {
+		stdoutHeight = terminalHeight
+		activePager = nil
+	})
+
+	newCmd := func(path ...string) *cobra.Command {
+		root := &cobra.Command{Use: "globus-connect-server"}
+		root.PersistentFlags().Bool(NoPagerFlag, false, "")
+		parent := root
+		for _, name := range path {
+			child := &cobra.Command{Use: name}
+			parent.AddCommand(child)
+			parent = child
+		}
+		parent.Flags().String("format", "text", "")
+		return parent
+	}
+
+	tests := []struct {
+		name string
+		cmd  *cobra.Command
+		args []string
+		want bool
+	}{
+		{name: "read-only list", cmd: newCmd("collection", "list"), want: true},
+		{name: "no-pager", cmd: newCmd("collection", "list"), args: []string{"--no-pager"}},
+		{name: "json", cmd: newCmd("collection", "list"), args: []string{"--format", "json"}},
+		{name: "mutating command", cmd: newCmd("collection", "delete")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			applyPager(tt.cmd)
+			if got := activePager != nil; got != tt.want {
+				t.Errorf("pager installed = %v, want %v", got, tt.want)
+			}
+			if tt.want && tt.cmd.OutOrStdout() != activePager {
+				t.Error("command output not routed to the pager")
+			}
+		})
+	}
+}
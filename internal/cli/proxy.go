@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+)
+
+// ProxyFlag is the root persistent flag that names the proxy for GCS
+// Manager API requests.
+const ProxyFlag = "proxy"
+
+// proxyOptions returns the gcs.ClientOptions for the effective proxy
+// setting. Without one, the HTTPS_PROXY environment variable applies.
+func proxyOptions() ([]gcs.ClientOption, error) {
+	setting, _ := effective.Lookup(config.KeyProxy)
+	if setting.Value == "" {
+		return nil, nil
+	}
+	if _, err := gcs.ParseProxyURL(setting.Value); err != nil {
+		return nil, fmt.Errorf("%w (from %s)", err, setting.Origin)
+	}
+	return []gcs.ClientOption{gcs.WithProxy(setting.Value)}, nil
+}
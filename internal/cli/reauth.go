@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/i18n"
+	"golang.org/x/term"
+)
+
+// Relogin runs the interactive login flow for a profile (or its reporter
+// token). main sets it to the login command's implementation; when nil,
+// a token that cannot be refreshed is never replaced interactively.
+var Relogin func(ctx context.Context, profile string, reporter bool) error
+
+// Test hooks.
+var (
+	refreshStoredToken           = refreshWithAuthClient
+	interactive                  = isTerminal
+	promptIn           io.Reader = os.Stdin
+	promptOut          io.Writer = os.Stderr
+)
+
+// loadedToken records which stored token LoadToken returned last, so that
+// the token can be refreshed or replaced if the server rejects it, and
+// the access tokens of its resource servers and its endpoint IDs.
+//
+// token is the stored token itself. GCS clients take their access tokens
+// from it through liveAccessToken, which refreshes it shortly before it
+// expires; refreshFailed stops further attempts once a refresh failed.
+// tokenMu guards both.
+var loadedToken struct {
+	profile       string
+	reporter      bool
+	accessTokens  map[string]string
+	endpoints     map[string]string
+	token         *auth.TokenInfo
+	refreshFailed bool
+}
+
+var tokenMu sync.Mutex
+
+// liveAccessToken implements the GCS client's TokenProvider for a client
+// talking to resourceServer (empty if unknown). It returns the loaded
+// token's access token, refreshing the stored token first if it expires
+// within a few minutes, so that long operations outlive a token. If the
+// refresh fails, the old token is used and reauthenticate takes over when
+// the server rejects it.
+func liveAccessToken(ctx context.Context, resourceServer string) string {
+	tokenMu.Lock()
+	defer tokenMu.Unlock()
+
+	token := loadedToken.token
+	if token.IsValid() || !token.CanRefresh() || loadedToken.refreshFailed {
+		return accessTokenFor(token, resourceServer)
+	}
+
+	refreshed, err := refreshStoredToken(ctx, loadedStorage())
+	if err != nil {
+		loadedToken.refreshFailed = true
+		Warnf("access token for profile %q expires soon and could not be refreshed: %v", loadedToken.profile, err)
+		return accessTokenFor(token, resourceServer)
+	}
+	loadedToken.token = refreshed
+	return accessTokenFor(refreshed, resourceServer)
+}
+
+// loadedStorage returns the name under which the loaded token is stored.
+func loadedStorage() string {
+	if loadedToken.reporter {
+		return auth.ReporterProfile(loadedToken.profile)
+	}
+	return loadedToken.profile
+}
+
+// replaceLoadedToken makes token the one liveAccessToken serves.
+func replaceLoadedToken(token *auth.TokenInfo) {
+	tokenMu.Lock()
+	defer tokenMu.Unlock()
+	loadedToken.token = token
+	loadedToken.refreshFailed = false
+}
+
+// reauthenticate implements the GCS client's TokenRefresher for a client
+// talking to resourceServer (empty if unknown). It is called when a
+// request fails with HTTP 401 although the token has not expired, which
+// usually means it was revoked.
+//
+// The stored token is refreshed once. If that fails and the CLI runs on a
+// terminal, the user is offered to log in again so that a half-finished
+// batch can resume instead of aborting.
+func reauthenticate(ctx context.Context, resourceServer string) (string, error) {
+	if suppliedToken != "" {
+		return "", fmt.Errorf("the access token from %s was rejected; supply a new one", suppliedFrom)
+	}
+	profile, reporter := loadedToken.profile, loadedToken.reporter
+	if profile == "" {
+		return "", errors.New("no stored token to refresh")
+	}
+
+	storage := loadedStorage()
+
+	token, err := refreshStoredToken(ctx, storage)
+	if err == nil {
+		Warnf("access token for profile %q was rejected; refreshed it and retrying", profile)
+		replaceLoadedToken(token)
+		return accessTokenFor(token, resourceServer), nil
+	}
+
+	if Relogin == nil || !interactive() {
+		return "", fmt.Errorf("%w (use 'login --profile %s' to log in again)", err, profile)
+	}
+
+	fmt.Fprintf(promptOut, "The access token for profile %q was rejected and could not be refreshed (%v).\n", profile, err)
+	fmt.Fprint(promptOut, i18n.T("Log in again to continue? [y/N]: "))
+	answer, _ := bufio.NewReader(promptIn).ReadString('\n')
+	if !i18n.IsYes(answer) {
+		return "", errors.New("re-login declined")
+	}
+
+	if err := Relogin(ctx, profile, reporter); err != nil {
+		return "", fmt.Errorf("login: %w", err)
+	}
+	token, err = auth.LoadToken(storage)
+	if err != nil {
+		return "", err
+	}
+	replaceLoadedToken(token)
+	return accessTokenFor(token, resourceServer), nil
+}
+
+// accessTokenFor returns the access token of token for resourceServer,
+// or its primary access token if there is none.
+func accessTokenFor(token *auth.TokenInfo, resourceServer string) string {
+	if other := token.ForResourceServer(resourceServer); other != nil {
+		return other.AccessToken
+	}
+	return token.AccessToken
+}
+
+// refreshWithAuthClient refreshes the stored token named storage.
+func refreshWithAuthClient(ctx context.Context, storage string) (*auth.TokenInfo, error) {
+	authClient, err := NewAuthClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return auth.RefreshToken(ctx, storage, authClient)
+}
+
+// isTerminal reports whether both stdin and stderr are terminals, so that
+// the user can be prompted.
+func isTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stderr.Fd()))
+}
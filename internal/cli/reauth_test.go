@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+)
+
+// stubReauth replaces the re-authentication hooks for a test.
+func stubReauth(t *testing.T, refresh func(context.Context, string) (*auth.TokenInfo, error), tty bool, answer string) *bytes.Buffer {
+	t.Helper()
+
+	oldRefresh, oldInteractive, oldIn, oldOut, oldRelogin, oldLoaded, oldWarn :=
+		refreshStoredToken, interactive, promptIn, promptOut, Relogin, loadedToken, warnOut
+	t.Cleanup(func() {
+		refreshStoredToken, interactive, promptIn, promptOut, Relogin, loadedToken, warnOut =
+			oldRefresh, oldInteractive, oldIn, oldOut, oldRelogin, oldLoaded, oldWarn
+	})
+
+	prompt := &bytes.Buffer{}
+	refreshStoredToken = refresh
+	interactive = func() bool { return tty }
+	promptIn = strings.NewReader(answer)
+	promptOut = prompt
+	warnOut = &bytes.Buffer{}
+	loadedToken.profile, loadedToken.reporter = "default", false
+	return prompt
+}
+
+func TestReauthenticate_Refresh(t *testing.T) {
+	var refreshed string
+	stubReauth(t, func(_ context.Context, storage string) (*auth.TokenInfo, error) {
+		refreshed = storage
+		return &auth.TokenInfo{AccessToken: "new-token"}, nil
+	}, false, "")
+	loadedToken.reporter = true
+
+	token, err := reauthenticate(context.Background(), "")
+	if err != nil {
+		t.Fatalf("reauthenticate() error = %v", err)
+	}
+	if token != "new-token" {
+		t.Errorf("reauthenticate() = %q, want new-token", token)
+	}
+	if refreshed != auth.ReporterProfile("default") {
+		t.Errorf("refreshed %q, want the reporter token", refreshed)
+	}
+}
+
+func TestReauthenticate_ResourceServer(t *testing.T) {
+	stubReauth(t, func(context.Context, string) (*auth.TokenInfo, error) {
+		return &auth.TokenInfo{
+			AccessToken:    "new-transfer-token",
+			ResourceServer: "transfer.api.globus.org",
+			OtherTokens:    map[string]*auth.TokenInfo{"ep-1": {AccessToken: "new-gcs-token"}},
+		}, nil
+	}, false, "")
+
+	for resourceServer, want := range map[string]string{"ep-1": "new-gcs-token", "ep-2": "new-transfer-token"} {
+		token, err := reauthenticate(context.Background(), resourceServer)
+		if err != nil {
+			t.Fatalf("reauthenticate(%s) error = %v", resourceServer, err)
+		}
+		if token != want {
+			t.Errorf("reauthenticate(%s) = %q, want %q", resourceServer, token, want)
+		}
+	}
+}
+
+func TestReauthenticate_NonInteractive(t *testing.T) {
+	prompt := stubReauth(t, func(context.Context, string) (*auth.TokenInfo, error) {
+		return nil, errors.New("refresh token revoked")
+	}, false, "")
+	Relogin = func(context.Context, string, bool) error {
+		t.Error("Relogin called without a terminal")
+		return nil
+	}
+
+	_, err := reauthenticate(context.Background(), "")
+	if err == nil || !strings.Contains(err.Error(), "refresh token revoked") {
+		t.Errorf("reauthenticate() error = %v, want the refresh error", err)
+	}
+	if prompt.Len() > 0 {
+		t.Errorf("reauthenticate() prompted without a terminal: %q", prompt.String())
+	}
+}
+
+func TestReauthenticate_Declined(t *testing.T) {
+	prompt := stubReauth(t, func(context.Context, string) (*auth.TokenInfo, error) {
+		return nil, errors.New("refresh token revoked")
+	}, true, "n\n")
+	Relogin = func(context.Context, string, bool) error {
+		t.Error("Relogin called after the prompt was declined")
+		return nil
+	}
+
+	_, err := reauthenticate(context.Background(), "")
+	if err == nil || !strings.Contains(err.Error(), "declined") {
+		t.Errorf("reauthenticate() error = %v, want declined", err)
+	}
+	if !strings.Contains(prompt.String(), "Log in again to continue?") {
+		t.Errorf("reauthenticate() prompt = %q, want a re-login prompt", prompt.String())
+	}
+}
+
+func TestReauthenticate_ReloginFails(t *testing.T) {
+	stubReauth(t, func(context.Context, string) (*auth.TokenInfo, error) {
+		return nil, errors.New("refresh token revoked")
+	}, true, "yes\n")
+
+	var gotProfile string
+	Relogin = func(_ context.Context, profile string, _ bool) error {
+		gotProfile = profile
+		return errors.New("browser closed")
+	}
+
+	_, err := reauthenticate(context.Background(), "")
+	if err == nil || !strings.Contains(err.Error(), "browser closed") {
+		t.Errorf("reauthenticate() error = %v, want the login error", err)
+	}
+	if gotProfile != "default" {
+		t.Errorf("Relogin profile = %q, want default", gotProfile)
+	}
+}
+
+func TestLiveAccessToken(t *testing.T) {
+	refreshes := 0
+	stubReauth(t, func(_ context.Context, storage string) (*auth.TokenInfo, error) {
+		refreshes++
+		if storage != "default" {
+			t.Errorf("refreshed %q, want default", storage)
+		}
+		return &auth.TokenInfo{
+			AccessToken:  "new-token",
+			RefreshToken: "refresh",
+			ExpiresAt:    time.Now().Add(time.Hour),
+			OtherTokens: map[string]*auth.TokenInfo{
+				"ep-1": {AccessToken: "new-gcs-token"},
+			},
+		}, nil
+	}, false, "")
+	replaceLoadedToken(&auth.TokenInfo{
+		AccessToken:  "old-token",
+		RefreshToken: "refresh",
+		ExpiresAt:    time.Now().Add(time.Minute),
+	})
+
+	for range 2 {
+		if got := liveAccessToken(context.Background(), "ep-1"); got != "new-gcs-token" {
+			t.Errorf("liveAccessToken() = %q, want new-gcs-token", got)
+		}
+	}
+	if refreshes != 1 {
+		t.Errorf("refreshed %d times, want once", refreshes)
+	}
+}
+
+func TestLiveAccessToken_RefreshFails(t *testing.T) {
+	refreshes := 0
+	stubReauth(t, func(context.Context, string) (*auth.TokenInfo, error) {
+		refreshes++
+		return nil, errors.New("auth service down")
+	}, false, "")
+	warnings := &bytes.Buffer{}
+	warnOut = warnings
+	replaceLoadedToken(&auth.TokenInfo{
+		AccessToken:  "old-token",
+		RefreshToken: "refresh",
+		ExpiresAt:    time.Now().Add(time.Minute),
+	})
+
+	for range 2 {
+		if got := liveAccessToken(context.Background(), ""); got != "old-token" {
+			t.Errorf("liveAccessToken() = %q, want old-token", got)
+		}
+	}
+	if refreshes != 1 {
+		t.Errorf("refreshed %d times, want once", refreshes)
+	}
+	if !strings.Contains(warnings.String(), "auth service down") {
+		t.Errorf("warnings = %q, want the refresh error", warnings.String())
+	}
+}
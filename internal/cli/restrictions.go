@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+// RestrictionsFile is the site restriction file for shared admin hosts.
+// Its location is fixed (not configurable) so that users cannot point the
+// CLI at a more permissive file.
+const RestrictionsFile = "/etc/globus-gcs-cli/restrictions.yaml"
+
+// Test hooks.
+var (
+	restrictionsPath       = RestrictionsFile
+	geteuid                = os.Geteuid
+	checkRestrictionsOwner = requireRootOwner
+)
+
+// Restrictions lists commands and flags that non-root users may not use,
+// and the confirmation risk of commands for all users (see ClassifyRisk).
+//
+//	commands:
+//	  - endpoint cleanup
+//	  - endpoint setup
+//	flags:
+//	  - insecure-skip-verify
+//	  - collection delete --force
+//	exempt_groups:
+//	  - gcs-admins
+//	message: Contact research-computing@example.edu for endpoint changes.
+//	risk:
+//	  role delete: high
+type Restrictions struct {
+	// Commands are command paths without the root name. An entry also
+	// restricts its subcommands ("endpoint" restricts every endpoint
+	// command).
+	Commands []string `yaml:"commands,omitempty"`
+
+	// Flags are flag names, optionally preceded by a command path to
+	// restrict the flag for that command only.
+	Flags []string `yaml:"flags,omitempty"`
+
+	// ExemptGroups are Unix groups whose members are not restricted.
+	ExemptGroups []string `yaml:"exempt_groups,omitempty"`
+
+	// Message is appended to the error shown when a command is refused.
+	Message string `yaml:"message,omitempty"`
+
+	// Risk overrides the risk of commands, keyed by command path. Unlike
+	// the other settings it applies to root and exempt users too.
+	Risk map[string]Risk `yaml:"risk,omitempty"`
+}
+
+// LoadRestrictions loads the restriction file at path.
+//
+// A missing file means no restrictions and returns nil. The file must be
+// owned by root and not writable by group or others; otherwise it cannot
+// be trusted and an error is returned.
+func LoadRestrictions(path string) (*Restrictions, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read restrictions file: %w", err)
+	}
+
+	if err := checkRestrictionsOwner(info); err != nil {
+		return nil, fmt.Errorf("restrictions file %s: %w", path, err)
+	}
+	if info.Mode().Perm()&0o022 != 0 {
+		return nil, fmt.Errorf("restrictions file %s is writable by group or others", path)
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // Fixed, root-owned system path
+	if err != nil {
+		return nil, fmt.Errorf("read restrictions file: %w", err)
+	}
+
+	var r Restrictions
+	if err := yaml.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parse restrictions file %s: %w", path, err)
+	}
+	if err := validateRisks(r.Risk); err != nil {
+		return nil, fmt.Errorf("restrictions file %s: %w", path, err)
+	}
+
+	return &r, nil
+}
+
+// Check returns an error if the command at path, with the given flags
+// set, is restricted.
+func (r *Restrictions) Check(path string, changed func(flag string) bool) error {
+	if r == nil {
+		return nil
+	}
+
+	for _, restricted := range r.Commands {
+		restricted = strings.Join(strings.Fields(restricted), " ")
+		if restricted != "" && (path == restricted || strings.HasPrefix(path, restricted+" ")) {
+			return r.refuse("%s is not permitted", path)
+		}
+	}
+
+	for _, entry := range r.Flags {
+		cmdPath, flag := splitFlagRestriction(entry)
+		if flag == "" || (cmdPath != "" && cmdPath != path) {
+			continue
+		}
+		if changed(flag) {
+			return r.refuse("--%s is not permitted with %s", flag, path)
+		}
+	}
+
+	return nil
+}
+
+// refuse builds the error for a restricted invocation.
+func (r *Restrictions) refuse(format string, args ...interface{}) error {
+	msg := fmt.Sprintf(format, args...) + " for non-root users on this host (see " + RestrictionsFile + ")"
+	if r.Message != "" {
+		msg += ": " + r.Message
+	}
+	return errors.New(msg)
+}
+
+// Exempt reports whether u belongs to one of the exempt groups.
+func (r *Restrictions) Exempt(u *user.User) bool {
+	if r == nil || u == nil || len(r.ExemptGroups) == 0 {
+		return false
+	}
+
+	gids, err := u.GroupIds()
+	if err != nil {
+		return false
+	}
+
+	for _, gid := range gids {
+		group, err := user.LookupGroupId(gid)
+		if err != nil {
+			continue
+		}
+		for _, exempt := range r.ExemptGroups {
+			if group.Name == exempt || gid == exempt {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitFlagRestriction splits "collection delete --force" into the
+// command path and flag name. A bare flag ("force" or "--force") applies
+// to every command.
+func splitFlagRestriction(entry string) (string, string) {
+	fields := strings.Fields(entry)
+	if len(fields) == 0 {
+		return "", ""
+	}
+
+	flag := strings.TrimLeft(fields[len(fields)-1], "-")
+	return strings.Join(fields[:len(fields)-1], " "), flag
+}
+
+// enforceRestrictions refuses cmd if the site restriction file forbids it
+// for the current user. Root is never restricted.
+func enforceRestrictions(cmd *cobra.Command) error {
+	if geteuid() == 0 {
+		return nil
+	}
+
+	r, err := LoadRestrictions(restrictionsPath)
+	if err != nil || r == nil {
+		return err
+	}
+
+	if u, err := user.Current(); err == nil && r.Exempt(u) {
+		return nil
+	}
+
+	return r.Check(CommandPath(cmd), cmd.Flags().Changed)
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package cli
+
+import "os"
+
+// requireRootOwner is a no-op on platforms without Unix file ownership.
+// Restrictions are not enforced there anyway, since os.Geteuid returns -1.
+func requireRootOwner(_ os.FileInfo) error {
+	return nil
+}
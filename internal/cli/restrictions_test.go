@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupRestrictions installs a restriction file and runs as a non-root
+// user for the duration of the test.
+func setupRestrictions(t *testing.T, contents string, mode os.FileMode) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "restrictions.yaml")
+	if err := os.WriteFile(path, []byte(contents), mode); err != nil {
+		t.Fatalf("write restrictions: %v", err)
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		t.Fatalf("chmod restrictions: %v", err)
+	}
+
+	oldPath, oldEuid, oldOwner := restrictionsPath, geteuid, checkRestrictionsOwner
+	restrictionsPath = path
+	geteuid = func() int { return 1000 }
+	checkRestrictionsOwner = func(os.FileInfo) error { return nil }
+	t.Cleanup(func() {
+		restrictionsPath, geteuid, checkRestrictionsOwner = oldPath, oldEuid, oldOwner
+	})
+	return path
+}
+
+func TestLoadRestrictions_Missing(t *testing.T) {
+	r, err := LoadRestrictions(filepath.Join(t.TempDir(), "restrictions.yaml"))
+	if err != nil {
+		t.Fatalf("LoadRestrictions() error = %v", err)
+	}
+	if r != nil {
+		t.Errorf("LoadRestrictions() = %+v, want nil", r)
+	}
+}
+
+func TestLoadRestrictions_WorldWritable(t *testing.T) {
+	path := setupRestrictions(t, "commands: [endpoint cleanup]\n", 0o666)
+
+	if _, err := LoadRestrictions(path); err == nil {
+		t.Error("LoadRestrictions() expected error for world-writable file, got nil")
+	}
+}
+
+func TestLoadRestrictions_NotRootOwned(t *testing.T) {
+	path := setupRestrictions(t, "commands: [endpoint cleanup]\n", 0o644)
+	checkRestrictionsOwner = requireRootOwner
+	if os.Geteuid() == 0 {
+		t.Skip("test files are root-owned when running as root")
+	}
+
+	if _, err := LoadRestrictions(path); err == nil {
+		t.Error("LoadRestrictions() expected error for file not owned by root, got nil")
+	}
+}
+
+func TestRestrictions_Check(t *testing.T) {
+	r := &Restrictions{
+		Commands: []string{"endpoint cleanup", "oidc"},
+		Flags:    []string{"insecure-skip-verify", "collection delete --force"},
+		Message:  "ask the storage team",
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		flags   []string
+		wantErr bool
+	}{
+		{name: "restricted command", path: "endpoint cleanup", wantErr: true},
+		{name: "restricted group", path: "oidc update", wantErr: true},
+		{name: "allowed command", path: "endpoint show"},
+		{name: "prefix is not a match", path: "endpoint cleanup-extra"},
+		{name: "global flag", path: "collection list", flags: []string{"insecure-skip-verify"}, wantErr: true},
+		{name: "command flag", path: "collection delete", flags: []string{"force"}, wantErr: true},
+		{name: "command flag elsewhere", path: "node delete", flags: []string{"force"}},
+		{name: "flag not set", path: "collection delete"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := func(flag string) bool {
+				for _, f := range tt.flags {
+					if f == flag {
+						return true
+					}
+				}
+				return false
+			}
+
+			err := r.Check(tt.path, changed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "ask the storage team") {
+				t.Errorf("Check() error = %v, want it to include the site message", err)
+			}
+		})
+	}
+}
+
+func TestPrepare_Restricted(t *testing.T) {
+	setupConfigDir(t, "")
+	setupRestrictions(t, "commands:\n  - collection delete\n", 0o644)
+
+	_, cmd := newTestTree("delete")
+	if err := Prepare(cmd, nil); err == nil {
+		t.Error("Prepare() expected error for restricted command, got nil")
+	}
+
+	_, cmd = newTestTree("list")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Errorf("Prepare() error = %v for unrestricted command", err)
+	}
+}
+
+func TestPrepare_RootUnrestricted(t *testing.T) {
+	setupConfigDir(t, "")
+	setupRestrictions(t, "commands:\n  - collection delete\n", 0o644)
+	geteuid = func() int { return 0 }
+
+	_, cmd := newTestTree("delete")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Errorf("Prepare() error = %v, want root to be unrestricted", err)
+	}
+}
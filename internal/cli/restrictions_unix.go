@@ -0,0 +1,21 @@
+//go:build unix
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// requireRootOwner returns an error unless the file is owned by root.
+func requireRootOwner(info os.FileInfo) error {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fmt.Errorf("cannot determine file owner")
+	}
+	if st.Uid != 0 {
+		return fmt.Errorf("not owned by root (uid %d)", st.Uid)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+// ConfirmWithFlag is the root persistent flag that confirms a high-risk
+// command non-interactively by naming the resource it acts on.
+const ConfirmWithFlag = "confirm-with"
+
+// Risk is how much confirmation a command requires before it runs.
+type Risk string
+
+const (
+	// RiskLow commands run without confirmation.
+	RiskLow Risk = "low"
+
+	// RiskMedium commands ask "[y/N]" on a terminal. --force, where the
+	// command has it, skips the question.
+	RiskMedium Risk = "medium"
+
+	// RiskHigh commands require the resource name to be typed, or given
+	// with --confirm-with. --force does not skip the confirmation.
+	RiskHigh Risk = "high"
+)
+
+// highRiskCommands are destructive commands that are hard to recover
+// from, keyed by command path.
+var highRiskCommands = map[string]bool{
+	"collection batch-delete": true,
+	"endpoint cleanup":        true,
+	"endpoint set-owner":      true,
+	"node cleanup":            true,
+	"oidc delete":             true,
+	"storage-gateway delete":  true,
+}
+
+// mediumRiskVerbs are the last words of command paths that remove or
+// disable something, e.g. "role delete".
+var mediumRiskVerbs = map[string]bool{
+	"clear":              true,
+	"delete":             true,
+	"disable":            true,
+	"reset-owner-string": true,
+	"s3-keys-delete":     true,
+	"set-owner":          true,
+	"set-owner-string":   true,
+}
+
+// ClassifyRisk returns the risk of the command at path. overrides, from
+// the restrictions file, take precedence over the built-in
+// classification: read-only commands are low risk, commands in
+// highRiskCommands are high risk, and other commands that delete or
+// disable something are medium risk.
+func ClassifyRisk(path string, overrides map[string]Risk) Risk {
+	if risk, ok := overrides[path]; ok {
+		return risk
+	}
+	if IsReadOnly(path) {
+		return RiskLow
+	}
+	if highRiskCommands[path] {
+		return RiskHigh
+	}
+	fields := strings.Fields(path)
+	if len(fields) > 0 && mediumRiskVerbs[fields[len(fields)-1]] {
+		return RiskMedium
+	}
+	return RiskLow
+}
+
+// validateRisks returns an error if overrides name an unknown risk.
+func validateRisks(overrides map[string]Risk) error {
+	for path, risk := range overrides {
+		switch risk {
+		case RiskLow, RiskMedium, RiskHigh:
+		default:
+			return fmt.Errorf("invalid risk %q for %q (valid: %s, %s, %s)", risk, path, RiskLow, RiskMedium, RiskHigh)
+		}
+	}
+	return nil
+}
+
+// confirmTarget returns the name a high-risk command must be confirmed
+// with: its single argument (e.g. the gateway ID), or otherwise the
+// endpoint it acts on.
+func confirmTarget(cmd *cobra.Command, args []string) string {
+	if len(args) == 1 {
+		return args[0]
+	}
+	if flag := cmd.Flags().Lookup(config.KeyEndpoint); flag != nil {
+		return flag.Value.String()
+	}
+	return ""
+}
+
+// confirmRisk asks for the confirmation that cmd's risk requires. A dry
+// run changes nothing, so it needs no confirmation.
+func confirmRisk(cmd *cobra.Command, args []string) error {
+	if dryRunOut != nil {
+		return nil
+	}
+
+	r, err := LoadRestrictions(restrictionsPath)
+	if err != nil {
+		return err
+	}
+	var overrides map[string]Risk
+	if r != nil {
+		overrides = r.Risk
+	}
+
+	path := CommandPath(cmd)
+	risk := ClassifyRisk(path, overrides)
+	target := confirmTarget(cmd, args)
+
+	if flag := cmd.Flags().Lookup(ConfirmWithFlag); flag != nil && flag.Changed {
+		if given := flag.Value.String(); given != target {
+			return fmt.Errorf("--confirm-with %q does not match %q; %s was not run", given, target, path)
+		}
+		return nil
+	}
+
+	switch risk {
+	case RiskHigh:
+		if target == "" {
+			return nil
+		}
+		if !interactive() {
+			return fmt.Errorf("%s is a high-risk command; confirm with --confirm-with %s", path, target)
+		}
+		fmt.Fprintf(promptOut, i18n.T("%s is a high-risk command. Type %q to continue: "), path, target)
+		answer, _ := bufio.NewReader(promptIn).ReadString('\n')
+		if strings.TrimSpace(answer) != target {
+			return fmt.Errorf("confirmation did not match %q; %s was not run", target, path)
+		}
+	case RiskMedium:
+		// Commands with --force ask for themselves
+		if cmd.Flags().Lookup("force") != nil || !interactive() {
+			return nil
+		}
+		fmt.Fprint(promptOut, i18n.Sprintf("Run %s? [y/N]: ", strings.TrimSpace(path+" "+target)))
+		answer, _ := bufio.NewReader(promptIn).ReadString('\n')
+		if !i18n.IsYes(answer) {
+			return fmt.Errorf("%s cancelled", path)
+		}
+	}
+	return nil
+}
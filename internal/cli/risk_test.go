@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestClassifyRisk(t *testing.T) {
+	tests := []struct {
+		path      string
+		overrides map[string]Risk
+		want      Risk
+	}{
+		{path: "collection list", want: RiskLow},
+		{path: "collection create", want: RiskLow},
+		{path: "role delete", want: RiskMedium},
+		{path: "node disable", want: RiskMedium},
+		{path: "storage-gateway delete", want: RiskHigh},
+		{path: "endpoint cleanup", want: RiskHigh},
+		{path: "role delete", overrides: map[string]Risk{"role delete": RiskHigh}, want: RiskHigh},
+		{path: "storage-gateway delete", overrides: map[string]Risk{"storage-gateway delete": RiskLow}, want: RiskLow},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyRisk(tt.path, tt.overrides); got != tt.want {
+			t.Errorf("ClassifyRisk(%q, %v) = %q, want %q", tt.path, tt.overrides, got, tt.want)
+		}
+	}
+}
+
+// newRiskTestCmd returns the command "group use" of a test tree, with the
+// root's --confirm-with flag.
+func newRiskTestCmd(group, use string) *cobra.Command {
+	root := &cobra.Command{Use: "globus-connect-server"}
+	root.PersistentFlags().String(ConfirmWithFlag, "", "")
+	parent := &cobra.Command{Use: group}
+	cmd := &cobra.Command{Use: use, RunE: func(*cobra.Command, []string) error { return nil }}
+	cmd.Flags().String("endpoint", "", "")
+	parent.AddCommand(cmd)
+	root.AddCommand(parent)
+	_ = cmd.ParseFlags(nil)
+	return cmd
+}
+
+func TestConfirmRisk_High(t *testing.T) {
+	tests := []struct {
+		name        string
+		tty         bool
+		answer      string
+		confirmWith string
+		wantErr     string
+	}{
+		{name: "typed name", tty: true, answer: "gw1\n"},
+		{name: "typed wrong name", tty: true, answer: "yes\n", wantErr: "did not match"},
+		{name: "non-interactive", wantErr: "confirm with --confirm-with gw1"},
+		{name: "confirm-with", confirmWith: "gw1"},
+		{name: "confirm-with wrong name", confirmWith: "gw2", wantErr: "does not match"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompt := stubReauth(t, nil, tt.tty, tt.answer)
+			cmd := newRiskTestCmd("storage-gateway", "delete")
+			if tt.confirmWith != "" {
+				_ = cmd.Flags().Set(ConfirmWithFlag, tt.confirmWith)
+			}
+
+			err := confirmRisk(cmd, []string{"gw1"})
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("confirmRisk() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("confirmRisk() error = %v, want %q", err, tt.wantErr)
+			}
+			if tt.tty && !strings.Contains(prompt.String(), `Type "gw1"`) {
+				t.Errorf("prompt = %q, want the gateway ID", prompt.String())
+			}
+		})
+	}
+}
+
+func TestConfirmRisk_Medium(t *testing.T) {
+	stubReauth(t, nil, true, "n\n")
+	if err := confirmRisk(newRiskTestCmd("role", "delete"), []string{"r1"}); err == nil {
+		t.Error("confirmRisk() should fail when the prompt is declined")
+	}
+
+	stubReauth(t, nil, true, "y\n")
+	if err := confirmRisk(newRiskTestCmd("role", "delete"), []string{"r1"}); err != nil {
+		t.Errorf("confirmRisk() error = %v after yes", err)
+	}
+
+	// Without a terminal, medium-risk commands run as before
+	stubReauth(t, nil, false, "")
+	if err := confirmRisk(newRiskTestCmd("role", "delete"), []string{"r1"}); err != nil {
+		t.Errorf("confirmRisk() error = %v without a terminal", err)
+	}
+}
+
+func TestConfirmRisk_Override(t *testing.T) {
+	setupRestrictions(t, "risk:\n  role delete: high\n", 0o644)
+	stubReauth(t, nil, false, "")
+
+	err := confirmRisk(newRiskTestCmd("role", "delete"), []string{"r1"})
+	if err == nil || !strings.Contains(err.Error(), "high-risk") {
+		t.Errorf("confirmRisk() error = %v, want high-risk", err)
+	}
+}
+
+func TestConfirmRisk_DryRun(t *testing.T) {
+	prompt := stubReauth(t, nil, false, "")
+	dryRunOut = io.Discard
+	t.Cleanup(func() { dryRunOut = nil })
+
+	if err := confirmRisk(newRiskTestCmd("storage-gateway", "delete"), []string{"gw1"}); err != nil {
+		t.Errorf("confirmRisk() error = %v under --dry-run", err)
+	}
+	if prompt.Len() != 0 {
+		t.Errorf("prompt = %q under --dry-run, want none", prompt.String())
+	}
+}
+
+func TestLoadRestrictions_InvalidRisk(t *testing.T) {
+	path := setupRestrictions(t, "risk:\n  role delete: extreme\n", 0o644)
+	if _, err := LoadRestrictions(path); err == nil || !strings.Contains(err.Error(), "invalid risk") {
+		t.Errorf("LoadRestrictions() error = %v, want invalid risk", err)
+	}
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// OutputStyleFlag is the root persistent flag that selects the text
+// output style.
+const OutputStyleFlag = "output-style"
+
+// NoColorFlag is the root persistent flag that turns off colored text
+// output. Color is also off when NO_COLOR is set, when output is not a
+// terminal, and in the plain output style.
+const NoColorFlag = "no-color"
+
+// noColor is set by Prepare from --no-color.
+var noColor bool
+
+// OutputStyle returns the effective text output style.
+func OutputStyle() output.Style {
+	if style := effective.Get(config.KeyOutputStyle); style != "" {
+		return output.Style(style)
+	}
+	return output.StyleDefault
+}
+
+// applyOutputStyle routes cmd's text output through the writer for the
+// effective output style. JSON and export formats are left untouched.
+func applyOutputStyle(cmd *cobra.Command, eff *config.Effective) error {
+	setting, _ := eff.Lookup(config.KeyOutputStyle)
+	switch output.Style(setting.Value) {
+	case output.StyleDefault, "":
+		return nil
+	case output.StylePlain:
+	default:
+		return fmt.Errorf("invalid output style %q from %s (valid: %s, %s)",
+			setting.Value, setting.Source, output.StyleDefault, output.StylePlain)
+	}
+
+	if flag := cmd.Flags().Lookup(config.KeyFormat); flag != nil && flag.Value.String() != string(output.FormatText) {
+		return nil
+	}
+
+	cmd.SetOut(output.NewPlainWriter(cmd.OutOrStdout()))
+	return nil
+}
+
+// applyNoColor reads --no-color from cmd.
+func applyNoColor(cmd *cobra.Command) {
+	noColor = false
+	if flag := cmd.Flags().Lookup(NoColorFlag); flag != nil {
+		noColor, _ = cmd.Flags().GetBool(NoColorFlag)
+	}
+}
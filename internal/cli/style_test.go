@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrepare_PlainOutputStyle(t *testing.T) {
+	setupConfigDir(t, "output_style: plain\n")
+	t.Setenv("GLOBUS_GCS_OUTPUT_STYLE", "")
+
+	_, cmd := newTestTree("show")
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	_, _ = cmd.OutOrStdout().Write([]byte("ID:                 col-1\n"))
+	if got := buf.String(); got != "ID: col-1\n" {
+		t.Errorf("output = %q, want plain style", got)
+	}
+}
+
+func TestPrepare_PlainOutputStyleSkipsJSON(t *testing.T) {
+	setupConfigDir(t, "output_style: plain\n")
+	t.Setenv("GLOBUS_GCS_OUTPUT_STYLE", "")
+
+	_, cmd := newTestTree("show")
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	if err := cmd.Flags().Set("format", "json"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+
+	if cmd.OutOrStdout() != &buf {
+		t.Error("JSON output was routed through the plain writer")
+	}
+}
+
+func TestPrepare_InvalidOutputStyle(t *testing.T) {
+	setupConfigDir(t, "")
+	t.Setenv("GLOBUS_GCS_OUTPUT_STYLE", "fancy")
+
+	_, cmd := newTestTree("show")
+	if err := Prepare(cmd, nil); err == nil {
+		t.Error("Prepare() expected error for invalid output style")
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/spf13/cobra"
+)
+
+// Root persistent flags that name the PEM files of a TLS client
+// certificate, for endpoints behind proxies that require mutual TLS.
+const (
+	ClientCertFlag = "client-cert"
+	ClientKeyFlag  = "client-key"
+)
+
+// CABundleFlag is the root persistent flag that names a PEM file of CA
+// certificates trusted in addition to the system's.
+const CABundleFlag = "ca-bundle"
+
+// InsecureSkipTLSVerifyFlag is the root persistent flag that disables
+// verification of endpoint certificates.
+const InsecureSkipTLSVerifyFlag = "insecure-skip-tls-verify"
+
+// insecureTLS records --insecure-skip-tls-verify.
+var insecureTLS bool
+
+// applyInsecureTLS records --insecure-skip-tls-verify, and warns that
+// it is set.
+func applyInsecureTLS(cmd *cobra.Command) {
+	insecureTLS, _ = cmd.Flags().GetBool(InsecureSkipTLSVerifyFlag)
+	if insecureTLS {
+		Warnf("TLS certificate verification is DISABLED (--%s): anyone between you and the endpoint can read and change requests, including your access token. Use it only against test endpoints.", InsecureSkipTLSVerifyFlag)
+	}
+}
+
+// tlsOptions returns the gcs.ClientOptions for the effective ca_bundle,
+// client_cert, and client_key settings and --insecure-skip-tls-verify.
+// The client key defaults to the certificate file, for PEM files holding
+// both.
+func tlsOptions() ([]gcs.ClientOption, error) {
+	var tlsOpts []gcs.TLSConfigOption
+	bundle, _ := effective.Lookup(config.KeyCABundle)
+	if bundle.Value != "" {
+		pool, err := loadCABundle(bundle.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w (from %s)", err, bundle.Origin)
+		}
+		tlsOpts = append(tlsOpts, gcs.WithRootCAs(pool))
+	}
+	if insecureTLS {
+		tlsOpts = append(tlsOpts, gcs.WithTLSInsecureSkipVerify())
+	}
+
+	var opts []gcs.ClientOption
+	if len(tlsOpts) > 0 {
+		opts = append(opts, gcs.WithTLSConfig(gcs.CustomTLSConfig(tlsOpts...)))
+	}
+
+	cert, _ := effective.Lookup(config.KeyClientCert)
+	key, _ := effective.Lookup(config.KeyClientKey)
+	if cert.Value == "" {
+		if key.Value != "" {
+			return nil, fmt.Errorf("client key %q (from %s) given without a client certificate", key.Value, key.Origin)
+		}
+		return opts, nil
+	}
+
+	keyFile := key.Value
+	if keyFile == "" {
+		keyFile = cert.Value
+	}
+	return append(opts, gcs.WithClientCertificate(cert.Value, keyFile)), nil
+}
+
+// loadCABundle returns the system's CA certificates together with those
+// in the PEM file path.
+func loadCABundle(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // The CA bundle is chosen by the user
+	if err != nil {
+		return nil, fmt.Errorf("read CA bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("CA bundle %s contains no PEM certificates", path)
+	}
+	return pool, nil
+}
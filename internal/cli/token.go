@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+)
+
+// LoadToken loads the access token a command should use for profile.
+//
+// Read-only commands prefer the profile's reporter token when one is
+// stored (see 'login --reporter'), so reporting jobs never need a
+// mutation-capable credential. All other commands, and read-only commands
+// for profiles without a reporter token, use the profile's regular token.
+//
+// An access token supplied with --access-token-stdin or AccessTokenEnv
+// takes precedence over both and is never written to disk.
+func LoadToken(profile string) (*auth.TokenInfo, error) {
+	if token := suppliedTokenInfo(); token != nil {
+		loadedToken.profile, loadedToken.reporter = "", false
+		loadedToken.accessTokens, loadedToken.endpoints = nil, nil
+		replaceLoadedToken(nil)
+		return token, nil
+	}
+
+	if IsReadOnly(currentCommand) {
+		ok, err := auth.HasReporterToken(profile)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			token, err := auth.LoadReporterToken(profile)
+			if err != nil {
+				return nil, fmt.Errorf("load reporter token: %w", err)
+			}
+			loadedToken.profile, loadedToken.reporter = profile, true
+			loadedToken.accessTokens = token.AccessTokens()
+			loadedToken.endpoints = token.Endpoints
+			replaceLoadedToken(token)
+			return token, nil
+		}
+	}
+
+	token, err := auth.LoadToken(profile)
+	if err != nil {
+		return nil, err
+	}
+	loadedToken.profile, loadedToken.reporter = profile, false
+	loadedToken.accessTokens = token.AccessTokens()
+	loadedToken.endpoints = token.Endpoints
+	replaceLoadedToken(token)
+	return token, nil
+}
+
+// applyTokenEncryption selects how token files are encrypted, from the
+// token_encryption setting.
+func applyTokenEncryption(eff *config.Effective) error {
+	setting, _ := eff.Lookup(config.KeyTokenEncryption)
+	if err := auth.SetTokenEncryption(setting.Value); err != nil {
+		return fmt.Errorf("%w (from %s)", err, setting.Origin)
+	}
+	return nil
+}
+
+// applySecretStore selects where the token encryption key is kept, from
+// the secret_store setting.
+func applySecretStore(eff *config.Effective) error {
+	setting, _ := eff.Lookup(config.KeySecretStore)
+	if err := auth.SetSecretStore(setting.Value); err != nil {
+		return fmt.Errorf("%w (from %s)", err, setting.Origin)
+	}
+	return nil
+}
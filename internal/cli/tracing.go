@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Environment variables that enable tracing, as defined by the
+// OpenTelemetry exporter specification.
+const (
+	otlpEndpointEnv       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	otlpTracesEndpointEnv = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	otelSDKDisabledEnv    = "OTEL_SDK_DISABLED"
+)
+
+// tracingShutdownTimeout bounds how long ShutdownTracing waits to export
+// the remaining spans.
+const tracingShutdownTimeout = 5 * time.Second
+
+// tracerProvider records the spans of GCS API requests, or is nil if
+// tracing is not enabled.
+var tracerProvider *sdktrace.TracerProvider
+
+// applyTracing starts exporting a span for each GCS API request over
+// OTLP/HTTP when an OTLP endpoint is set in the environment. The exporter
+// reads the rest of its settings (headers, certificates, compression)
+// from the standard OTEL_EXPORTER_OTLP_* variables.
+func applyTracing() {
+	if tracerProvider != nil || !tracingEnabled() {
+		return
+	}
+
+	exporter, err := otlptracehttp.New(context.Background())
+	if err != nil {
+		Warnf("tracing disabled: %v", err)
+		return
+	}
+	res, err := resource.Merge(
+		resource.NewSchemaless(attribute.String("service.name", "globus-connect-server")),
+		resource.Environment(),
+	)
+	if err != nil {
+		res = resource.Environment()
+	}
+
+	tracerProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// tracingEnabled reports whether the environment sets an OTLP endpoint
+// and does not disable the OpenTelemetry SDK.
+func tracingEnabled() bool {
+	if strings.EqualFold(os.Getenv(otelSDKDisabledEnv), "true") {
+		return false
+	}
+	return os.Getenv(otlpEndpointEnv) != "" || os.Getenv(otlpTracesEndpointEnv) != ""
+}
+
+// tracingOptions returns the gcs.ClientOptions that trace requests, if
+// tracing is enabled.
+func tracingOptions() []gcs.ClientOption {
+	if tracerProvider == nil {
+		return nil
+	}
+	return []gcs.ClientOption{gcs.WithTracerProvider(tracerProvider)}
+}
+
+// ShutdownTracing exports the spans not yet sent. Call it once the
+// command has finished.
+func ShutdownTracing() {
+	if tracerProvider == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	if err := tracerProvider.Shutdown(ctx); err != nil {
+		Warnf("export traces: %v", err)
+	}
+	tracerProvider = nil
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+)
+
+func TestTracing_ExportsSpans(t *testing.T) {
+	var exported atomic.Int32
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/v1/traces" {
+			exported.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	setupConfigDir(t, "")
+	t.Setenv(otlpTracesEndpointEnv, collector.URL+"/v1/traces")
+	prev := otel.GetTracerProvider()
+	t.Cleanup(func() { ShutdownTracing(); otel.SetTracerProvider(prev) })
+
+	_, cmd := newTestTree("list")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if len(tracingOptions()) != 1 {
+		t.Fatal("an OTLP endpoint did not enable the tracing client option")
+	}
+
+	_, span := otel.Tracer("test").Start(context.Background(), "GCS GET")
+	span.End()
+	ShutdownTracing()
+
+	if exported.Load() == 0 {
+		t.Error("ShutdownTracing() did not export the span to the collector")
+	}
+}
+
+func TestTracing_Disabled(t *testing.T) {
+	setupConfigDir(t, "")
+	t.Setenv(otlpEndpointEnv, "http://127.0.0.1:4318")
+	t.Setenv(otelSDKDisabledEnv, "true")
+
+	_, cmd := newTestTree("list")
+	if err := Prepare(cmd, nil); err != nil {
+		t.Fatalf("Prepare() error = %v", err)
+	}
+	if opts := tracingOptions(); opts != nil {
+		t.Errorf("tracingOptions() = %d options with %s=true, want none", len(opts), otelSDKDisabledEnv)
+	}
+}
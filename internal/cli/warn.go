@@ -0,0 +1,19 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/i18n"
+)
+
+// warnOut is where warnings are written.
+var warnOut io.Writer = os.Stderr
+
+// Warnf writes a warning to stderr, translated into the selected
+// language. Warnings never go to stdout so that they do not corrupt JSON
+// output.
+func Warnf(format string, args ...interface{}) {
+	fmt.Fprintf(warnOut, i18n.T("Warning: ")+i18n.T(format)+"\n", args...)
+}
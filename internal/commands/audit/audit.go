@@ -2,15 +2,26 @@
 package audit
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
-	_ "modernc.org/sqlite" // SQLite driver
 )
 
+// defaultStatementTimeout is how long a single SQL statement on the audit
+// database may run.
+const defaultStatementTimeout = 5 * time.Minute
+
+// statementTimeout is the --statement-timeout flag of the audit command.
+var statementTimeout = defaultStatementTimeout
+
 // NewAuditCmd creates the audit command with subcommands.
 func NewAuditCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -20,13 +31,20 @@ func NewAuditCmd() *cobra.Command {
 
 Audit logs track all activities on the endpoint including transfers,
 access events, and authentication. Logs can be loaded into a local
-SQLite database for searching and analysis.`,
+SQLite database for searching and analysis.
+
+Queries on the local database can be interrupted with Ctrl-C; partial
+results are discarded.`,
 	}
 
+	cmd.PersistentFlags().DurationVar(&statementTimeout, "statement-timeout", defaultStatementTimeout,
+		"Maximum time for a single audit database statement, or for 'audit dump' until the first row (0 for no limit)")
+
 	// Add subcommands
 	cmd.AddCommand(NewLoadCmd())
 	cmd.AddCommand(NewQueryCmd())
 	cmd.AddCommand(NewDumpCmd())
+	cmd.AddCommand(NewMetricsCmd())
 
 	return cmd
 }
@@ -46,8 +64,52 @@ func getAuditDBPath() (string, error) {
 	return filepath.Join(dbDir, "audit.db"), nil
 }
 
+// interruptible returns a context that is cancelled when the process is
+// interrupted, so that in-flight statements on the audit database stop.
+func interruptible(ctx context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+}
+
+// statementContext returns the context for one statement on the audit
+// database, limited to --statement-timeout.
+func statementContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if statementTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, statementTimeout)
+}
+
+// streamContext returns the context for a query whose rows are streamed
+// to an export. --statement-timeout limits the query only until started
+// is called, once the first row has been read: writing a large export
+// may take much longer than the query, and is not cut off.
+func streamContext(ctx context.Context) (streamCtx context.Context, started, cancel func()) {
+	streamCtx, cancelCause := context.WithCancelCause(ctx)
+	started = func() {}
+	if statementTimeout > 0 {
+		timer := time.AfterFunc(statementTimeout, func() { cancelCause(context.DeadlineExceeded) })
+		started = func() { timer.Stop() }
+	}
+	return streamCtx, started, func() {
+		started()
+		cancelCause(context.Canceled)
+	}
+}
+
+// dbError wraps an error from a statement run with ctx, explaining
+// interruptions and timeouts.
+func dbError(ctx context.Context, op string, err error) error {
+	switch {
+	case errors.Is(context.Cause(ctx), context.DeadlineExceeded):
+		return fmt.Errorf("%s: statement timed out after %s (raise --statement-timeout)", op, statementTimeout)
+	case errors.Is(ctx.Err(), context.Canceled):
+		return fmt.Errorf("%s: interrupted, partial results discarded: %w", op, context.Canceled)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
+
 // initAuditDB initializes the audit database.
-func initAuditDB(dbPath string) (*sql.DB, error) {
+func initAuditDB(ctx context.Context, dbPath string) (*sql.DB, error) {
 	db, err := sql.Open("sqlite", dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("open database: %w", err)
@@ -75,9 +137,9 @@ func initAuditDB(dbPath string) (*sql.DB, error) {
 	CREATE INDEX IF NOT EXISTS idx_result ON audit_logs(result);
 	`
 
-	if _, err := db.Exec(createTableSQL); err != nil {
+	if _, err := db.ExecContext(ctx, createTableSQL); err != nil {
 		_ = db.Close()
-		return nil, fmt.Errorf("create table: %w", err)
+		return nil, dbError(ctx, "create table", err)
 	}
 
 	return db, nil
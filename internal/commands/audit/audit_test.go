@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDBError(t *testing.T) {
+	cause := errors.New("database is locked")
+
+	if err := dbError(context.Background(), "query database", cause); !errors.Is(err, cause) {
+		t.Errorf("dbError() = %v, want wrapped cause", err)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := dbError(canceled, "query database", cause)
+	if !errors.Is(err, context.Canceled) || !strings.Contains(err.Error(), "partial results discarded") {
+		t.Errorf("dbError() after cancel = %v", err)
+	}
+
+	expired, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+	if err := dbError(expired, "query database", cause); !strings.Contains(err.Error(), "--statement-timeout") {
+		t.Errorf("dbError() after timeout = %v", err)
+	}
+}
+
+func TestRunQuery_Canceled(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var buf strings.Builder
+	err := runQuery(ctx, "text", "", "", "", "", "", "", 10, &buf)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("runQuery() error = %v, want context.Canceled", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("runQuery() wrote partial output %q", buf.String())
+	}
+}
+
+func TestStreamContext(t *testing.T) {
+	timeout := statementTimeout
+	statementTimeout = 20 * time.Millisecond
+	t.Cleanup(func() { statementTimeout = timeout })
+
+	ctx, _, cancel := streamContext(context.Background())
+	defer cancel()
+	<-ctx.Done()
+	if err := dbError(ctx, "query database", ctx.Err()); !strings.Contains(err.Error(), "--statement-timeout") {
+		t.Errorf("dbError() before the first row = %v, want a timeout", err)
+	}
+
+	ctx, started, cancel := streamContext(context.Background())
+	defer cancel()
+	started()
+	time.Sleep(3 * statementTimeout)
+	if err := ctx.Err(); err != nil {
+		t.Errorf("context after the first row = %v, want no timeout while the export is written", err)
+	}
+}
@@ -1,18 +1,27 @@
 package audit
 
 import (
+	"bufio"
+	"compress/gzip"
 	"context"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/objectstore"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
 )
 
+// defaultChunkRows is how many rows audit dump writes between flushes.
+const defaultChunkRows = 1000
+
 // NewDumpCmd creates the audit dump command.
 func NewDumpCmd() *cobra.Command {
 	var (
@@ -24,16 +33,41 @@ func NewDumpCmd() *cobra.Command {
 		identityID string
 		action     string
 		result     string
+		limit      int
+		chunkRows  int
+		dest       dumpDestination
 	)
 
 	cmd := &cobra.Command{
 		Use:   "dump",
-		Short: "Export audit logs to file",
-		Long: `Export audit logs from the local database to a file.
+		Short: "Export audit logs to a file or object storage",
+		Long: `Export audit logs from the local database to a file, or to Amazon S3
+or Google Cloud Storage.
 
 Supports JSON and CSV export formats. You can apply filters to export
 specific subsets of logs.
 
+Rows are streamed from the database to the file and flushed every
+--chunk-rows rows, so large exports do not need to fit in memory. The file
+is written under a temporary name and only renamed to --output once the
+export is complete. --statement-timeout limits only the query, until its
+first row is read, not the time taken to write the export.
+
+An --output of s3://bucket/key or gs://bucket/key streams the export to
+object storage with a multipart upload, without writing it to local disk.
+An --output ending in "/" is a prefix, under which the export is named
+audit-<UTC time>.<format>.gz. The object only appears once the upload is
+complete; a failed or interrupted export is aborted and leaves nothing
+behind. Exports to object storage are gzip-compressed unless
+--compress=false.
+
+Credentials are read as the AWS and Google Cloud command-line tools do:
+  S3:  AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY, AWS_PROFILE, or the EC2
+       instance role; AWS_REGION selects the region and AWS_ENDPOINT_URL
+       an S3-compatible service
+  GCS: GOOGLE_APPLICATION_CREDENTIALS (service account key),
+       GOOGLE_OAUTH_ACCESS_TOKEN, or the instance service account
+
 Example:
   # Export to JSON
   globus-connect-server audit dump \
@@ -45,21 +79,47 @@ Example:
     --output audit-logs.csv \
     --format csv \
     --event-type transfer \
-    --result success`,
+    --result success
+
+  # Export at most one million rows
+  globus-connect-server audit dump \
+    --output audit-logs.csv \
+    --format csv \
+    --limit 1000000
+
+  # Nightly export to S3, encrypted with a KMS key
+  globus-connect-server audit dump \
+    --output s3://audit-archive/gcs/ \
+    --sse aws:kms \
+    --sse-kms-key-id alias/audit-archive
+
+  # Export to Google Cloud Storage
+  globus-connect-server audit dump \
+    --output gs://audit-archive/gcs/audit.csv.gz \
+    --format csv`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !cmd.Flags().Changed("compress") {
+				dest.compress = objectstore.IsURL(outputFile)
+			}
 			return runDump(cmd.Context(), format, outputFile, startTime, endTime,
-				eventType, identityID, action, result, cmd.OutOrStdout())
+				eventType, identityID, action, result, limit, chunkRows, dest, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&format, "format", "f", "json", "Export format (json, csv)")
-	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file path")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file path, or s3:// or gs:// URL")
 	cmd.Flags().StringVar(&startTime, "start-time", "", "Start time (RFC3339 format)")
 	cmd.Flags().StringVar(&endTime, "end-time", "", "End time (RFC3339 format)")
-	cmd.Flags().StringVar(&eventType, "event-type", "", "Filter by event type")
+	cli.EnumVar(cmd, &eventType, "event-type", "", "Filter by event type (transfer, access, authentication)",
+		gcs.AuditEventTransfer, gcs.AuditEventAccess, gcs.AuditEventAuthentication)
 	cmd.Flags().StringVar(&identityID, "identity", "", "Filter by identity ID")
 	cmd.Flags().StringVar(&action, "action", "", "Filter by action")
-	cmd.Flags().StringVar(&result, "result", "", "Filter by result (success, failure)")
+	cli.EnumVar(cmd, &result, "result", "", "Filter by result (success, failure)", gcs.AuditResultSuccess, gcs.AuditResultFailure)
+	cmd.Flags().IntVar(&limit, "limit", 0, "Maximum number of rows to export (0 for no limit)")
+	cmd.Flags().IntVar(&chunkRows, "chunk-rows", defaultChunkRows, "Number of rows to write between flushes")
+	cmd.Flags().BoolVar(&dest.compress, "compress", false, "Gzip-compress the export (default true for s3:// and gs:// outputs)")
+	cli.EnumVar(cmd, &dest.sse, "sse", "", "S3 server-side encryption (AES256, aws:kms)", "AES256", "aws:kms")
+	cmd.Flags().StringVar(&dest.kmsKeyID, "sse-kms-key-id", "", "KMS key for --sse aws:kms, or Cloud KMS key for gs:// outputs")
 
 	_ = cmd.MarkFlagRequired("output")
 
@@ -68,7 +128,34 @@ Example:
 
 // runDump executes the audit dump command.
 func runDump(ctx context.Context, format, outputFile, startTimeStr, endTimeStr, eventType,
-	identityID, action, result string, out interface{ Write([]byte) (int, error) }) error {
+	identityID, action, result string, limit, chunkRows int, dest dumpDestination,
+	out interface{ Write([]byte) (int, error) }) error {
+	if format != "json" && format != "csv" {
+		return fmt.Errorf("unsupported format: %s (use json or csv)", format)
+	}
+	if limit < 0 {
+		return fmt.Errorf("--limit must not be negative")
+	}
+	if chunkRows <= 0 {
+		return fmt.Errorf("--chunk-rows must be positive")
+	}
+	var loc *objectstore.Location
+	if objectstore.IsURL(outputFile) {
+		var err error
+		if loc, err = objectstore.Parse(outputFile); err != nil {
+			return err
+		}
+		if loc.IsPrefix() {
+			loc = loc.Object(dumpObjectName(format, dest.compress))
+		}
+		outputFile = loc.String()
+	} else if dest.sse != "" || dest.kmsKeyID != "" {
+		return fmt.Errorf("--sse and --sse-kms-key-id only apply to s3:// and gs:// outputs")
+	}
+
+	ctx, stop := interruptible(ctx)
+	defer stop()
+
 	// Create output formatter
 	formatter := output.NewFormatter(output.Format("text"), out)
 
@@ -95,7 +182,7 @@ func runDump(ctx context.Context, format, outputFile, startTimeStr, endTimeStr,
 		return fmt.Errorf("get database path: %w", err)
 	}
 
-	db, err := initAuditDB(dbPath)
+	db, err := initAuditDB(ctx, dbPath)
 	if err != nil {
 		return fmt.Errorf("initialize database: %w", err)
 	}
@@ -131,112 +218,304 @@ func runDump(ctx context.Context, format, outputFile, startTimeStr, endTimeStr,
 	}
 
 	query += " ORDER BY timestamp DESC"
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
 
 	// Execute query
-	rows, err := db.QueryContext(ctx, query, args...)
+	stmtCtx, started, cancel := streamContext(ctx)
+	defer cancel()
+
+	rows, err := db.QueryContext(stmtCtx, query, args...)
 	if err != nil {
-		return fmt.Errorf("query database: %w", err)
+		return dbError(stmtCtx, "query database", err)
 	}
 	defer func() { _ = rows.Close() }()
 
-	// Fetch results
-	var logs []gcs.AuditLog
-	for rows.Next() {
-		var log gcs.AuditLog
-		var timestamp string
-		var metadataJSON string
-
-		err := rows.Scan(
-			&log.ID,
-			&timestamp,
-			&log.EventType,
-			&log.IdentityID,
-			&log.Username,
-			&log.Resource,
-			&log.ResourceID,
-			&log.Action,
-			&log.Result,
-			&log.Message,
-			&log.ClientIP,
-			&metadataJSON,
-		)
-		if err != nil {
-			return fmt.Errorf("scan row: %w", err)
-		}
+	target, err := openDumpTarget(ctx, outputFile, loc, format, dest)
+	if err != nil {
+		return err
+	}
+	defer target.discard()
 
-		// Parse timestamp
-		log.Timestamp, _ = time.Parse(time.RFC3339, timestamp)
+	exported, err := writeDump(&startedRows{rowScanner: rows, started: started}, format, chunkRows, target)
+	if err != nil {
+		return dbError(stmtCtx, "export audit logs", err)
+	}
+	if err := target.commit(); err != nil {
+		return dbError(ctx, "write "+outputFile, err)
+	}
 
-		// Parse metadata JSON
-		if metadataJSON != "" {
-			_ = json.Unmarshal([]byte(metadataJSON), &log.Metadata)
-		}
+	// Output success message
+	if err := formatter.PrintText("Exported %d audit log entries to %s\n", exported, outputFile); err != nil {
+		return err
+	}
 
-		logs = append(logs, log)
+	return nil
+}
+
+// startedRows calls started once the first row has been read.
+type startedRows struct {
+	rowScanner
+	started func()
+}
+
+// Next implements rowScanner.
+func (r *startedRows) Next() bool {
+	next := r.rowScanner.Next()
+	if r.started != nil {
+		r.started()
+		r.started = nil
 	}
+	return next
+}
 
-	if err := rows.Err(); err != nil {
-		return fmt.Errorf("iterate rows: %w", err)
+// dumpDestination holds the options of audit dump for where and how the
+// export is stored.
+type dumpDestination struct {
+	compress bool
+	sse      string
+	kmsKeyID string
+}
+
+// dumpNow returns the current time for export names. It is a test hook.
+var dumpNow = time.Now
+
+// dumpObjectName returns the name of an export written under an object
+// storage prefix.
+func dumpObjectName(format string, compress bool) string {
+	name := "audit-" + dumpNow().UTC().Format("20060102T150405Z") + "." + format
+	if compress {
+		name += ".gz"
 	}
+	return name
+}
 
-	// Create output file
-	// #nosec G304 - outputFile path is user-provided via flag, which is expected behavior
-	file, err := os.Create(outputFile)
-	if err != nil {
-		return fmt.Errorf("create output file: %w", err)
+// dumpTarget is the file or object an export is written to. Nothing is
+// visible at the destination until commit succeeds.
+type dumpTarget struct {
+	io.Writer
+	commit  func() error
+	discard func()
+}
+
+// openDumpTarget opens outputFile for an export, or the object at loc if
+// it is not nil.
+func openDumpTarget(ctx context.Context, outputFile string, loc *objectstore.Location, format string, dest dumpDestination) (*dumpTarget, error) {
+	target := &dumpTarget{}
+	if loc != nil {
+		contentType := "application/json"
+		if format == "csv" {
+			contentType = "text/csv"
+		}
+		if dest.compress {
+			contentType = "application/gzip"
+		}
+		upload, err := objectstore.NewWriter(ctx, loc, objectstore.Options{
+			ContentType: contentType,
+			SSE:         dest.sse,
+			KMSKeyID:    dest.kmsKeyID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		target.Writer = upload
+		target.commit = upload.Close
+		target.discard = func() { _ = upload.Abort() }
+	} else {
+		// Stream results to a temporary file next to the output file, so
+		// that an interrupted export leaves no partial file behind
+		// #nosec G304 - outputFile path is user-provided via flag, which is expected behavior
+		file, err := os.CreateTemp(filepath.Dir(outputFile), "."+filepath.Base(outputFile)+".*.tmp")
+		if err != nil {
+			return nil, fmt.Errorf("create output file: %w", err)
+		}
+		target.Writer = file
+		target.commit = func() error {
+			if err := file.Close(); err != nil {
+				return err
+			}
+			return os.Rename(file.Name(), outputFile)
+		}
+		target.discard = func() {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+		}
 	}
-	defer func() { _ = file.Close() }()
 
-	// Export based on format
-	switch format {
-	case "json":
-		encoder := json.NewEncoder(file)
-		encoder.SetIndent("", "  ")
-		if err := encoder.Encode(logs); err != nil {
-			return fmt.Errorf("encode JSON: %w", err)
+	if dest.compress {
+		gz := gzip.NewWriter(target.Writer)
+		commit := target.commit
+		target.Writer = gz
+		target.commit = func() error {
+			if err := gz.Close(); err != nil {
+				return err
+			}
+			return commit()
 		}
+	}
+	return target, nil
+}
+
+// dumpWriter writes audit log entries to an export file.
+type dumpWriter interface {
+	// Write writes one entry.
+	Write(log *gcs.AuditLog) error
+
+	// Flush writes buffered entries to the underlying writer.
+	Flush() error
 
+	// Close completes the export and flushes it.
+	Close() error
+}
+
+// writeDump streams rows to w in format, flushing every chunkRows rows,
+// and returns the number of rows written.
+func writeDump(rows rowScanner, format string, chunkRows int, w io.Writer) (int, error) {
+	buffered := bufio.NewWriter(w)
+
+	var writer dumpWriter
+	switch format {
+	case "json":
+		writer = &jsonDumpWriter{w: buffered}
 	case "csv":
-		writer := csv.NewWriter(file)
-		defer writer.Flush()
+		writer = &csvDumpWriter{w: csv.NewWriter(buffered)}
+	default:
+		return 0, fmt.Errorf("unsupported format: %s (use json or csv)", format)
+	}
 
-		// Write header
-		header := []string{
-			"ID", "Timestamp", "EventType", "IdentityID", "Username",
-			"Resource", "ResourceID", "Action", "Result", "Message", "ClientIP",
+	count := 0
+	for rows.Next() {
+		log, err := scanAuditLog(rows)
+		if err != nil {
+			return count, err
 		}
-		if err := writer.Write(header); err != nil {
-			return fmt.Errorf("write CSV header: %w", err)
+		if err := writer.Write(&log); err != nil {
+			return count, err
 		}
-
-		// Write rows
-		for _, log := range logs {
-			row := []string{
-				log.ID,
-				log.Timestamp.Format(time.RFC3339),
-				log.EventType,
-				log.IdentityID,
-				log.Username,
-				log.Resource,
-				log.ResourceID,
-				log.Action,
-				log.Result,
-				log.Message,
-				log.ClientIP,
+		count++
+		if count%chunkRows == 0 {
+			if err := writer.Flush(); err != nil {
+				return count, err
 			}
-			if err := writer.Write(row); err != nil {
-				return fmt.Errorf("write CSV row: %w", err)
+			if err := buffered.Flush(); err != nil {
+				return count, fmt.Errorf("write output file: %w", err)
 			}
 		}
+	}
+	if err := rows.Err(); err != nil {
+		return count, fmt.Errorf("iterate rows: %w", err)
+	}
 
-	default:
-		return fmt.Errorf("unsupported format: %s (use json or csv)", format)
+	if err := writer.Close(); err != nil {
+		return count, err
 	}
+	if err := buffered.Flush(); err != nil {
+		return count, fmt.Errorf("write output file: %w", err)
+	}
+	return count, nil
+}
 
-	// Output success message
-	if err := formatter.PrintText("Exported %d audit log entries to %s\n", len(logs), outputFile); err != nil {
+// jsonDumpWriter writes entries as an indented JSON array, one element at
+// a time.
+type jsonDumpWriter struct {
+	w       io.Writer
+	written bool
+}
+
+func (j *jsonDumpWriter) Write(log *gcs.AuditLog) error {
+	data, err := json.MarshalIndent(log, "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("encode JSON: %w", err)
+	}
+
+	sep := ",\n  "
+	if !j.written {
+		sep = "[\n  "
+		j.written = true
+	}
+	if _, err := io.WriteString(j.w, sep); err != nil {
+		return fmt.Errorf("write JSON: %w", err)
+	}
+	if _, err := j.w.Write(data); err != nil {
+		return fmt.Errorf("write JSON: %w", err)
+	}
+	return nil
+}
+
+func (j *jsonDumpWriter) Flush() error {
+	return nil
+}
+
+func (j *jsonDumpWriter) Close() error {
+	end := "\n]\n"
+	if !j.written {
+		end = "[]\n"
+	}
+	if _, err := io.WriteString(j.w, end); err != nil {
+		return fmt.Errorf("write JSON: %w", err)
+	}
+	return nil
+}
+
+// csvDumpWriter writes entries as CSV rows after a header row.
+type csvDumpWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (c *csvDumpWriter) writeHeader() error {
+	if c.headerWritten {
+		return nil
+	}
+	c.headerWritten = true
+
+	header := []string{
+		"ID", "Timestamp", "EventType", "IdentityID", "Username",
+		"Resource", "ResourceID", "Action", "Result", "Message", "ClientIP",
+	}
+	if err := c.w.Write(header); err != nil {
+		return fmt.Errorf("write CSV header: %w", err)
+	}
+	return nil
+}
+
+func (c *csvDumpWriter) Write(log *gcs.AuditLog) error {
+	if err := c.writeHeader(); err != nil {
 		return err
 	}
 
+	row := []string{
+		log.ID,
+		log.Timestamp.Format(time.RFC3339),
+		log.EventType,
+		log.IdentityID,
+		log.Username,
+		log.Resource,
+		log.ResourceID,
+		log.Action,
+		log.Result,
+		log.Message,
+		log.ClientIP,
+	}
+	if err := c.w.Write(row); err != nil {
+		return fmt.Errorf("write CSV row: %w", err)
+	}
 	return nil
 }
+
+func (c *csvDumpWriter) Flush() error {
+	c.w.Flush()
+	if err := c.w.Error(); err != nil {
+		return fmt.Errorf("write CSV: %w", err)
+	}
+	return nil
+}
+
+func (c *csvDumpWriter) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	return c.Flush()
+}
@@ -0,0 +1,225 @@
+package audit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+)
+
+// seedAuditDB creates the audit database under a temporary HOME with n
+// transfer events.
+func seedAuditDB(t *testing.T, n int) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	dbPath, err := getAuditDBPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := initAuditDB(context.Background(), dbPath)
+	if err != nil {
+		t.Fatalf("initAuditDB() error = %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	now := time.Now().UTC()
+	for i := 0; i < n; i++ {
+		if _, err := db.Exec(
+			"INSERT INTO audit_logs VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			string(rune('a'+i)), now.Add(time.Duration(i)*time.Minute), "transfer", "id-1", "alice",
+			"collection", "col-1", "read", "success", "line one, \"two\"", "192.0.2.1", "{}"); err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	}
+}
+
+func TestRunDump_JSON(t *testing.T) {
+	seedAuditDB(t, 5)
+	outputFile := filepath.Join(t.TempDir(), "audit.json")
+
+	var buf bytes.Buffer
+	if err := runDump(context.Background(), "json", outputFile, "", "", "", "", "", "", 0, 2, dumpDestination{}, &buf); err != nil {
+		t.Fatalf("runDump() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "Exported 5 audit log entries") {
+		t.Errorf("runDump() output = %q", buf.String())
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logs []gcs.AuditLog
+	if err := json.Unmarshal(data, &logs); err != nil {
+		t.Fatalf("exported JSON is invalid: %v\n%s", err, data)
+	}
+	if len(logs) != 5 || logs[0].ID != "e" {
+		t.Errorf("exported %d logs, first %q; want 5, newest first", len(logs), logs[0].ID)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(outputFile))
+	if len(entries) != 1 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+}
+
+func TestRunDump_CSVLimit(t *testing.T) {
+	seedAuditDB(t, 5)
+	outputFile := filepath.Join(t.TempDir(), "audit.csv")
+
+	if err := runDump(context.Background(), "csv", outputFile, "", "", "", "", "", "", 3, 2, dumpDestination{}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("runDump() error = %v", err)
+	}
+
+	file, err := os.Open(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = file.Close() }()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("exported CSV is invalid: %v", err)
+	}
+	if len(records) != 4 || records[0][0] != "ID" {
+		t.Errorf("exported %d CSV records, want header and 3 rows", len(records))
+	}
+}
+
+func TestRunDump_Empty(t *testing.T) {
+	seedAuditDB(t, 0)
+	outputFile := filepath.Join(t.TempDir(), "audit.json")
+
+	if err := runDump(context.Background(), "json", outputFile, "", "", "", "", "", "", 0, defaultChunkRows, dumpDestination{}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("runDump() error = %v", err)
+	}
+	data, _ := os.ReadFile(outputFile)
+	if string(data) != "[]\n" {
+		t.Errorf("empty export = %q, want []", data)
+	}
+}
+
+func TestRunDump_InvalidOptions(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "audit.out")
+
+	tests := []struct {
+		name      string
+		format    string
+		limit     int
+		chunkRows int
+	}{
+		{name: "unsupported format", format: "xml", chunkRows: 1},
+		{name: "negative limit", format: "json", limit: -1, chunkRows: 1},
+		{name: "zero chunk rows", format: "json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := runDump(context.Background(), tt.format, outputFile, "", "", "", "", "", "", tt.limit, tt.chunkRows, dumpDestination{}, &bytes.Buffer{}); err == nil {
+				t.Error("runDump() expected error")
+			}
+			if _, err := os.Stat(outputFile); !os.IsNotExist(err) {
+				t.Error("runDump() created the output file")
+			}
+		})
+	}
+}
+
+func TestRunDump_Compressed(t *testing.T) {
+	seedAuditDB(t, 3)
+	outputFile := filepath.Join(t.TempDir(), "audit.json.gz")
+
+	if err := runDump(context.Background(), "json", outputFile, "", "", "", "", "", "", 0, 2, dumpDestination{compress: true}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("runDump() error = %v", err)
+	}
+
+	file, err := os.Open(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = file.Close() }()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("export is not gzip-compressed: %v", err)
+	}
+	var logs []gcs.AuditLog
+	if err := json.NewDecoder(gz).Decode(&logs); err != nil || len(logs) != 3 {
+		t.Errorf("decompressed export has %d logs (%v), want 3", len(logs), err)
+	}
+}
+
+func TestRunDump_ObjectStorage(t *testing.T) {
+	seedAuditDB(t, 4)
+
+	var (
+		key    string
+		object []byte
+		sse    string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.Method == http.MethodPost && query.Has("uploads"):
+			key = r.URL.Path
+			sse = r.Header.Get("X-Amz-Server-Side-Encryption")
+			_, _ = io.WriteString(w, "<InitiateMultipartUploadResult><UploadId>u1</UploadId></InitiateMultipartUploadResult>")
+		case r.Method == http.MethodPut:
+			object, _ = io.ReadAll(r.Body)
+			w.Header().Set("ETag", `"1"`)
+		case r.Method == http.MethodPost:
+			_, _ = io.WriteString(w, "<CompleteMultipartUploadResult/>")
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("AWS_ENDPOINT_URL", server.URL)
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	oldNow := dumpNow
+	dumpNow = func() time.Time { return time.Date(2026, 10, 16, 2, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { dumpNow = oldNow })
+
+	var buf bytes.Buffer
+	dest := dumpDestination{compress: true, sse: "AES256"}
+	if err := runDump(context.Background(), "csv", "s3://archive/nightly/", "", "", "", "", "", "", 0, 2, dest, &buf); err != nil {
+		t.Fatalf("runDump() error = %v", err)
+	}
+
+	if key != "/archive/nightly/audit-20261016T020000Z.csv.gz" {
+		t.Errorf("uploaded object %q, want a timestamped name under the prefix", key)
+	}
+	if sse != "AES256" {
+		t.Errorf("server-side encryption = %q, want AES256", sse)
+	}
+	if !strings.Contains(buf.String(), "s3://archive/nightly/audit-20261016T020000Z.csv.gz") {
+		t.Errorf("runDump() output = %q", buf.String())
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(object))
+	if err != nil {
+		t.Fatalf("uploaded object is not gzip-compressed: %v", err)
+	}
+	records, err := csv.NewReader(gz).ReadAll()
+	if err != nil || len(records) != 5 {
+		t.Errorf("uploaded CSV has %d records (%v), want header and 4 rows", len(records), err)
+	}
+}
+
+func TestRunDump_SSERequiresObjectStorage(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "audit.json")
+	err := runDump(context.Background(), "json", outputFile, "", "", "", "", "", "", 0, 1, dumpDestination{sse: "AES256"}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "only apply to s3:// and gs://") {
+		t.Errorf("runDump() error = %v, want --sse rejected for a local file", err)
+	}
+}
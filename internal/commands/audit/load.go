@@ -6,8 +6,8 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -53,11 +53,12 @@ Requires an active authentication session (use 'login' first).`,
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 	cmd.Flags().StringVar(&startTime, "start-time", "", "Start time (RFC3339 format)")
 	cmd.Flags().StringVar(&endTime, "end-time", "", "End time (RFC3339 format)")
-	cmd.Flags().StringVar(&eventType, "event-type", "", "Filter by event type")
+	cli.EnumVar(cmd, &eventType, "event-type", "", "Filter by event type (transfer, access, authentication)",
+		gcs.AuditEventTransfer, gcs.AuditEventAccess, gcs.AuditEventAuthentication)
 	cmd.Flags().IntVar(&limit, "limit", 1000, "Maximum number of logs to load")
 
 	_ = cmd.MarkFlagRequired("endpoint")
@@ -69,7 +70,7 @@ Requires an active authentication session (use 'login' first).`,
 func runLoad(ctx context.Context, profile, formatStr, endpointFQDN, startTimeStr, endTimeStr,
 	eventType string, limit int, out interface{ Write([]byte) (int, error) }) error {
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -80,7 +81,7 @@ func runLoad(ctx context.Context, profile, formatStr, endpointFQDN, startTimeStr
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Parse time parameters
 	var startTime, endTime *time.Time
@@ -100,10 +101,7 @@ func runLoad(ctx context.Context, profile, formatStr, endpointFQDN, startTimeStr
 	}
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -128,27 +126,33 @@ func runLoad(ctx context.Context, profile, formatStr, endpointFQDN, startTimeStr
 		return fmt.Errorf("get database path: %w", err)
 	}
 
-	db, err := initAuditDB(dbPath)
+	db, err := initAuditDB(ctx, dbPath)
 	if err != nil {
 		return fmt.Errorf("initialize database: %w", err)
 	}
 	defer func() { _ = db.Close() }()
 
-	// Insert logs into database
-	tx, err := db.Begin()
+	// Insert logs into database; an interrupted load is rolled back
+	ctx, stop := interruptible(ctx)
+	defer stop()
+
+	stmtCtx, cancel := statementContext(ctx)
+	defer cancel()
+
+	tx, err := db.BeginTx(stmtCtx, nil)
 	if err != nil {
-		return fmt.Errorf("begin transaction: %w", err)
+		return dbError(stmtCtx, "begin transaction", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	stmt, err := tx.Prepare(`
+	stmt, err := tx.PrepareContext(stmtCtx, `
 		INSERT OR REPLACE INTO audit_logs
 		(id, timestamp, event_type, identity_id, username, resource, resource_id,
 		 action, result, message, client_ip, metadata)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
-		return fmt.Errorf("prepare statement: %w", err)
+		return dbError(stmtCtx, "prepare statement", err)
 	}
 	defer func() { _ = stmt.Close() }()
 
@@ -156,7 +160,7 @@ func runLoad(ctx context.Context, profile, formatStr, endpointFQDN, startTimeStr
 	for _, log := range logs.Data {
 		metadataJSON, _ := json.Marshal(log.Metadata)
 
-		_, err := stmt.Exec(
+		_, err := stmt.ExecContext(stmtCtx,
 			log.ID,
 			log.Timestamp,
 			log.EventType,
@@ -171,18 +175,18 @@ func runLoad(ctx context.Context, profile, formatStr, endpointFQDN, startTimeStr
 			string(metadataJSON),
 		)
 		if err != nil {
-			return fmt.Errorf("insert log: %w", err)
+			return dbError(stmtCtx, "insert log", err)
 		}
 		loaded++
 	}
 
 	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("commit transaction: %w", err)
+		return dbError(stmtCtx, "commit transaction", err)
 	}
 
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(map[string]interface{}{
+	if formatter.IsStructured() {
+		return formatter.PrintData(map[string]interface{}{
 			"loaded": loaded,
 			"database": dbPath,
 		})
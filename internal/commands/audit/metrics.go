@@ -0,0 +1,314 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+// NewMetricsCmd creates the audit metrics command.
+func NewMetricsCmd() *cobra.Command {
+	var (
+		listen   string
+		interval time.Duration
+		window   time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "Serve audit log metrics for Prometheus",
+		Long: `Serve metrics derived from the local audit database in the Prometheus
+text exposition format.
+
+The metrics are recomputed from the database every --interval and served
+at /metrics. Keep the database current with a scheduled 'audit load' so
+that transfer counts and failure ratios reflect recent activity.
+
+Metrics:
+  gcs_audit_events_total{event_type,result}      Audit events in the database
+  gcs_audit_transfers_total{result}              Transfer events in the database
+  gcs_audit_transfer_failure_ratio               Failed share of transfers in --window
+  gcs_audit_window_transfers                     Transfers in --window
+  gcs_audit_collection_events_total{collection}  Events per collection
+  gcs_audit_metrics_last_refresh_timestamp_seconds
+  gcs_audit_metrics_refresh_errors_total
+
+Example:
+  globus-connect-server audit metrics --listen :9200 --interval 1m --window 1h`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runMetrics(cmd.Context(), listen, interval, window, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVar(&listen, "listen", ":9200", "Address to serve metrics on")
+	cmd.Flags().DurationVar(&interval, "interval", time.Minute, "How often to recompute metrics from the database")
+	cmd.Flags().DurationVar(&window, "window", time.Hour, "Period used for the transfer failure ratio")
+
+	return cmd
+}
+
+// labeledCount is a count for one combination of label values.
+type labeledCount struct {
+	Labels []string
+	Count  int64
+}
+
+// auditMetrics is one computation of the metrics served by 'audit metrics'.
+type auditMetrics struct {
+	Events          []labeledCount // labels: event_type, result
+	Transfers       []labeledCount // labels: result
+	Collections     []labeledCount // labels: collection
+	WindowTransfers int64
+	WindowFailures  int64
+	RefreshedAt     time.Time
+	RefreshErrors   int64
+}
+
+// metricsServer recomputes metrics on an interval and serves the latest
+// result.
+type metricsServer struct {
+	db     *sql.DB
+	window time.Duration
+
+	mu      sync.Mutex
+	metrics *auditMetrics
+	errors  int64
+}
+
+// runMetrics serves audit metrics until ctx is done or the process is
+// interrupted.
+func runMetrics(ctx context.Context, listen string, interval, window time.Duration, out io.Writer) error {
+	if interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	if window <= 0 {
+		return fmt.Errorf("--window must be positive")
+	}
+
+	dbPath, err := getAuditDBPath()
+	if err != nil {
+		return fmt.Errorf("get database path: %w", err)
+	}
+
+	db, err := initAuditDB(ctx, dbPath)
+	if err != nil {
+		return fmt.Errorf("initialize database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	ctx, stop := interruptible(ctx)
+	defer stop()
+
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", listen, err)
+	}
+
+	srv := &metricsServer{db: db, window: window}
+	srv.refresh(ctx)
+	go srv.refreshEvery(ctx, interval)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", srv)
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			errChan <- fmt.Errorf("metrics server: %w", err)
+		}
+	}()
+
+	_, _ = fmt.Fprintf(out, "Serving audit metrics on http://%s/metrics (refresh every %s)\n", listener.Addr(), interval)
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+		return nil
+	}
+}
+
+// refreshEvery recomputes the metrics every interval until ctx is done.
+func (s *metricsServer) refreshEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.refresh(ctx)
+		}
+	}
+}
+
+// refresh recomputes the metrics. On failure the previous values are kept
+// and the error counter is incremented.
+func (s *metricsServer) refresh(ctx context.Context) {
+	stmtCtx, cancel := statementContext(ctx)
+	defer cancel()
+	metrics, err := collectMetrics(stmtCtx, s.db, time.Now().Add(-s.window))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.errors++
+		cli.Warnf("refresh audit metrics: %v", err)
+		return
+	}
+	s.metrics = metrics
+}
+
+// ServeHTTP writes the latest metrics in the Prometheus text format.
+func (s *metricsServer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	var metrics auditMetrics
+	if s.metrics != nil {
+		metrics = *s.metrics
+	}
+	metrics.RefreshErrors = s.errors
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = writeMetrics(w, &metrics)
+}
+
+// collectMetrics computes the audit metrics from db. Transfers at or after
+// since count toward the failure ratio.
+func collectMetrics(ctx context.Context, db *sql.DB, since time.Time) (*auditMetrics, error) {
+	metrics := &auditMetrics{RefreshedAt: time.Now()}
+
+	var err error
+	metrics.Events, err = queryCounts(ctx, db,
+		"SELECT COALESCE(event_type, ''), COALESCE(result, ''), COUNT(*) FROM audit_logs GROUP BY 1, 2", 2)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.Transfers, err = queryCounts(ctx, db,
+		"SELECT COALESCE(result, ''), COUNT(*) FROM audit_logs WHERE event_type = 'transfer' GROUP BY 1", 1)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.Collections, err = queryCounts(ctx, db,
+		"SELECT resource_id, COUNT(*) FROM audit_logs WHERE resource = 'collection' AND resource_id != '' GROUP BY 1", 1)
+	if err != nil {
+		return nil, err
+	}
+
+	row := db.QueryRowContext(ctx,
+		"SELECT COUNT(*), COALESCE(SUM(CASE WHEN result = 'failure' THEN 1 ELSE 0 END), 0) FROM audit_logs WHERE event_type = 'transfer' AND timestamp >= ?",
+		since)
+	if err := row.Scan(&metrics.WindowTransfers, &metrics.WindowFailures); err != nil {
+		return nil, fmt.Errorf("query transfer window: %w", err)
+	}
+
+	return metrics, nil
+}
+
+// queryCounts runs a query whose rows are n label values followed by a
+// count.
+func queryCounts(ctx context.Context, db *sql.DB, query string, n int) ([]labeledCount, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query database: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var counts []labeledCount
+	for rows.Next() {
+		c := labeledCount{Labels: make([]string, n)}
+		dest := make([]interface{}, 0, n+1)
+		for i := range c.Labels {
+			dest = append(dest, &c.Labels[i])
+		}
+		dest = append(dest, &c.Count)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate rows: %w", err)
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		return strings.Join(counts[i].Labels, "\x00") < strings.Join(counts[j].Labels, "\x00")
+	})
+	return counts, nil
+}
+
+// writeMetrics writes metrics in the Prometheus text exposition format.
+func writeMetrics(w io.Writer, m *auditMetrics) error {
+	var b strings.Builder
+
+	writeFamily(&b, "gcs_audit_events_total", "counter", "Audit events in the local database.",
+		[]string{"event_type", "result"}, m.Events)
+	writeFamily(&b, "gcs_audit_transfers_total", "counter", "Transfer events in the local database.",
+		[]string{"result"}, m.Transfers)
+	writeFamily(&b, "gcs_audit_collection_events_total", "counter", "Audit events per collection.",
+		[]string{"collection"}, m.Collections)
+
+	ratio := 0.0
+	if m.WindowTransfers > 0 {
+		ratio = float64(m.WindowFailures) / float64(m.WindowTransfers)
+	}
+	writeGauge(&b, "gcs_audit_window_transfers", "Transfer events in the failure ratio window.", float64(m.WindowTransfers))
+	writeGauge(&b, "gcs_audit_transfer_failure_ratio", "Failed share of transfer events in the window.", ratio)
+
+	refreshed := 0.0
+	if !m.RefreshedAt.IsZero() {
+		refreshed = float64(m.RefreshedAt.Unix())
+	}
+	writeGauge(&b, "gcs_audit_metrics_last_refresh_timestamp_seconds", "Time of the last successful refresh.", refreshed)
+	fmt.Fprintf(&b, "# HELP gcs_audit_metrics_refresh_errors_total Failed metric refreshes.\n")
+	fmt.Fprintf(&b, "# TYPE gcs_audit_metrics_refresh_errors_total counter\n")
+	fmt.Fprintf(&b, "gcs_audit_metrics_refresh_errors_total %d\n", m.RefreshErrors)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeFamily writes a metric family with one sample per labeled count.
+func writeFamily(b *strings.Builder, name, kind, help string, labels []string, counts []labeledCount) {
+	fmt.Fprintf(b, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE %s %s\n", name, kind)
+	for _, c := range counts {
+		pairs := make([]string, len(labels))
+		for i, label := range labels {
+			pairs[i] = fmt.Sprintf("%s=\"%s\"", label, escapeLabel(c.Labels[i]))
+		}
+		fmt.Fprintf(b, "%s{%s} %d\n", name, strings.Join(pairs, ","), c.Count)
+	}
+}
+
+// writeGauge writes a single unlabeled gauge.
+func writeGauge(b *strings.Builder, name, help string, value float64) {
+	fmt.Fprintf(b, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE %s gauge\n", name)
+	fmt.Fprintf(b, "%s %g\n", name, value)
+}
+
+// escapeLabel escapes a label value for the text exposition format.
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"context"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCollectMetrics(t *testing.T) {
+	db, err := initAuditDB(context.Background(), filepath.Join(t.TempDir(), "audit.db"))
+	if err != nil {
+		t.Fatalf("initAuditDB() error = %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	now := time.Now().UTC()
+	rows := []struct {
+		id, eventType, resource, resourceID, result string
+		at                                          time.Time
+	}{
+		{"1", "transfer", "collection", "col-a", "success", now.Add(-10 * time.Minute)},
+		{"2", "transfer", "collection", "col-a", "failure", now.Add(-5 * time.Minute)},
+		{"3", "transfer", "collection", "col-b", "failure", now.Add(-48 * time.Hour)},
+		{"4", "access", "endpoint", "ep", "success", now.Add(-time.Minute)},
+	}
+	for _, r := range rows {
+		if _, err := db.Exec(
+			"INSERT INTO audit_logs (id, timestamp, event_type, resource, resource_id, result) VALUES (?, ?, ?, ?, ?, ?)",
+			r.id, r.at, r.eventType, r.resource, r.resourceID, r.result); err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	}
+
+	metrics, err := collectMetrics(context.Background(), db, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("collectMetrics() error = %v", err)
+	}
+	if metrics.WindowTransfers != 2 || metrics.WindowFailures != 1 {
+		t.Errorf("window = %d transfers, %d failures; want 2, 1", metrics.WindowTransfers, metrics.WindowFailures)
+	}
+
+	rec := httptest.NewRecorder()
+	srv := &metricsServer{metrics: metrics, errors: 2}
+	srv.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+
+	for _, want := range []string{
+		`gcs_audit_events_total{event_type="transfer",result="failure"} 2`,
+		`gcs_audit_events_total{event_type="access",result="success"} 1`,
+		`gcs_audit_transfers_total{result="success"} 1`,
+		`gcs_audit_collection_events_total{collection="col-a"} 2`,
+		`gcs_audit_collection_events_total{collection="col-b"} 1`,
+		"gcs_audit_transfer_failure_ratio 0.5\n",
+		"gcs_audit_metrics_refresh_errors_total 2\n",
+		"# TYPE gcs_audit_transfers_total counter\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %q\n%s", want, body)
+		}
+	}
+}
+
+func TestEscapeLabel(t *testing.T) {
+	if got := escapeLabel("a\"b\\c\nd"); got != `a\"b\\c\nd` {
+		t.Errorf("escapeLabel() = %q", got)
+	}
+}
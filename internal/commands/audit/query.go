@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -51,13 +52,14 @@ Example:
 		},
 	}
 
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&startTime, "start-time", "", "Start time (RFC3339 format)")
 	cmd.Flags().StringVar(&endTime, "end-time", "", "End time (RFC3339 format)")
-	cmd.Flags().StringVar(&eventType, "event-type", "", "Filter by event type")
+	cli.EnumVar(cmd, &eventType, "event-type", "", "Filter by event type (transfer, access, authentication)",
+		gcs.AuditEventTransfer, gcs.AuditEventAccess, gcs.AuditEventAuthentication)
 	cmd.Flags().StringVar(&identityID, "identity", "", "Filter by identity ID")
 	cmd.Flags().StringVar(&action, "action", "", "Filter by action")
-	cmd.Flags().StringVar(&result, "result", "", "Filter by result (success, failure)")
+	cli.EnumVar(cmd, &result, "result", "", "Filter by result (success, failure)", gcs.AuditResultSuccess, gcs.AuditResultFailure)
 	cmd.Flags().IntVar(&limit, "limit", 100, "Maximum number of results")
 
 	return cmd
@@ -66,8 +68,11 @@ Example:
 // runQuery executes the audit query command.
 func runQuery(ctx context.Context, formatStr, startTimeStr, endTimeStr, eventType,
 	identityID, action, result string, limit int, out interface{ Write([]byte) (int, error) }) error {
+	ctx, stop := interruptible(ctx)
+	defer stop()
+
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Parse time parameters
 	var startTime, endTime *time.Time
@@ -92,7 +97,7 @@ func runQuery(ctx context.Context, formatStr, startTimeStr, endTimeStr, eventTyp
 		return fmt.Errorf("get database path: %w", err)
 	}
 
-	db, err := initAuditDB(dbPath)
+	db, err := initAuditDB(ctx, dbPath)
 	if err != nil {
 		return fmt.Errorf("initialize database: %w", err)
 	}
@@ -131,60 +136,40 @@ func runQuery(ctx context.Context, formatStr, startTimeStr, endTimeStr, eventTyp
 	args = append(args, limit)
 
 	// Execute query
-	rows, err := db.QueryContext(ctx, query, args...)
+	stmtCtx, cancel := statementContext(ctx)
+	defer cancel()
+
+	rows, err := db.QueryContext(stmtCtx, query, args...)
 	if err != nil {
-		return fmt.Errorf("query database: %w", err)
+		return dbError(stmtCtx, "query database", err)
 	}
 	defer func() { _ = rows.Close() }()
 
 	// Fetch results
 	logs, err := scanAuditLogs(rows)
 	if err != nil {
-		return err
+		return dbError(stmtCtx, "read results", err)
 	}
 
 	// Output results
 	return formatQueryResults(formatter, logs)
 }
 
-// scanAuditLogs scans query results into audit log entries.
-func scanAuditLogs(rows interface {
+// rowScanner is the subset of *sql.Rows used to read audit log rows.
+type rowScanner interface {
 	Next() bool
 	Scan(dest ...interface{}) error
 	Err() error
-}) ([]gcs.AuditLog, error) {
+}
+
+// scanAuditLogs scans query results into audit log entries.
+func scanAuditLogs(rows rowScanner) ([]gcs.AuditLog, error) {
 	var logs []gcs.AuditLog
 	for rows.Next() {
-		var log gcs.AuditLog
-		var timestamp string
-		var metadataJSON string
-
-		err := rows.Scan(
-			&log.ID,
-			&timestamp,
-			&log.EventType,
-			&log.IdentityID,
-			&log.Username,
-			&log.Resource,
-			&log.ResourceID,
-			&log.Action,
-			&log.Result,
-			&log.Message,
-			&log.ClientIP,
-			&metadataJSON,
-		)
+		log, err := scanAuditLog(rows)
 		if err != nil {
-			return nil, fmt.Errorf("scan row: %w", err)
-		}
-
-		// Parse timestamp
-		log.Timestamp, _ = time.Parse(time.RFC3339, timestamp)
-
-		// Parse metadata JSON
-		if metadataJSON != "" {
-			_ = json.Unmarshal([]byte(metadataJSON), &log.Metadata)
+			return nil, err
 		}
-
 		logs = append(logs, log)
 	}
 
@@ -195,11 +180,46 @@ func scanAuditLogs(rows interface {
 	return logs, nil
 }
 
+// scanAuditLog scans the current row into an audit log entry.
+func scanAuditLog(rows rowScanner) (gcs.AuditLog, error) {
+	var log gcs.AuditLog
+	var timestamp string
+	var metadataJSON string
+
+	err := rows.Scan(
+		&log.ID,
+		&timestamp,
+		&log.EventType,
+		&log.IdentityID,
+		&log.Username,
+		&log.Resource,
+		&log.ResourceID,
+		&log.Action,
+		&log.Result,
+		&log.Message,
+		&log.ClientIP,
+		&metadataJSON,
+	)
+	if err != nil {
+		return log, fmt.Errorf("scan row: %w", err)
+	}
+
+	// Parse timestamp
+	log.Timestamp, _ = time.Parse(time.RFC3339, timestamp)
+
+	// Parse metadata JSON
+	if metadataJSON != "" {
+		_ = json.Unmarshal([]byte(metadataJSON), &log.Metadata)
+	}
+
+	return log, nil
+}
+
 // formatQueryResults formats query results for output.
 func formatQueryResults(formatter *output.Formatter, logs []gcs.AuditLog) error {
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(map[string]interface{}{
+	if formatter.IsStructured() {
+		return formatter.PrintData(map[string]interface{}{
 			"count": len(logs),
 			"logs":  logs,
 		})
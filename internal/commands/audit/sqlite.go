@@ -0,0 +1,7 @@
+//go:build !js
+
+package audit
+
+// The SQLite driver needs a libc port, which js/wasm does not have; without
+// it, opening the audit database fails with an unknown driver error.
+import _ "modernc.org/sqlite" // SQLite driver
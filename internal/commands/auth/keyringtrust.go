@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// newKeyringTrustCmd creates the auth keyring-trust command.
+func newKeyringTrustCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "keyring-trust",
+		Short: "Stop keychain prompts for the encryption key",
+		Long: `Allow this program to read the token encryption key without asking.
+
+On macOS, the Keychain asks for authorization whenever a program that is not
+on an item's access list reads it, so a script running many commands can
+trigger a prompt for each one. New keys are created with this program and
+/usr/bin/security on their access list; keyring-trust updates keys created
+by earlier versions, or left untrusted after the program was reinstalled
+elsewhere.
+
+Each key is read once more (answer "Always Allow" if asked) and stored
+again with the new access list. Run keyring-trust again after moving the
+binary.
+
+Other platforms do not ask per program, and there is nothing to do.
+
+Example:
+  globus-connect-server auth keyring-trust`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runKeyringTrust(format, auth.TrustKeyring, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+
+	return cmd
+}
+
+// runKeyringTrust executes the auth keyring-trust command. trust is
+// auth.TrustKeyring outside tests.
+func runKeyringTrust(formatStr string, trust func() (*auth.KeyringTrust, error), out interface{ Write([]byte) (int, error) }) error {
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	result, err := trust()
+	if errors.Is(err, auth.ErrKeyringTrustUnsupported) {
+		if formatter.IsStructured() {
+			return formatter.PrintData(&auth.KeyringTrust{Items: []string{}})
+		}
+		return formatter.PrintText("The %s keyring does not ask for authorization per program; nothing to do\n", runtime.GOOS)
+	}
+	if err != nil {
+		return fmt.Errorf("trust keyring: %w", err)
+	}
+
+	// Output based on format
+	if formatter.IsStructured() {
+		return formatter.PrintData(result)
+	}
+
+	// Text format
+	if len(result.Items) == 0 {
+		return formatter.PrintText("No encryption keys in the keyring yet; new keys are trusted when created\n")
+	}
+	return formatter.PrintText("✓ Updated access control of %d keyring item(s)\n", len(result.Items))
+}
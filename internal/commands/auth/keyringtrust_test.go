@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+)
+
+func TestRunKeyringTrust(t *testing.T) {
+	trust := func() (*auth.KeyringTrust, error) {
+		return &auth.KeyringTrust{Items: []string{"encryption-key-current", "encryption-key"}}, nil
+	}
+
+	buf := &bytes.Buffer{}
+	if err := runKeyringTrust("text", trust, buf); err != nil {
+		t.Fatalf("runKeyringTrust() error = %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "2 keyring item(s)") {
+		t.Errorf("runKeyringTrust() output = %q", got)
+	}
+}
+
+func TestRunKeyringTrust_Unsupported(t *testing.T) {
+	trust := func() (*auth.KeyringTrust, error) {
+		return nil, auth.ErrKeyringTrustUnsupported
+	}
+
+	buf := &bytes.Buffer{}
+	if err := runKeyringTrust("text", trust, buf); err != nil {
+		t.Fatalf("runKeyringTrust() error = %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "nothing to do") {
+		t.Errorf("runKeyringTrust() output = %q", got)
+	}
+}
+
+func TestRunKeyringTrust_Error(t *testing.T) {
+	trust := func() (*auth.KeyringTrust, error) {
+		return nil, errors.New("user canceled")
+	}
+
+	buf := &bytes.Buffer{}
+	if err := runKeyringTrust("text", trust, buf); err == nil || !strings.Contains(err.Error(), "user canceled") {
+		t.Errorf("runKeyringTrust() error = %v, want user canceled", err)
+	}
+}
@@ -4,12 +4,18 @@ package auth
 import (
 	"context"
 	"fmt"
+	"io"
+	"maps"
 	"net/http"
+	"os"
+	"regexp"
+	"slices"
 	"time"
 
 	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
-	globusauth "github.com/scttfrdmn/globus-go-sdk/v3/pkg/services/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/spf13/cobra"
 )
 
@@ -19,6 +25,10 @@ const (
 		"urn:globus:auth:scope:auth.globus.org:view_identities " +
 		"urn:globus:auth:scope:transfer.api.globus.org:all"
 
+	// Reduced OAuth2 scopes requested for reporter tokens (--reporter)
+	reporterScopes = "openid profile email " +
+		"urn:globus:auth:scope:auth.globus.org:view_identities"
+
 	// Local callback server settings
 	callbackPort = "8080"
 	callbackPath = "/callback"
@@ -27,9 +37,11 @@ const (
 // NewLoginCmd creates the login command.
 func NewLoginCmd() *cobra.Command {
 	var (
-		profile string
-		scopes  string
-		noLocal bool
+		profile   string
+		scopes    string
+		noLocal   bool
+		reporter  bool
+		endpoints []string
 	)
 
 	cmd := &cobra.Command{
@@ -43,34 +55,106 @@ After authentication, your tokens are securely stored locally.
 The tokens are stored in: ~/.globus-connect-server/tokens/<profile>.json
 
 By default, uses a local callback server to receive the OAuth code.
-Use --no-local-server to manually copy/paste the authorization code.`,
+Use --no-local-server to manually copy/paste the authorization code.
+
+Use --reporter to store a second, reduced-scope "reporter" token for the
+profile in ~/.globus-connect-server/tokens/<profile>.reporter.json.
+Read-only commands (show, list, audit dump, ...) use the reporter token
+automatically when it exists, so hosts that only run reports never need
+to hold a mutation-capable credential.
+
+The GCS Manager API of each endpoint is a resource server of its own. Use
+--endpoint to also request a token for managing an endpoint's collections;
+commands talking to that endpoint then use it. Endpoint tokens from
+earlier logins of the profile are kept, so a single profile can manage
+several endpoints:
+
+  globus-connect-server login --endpoint abc.def.data.globus.org
+  globus-connect-server login --endpoint ghi.jkl.data.globus.org`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runLogin(cmd.Context(), profile, scopes, noLocal)
+			if reporter && !cmd.Flags().Changed("scopes") {
+				scopes = reporterScopes
+			}
+			return runLogin(cmd.Context(), profile, scopes, noLocal, reporter, endpoints)
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
 	cmd.Flags().StringVar(&scopes, "scopes", defaultScopes, "OAuth2 scopes (space-separated)")
 	cmd.Flags().BoolVar(&noLocal, "no-local-server", false, "Disable local callback server (manual code entry)")
+	cmd.Flags().BoolVar(&reporter, "reporter", false, "Store a reduced-scope token used by read-only commands")
+	cmd.Flags().StringArrayVar(&endpoints, "endpoint", nil, "Endpoint FQDN or ID to request a manage_collections token for (repeatable)")
 
 	return cmd
 }
 
 // runLogin executes the login flow.
-func runLogin(ctx context.Context, profile, scopes string, noLocal bool) error {
-	// Load client configuration
-	cfg, err := config.LoadClientConfig()
+func runLogin(ctx context.Context, profile, scopes string, noLocal, reporter bool, endpoints []string) error {
+	fqdns := map[string]string{}
+	for _, endpoint := range endpoints {
+		endpointID := endpoint
+		if !isEndpointID(endpoint) {
+			id, err := lookupEndpointID(ctx, endpoint)
+			if err != nil {
+				return fmt.Errorf("look up endpoint ID of %s: %w", endpoint, err)
+			}
+			endpointID = id
+			fqdns[endpoint] = id
+		}
+		scopes += " " + auth.EndpointScope(endpointID)
+	}
+	return login(ctx, profile, scopes, noLocal, reporter, fqdns, os.Stdout)
+}
+
+// endpointIDPattern matches endpoint IDs, as opposed to FQDNs.
+var endpointIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// isEndpointID reports whether endpoint is an endpoint ID.
+func isEndpointID(endpoint string) bool {
+	return endpointIDPattern.MatchString(endpoint)
+}
+
+// lookupEndpointID returns the ID of the endpoint at fqdn from its
+// unauthenticated info document. It is a test hook.
+var lookupEndpointID = func(ctx context.Context, fqdn string) (string, error) {
+	opts, err := cli.ClientOptions()
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		return "", err
+	}
+	client, err := gcs.NewClient(fqdn, opts...)
+	if err != nil {
+		return "", err
+	}
+	info, err := client.GetInfo(ctx)
+	if err != nil {
+		return "", err
 	}
+	if info.EndpointID == "" {
+		return "", fmt.Errorf("%s did not report an endpoint ID", fqdn)
+	}
+	return info.EndpointID, nil
+}
 
-	// Create auth client
-	authClient, err := globusauth.NewClient(
-		globusauth.WithClientID(cfg.ClientID),
-		globusauth.WithClientSecret(cfg.ClientSecret),
-	)
+// Relogin runs the interactive login flow for profile in the middle of
+// another command, after its token was rejected and could not be
+// refreshed. Messages go to stderr so that the command's output is not
+// corrupted. With reporter, the profile's reporter token is replaced.
+func Relogin(ctx context.Context, profile string, reporter bool) error {
+	scopes := defaultScopes
+	if reporter {
+		scopes = reporterScopes
+	}
+	return login(ctx, profile, scopes, false, reporter, nil, os.Stderr)
+}
+
+// login performs the OAuth2 flow and saves the resulting token, writing
+// instructions and progress to out. endpoints maps the FQDNs of the
+// endpoints whose scopes are requested to their IDs.
+func login(ctx context.Context, profile, scopes string, noLocal, reporter bool, endpoints map[string]string, out io.Writer) error {
+	// Create auth client for the profile's Globus environment
+	authClient, err := cli.NewAuthClient()
 	if err != nil {
-		return fmt.Errorf("create auth client: %w", err)
+		return err
 	}
 
 	// Set redirect URI
@@ -83,24 +167,24 @@ func runLogin(ctx context.Context, profile, scopes string, noLocal bool) error {
 	// Get authorization URL
 	authURL := authClient.GetAuthorizationURL(state, scopes)
 
-	fmt.Println("Please authenticate by visiting this URL:")
-	fmt.Println()
-	fmt.Println(authURL)
-	fmt.Println()
+	fmt.Fprintln(out, "Please authenticate by visiting this URL:")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, authURL)
+	fmt.Fprintln(out)
 
 	// Get authorization code
 	var code string
 	if noLocal {
-		code, err = getCodeManual()
+		code, err = getCodeManual(out)
 	} else {
-		code, err = getCodeViaCallback(ctx, state, callbackPort, callbackPath)
+		code, err = getCodeViaCallback(ctx, state, callbackPort, callbackPath, out)
 	}
 	if err != nil {
 		return fmt.Errorf("get authorization code: %w", err)
 	}
 
 	// Exchange code for tokens
-	fmt.Println("Exchanging authorization code for tokens...")
+	fmt.Fprintln(out, "Exchanging authorization code for tokens...")
 	tokenResp, err := authClient.ExchangeAuthorizationCode(ctx, code)
 	if err != nil {
 		return fmt.Errorf("exchange code: %w", err)
@@ -108,20 +192,47 @@ func runLogin(ctx context.Context, profile, scopes string, noLocal bool) error {
 
 	// Save tokens
 	tokenInfo := auth.TokenFromAuthResponse(tokenResp)
-	if err := auth.SaveToken(profile, tokenInfo); err != nil {
+	for fqdn, endpointID := range endpoints {
+		tokenInfo.SetEndpoint(fqdn, endpointID)
+	}
+	tokenInfo.KeepEndpoints(previousToken(profile, reporter))
+	if reporter {
+		if err := auth.SaveReporterToken(profile, tokenInfo); err != nil {
+			return fmt.Errorf("save reporter token: %w", err)
+		}
+	} else if err := auth.SaveToken(profile, tokenInfo); err != nil {
 		return fmt.Errorf("save token: %w", err)
 	}
 
-	fmt.Println("✓ Login successful!")
-	fmt.Printf("Profile: %s\n", profile)
-	fmt.Printf("Token expires: %s\n", tokenInfo.ExpiresAt.Format(time.RFC3339))
+	fmt.Fprintln(out, "✓ Login successful!")
+	fmt.Fprintf(out, "Profile: %s\n", profile)
+	if reporter {
+		fmt.Fprintln(out, "Token type: reporter (used by read-only commands)")
+	}
+	for _, fqdn := range slices.Sorted(maps.Keys(tokenInfo.Endpoints)) {
+		fmt.Fprintf(out, "Endpoint: %s (%s)\n", fqdn, tokenInfo.Endpoints[fqdn])
+	}
+	fmt.Fprintf(out, "Token expires: %s\n", tokenInfo.ExpiresAt.Format(time.RFC3339))
 
 	return nil
 }
 
+// previousToken returns the token a login for profile replaces, or nil.
+func previousToken(profile string, reporter bool) *auth.TokenInfo {
+	load := auth.LoadToken
+	if reporter {
+		load = auth.LoadReporterToken
+	}
+	token, err := load(profile)
+	if err != nil {
+		return nil
+	}
+	return token
+}
+
 // getCodeManual prompts the user to manually enter the authorization code.
-func getCodeManual() (string, error) {
-	fmt.Print("Enter authorization code: ")
+func getCodeManual(out io.Writer) (string, error) {
+	fmt.Fprint(out, "Enter authorization code: ")
 	var code string
 	if _, err := fmt.Scanln(&code); err != nil {
 		return "", fmt.Errorf("read code: %w", err)
@@ -131,7 +242,7 @@ func getCodeManual() (string, error) {
 }
 
 // getCodeViaCallback starts a local HTTP server to receive the OAuth callback.
-func getCodeViaCallback(ctx context.Context, expectedState, port, path string) (string, error) {
+func getCodeViaCallback(ctx context.Context, expectedState, port, path string, out io.Writer) (string, error) {
 	// Create channel to receive code
 	codeChan := make(chan string, 1)
 	errChan := make(chan error, 1)
@@ -186,9 +297,9 @@ func getCodeViaCallback(ctx context.Context, expectedState, port, path string) (
 		}
 	}()
 
-	fmt.Printf("Waiting for authentication on http://localhost:%s%s\n", port, path)
-	fmt.Println("(If browser doesn't open automatically, copy the URL above)")
-	fmt.Println()
+	fmt.Fprintf(out, "Waiting for authentication on http://localhost:%s%s\n", port, path)
+	fmt.Fprintln(out, "(If browser doesn't open automatically, copy the URL above)")
+	fmt.Fprintln(out)
 
 	// Wait for code or error with timeout
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
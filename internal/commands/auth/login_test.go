@@ -3,7 +3,7 @@ package auth
 import (
 	"testing"
 
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 )
 
 func TestNewLoginCmd(t *testing.T) {
@@ -57,6 +57,17 @@ func TestNewLoginCmd_Flags(t *testing.T) {
 			shorthand: "",
 			// Bool flags have "false" as default
 		},
+		{
+			name:      "reporter flag",
+			flagName:  "reporter",
+			shorthand: "",
+		},
+		{
+			name:         "endpoint flag",
+			flagName:     "endpoint",
+			shorthand:    "",
+			defaultValue: "[]",
+		},
 	}
 
 	for _, tt := range tests {
@@ -100,3 +111,17 @@ func TestGenerateState(t *testing.T) {
 		t.Errorf("generateState() prefix = %q, want %q", actualPrefix, expectedPrefix)
 	}
 }
+
+func TestIsEndpointID(t *testing.T) {
+	tests := map[string]bool{
+		"3f2c7a9e-5d1b-4e8a-9c6f-0b7d2e4a1c93": true,
+		"3F2C7A9E-5D1B-4E8A-9C6F-0B7D2E4A1C93": true,
+		"abc.def.data.globus.org":              false,
+		"3f2c7a9e-5d1b-4e8a-9c6f":              false,
+	}
+	for endpoint, want := range tests {
+		if got := isEndpointID(endpoint); got != want {
+			t.Errorf("isEndpointID(%q) = %v, want %v", endpoint, got, want)
+		}
+	}
+}
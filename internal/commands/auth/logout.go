@@ -1,49 +1,130 @@
 package auth
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/spf13/cobra"
 )
 
 // NewLogoutCmd creates the logout command.
 func NewLogoutCmd() *cobra.Command {
-	var profile string
+	var (
+		profile   string
+		reporter  bool
+		localOnly bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "logout",
 		Short: "Remove stored authentication tokens",
 		Long: `Remove stored authentication tokens for the specified profile.
 
-This command deletes the locally stored tokens, effectively logging you out.
-You will need to login again to use authenticated commands.
+This command revokes the stored access and refresh tokens with Globus Auth,
+so that copies of them stop working, and then deletes them, logging you
+out. You will need to login again to use authenticated commands.
 
-The token file is removed from: ~/.globus-connect-server/tokens/<profile>.json`,
-		RunE: func(_ *cobra.Command, _ []string) error {
-			return runLogout(profile)
+If revocation fails, the tokens are kept so that logout can be retried. Use
+--local-only to delete them without contacting Globus Auth, for example
+when offline.
+
+The token file is removed from: ~/.globus-connect-server/tokens/<profile>.json
+Any reporter token stored for the profile is removed as well.
+
+Use --reporter to remove only the reporter token and keep the regular
+session.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runLogout(cmd.Context(), profile, reporter, localOnly)
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().BoolVar(&reporter, "reporter", false, "Remove only the reporter token")
+	cmd.Flags().BoolVar(&localOnly, "local-only", false, "Delete the tokens without revoking them")
 
 	return cmd
 }
 
-// runLogout executes the logout flow.
-func runLogout(profile string) error {
-	// Check if token exists
-	_, err := auth.LoadToken(profile)
+// newLogoutAuthClient creates the Globus Auth client tokens are revoked
+// with. It is a test hook.
+var newLogoutAuthClient = cli.NewAuthClient
+
+// runLogout executes the logout flow. Unless localOnly, the tokens are
+// revoked before they are deleted.
+func runLogout(ctx context.Context, profile string, reporterOnly, localOnly bool) error {
+	// Check for a reporter token
+	hasReporter, err := auth.HasReporterToken(profile)
 	if err != nil {
+		return err
+	}
+
+	if reporterOnly {
+		if !hasReporter {
+			return fmt.Errorf("no reporter token for profile %q", profile)
+		}
+		if !localOnly {
+			if err := revokeReporterToken(ctx, profile); err != nil {
+				return err
+			}
+		}
+		if err := auth.DeleteReporterToken(profile); err != nil {
+			return fmt.Errorf("delete reporter token: %w", err)
+		}
+		fmt.Printf("✓ Removed reporter token from profile: %s\n", profile)
+		return nil
+	}
+
+	// Check if token exists
+	token, err := auth.LoadToken(profile)
+	if err != nil && !hasReporter {
 		return fmt.Errorf("no active session for profile %q", profile)
 	}
 
-	// Delete token
+	if !localOnly {
+		if err == nil {
+			if err := revokeStored(ctx, token); err != nil {
+				return err
+			}
+		}
+		if hasReporter {
+			if err := revokeReporterToken(ctx, profile); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Delete tokens
 	if err := auth.DeleteToken(profile); err != nil {
 		return fmt.Errorf("delete token: %w", err)
 	}
+	if err := auth.DeleteReporterToken(profile); err != nil {
+		return fmt.Errorf("delete reporter token: %w", err)
+	}
 
 	fmt.Printf("✓ Logged out from profile: %s\n", profile)
 	return nil
 }
+
+// revokeReporterToken revokes the reporter token of profile.
+func revokeReporterToken(ctx context.Context, profile string) error {
+	token, err := auth.LoadReporterToken(profile)
+	if err != nil {
+		return fmt.Errorf("load reporter token to revoke: %w (use --local-only to delete it without revoking)", err)
+	}
+	return revokeStored(ctx, token)
+}
+
+// revokeStored revokes token and all tokens stored with it.
+func revokeStored(ctx context.Context, token *auth.TokenInfo) error {
+	authClient, err := newLogoutAuthClient()
+	if err != nil {
+		return err
+	}
+	if err := auth.RevokeToken(ctx, token, authClient); err != nil {
+		return fmt.Errorf("%w (tokens were kept; use --local-only to delete them without revoking)", err)
+	}
+	return nil
+}
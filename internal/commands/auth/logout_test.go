@@ -1,9 +1,16 @@
 package auth
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	globusauth "github.com/scttfrdmn/globus-go-sdk/v3/pkg/services/auth"
 )
 
 func TestNewLogoutCmd(t *testing.T) {
@@ -49,8 +56,91 @@ func TestNewLogoutCmd_Flags(t *testing.T) {
 
 func TestRunLogout_NoToken(t *testing.T) {
 	// Test with a profile that doesn't exist
-	err := runLogout("nonexistent-profile-test")
+	err := runLogout(context.Background(), "nonexistent-profile-test", false, false)
 	if err == nil {
 		t.Error("runLogout() expected error for nonexistent profile, got nil")
 	}
 }
+
+func TestRunLogout_NoReporterToken(t *testing.T) {
+	err := runLogout(context.Background(), "nonexistent-profile-test", true, false)
+	if err == nil {
+		t.Error("runLogout() expected error for missing reporter token, got nil")
+	}
+}
+
+// useRevocationServer saves a token for profile in passphrase mode and
+// points logout at a Globus Auth fake that records revoked tokens and
+// answers with status.
+func useRevocationServer(t *testing.T, profile string, status int) *[]string {
+	t.Helper()
+	t.Setenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR", t.TempDir())
+	t.Setenv(auth.PassphraseEnv, "correct horse battery staple")
+	if err := auth.SetTokenEncryption(auth.TokenEncryptionPassphrase); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = auth.SetTokenEncryption(auth.TokenEncryptionKeyring) })
+
+	token := &auth.TokenInfo{AccessToken: "access", RefreshToken: "refresh", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := auth.SaveToken(profile, token); err != nil {
+		t.Fatalf("SaveToken() error = %v", err)
+	}
+
+	var revoked []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oauth2/token/revoke" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		_ = r.ParseForm()
+		revoked = append(revoked, r.PostForm.Get("token"))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	prev := newLogoutAuthClient
+	newLogoutAuthClient = func() (*globusauth.Client, error) {
+		return globusauth.NewClient(globusauth.WithClientID("cli"), globusauth.WithBaseURL(server.URL+"/"))
+	}
+	t.Cleanup(func() { newLogoutAuthClient = prev })
+	return &revoked
+}
+
+func TestRunLogout_Revokes(t *testing.T) {
+	revoked := useRevocationServer(t, "revoke-test", http.StatusOK)
+
+	if err := runLogout(context.Background(), "revoke-test", false, false); err != nil {
+		t.Fatalf("runLogout() error = %v", err)
+	}
+	if strings.Join(*revoked, ",") != "refresh,access" {
+		t.Errorf("revoked %v, want the refresh and access tokens", *revoked)
+	}
+	if _, err := auth.LoadToken("revoke-test"); err == nil {
+		t.Error("token still stored after logout")
+	}
+}
+
+func TestRunLogout_RevocationFails(t *testing.T) {
+	useRevocationServer(t, "revoke-test", http.StatusServiceUnavailable)
+
+	err := runLogout(context.Background(), "revoke-test", false, false)
+	if err == nil || !strings.Contains(err.Error(), "--local-only") {
+		t.Errorf("runLogout() error = %v, want a --local-only hint", err)
+	}
+	if _, err := auth.LoadToken("revoke-test"); err != nil {
+		t.Errorf("token removed although revocation failed: %v", err)
+	}
+}
+
+func TestRunLogout_LocalOnly(t *testing.T) {
+	revoked := useRevocationServer(t, "revoke-test", http.StatusOK)
+
+	if err := runLogout(context.Background(), "revoke-test", false, true); err != nil {
+		t.Fatalf("runLogout() error = %v", err)
+	}
+	if len(*revoked) > 0 {
+		t.Errorf("--local-only revoked %v", *revoked)
+	}
+	if _, err := auth.LoadToken("revoke-test"); err == nil {
+		t.Error("token still stored after logout")
+	}
+}
@@ -0,0 +1,256 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+)
+
+// defaultMinValidity is how long the token must remain valid for a
+// preflight check to pass, unless --min-validity says otherwise.
+const defaultMinValidity = 15 * time.Minute
+
+// identityURNPrefix is the prefix of role principals that are identities.
+const identityURNPrefix = "urn:globus:auth:identity:"
+
+// impliedRoles lists, for each role, the roles it includes.
+var impliedRoles = map[string][]string{
+	"owner":            {"administrator", "access_manager", "activity_manager", "activity_monitor"},
+	"administrator":    {"access_manager", "activity_manager", "activity_monitor"},
+	"activity_manager": {"activity_monitor"},
+}
+
+// preflightOptions are the requirements checked by 'whoami --check-roles'.
+type preflightOptions struct {
+	endpointFQDN string
+	collection   string
+	roles        []string
+	scopes       []string
+	minValidity  time.Duration
+}
+
+// PreflightCheck is the result of one preflight requirement.
+type PreflightCheck struct {
+	Requirement string `json:"requirement"`
+	OK          bool   `json:"ok"`
+	Detail      string `json:"detail,omitempty"`
+}
+
+// PreflightResult is the output of 'whoami --check-roles'.
+type PreflightResult struct {
+	Identity  string           `json:"identity"`
+	Profile   string           `json:"profile"`
+	ExpiresAt time.Time        `json:"expires_at"`
+	Passed    bool             `json:"passed"`
+	Checks    []PreflightCheck `json:"checks"`
+}
+
+// runPreflight checks that the token of profile is valid for at least
+// opts.minValidity, carries opts.scopes, and that its identity holds
+// opts.roles on the endpoint (or collection). Every requirement is
+// checked; the error lists all that are not met.
+func runPreflight(ctx context.Context, profile, formatStr string, opts preflightOptions, out interface{ Write([]byte) (int, error) }) error {
+	// Load token
+	token, err := auth.LoadToken(profile)
+	if err != nil {
+		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+
+	// Check if token is valid
+	if !token.IsValid() {
+		return fmt.Errorf("token expired, please login again")
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	authClient, err := cli.NewAuthClient()
+	if err != nil {
+		return err
+	}
+	introspectResp, err := authClient.IntrospectToken(ctx, token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("introspect token: %w", err)
+	}
+	if !introspectResp.Active {
+		return fmt.Errorf("token is not active, please login again")
+	}
+
+	result := PreflightResult{
+		Identity:  introspectResp.Username,
+		Profile:   profile,
+		ExpiresAt: token.ExpiresAt,
+	}
+	if result.Identity == "" {
+		result.Identity = introspectResp.Subject
+	}
+
+	remaining := time.Until(token.ExpiresAt)
+	result.Checks = append(result.Checks, PreflightCheck{
+		Requirement: fmt.Sprintf("token valid for %s", cli.Duration(opts.minValidity)),
+		OK:          remaining >= opts.minValidity,
+		Detail:      fmt.Sprintf("expires in %s", cli.Duration(remaining)),
+	})
+
+	result.Checks = append(result.Checks, checkScopes(token.Scopes, opts.scopes)...)
+
+	if len(opts.roles) > 0 {
+		client, err := cli.NewGCSClient(opts.endpointFQDN, token.AccessToken)
+		if err != nil {
+			return fmt.Errorf("create GCS client: %w", err)
+		}
+		roles, err := listAllRoles(ctx, client, opts.collection)
+		if err != nil {
+			return err
+		}
+		identities := append([]string{introspectResp.Subject}, introspectResp.IdentitySet...)
+		result.Checks = append(result.Checks, checkRoles(roles, identities, opts)...)
+	}
+
+	var missing []string
+	for _, check := range result.Checks {
+		if !check.OK {
+			missing = append(missing, check.Requirement)
+		}
+	}
+	result.Passed = len(missing) == 0
+
+	if formatter.IsStructured() {
+		if err := formatter.PrintData(result); err != nil {
+			return err
+		}
+	} else if err := printPreflight(formatter, result); err != nil {
+		return err
+	}
+
+	if !result.Passed {
+		return fmt.Errorf("preflight failed, missing: %s", strings.Join(missing, "; "))
+	}
+	return nil
+}
+
+// checkScopes checks that granted, the scopes stored with a token, include
+// each of required.
+func checkScopes(granted, required []string) []PreflightCheck {
+	var have []string
+	for _, scope := range granted {
+		// Tokens from a login store the space-separated scope string
+		have = append(have, strings.Fields(scope)...)
+	}
+
+	checks := make([]PreflightCheck, 0, len(required))
+	for _, scope := range required {
+		check := PreflightCheck{Requirement: "scope " + scope, OK: slices.Contains(have, scope)}
+		if !check.OK {
+			check.Detail = "not granted; log in again with this scope"
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// listAllRoles returns the roles on the endpoint and, if collection is
+// set, those on the collection.
+func listAllRoles(ctx context.Context, client gcs.GCSAPI, collection string) ([]gcs.Role, error) {
+	var roles []gcs.Role
+	seen := map[string]bool{}
+
+	filters := []string{""}
+	if collection != "" {
+		filters = append(filters, collection)
+	}
+	for _, filter := range filters {
+		for role, err := range client.Roles(ctx, &gcs.ListRolesOptions{Collection: filter}) {
+			if err != nil {
+				return nil, fmt.Errorf("list roles: %w", err)
+			}
+			if role.ID == "" || !seen[role.ID] {
+				seen[role.ID] = true
+				roles = append(roles, role)
+			}
+		}
+	}
+	return roles, nil
+}
+
+// checkRoles checks that one of identities holds each role of opts, either
+// on the endpoint or, if opts.collection is set, on that collection. A
+// role includes the roles listed for it in impliedRoles. Assignments to
+// groups are reported but not counted, as group membership is not known.
+func checkRoles(roles []gcs.Role, identities []string, opts preflightOptions) []PreflightCheck {
+	scope := "endpoint " + opts.endpointFQDN
+	if opts.collection != "" {
+		scope = "collection " + opts.collection
+	}
+
+	checks := make([]PreflightCheck, 0, len(opts.roles))
+	for _, want := range opts.roles {
+		check := PreflightCheck{Requirement: fmt.Sprintf("role %s on %s", want, scope)}
+		var groups []string
+		for _, role := range roles {
+			if role.Collection != "" && role.Collection != opts.collection {
+				continue
+			}
+			if role.Role != want && !slices.Contains(impliedRoles[role.Role], want) {
+				continue
+			}
+			if !isIdentityPrincipal(role.Principal) {
+				groups = append(groups, role.Principal)
+				continue
+			}
+			if slices.Contains(identities, strings.TrimPrefix(role.Principal, identityURNPrefix)) {
+				check.OK = true
+				check.Detail = "granted as " + role.Role
+				if role.Collection == "" && opts.collection != "" {
+					check.Detail += " on the endpoint"
+				}
+				break
+			}
+		}
+		if !check.OK {
+			check.Detail = "not granted"
+			if len(groups) > 0 {
+				check.Detail += fmt.Sprintf("; granted to %s, whose membership is not checked", strings.Join(groups, ", "))
+			}
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// isIdentityPrincipal reports whether a role principal is an identity, as
+// opposed to a group. Principals are URNs, or bare identity IDs.
+func isIdentityPrincipal(principal string) bool {
+	return strings.HasPrefix(principal, identityURNPrefix) || !strings.HasPrefix(principal, "urn:")
+}
+
+// printPreflight prints result as text.
+func printPreflight(formatter *output.Formatter, result PreflightResult) error {
+	if err := formatter.PrintText("Preflight for %s (profile %s):\n", result.Identity, result.Profile); err != nil {
+		return err
+	}
+	if err := formatter.Println(); err != nil {
+		return err
+	}
+	for _, check := range result.Checks {
+		mark := formatter.Success("✓")
+		if !check.OK {
+			mark = formatter.Failure("✗")
+		}
+		detail := ""
+		if check.Detail != "" {
+			detail = formatter.Dim(" (" + check.Detail + ")")
+		}
+		if err := formatter.PrintText("  %s %s%s\n", mark, check.Requirement, detail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
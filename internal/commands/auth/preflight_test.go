@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+)
+
+func TestCheckScopes(t *testing.T) {
+	granted := []string{"openid urn:globus:auth:scope:transfer.api.globus.org:all"}
+
+	checks := checkScopes(granted, []string{"urn:globus:auth:scope:transfer.api.globus.org:all", "email"})
+	if len(checks) != 2 {
+		t.Fatalf("checkScopes() returned %d checks, want 2", len(checks))
+	}
+	if !checks[0].OK {
+		t.Errorf("granted scope reported missing: %+v", checks[0])
+	}
+	if checks[1].OK {
+		t.Errorf("missing scope reported granted: %+v", checks[1])
+	}
+}
+
+func TestCheckRoles(t *testing.T) {
+	const (
+		me    = "11111111-1111-1111-1111-111111111111"
+		other = "22222222-2222-2222-2222-222222222222"
+		group = "urn:globus:groups:id:33333333-3333-3333-3333-333333333333"
+	)
+	roles := []gcs.Role{
+		{ID: "r1", Principal: identityURNPrefix + me, Role: "activity_manager"},
+		{ID: "r2", Principal: identityURNPrefix + other, Role: "administrator"},
+		{ID: "r3", Principal: group, Role: "owner"},
+		{ID: "r4", Collection: "coll-1", Principal: me, Role: "access_manager"},
+	}
+
+	tests := []struct {
+		name       string
+		collection string
+		role       string
+		wantOK     bool
+		wantDetail string
+	}{
+		{"held directly", "", "activity_manager", true, "granted as activity_manager"},
+		{"implied", "", "activity_monitor", true, "granted as activity_manager"},
+		{"held by others", "", "administrator", false, "not granted; granted to " + group},
+		{"collection role", "coll-1", "access_manager", true, "granted as access_manager"},
+		{"endpoint role on collection", "coll-1", "activity_monitor", true, "granted as activity_manager on the endpoint"},
+		{"other collection", "coll-2", "access_manager", false, "not granted; granted to " + group},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := preflightOptions{endpointFQDN: "ep.example.org", collection: tt.collection, roles: []string{tt.role}}
+			checks := checkRoles(roles, []string{me}, opts)
+			if len(checks) != 1 {
+				t.Fatalf("checkRoles() returned %d checks, want 1", len(checks))
+			}
+			if checks[0].OK != tt.wantOK {
+				t.Errorf("OK = %v, want %v", checks[0].OK, tt.wantOK)
+			}
+			if !strings.HasPrefix(checks[0].Detail, tt.wantDetail) {
+				t.Errorf("Detail = %q, want prefix %q", checks[0].Detail, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestRunPreflight_NoToken(t *testing.T) {
+	buf := &bytes.Buffer{}
+	opts := preflightOptions{endpointFQDN: "ep.example.org", roles: []string{"administrator"}, minValidity: defaultMinValidity}
+
+	err := runPreflight(t.Context(), "nonexistent-profile-test", "text", opts, buf)
+	if err == nil || !strings.Contains(err.Error(), "not logged in") {
+		t.Errorf("runPreflight() error = %v, want not logged in", err)
+	}
+	if buf.Len() > 0 {
+		t.Errorf("runPreflight() wrote to buffer on error: %q", buf.String())
+	}
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// newRotateKeyCmd creates the auth rotate-key command.
+func newRotateKeyCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "rotate-key",
+		Short: "Rotate the token encryption key",
+		Long: `Replace the key that encrypts stored tokens with a new one.
+
+A new key is generated and stored in the system keyring, and the token
+files of all profiles are re-encrypted with it. If any file cannot be
+re-encrypted, the files already rewritten are restored and the old key
+stays in use, so an interrupted rotation never leaves tokens unreadable.
+
+Previous keys remain in the keyring, so token files restored from a backup
+can still be read. Rotate the key periodically (e.g., every 90 days) or
+when it may have been exposed.
+
+Example:
+  globus-connect-server auth rotate-key`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runRotateKey(format, auth.RotateEncryptionKey, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+
+	return cmd
+}
+
+// runRotateKey executes the auth rotate-key command. rotate is
+// auth.RotateEncryptionKey outside tests.
+func runRotateKey(formatStr string, rotate func() (*auth.KeyRotation, error), out interface{ Write([]byte) (int, error) }) error {
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	rotation, err := rotate()
+	if err != nil {
+		return fmt.Errorf("rotate encryption key: %w", err)
+	}
+
+	// Output based on format
+	if formatter.IsStructured() {
+		return formatter.PrintData(rotation)
+	}
+
+	// Text format
+	if err := formatter.PrintText("✓ Rotated encryption key from %s to %s\n", rotation.OldVersion, rotation.NewVersion); err != nil {
+		return err
+	}
+	return formatter.PrintText("Re-encrypted %d token file(s)\n", len(rotation.Files))
+}
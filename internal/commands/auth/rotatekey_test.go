@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+)
+
+func TestRunRotateKey(t *testing.T) {
+	rotate := func() (*auth.KeyRotation, error) {
+		return &auth.KeyRotation{OldVersion: "v1", NewVersion: "v2", Files: []string{"a.json", "b.json"}}, nil
+	}
+
+	buf := &bytes.Buffer{}
+	if err := runRotateKey("text", rotate, buf); err != nil {
+		t.Fatalf("runRotateKey() error = %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "from v1 to v2") || !strings.Contains(got, "2 token file(s)") {
+		t.Errorf("runRotateKey() output = %q", got)
+	}
+}
+
+func TestRunRotateKey_Error(t *testing.T) {
+	rotate := func() (*auth.KeyRotation, error) {
+		return nil, errors.New("keyring locked")
+	}
+
+	buf := &bytes.Buffer{}
+	err := runRotateKey("text", rotate, buf)
+	if err == nil || !strings.Contains(err.Error(), "keyring locked") {
+		t.Errorf("runRotateKey() error = %v, want keyring locked", err)
+	}
+	if buf.Len() > 0 {
+		t.Errorf("runRotateKey() wrote to buffer on error: %q", buf.String())
+	}
+}
@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// newStatusCmd creates the auth status command.
+func newStatusCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the stored tokens of all profiles",
+		Long: `Show the stored tokens of all profiles without revealing them.
+
+For each profile (and its reporter token, see 'login --reporter') this
+shows when the token expires, whether it can be refreshed, its scopes and
+resource server, and how the token file is protected: encrypted-v1 (key
+in the system keyring, with the key version), passphrase-v1, or
+plaintext for files from v1.x that have not been loaded since. Token
+files readable by other users are flagged.
+
+Reading passphrase-encrypted files needs the token passphrase; files
+that cannot be decrypted are listed with the reason.
+
+Example:
+  globus-connect-server auth status
+  globus-connect-server auth status --format json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runStatus(format, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+
+	return cmd
+}
+
+// runStatus executes the auth status command.
+func runStatus(formatStr string, out interface{ Write([]byte) (int, error) }) error {
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	statuses, err := auth.InspectTokens()
+	if err != nil {
+		return err
+	}
+
+	// Output based on format
+	if formatter.IsStructured() {
+		return formatter.PrintData(statuses)
+	}
+
+	if formatter.IsTabular() {
+		table := output.NewTable("Profile", "Reporter", "Encryption", "Expires", "Valid", "Refreshable")
+		table.AddWideColumns("Resource Servers", "Scopes", "Permissions", "Error")
+		for _, s := range statuses {
+			expires := ""
+			if !s.ExpiresAt.IsZero() {
+				expires = s.ExpiresAt.Format(time.RFC3339)
+			}
+			table.AddRow(s.Profile, fmt.Sprint(s.Reporter), encryptionLabel(s), expires,
+				fmt.Sprint(s.Valid), fmt.Sprint(s.Refreshable),
+				strings.Join(resourceServers(s), " "), strings.Join(s.Scopes, " "), s.Permissions, s.Error)
+		}
+		return formatter.PrintTable(table)
+	}
+
+	// Text format
+	if len(statuses) == 0 {
+		return formatter.Println("No stored tokens. Use 'login' to log in.")
+	}
+
+	if err := formatter.PrintText("Stored tokens (%d):\n", len(statuses)); err != nil {
+		return err
+	}
+	for _, s := range statuses {
+		if err := printTokenStatus(formatter, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encryptionLabel describes how a token file is protected.
+func encryptionLabel(s auth.TokenStatus) string {
+	if s.KeyVersion != "" {
+		return fmt.Sprintf("%s (key %s)", s.Encryption, s.KeyVersion)
+	}
+	return s.Encryption
+}
+
+// printTokenStatus prints the status of one token as text.
+func printTokenStatus(formatter *output.Formatter, s auth.TokenStatus) error {
+	name := s.Profile
+	if s.Reporter {
+		name += " (reporter)"
+	}
+	if err := formatter.PrintText("\n  %s\n", formatter.Heading(name)); err != nil {
+		return err
+	}
+
+	encryption := encryptionLabel(s)
+	if s.Encryption == auth.PlaintextTokenFormat {
+		encryption = formatter.Warning(encryption + " (encrypted when next used)")
+	}
+	if err := formatter.PrintText("    Encryption:      %s\n", encryption); err != nil {
+		return err
+	}
+
+	permissions := s.Permissions
+	if permissions != "0600" {
+		permissions = formatter.Warning(permissions + " (readable by other users; should be 0600)")
+	}
+	if err := formatter.PrintText("    Permissions:     %s\n", permissions); err != nil {
+		return err
+	}
+
+	if s.Error != "" {
+		return formatter.PrintText("    Error:           %s\n", formatter.Failure(s.Error))
+	}
+
+	expires := fmt.Sprintf("%s (in %s)", s.ExpiresAt.Local().Format(time.RFC3339), cli.Duration(time.Until(s.ExpiresAt)))
+	if time.Until(s.ExpiresAt) <= 0 {
+		expires = formatter.Failure(s.ExpiresAt.Local().Format(time.RFC3339) + " (expired)")
+	}
+	if err := formatter.PrintText("    Expires:         %s\n", expires); err != nil {
+		return err
+	}
+
+	refreshable := "no"
+	if s.Refreshable {
+		refreshable = "yes"
+	}
+	if err := formatter.PrintText("    Refreshable:     %s\n", refreshable); err != nil {
+		return err
+	}
+	if servers := resourceServers(s); len(servers) > 0 {
+		if err := formatter.PrintText("    Resource server: %s\n", strings.Join(servers, ", ")); err != nil {
+			return err
+		}
+	}
+	if len(s.Endpoints) > 0 {
+		endpoints := make([]string, 0, len(s.Endpoints))
+		for _, fqdn := range slices.Sorted(maps.Keys(s.Endpoints)) {
+			endpoints = append(endpoints, fmt.Sprintf("%s (%s)", fqdn, s.Endpoints[fqdn]))
+		}
+		if err := formatter.PrintText("    Endpoints:       %s\n", strings.Join(endpoints, ", ")); err != nil {
+			return err
+		}
+	}
+	if len(s.Scopes) > 0 {
+		if err := formatter.PrintText("    Scopes:          %s\n", strings.Join(s.Scopes, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourceServers returns the resource servers s holds tokens for,
+// starting with that of the primary token.
+func resourceServers(s auth.TokenStatus) []string {
+	var servers []string
+	if s.ResourceServer != "" {
+		servers = append(servers, s.ResourceServer)
+	}
+	return append(servers, s.OtherResourceServers...)
+}
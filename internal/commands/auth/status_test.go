@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+)
+
+func TestRunStatus(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR", dir)
+
+	buf := &bytes.Buffer{}
+	if err := runStatus("text", buf); err != nil {
+		t.Fatalf("runStatus() error = %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "No stored tokens") {
+		t.Errorf("runStatus() output = %q, want no stored tokens", got)
+	}
+
+	// A v1.x plaintext token file, which needs no keyring to inspect
+	if err := os.MkdirAll(filepath.Join(dir, "tokens"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(&auth.TokenInfo{
+		AccessToken:  "secret-access-token",
+		RefreshToken: "secret-refresh-token",
+		ExpiresAt:    time.Now().Add(time.Hour),
+		Scopes:       []string{"openid email"},
+	})
+	if err := os.WriteFile(filepath.Join(dir, "tokens", "prod.json"), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	buf.Reset()
+	if err := runStatus("text", buf); err != nil {
+		t.Fatalf("runStatus() error = %v", err)
+	}
+	got := buf.String()
+	for _, want := range []string{"Stored tokens (1)", "prod", "plaintext", "Refreshable:     yes", "openid email"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("runStatus() output missing %q:\n%s", want, got)
+		}
+	}
+
+	buf.Reset()
+	if err := runStatus("json", buf); err != nil {
+		t.Fatalf("runStatus() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "secret-") {
+		t.Errorf("runStatus() revealed a token:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,237 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/secureinput"
+	"github.com/spf13/cobra"
+)
+
+// NewAuthCmd creates the auth command.
+func NewAuthCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Manage stored authentication tokens",
+		Long: `Commands for the authentication tokens stored by 'login'.
+
+Use 'login', 'logout', and 'whoami' to start, end, and inspect a session.
+Stored tokens are encrypted with a key kept in the system keyring; 'auth
+rotate-key' replaces that key, and 'auth status' shows the tokens of all
+profiles and how each is protected.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(newStatusCmd())
+	cmd.AddCommand(newTokenCmd())
+	cmd.AddCommand(newRotateKeyCmd())
+	cmd.AddCommand(newKeyringTrustCmd())
+
+	return cmd
+}
+
+// newTokenCmd creates the auth token command.
+func newTokenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Move tokens between machines",
+		Long: `Export the tokens of a profile and import them elsewhere.
+
+Stored tokens are encrypted with a key kept in the system keyring, so a
+token file copied to another machine cannot be read there. 'auth token
+export' re-encrypts the tokens with a passphrase instead, and 'auth token
+import' stores them on the new machine, for example in a container that
+cannot run the browser login flow.`,
+	}
+
+	cmd.AddCommand(newTokenExportCmd())
+	cmd.AddCommand(newTokenImportCmd())
+
+	return cmd
+}
+
+// newTokenExportCmd creates the auth token export command.
+func newTokenExportCmd() *cobra.Command {
+	var (
+		profile           string
+		outputFile        string
+		passphraseEnv     string
+		insecurePlaintext bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the tokens of a profile",
+		Long: `Export the tokens of a profile as a passphrase-encrypted bundle.
+
+The passphrase is prompted for, or read from the environment variable
+named by --passphrase-env. The key is derived from it with PBKDF2-SHA256;
+choose a strong passphrase, as the bundle grants the same access as the
+tokens themselves.
+
+With --insecure-plaintext the tokens are written as plain JSON instead,
+e.g. to inject them into a container's secret store. Anyone who can read
+the output can act as you until the tokens expire.
+
+The bundle is written to standard output unless --output is given, in
+which case the file is created with owner-only permissions.
+
+Example:
+  globus-connect-server auth token export --output tokens.json
+  globus-connect-server auth token import tokens.json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTokenExport(profile, outputFile, passphraseEnv, insecurePlaintext, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write the bundle to this file instead of standard output")
+	cmd.Flags().StringVar(&passphraseEnv, "passphrase-env", "", "Read the passphrase from this environment variable")
+	cmd.Flags().BoolVar(&insecurePlaintext, "insecure-plaintext", false, "Export the tokens unencrypted")
+	cmd.MarkFlagsMutuallyExclusive("passphrase-env", "insecure-plaintext")
+
+	return cmd
+}
+
+// newTokenImportCmd creates the auth token import command.
+func newTokenImportCmd() *cobra.Command {
+	var (
+		profile       string
+		passphraseEnv string
+		force         bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import [FILE|-]",
+		Short: "Import tokens exported from another machine",
+		Long: `Store tokens written by 'auth token export' for a profile.
+
+FILE may be a passphrase-encrypted bundle, plain token JSON exported with
+--insecure-plaintext, or a token file of this machine. Without FILE, or
+with "-", the tokens are read from standard input; in that case an
+encrypted bundle needs --passphrase-env, since standard input cannot also
+be used to prompt for the passphrase.
+
+An existing session of the profile is only replaced with --force.
+
+Example:
+  globus-connect-server auth token import tokens.json
+  GCS_PASSPHRASE=... globus-connect-server auth token import --passphrase-env GCS_PASSPHRASE - < tokens.json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file := "-"
+			if len(args) > 0 {
+				file = args[0]
+			}
+			return runTokenImport(profile, file, passphraseEnv, force, cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVar(&passphraseEnv, "passphrase-env", "", "Read the passphrase from this environment variable")
+	cmd.Flags().BoolVar(&force, "force", false, "Replace an existing session of the profile")
+
+	return cmd
+}
+
+// runTokenExport executes the auth token export command.
+func runTokenExport(profile, outputFile, passphraseEnv string, insecurePlaintext bool, out io.Writer) error {
+	token, err := auth.LoadToken(profile)
+	if err != nil {
+		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+
+	var bundle any = token
+	if !insecurePlaintext {
+		passphrase, err := secureinput.ReadSecret(secureinput.ReadSecretOptions{
+			PromptMessage: "Passphrase for the exported tokens",
+			EnvVar:        passphraseEnv,
+		})
+		if err != nil {
+			return fmt.Errorf("read passphrase: %w", err)
+		}
+		if passphraseEnv == "" {
+			confirm, err := secureinput.ReadSecret(secureinput.ReadSecretOptions{
+				PromptMessage: "Repeat passphrase",
+			})
+			if err != nil {
+				return fmt.Errorf("read passphrase: %w", err)
+			}
+			if confirm != passphrase {
+				return errors.New("passphrases do not match")
+			}
+		}
+
+		if bundle, err = auth.ExportToken(token, passphrase); err != nil {
+			return fmt.Errorf("export token: %w", err)
+		}
+	}
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal token: %w", err)
+	}
+	data = append(data, '\n')
+
+	if outputFile == "" {
+		_, err = out.Write(data)
+		return err
+	}
+	if err := os.WriteFile(outputFile, data, 0600); err != nil {
+		return fmt.Errorf("write %s: %w", outputFile, err)
+	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(outputFile, 0600); err != nil {
+		return fmt.Errorf("write %s: %w", outputFile, err)
+	}
+	_, err = fmt.Fprintf(out, "✓ Exported tokens of profile %s to %s\n", profile, outputFile)
+	return err
+}
+
+// runTokenImport executes the auth token import command.
+func runTokenImport(profile, file, passphraseEnv string, force bool, in io.Reader, out io.Writer) error {
+	if !force {
+		if _, err := auth.LoadToken(profile); err == nil {
+			return fmt.Errorf("profile %q already has a session (use --force to replace it)", profile)
+		}
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if file == "-" {
+		data, err = io.ReadAll(in)
+	} else {
+		data, err = os.ReadFile(file) //nolint:gosec // User-specified token file
+	}
+	if err != nil {
+		return fmt.Errorf("read tokens: %w", err)
+	}
+
+	passphrase := func() (string, error) {
+		if passphraseEnv == "" && file == "-" {
+			return "", errors.New("the tokens are encrypted; use --passphrase-env when reading them from standard input")
+		}
+		return secureinput.ReadSecret(secureinput.ReadSecretOptions{
+			PromptMessage: "Passphrase for the imported tokens",
+			EnvVar:        passphraseEnv,
+		})
+	}
+
+	token, err := auth.ImportToken(data, passphrase)
+	if err != nil {
+		return fmt.Errorf("import token: %w", err)
+	}
+	if err := auth.SaveToken(profile, token); err != nil {
+		return fmt.Errorf("save token: %w", err)
+	}
+
+	_, err = fmt.Fprintf(out, "✓ Imported tokens into profile: %s\n", profile)
+	return err
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNewAuthCmd(t *testing.T) {
+	cmd := NewAuthCmd()
+
+	if cmd.Use != "auth" {
+		t.Errorf("NewAuthCmd() Use = %q, want %q", cmd.Use, "auth")
+	}
+
+	for _, path := range [][]string{{"token", "export"}, {"token", "import"}} {
+		sub, _, err := cmd.Find(path)
+		if err != nil || sub.Name() != path[1] {
+			t.Errorf("auth %s not found", strings.Join(path, " "))
+			continue
+		}
+		if sub.Short == "" || sub.RunE == nil {
+			t.Errorf("auth %s is incomplete", strings.Join(path, " "))
+		}
+	}
+}
+
+func TestTokenExportCmd_Flags(t *testing.T) {
+	cmd := newTokenExportCmd()
+
+	for _, name := range []string{"profile", "output", "passphrase-env", "insecure-plaintext"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("%s flag not found", name)
+		}
+	}
+}
+
+func TestRunTokenExport_NotLoggedIn(t *testing.T) {
+	var buf bytes.Buffer
+	err := runTokenExport("nonexistent-profile-test", "", "", true, &buf)
+	if err == nil || !strings.Contains(err.Error(), "not logged in") {
+		t.Errorf("runTokenExport() error = %v, want not logged in", err)
+	}
+}
+
+func TestRunTokenImport_Invalid(t *testing.T) {
+	var buf bytes.Buffer
+	err := runTokenImport("nonexistent-profile-test", "-", "", false, strings.NewReader(`{"refresh_token":"r"}`), &buf)
+	if err == nil || !strings.Contains(err.Error(), "no access_token") {
+		t.Errorf("runTokenImport() error = %v, want no access_token", err)
+	}
+}
+
+func TestRunTokenImport_StdinNeedsPassphraseEnv(t *testing.T) {
+	var buf bytes.Buffer
+	bundle := `{"format":"passphrase-v1","encrypted_data":{"Version":"v1","Nonce":"AAAAAAAAAAAAAAAA","Ciphertext":"AA=="},"salt":"AAAA","iterations":1}`
+	err := runTokenImport("nonexistent-profile-test", "-", "", false, strings.NewReader(bundle), &buf)
+	if err == nil || !strings.Contains(err.Error(), "--passphrase-env") {
+		t.Errorf("runTokenImport() error = %v, want --passphrase-env hint", err)
+	}
+}
@@ -3,20 +3,23 @@ package auth
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
-	globusauth "github.com/scttfrdmn/globus-go-sdk/v3/pkg/services/auth"
 	"github.com/spf13/cobra"
 )
 
 // NewWhoamiCmd creates the whoami command.
 func NewWhoamiCmd() *cobra.Command {
 	var (
-		profile string
-		format  string
+		profile   string
+		format    string
+		preflight preflightOptions
 	)
 
 	cmd := &cobra.Command{
@@ -25,16 +28,48 @@ func NewWhoamiCmd() *cobra.Command {
 		Long: `Show information about the current authenticated identity.
 
 This command displays your Globus identity information including
-username, email, and organization.
+username, email, and organization, and the identities linked to it in
+Globus Auth (your identity set) with their identity providers.
 
-Requires an active authentication session (use 'login' first).`,
+The effective identity is the one GCS sees for requests made with this
+session. Use its URN as --principal when granting roles or permissions to
+yourself.
+
+Requires an active authentication session (use 'login' first).
+
+Preflight checks:
+  With --check-roles, --scope, or --min-validity, whoami instead checks
+  that the session can carry out a pipeline: that the token stays valid
+  for --min-validity (default 15m), was granted each --scope, and that
+  your identity holds each --check-roles role on --endpoint (or on
+  --collection). A role counts if it is held directly or through a role
+  that includes it, such as administrator for activity_manager; roles
+  granted to groups are listed but not counted.
+
+  Every requirement is checked, and the command fails with the list of
+  those that are not met, so a CI job can stop before it starts deploying.
+
+Example:
+  globus-connect-server whoami --endpoint abc.def.data.globus.org \
+    --check-roles administrator --min-validity 1h`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			if len(preflight.roles) > 0 || len(preflight.scopes) > 0 || cmd.Flags().Changed("min-validity") {
+				if len(preflight.roles) > 0 && preflight.endpointFQDN == "" {
+					return fmt.Errorf("--check-roles requires --endpoint")
+				}
+				return runPreflight(cmd.Context(), profile, format, preflight, cmd.OutOrStdout())
+			}
 			return runWhoami(cmd.Context(), profile, format, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().StringVar(&preflight.endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	cmd.Flags().StringVar(&preflight.collection, "collection", "", "Check roles on this collection ID instead of the endpoint")
+	cmd.Flags().StringSliceVar(&preflight.roles, "check-roles", nil, "Fail unless you hold these roles (comma-separated)")
+	cmd.Flags().StringSliceVar(&preflight.scopes, "scope", nil, "Fail unless the token was granted this scope (repeatable)")
+	cmd.Flags().DurationVar(&preflight.minValidity, "min-validity", defaultMinValidity, "Fail unless the token stays valid this long")
 
 	return cmd
 }
@@ -53,20 +88,12 @@ func runWhoami(ctx context.Context, profile, formatStr string, out interface{ Wr
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Create auth client (for potential future API calls)
-	cfg, err := config.LoadClientConfig()
-	if err != nil {
-		return fmt.Errorf("load config: %w", err)
-	}
-
-	authClient, err := globusauth.NewClient(
-		globusauth.WithClientID(cfg.ClientID),
-		globusauth.WithClientSecret(cfg.ClientSecret),
-	)
+	authClient, err := cli.NewAuthClient()
 	if err != nil {
-		return fmt.Errorf("create auth client: %w", err)
+		return err
 	}
 
 	// Introspect token to get user info
@@ -79,21 +106,35 @@ func runWhoami(ctx context.Context, profile, formatStr string, out interface{ Wr
 		return fmt.Errorf("token is not active, please login again")
 	}
 
+	effective := identityURNPrefix + introspectResp.Subject
+
+	// Linked identities are informational; show what could be looked up
+	env, err := cli.GlobusEnvironment()
+	if err != nil {
+		return err
+	}
+	identities, err := linkedIdentities(ctx, env.AuthURL, token.AccessToken, introspectResp.Subject, introspectResp.IdentitySet)
+	if err != nil {
+		cli.Warnf("could not look up linked identities: %v", err)
+	}
+
 	// Prepare output data
 	info := map[string]interface{}{
-		"username":        introspectResp.Username,
-		"email":           introspectResp.Email,
-		"name":            introspectResp.Name,
-		"sub":             introspectResp.Subject,
-		"profile":         profile,
-		"expires_at":      token.ExpiresAt.Format(time.RFC3339),
-		"scopes":          token.Scopes,
-		"resource_server": token.ResourceServer,
+		"username":           introspectResp.Username,
+		"email":              introspectResp.Email,
+		"name":               introspectResp.Name,
+		"sub":                introspectResp.Subject,
+		"effective_identity": effective,
+		"identities":         identities,
+		"profile":            profile,
+		"expires_at":         token.ExpiresAt.Format(time.RFC3339),
+		"scopes":             token.Scopes,
+		"resource_server":    token.ResourceServer,
 	}
 
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(info)
+	if formatter.IsStructured() {
+		return formatter.PrintData(info)
 	}
 
 	// Text format
@@ -105,34 +146,147 @@ func runWhoami(ctx context.Context, profile, formatStr string, out interface{ Wr
 	}
 
 	if introspectResp.Name != "" {
-		if err := formatter.PrintText("Name:     %s\n", introspectResp.Name); err != nil {
+		if err := formatter.PrintText("Name:      %s\n", introspectResp.Name); err != nil {
 			return err
 		}
 	}
 
 	if introspectResp.Username != "" {
-		if err := formatter.PrintText("Username: %s\n", introspectResp.Username); err != nil {
+		if err := formatter.PrintText("Username:  %s\n", introspectResp.Username); err != nil {
 			return err
 		}
 	}
 
 	if introspectResp.Email != "" {
-		if err := formatter.PrintText("Email:    %s\n", introspectResp.Email); err != nil {
+		if err := formatter.PrintText("Email:     %s\n", introspectResp.Email); err != nil {
 			return err
 		}
 	}
 
-	if err := formatter.PrintText("ID:       %s\n", introspectResp.Subject); err != nil {
+	if err := formatter.PrintText("ID:        %s\n", introspectResp.Subject); err != nil {
+		return err
+	}
+
+	if err := formatter.PrintText("Effective: %s\n", effective); err != nil {
+		return err
+	}
+
+	if err := formatter.PrintText("Profile:   %s\n", profile); err != nil {
 		return err
 	}
 
-	if err := formatter.PrintText("Profile:  %s\n", profile); err != nil {
+	if err := formatter.PrintText("Expires:   %s (in %s)\n", token.ExpiresAt.Format(time.RFC3339), cli.Duration(time.Until(token.ExpiresAt))); err != nil {
 		return err
 	}
 
-	if err := formatter.PrintText("Expires:  %s\n", token.ExpiresAt.Format(time.RFC3339)); err != nil {
+	if len(identities) == 0 {
+		return nil
+	}
+	if err := formatter.Println(); err != nil {
+		return err
+	}
+	if err := formatter.Println("Linked Identities:"); err != nil {
 		return err
 	}
+	for _, identity := range identities {
+		if err := printLinkedIdentity(formatter, identity); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
+
+// LinkedIdentity is an identity in the user's Globus Auth identity set.
+type LinkedIdentity struct {
+	ID               string `json:"id"`
+	Username         string `json:"username,omitempty"`
+	Name             string `json:"name,omitempty"`
+	Email            string `json:"email,omitempty"`
+	Organization     string `json:"organization,omitempty"`
+	IdentityProvider string `json:"identity_provider,omitempty"`
+	Status           string `json:"status,omitempty"`
+
+	// Primary marks the identity the session authenticated as.
+	Primary bool `json:"primary"`
+}
+
+// linkedIdentities describes the identities of the identity set, subject
+// first, with the Globus Auth API at authURL. IDs that cannot be looked up are returned with only their ID, so
+// the result is usable even if err is not nil.
+func linkedIdentities(ctx context.Context, authURL, accessToken, subject string, identitySet []string) ([]LinkedIdentity, error) {
+	ids := []string{subject}
+	for _, id := range identitySet {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	linked := make([]LinkedIdentity, len(ids))
+	for i, id := range ids {
+		linked[i] = LinkedIdentity{ID: id, Primary: id == subject}
+	}
+
+	found, err := auth.GetIdentities(ctx, authURL, accessToken, ids...)
+	if err != nil {
+		return linked, err
+	}
+
+	byID := map[string]auth.Identity{}
+	var providerIDs []string
+	for _, identity := range found {
+		byID[identity.ID] = identity
+		if identity.IdentityProvider != "" && !slices.Contains(providerIDs, identity.IdentityProvider) {
+			providerIDs = append(providerIDs, identity.IdentityProvider)
+		}
+	}
+
+	// Provider names are a nicety; fall back to their IDs
+	providerNames := map[string]string{}
+	providers, providerErr := auth.GetIdentityProviders(ctx, authURL, accessToken, providerIDs...)
+	for _, provider := range providers {
+		providerNames[provider.ID] = provider.Name
+	}
+
+	for i := range linked {
+		identity, ok := byID[linked[i].ID]
+		if !ok {
+			continue
+		}
+		linked[i].Username = identity.Username
+		linked[i].Name = identity.Name
+		linked[i].Email = identity.Email
+		linked[i].Organization = identity.Organization
+		linked[i].Status = identity.Status
+		linked[i].IdentityProvider = identity.IdentityProvider
+		if name, ok := providerNames[identity.IdentityProvider]; ok {
+			linked[i].IdentityProvider = name
+		}
+	}
+	return linked, providerErr
+}
+
+// printLinkedIdentity prints one linked identity as a line of text.
+func printLinkedIdentity(formatter *output.Formatter, identity LinkedIdentity) error {
+	label := identity.Username
+	if label == "" {
+		label = identity.ID
+	}
+	var details []string
+	if identity.IdentityProvider != "" {
+		details = append(details, identity.IdentityProvider)
+	}
+	if identity.Organization != "" {
+		details = append(details, identity.Organization)
+	}
+	if identity.Primary {
+		details = append(details, "primary")
+	}
+	line := "  " + label
+	if len(details) > 0 {
+		line += " (" + strings.Join(details, ", ") + ")"
+	}
+	if err := formatter.Println(line); err != nil {
+		return err
+	}
+	return formatter.PrintText("    %s%s\n", identityURNPrefix, identity.ID)
+}
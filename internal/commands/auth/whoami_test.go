@@ -3,9 +3,12 @@ package auth
 import (
 	"bytes"
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 )
 
 func TestNewWhoamiCmd(t *testing.T) {
@@ -53,6 +56,16 @@ func TestNewWhoamiCmd_Flags(t *testing.T) {
 			shorthand:    "f",
 			defaultValue: "text",
 		},
+		{
+			name:         "check-roles flag",
+			flagName:     "check-roles",
+			defaultValue: "[]",
+		},
+		{
+			name:         "min-validity flag",
+			flagName:     "min-validity",
+			defaultValue: "15m0s",
+		},
 	}
 
 	for _, tt := range tests {
@@ -88,3 +101,52 @@ func TestRunWhoami_NoToken(t *testing.T) {
 		t.Errorf("runWhoami() wrote to buffer on error: %q", buf.String())
 	}
 }
+
+func TestLinkedIdentities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/identities":
+			if got := r.URL.Query().Get("ids"); got != "id-1,id-2,id-3" {
+				t.Errorf("ids = %q, want subject first without duplicates", got)
+			}
+			_, _ = w.Write([]byte(`{"identities": [
+				{"id": "id-2", "username": "alice@uni.edu", "organization": "University", "identity_provider": "idp-uni"},
+				{"id": "id-1", "username": "alice@globusid.org", "identity_provider": "idp-globus"}]}`))
+		case "/api/identity_providers":
+			_, _ = w.Write([]byte(`{"identity_providers": [{"id": "idp-globus", "name": "Globus ID"}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	}))
+	defer server.Close()
+
+	linked, err := linkedIdentities(context.Background(), server.URL+"/", "token", "id-1", []string{"id-1", "id-2", "id-3"})
+	if err != nil {
+		t.Fatalf("linkedIdentities() error = %v", err)
+	}
+
+	want := []LinkedIdentity{
+		{ID: "id-1", Username: "alice@globusid.org", IdentityProvider: "Globus ID", Primary: true},
+		{ID: "id-2", Username: "alice@uni.edu", Organization: "University", IdentityProvider: "idp-uni"},
+		{ID: "id-3"},
+	}
+	if !reflect.DeepEqual(linked, want) {
+		t.Errorf("linkedIdentities() = %+v, want %+v", linked, want)
+	}
+}
+
+func TestLinkedIdentities_LookupFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	linked, err := linkedIdentities(context.Background(), server.URL+"/", "token", "id-1", nil)
+	if err == nil {
+		t.Error("linkedIdentities() expected error for HTTP 403")
+	}
+	if len(linked) != 1 || linked[0].ID != "id-1" || !linked[0].Primary {
+		t.Errorf("linkedIdentities() = %+v, want the subject by ID", linked)
+	}
+}
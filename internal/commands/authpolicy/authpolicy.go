@@ -20,6 +20,7 @@ and domain-based access controls.`,
 	cmd.AddCommand(NewShowCmd())
 	cmd.AddCommand(NewCreateCmd())
 	cmd.AddCommand(NewUpdateCmd())
+	cmd.AddCommand(NewEditCmd())
 	cmd.AddCommand(NewDeleteCmd())
 
 	return cmd
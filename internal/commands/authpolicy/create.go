@@ -5,8 +5,8 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -17,6 +17,7 @@ func NewCreateCmd() *cobra.Command {
 	var (
 		profile              string
 		format               string
+		quiet                bool
 		endpointFQDN         string
 		name                 string
 		description          string
@@ -45,12 +46,13 @@ Example:
 Requires an active authentication session (use 'login' first).`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return runCreate(cmd.Context(), profile, format, endpointFQDN, name, description,
-				requireMFA, requireHighAssurance, allowedDomains, blockedDomains, cmd.OutOrStdout())
+				requireMFA, requireHighAssurance, allowedDomains, blockedDomains, quiet, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Only print IDs, one per line")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 	cmd.Flags().StringVar(&name, "name", "", "Policy name")
 	cmd.Flags().StringVar(&description, "description", "", "Policy description")
@@ -68,10 +70,14 @@ Requires an active authentication session (use 'login' first).`,
 // runCreate executes the auth-policy create command.
 func runCreate(ctx context.Context, profile, formatStr, endpointFQDN, name, description string,
 	requireMFA, requireHighAssurance bool, allowedDomains, blockedDomains string,
-	out interface{ Write([]byte) (int, error) }) error {
+	quiet bool, out interface{ Write([]byte) (int, error) }) error {
+
+	if quiet && output.Format(formatStr) != output.FormatText {
+		return fmt.Errorf("--quiet cannot be combined with --format %s", formatStr)
+	}
 
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -82,13 +88,10 @@ func runCreate(ctx context.Context, profile, formatStr, endpointFQDN, name, desc
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions(output.WithQuiet(quiet))...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -124,9 +127,13 @@ func runCreate(ctx context.Context, profile, formatStr, endpointFQDN, name, desc
 		return fmt.Errorf("create auth policy: %w", err)
 	}
 
+	if formatter.IsQuiet() {
+		return formatter.PrintIDs(created.ID)
+	}
+
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(created)
+	if formatter.IsStructured() {
+		return formatter.PrintData(created)
 	}
 
 	// Text format
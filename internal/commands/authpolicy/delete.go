@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/trash"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -18,6 +18,7 @@ func NewDeleteCmd() *cobra.Command {
 		format       string
 		endpointFQDN string
 		force        bool
+		noTrash      bool
 	)
 
 	cmd := &cobra.Command{
@@ -25,8 +26,10 @@ func NewDeleteCmd() *cobra.Command {
 		Short: "Delete an authentication policy",
 		Long: `Delete an authentication policy from the endpoint.
 
-WARNING: This action cannot be undone. Deleting a policy may affect
-access controls for users relying on this policy.
+WARNING: Deleting a policy may affect access controls for users relying
+on this policy. A copy of the policy is kept in the local trash for 30
+days and can be re-created with 'trash restore', unless --no-trash is
+given.
 
 Example:
   globus-connect-server auth-policy delete abc123 \
@@ -39,14 +42,15 @@ Requires an active authentication session (use 'login' first).`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			policyID := args[0]
-			return runDelete(cmd.Context(), profile, format, endpointFQDN, policyID, force, cmd.OutOrStdout())
+			return runDelete(cmd.Context(), profile, format, endpointFQDN, policyID, force, noTrash, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&noTrash, "no-trash", false, "Do not keep a copy in the local trash")
 
 	_ = cmd.MarkFlagRequired("endpoint")
 
@@ -54,9 +58,9 @@ Requires an active authentication session (use 'login' first).`,
 }
 
 // runDelete executes the auth-policy delete command.
-func runDelete(ctx context.Context, profile, formatStr, endpointFQDN, policyID string, force bool, out interface{ Write([]byte) (int, error) }) error {
+func runDelete(ctx context.Context, profile, formatStr, endpointFQDN, policyID string, force, noTrash bool, out interface{ Write([]byte) (int, error) }) error {
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -67,7 +71,7 @@ func runDelete(ctx context.Context, profile, formatStr, endpointFQDN, policyID s
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Confirmation prompt (unless --force)
 	if !force {
@@ -87,33 +91,51 @@ func runDelete(ctx context.Context, profile, formatStr, endpointFQDN, policyID s
 	}
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
 
+	// Keep a copy for 'trash restore'
+	var trashed *trash.Item
+	if !noTrash {
+		policy, err := gcsClient.GetAuthPolicy(ctx, policyID)
+		if err != nil {
+			return fmt.Errorf("get auth policy: %w", err)
+		}
+		if trashed, err = trash.Save(trash.KindAuthPolicy, policyID, endpointFQDN, policy); err != nil {
+			return fmt.Errorf("%w (use --no-trash to delete without a copy)", err)
+		}
+	}
+
 	// Delete policy
 	if err := gcsClient.DeleteAuthPolicy(ctx, policyID); err != nil {
+		if trashed != nil {
+			_ = trash.Discard(trashed.ID)
+		}
 		return fmt.Errorf("delete auth policy: %w", err)
 	}
 
 	// Output based on format
-	if formatter.IsJSON() {
+	if formatter.IsStructured() {
 		result := map[string]string{
 			"status":    "success",
 			"policy_id": policyID,
 			"message":   "Authentication policy deleted successfully",
 		}
-		return formatter.PrintJSON(result)
+		if trashed != nil {
+			result["trash_id"] = trashed.ID
+		}
+		return formatter.PrintData(result)
 	}
 
 	// Text format
 	if err := formatter.PrintText("Authentication policy %s deleted successfully.\n", policyID); err != nil {
 		return err
 	}
+	if trashed != nil {
+		return formatter.PrintText("Restore it with 'trash restore %s' within 30 days.\n", trashed.ID)
+	}
 
 	return nil
 }
@@ -0,0 +1,80 @@
+package authpolicy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// NewEditCmd creates the auth-policy edit command.
+func NewEditCmd() *cobra.Command {
+	var (
+		profile      string
+		format       string
+		endpointFQDN string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "edit POLICY_ID",
+		Short: "Edit an authentication policy in your editor",
+		Long: `Edit an authentication policy as a YAML document in your editor.
+
+The auth policy is opened as YAML in $VISUAL or $EDITOR (default vi). When
+the editor exits, only the fields that were changed are sent, including
+fields set to false or removed, which 'update' cannot express. Saving an
+empty file cancels the edit.
+
+Example:
+  globus-connect-server auth-policy edit abc123 --endpoint example.data.globus.org
+
+Requires an active authentication session (use 'login' first).`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEdit(cmd.Context(), profile, format, endpointFQDN, args[0], cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+
+	_ = cmd.MarkFlagRequired("endpoint")
+
+	return cmd
+}
+
+// runEdit executes the auth-policy edit command.
+func runEdit(ctx context.Context, profile, formatStr, endpointFQDN, policyID string, out interface{ Write([]byte) (int, error) }) error {
+	// Load token
+	token, err := cli.LoadToken(profile)
+	if err != nil {
+		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+
+	// Check if token is valid
+	if !token.IsValid() {
+		return fmt.Errorf("token expired, please login again")
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	// Create GCS client
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("create GCS client: %w", err)
+	}
+
+	return cli.EditResource(ctx, "auth policy "+policyID,
+		func(ctx context.Context) (map[string]interface{}, error) {
+			return gcsClient.GetAuthPolicyDocument(ctx, policyID)
+		},
+		func(ctx context.Context, fields map[string]interface{}) error {
+			return gcsClient.PatchAuthPolicy(ctx, policyID, fields)
+		},
+		formatter)
+}
@@ -5,9 +5,8 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -41,7 +40,7 @@ Requires an active authentication session (use 'login' first).`,
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 
 	_ = cmd.MarkFlagRequired("endpoint")
@@ -52,7 +51,7 @@ Requires an active authentication session (use 'login' first).`,
 // runShow executes the auth-policy show command.
 func runShow(ctx context.Context, profile, formatStr, endpointFQDN, policyID string, out interface{ Write([]byte) (int, error) }) error {
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -63,13 +62,10 @@ func runShow(ctx context.Context, profile, formatStr, endpointFQDN, policyID str
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -81,8 +77,8 @@ func runShow(ctx context.Context, profile, formatStr, endpointFQDN, policyID str
 	}
 
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(policy)
+	if formatter.IsStructured() {
+		return formatter.PrintData(policy)
 	}
 
 	// Text format
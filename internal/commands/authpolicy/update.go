@@ -5,8 +5,8 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -49,12 +49,12 @@ Requires an active authentication session (use 'login' first).`,
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 	cmd.Flags().StringVar(&name, "name", "", "Policy name")
 	cmd.Flags().StringVar(&description, "description", "", "Policy description")
-	cmd.Flags().StringVar(&requireMFA, "require-mfa", "", "Require multi-factor authentication (true/false)")
-	cmd.Flags().StringVar(&requireHighAssurance, "require-high-assurance", "", "Require high assurance authentication (true/false)")
+	cli.EnumVar(cmd, &requireMFA, "require-mfa", "", "Require multi-factor authentication (true/false)", cli.BoolChoices...)
+	cli.EnumVar(cmd, &requireHighAssurance, "require-high-assurance", "", "Require high assurance authentication (true/false)", cli.BoolChoices...)
 	cmd.Flags().StringVar(&allowedDomains, "allowed-domains", "", "Comma-separated list of allowed domains")
 	cmd.Flags().StringVar(&blockedDomains, "blocked-domains", "", "Comma-separated list of blocked domains")
 
@@ -69,7 +69,7 @@ func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN, policyID,
 	out interface{ Write([]byte) (int, error) }) error {
 
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -80,13 +80,10 @@ func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN, policyID,
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -135,8 +132,8 @@ func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN, policyID,
 	}
 
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(updated)
+	if formatter.IsStructured() {
+		return formatter.PrintData(updated)
 	}
 
 	// Text format
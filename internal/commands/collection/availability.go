@@ -0,0 +1,292 @@
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// availabilityStateFile records the state replaced by 'collection disable'
+// so that 'collection enable' can restore it.
+const availabilityStateFile = "disabled-collections.json"
+
+// NewDisableCmd creates the collection disable command.
+func NewDisableCmd() *cobra.Command {
+	var (
+		profile      string
+		format       string
+		endpointFQDN string
+		message      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "disable COLLECTION_ID",
+		Short: "Take a collection offline for maintenance",
+		Long: `Take a collection offline for maintenance without deleting it.
+
+If the endpoint supports a collection availability flag, it is used, and
+--message replaces the user message. Otherwise the collection is made
+non-public and its user message is replaced with the maintenance message.
+The maintenance message is marked with "[DISABLED]", and the previous
+visibility and message are saved locally so that 'collection enable' can
+restore them.
+
+Example:
+  globus-connect-server collection disable abc123 \
+    --endpoint example.data.globus.org \
+    --message "Offline for storage migration until Monday"
+
+Requires an active authentication session (use 'login' first).`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDisable(cmd.Context(), profile, format, endpointFQDN, args[0], message, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	cmd.Flags().StringVar(&message, "message", "", "Message shown to users while the collection is disabled")
+
+	_ = cmd.MarkFlagRequired("endpoint")
+
+	return cmd
+}
+
+// NewEnableCmd creates the collection enable command.
+func NewEnableCmd() *cobra.Command {
+	var (
+		profile      string
+		format       string
+		endpointFQDN string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "enable COLLECTION_ID",
+		Short: "Bring a disabled collection back online",
+		Long: `Bring a collection disabled with 'collection disable' back online.
+
+The collection's previous visibility and user message are restored if they
+were saved on this host. Otherwise the maintenance message is cleared and
+visibility is left unchanged (use 'collection update --public' if needed).
+
+Example:
+  globus-connect-server collection enable abc123 \
+    --endpoint example.data.globus.org
+
+Requires an active authentication session (use 'login' first).`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEnable(cmd.Context(), profile, format, endpointFQDN, args[0], cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+
+	_ = cmd.MarkFlagRequired("endpoint")
+
+	return cmd
+}
+
+// runDisable executes the collection disable command.
+func runDisable(ctx context.Context, profile, formatStr, endpointFQDN, collectionID, message string, out interface{ Write([]byte) (int, error) }) error {
+	// Load token
+	token, err := cli.LoadToken(profile)
+	if err != nil {
+		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+
+	// Check if token is valid
+	if !token.IsValid() {
+		return fmt.Errorf("token expired, please login again")
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	// Create GCS client
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("create GCS client: %w", err)
+	}
+
+	// Disable collection
+	change, err := gcsClient.DisableCollection(ctx, collectionID, message)
+	if err != nil {
+		return fmt.Errorf("disable collection: %w", err)
+	}
+
+	// Save the replaced state for 'collection enable'
+	if change.Method == gcs.AvailabilityVisibility || change.UserMessage != "" {
+		if err := saveAvailabilityState(endpointFQDN, change); err != nil {
+			cli.Warnf("could not save previous collection state: %v", err)
+		}
+	}
+
+	// Output based on format
+	if formatter.IsStructured() {
+		return formatter.PrintData(change)
+	}
+
+	// Text format
+	if err := formatter.Println("Collection disabled successfully."); err != nil {
+		return err
+	}
+	return printAvailabilityChange(formatter, change)
+}
+
+// runEnable executes the collection enable command.
+func runEnable(ctx context.Context, profile, formatStr, endpointFQDN, collectionID string, out interface{ Write([]byte) (int, error) }) error {
+	// Load token
+	token, err := cli.LoadToken(profile)
+	if err != nil {
+		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+
+	// Check if token is valid
+	if !token.IsValid() {
+		return fmt.Errorf("token expired, please login again")
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	// Create GCS client
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("create GCS client: %w", err)
+	}
+
+	previous, err := loadAvailabilityState(endpointFQDN, collectionID)
+	if err != nil {
+		cli.Warnf("could not read previous collection state: %v", err)
+	}
+
+	// Enable collection
+	change, err := gcsClient.EnableCollection(ctx, collectionID, previous)
+	if err != nil {
+		return fmt.Errorf("enable collection: %w", err)
+	}
+
+	if change.Method == gcs.AvailabilityVisibility && previous == nil {
+		cli.Warnf("previous visibility of %s is unknown; it was left non-public", collectionID)
+	}
+	if change.Method == gcs.AvailabilityVisibility || previous != nil {
+		if err := saveAvailabilityState(endpointFQDN, &gcs.AvailabilityChange{CollectionID: collectionID}); err != nil {
+			cli.Warnf("could not clear saved collection state: %v", err)
+		}
+	}
+
+	// Output based on format
+	if formatter.IsStructured() {
+		return formatter.PrintData(change)
+	}
+
+	// Text format
+	if err := formatter.Println("Collection enabled successfully."); err != nil {
+		return err
+	}
+	return printAvailabilityChange(formatter, change)
+}
+
+// printAvailabilityChange prints the details of a disable or enable.
+func printAvailabilityChange(formatter *output.Formatter, change *gcs.AvailabilityChange) error {
+	if err := formatter.Println(); err != nil {
+		return err
+	}
+	if err := formatter.PrintText("%-20s%s\n", "Collection ID:", change.CollectionID); err != nil {
+		return err
+	}
+	if err := formatter.PrintText("%-20s%s\n", "Method:", change.Method); err != nil {
+		return err
+	}
+	if change.UserMessage != "" {
+		if err := formatter.PrintText("%-20s%s\n", "User Message:", change.UserMessage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// availabilityStatePath returns the path of the saved availability state.
+func availabilityStatePath() (string, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, availabilityStateFile), nil
+}
+
+// readAvailabilityState reads all saved availability state, keyed by
+// endpoint FQDN and collection ID.
+func readAvailabilityState(path string) (map[string]*gcs.AvailabilityChange, error) {
+	state := map[string]*gcs.AvailabilityChange{}
+
+	data, err := os.ReadFile(path) //nolint:gosec // Path is in the config directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// saveAvailabilityState records change for the collection. A change that
+// is not disabled removes the saved entry.
+func saveAvailabilityState(endpointFQDN string, change *gcs.AvailabilityChange) error {
+	path, err := availabilityStatePath()
+	if err != nil {
+		return err
+	}
+
+	state, err := readAvailabilityState(path)
+	if err != nil {
+		return err
+	}
+
+	key := endpointFQDN + "/" + change.CollectionID
+	if change.Disabled {
+		state[key] = change
+	} else {
+		delete(state, key)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// loadAvailabilityState returns the saved state for the collection, or
+// nil if none was saved.
+func loadAvailabilityState(endpointFQDN, collectionID string) (*gcs.AvailabilityChange, error) {
+	path, err := availabilityStatePath()
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := readAvailabilityState(path)
+	if err != nil {
+		return nil, err
+	}
+	return state[endpointFQDN+"/"+collectionID], nil
+}
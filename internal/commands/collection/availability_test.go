@@ -0,0 +1,88 @@
+package collection
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+)
+
+func TestNewDisableCmd(t *testing.T) {
+	cmd := NewDisableCmd()
+
+	if cmd.Use != "disable COLLECTION_ID" {
+		t.Errorf("NewDisableCmd() Use = %q, want %q", cmd.Use, "disable COLLECTION_ID")
+	}
+	if cmd.Short == "" || cmd.Long == "" {
+		t.Error("NewDisableCmd() description is empty")
+	}
+	if cmd.RunE == nil {
+		t.Error("NewDisableCmd() RunE is nil")
+	}
+	if cmd.Flags().Lookup("message") == nil {
+		t.Error("NewDisableCmd() missing --message flag")
+	}
+}
+
+func TestNewEnableCmd(t *testing.T) {
+	cmd := NewEnableCmd()
+
+	if cmd.Use != "enable COLLECTION_ID" {
+		t.Errorf("NewEnableCmd() Use = %q, want %q", cmd.Use, "enable COLLECTION_ID")
+	}
+	if cmd.Short == "" || cmd.Long == "" {
+		t.Error("NewEnableCmd() description is empty")
+	}
+	if cmd.RunE == nil {
+		t.Error("NewEnableCmd() RunE is nil")
+	}
+}
+
+func TestRunDisable_NoToken(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	err := runDisable(context.Background(), "nonexistent-profile-test", "text", "test.example.org", "col-1", "", buf)
+	if err == nil {
+		t.Error("runDisable() expected error for nonexistent profile, got nil")
+	}
+	if buf.Len() > 0 {
+		t.Errorf("runDisable() wrote to buffer on error: %q", buf.String())
+	}
+}
+
+func TestAvailabilityState(t *testing.T) {
+	t.Setenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR", t.TempDir())
+
+	change := &gcs.AvailabilityChange{
+		CollectionID:        "col-1",
+		Disabled:            true,
+		Method:              gcs.AvailabilityVisibility,
+		PreviousPublic:      true,
+		PreviousUserMessage: "Welcome",
+	}
+	if err := saveAvailabilityState("ep.example.org", change); err != nil {
+		t.Fatalf("saveAvailabilityState() error = %v", err)
+	}
+
+	got, err := loadAvailabilityState("ep.example.org", "col-1")
+	if err != nil {
+		t.Fatalf("loadAvailabilityState() error = %v", err)
+	}
+	if got == nil || !got.PreviousPublic || got.PreviousUserMessage != "Welcome" {
+		t.Fatalf("loadAvailabilityState() = %+v, want the saved state", got)
+	}
+
+	// Other endpoints do not share state
+	if other, _ := loadAvailabilityState("other.example.org", "col-1"); other != nil {
+		t.Errorf("loadAvailabilityState() for another endpoint = %+v, want nil", other)
+	}
+
+	// Enabling clears the saved state
+	if err := saveAvailabilityState("ep.example.org", &gcs.AvailabilityChange{CollectionID: "col-1"}); err != nil {
+		t.Fatalf("saveAvailabilityState() error = %v", err)
+	}
+	if got, _ := loadAvailabilityState("ep.example.org", "col-1"); got != nil {
+		t.Errorf("loadAvailabilityState() after enable = %+v, want nil", got)
+	}
+}
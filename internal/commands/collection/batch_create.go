@@ -0,0 +1,234 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// batchCreateResult reports the outcome of 'collection batch-create'.
+// Skipped lists the entries whose request --dry-run printed instead of
+// sending.
+type batchCreateResult struct {
+	Created    []createdCollection `json:"created"`
+	Failed     []failedCollection  `json:"failed"`
+	Skipped    []skippedCollection `json:"skipped,omitempty"`
+	Provenance *provenance         `json:"provenance,omitempty"`
+}
+
+// createdCollection is a collection created from a manifest entry.
+type createdCollection struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name"`
+	BasePath    string `json:"collection_base_path"`
+}
+
+// skippedCollection is a manifest entry not created because of --dry-run.
+type skippedCollection struct {
+	DisplayName string `json:"display_name"`
+	BasePath    string `json:"collection_base_path"`
+}
+
+// failedCollection is a manifest entry that could not be created.
+type failedCollection struct {
+	DisplayName string `json:"display_name"`
+	BasePath    string `json:"collection_base_path"`
+	Error       string `json:"error"`
+}
+
+// NewBatchCreateCmd creates the collection batch-create command.
+func NewBatchCreateCmd() *cobra.Command {
+	var (
+		profile      string
+		format       string
+		endpointFQDN string
+		manifestPath string
+		noProvenance bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "batch-create",
+		Short: "Create the collections listed in a manifest",
+		Long: `Create several collections from a YAML manifest, such as one written by
+'collection suggest'.
+
+The manifest lists collections with display_name, storage_gateway_id,
+and collection_base_path, plus optional collection_type (default
+mapped), description, public, contact_email, organization, department,
+and keywords:
+
+  collections:
+    - display_name: Project Alpha
+      storage_gateway_id: abc123
+      collection_base_path: /data/projects/alpha/
+
+Every entry is attempted; the command reports which collections were
+created and which failed, and exits non-zero if any failed.
+
+Each collection is tagged with keywords recording where it came from:
+the manifest path, the git commit of the manifest when it is in a git
+checkout, and the CI pipeline URL under GitHub Actions, GitLab CI, or
+Jenkins (e.g., provenance:commit=3f2a1c9). 'collection show' prints them
+under Provenance. Pass --no-provenance to leave them out.
+
+Example:
+  globus-connect-server collection batch-create --manifest projects.yaml \
+    --endpoint example.data.globus.org
+
+Requires an active authentication session (use 'login' first).`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runBatchCreate(cmd.Context(), profile, format, endpointFQDN, manifestPath, noProvenance, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	cmd.Flags().StringVar(&manifestPath, "manifest", "", "YAML manifest of collections to create")
+	cmd.Flags().BoolVar(&noProvenance, "no-provenance", false, "Do not tag collections with the manifest's commit, path, and pipeline")
+
+	_ = cmd.MarkFlagRequired("endpoint")
+	_ = cmd.MarkFlagRequired("manifest")
+
+	return cmd
+}
+
+// runBatchCreate executes the collection batch-create command.
+func runBatchCreate(ctx context.Context, profile, formatStr, endpointFQDN, manifestPath string, noProvenance bool, out interface{ Write([]byte) (int, error) }) error {
+	manifest, err := loadManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	// Load token
+	token, err := cli.LoadToken(profile)
+	if err != nil {
+		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+
+	// Check if token is valid
+	if !token.IsValid() {
+		return fmt.Errorf("token expired, please login again")
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	// Create GCS client
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("create GCS client: %w", err)
+	}
+
+	result := batchCreateResult{Created: []createdCollection{}, Failed: []failedCollection{}}
+	if !noProvenance {
+		p := detectProvenance(manifestPath, os.Getenv)
+		result.Provenance = &p
+	}
+
+	for _, entry := range manifest.Collections {
+		collection := entry.toCollection()
+		if result.Provenance != nil {
+			collection.Keywords = withProvenance(collection.Keywords, *result.Provenance)
+		}
+		created, err := gcsClient.CreateCollection(ctx, collection)
+		if errors.Is(err, gcs.ErrDryRun) {
+			result.Skipped = append(result.Skipped, skippedCollection{
+				DisplayName: entry.DisplayName,
+				BasePath:    entry.CollectionBasePath,
+			})
+			continue
+		}
+		if err != nil {
+			result.Failed = append(result.Failed, failedCollection{
+				DisplayName: entry.DisplayName,
+				BasePath:    entry.CollectionBasePath,
+				Error:       err.Error(),
+			})
+			continue
+		}
+		result.Created = append(result.Created, createdCollection{
+			ID:          created.ID,
+			DisplayName: entry.DisplayName,
+			BasePath:    entry.CollectionBasePath,
+		})
+	}
+
+	if err := printBatchCreateResult(formatter, &result); err != nil {
+		return err
+	}
+
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("batch create completed with %d failure(s)", len(result.Failed))
+	}
+	return nil
+}
+
+// printBatchCreateResult prints the created and failed collections.
+func printBatchCreateResult(formatter *output.Formatter, result *batchCreateResult) error {
+	if formatter.IsStructured() {
+		return formatter.PrintData(result)
+	}
+
+	if len(result.Created) > 0 {
+		if err := formatter.PrintText("Created %d collection(s):\n", len(result.Created)); err != nil {
+			return err
+		}
+		for _, c := range result.Created {
+			if err := formatter.PrintText("  ✓ %-38s %s (%s)\n", c.ID, c.DisplayName, c.BasePath); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(result.Failed) > 0 {
+		if len(result.Created) > 0 {
+			if err := formatter.Println(); err != nil {
+				return err
+			}
+		}
+		if err := formatter.PrintText("Failed to create %d collection(s):\n", len(result.Failed)); err != nil {
+			return err
+		}
+		for _, f := range result.Failed {
+			if err := formatter.PrintText("  ✗ %s (%s): %s\n", f.DisplayName, f.BasePath, f.Error); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(result.Skipped) > 0 {
+		if len(result.Created) > 0 || len(result.Failed) > 0 {
+			if err := formatter.Println(); err != nil {
+				return err
+			}
+		}
+		if err := formatter.PrintText("Skipped %d collection(s) (dry run, not sent):\n", len(result.Skipped)); err != nil {
+			return err
+		}
+		for _, s := range result.Skipped {
+			if err := formatter.PrintText("  - %s (%s)\n", s.DisplayName, s.BasePath); err != nil {
+				return err
+			}
+		}
+	}
+
+	if result.Provenance != nil && len(result.Created) > 0 {
+		if err := formatter.Println(); err != nil {
+			return err
+		}
+		if err := printProvenance(formatter, result.Provenance); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package collection
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs/gcstest"
+)
+
+// testManifest is a manifest of two collections.
+const testManifest = `collections:
+  - display_name: Alpha
+    storage_gateway_id: gw1
+    collection_base_path: /data/alpha/
+  - display_name: Beta
+    storage_gateway_id: gw1
+    collection_base_path: /data/beta/
+`
+
+// writeTestManifest writes testManifest to a temporary file and returns
+// its path.
+func writeTestManifest(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "collections.yaml")
+	if err := os.WriteFile(path, []byte(testManifest), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// useMockClient makes the commands use client.
+func useMockClient(t *testing.T, client *gcstest.Client) {
+	t.Helper()
+	factory := cli.GCSClientFactory
+	cli.GCSClientFactory = func(string, string) (gcs.GCSAPI, error) { return client, nil }
+	t.Cleanup(func() { cli.GCSClientFactory = factory })
+}
+
+func TestRunBatchCreate_DryRun(t *testing.T) {
+	saveMockToken(t)
+	client := &gcstest.Client{
+		CreateCollectionFunc: func(context.Context, *gcs.Collection) (*gcs.Collection, error) {
+			return nil, fmt.Errorf("%w: POST /api/collections not sent", gcs.ErrDryRun)
+		},
+	}
+	useMockClient(t, client)
+
+	buf := &bytes.Buffer{}
+	if err := runBatchCreate(context.Background(), "mock", "text", "test.example.org", writeTestManifest(t), true, buf); err != nil {
+		t.Errorf("runBatchCreate() error = %v, want nil for a dry run", err)
+	}
+	if calls := client.CallsTo("CreateCollection"); len(calls) != 2 {
+		t.Errorf("CreateCollection called %d times, want every request printed", len(calls))
+	}
+	for _, want := range []string{"Skipped 2 collection(s) (dry run, not sent)", "Alpha (/data/alpha/)", "Beta (/data/beta/)"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+	if strings.Contains(buf.String(), "Failed") {
+		t.Errorf("dry run reported failures:\n%s", buf.String())
+	}
+}
+
+func TestRunBatchCreate_PartialFailure(t *testing.T) {
+	saveMockToken(t)
+	client := &gcstest.Client{
+		CreateCollectionFunc: func(_ context.Context, c *gcs.Collection) (*gcs.Collection, error) {
+			if c.DisplayName == "Beta" {
+				return nil, fmt.Errorf("base path does not exist")
+			}
+			return &gcs.Collection{ID: "col-alpha", DisplayName: c.DisplayName}, nil
+		},
+	}
+	useMockClient(t, client)
+
+	buf := &bytes.Buffer{}
+	err := runBatchCreate(context.Background(), "mock", "text", "test.example.org", writeTestManifest(t), true, buf)
+	if err == nil || err.Error() != "batch create completed with 1 failure(s)" {
+		t.Errorf("runBatchCreate() error = %v, want the failure count", err)
+	}
+	if calls := client.CallsTo("CreateCollection"); len(calls) != 2 {
+		t.Errorf("CreateCollection called %d times, want the batch to continue past a failure", len(calls))
+	}
+	for _, want := range []string{
+		"Created 1 collection(s):",
+		"col-alpha",
+		"Failed to create 1 collection(s):",
+		"Beta (/data/beta/): base path does not exist",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestRunBatchCreate_PartialFailureJSON(t *testing.T) {
+	saveMockToken(t)
+	useMockClient(t, &gcstest.Client{
+		CreateCollectionFunc: func(_ context.Context, c *gcs.Collection) (*gcs.Collection, error) {
+			if c.DisplayName == "Alpha" {
+				return nil, fmt.Errorf("permission denied")
+			}
+			return &gcs.Collection{ID: "col-beta", DisplayName: c.DisplayName}, nil
+		},
+	})
+
+	buf := &bytes.Buffer{}
+	if err := runBatchCreate(context.Background(), "mock", "json", "test.example.org", writeTestManifest(t), true, buf); err == nil {
+		t.Fatal("runBatchCreate() error = nil, want non-nil when a collection fails")
+	}
+
+	var result batchCreateResult
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	if len(result.Created) != 1 || result.Created[0].ID != "col-beta" {
+		t.Errorf("created = %+v, want col-beta", result.Created)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].DisplayName != "Alpha" || result.Failed[0].Error != "permission denied" {
+		t.Errorf("failed = %+v, want Alpha with its error", result.Failed)
+	}
+}
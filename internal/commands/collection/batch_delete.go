@@ -1,33 +1,66 @@
 package collection
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
 )
 
+// defaultBatchDeleteSize is the number of collections sent in each batch
+// delete request.
+const defaultBatchDeleteSize = 50
+
+// batchDeleteOptions holds the flags of the collection batch-delete
+// command.
+type batchDeleteOptions struct {
+	idsFile         string
+	force           bool
+	continueOnError bool
+	batchSize       int
+}
+
+// batchDeleteSummary is the outcome of a batch delete. NotRun lists the
+// collections not attempted because an earlier request failed, and
+// Skipped those whose request --dry-run printed instead of sending.
+type batchDeleteSummary struct {
+	gcs.BatchDeleteResult
+	NotRun  []string `json:"not_run,omitempty"`
+	Skipped []string `json:"skipped,omitempty"`
+}
+
 // NewBatchDeleteCmd creates the collection batch-delete command.
 func NewBatchDeleteCmd() *cobra.Command {
 	var (
 		profile      string
 		format       string
 		endpointFQDN string
-		force        bool
+		opts         batchDeleteOptions
 	)
 
 	cmd := &cobra.Command{
-		Use:   "batch-delete COLLECTION_ID [COLLECTION_ID...]",
+		Use:   "batch-delete [COLLECTION_ID...]",
 		Short: "Delete multiple collections in one operation",
-		Long: `Delete multiple collections in a single batch operation.
+		Long: `Delete multiple collections in batch operations.
 
-This command allows you to delete several collections at once. If some
-deletions fail, the command will continue and report which succeeded
-and which failed.
+Collection IDs are given as arguments or, with --ids-file, read from a
+file with one ID per line ("-" reads standard input). Blank lines and
+lines starting with # are ignored.
+
+The IDs are sent in requests of --batch-size collections. The endpoint
+reports each collection's outcome; by default the command stops after a
+request in which any deletion failed, and --continue-on-error sends the
+remaining requests anyway. Each collection is listed as deleted, failed,
+or not run, and the command exits non-zero unless all were deleted.
 
 WARNING: This action is permanent and cannot be undone.
 
@@ -36,20 +69,24 @@ Example:
     --endpoint example.data.globus.org \
     --force
 
+  grep -v keep collections.txt | globus-connect-server collection batch-delete \
+    --endpoint example.data.globus.org --ids-file - --continue-on-error --force
+
 Use --force to skip confirmation prompt.
 
 Requires an active authentication session (use 'login' first).`,
-		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			collectionIDs := args
-			return runBatchDelete(cmd.Context(), profile, format, endpointFQDN, collectionIDs, force, cmd.OutOrStdout())
+			return runBatchDelete(cmd.Context(), profile, format, endpointFQDN, args, opts, cmd.InOrStdin(), cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml, table, wide, csv)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
-	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Skip confirmation prompt")
+	cmd.Flags().StringVar(&opts.idsFile, "ids-file", "", "Read collection IDs from this file, one per line (- for standard input)")
+	cmd.Flags().BoolVar(&opts.continueOnError, "continue-on-error", false, "Keep deleting after a request in which a deletion failed")
+	cmd.Flags().IntVar(&opts.batchSize, "batch-size", defaultBatchDeleteSize, "Number of collections deleted per request")
 
 	_ = cmd.MarkFlagRequired("endpoint")
 
@@ -57,9 +94,20 @@ Requires an active authentication session (use 'login' first).`,
 }
 
 // runBatchDelete executes the collection batch-delete command.
-func runBatchDelete(ctx context.Context, profile, formatStr, endpointFQDN string, collectionIDs []string, force bool, out interface{ Write([]byte) (int, error) }) error {
+func runBatchDelete(ctx context.Context, profile, formatStr, endpointFQDN string, args []string, opts batchDeleteOptions, in io.Reader, out io.Writer) error {
+	if opts.batchSize < 1 {
+		return fmt.Errorf("--batch-size must be at least 1")
+	}
+	collectionIDs, err := batchDeleteIDs(args, opts.idsFile, in)
+	if err != nil {
+		return err
+	}
+	if len(collectionIDs) == 0 {
+		return fmt.Errorf("no collection IDs given (pass them as arguments or with --ids-file)")
+	}
+
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -70,11 +118,11 @@ func runBatchDelete(ctx context.Context, profile, formatStr, endpointFQDN string
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Confirmation prompt (unless --force)
-	if !force {
-		if err := formatter.PrintText("WARNING: This will permanently delete %d collection(s).\\n", len(collectionIDs)); err != nil {
+	if !opts.force {
+		if err := formatter.PrintText("WARNING: This will permanently delete %d collection(s).\n", len(collectionIDs)); err != nil {
 			return err
 		}
 		if err := formatter.Println("This action cannot be undone."); err != nil {
@@ -83,45 +131,170 @@ func runBatchDelete(ctx context.Context, profile, formatStr, endpointFQDN string
 		if err := formatter.Println(); err != nil {
 			return err
 		}
-		if err := formatter.PrintText("Collections to delete:\\n"); err != nil {
+		if err := formatter.Println("Collections to delete:"); err != nil {
 			return err
 		}
 		for _, id := range collectionIDs {
-			if err := formatter.PrintText("  - %s\\n", id); err != nil {
+			if err := formatter.PrintText("  - %s\n", id); err != nil {
 				return err
 			}
 		}
 		if err := formatter.Println(); err != nil {
 			return err
 		}
-		if err := formatter.PrintText("To proceed, use --force flag.\\n"); err != nil {
+		if err := formatter.Println("To proceed, use --force flag."); err != nil {
 			return err
 		}
 		return fmt.Errorf("batch delete cancelled (use --force to proceed)")
 	}
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
 
-	// Batch delete collections
-	result, err := gcsClient.BatchDeleteCollections(ctx, collectionIDs)
+	summary := batchDelete(ctx, gcsClient, collectionIDs, opts)
+
+	switch {
+	case formatter.IsStructured():
+		err = formatter.PrintData(summary)
+	case formatter.IsTabular():
+		err = formatter.PrintTable(batchDeleteTable(summary))
+	default:
+		err = formatBatchDelete(formatter, summary, len(collectionIDs))
+	}
 	if err != nil {
-		return fmt.Errorf("batch delete collections: %w", err)
+		return err
 	}
 
-	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(result)
+	switch {
+	case len(summary.Failed) > 0 && len(summary.NotRun) > 0:
+		return fmt.Errorf("batch delete stopped with %d failure(s); %d collection(s) not run (use --continue-on-error to delete them anyway)",
+			len(summary.Failed), len(summary.NotRun))
+	case len(summary.Failed) > 0:
+		return fmt.Errorf("batch delete completed with %d failure(s)", len(summary.Failed))
+	case len(summary.NotRun) > 0:
+		return fmt.Errorf("batch delete interrupted; %d collection(s) not run", len(summary.NotRun))
+	}
+	return nil
+}
+
+// batchDeleteIDs returns the collection IDs of args and the IDs file, in
+// order and without duplicates.
+func batchDeleteIDs(args []string, idsFile string, in io.Reader) ([]string, error) {
+	ids := append([]string(nil), args...)
+	if idsFile != "" {
+		r := in
+		if idsFile != "-" {
+			f, err := os.Open(idsFile) //nolint:gosec // User-specified ID list
+			if err != nil {
+				return nil, fmt.Errorf("read IDs file: %w", err)
+			}
+			defer func() { _ = f.Close() }()
+			r = f
+		}
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			ids = append(ids, line)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("read IDs file: %w", err)
+		}
+	}
+
+	seen := make(map[string]bool, len(ids))
+	unique := ids[:0]
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique, nil
+}
+
+// batchDelete deletes the collections in requests of opts.batchSize, one
+// request at a time, stopping after a request with failures unless
+// opts.continueOnError is set.
+func batchDelete(ctx context.Context, client gcs.GCSAPI, collectionIDs []string, opts batchDeleteOptions) *batchDeleteSummary {
+	var chunks [][]string
+	for ids := collectionIDs; len(ids) > 0; {
+		n := min(opts.batchSize, len(ids))
+		chunks = append(chunks, ids[:n])
+		ids = ids[n:]
 	}
 
-	// Text format
-	if err := formatter.Println("Batch Delete Results"); err != nil {
+	summary := &batchDeleteSummary{BatchDeleteResult: gcs.BatchDeleteResult{Deleted: []string{}}}
+	sent := make(map[string]bool, len(chunks))
+	batch := gcs.Batch[[]string, *gcs.BatchDeleteResult]{
+		Workers:     1,
+		StopOnError: !opts.continueOnError,
+		OnResult: func(r gcs.BatchResult[[]string, *gcs.BatchDeleteResult]) {
+			// IDs are unique, so a chunk's first ID identifies it
+			sent[r.Item[0]] = true
+			if r.Value == nil && r.Err == nil {
+				summary.Skipped = append(summary.Skipped, r.Item...)
+				return
+			}
+			if r.Value == nil {
+				for _, id := range r.Item {
+					summary.Failed = append(summary.Failed, gcs.BatchDeleteError{CollectionID: id, Error: r.Err.Error()})
+				}
+				return
+			}
+			summary.Deleted = append(summary.Deleted, r.Value.Deleted...)
+			summary.Failed = append(summary.Failed, r.Value.Failed...)
+		},
+	}
+	results, _ := batch.Run(ctx, chunks, func(ctx context.Context, ids []string) (*gcs.BatchDeleteResult, error) {
+		result, err := client.BatchDeleteCollections(ctx, ids)
+		if errors.Is(err, gcs.ErrDryRun) {
+			// The request was printed, not sent; go on to the next one
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(result.Failed) > 0 {
+			return result, fmt.Errorf("%d of %d deletion(s) failed", len(result.Failed), len(ids))
+		}
+		return result, nil
+	})
+	for _, r := range results {
+		if !sent[r.Item[0]] {
+			summary.NotRun = append(summary.NotRun, r.Item...)
+		}
+	}
+	return summary
+}
+
+// batchDeleteTable returns the outcome of each collection as a table.
+func batchDeleteTable(summary *batchDeleteSummary) *output.Table {
+	table := output.NewTable("Collection ID", "Status", "Error")
+	for _, id := range summary.Deleted {
+		table.AddRow(id, "deleted", "")
+	}
+	for _, f := range summary.Failed {
+		table.AddRow(f.CollectionID, "failed", f.Error)
+	}
+	for _, id := range summary.NotRun {
+		table.AddRow(id, "not run", "")
+	}
+	for _, id := range summary.Skipped {
+		table.AddRow(id, "skipped", "dry run")
+	}
+	return table
+}
+
+// formatBatchDelete prints the outcome of each collection and a summary
+// line in text format.
+func formatBatchDelete(formatter *output.Formatter, summary *batchDeleteSummary, total int) error {
+	if err := formatter.Println(formatter.Heading("Batch Delete Results")); err != nil {
 		return err
 	}
 	if err := formatter.Println("===================="); err != nil {
@@ -131,39 +304,34 @@ func runBatchDelete(ctx context.Context, profile, formatStr, endpointFQDN string
 		return err
 	}
 
-	// Show successful deletions
-	if len(result.Deleted) > 0 {
-		if err := formatter.PrintText("Successfully deleted %d collection(s):\\n", len(result.Deleted)); err != nil {
+	for _, id := range summary.Deleted {
+		if err := formatter.PrintText("  %s %s\n", formatter.Success("✓"), id); err != nil {
 			return err
 		}
-		for _, id := range result.Deleted {
-			if err := formatter.PrintText("  ✓ %s\\n", id); err != nil {
-				return err
-			}
-		}
-		if err := formatter.Println(); err != nil {
+	}
+	for _, f := range summary.Failed {
+		if err := formatter.PrintText("  %s %s: %s\n", formatter.Failure("✗"), f.CollectionID, f.Error); err != nil {
 			return err
 		}
 	}
-
-	// Show failed deletions
-	if len(result.Failed) > 0 {
-		if err := formatter.PrintText("Failed to delete %d collection(s):\\n", len(result.Failed)); err != nil {
+	for _, id := range summary.NotRun {
+		if err := formatter.PrintText("  %s %s %s\n", formatter.Dim("-"), id, formatter.Dim("(not run)")); err != nil {
 			return err
 		}
-		for _, f := range result.Failed {
-			if err := formatter.PrintText("  ✗ %s: %s\\n", f.CollectionID, f.Error); err != nil {
-				return err
-			}
-		}
-		if err := formatter.Println(); err != nil {
+	}
+	for _, id := range summary.Skipped {
+		if err := formatter.PrintText("  %s %s %s\n", formatter.Dim("-"), id, formatter.Dim("(dry run, not sent)")); err != nil {
 			return err
 		}
 	}
-
-	if len(result.Failed) > 0 {
-		return fmt.Errorf("batch delete completed with %d failure(s)", len(result.Failed))
+	if err := formatter.Println(); err != nil {
+		return err
 	}
 
-	return nil
+	if len(summary.Skipped) > 0 {
+		return formatter.PrintText("Deleted %d of %d collection(s): %d failed, %d not run, %d skipped (dry run)\n",
+			len(summary.Deleted), total, len(summary.Failed), len(summary.NotRun), len(summary.Skipped))
+	}
+	return formatter.PrintText("Deleted %d of %d collection(s): %d failed, %d not run\n",
+		len(summary.Deleted), total, len(summary.Failed), len(summary.NotRun))
 }
@@ -0,0 +1,157 @@
+package collection
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs/gcstest"
+)
+
+// newBatchDeleteClient returns a mock whose batch deletes fail for the IDs
+// in fail and return an error for any request including broken.
+func newBatchDeleteClient(fail ...string) *gcstest.Client {
+	return &gcstest.Client{
+		BatchDeleteCollectionsFunc: func(_ context.Context, ids []string) (*gcs.BatchDeleteResult, error) {
+			if slices.Contains(ids, "broken") {
+				return nil, errors.New("HTTP 500")
+			}
+			result := &gcs.BatchDeleteResult{}
+			for _, id := range ids {
+				if slices.Contains(fail, id) {
+					result.Failed = append(result.Failed, gcs.BatchDeleteError{CollectionID: id, Error: "in use"})
+				} else {
+					result.Deleted = append(result.Deleted, id)
+				}
+			}
+			return result, nil
+		},
+	}
+}
+
+func TestBatchDeleteIDs(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "ids.txt")
+	if err := os.WriteFile(file, []byte("# old collections\nc2\n\n  c3  \nc1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := batchDeleteIDs([]string{"c1"}, file, nil)
+	if err != nil {
+		t.Fatalf("batchDeleteIDs() error = %v", err)
+	}
+	if strings.Join(ids, ",") != "c1,c2,c3" {
+		t.Errorf("ids = %v, want c1, c2, c3", ids)
+	}
+
+	ids, err = batchDeleteIDs(nil, "-", strings.NewReader("c4\nc5\n"))
+	if err != nil || strings.Join(ids, ",") != "c4,c5" {
+		t.Errorf("batchDeleteIDs(stdin) = %v, %v; want c4, c5", ids, err)
+	}
+
+	if _, err := batchDeleteIDs(nil, filepath.Join(t.TempDir(), "missing"), nil); err == nil {
+		t.Error("batchDeleteIDs() with a missing file succeeded")
+	}
+}
+
+func TestBatchDelete_StopsOnError(t *testing.T) {
+	client := newBatchDeleteClient("b")
+	summary := batchDelete(context.Background(), client, []string{"a", "b", "c", "d", "e"}, batchDeleteOptions{batchSize: 2})
+
+	if strings.Join(summary.Deleted, ",") != "a" {
+		t.Errorf("Deleted = %v, want a", summary.Deleted)
+	}
+	if len(summary.Failed) != 1 || summary.Failed[0].CollectionID != "b" {
+		t.Errorf("Failed = %+v, want b", summary.Failed)
+	}
+	if strings.Join(summary.NotRun, ",") != "c,d,e" {
+		t.Errorf("NotRun = %v, want c, d, e", summary.NotRun)
+	}
+	if calls := client.CallsTo("BatchDeleteCollections"); len(calls) != 1 {
+		t.Errorf("BatchDeleteCollections called %d times, want 1", len(calls))
+	}
+}
+
+func TestBatchDelete_ContinueOnError(t *testing.T) {
+	client := newBatchDeleteClient("b")
+	opts := batchDeleteOptions{batchSize: 2, continueOnError: true}
+	summary := batchDelete(context.Background(), client, []string{"a", "b", "broken", "d", "e"}, opts)
+
+	if strings.Join(summary.Deleted, ",") != "a,e" {
+		t.Errorf("Deleted = %v, want a, e", summary.Deleted)
+	}
+	var failed []string
+	for _, f := range summary.Failed {
+		failed = append(failed, f.CollectionID+"="+f.Error)
+	}
+	if strings.Join(failed, ",") != "b=in use,broken=HTTP 500,d=HTTP 500" {
+		t.Errorf("Failed = %v, want b, and broken and d from the failed request", failed)
+	}
+	if len(summary.NotRun) != 0 {
+		t.Errorf("NotRun = %v, want none", summary.NotRun)
+	}
+}
+
+func TestRunBatchDelete(t *testing.T) {
+	saveMockToken(t)
+	client := newBatchDeleteClient("b")
+	factory := cli.GCSClientFactory
+	cli.GCSClientFactory = func(string, string) (gcs.GCSAPI, error) { return client, nil }
+	t.Cleanup(func() { cli.GCSClientFactory = factory })
+
+	buf := &bytes.Buffer{}
+	opts := batchDeleteOptions{idsFile: "-", force: true, batchSize: 1}
+	err := runBatchDelete(context.Background(), "mock", "text", "test.example.org", nil, opts, strings.NewReader("a\nb\nc\n"), buf)
+	if err == nil || !strings.Contains(err.Error(), "1 collection(s) not run") {
+		t.Errorf("runBatchDelete() error = %v, want a failure with 1 not run", err)
+	}
+	for _, want := range []string{"✓ a", "✗ b: in use", "c (not run)", "Deleted 1 of 3 collection(s): 1 failed, 1 not run"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+	if strings.Contains(buf.String(), `\n`) {
+		t.Errorf("output contains a literal \\n:\n%s", buf.String())
+	}
+}
+
+func TestRunBatchDelete_DryRun(t *testing.T) {
+	saveMockToken(t)
+	client := &gcstest.Client{
+		BatchDeleteCollectionsFunc: func(context.Context, []string) (*gcs.BatchDeleteResult, error) {
+			return nil, fmt.Errorf("%w: POST /api/collections/batch_delete not sent", gcs.ErrDryRun)
+		},
+	}
+	factory := cli.GCSClientFactory
+	cli.GCSClientFactory = func(string, string) (gcs.GCSAPI, error) { return client, nil }
+	t.Cleanup(func() { cli.GCSClientFactory = factory })
+
+	buf := &bytes.Buffer{}
+	opts := batchDeleteOptions{force: true, batchSize: 1}
+	if err := runBatchDelete(context.Background(), "mock", "text", "test.example.org", []string{"a", "b", "c"}, opts, nil, buf); err != nil {
+		t.Errorf("runBatchDelete() error = %v, want nil for a dry run", err)
+	}
+	if calls := client.CallsTo("BatchDeleteCollections"); len(calls) != 3 {
+		t.Errorf("BatchDeleteCollections called %d times, want every request printed", len(calls))
+	}
+	for _, want := range []string{"a (dry run, not sent)", "c (dry run, not sent)", "0 failed, 0 not run, 3 skipped (dry run)"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestRunBatchDelete_NoIDs(t *testing.T) {
+	err := runBatchDelete(context.Background(), "nonexistent-profile-test", "text", "test.example.org", nil,
+		batchDeleteOptions{idsFile: "-", batchSize: 1}, strings.NewReader("# nothing\n"), &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "no collection IDs given") {
+		t.Errorf("runBatchDelete() error = %v, want no collection IDs given", err)
+	}
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -26,7 +26,14 @@ func NewCheckCmd() *cobra.Command {
 
 This command performs comprehensive validation of a collection's settings,
 including storage gateway connectivity, path accessibility, and permission
-configuration. It returns any errors or warnings found.
+configuration. Each error or warning found is printed with its severity,
+and the command exits non-zero if the collection is invalid (has errors),
+so it can gate scripts and monitoring.
+
+With --format github-actions, errors and warnings are written as GitHub
+Actions annotations and a summary is added to the job summary; with
+--format sarif, they are written as a SARIF log for code-scanning
+dashboards.
 
 Example:
   globus-connect-server collection check abc123 \
@@ -41,7 +48,7 @@ Requires an active authentication session (use 'login' first).`,
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml, github-actions, sarif)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 
 	_ = cmd.MarkFlagRequired("endpoint")
@@ -52,7 +59,7 @@ Requires an active authentication session (use 'login' first).`,
 // runCheck executes the collection check command.
 func runCheck(ctx context.Context, profile, formatStr, endpointFQDN, collectionID string, out interface{ Write([]byte) (int, error) }) error {
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -63,13 +70,10 @@ func runCheck(ctx context.Context, profile, formatStr, endpointFQDN, collectionI
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -80,18 +84,65 @@ func runCheck(ctx context.Context, profile, formatStr, endpointFQDN, collectionI
 		return fmt.Errorf("check collection: %w", err)
 	}
 
-	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(result)
+	switch {
+	case formatter.IsStructured():
+		err = formatter.PrintData(result)
+	case formatter.IsAnnotated():
+		err = reportCheckResults(formatter, result)
+	default:
+		err = formatCheckResults(formatter, result)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !result.Valid {
+		return fmt.Errorf("collection %s failed validation", result.CollectionID)
+	}
+	return nil
+}
+
+// reportCheckResults reports validation results as annotations (GitHub
+// Actions or SARIF) and a job summary.
+func reportCheckResults(formatter *output.Formatter, result *gcs.CollectionValidation) error {
+	var annotations []output.Annotation
+	for _, issue := range result.Errors {
+		annotations = append(annotations, checkAnnotation(output.AnnotationError, result.CollectionID, issue))
+	}
+	for _, issue := range result.Warnings {
+		annotations = append(annotations, checkAnnotation(output.AnnotationWarning, result.CollectionID, issue))
+	}
+	if err := formatter.PrintAnnotations(annotations); err != nil {
+		return err
+	}
+
+	status := "✅ valid"
+	if !result.Valid {
+		status = "❌ invalid"
 	}
+	summary := fmt.Sprintf("### Collection %s\n\n%s: %d error(s), %d warning(s)\n\n",
+		result.CollectionID, status, len(result.Errors), len(result.Warnings))
+	return formatter.WriteStepSummary(summary)
+}
 
-	return formatCheckResults(formatter, result)
+// checkAnnotation converts a validation issue to an annotation.
+func checkAnnotation(level, collectionID string, issue gcs.ValidationError) output.Annotation {
+	message := issue.Message
+	if issue.Field != "" {
+		message = fmt.Sprintf("%s (field: %s)", message, issue.Field)
+	}
+	return output.Annotation{
+		Level:   level,
+		Title:   fmt.Sprintf("collection %s: %s", collectionID, issue.Code),
+		Rule:    issue.Code,
+		Message: message,
+	}
 }
 
 // formatCheckResults formats the validation results in text format.
 func formatCheckResults(formatter *output.Formatter, result *gcs.CollectionValidation) error {
 	// Header
-	if err := formatter.Println("Collection Validation Results"); err != nil {
+	if err := formatter.Println(formatter.Heading("Collection Validation Results")); err != nil {
 		return err
 	}
 	if err := formatter.Println("============================"); err != nil {
@@ -105,9 +156,9 @@ func formatCheckResults(formatter *output.Formatter, result *gcs.CollectionValid
 	if err := formatter.PrintText("%-20s%s\n", "Collection ID:", result.CollectionID); err != nil {
 		return err
 	}
-	status := "Valid"
+	status := formatter.Success("Valid")
 	if !result.Valid {
-		status = "Invalid"
+		status = formatter.Failure("Invalid")
 	}
 	if err := formatter.PrintText("%-20s%s\n", "Status:", status); err != nil {
 		return err
@@ -116,19 +167,13 @@ func formatCheckResults(formatter *output.Formatter, result *gcs.CollectionValid
 		return err
 	}
 
-	// Display errors
-	if err := formatValidationIssues(formatter, "Errors:", result.Errors); err != nil {
-		return err
-	}
-
-	// Display warnings
-	if err := formatValidationIssues(formatter, "Warnings:", result.Warnings); err != nil {
+	if err := formatValidationIssues(formatter, result); err != nil {
 		return err
 	}
 
 	// Success message
 	if result.Valid && len(result.Warnings) == 0 {
-		if err := formatter.Println("No issues found. Collection is properly configured."); err != nil {
+		if err := formatter.Println(formatter.Success("No issues found. Collection is properly configured.")); err != nil {
 			return err
 		}
 	}
@@ -136,28 +181,40 @@ func formatCheckResults(formatter *output.Formatter, result *gcs.CollectionValid
 	return nil
 }
 
-// formatValidationIssues formats a list of validation errors or warnings.
-func formatValidationIssues(formatter *output.Formatter, header string, issues []gcs.ValidationError) error {
-	if len(issues) == 0 {
+// formatValidationIssues lists a validation's errors and then its
+// warnings, each with its severity.
+func formatValidationIssues(formatter *output.Formatter, result *gcs.CollectionValidation) error {
+	if len(result.Errors) == 0 && len(result.Warnings) == 0 {
 		return nil
 	}
 
-	if err := formatter.Println(header); err != nil {
+	if err := formatter.Println(formatter.Heading("Issues:")); err != nil {
 		return err
 	}
 
-	for i, issue := range issues {
-		prefix := fmt.Sprintf("  %d. ", i+1)
-		if err := formatter.PrintText("%s[%s] %s", prefix, issue.Code, issue.Message); err != nil {
-			return err
-		}
-		if issue.Field != "" {
-			if err := formatter.PrintText(" (Field: %s)", issue.Field); err != nil {
+	type severity struct {
+		label  string
+		style  func(string) string
+		issues []gcs.ValidationError
+	}
+	n := 0
+	for _, sev := range []severity{
+		{"ERROR", formatter.Failure, result.Errors},
+		{"WARNING", formatter.Warning, result.Warnings},
+	} {
+		for _, issue := range sev.issues {
+			n++
+			if err := formatter.PrintText("  %d. %s %s %s", n, sev.style(fmt.Sprintf("%-7s", sev.label)), "["+issue.Code+"]", issue.Message); err != nil {
+				return err
+			}
+			if issue.Field != "" {
+				if err := formatter.PrintText(" %s", formatter.Dim("(Field: "+issue.Field+")")); err != nil {
+					return err
+				}
+			}
+			if err := formatter.Println(); err != nil {
 				return err
 			}
-		}
-		if err := formatter.Println(); err != nil {
-			return err
 		}
 	}
 
@@ -0,0 +1,76 @@
+package collection
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs/gcstest"
+)
+
+// useCheckResult logs in the profile "mock" and makes CheckCollection
+// return result.
+func useCheckResult(t *testing.T, result *gcs.CollectionValidation) {
+	t.Helper()
+	saveMockToken(t)
+
+	client := &gcstest.Client{
+		CheckCollectionFunc: func(context.Context, string) (*gcs.CollectionValidation, error) {
+			return result, nil
+		},
+	}
+	factory := cli.GCSClientFactory
+	cli.GCSClientFactory = func(string, string) (gcs.GCSAPI, error) { return client, nil }
+	t.Cleanup(func() { cli.GCSClientFactory = factory })
+}
+
+func TestRunCheck_Invalid(t *testing.T) {
+	useCheckResult(t, &gcs.CollectionValidation{
+		CollectionID: "c1",
+		Errors:       []gcs.ValidationError{{Code: "PATH_NOT_FOUND", Message: "base path does not exist", Field: "collection_base_path"}},
+		Warnings:     []gcs.ValidationError{{Code: "NO_CONTACT", Message: "no contact email"}},
+	})
+
+	for _, format := range []string{"text", "json"} {
+		t.Run(format, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := runCheck(context.Background(), "mock", format, "test.example.org", "c1", buf)
+			if err == nil || err.Error() != "collection c1 failed validation" {
+				t.Errorf("runCheck() error = %v, want collection c1 failed validation", err)
+			}
+			if buf.Len() == 0 {
+				t.Error("runCheck() printed nothing for an invalid collection")
+			}
+		})
+	}
+
+	buf := &bytes.Buffer{}
+	_ = runCheck(context.Background(), "mock", "text", "test.example.org", "c1", buf)
+	text := buf.String()
+	errorAt := strings.Index(text, "ERROR   [PATH_NOT_FOUND]")
+	warningAt := strings.Index(text, "WARNING [NO_CONTACT]")
+	if errorAt < 0 || warningAt < errorAt {
+		t.Errorf("text output should list the error and then the warning with severities:\n%s", text)
+	}
+}
+
+func TestRunCheck_ValidWithWarnings(t *testing.T) {
+	useCheckResult(t, &gcs.CollectionValidation{
+		CollectionID: "c1",
+		Valid:        true,
+		Warnings:     []gcs.ValidationError{{Code: "NO_CONTACT", Message: "no contact email"}},
+	})
+
+	buf := &bytes.Buffer{}
+	if err := runCheck(context.Background(), "mock", "json", "test.example.org", "c1", buf); err != nil {
+		t.Fatalf("runCheck() error = %v, want nil for warnings only", err)
+	}
+	var got gcs.CollectionValidation
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil || !got.Valid || len(got.Warnings) != 1 {
+		t.Errorf("output = %s (%v), want the valid result with one warning", buf.String(), err)
+	}
+}
@@ -18,15 +18,24 @@ both mapped collections (direct storage access) and guest collections
 	cmd.AddCommand(NewListCmd())
 	cmd.AddCommand(NewShowCmd())
 	cmd.AddCommand(NewCreateCmd())
+	cmd.AddCommand(NewCreateGuestCmd())
 	cmd.AddCommand(NewUpdateCmd())
+	cmd.AddCommand(NewEditCmd())
+	cmd.AddCommand(NewExportCmd())
+	cmd.AddCommand(NewDiffCmd())
 	cmd.AddCommand(NewDeleteCmd())
+	cmd.AddCommand(NewDisableCmd())
+	cmd.AddCommand(NewEnableCmd())
 	cmd.AddCommand(NewCheckCmd())
 	cmd.AddCommand(NewBatchDeleteCmd())
+	cmd.AddCommand(NewBatchCreateCmd())
+	cmd.AddCommand(NewSuggestCmd())
 	cmd.AddCommand(NewSetOwnerCmd())
 	cmd.AddCommand(NewSetOwnerStringCmd())
 	cmd.AddCommand(NewResetOwnerStringCmd())
 	cmd.AddCommand(NewSetSubscriptionAdminVerifiedCmd())
 	cmd.AddCommand(NewDomainCmd())
+	cmd.AddCommand(NewPermissionCmd())
 
 	return cmd
 }
@@ -5,8 +5,8 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -17,6 +17,7 @@ func NewCreateCmd() *cobra.Command {
 	var (
 		profile                  string
 		format                   string
+		quiet                    bool
 		endpointFQDN             string
 		displayName              string
 		storageGatewayID         string
@@ -33,7 +34,9 @@ func NewCreateCmd() *cobra.Command {
 		department               string
 		userMessage              string
 		userMessageLink          string
+		policyOpts               policyFlags
 		identityID               string
+		fromFile                 string
 	)
 
 	cmd := &cobra.Command{
@@ -43,6 +46,7 @@ func NewCreateCmd() *cobra.Command {
 
 A collection provides access to a storage location through a storage gateway.
 You must specify the display name, storage gateway ID, and base path.
+Guest collections are created with 'collection create-guest'.
 
 Example:
   globus-connect-server collection create \
@@ -51,23 +55,53 @@ Example:
     --storage-gateway-id abc123 \
     --collection-base-path /data/shared
 
+Policy flags fill in the collection's Policies block.
+--authentication-timeout-mins makes users authenticate again after that
+many minutes; --sharing-restrict, --sharing-users-allow and
+--sharing-users-deny limit who may create shares. The user flags may be
+repeated or given comma-separated lists:
+  globus-connect-server collection create \
+    --endpoint example.data.globus.org \
+    --display-name "Protected Data" \
+    --storage-gateway-id abc123 \
+    --collection-base-path /data/protected \
+    --authentication-timeout-mins 60 \
+    --sharing-users-allow alice@example.org \
+    --sharing-users-allow bob@example.org
+
+--from-file creates the collection defined by a file written by
+'collection export' (use - for stdin), with every field it holds,
+including policies. It cannot be combined with the field flags.
+  globus-connect-server collection create --from-file collection.yaml \
+    --endpoint example.data.globus.org
+
 Requires an active authentication session (use 'login' first).`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			if fromFile != "" {
+				return runCreateFromFile(cmd.Context(), profile, format, endpointFQDN, fromFile,
+					quiet, cmd.InOrStdin(), cmd.OutOrStdout())
+			}
+			policies, err := policyOpts.policies(cmd)
+			if err != nil {
+				return err
+			}
 			return runCreate(cmd.Context(), profile, format, endpointFQDN,
 				displayName, storageGatewayID, collectionBaseFolder, collectionType,
 				description, public, disableAnonymousWrites, contactEmail,
 				contactInfo, infoLink, keywords, organization, department,
-				userMessage, userMessageLink, identityID, cmd.OutOrStdout())
+				userMessage, userMessageLink, identityID, policies, quiet, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Only print IDs, one per line")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 	cmd.Flags().StringVar(&displayName, "display-name", "", "Display name for the collection")
 	cmd.Flags().StringVar(&storageGatewayID, "storage-gateway-id", "", "Storage gateway ID")
 	cmd.Flags().StringVar(&collectionBaseFolder, "collection-base-path", "", "Base path for the collection")
-	cmd.Flags().StringVar(&collectionType, "collection-type", "mapped", "Collection type (mapped, guest)")
+	cli.EnumVar(cmd, &collectionType, "collection-type", gcs.CollectionTypeMapped, "Collection type (mapped, guest)",
+		gcs.CollectionTypeMapped, gcs.CollectionTypeGuest)
 	cmd.Flags().StringVar(&description, "description", "", "Description of the collection")
 	cmd.Flags().BoolVar(&public, "public", false, "Make collection public")
 	cmd.Flags().BoolVar(&disableAnonymousWrites, "disable-anonymous-writes", false, "Disable anonymous writes")
@@ -80,11 +114,11 @@ Requires an active authentication session (use 'login' first).`,
 	cmd.Flags().StringVar(&userMessage, "user-message", "", "Message shown to users")
 	cmd.Flags().StringVar(&userMessageLink, "user-message-link", "", "Link for user message")
 	cmd.Flags().StringVar(&identityID, "identity-id", "", "Identity ID")
+	addPolicyFlags(cmd, &policyOpts)
+	cmd.Flags().StringVar(&fromFile, fromFileFlag, "", "Create the collection defined in a file written by 'collection export' (- for stdin)")
 
 	_ = cmd.MarkFlagRequired("endpoint")
-	_ = cmd.MarkFlagRequired("display-name")
-	_ = cmd.MarkFlagRequired("storage-gateway-id")
-	_ = cmd.MarkFlagRequired("collection-base-path")
+	markFromFileExclusive(cmd, "display-name", "storage-gateway-id", "collection-base-path")
 
 	return cmd
 }
@@ -95,10 +129,18 @@ func runCreate(ctx context.Context, profile, formatStr, endpointFQDN string,
 	description string, public, disableAnonymousWrites bool,
 	contactEmail, contactInfo, infoLink, keywords, organization, department,
 	userMessage, userMessageLink, identityID string,
-	out interface{ Write([]byte) (int, error) }) error {
+	policies *gcs.CollectionPolicies,
+	quiet bool, out interface{ Write([]byte) (int, error) }) error {
+
+	if quiet && output.Format(formatStr) != output.FormatText {
+		return fmt.Errorf("--quiet cannot be combined with --format %s", formatStr)
+	}
+	if collectionType == gcs.CollectionTypeGuest {
+		return fmt.Errorf("guest collections are created with 'collection create-guest', which takes the mapped collection and user credential")
+	}
 
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -109,13 +151,10 @@ func runCreate(ctx context.Context, profile, formatStr, endpointFQDN string,
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions(output.WithQuiet(quiet))...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -137,6 +176,7 @@ func runCreate(ctx context.Context, profile, formatStr, endpointFQDN string,
 		UserMessage:            userMessage,
 		UserMessageLink:        userMessageLink,
 		IdentityID:             identityID,
+		Policies:               policies,
 	}
 
 	// Parse keywords if provided
@@ -148,18 +188,33 @@ func runCreate(ctx context.Context, profile, formatStr, endpointFQDN string,
 		}
 	}
 
+	// Warn about subscription limits the request would exceed
+	if limits, err := gcsClient.GetLimits(ctx); err == nil {
+		for _, problem := range limits.CheckCollectionCreate(collectionType) {
+			cli.Warnf("%v (see 'endpoint limits')", problem)
+		}
+	}
+
 	// Create collection
 	created, err := gcsClient.CreateCollection(ctx, collection)
 	if err != nil {
 		return fmt.Errorf("create collection: %w", err)
 	}
 
+	if formatter.IsQuiet() {
+		return formatter.PrintIDs(created.ID)
+	}
+
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(created)
+	if formatter.IsStructured() {
+		return formatter.PrintData(created)
 	}
 
-	// Text format
+	return printCreatedCollection(formatter, created)
+}
+
+// printCreatedCollection prints a created collection in text format.
+func printCreatedCollection(formatter *output.Formatter, created *gcs.Collection) error {
 	if err := formatter.Println("Collection created successfully!"); err != nil {
 		return err
 	}
@@ -187,6 +242,11 @@ func runCreate(ctx context.Context, profile, formatStr, endpointFQDN string,
 			return err
 		}
 	}
+	if created.MappedCollectionID != "" {
+		if err := formatter.PrintText("%-20s%s\n", "Mapped Collection:", created.MappedCollectionID); err != nil {
+			return err
+		}
+	}
 	if created.CollectionBaseFolder != "" {
 		if err := formatter.PrintText("%-20s%s\n", "Base Path:", created.CollectionBaseFolder); err != nil {
 			return err
@@ -0,0 +1,138 @@
+package collection
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// NewCreateGuestCmd creates the collection create-guest command.
+func NewCreateGuestCmd() *cobra.Command {
+	var (
+		profile      string
+		format       string
+		quiet        bool
+		endpointFQDN string
+		keywords     string
+		collection   gcs.Collection
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create-guest",
+		Short: "Create a guest collection on a mapped collection",
+		Long: `Create a guest collection that shares a path of a mapped collection.
+
+A guest collection accesses storage through the mapped collection's storage
+gateway, as the identity of the given user credential. The base path is
+within the mapped collection: "/" shares all of it, "/projects/shared"
+only that directory.
+
+The mapped collection must allow guest collections and the user credential
+must be for its storage gateway; both are checked before the collection is
+created. Guest collections require an endpoint managed by a subscription.
+
+Example:
+  globus-connect-server collection create-guest \
+    --endpoint example.data.globus.org \
+    --mapped-collection-id 1234abcd \
+    --user-credential-id 5678efgh \
+    --display-name "Project Share" \
+    --collection-base-path /projects/shared
+
+Requires an active authentication session (use 'login' first).`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runCreateGuest(cmd.Context(), profile, format, endpointFQDN, &collection, keywords, quiet, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Only print IDs, one per line")
+	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	cmd.Flags().StringVar(&collection.MappedCollectionID, "mapped-collection-id", "", "ID of the mapped collection to share")
+	cmd.Flags().StringVar(&collection.UserCredentialID, "user-credential-id", "", "ID of the user credential the guest collection accesses storage with")
+	cmd.Flags().StringVar(&collection.DisplayName, "display-name", "", "Display name for the collection")
+	cmd.Flags().StringVar(&collection.CollectionBaseFolder, "collection-base-path", "/", "Path within the mapped collection to share")
+	cmd.Flags().StringVar(&collection.Description, "description", "", "Description of the collection")
+	cmd.Flags().BoolVar(&collection.Public, "public", false, "Make collection public")
+	cmd.Flags().StringVar(&collection.ContactEmail, "contact-email", "", "Contact email")
+	cmd.Flags().StringVar(&collection.ContactInfo, "contact-info", "", "Contact information")
+	cmd.Flags().StringVar(&collection.InfoLink, "info-link", "", "Information link URL")
+	cmd.Flags().StringVar(&keywords, "keywords", "", "Comma-separated keywords")
+	cmd.Flags().StringVar(&collection.Organization, "organization", "", "Organization name")
+	cmd.Flags().StringVar(&collection.Department, "department", "", "Department name")
+	cmd.Flags().StringVar(&collection.UserMessage, "user-message", "", "Message shown to users")
+	cmd.Flags().StringVar(&collection.UserMessageLink, "user-message-link", "", "Link for user message")
+	cmd.Flags().StringVar(&collection.IdentityID, "identity-id", "", "Identity ID")
+
+	_ = cmd.MarkFlagRequired("endpoint")
+	_ = cmd.MarkFlagRequired("mapped-collection-id")
+	_ = cmd.MarkFlagRequired("user-credential-id")
+	_ = cmd.MarkFlagRequired("display-name")
+
+	return cmd
+}
+
+// runCreateGuest executes the collection create-guest command.
+func runCreateGuest(ctx context.Context, profile, formatStr, endpointFQDN string,
+	collection *gcs.Collection, keywords string,
+	quiet bool, out interface{ Write([]byte) (int, error) }) error {
+	if quiet && output.Format(formatStr) != output.FormatText {
+		return fmt.Errorf("--quiet cannot be combined with --format %s", formatStr)
+	}
+
+	// Load token
+	token, err := cli.LoadToken(profile)
+	if err != nil {
+		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+
+	// Check if token is valid
+	if !token.IsValid() {
+		return fmt.Errorf("token expired, please login again")
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions(output.WithQuiet(quiet))...)
+
+	// Create GCS client
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("create GCS client: %w", err)
+	}
+
+	guest := *collection
+	if keywords != "" {
+		for _, kw := range strings.Split(keywords, ",") {
+			guest.Keywords = append(guest.Keywords, strings.TrimSpace(kw))
+		}
+	}
+
+	// Warn about subscription limits the request would exceed
+	if limits, err := gcsClient.GetLimits(ctx); err == nil {
+		for _, problem := range limits.CheckCollectionCreate(gcs.CollectionTypeGuest) {
+			cli.Warnf("%v (see 'endpoint limits')", problem)
+		}
+	}
+
+	created, err := gcsClient.CreateGuestCollection(ctx, &guest)
+	if err != nil {
+		return fmt.Errorf("create guest collection: %w", err)
+	}
+
+	if formatter.IsQuiet() {
+		return formatter.PrintIDs(created.ID)
+	}
+
+	if formatter.IsStructured() {
+		return formatter.PrintData(created)
+	}
+
+	return printCreatedCollection(formatter, created)
+}
@@ -0,0 +1,84 @@
+package collection
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs/gcstest"
+)
+
+func TestNewCreateGuestCmd_Flags(t *testing.T) {
+	cmd := NewCreateGuestCmd()
+
+	required := []string{"endpoint", "mapped-collection-id", "user-credential-id", "display-name"}
+	for _, name := range required {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			t.Fatalf("flag %q not found", name)
+		}
+		if flag.Annotations["cobra_annotation_bash_completion_one_required_flag"] == nil {
+			t.Errorf("flag %q is not required", name)
+		}
+	}
+
+	if flag := cmd.Flags().Lookup("collection-base-path"); flag == nil || flag.DefValue != "/" {
+		t.Errorf("collection-base-path flag = %+v, want default /", flag)
+	}
+	if cmd.Flags().Lookup("storage-gateway-id") != nil {
+		t.Error("create-guest has a storage-gateway-id flag; the gateway is the mapped collection's")
+	}
+}
+
+func TestRunCreate_GuestType(t *testing.T) {
+	err := runCreate(context.Background(), "nonexistent-profile-test", "text", "test.example.org",
+		"Share", "gw-1", "/", gcs.CollectionTypeGuest, "", false, false,
+		"", "", "", "", "", "", "", "", "", nil, false, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "collection create-guest") {
+		t.Errorf("runCreate() error = %v, want a pointer to create-guest", err)
+	}
+}
+
+func TestRunCreateGuest_MockClient(t *testing.T) {
+	saveMockToken(t)
+
+	client := &gcstest.Client{
+		CreateGuestCollectionFunc: func(_ context.Context, guest *gcs.Collection) (*gcs.Collection, error) {
+			created := *guest
+			created.ID = "g1"
+			created.CollectionType = gcs.CollectionTypeGuest
+			return &created, nil
+		},
+	}
+	factory := cli.GCSClientFactory
+	cli.GCSClientFactory = func(string, string) (gcs.GCSAPI, error) { return client, nil }
+	t.Cleanup(func() { cli.GCSClientFactory = factory })
+
+	guest := &gcs.Collection{
+		DisplayName:          "Share",
+		MappedCollectionID:   "m1",
+		UserCredentialID:     "cred-1",
+		CollectionBaseFolder: "/projects",
+	}
+	buf := &bytes.Buffer{}
+	if err := runCreateGuest(context.Background(), "mock", "text", "test.example.org", guest, "a, b", false, buf); err != nil {
+		t.Fatalf("runCreateGuest() error = %v", err)
+	}
+
+	for _, want := range []string{"g1", "guest", "Mapped Collection:  m1", "/projects"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+	calls := client.CallsTo("CreateGuestCollection")
+	if len(calls) != 1 {
+		t.Fatalf("CreateGuestCollection called %d times, want 1", len(calls))
+	}
+	sent := calls[0].Args[1].(*gcs.Collection)
+	if strings.Join(sent.Keywords, "|") != "a|b" {
+		t.Errorf("keywords = %q, want a, b", sent.Keywords)
+	}
+}
@@ -3,7 +3,7 @@ package collection
 import (
 	"testing"
 
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 )
 
 func TestNewCreateCmd(t *testing.T) {
@@ -4,9 +4,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -40,7 +39,7 @@ Requires an active authentication session (use 'login' first).`,
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 
 	_ = cmd.MarkFlagRequired("endpoint")
@@ -51,7 +50,7 @@ Requires an active authentication session (use 'login' first).`,
 // runDelete executes the collection delete command.
 func runDelete(ctx context.Context, profile, formatStr, endpointFQDN, collectionID string, out interface{ Write([]byte) (int, error) }) error {
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -62,13 +61,10 @@ func runDelete(ctx context.Context, profile, formatStr, endpointFQDN, collection
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -79,13 +75,13 @@ func runDelete(ctx context.Context, profile, formatStr, endpointFQDN, collection
 	}
 
 	// Output based on format
-	if formatter.IsJSON() {
+	if formatter.IsStructured() {
 		result := map[string]string{
 			"status":        "success",
 			"collection_id": collectionID,
 			"message":       "Collection deleted successfully",
 		}
-		return formatter.PrintJSON(result)
+		return formatter.PrintData(result)
 	}
 
 	// Text format
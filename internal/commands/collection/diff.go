@@ -0,0 +1,235 @@
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// diffExitDrift is the exit status of 'collection diff' when the live
+// collection differs from the file. Errors exit 1.
+const diffExitDrift = 2
+
+// Kinds of field difference reported by 'collection diff'.
+const (
+	fieldAdded   = "added"
+	fieldChanged = "changed"
+	fieldRemoved = "removed"
+)
+
+// fieldDiff is one field that differs between the live collection and a
+// collection file. Nested fields, such as those of policies, are named by
+// their path (e.g., policies.authentication_timeout_mins).
+type fieldDiff struct {
+	Field  string `json:"field"`
+	Change string `json:"change"`
+
+	// Live is the value in the live collection, nil for added fields.
+	Live interface{} `json:"live"`
+
+	// Desired is the value in the file, nil for removed fields.
+	Desired interface{} `json:"desired"`
+}
+
+// diffResult is the output of 'collection diff'.
+type diffResult struct {
+	CollectionID string      `json:"collection_id"`
+	File         string      `json:"file"`
+	Drift        bool        `json:"drift"`
+	Fields       []fieldDiff `json:"fields"`
+}
+
+// NewDiffCmd creates the collection diff command.
+func NewDiffCmd() *cobra.Command {
+	var (
+		profile      string
+		format       string
+		endpointFQDN string
+		fromFile     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff COLLECTION_ID",
+		Short: "Compare a collection to a collection file",
+		Long: `Show how a live collection differs from the definition in a file written by
+'collection export' (use - for stdin), field by field.
+
+Fields in the file but not the collection are listed as added, fields
+whose values differ as changed, and fields of the collection missing from
+the file as removed. Fields of policies and other nested blocks are
+compared one by one. Fields set by the server, such as the ID and
+timestamps, are not compared. 'collection update --from-file' applies the
+added and changed fields; it leaves removed fields as they are.
+
+The command exits 0 when the collection matches the file and 2 when it
+has drifted, so it can gate a review or CI job.
+
+Example:
+  globus-connect-server collection diff abc123 --from-file collection.yaml \
+    --endpoint example.data.globus.org
+
+Requires an active authentication session (use 'login' first).`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(cmd.Context(), profile, format, endpointFQDN, args[0], fromFile,
+				cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	cmd.Flags().StringVar(&fromFile, fromFileFlag, "", "Collection file to compare with, written by 'collection export' (- for stdin)")
+
+	_ = cmd.MarkFlagRequired("endpoint")
+	_ = cmd.MarkFlagRequired(fromFileFlag)
+
+	return cmd
+}
+
+// runDiff executes the collection diff command.
+func runDiff(ctx context.Context, profile, formatStr, endpointFQDN, collectionID, path string,
+	in io.Reader, out io.Writer) error {
+	desired, err := loadCollectionFile(path, in)
+	if err != nil {
+		return err
+	}
+
+	// Load token
+	token, err := cli.LoadToken(profile)
+	if err != nil {
+		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+
+	// Check if token is valid
+	if !token.IsValid() {
+		return fmt.Errorf("token expired, please login again")
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	// Create GCS client
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("create GCS client: %w", err)
+	}
+
+	live, err := gcsClient.GetCollectionDocument(ctx, collectionID, gcs.IncludePrivatePolicies)
+	if err != nil {
+		return fmt.Errorf("get collection: %w", err)
+	}
+	removeServerAssignedFields(live)
+
+	// DATA_TYPE versions the document rather than setting anything
+	delete(live, "DATA_TYPE")
+	delete(desired, "DATA_TYPE")
+
+	if path == "-" {
+		path = "standard input"
+	}
+	result := &diffResult{
+		CollectionID: collectionID,
+		File:         path,
+		Fields:       diffFields("", live, desired),
+	}
+	result.Drift = len(result.Fields) > 0
+
+	if formatter.IsStructured() {
+		err = formatter.PrintData(result)
+	} else {
+		err = formatDiff(formatter, result)
+	}
+	if err != nil {
+		return err
+	}
+
+	if result.Drift {
+		return &cli.ExitError{Code: diffExitDrift}
+	}
+	return nil
+}
+
+// diffFields returns the fields that differ between live and desired,
+// sorted by name. Nested objects are compared field by field, with names
+// prefixed by prefix; other values, including lists, are compared whole.
+func diffFields(prefix string, live, desired map[string]interface{}) []fieldDiff {
+	diffs := []fieldDiff{}
+	for key, want := range desired {
+		name := prefix + key
+		have, ok := live[key]
+		if !ok {
+			diffs = append(diffs, fieldDiff{Field: name, Change: fieldAdded, Desired: want})
+			continue
+		}
+		haveObject, haveIsObject := have.(map[string]interface{})
+		wantObject, wantIsObject := want.(map[string]interface{})
+		switch {
+		case haveIsObject && wantIsObject:
+			diffs = append(diffs, diffFields(name+".", haveObject, wantObject)...)
+		case !reflect.DeepEqual(have, want):
+			diffs = append(diffs, fieldDiff{Field: name, Change: fieldChanged, Live: have, Desired: want})
+		}
+	}
+	for key, have := range live {
+		if _, ok := desired[key]; !ok {
+			diffs = append(diffs, fieldDiff{Field: prefix + key, Change: fieldRemoved, Live: have})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Field < diffs[j].Field })
+	return diffs
+}
+
+// formatDiff prints the result of 'collection diff' in text format.
+func formatDiff(formatter *output.Formatter, result *diffResult) error {
+	if !result.Drift {
+		return formatter.PrintText("%s Collection %s matches %s\n",
+			formatter.Success("✓"), result.CollectionID, result.File)
+	}
+
+	if err := formatter.PrintText("Collection %s differs from %s in %d field(s):\n",
+		result.CollectionID, result.File, len(result.Fields)); err != nil {
+		return err
+	}
+
+	styles := map[string]func(string) string{
+		fieldAdded:   formatter.Success,
+		fieldChanged: formatter.Warning,
+		fieldRemoved: formatter.Failure,
+	}
+	for _, d := range result.Fields {
+		var value string
+		switch d.Change {
+		case fieldAdded:
+			value = diffValue(d.Desired)
+		case fieldRemoved:
+			value = diffValue(d.Live)
+		default:
+			value = diffValue(d.Live) + " → " + diffValue(d.Desired)
+		}
+		label := styles[d.Change](fmt.Sprintf("%-8s", d.Change))
+		if err := formatter.PrintText("  %s %s: %s\n", label, d.Field, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffValue renders a document value on one line.
+func diffValue(value interface{}) string {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(body)
+}
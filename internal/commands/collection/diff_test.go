@@ -0,0 +1,126 @@
+package collection
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs/gcstest"
+)
+
+func TestDiffFields(t *testing.T) {
+	live := map[string]interface{}{
+		"display_name": "Alpha",
+		"public":       false,
+		"user_message": "hello",
+		"keywords":     []interface{}{"a", "b"},
+		"policies": map[string]interface{}{
+			"authentication_timeout_mins": float64(60),
+			"sharing_groups_allow":        []interface{}{"g-1"},
+		},
+	}
+	desired := map[string]interface{}{
+		"display_name": "Alpha",
+		"public":       true,
+		"description":  "New",
+		"keywords":     []interface{}{"a", "b"},
+		"policies": map[string]interface{}{
+			"authentication_timeout_mins": float64(30),
+			"sharing_groups_allow":        []interface{}{"g-1"},
+			"sharing_restrict":            "private",
+		},
+	}
+
+	want := []fieldDiff{
+		{Field: "description", Change: fieldAdded, Desired: "New"},
+		{Field: "policies.authentication_timeout_mins", Change: fieldChanged, Live: float64(60), Desired: float64(30)},
+		{Field: "policies.sharing_restrict", Change: fieldAdded, Desired: "private"},
+		{Field: "public", Change: fieldChanged, Live: false, Desired: true},
+		{Field: "user_message", Change: fieldRemoved, Live: "hello"},
+	}
+	if got := diffFields("", live, desired); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffFields() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestRunDiff(t *testing.T) {
+	client := &gcstest.Client{
+		GetCollectionDocumentFunc: getExportedDocument,
+	}
+	useMockCollectionClient(t, client)
+
+	matching := `DATA_TYPE: collection#1.5.0
+display_name: Project Alpha
+collection_type: mapped
+storage_gateway_id: gw-1
+collection_base_path: /data/alpha/
+public: false
+allow_guest_collections: true
+disable_anonymous_writes: false
+keywords: [alpha, physics]
+sharing_restrict_paths:
+  DATA_TYPE: path_restrictions#1.0.0
+  read: [/public/]
+policies:
+  DATA_TYPE: posix_collection_policies#1.0.0
+  authentication_timeout_mins: 60
+  sharing_groups_allow: [g-1]
+`
+
+	t.Run("matches", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		if err := runDiff(context.Background(), "mock", "text", "test.example.org", "c1", "-",
+			strings.NewReader(matching), buf); err != nil {
+			t.Fatalf("runDiff() error = %v", err)
+		}
+		if !strings.Contains(buf.String(), "Collection c1 matches standard input") {
+			t.Errorf("output = %q, want a match", buf.String())
+		}
+	})
+
+	t.Run("drifted", func(t *testing.T) {
+		drifted := strings.Replace(matching, "authentication_timeout_mins: 60", "authentication_timeout_mins: 30", 1)
+		drifted = strings.Replace(drifted, "public: false\n", "", 1)
+
+		buf := &bytes.Buffer{}
+		err := runDiff(context.Background(), "mock", "text", "test.example.org", "c1", "-",
+			strings.NewReader(drifted), buf)
+		var exitErr *cli.ExitError
+		if !errors.As(err, &exitErr) || exitErr.Code != diffExitDrift || exitErr.Err != nil {
+			t.Fatalf("runDiff() error = %v, want status-only exit %d", err, diffExitDrift)
+		}
+		for _, want := range []string{
+			"differs from standard input in 2 field(s)",
+			"changed  policies.authentication_timeout_mins: 60 → 30",
+			"removed  public: false",
+		} {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("output missing %q:\n%s", want, buf.String())
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		drifted := strings.Replace(matching, "public: false", "public: true", 1)
+
+		buf := &bytes.Buffer{}
+		err := runDiff(context.Background(), "mock", "json", "test.example.org", "c1", "-",
+			strings.NewReader(drifted), buf)
+		if err == nil {
+			t.Fatal("runDiff() error = nil, want drift")
+		}
+		var result diffResult
+		if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+			t.Fatalf("unmarshal output: %v\n%s", err, buf.String())
+		}
+		want := []fieldDiff{{Field: "public", Change: fieldChanged, Live: false, Desired: true}}
+		if !result.Drift || !reflect.DeepEqual(result.Fields, want) {
+			t.Errorf("result = %+v, want drift in %+v", result, want)
+		}
+	})
+}
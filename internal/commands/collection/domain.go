@@ -4,8 +4,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -78,7 +78,7 @@ Requires an active authentication session (use 'login' first).`,
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 	cmd.Flags().StringVar(&domain, "domain", "", "Custom domain name")
 	cmd.Flags().StringVar(&certificate, "certificate", "", "Path to SSL certificate file")
@@ -95,7 +95,7 @@ Requires an active authentication session (use 'login' first).`,
 // runDomainSetup executes the collection domain setup command.
 func runDomainSetup(ctx context.Context, profile, formatStr, endpointFQDN, collectionID, domain, certificate, privateKey string, out interface{ Write([]byte) (int, error) }) error {
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -106,13 +106,10 @@ func runDomainSetup(ctx context.Context, profile, formatStr, endpointFQDN, colle
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -130,14 +127,14 @@ func runDomainSetup(ctx context.Context, profile, formatStr, endpointFQDN, colle
 	}
 
 	// Output based on format
-	if formatter.IsJSON() {
+	if formatter.IsStructured() {
 		result := map[string]string{
 			"status":        "success",
 			"collection_id": collectionID,
 			"domain":        domain,
 			"message":       "Collection domain configured successfully",
 		}
-		return formatter.PrintJSON(result)
+		return formatter.PrintData(result)
 	}
 
 	// Text format
@@ -182,7 +179,7 @@ Requires an active authentication session (use 'login' first).`,
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 
 	_ = cmd.MarkFlagRequired("endpoint")
@@ -193,7 +190,7 @@ Requires an active authentication session (use 'login' first).`,
 // runDomainShow executes the collection domain show command.
 func runDomainShow(ctx context.Context, profile, formatStr, endpointFQDN, collectionID string, out interface{ Write([]byte) (int, error) }) error {
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -204,13 +201,10 @@ func runDomainShow(ctx context.Context, profile, formatStr, endpointFQDN, collec
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -222,8 +216,8 @@ func runDomainShow(ctx context.Context, profile, formatStr, endpointFQDN, collec
 	}
 
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(domainConfig)
+	if formatter.IsStructured() {
+		return formatter.PrintData(domainConfig)
 	}
 
 	// Text format
@@ -292,7 +286,7 @@ Requires an active authentication session (use 'login' first).`,
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt")
 
@@ -304,7 +298,7 @@ Requires an active authentication session (use 'login' first).`,
 // runDomainDelete executes the collection domain delete command.
 func runDomainDelete(ctx context.Context, profile, formatStr, endpointFQDN, collectionID string, force bool, out interface{ Write([]byte) (int, error) }) error {
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -315,7 +309,7 @@ func runDomainDelete(ctx context.Context, profile, formatStr, endpointFQDN, coll
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Confirmation prompt (unless --force)
 	if !force {
@@ -335,10 +329,7 @@ func runDomainDelete(ctx context.Context, profile, formatStr, endpointFQDN, coll
 	}
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -349,13 +340,13 @@ func runDomainDelete(ctx context.Context, profile, formatStr, endpointFQDN, coll
 	}
 
 	// Output based on format
-	if formatter.IsJSON() {
+	if formatter.IsStructured() {
 		result := map[string]string{
 			"status":        "success",
 			"collection_id": collectionID,
 			"message":       "Collection domain configuration removed successfully",
 		}
-		return formatter.PrintJSON(result)
+		return formatter.PrintData(result)
 	}
 
 	// Text format
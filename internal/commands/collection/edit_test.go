@@ -0,0 +1,42 @@
+package collection
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestNewEditCmd(t *testing.T) {
+	cmd := NewEditCmd()
+
+	if cmd.Use != "edit COLLECTION_ID" {
+		t.Errorf("NewEditCmd() Use = %q, want %q", cmd.Use, "edit COLLECTION_ID")
+	}
+
+	if cmd.Short == "" {
+		t.Error("NewEditCmd() Short description is empty")
+	}
+
+	if cmd.RunE == nil {
+		t.Error("NewEditCmd() RunE is nil")
+	}
+
+	for _, name := range []string{"profile", "format", "endpoint"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("flag %q not found", name)
+		}
+	}
+}
+
+func TestRunEdit_NoToken(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	err := runEdit(context.Background(), "nonexistent-profile-test", "text", "test.example.org", "col-1", buf)
+	if err == nil {
+		t.Error("runEdit() expected error for nonexistent profile, got nil")
+	}
+
+	if buf.Len() > 0 {
+		t.Errorf("runEdit() wrote to buffer on error: %q", buf.String())
+	}
+}
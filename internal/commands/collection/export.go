@@ -0,0 +1,165 @@
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+// serverAssignedFields are collection document fields set by the server.
+// 'collection export' leaves them out, and --from-file ignores them, so
+// that a collection file can create a copy of the collection or be applied
+// to it.
+var serverAssignedFields = []string{
+	"id",
+	"connector_id",
+	"created_timestamp",
+	"deleted",
+	"https_url",
+	"last_access",
+	"last_modified",
+	"manager_url",
+	"tlsftp_url",
+}
+
+// NewExportCmd creates the collection export command.
+func NewExportCmd() *cobra.Command {
+	var (
+		profile      string
+		endpointFQDN string
+		outputFile   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export COLLECTION_ID",
+		Short: "Export a collection as a YAML file",
+		Long: `Write a collection's definition as a YAML file that can be kept in version
+control and applied with 'collection create --from-file' or
+'collection update --from-file'.
+
+The file holds every field of the collection as the GCS Manager API
+returns it, including its policies, except fields set by the server such
+as its ID and timestamps.
+
+Examples:
+  globus-connect-server collection export abc123 -o collection.yaml \
+    --endpoint example.data.globus.org
+  globus-connect-server collection create --from-file collection.yaml \
+    --endpoint other.data.globus.org
+
+Requires an active authentication session (use 'login' first).`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(cmd.Context(), profile, endpointFQDN, args[0], outputFile, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Write the collection to a file instead of stdout")
+
+	_ = cmd.MarkFlagRequired("endpoint")
+
+	return cmd
+}
+
+// runExport executes the collection export command.
+func runExport(ctx context.Context, profile, endpointFQDN, collectionID, outputFile string, out io.Writer) error {
+	// Load token
+	token, err := cli.LoadToken(profile)
+	if err != nil {
+		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+
+	// Check if token is valid
+	if !token.IsValid() {
+		return fmt.Errorf("token expired, please login again")
+	}
+
+	// Create GCS client
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("create GCS client: %w", err)
+	}
+
+	doc, err := gcsClient.GetCollectionDocument(ctx, collectionID, gcs.IncludePrivatePolicies)
+	if err != nil {
+		return fmt.Errorf("get collection: %w", err)
+	}
+	removeServerAssignedFields(doc)
+
+	body, err := yaml.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("encode collection: %w", err)
+	}
+	header := fmt.Sprintf("# Collection %s exported from %s.\n"+
+		"# Apply with 'collection create --from-file' or 'collection update --from-file'.\n\n",
+		collectionID, endpointFQDN)
+
+	if outputFile == "" {
+		_, err := io.WriteString(out, header+string(body))
+		return err
+	}
+
+	if err := os.WriteFile(outputFile, []byte(header+string(body)), 0644); err != nil { //nolint:gosec // Collection definitions hold no secrets
+		return fmt.Errorf("write %s: %w", outputFile, err)
+	}
+	_, err = fmt.Fprintf(out, "Wrote collection %s to %s\n", collectionID, outputFile)
+	return err
+}
+
+// loadCollectionFile reads a collection file written by 'collection
+// export', or a YAML or JSON document in the same form, from path, or from
+// in if path is "-". Values are decoded as encoding/json would decode
+// them, so that they compare equal to a fetched document, and
+// server-assigned fields are removed.
+func loadCollectionFile(path string, in io.Reader) (map[string]interface{}, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		path = "standard input"
+		data, err = io.ReadAll(in)
+	} else {
+		data, err = os.ReadFile(path) //nolint:gosec // The file named by the user
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read collection file: %w", err)
+	}
+
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse collection file %s: %w", path, err)
+	}
+	if len(doc) == 0 {
+		return nil, fmt.Errorf("collection file %s holds no fields", path)
+	}
+
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("parse collection file %s: %w", path, err)
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(body, &normalized); err != nil {
+		return nil, fmt.Errorf("parse collection file %s: %w", path, err)
+	}
+
+	removeServerAssignedFields(normalized)
+	return normalized, nil
+}
+
+// removeServerAssignedFields deletes the serverAssignedFields from doc.
+func removeServerAssignedFields(doc map[string]interface{}) {
+	for _, field := range serverAssignedFields {
+		delete(doc, field)
+	}
+}
@@ -0,0 +1,233 @@
+package collection
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs/gcstest"
+	"github.com/spf13/cobra"
+)
+
+// exportedDocument returns a collection document as the server returns
+// it, with server-assigned fields, policies, and values that the typed
+// Collection would drop.
+func exportedDocument() map[string]interface{} {
+	return map[string]interface{}{
+		"DATA_TYPE":                "collection#1.6.0",
+		"id":                       "c1",
+		"created_timestamp":        "2026-01-02T03:04:05Z",
+		"https_url":                "https://g-1.data.globus.org",
+		"display_name":             "Project Alpha",
+		"collection_type":          "mapped",
+		"storage_gateway_id":       "gw-1",
+		"collection_base_path":     "/data/alpha/",
+		"public":                   false,
+		"allow_guest_collections":  true,
+		"disable_anonymous_writes": false,
+		"keywords":                 []interface{}{"alpha", "physics"},
+		"sharing_restrict_paths": map[string]interface{}{
+			"DATA_TYPE": "path_restrictions#1.0.0",
+			"read":      []interface{}{"/public/"},
+		},
+		"policies": map[string]interface{}{
+			"DATA_TYPE":                   "posix_collection_policies#1.0.0",
+			"authentication_timeout_mins": float64(60),
+			"sharing_groups_allow":        []interface{}{"g-1"},
+		},
+	}
+}
+
+// getExportedDocument returns exportedDocument as GetCollectionDocument
+// does: like the GCS Manager API, without policies unless they are asked
+// for.
+func getExportedDocument(_ context.Context, _ string, include ...string) (map[string]interface{}, error) {
+	doc := exportedDocument()
+	if !slices.Contains(include, gcs.IncludePrivatePolicies) {
+		delete(doc, "policies")
+	}
+	return doc, nil
+}
+
+// useMockCollectionClient logs in the profile "mock" and makes the
+// commands use client.
+func useMockCollectionClient(t *testing.T, client *gcstest.Client) {
+	t.Helper()
+	saveMockToken(t)
+
+	factory := cli.GCSClientFactory
+	cli.GCSClientFactory = func(string, string) (gcs.GCSAPI, error) { return client, nil }
+	t.Cleanup(func() { cli.GCSClientFactory = factory })
+}
+
+func TestCollectionFile_RoundTrip(t *testing.T) {
+	client := &gcstest.Client{
+		GetCollectionDocumentFunc: getExportedDocument,
+		CreateCollectionDocumentFunc: func(context.Context, map[string]interface{}) (*gcs.Collection, error) {
+			return &gcs.Collection{ID: "c2", DisplayName: "Project Alpha"}, nil
+		},
+	}
+	useMockCollectionClient(t, client)
+
+	path := filepath.Join(t.TempDir(), "collection.yaml")
+	buf := &bytes.Buffer{}
+	if err := runExport(context.Background(), "mock", "test.example.org", "c1", path, buf); err != nil {
+		t.Fatalf("runExport() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "Wrote collection c1 to "+path) {
+		t.Errorf("output = %q, want the file written", buf.String())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{"id:", "created_timestamp", "https_url"} {
+		if strings.Contains(string(data), "\n"+field) {
+			t.Errorf("export holds server-assigned field %s:\n%s", field, data)
+		}
+	}
+
+	if err := runCreateFromFile(context.Background(), "mock", "text", "test.example.org", path,
+		false, nil, &bytes.Buffer{}); err != nil {
+		t.Fatalf("runCreateFromFile() error = %v", err)
+	}
+	want := exportedDocument()
+	removeServerAssignedFields(want)
+	sent := client.CallsTo("CreateCollectionDocument")[0].Args[1].(map[string]interface{})
+	if !reflect.DeepEqual(sent, want) {
+		t.Errorf("created from\n%v\nwant\n%v", sent, want)
+	}
+
+	buf.Reset()
+	if err := runUpdateFromFile(context.Background(), "mock", "text", "test.example.org", "c1", path,
+		nil, buf); err != nil {
+		t.Fatalf("runUpdateFromFile() error = %v", err)
+	}
+	if len(client.CallsTo("PatchCollection")) != 0 {
+		t.Error("update from an unchanged export sent a patch")
+	}
+	if !strings.Contains(buf.String(), "No changes made.") {
+		t.Errorf("output = %q, want no changes", buf.String())
+	}
+}
+
+func TestRunUpdateFromFile_SendsChangedFields(t *testing.T) {
+	client := &gcstest.Client{
+		GetCollectionDocumentFunc: getExportedDocument,
+		PatchCollectionFunc: func(context.Context, string, map[string]interface{}) error {
+			return nil
+		},
+	}
+	useMockCollectionClient(t, client)
+
+	file := `DATA_TYPE: collection#1.7.0
+id: other
+display_name: Project Alpha
+public: true
+policies:
+  DATA_TYPE: posix_collection_policies#1.0.0
+  authentication_timeout_mins: 30
+  sharing_groups_allow: [g-1]
+`
+	buf := &bytes.Buffer{}
+	if err := runUpdateFromFile(context.Background(), "mock", "text", "test.example.org", "c1", "-",
+		strings.NewReader(file), buf); err != nil {
+		t.Fatalf("runUpdateFromFile() error = %v", err)
+	}
+
+	calls := client.CallsTo("PatchCollection")
+	if len(calls) != 1 {
+		t.Fatalf("PatchCollection called %d times, want 1", len(calls))
+	}
+	want := map[string]interface{}{
+		"public": true,
+		"policies": map[string]interface{}{
+			"DATA_TYPE":                   "posix_collection_policies#1.0.0",
+			"authentication_timeout_mins": float64(30),
+			"sharing_groups_allow":        []interface{}{"g-1"},
+		},
+	}
+	if got := calls[0].Args[2]; !reflect.DeepEqual(got, want) {
+		t.Errorf("patch = %v, want %v", got, want)
+	}
+	if !strings.Contains(buf.String(), "public: false → true") {
+		t.Errorf("output missing the changed field:\n%s", buf.String())
+	}
+}
+
+func TestLoadCollectionFile_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "empty", content: "# nothing\n", wantErr: "holds no fields"},
+		{name: "not a mapping", content: "- a\n- b\n", wantErr: "parse collection file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadCollectionFile("-", strings.NewReader(tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("loadCollectionFile() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFromFile_FlagGroups(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     func() *cobra.Command
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "create with a field flag",
+			cmd:     NewCreateCmd,
+			args:    []string{"--from-file", "c.yaml", "--display-name", "x"},
+			wantErr: "none of the others can be",
+		},
+		{
+			name:    "create without a file or required flags",
+			cmd:     NewCreateCmd,
+			args:    []string{"--display-name", "x"},
+			wantErr: "at least one of the flags",
+		},
+		{
+			name: "create from a file",
+			cmd:  NewCreateCmd,
+			args: []string{"--from-file", "c.yaml", "--quiet"},
+		},
+		{
+			name:    "update with a policy flag",
+			cmd:     NewUpdateCmd,
+			args:    []string{"--from-file", "c.yaml", "--sharing-restrict", "private"},
+			wantErr: "none of the others can be",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := tt.cmd()
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			err := cmd.ValidateFlagGroups()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateFlagGroups() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateFlagGroups() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
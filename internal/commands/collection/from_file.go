@@ -0,0 +1,160 @@
+package collection
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// fromFileFlag names the flag that reads a collection from a file written
+// by 'collection export'.
+const fromFileFlag = "from-file"
+
+// fromFileCompatible are the flags that may be combined with --from-file;
+// every other flag sets a collection field, which the file sets instead.
+var fromFileCompatible = map[string]bool{
+	fromFileFlag: true,
+	"endpoint":   true,
+	"format":     true,
+	"profile":    true,
+	"quiet":      true,
+}
+
+// markFromFileExclusive makes --from-file mutually exclusive with the
+// collection field flags of cmd, and makes required the flags that must be
+// given when --from-file is not. It must be called after the field flags
+// are added.
+func markFromFileExclusive(cmd *cobra.Command, required ...string) {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if !fromFileCompatible[flag.Name] {
+			cmd.MarkFlagsMutuallyExclusive(fromFileFlag, flag.Name)
+		}
+	})
+	for _, name := range required {
+		cmd.MarkFlagsOneRequired(fromFileFlag, name)
+	}
+}
+
+// runCreateFromFile creates a collection from a collection file.
+func runCreateFromFile(ctx context.Context, profile, formatStr, endpointFQDN, path string,
+	quiet bool, in io.Reader, out io.Writer) error {
+	if quiet && output.Format(formatStr) != output.FormatText {
+		return fmt.Errorf("--quiet cannot be combined with --format %s", formatStr)
+	}
+
+	doc, err := loadCollectionFile(path, in)
+	if err != nil {
+		return err
+	}
+
+	// Load token
+	token, err := cli.LoadToken(profile)
+	if err != nil {
+		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+
+	// Check if token is valid
+	if !token.IsValid() {
+		return fmt.Errorf("token expired, please login again")
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions(output.WithQuiet(quiet))...)
+
+	// Create GCS client
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("create GCS client: %w", err)
+	}
+
+	// Warn about subscription limits the request would exceed
+	collectionType, _ := doc["collection_type"].(string)
+	if limits, err := gcsClient.GetLimits(ctx); err == nil {
+		for _, problem := range limits.CheckCollectionCreate(collectionType) {
+			cli.Warnf("%v (see 'endpoint limits')", problem)
+		}
+	}
+
+	created, err := gcsClient.CreateCollectionDocument(ctx, doc)
+	if err != nil {
+		return fmt.Errorf("create collection: %w", err)
+	}
+
+	if formatter.IsQuiet() {
+		return formatter.PrintIDs(created.ID)
+	}
+	if formatter.IsStructured() {
+		return formatter.PrintData(created)
+	}
+	return printCreatedCollection(formatter, created)
+}
+
+// runUpdateFromFile updates a collection to match a collection file. Only
+// the fields of the file that differ from the collection are sent; fields
+// the file leaves out are not changed.
+func runUpdateFromFile(ctx context.Context, profile, formatStr, endpointFQDN, collectionID, path string,
+	in io.Reader, out io.Writer) error {
+	doc, err := loadCollectionFile(path, in)
+	if err != nil {
+		return err
+	}
+
+	// Load token
+	token, err := cli.LoadToken(profile)
+	if err != nil {
+		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+
+	// Check if token is valid
+	if !token.IsValid() {
+		return fmt.Errorf("token expired, please login again")
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	// Create GCS client
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("create GCS client: %w", err)
+	}
+
+	current, err := gcsClient.GetCollectionDocument(ctx, collectionID, gcs.IncludePrivatePolicies)
+	if err != nil {
+		return fmt.Errorf("get collection: %w", err)
+	}
+
+	changes := map[string]interface{}{}
+	for key, value := range doc {
+		// DATA_TYPE versions the document rather than setting anything
+		if key == "DATA_TYPE" {
+			continue
+		}
+		if old, ok := current[key]; !ok || !reflect.DeepEqual(old, value) {
+			changes[key] = value
+		}
+	}
+	if len(changes) == 0 {
+		if formatter.IsStructured() {
+			return formatter.PrintData(changes)
+		}
+		return formatter.Println("No changes made.")
+	}
+
+	name := "collection " + collectionID
+	if err := gcsClient.PatchCollection(ctx, collectionID, changes); err != nil {
+		if gcs.IsPreconditionFailed(err) {
+			return fmt.Errorf("%s was changed by someone else while the update was prepared; run the command again: %w", name, err)
+		}
+		return fmt.Errorf("update collection: %w", err)
+	}
+
+	return cli.PrintChanges(formatter, name, current, changes)
+}
@@ -4,9 +4,10 @@ package collection
 import (
 	"context"
 	"fmt"
+	"time"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -17,8 +18,12 @@ func NewListCmd() *cobra.Command {
 	var (
 		profile      string
 		format       string
+		quiet        bool
+		all          bool
 		endpointFQDN string
-		filter       string
+		columns      []string
+		opts         gcs.ListCollectionsOptions
+		times        listTimeFlags
 	)
 
 	cmd := &cobra.Command{
@@ -27,27 +32,116 @@ func NewListCmd() *cobra.Command {
 		Long: `List all collections configured on a Globus Connect Server endpoint.
 
 This command retrieves and displays all collections (both mapped and guest)
-on the specified endpoint. Collections can be filtered by name.
+on the specified endpoint. Collections can be filtered by name, storage
+gateway, type, visibility, and creation or modification time. Times are
+dates (YYYY-MM-DD), RFC 3339 times, or durations before now like 72h.
+
+Only the first page of results is shown unless --all is given. Use
+--page-size to choose the page length and --marker to continue from the
+marker of an earlier page; with --all, listing resumes from that page.
 
 Requires an active authentication session (use 'login' first).`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runList(cmd.Context(), profile, format, endpointFQDN, filter, cmd.OutOrStdout())
+			if err := times.apply(&opts, time.Now()); err != nil {
+				return err
+			}
+			return runList(cmd.Context(), profile, format, endpointFQDN, opts, columns, quiet, all, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml, table, wide, csv)")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Only print IDs, one per line")
+	cmd.Flags().BoolVar(&all, "all", false, "List every collection, following pagination, not just the first page")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
-	cmd.Flags().StringVar(&filter, "filter", "", "Filter collections by name")
+	cmd.Flags().StringSliceVar(&columns, "columns", nil, "Comma-separated columns to show, e.g. id,display_name (implies --format table)")
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Filter collections by name")
+	cmd.Flags().IntVar(&opts.PageSize, "page-size", 0, "Number of collections per page (default: the endpoint's)")
+	cmd.Flags().StringVar(&opts.Marker, "marker", "", "Start at the page of this pagination marker")
+	cmd.Flags().StringVar(&opts.StorageGatewayID, "storage-gateway", "", "Only list collections on this storage gateway")
+	cli.EnumVar(cmd, &opts.CollectionType, "type", "", "Only list collections of this type (mapped, guest)", "mapped", "guest")
+	publicStr := cli.Enum(cmd, "public", "", "Only list public (true) or private (false) collections", cli.BoolChoices...)
+	cmd.PreRunE = func(*cobra.Command, []string) error {
+		if *publicStr != "" {
+			public := *publicStr == "true"
+			opts.Public = &public
+		}
+		return nil
+	}
+	cmd.Flags().StringVar(&times.createdAfter, "created-after", "", "Only list collections created after this time")
+	cmd.Flags().StringVar(&times.createdBefore, "created-before", "", "Only list collections created before this time")
+	cmd.Flags().StringVar(&times.modifiedAfter, "modified-after", "", "Only list collections modified after this time")
+	cmd.Flags().StringVar(&times.modifiedBefore, "modified-before", "", "Only list collections modified before this time")
 	_ = cmd.MarkFlagRequired("endpoint")
 
 	return cmd
 }
 
+// listTimeFlags holds the unparsed time filter flags of collection list.
+type listTimeFlags struct {
+	createdAfter, createdBefore   string
+	modifiedAfter, modifiedBefore string
+}
+
+// apply parses the time flags into opts.
+func (f listTimeFlags) apply(opts *gcs.ListCollectionsOptions, now time.Time) error {
+	for _, flag := range []struct {
+		name  string
+		value string
+		dst   *time.Time
+	}{
+		{"created-after", f.createdAfter, &opts.CreatedAfter},
+		{"created-before", f.createdBefore, &opts.CreatedBefore},
+		{"modified-after", f.modifiedAfter, &opts.ModifiedAfter},
+		{"modified-before", f.modifiedBefore, &opts.ModifiedBefore},
+	} {
+		if flag.value == "" {
+			continue
+		}
+		t, err := parseListTime(flag.value, now)
+		if err != nil {
+			return fmt.Errorf("invalid --%s %q: use YYYY-MM-DD, an RFC 3339 time, or a duration like 72h", flag.name, flag.value)
+		}
+		*flag.dst = t
+	}
+	return nil
+}
+
+// parseListTime parses a date (midnight UTC), an RFC 3339 time, or a
+// duration before now.
+func parseListTime(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("invalid time %q", value)
+	}
+	return now.Add(-d).UTC(), nil
+}
+
 // runList executes the collection list command.
-func runList(ctx context.Context, profile, formatStr, endpointFQDN, filter string, out interface{ Write([]byte) (int, error) }) error {
+func runList(ctx context.Context, profile, formatStr, endpointFQDN string, opts gcs.ListCollectionsOptions, columns []string, quiet, all bool, out interface{ Write([]byte) (int, error) }) error {
+	if opts.PageSize < 0 {
+		return fmt.Errorf("--page-size must not be negative")
+	}
+	if quiet && output.Format(formatStr) != output.FormatText {
+		return fmt.Errorf("--quiet cannot be combined with --format %s", formatStr)
+	}
+
+	// --columns implies table format
+	if len(columns) > 0 && output.Format(formatStr) == output.FormatText {
+		formatStr = string(output.FormatTable)
+	}
+	if len(columns) > 0 && !output.Format(formatStr).IsTabular() {
+		return fmt.Errorf("--columns requires --format table, wide, or csv")
+	}
+
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -58,32 +152,49 @@ func runList(ctx context.Context, profile, formatStr, endpointFQDN, filter strin
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions(output.WithColumns(columns), output.WithQuiet(quiet))...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
 
-	// Build list options
-	opts := &gcs.ListCollectionsOptions{}
-	if filter != "" {
-		opts.Filter = filter
-	}
-
 	// Get collections
-	list, err := gcsClient.ListCollections(ctx, opts)
+	list, err := listCollections(ctx, gcsClient, &opts, all)
 	if err != nil {
 		return fmt.Errorf("list collections: %w", err)
 	}
+	switch {
+	case list.HasNextPage && list.Marker != "":
+		cli.Warnf("the endpoint has more results than this page; use --all to list them all, or --marker %s for the next page", list.Marker)
+	case list.HasNextPage:
+		cli.Warnf("the endpoint has more results than this page; use --all to list them all")
+	}
+
+	if formatter.IsQuiet() {
+		ids := make([]string, 0, len(list.Data))
+		for _, item := range list.Data {
+			ids = append(ids, item.ID)
+		}
+		return formatter.PrintIDs(ids...)
+	}
 
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(list)
+	if formatter.IsStructured() {
+		return formatter.PrintData(list)
+	}
+
+	if formatter.IsTabular() {
+		table := output.NewTable("ID", "Display Name", "Type", "Storage Gateway ID", "Public")
+		table.AddWideColumns("Base Path", "Anonymous Writes Disabled", "Organization", "HTTPS URL")
+		for _, collection := range list.Data {
+			table.AddRow(collection.ID, collection.DisplayName, collection.CollectionType,
+				collection.StorageGatewayID, fmt.Sprint(collection.Public),
+				collection.CollectionBaseFolder, fmt.Sprint(collection.DisableAnonymousWrites),
+				collection.Organization, collection.HTTPSURL)
+		}
+		return formatter.PrintTable(table)
 	}
 
 	// Text format
@@ -126,3 +237,16 @@ func runList(ctx context.Context, profile, formatStr, endpointFQDN, filter strin
 
 	return nil
 }
+
+// listCollections returns the page of collections at opts.Marker, or with
+// all, that page and every one after it combined into one.
+func listCollections(ctx context.Context, client gcs.GCSAPI, opts *gcs.ListCollectionsOptions, all bool) (*gcs.CollectionList, error) {
+	if !all {
+		return client.ListCollections(ctx, opts)
+	}
+	items, err := client.ListAllCollections(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &gcs.CollectionList{Data: items, TotalResults: len(items)}, nil
+}
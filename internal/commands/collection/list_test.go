@@ -3,9 +3,13 @@ package collection
 import (
 	"bytes"
 	"context"
+	"strings"
 	"testing"
+	"time"
 
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs/gcstest"
 )
 
 func TestNewListCmd(t *testing.T) {
@@ -58,11 +62,28 @@ func TestNewListCmd_Flags(t *testing.T) {
 			flagName:  "endpoint",
 			shorthand: "",
 		},
+		{
+			name:         "all flag",
+			flagName:     "all",
+			shorthand:    "",
+			defaultValue: "false",
+		},
 		{
 			name:      "filter flag",
 			flagName:  "filter",
 			shorthand: "",
 		},
+		{
+			name:         "page-size flag",
+			flagName:     "page-size",
+			shorthand:    "",
+			defaultValue: "0",
+		},
+		{
+			name:      "marker flag",
+			flagName:  "marker",
+			shorthand: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -88,7 +109,7 @@ func TestRunList_NoToken(t *testing.T) {
 	buf := &bytes.Buffer{}
 
 	// Test with a profile that doesn't exist
-	err := runList(ctx, "nonexistent-profile-test", "text", "test.example.org", "", buf)
+	err := runList(ctx, "nonexistent-profile-test", "text", "test.example.org", gcs.ListCollectionsOptions{}, nil, false, false, buf)
 	if err == nil {
 		t.Error("runList() expected error for nonexistent profile, got nil")
 	}
@@ -98,3 +119,86 @@ func TestRunList_NoToken(t *testing.T) {
 		t.Errorf("runList() wrote to buffer on error: %q", buf.String())
 	}
 }
+
+func TestRunList_ColumnsRequireTabularFormat(t *testing.T) {
+	err := runList(context.Background(), "nonexistent-profile-test", "json", "test.example.org", gcs.ListCollectionsOptions{}, []string{"id"}, false, false, &bytes.Buffer{})
+	if err == nil || err.Error() != "--columns requires --format table, wide, or csv" {
+		t.Errorf("runList() error = %v, want --columns requires --format table, wide, or csv", err)
+	}
+}
+
+func TestRunList_QuietRequiresTextFormat(t *testing.T) {
+	err := runList(context.Background(), "nonexistent-profile-test", "json", "test.example.org", gcs.ListCollectionsOptions{}, nil, true, false, &bytes.Buffer{})
+	if err == nil || err.Error() != "--quiet cannot be combined with --format json" {
+		t.Errorf("runList() error = %v, want --quiet cannot be combined with --format json", err)
+	}
+}
+
+func TestRunList_NegativePageSize(t *testing.T) {
+	opts := gcs.ListCollectionsOptions{PageSize: -1}
+	err := runList(context.Background(), "nonexistent-profile-test", "text", "test.example.org", opts, nil, false, false, &bytes.Buffer{})
+	if err == nil || err.Error() != "--page-size must not be negative" {
+		t.Errorf("runList() error = %v, want --page-size must not be negative", err)
+	}
+}
+
+func TestListCollections_Page(t *testing.T) {
+	client := &gcstest.Client{
+		ListCollectionsFunc: func(_ context.Context, opts *gcs.ListCollectionsOptions) (*gcs.CollectionList, error) {
+			if opts.PageSize != 2 || opts.Marker != "m1" {
+				t.Errorf("ListCollections() opts = %+v, want page size 2 at marker m1", opts)
+			}
+			return &gcs.CollectionList{Data: []gcs.Collection{{ID: "c3"}, {ID: "c4"}}, HasNextPage: true, Marker: "m2"}, nil
+		},
+	}
+
+	list, err := listCollections(context.Background(), client, &gcs.ListCollectionsOptions{PageSize: 2, Marker: "m1"}, false)
+	if err != nil {
+		t.Fatalf("listCollections() error = %v", err)
+	}
+	if len(list.Data) != 2 || !list.HasNextPage || list.Marker != "m2" {
+		t.Errorf("listCollections() = %+v, want the page with its next marker", list)
+	}
+}
+
+func TestListTimeFlags(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	flags := listTimeFlags{
+		createdAfter:   "2026-01-02",
+		createdBefore:  "2026-03-01T08:00:00Z",
+		modifiedAfter:  "72h",
+		modifiedBefore: "",
+	}
+
+	var opts gcs.ListCollectionsOptions
+	if err := flags.apply(&opts, now); err != nil {
+		t.Fatalf("apply() error = %v", err)
+	}
+	if want := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC); !opts.CreatedAfter.Equal(want) {
+		t.Errorf("CreatedAfter = %v, want %v", opts.CreatedAfter, want)
+	}
+	if want := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC); !opts.CreatedBefore.Equal(want) {
+		t.Errorf("CreatedBefore = %v, want %v", opts.CreatedBefore, want)
+	}
+	if want := now.Add(-72 * time.Hour); !opts.ModifiedAfter.Equal(want) {
+		t.Errorf("ModifiedAfter = %v, want %v", opts.ModifiedAfter, want)
+	}
+	if !opts.ModifiedBefore.IsZero() {
+		t.Errorf("ModifiedBefore = %v, want zero", opts.ModifiedBefore)
+	}
+
+	err := listTimeFlags{modifiedBefore: "last tuesday"}.apply(&opts, now)
+	if err == nil || !strings.Contains(err.Error(), "invalid --modified-before") {
+		t.Errorf("apply() error = %v, want invalid --modified-before", err)
+	}
+}
+
+func TestNewListCmd_TypeFlag(t *testing.T) {
+	cmd := NewListCmd()
+	if err := cmd.Flags().Set("type", "guest"); err != nil {
+		t.Errorf("--type guest error = %v", err)
+	}
+	if err := cmd.Flags().Set("type", "shared"); err == nil {
+		t.Error("--type shared accepted, want an error")
+	}
+}
@@ -0,0 +1,87 @@
+package collection
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"go.yaml.in/yaml/v3"
+)
+
+// collectionManifest is a YAML list of collections to create, written by
+// 'collection suggest' and read by 'collection batch-create'.
+type collectionManifest struct {
+	Collections []manifestCollection `yaml:"collections" json:"collections"`
+}
+
+// manifestCollection is one collection definition in a manifest.
+type manifestCollection struct {
+	DisplayName        string   `yaml:"display_name" json:"display_name"`
+	StorageGatewayID   string   `yaml:"storage_gateway_id" json:"storage_gateway_id"`
+	CollectionBasePath string   `yaml:"collection_base_path" json:"collection_base_path"`
+	CollectionType     string   `yaml:"collection_type,omitempty" json:"collection_type,omitempty"`
+	Description        string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Public             bool     `yaml:"public,omitempty" json:"public,omitempty"`
+	ContactEmail       string   `yaml:"contact_email,omitempty" json:"contact_email,omitempty"`
+	Organization       string   `yaml:"organization,omitempty" json:"organization,omitempty"`
+	Department         string   `yaml:"department,omitempty" json:"department,omitempty"`
+	Keywords           []string `yaml:"keywords,omitempty" json:"keywords,omitempty"`
+}
+
+// toCollection converts a manifest entry to a collection create request.
+func (m *manifestCollection) toCollection() *gcs.Collection {
+	collectionType := m.CollectionType
+	if collectionType == "" {
+		collectionType = "mapped"
+	}
+	return &gcs.Collection{
+		DisplayName:          m.DisplayName,
+		StorageGatewayID:     m.StorageGatewayID,
+		CollectionBaseFolder: m.CollectionBasePath,
+		CollectionType:       collectionType,
+		Description:          m.Description,
+		Public:               m.Public,
+		ContactEmail:         m.ContactEmail,
+		Organization:         m.Organization,
+		Department:           m.Department,
+		Keywords:             m.Keywords,
+	}
+}
+
+// loadManifest reads and validates a collection manifest.
+func loadManifest(path string) (*collectionManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+
+	var manifest collectionManifest
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("parse manifest %s: %w", path, err)
+	}
+
+	if len(manifest.Collections) == 0 {
+		return nil, fmt.Errorf("manifest %s lists no collections", path)
+	}
+	for i, c := range manifest.Collections {
+		var missing []string
+		if c.DisplayName == "" {
+			missing = append(missing, "display_name")
+		}
+		if c.StorageGatewayID == "" {
+			missing = append(missing, "storage_gateway_id")
+		}
+		if c.CollectionBasePath == "" {
+			missing = append(missing, "collection_base_path")
+		}
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("manifest %s: collection %d is missing %s", path, i+1, strings.Join(missing, ", "))
+		}
+	}
+
+	return &manifest, nil
+}
@@ -0,0 +1,333 @@
+package collection
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/transfer"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// aclClient is the part of the Transfer API client used by the permission
+// commands.
+type aclClient interface {
+	ListAccessRules(ctx context.Context, collectionID string) ([]transfer.AccessRule, error)
+	CreateAccessRule(ctx context.Context, collectionID string, rule *transfer.AccessRule) (string, error)
+	DeleteAccessRule(ctx context.Context, collectionID, ruleID string) error
+}
+
+// newACLClient creates the client of the permission commands. Tests
+// replace it.
+var newACLClient = func(token *auth.TokenInfo) (aclClient, error) {
+	return cli.NewTransferClient(token)
+}
+
+// NewPermissionCmd creates the collection permission command with
+// subcommands.
+func NewPermissionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "permission",
+		Short: "Manage guest collection permissions",
+		Long: `Manage the access rules of a guest collection.
+
+Each rule grants an identity, a group, all authenticated users, or anyone
+(anonymous) read or read/write access to a directory of the collection.
+Guest collection permissions are kept by the Globus Transfer service, so
+these commands need the Transfer scope that 'login' requests by default,
+but no endpoint.
+
+Available subcommands:
+  list   - List the rules of a collection
+  create - Grant access to a path
+  delete - Remove a rule`,
+	}
+
+	cmd.AddCommand(NewPermissionListCmd())
+	cmd.AddCommand(NewPermissionCreateCmd())
+	cmd.AddCommand(NewPermissionDeleteCmd())
+
+	return cmd
+}
+
+// NewPermissionListCmd creates the collection permission list command.
+func NewPermissionListCmd() *cobra.Command {
+	var (
+		profile string
+		format  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list COLLECTION_ID",
+		Short: "List the access rules of a guest collection",
+		Long: `List the access rules of a guest collection.
+
+Example:
+  globus-connect-server collection permission list abc123
+
+Requires an active authentication session (use 'login' first).`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPermissionList(cmd.Context(), profile, format, args[0], cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml, table, wide, csv)")
+
+	return cmd
+}
+
+// runPermissionList executes the collection permission list command.
+func runPermissionList(ctx context.Context, profile, formatStr, collectionID string, out interface{ Write([]byte) (int, error) }) error {
+	client, err := loadACLClient(profile)
+	if err != nil {
+		return err
+	}
+
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	rules, err := client.ListAccessRules(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+	if rules == nil {
+		rules = []transfer.AccessRule{}
+	}
+
+	if formatter.IsStructured() {
+		return formatter.PrintData(rules)
+	}
+
+	if formatter.IsTabular() {
+		table := output.NewTable("ID", "Principal Type", "Principal", "Path", "Permissions")
+		table.AddWideColumns("Role ID", "Created")
+		for _, rule := range rules {
+			table.AddRow(rule.ID, rule.PrincipalType, rule.Principal, rule.Path, rule.Permissions,
+				rule.RoleID, rule.CreateTime)
+		}
+		return formatter.PrintTable(table)
+	}
+
+	if len(rules) == 0 {
+		return formatter.Println("No permissions found.")
+	}
+
+	if err := formatter.PrintText("Permissions (%d):\n\n", len(rules)); err != nil {
+		return err
+	}
+	for i, rule := range rules {
+		if i > 0 {
+			if err := formatter.Println(); err != nil {
+				return err
+			}
+		}
+		if err := formatter.PrintText("  ID:           %s\n", rule.ID); err != nil {
+			return err
+		}
+		if err := formatter.PrintText("  Principal:    %s\n", describePrincipal(rule)); err != nil {
+			return err
+		}
+		if err := formatter.PrintText("  Path:         %s\n", rule.Path); err != nil {
+			return err
+		}
+		if err := formatter.PrintText("  Permissions:  %s\n", rule.Permissions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewPermissionCreateCmd creates the collection permission create command.
+func NewPermissionCreateCmd() *cobra.Command {
+	var (
+		profile string
+		format  string
+		quiet   bool
+		rule    transfer.AccessRule
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create COLLECTION_ID",
+		Short: "Grant access to a path of a guest collection",
+		Long: `Grant a principal access to a directory of a guest collection.
+
+--principal is the identity or group ID; it is not used with
+all_authenticated_users or anonymous. The path is a directory and gets a
+trailing / if it has none.
+
+Example:
+  globus-connect-server collection permission create abc123 \
+    --principal-type identity \
+    --principal 5e0c2a0f-8a4b-4f43-9f3a-0d3c2b1a9e8d \
+    --path /projects/shared/ \
+    --permissions rw
+
+Requires an active authentication session (use 'login' first).`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPermissionCreate(cmd.Context(), profile, format, args[0], rule, quiet, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Only print IDs, one per line")
+	cli.EnumVar(cmd, &rule.PrincipalType, "principal-type", transfer.PrincipalIdentity,
+		"Kind of principal (identity, group, all_authenticated_users, anonymous)",
+		transfer.PrincipalIdentity, transfer.PrincipalGroup, transfer.PrincipalAllAuthenticatedUsers, transfer.PrincipalAnonymous)
+	cmd.Flags().StringVar(&rule.Principal, "principal", "", "Identity or group ID")
+	cmd.Flags().StringVar(&rule.Path, "path", "", "Directory to grant access to")
+	cli.EnumVar(cmd, &rule.Permissions, "permissions", transfer.PermissionRead, "Access to grant (r, rw)",
+		transfer.PermissionRead, transfer.PermissionReadWrite)
+	cmd.Flags().StringVar(&rule.NotifyEmail, "notify-email", "", "Email address to send an invitation to")
+
+	_ = cmd.MarkFlagRequired("path")
+
+	return cmd
+}
+
+// runPermissionCreate executes the collection permission create command.
+func runPermissionCreate(ctx context.Context, profile, formatStr, collectionID string, rule transfer.AccessRule, quiet bool, out interface{ Write([]byte) (int, error) }) error {
+	if quiet && output.Format(formatStr) != output.FormatText {
+		return fmt.Errorf("--quiet cannot be combined with --format %s", formatStr)
+	}
+	if err := validateAccessRule(&rule); err != nil {
+		return err
+	}
+
+	client, err := loadACLClient(profile)
+	if err != nil {
+		return err
+	}
+
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions(output.WithQuiet(quiet))...)
+
+	id, err := client.CreateAccessRule(ctx, collectionID, &rule)
+	if err != nil {
+		return err
+	}
+	rule.ID = id
+
+	if formatter.IsQuiet() {
+		return formatter.PrintIDs(id)
+	}
+	if formatter.IsStructured() {
+		return formatter.PrintData(rule)
+	}
+
+	if err := formatter.Println("Permission created successfully!"); err != nil {
+		return err
+	}
+	if err := formatter.Println(); err != nil {
+		return err
+	}
+	if err := formatter.PrintText("%-14s%s\n", "ID:", id); err != nil {
+		return err
+	}
+	if err := formatter.PrintText("%-14s%s\n", "Principal:", describePrincipal(rule)); err != nil {
+		return err
+	}
+	if err := formatter.PrintText("%-14s%s\n", "Path:", rule.Path); err != nil {
+		return err
+	}
+	return formatter.PrintText("%-14s%s\n", "Permissions:", rule.Permissions)
+}
+
+// NewPermissionDeleteCmd creates the collection permission delete command.
+func NewPermissionDeleteCmd() *cobra.Command {
+	var (
+		profile string
+		format  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "delete COLLECTION_ID RULE_ID",
+		Short: "Remove an access rule from a guest collection",
+		Long: `Remove an access rule from a guest collection.
+
+Example:
+  globus-connect-server collection permission delete abc123 def456
+
+Requires an active authentication session (use 'login' first).`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPermissionDelete(cmd.Context(), profile, format, args[0], args[1], cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+
+	return cmd
+}
+
+// runPermissionDelete executes the collection permission delete command.
+func runPermissionDelete(ctx context.Context, profile, formatStr, collectionID, ruleID string, out interface{ Write([]byte) (int, error) }) error {
+	client, err := loadACLClient(profile)
+	if err != nil {
+		return err
+	}
+
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	if err := client.DeleteAccessRule(ctx, collectionID, ruleID); err != nil {
+		return err
+	}
+
+	if formatter.IsStructured() {
+		return formatter.PrintData(map[string]string{
+			"status":        "success",
+			"collection_id": collectionID,
+			"rule_id":       ruleID,
+		})
+	}
+	return formatter.PrintText("Permission %s deleted from collection %s.\n", ruleID, collectionID)
+}
+
+// loadACLClient loads the profile's token and creates the permission
+// commands' client.
+func loadACLClient(profile string) (aclClient, error) {
+	token, err := cli.LoadToken(profile)
+	if err != nil {
+		return nil, fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+	if !token.IsValid() {
+		return nil, fmt.Errorf("token expired, please login again")
+	}
+	return newACLClient(token)
+}
+
+// validateAccessRule checks that the principal fits its type and makes
+// the path a directory.
+func validateAccessRule(rule *transfer.AccessRule) error {
+	switch rule.PrincipalType {
+	case transfer.PrincipalIdentity, transfer.PrincipalGroup:
+		if rule.Principal == "" {
+			return fmt.Errorf("--principal is required for principal type %s", rule.PrincipalType)
+		}
+	default:
+		if rule.Principal != "" {
+			return fmt.Errorf("--principal cannot be used with principal type %s", rule.PrincipalType)
+		}
+	}
+	if !strings.HasPrefix(rule.Path, "/") {
+		return fmt.Errorf("--path %q must start with /", rule.Path)
+	}
+	if !strings.HasSuffix(rule.Path, "/") {
+		rule.Path += "/"
+	}
+	return nil
+}
+
+// describePrincipal returns the principal of rule for text output.
+func describePrincipal(rule transfer.AccessRule) string {
+	if rule.Principal == "" {
+		return rule.PrincipalType
+	}
+	return rule.PrincipalType + " " + rule.Principal
+}
@@ -0,0 +1,220 @@
+package collection
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/transfer"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/spf13/cobra"
+)
+
+// fakeACLClient records the rules created and deleted.
+type fakeACLClient struct {
+	rules   []transfer.AccessRule
+	created []transfer.AccessRule
+	deleted []string
+}
+
+func (f *fakeACLClient) ListAccessRules(context.Context, string) ([]transfer.AccessRule, error) {
+	return f.rules, nil
+}
+
+func (f *fakeACLClient) CreateAccessRule(_ context.Context, _ string, rule *transfer.AccessRule) (string, error) {
+	f.created = append(f.created, *rule)
+	return "rule-new", nil
+}
+
+func (f *fakeACLClient) DeleteAccessRule(_ context.Context, _, ruleID string) error {
+	f.deleted = append(f.deleted, ruleID)
+	return nil
+}
+
+// saveMockToken logs in the profile "mock" in a temporary config
+// directory.
+func saveMockToken(t *testing.T) {
+	t.Helper()
+	t.Setenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR", t.TempDir())
+	t.Setenv(auth.PassphraseEnv, "correct horse battery staple")
+	if err := auth.SetTokenEncryption(auth.TokenEncryptionPassphrase); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = auth.SetTokenEncryption(auth.TokenEncryptionKeyring) })
+	token := &auth.TokenInfo{AccessToken: "access", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := auth.SaveToken("mock", token); err != nil {
+		t.Fatalf("SaveToken() error = %v", err)
+	}
+}
+
+// useFakeACLClient logs in the profile "mock" and makes the permission
+// commands use client.
+func useFakeACLClient(t *testing.T, client *fakeACLClient) {
+	t.Helper()
+	saveMockToken(t)
+
+	factory := newACLClient
+	newACLClient = func(*auth.TokenInfo) (aclClient, error) { return client, nil }
+	t.Cleanup(func() { newACLClient = factory })
+}
+
+func TestRunPermissionList(t *testing.T) {
+	client := &fakeACLClient{rules: []transfer.AccessRule{
+		{ID: "r1", PrincipalType: transfer.PrincipalIdentity, Principal: "id-1", Path: "/a/", Permissions: "rw"},
+		{ID: "r2", PrincipalType: transfer.PrincipalAnonymous, Path: "/pub/", Permissions: "r"},
+	}}
+	useFakeACLClient(t, client)
+
+	buf := &bytes.Buffer{}
+	if err := runPermissionList(context.Background(), "mock", "text", "col-1", buf); err != nil {
+		t.Fatalf("runPermissionList() error = %v", err)
+	}
+	for _, want := range []string{"Permissions (2)", "identity id-1", "anonymous", "/pub/"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	client.rules = nil
+	if err := runPermissionList(context.Background(), "mock", "json", "col-1", buf); err != nil {
+		t.Fatalf("runPermissionList() error = %v", err)
+	}
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("JSON output for no rules = %q, want []", buf.String())
+	}
+}
+
+func TestRunPermissionCreate(t *testing.T) {
+	client := &fakeACLClient{}
+	useFakeACLClient(t, client)
+
+	rule := transfer.AccessRule{PrincipalType: transfer.PrincipalGroup, Principal: "grp-1", Path: "/projects/x", Permissions: "rw"}
+	buf := &bytes.Buffer{}
+	if err := runPermissionCreate(context.Background(), "mock", "json", "col-1", rule, false, buf); err != nil {
+		t.Fatalf("runPermissionCreate() error = %v", err)
+	}
+
+	if len(client.created) != 1 || client.created[0].Path != "/projects/x/" {
+		t.Fatalf("created = %+v, want one rule for /projects/x/", client.created)
+	}
+	var got transfer.AccessRule
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not a rule: %v\n%s", err, buf.String())
+	}
+	if got.ID != "rule-new" {
+		t.Errorf("output ID = %q, want rule-new", got.ID)
+	}
+}
+
+func TestPermissionCreate_DryRun(t *testing.T) {
+	saveMockToken(t)
+	config := filepath.Join(os.Getenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR"), "config.yaml")
+	if err := os.WriteFile(config, []byte("token_encryption: passphrase\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{"access_id": "rule-new"}`))
+	}))
+	defer server.Close()
+	factory := cli.TransferClientFactory
+	cli.TransferClientFactory = func(_, accessToken string, opts ...transfer.Option) *transfer.Client {
+		return transfer.NewClient(server.URL+"/", accessToken, opts...)
+	}
+	t.Cleanup(func() { cli.TransferClientFactory = factory })
+
+	root := &cobra.Command{Use: "globus-connect-server", PersistentPreRunE: cli.Prepare, SilenceErrors: true}
+	root.PersistentFlags().Bool(cli.DryRunFlag, false, "")
+	collectionCmd := &cobra.Command{Use: "collection"}
+	collectionCmd.AddCommand(NewPermissionCmd())
+	root.AddCommand(collectionCmd)
+	// Prepare again without --dry-run, so later tests send requests
+	t.Cleanup(func() { _ = cli.Prepare(&cobra.Command{}, nil) })
+
+	buf := &bytes.Buffer{}
+	root.SetOut(buf)
+	root.SetArgs([]string{"collection", "permission", "create", "col-1", "--profile", "mock",
+		"--principal-type", "anonymous", "--path", "/shared", "--dry-run"})
+	if err := root.Execute(); !errors.Is(err, gcs.ErrDryRun) {
+		t.Fatalf("Execute() error = %v, want ErrDryRun", err)
+	}
+
+	if len(requests) != 0 {
+		t.Errorf("dry run sent %q", requests)
+	}
+	if !strings.Contains(buf.String(), "POST /endpoint/col-1/access") {
+		t.Errorf("output missing the request not sent:\n%s", buf.String())
+	}
+}
+
+func TestValidateAccessRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    transfer.AccessRule
+		wantErr string
+	}{
+		{
+			name:    "identity without principal",
+			rule:    transfer.AccessRule{PrincipalType: transfer.PrincipalIdentity, Path: "/"},
+			wantErr: "--principal is required",
+		},
+		{
+			name:    "anonymous with principal",
+			rule:    transfer.AccessRule{PrincipalType: transfer.PrincipalAnonymous, Principal: "id-1", Path: "/"},
+			wantErr: "--principal cannot be used",
+		},
+		{
+			name:    "relative path",
+			rule:    transfer.AccessRule{PrincipalType: transfer.PrincipalAllAuthenticatedUsers, Path: "data/"},
+			wantErr: "must start with /",
+		},
+		{
+			name: "valid",
+			rule: transfer.AccessRule{PrincipalType: transfer.PrincipalAllAuthenticatedUsers, Path: "/data/"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAccessRule(&tt.rule)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateAccessRule() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateAccessRule() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunPermissionDelete(t *testing.T) {
+	client := &fakeACLClient{}
+	useFakeACLClient(t, client)
+
+	buf := &bytes.Buffer{}
+	if err := runPermissionDelete(context.Background(), "mock", "text", "col-1", "r1", buf); err != nil {
+		t.Fatalf("runPermissionDelete() error = %v", err)
+	}
+	if len(client.deleted) != 1 || client.deleted[0] != "r1" {
+		t.Errorf("deleted = %v, want r1", client.deleted)
+	}
+	if !strings.Contains(buf.String(), "r1 deleted") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
@@ -0,0 +1,86 @@
+package collection
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/spf13/cobra"
+)
+
+func TestPolicyFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    *gcs.CollectionPolicies
+		wantErr bool
+	}{
+		{name: "no policy flags", args: nil, want: nil},
+		{
+			name: "timeout only",
+			args: []string{"--authentication-timeout-mins", "60"},
+			want: &gcs.CollectionPolicies{AuthenticationTimeoutMins: 60},
+		},
+		{
+			name: "repeated and comma-separated users",
+			args: []string{
+				"--sharing-restrict", "private",
+				"--sharing-users-allow", "alice@example.org, bob@example.org",
+				"--sharing-users-allow", "carol@example.org",
+				"--sharing-users-allow", "alice@example.org",
+				"--sharing-users-deny", "mallory@example.org",
+			},
+			want: &gcs.CollectionPolicies{
+				SharingRestrict:   "private",
+				SharingUsersAllow: []string{"alice@example.org", "bob@example.org", "carol@example.org"},
+				SharingUsersDeny:  []string{"mallory@example.org"},
+			},
+		},
+		{name: "zero timeout", args: []string{"--authentication-timeout-mins", "0"}, wantErr: true},
+		{
+			name:    "allowed and denied",
+			args:    []string{"--sharing-users-allow", "alice@example.org", "--sharing-users-deny", "alice@example.org"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p policyFlags
+			cmd := &cobra.Command{}
+			addPolicyFlags(cmd, &p)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := p.policies(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("policies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("policies() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyFlags_MarshalPolicies(t *testing.T) {
+	collection := &gcs.Collection{
+		DisplayName: "Protected",
+		Policies:    &gcs.CollectionPolicies{AuthenticationTimeoutMins: 30, SharingUsersDeny: []string{"mallory@example.org"}},
+	}
+	data, err := json.Marshal(collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"authentication_timeout_mins":30,"sharing_users_deny":["mallory@example.org"]}`
+	if string(doc["policies"]) != want {
+		t.Errorf("policies = %s, want %s", doc["policies"], want)
+	}
+}
@@ -0,0 +1,121 @@
+package collection
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// provenanceKeywordPrefix marks the collection keywords that record where a
+// collection was defined.
+const provenanceKeywordPrefix = "provenance:"
+
+// provenance identifies the source of truth of a collection created from
+// a manifest. It is stored on the collection as keywords such as
+// "provenance:commit=3f2a1c9".
+type provenance struct {
+	Commit   string `json:"commit,omitempty"`
+	File     string `json:"file,omitempty"`
+	Pipeline string `json:"pipeline,omitempty"`
+}
+
+// gitOutput runs git in dir and returns its trimmed output. It is a test
+// hook.
+var gitOutput = func(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...) //nolint:gosec // Fixed git subcommands
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// detectProvenance describes the manifest at path: the git commit and
+// repository-relative path of the file if it is in a git checkout, and
+// the CI pipeline running the command, if any. getenv is os.Getenv
+// outside tests.
+func detectProvenance(path string, getenv func(string) string) provenance {
+	var p provenance
+
+	file, err := filepath.Abs(path)
+	if err != nil {
+		file = path
+	}
+	p.File = file
+
+	dir := filepath.Dir(file)
+	if root, err := gitOutput(dir, "rev-parse", "--show-toplevel"); err == nil && root != "" {
+		if rel, err := filepath.Rel(root, file); err == nil && !strings.HasPrefix(rel, "..") {
+			p.File = filepath.ToSlash(rel)
+		}
+		if commit, err := gitOutput(dir, "rev-parse", "HEAD"); err == nil {
+			p.Commit = commit
+		}
+	}
+	if p.Commit == "" {
+		p.Commit = firstEnv(getenv, "GITHUB_SHA", "CI_COMMIT_SHA", "GIT_COMMIT")
+	}
+
+	switch {
+	case getenv("GITHUB_RUN_ID") != "":
+		p.Pipeline = strings.Join([]string{getenv("GITHUB_SERVER_URL"), getenv("GITHUB_REPOSITORY"), "actions/runs", getenv("GITHUB_RUN_ID")}, "/")
+	default:
+		p.Pipeline = firstEnv(getenv, "CI_PIPELINE_URL", "BUILD_URL")
+	}
+
+	return p
+}
+
+// firstEnv returns the first non-empty environment variable of names.
+func firstEnv(getenv func(string) string, names ...string) string {
+	for _, name := range names {
+		if v := getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// keywords returns p as collection keywords.
+func (p provenance) keywords() []string {
+	var keywords []string
+	for _, kv := range [][2]string{{"commit", p.Commit}, {"file", p.File}, {"pipeline", p.Pipeline}} {
+		if kv[1] != "" {
+			keywords = append(keywords, provenanceKeywordPrefix+kv[0]+"="+kv[1])
+		}
+	}
+	return keywords
+}
+
+// withProvenance returns keywords with any provenance keywords replaced by
+// those of p.
+func withProvenance(keywords []string, p provenance) []string {
+	merged, _ := splitProvenance(keywords)
+	return append(merged, p.keywords()...)
+}
+
+// splitProvenance separates the provenance keywords of a collection from
+// its other keywords.
+func splitProvenance(keywords []string) ([]string, *provenance) {
+	var (
+		rest []string
+		p    *provenance
+	)
+	for _, keyword := range keywords {
+		kv, ok := strings.CutPrefix(keyword, provenanceKeywordPrefix)
+		if !ok {
+			rest = append(rest, keyword)
+			continue
+		}
+		key, value, _ := strings.Cut(kv, "=")
+		if p == nil {
+			p = &provenance{}
+		}
+		switch key {
+		case "commit":
+			p.Commit = value
+		case "file":
+			p.File = value
+		case "pipeline":
+			p.Pipeline = value
+		}
+	}
+	return rest, p
+}
@@ -0,0 +1,98 @@
+package collection
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+)
+
+func TestDetectProvenance(t *testing.T) {
+	root := t.TempDir()
+	manifest := filepath.Join(root, "sites", "projects.yaml")
+
+	old := gitOutput
+	gitOutput = func(_ string, args ...string) (string, error) {
+		switch strings.Join(args, " ") {
+		case "rev-parse --show-toplevel":
+			return root, nil
+		case "rev-parse HEAD":
+			return "3f2a1c9", nil
+		}
+		return "", errors.New("unexpected git command")
+	}
+	defer func() { gitOutput = old }()
+
+	env := map[string]string{
+		"GITHUB_SERVER_URL": "https://github.com",
+		"GITHUB_REPOSITORY": "example/gcs-config",
+		"GITHUB_RUN_ID":     "42",
+		"GITHUB_SHA":        "ignored-when-git-works",
+	}
+	got := detectProvenance(manifest, func(k string) string { return env[k] })
+
+	want := provenance{
+		Commit:   "3f2a1c9",
+		File:     "sites/projects.yaml",
+		Pipeline: "https://github.com/example/gcs-config/actions/runs/42",
+	}
+	if got != want {
+		t.Errorf("detectProvenance() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDetectProvenance_NoGit(t *testing.T) {
+	old := gitOutput
+	gitOutput = func(string, ...string) (string, error) { return "", errors.New("not a git repository") }
+	defer func() { gitOutput = old }()
+
+	manifest := filepath.Join(t.TempDir(), "projects.yaml")
+	env := map[string]string{"CI_COMMIT_SHA": "abc123", "CI_PIPELINE_URL": "https://gitlab.example.org/p/-/pipelines/7"}
+	got := detectProvenance(manifest, func(k string) string { return env[k] })
+
+	want := provenance{Commit: "abc123", File: manifest, Pipeline: "https://gitlab.example.org/p/-/pipelines/7"}
+	if got != want {
+		t.Errorf("detectProvenance() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWithProvenance(t *testing.T) {
+	keywords := withProvenance(
+		[]string{"genomics", "provenance:commit=old"},
+		provenance{Commit: "new", File: "projects.yaml"},
+	)
+
+	want := []string{"genomics", "provenance:commit=new", "provenance:file=projects.yaml"}
+	if !reflect.DeepEqual(keywords, want) {
+		t.Errorf("withProvenance() = %v, want %v", keywords, want)
+	}
+
+	rest, p := splitProvenance(keywords)
+	if !reflect.DeepEqual(rest, []string{"genomics"}) || p == nil || p.Commit != "new" || p.File != "projects.yaml" {
+		t.Errorf("splitProvenance() = %v, %+v", rest, p)
+	}
+}
+
+func TestFormatCollectionText_Provenance(t *testing.T) {
+	var buf bytes.Buffer
+	collection := &gcs.Collection{
+		ID:       "col-1",
+		Keywords: []string{"genomics", "provenance:commit=3f2a1c9", "provenance:pipeline=https://ci.example.org/1"},
+	}
+	if err := formatCollectionText(output.NewFormatter(output.FormatText, &buf), collection); err != nil {
+		t.Fatalf("formatCollectionText() error = %v", err)
+	}
+
+	got := buf.String()
+	if !strings.Contains(got, "  - genomics\n") || strings.Contains(got, "  - provenance:") {
+		t.Errorf("keywords should list only non-provenance keywords:\n%s", got)
+	}
+	if !strings.Contains(got, "Provenance:\n  Commit:   3f2a1c9\n  Pipeline: https://ci.example.org/1\n") {
+		t.Errorf("output missing provenance:\n%s", got)
+	}
+}
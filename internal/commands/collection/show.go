@@ -3,9 +3,10 @@ package collection
 import (
 	"context"
 	"fmt"
+	"strings"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -36,7 +37,7 @@ Requires an active authentication session (use 'login' first).`,
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 	_ = cmd.MarkFlagRequired("endpoint")
 
@@ -46,7 +47,7 @@ Requires an active authentication session (use 'login' first).`,
 // runShow executes the collection show command.
 func runShow(ctx context.Context, profile, formatStr, endpointFQDN, collectionID string, out interface{ Write([]byte) (int, error) }) error {
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -57,13 +58,10 @@ func runShow(ctx context.Context, profile, formatStr, endpointFQDN, collectionID
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -75,8 +73,8 @@ func runShow(ctx context.Context, profile, formatStr, endpointFQDN, collectionID
 	}
 
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(collection)
+	if formatter.IsStructured() {
+		return formatter.PrintData(collection)
 	}
 
 	// Text format
@@ -139,6 +137,11 @@ func printCollectionBasicFields(formatter *output.Formatter, collection *gcs.Col
 	if err := printField("Base Path", collection.CollectionBaseFolder); err != nil {
 		return err
 	}
+	if collection.IsDisabled() {
+		if err := printField("Status", "disabled (see 'collection enable')"); err != nil {
+			return err
+		}
+	}
 
 	// Boolean fields
 	if err := formatter.PrintText("%-25s%t\n", "Public:", collection.Public); err != nil {
@@ -177,20 +180,49 @@ func printCollectionOptionalFields(formatter *output.Formatter, collection *gcs.
 	return nil
 }
 
-// printCollectionKeywords prints collection keywords.
+// printCollectionKeywords prints collection keywords, with provenance
+// keywords shown separately.
 func printCollectionKeywords(formatter *output.Formatter, collection *gcs.Collection) error {
-	if len(collection.Keywords) == 0 {
-		return nil
+	keywords, p := splitProvenance(collection.Keywords)
+
+	if len(keywords) > 0 {
+		if err := formatter.Println(); err != nil {
+			return err
+		}
+		if err := formatter.Println("Keywords:"); err != nil {
+			return err
+		}
+		for _, keyword := range keywords {
+			if err := formatter.PrintText("  - %s\n", keyword); err != nil {
+				return err
+			}
+		}
 	}
 
+	if p == nil {
+		return nil
+	}
 	if err := formatter.Println(); err != nil {
 		return err
 	}
-	if err := formatter.Println("Keywords:"); err != nil {
+	return printProvenance(formatter, p)
+}
+
+// printProvenance prints where a collection was defined.
+func printProvenance(formatter *output.Formatter, p *provenance) error {
+	if err := formatter.Println("Provenance:"); err != nil {
 		return err
 	}
-	for _, keyword := range collection.Keywords {
-		if err := formatter.PrintText("  - %s\n", keyword); err != nil {
+	fields := []struct{ label, value string }{
+		{"Commit", p.Commit},
+		{"File", p.File},
+		{"Pipeline", p.Pipeline},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		if err := formatter.PrintText("  %-10s%s\n", f.label+":", f.value); err != nil {
 			return err
 		}
 	}
@@ -220,6 +252,16 @@ func printCollectionPolicies(formatter *output.Formatter, collection *gcs.Collec
 			return err
 		}
 	}
+	if len(collection.Policies.SharingUsersAllow) > 0 {
+		if err := formatter.PrintText("  Sharing Users Allowed:  %s\n", strings.Join(collection.Policies.SharingUsersAllow, ", ")); err != nil {
+			return err
+		}
+	}
+	if len(collection.Policies.SharingUsersDeny) > 0 {
+		if err := formatter.PrintText("  Sharing Users Denied:   %s\n", strings.Join(collection.Policies.SharingUsersDeny, ", ")); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -3,9 +3,15 @@ package collection
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs/gcstest"
 )
 
 func TestNewShowCmd(t *testing.T) {
@@ -97,3 +103,41 @@ func TestRunShow_NoToken(t *testing.T) {
 		t.Errorf("runShow() wrote to buffer on error: %q", buf.String())
 	}
 }
+
+func TestRunShow_MockClient(t *testing.T) {
+	t.Setenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR", t.TempDir())
+	t.Setenv(auth.PassphraseEnv, "correct horse battery staple")
+	if err := auth.SetTokenEncryption(auth.TokenEncryptionPassphrase); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = auth.SetTokenEncryption(auth.TokenEncryptionKeyring) })
+	token := &auth.TokenInfo{AccessToken: "access", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := auth.SaveToken("mock", token); err != nil {
+		t.Fatalf("SaveToken() error = %v", err)
+	}
+
+	client := &gcstest.Client{
+		GetCollectionFunc: func(_ context.Context, id string) (*gcs.Collection, error) {
+			return &gcs.Collection{ID: id, DisplayName: "Archive", CollectionType: "mapped"}, nil
+		},
+	}
+	factory := cli.GCSClientFactory
+	cli.GCSClientFactory = func(string, string) (gcs.GCSAPI, error) { return client, nil }
+	t.Cleanup(func() { cli.GCSClientFactory = factory })
+
+	buf := &bytes.Buffer{}
+	if err := runShow(context.Background(), "mock", "json", "test.example.org", "c1", buf); err != nil {
+		t.Fatalf("runShow() error = %v", err)
+	}
+
+	var got gcs.Collection
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not a collection: %v\n%s", err, buf.String())
+	}
+	if got.ID != "c1" || got.DisplayName != "Archive" {
+		t.Errorf("output = %+v, want collection c1 named Archive", got)
+	}
+	if calls := client.CallsTo("GetCollection"); len(calls) != 1 {
+		t.Errorf("GetCollection called %d times, want 1", len(calls))
+	}
+}
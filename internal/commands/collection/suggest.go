@@ -0,0 +1,278 @@
+package collection
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+// suggestOptions holds the flags of 'collection suggest'.
+type suggestOptions struct {
+	StorageGatewayID string
+	GatewayRoot      string
+	NamePrefix       string
+	Exclude          []string
+	IncludeHidden    bool
+	NoStats          bool
+	OutputFile       string
+	Format           string
+}
+
+// folderSuggestion is a top-level folder proposed as a collection.
+type folderSuggestion struct {
+	Collection manifestCollection
+	Files      int64
+	Bytes      int64
+
+	// StatErrors counts entries below the folder that could not be read.
+	StatErrors int
+}
+
+// NewSuggestCmd creates the collection suggest command.
+func NewSuggestCmd() *cobra.Command {
+	var opts suggestOptions
+
+	cmd := &cobra.Command{
+		Use:   "suggest DIRECTORY",
+		Short: "Suggest collections for the project folders in a directory",
+		Long: `Scan a directory on this node and suggest one mapped collection per
+top-level folder, written as a manifest for 'collection batch-create'.
+
+Run this on a GCS node with access to the storage. Each folder directly
+under DIRECTORY becomes a collection named after the folder, with its
+base path given in the storage gateway's path namespace: --gateway-root
+is the local directory that corresponds to "/" on the gateway (the
+default, "/", means paths are used as they are on disk).
+
+Unless --no-stats is given, each folder is walked to report its file
+count and size, which are written as comments in the manifest. With
+--format json the same manifest is written as JSON, without the folder
+statistics. Review and edit the manifest before creating the collections.
+
+This command does not contact the endpoint and needs no login.
+
+Example:
+  # Suggest collections for /data/projects/* and review them
+  globus-connect-server collection suggest /data/projects \
+    --storage-gateway-id abc123 \
+    --output projects.yaml
+
+  # Create them
+  globus-connect-server collection batch-create --manifest projects.yaml \
+    --endpoint example.data.globus.org`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSuggest(args[0], opts, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.StorageGatewayID, "storage-gateway-id", "", "Storage gateway the collections will use")
+	cmd.Flags().StringVar(&opts.GatewayRoot, "gateway-root", "/", "Local directory that is the storage gateway's root path")
+	cmd.Flags().StringVar(&opts.NamePrefix, "name-prefix", "", "Prefix for suggested display names (e.g., \"Physics - \")")
+	cmd.Flags().StringArrayVar(&opts.Exclude, "exclude", nil, "Skip top-level folders matching this glob (repeatable)")
+	cmd.Flags().BoolVar(&opts.IncludeHidden, "include-hidden", false, "Include folders whose names start with '.'")
+	cmd.Flags().BoolVar(&opts.NoStats, "no-stats", false, "Do not walk folders to count files and bytes")
+	cmd.Flags().StringVarP(&opts.OutputFile, "output", "o", "", "Write the manifest to a file instead of stdout")
+	cmd.Flags().StringVarP(&opts.Format, "format", "f", "yaml", "Output format (yaml, json)")
+
+	_ = cmd.MarkFlagRequired("storage-gateway-id")
+
+	return cmd
+}
+
+// runSuggest executes the collection suggest command.
+func runSuggest(dir string, opts suggestOptions, out io.Writer) error {
+	if opts.Format != "yaml" && opts.Format != string(output.FormatJSON) {
+		return fmt.Errorf("invalid format %q (valid: yaml, json)", opts.Format)
+	}
+	for _, pattern := range opts.Exclude {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid --exclude pattern %q: %w", pattern, err)
+		}
+	}
+
+	suggestions, err := suggestCollections(dir, opts)
+	if err != nil {
+		return err
+	}
+	if len(suggestions) == 0 {
+		return fmt.Errorf("no folders to suggest under %s", dir)
+	}
+
+	stdout := out
+	if opts.OutputFile != "" {
+		file, err := os.OpenFile(opts.OutputFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return fmt.Errorf("create output file: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		out = file
+	}
+
+	if opts.Format == string(output.FormatJSON) {
+		err = output.NewFormatter(output.FormatJSON, out).PrintJSON(suggestManifest(suggestions))
+	} else {
+		err = writeSuggestManifest(out, dir, suggestions, !opts.NoStats)
+	}
+	if err != nil {
+		return err
+	}
+
+	if opts.OutputFile != "" {
+		_, err := fmt.Fprintf(stdout, "Wrote %d suggested collection(s) to %s\n", len(suggestions), opts.OutputFile)
+		return err
+	}
+	return nil
+}
+
+// suggestCollections proposes one collection per top-level folder of dir.
+func suggestCollections(dir string, opts suggestOptions) ([]folderSuggestion, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", dir, err)
+	}
+	absRoot, err := filepath.Abs(opts.GatewayRoot)
+	if err != nil {
+		return nil, fmt.Errorf("resolve gateway root: %w", err)
+	}
+	if _, err := gatewayPath(absRoot, absDir); err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(absDir)
+	if err != nil {
+		return nil, fmt.Errorf("read directory: %w", err)
+	}
+
+	var suggestions []folderSuggestion
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || (!opts.IncludeHidden && strings.HasPrefix(name, ".")) || excluded(name, opts.Exclude) {
+			continue
+		}
+
+		folder := filepath.Join(absDir, name)
+		basePath, err := gatewayPath(absRoot, folder)
+		if err != nil {
+			return nil, err
+		}
+
+		s := folderSuggestion{
+			Collection: manifestCollection{
+				DisplayName:        opts.NamePrefix + name,
+				StorageGatewayID:   opts.StorageGatewayID,
+				CollectionBasePath: basePath,
+				CollectionType:     "mapped",
+			},
+		}
+		if !opts.NoStats {
+			s.Files, s.Bytes, s.StatErrors = folderStats(folder)
+		}
+		suggestions = append(suggestions, s)
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		return suggestions[i].Collection.CollectionBasePath < suggestions[j].Collection.CollectionBasePath
+	})
+	return suggestions, nil
+}
+
+// gatewayPath converts a local directory to a path in the storage
+// gateway's namespace rooted at root.
+func gatewayPath(root, dir string) (string, error) {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is not under the gateway root %s", dir, root)
+	}
+	if rel == "." {
+		return "/", nil
+	}
+	return "/" + filepath.ToSlash(rel) + "/", nil
+}
+
+// excluded reports whether name matches any of the glob patterns.
+func excluded(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// folderStats counts the regular files below dir and their total size.
+// Entries that cannot be read are counted rather than failing the scan.
+func folderStats(dir string) (files, bytes int64, statErrors int) {
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			statErrors++
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			statErrors++
+			return nil
+		}
+		files++
+		bytes += info.Size()
+		return nil
+	})
+	return files, bytes, statErrors
+}
+
+// suggestManifest returns the manifest that creates the suggested
+// collections.
+func suggestManifest(suggestions []folderSuggestion) *collectionManifest {
+	manifest := &collectionManifest{}
+	for _, s := range suggestions {
+		manifest.Collections = append(manifest.Collections, s.Collection)
+	}
+	return manifest
+}
+
+// writeSuggestManifest writes suggestions as a YAML manifest, with each
+// folder's size as a comment.
+func writeSuggestManifest(out io.Writer, dir string, suggestions []folderSuggestion, stats bool) error {
+	manifest := suggestManifest(suggestions)
+
+	var doc yaml.Node
+	if err := doc.Encode(manifest); err != nil {
+		return fmt.Errorf("encode manifest: %w", err)
+	}
+	doc.HeadComment = fmt.Sprintf("Collections suggested by 'collection suggest' for %s.\nReview before running 'collection batch-create --manifest FILE'.", dir)
+
+	if stats {
+		// doc is a mapping whose only value is the collections sequence
+		items := doc.Content[1].Content
+		for i, s := range suggestions {
+			comment := fmt.Sprintf("%d files, %s", s.Files, cli.Bytes(s.Bytes))
+			if s.StatErrors > 0 {
+				comment += fmt.Sprintf(" (%d entries unreadable)", s.StatErrors)
+			}
+			items[i].HeadComment = comment
+		}
+	}
+
+	encoder := yaml.NewEncoder(out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,157 @@
+package collection
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewSuggestCmd(t *testing.T) {
+	cmd := NewSuggestCmd()
+
+	if cmd.Use != "suggest DIRECTORY" {
+		t.Errorf("Use = %q, want %q", cmd.Use, "suggest DIRECTORY")
+	}
+	for _, name := range []string{"storage-gateway-id", "gateway-root", "exclude", "output", "format"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("flag %q not found", name)
+		}
+	}
+}
+
+func TestSuggestCollections(t *testing.T) {
+	root := t.TempDir()
+	projects := filepath.Join(root, "data", "projects")
+	for _, dir := range []string{"beta", "alpha/raw", ".snapshot", "scratch"} {
+		if err := os.MkdirAll(filepath.Join(projects, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(projects, "alpha", "raw", "a.dat"), make([]byte, 2048), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(projects, "README"), []byte("not a folder"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	suggestions, err := suggestCollections(projects, suggestOptions{
+		StorageGatewayID: "gw-1",
+		GatewayRoot:      root,
+		NamePrefix:       "Lab - ",
+		Exclude:          []string{"scr*"},
+	})
+	if err != nil {
+		t.Fatalf("suggestCollections() error = %v", err)
+	}
+
+	if len(suggestions) != 2 {
+		t.Fatalf("got %d suggestions, want 2: %+v", len(suggestions), suggestions)
+	}
+	alpha := suggestions[0]
+	if alpha.Collection.DisplayName != "Lab - alpha" || alpha.Collection.CollectionBasePath != "/data/projects/alpha/" {
+		t.Errorf("alpha = %+v", alpha.Collection)
+	}
+	if alpha.Collection.StorageGatewayID != "gw-1" || alpha.Files != 1 || alpha.Bytes != 2048 {
+		t.Errorf("alpha stats = %d files, %d bytes, gateway %q", alpha.Files, alpha.Bytes, alpha.Collection.StorageGatewayID)
+	}
+	if suggestions[1].Collection.DisplayName != "Lab - beta" {
+		t.Errorf("second suggestion = %q, want %q", suggestions[1].Collection.DisplayName, "Lab - beta")
+	}
+}
+
+func TestSuggestCollections_OutsideGatewayRoot(t *testing.T) {
+	dir := t.TempDir()
+	_, err := suggestCollections(dir, suggestOptions{GatewayRoot: filepath.Join(dir, "elsewhere")})
+	if err == nil || !strings.Contains(err.Error(), "not under the gateway root") {
+		t.Errorf("suggestCollections() error = %v, want gateway root error", err)
+	}
+}
+
+func TestSuggestManifest_RoundTrip(t *testing.T) {
+	suggestions := []folderSuggestion{
+		{Collection: manifestCollection{DisplayName: "alpha", StorageGatewayID: "gw-1", CollectionBasePath: "/alpha/", CollectionType: "mapped"}, Files: 3, Bytes: 1536},
+	}
+
+	var buf bytes.Buffer
+	if err := writeSuggestManifest(&buf, "/data", suggestions, true); err != nil {
+		t.Fatalf("writeSuggestManifest() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "# 3 files, 1.5 KiB") {
+		t.Errorf("manifest missing size comment:\n%s", buf.String())
+	}
+
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := loadManifest(path)
+	if err != nil {
+		t.Fatalf("loadManifest() error = %v", err)
+	}
+	if len(manifest.Collections) != 1 || !reflect.DeepEqual(manifest.Collections[0], suggestions[0].Collection) {
+		t.Errorf("loaded manifest = %+v", manifest.Collections)
+	}
+}
+
+func TestRunSuggest_JSONManifest(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"alpha", "beta"} {
+		if err := os.Mkdir(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	opts := suggestOptions{StorageGatewayID: "gw-1", GatewayRoot: root, OutputFile: path, Format: "json"}
+	if err := runSuggest(root, opts, &bytes.Buffer{}); err != nil {
+		t.Fatalf("runSuggest() error = %v", err)
+	}
+
+	manifest, err := loadManifest(path)
+	if err != nil {
+		t.Fatalf("loadManifest() error = %v", err)
+	}
+	want := []manifestCollection{
+		{DisplayName: "alpha", StorageGatewayID: "gw-1", CollectionBasePath: "/alpha/", CollectionType: "mapped"},
+		{DisplayName: "beta", StorageGatewayID: "gw-1", CollectionBasePath: "/beta/", CollectionType: "mapped"},
+	}
+	if !reflect.DeepEqual(manifest.Collections, want) {
+		t.Errorf("loaded manifest = %+v, want %+v", manifest.Collections, want)
+	}
+}
+
+func TestLoadManifest_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":         "collections: []\n",
+		"missing field": "collections:\n  - display_name: a\n    storage_gateway_id: gw\n",
+		"unknown field": "collections:\n  - display_name: a\n    storage_gateway_id: gw\n    collection_base_path: /a/\n    colour: red\n",
+	}
+	for name, contents := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "manifest.yaml")
+			if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := loadManifest(path); err == nil {
+				t.Error("loadManifest() expected error")
+			}
+		})
+	}
+}
+
+func TestRunBatchCreate_NoToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	contents := "collections:\n  - display_name: a\n    storage_gateway_id: gw\n    collection_base_path: /a/\n"
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	err := runBatchCreate(t.Context(), "nonexistent-profile-test", "text", "example.org", path, false, &buf)
+	if err == nil || !strings.Contains(err.Error(), "not logged in") {
+		t.Errorf("runBatchCreate() error = %v, want not logged in", err)
+	}
+}
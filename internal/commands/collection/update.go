@@ -5,8 +5,8 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -30,6 +30,8 @@ func NewUpdateCmd() *cobra.Command {
 		department               string
 		userMessage              string
 		userMessageLink          string
+		policyOpts               policyFlags
+		fromFile                 string
 	)
 
 	cmd := &cobra.Command{
@@ -45,25 +47,45 @@ Example:
     --display-name "Updated Collection Name" \
     --description "New description"
 
+Policy flags (--authentication-timeout-mins, --sharing-restrict,
+--sharing-users-allow, --sharing-users-deny) replace the corresponding
+fields of the collection's Policies block; the block is only sent if one
+of them is given.
+
+--from-file applies a file written by 'collection export' (use - for
+stdin): the fields of the file that differ from the collection, including
+policies, are updated, and fields the file leaves out are not changed. It
+cannot be combined with the field flags.
+  globus-connect-server collection update abc123 --from-file collection.yaml \
+    --endpoint example.data.globus.org
+
 Requires an active authentication session (use 'login' first).`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			collectionID := args[0]
+			if fromFile != "" {
+				return runUpdateFromFile(cmd.Context(), profile, format, endpointFQDN, collectionID,
+					fromFile, cmd.InOrStdin(), cmd.OutOrStdout())
+			}
+			policies, err := policyOpts.policies(cmd)
+			if err != nil {
+				return err
+			}
 			return runUpdate(cmd.Context(), profile, format, endpointFQDN, collectionID,
 				displayName, description, public, disableAnonymousWrites,
 				contactEmail, contactInfo, infoLink, keywords, organization,
-				department, userMessage, userMessageLink, cmd.OutOrStdout())
+				department, userMessage, userMessageLink, policies, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 	cmd.Flags().StringVar(&displayName, "display-name", "", "Display name for the collection")
 	cmd.Flags().StringVar(&description, "description", "", "Description of the collection")
 
 	// Boolean flags need special handling - use string and convert to *bool
-	publicStr := cmd.Flags().String("public", "", "Make collection public (true/false)")
+	publicStr := cli.Enum(cmd, "public", "", "Make collection public (true/false)", cli.BoolChoices...)
 	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
 		if *publicStr != "" {
 			val := *publicStr == "true"
@@ -77,7 +99,7 @@ Requires an active authentication session (use 'login' first).`,
 		return nil
 	}
 
-	cmd.Flags().String("disable-anonymous-writes", "", "Disable anonymous writes (true/false)")
+	cli.Enum(cmd, "disable-anonymous-writes", "", "Disable anonymous writes (true/false)", cli.BoolChoices...)
 	cmd.Flags().StringVar(&contactEmail, "contact-email", "", "Contact email")
 	cmd.Flags().StringVar(&contactInfo, "contact-info", "", "Contact information")
 	cmd.Flags().StringVar(&infoLink, "info-link", "", "Information link URL")
@@ -86,8 +108,11 @@ Requires an active authentication session (use 'login' first).`,
 	cmd.Flags().StringVar(&department, "department", "", "Department name")
 	cmd.Flags().StringVar(&userMessage, "user-message", "", "Message shown to users")
 	cmd.Flags().StringVar(&userMessageLink, "user-message-link", "", "Link for user message")
+	addPolicyFlags(cmd, &policyOpts)
+	cmd.Flags().StringVar(&fromFile, fromFileFlag, "", "Apply a file written by 'collection export' (- for stdin)")
 
 	_ = cmd.MarkFlagRequired("endpoint")
+	markFromFileExclusive(cmd)
 
 	return cmd
 }
@@ -96,11 +121,11 @@ Requires an active authentication session (use 'login' first).`,
 func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN, collectionID string,
 	displayName, description string, public, disableAnonymousWrites *bool,
 	contactEmail, contactInfo, infoLink, keywords, organization, department,
-	userMessage, userMessageLink string,
+	userMessage, userMessageLink string, policies *gcs.CollectionPolicies,
 	out interface{ Write([]byte) (int, error) }) error {
 
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -111,13 +136,10 @@ func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN, collection
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -158,6 +180,7 @@ func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN, collection
 	if userMessageLink != "" {
 		collection.UserMessageLink = userMessageLink
 	}
+	collection.Policies = policies
 
 	// Parse keywords if provided
 	if keywords != "" {
@@ -175,8 +198,8 @@ func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN, collection
 	}
 
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(updated)
+	if formatter.IsStructured() {
+		return formatter.PrintData(updated)
 	}
 
 	// Text format
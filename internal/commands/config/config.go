@@ -0,0 +1,25 @@
+// Package config provides commands for inspecting CLI configuration.
+package config
+
+import "github.com/spf13/cobra"
+
+// NewConfigCmd creates the config command with subcommands.
+func NewConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect and replicate CLI configuration",
+		Long: `Commands for inspecting the Globus Connect Server CLI configuration, and for
+copying it to another workstation.
+
+Configuration values are resolved from command-line flags, environment
+variables, the configuration file (~/.globus-connect-server/config.yaml),
+and built-in defaults, in that order of precedence.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(NewEffectiveCmd())
+	cmd.AddCommand(NewExportCmd())
+	cmd.AddCommand(NewImportCmd())
+
+	return cmd
+}
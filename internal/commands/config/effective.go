@@ -0,0 +1,139 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	pkgconfig "github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// NewEffectiveCmd creates the config effective command.
+func NewEffectiveCmd() *cobra.Command {
+	var (
+		profile      string
+		format       string
+		endpointFQDN string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "effective",
+		Short: "Show the fully resolved configuration",
+		Long: `Show the configuration that commands would use for this invocation.
+
+For each setting (profile, endpoint, format, timeout, ...) this command
+prints the effective value and where it came from: a command-line flag,
+an environment variable, the configuration file, or the built-in default.
+
+Use it to debug which profile or endpoint a command will talk to. Pass the
+same flags you would pass to the command being investigated.
+
+Example:
+  globus-connect-server config effective
+  globus-connect-server config effective --profile production --format json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runEffective(format, cli.Effective(), cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", pkgconfig.DefaultProfile, "Profile name")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+
+	return cmd
+}
+
+// runEffective executes the config effective command.
+func runEffective(formatStr string, eff *pkgconfig.Effective, out interface{ Write([]byte) (int, error) }) error {
+	if eff == nil {
+		return fmt.Errorf("configuration has not been resolved")
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	// Output based on format
+	if formatter.IsStructured() {
+		return formatter.PrintData(eff)
+	}
+
+	// Text format
+	if err := formatter.Println("Effective Configuration"); err != nil {
+		return err
+	}
+	if err := formatter.Println("======================="); err != nil {
+		return err
+	}
+	if err := formatter.Println(); err != nil {
+		return err
+	}
+
+	status := "found"
+	if !eff.ConfigFileFound {
+		status = "not found"
+	}
+	if err := formatter.PrintText("Config File: %s (%s)\n\n", eff.ConfigFile, status); err != nil {
+		return err
+	}
+
+	if err := formatter.PrintText("%-12s %-40s %s\n", "KEY", "VALUE", "SOURCE"); err != nil {
+		return err
+	}
+	for _, s := range eff.Settings {
+		if err := formatter.PrintText("%-12s %-40s %s\n", s.Key, displayValue(s.Value), displaySource(s)); err != nil {
+			return err
+		}
+	}
+
+	if len(eff.Hooks) > 0 {
+		if err := formatter.PrintText("\nHooks:\n"); err != nil {
+			return err
+		}
+		names := make([]string, 0, len(eff.Hooks))
+		for name := range eff.Hooks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if err := formatter.PrintText("  %-24s %s\n", name, eff.Hooks[name]); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(eff.Annotations) > 0 {
+		if err := formatter.PrintText("\nAnnotations:\n"); err != nil {
+			return err
+		}
+		keys := make([]string, 0, len(eff.Annotations))
+		for key := range eff.Annotations {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if err := formatter.PrintText("  %-24s %s\n", key, eff.Annotations[key]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// displayValue returns a placeholder for empty values.
+func displayValue(value string) string {
+	if value == "" {
+		return "(not set)"
+	}
+	return value
+}
+
+// displaySource formats the source of a setting with its origin.
+func displaySource(s pkgconfig.Setting) string {
+	if s.Origin == "" {
+		return string(s.Source)
+	}
+	return fmt.Sprintf("%s (%s)", s.Source, s.Origin)
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	pkgconfig "github.com/scttfrdmn/globus-go-gcs/internal/config"
+)
+
+func TestNewEffectiveCmd(t *testing.T) {
+	cmd := NewEffectiveCmd()
+
+	if cmd == nil {
+		t.Fatal("NewEffectiveCmd() returned nil")
+	}
+
+	if cmd.Use != "effective" {
+		t.Errorf("NewEffectiveCmd() Use = %q, want %q", cmd.Use, "effective")
+	}
+
+	if cmd.Short == "" {
+		t.Error("NewEffectiveCmd() Short description is empty")
+	}
+
+	if cmd.Long == "" {
+		t.Error("NewEffectiveCmd() Long description is empty")
+	}
+
+	if cmd.RunE == nil {
+		t.Error("NewEffectiveCmd() RunE is nil")
+	}
+}
+
+func TestNewEffectiveCmd_Flags(t *testing.T) {
+	cmd := NewEffectiveCmd()
+
+	tests := []struct {
+		name         string
+		flagName     string
+		shorthand    string
+		defaultValue string
+	}{
+		{
+			name:         "profile flag",
+			flagName:     "profile",
+			shorthand:    "p",
+			defaultValue: pkgconfig.DefaultProfile,
+		},
+		{
+			name:         "format flag",
+			flagName:     "format",
+			shorthand:    "f",
+			defaultValue: "text",
+		},
+		{
+			name:     "endpoint flag",
+			flagName: "endpoint",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flag := cmd.Flags().Lookup(tt.flagName)
+			if flag == nil {
+				t.Fatalf("flag %q not found", tt.flagName)
+			}
+
+			if flag.Shorthand != tt.shorthand {
+				t.Errorf("flag %q shorthand = %q, want %q", tt.flagName, flag.Shorthand, tt.shorthand)
+			}
+
+			if tt.defaultValue != "" && flag.DefValue != tt.defaultValue {
+				t.Errorf("flag %q default = %q, want %q", tt.flagName, flag.DefValue, tt.defaultValue)
+			}
+		})
+	}
+}
+
+func testEffective() *pkgconfig.Effective {
+	return &pkgconfig.Effective{
+		ConfigFile:      "/tmp/gcs/config.yaml",
+		ConfigFileFound: true,
+		Settings: []pkgconfig.Setting{
+			{Key: pkgconfig.KeyProfile, Value: "production", Source: pkgconfig.SourceFlag, Origin: "--profile"},
+			{Key: pkgconfig.KeyEndpoint, Value: "", Source: pkgconfig.SourceDefault},
+		},
+		Hooks: map[string]string{"pre-delete": "/usr/local/bin/change-ticket-check"},
+	}
+}
+
+func TestRunEffective_Text(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	if err := runEffective("text", testEffective(), buf); err != nil {
+		t.Fatalf("runEffective() error = %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{
+		"/tmp/gcs/config.yaml (found)",
+		"production",
+		"flag (--profile)",
+		"(not set)",
+		"pre-delete",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("runEffective() output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRunEffective_JSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	if err := runEffective("json", testEffective(), buf); err != nil {
+		t.Fatalf("runEffective() error = %v", err)
+	}
+
+	var got pkgconfig.Effective
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("runEffective() produced invalid JSON: %v", err)
+	}
+	if got.Get(pkgconfig.KeyProfile) != "production" {
+		t.Errorf("profile = %q, want %q", got.Get(pkgconfig.KeyProfile), "production")
+	}
+}
+
+func TestRunEffective_NotResolved(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	if err := runEffective("text", nil, buf); err == nil {
+		t.Error("runEffective() expected error for nil configuration, got nil")
+	}
+
+	if buf.Len() > 0 {
+		t.Errorf("runEffective() wrote to buffer on error: %q", buf.String())
+	}
+}
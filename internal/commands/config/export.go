@@ -0,0 +1,386 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	pkgconfig "github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/secureinput"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+// setupFormat identifies the content of a configuration export.
+const setupFormat = "gcs-setup-v1"
+
+// setupBundle is the content of a configuration export, before it is
+// encrypted.
+type setupBundle struct {
+	Format     string    `json:"format"`
+	ExportedAt time.Time `json:"exported_at"`
+
+	// Config is config.yaml as written, comments included.
+	Config string `json:"config,omitempty"`
+
+	// Tokens are keyed by the name they are stored under: a profile, or
+	// a profile's reporter profile.
+	Tokens map[string]*auth.TokenInfo `json:"tokens,omitempty"`
+}
+
+// exportOptions holds the flags of the config export command.
+type exportOptions struct {
+	OutputFile      string
+	PassphraseEnv   string
+	IncludeProfiles bool
+	IncludeTokens   bool
+}
+
+// NewExportCmd creates the config export command.
+func NewExportCmd() *cobra.Command {
+	var (
+		profile string
+		opts    exportOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the CLI setup to provision another workstation",
+		Long: `Export config.yaml, and optionally stored tokens, as one passphrase-encrypted
+file, so that a new or rebuilt admin workstation can be set up with
+'config import' in one step.
+
+The export holds the settings, hooks and annotations of config.yaml. With
+--include-profiles, the profiles: sections are included as well; without
+it, they are left out, e.g. to share site settings without personal
+profiles. With --include-tokens, the stored tokens of the active profile
+(or, with --include-profiles, of every profile) are included, so that the
+new workstation needs no login. Tokens grant the same access as logging in:
+choose a strong passphrase.
+
+The passphrase is prompted for, or read from the environment variable
+named by --passphrase-env, and the key is derived from it with
+PBKDF2-SHA256. The export is written to standard output unless --output is
+given, in which case the file is created with owner-only permissions.
+
+Examples:
+  globus-connect-server config export --include-profiles --output setup.enc
+  globus-connect-server config export --include-profiles --include-tokens --output setup.enc`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !cmd.Flags().Changed("profile") {
+				profile = cli.Effective().Get(pkgconfig.KeyProfile)
+			}
+			return runExport(profile, opts, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", pkgconfig.DefaultProfile, "Profile whose tokens --include-tokens exports")
+	cmd.Flags().StringVarP(&opts.OutputFile, "output", "o", "", "Write the export to this file instead of standard output")
+	cmd.Flags().StringVar(&opts.PassphraseEnv, "passphrase-env", "", "Read the passphrase from this environment variable")
+	cmd.Flags().BoolVar(&opts.IncludeProfiles, "include-profiles", false, "Include the profiles sections of config.yaml")
+	cmd.Flags().BoolVar(&opts.IncludeTokens, "include-tokens", false, "Include stored tokens")
+
+	return cmd
+}
+
+// NewImportCmd creates the config import command.
+func NewImportCmd() *cobra.Command {
+	var (
+		passphraseEnv string
+		force         bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import [FILE|-]",
+		Short: "Set up this workstation from a config export",
+		Long: `Write the config.yaml and tokens of a file written by 'config export'.
+
+Without FILE, or with "-", the export is read from standard input, and the
+passphrase must then come from --passphrase-env.
+
+Nothing is written if config.yaml already exists or a profile in the export
+already has a session on this workstation, unless --force is given.
+
+Example:
+  globus-connect-server config import setup.enc`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file := "-"
+			if len(args) > 0 {
+				file = args[0]
+			}
+			return runImport(file, passphraseEnv, force, cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVar(&passphraseEnv, "passphrase-env", "", "Read the passphrase from this environment variable")
+	cmd.Flags().BoolVar(&force, "force", false, "Replace an existing config.yaml and sessions")
+
+	return cmd
+}
+
+// runExport executes the config export command.
+func runExport(profile string, opts exportOptions, out io.Writer) error {
+	bundle := &setupBundle{Format: setupFormat, ExportedAt: time.Now().UTC()}
+
+	configPath, err := pkgconfig.GetConfigFilePath()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(configPath) //nolint:gosec // The CLI's own config file
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("read config file: %w", err)
+	case opts.IncludeProfiles:
+		bundle.Config = string(data)
+	default:
+		if bundle.Config, err = withoutProfiles(data); err != nil {
+			return fmt.Errorf("parse config file %s: %w", configPath, err)
+		}
+	}
+
+	if opts.IncludeTokens {
+		if bundle.Tokens, err = exportTokens(profile, opts.IncludeProfiles); err != nil {
+			return err
+		}
+	}
+	if bundle.Config == "" && len(bundle.Tokens) == 0 {
+		return errors.New("nothing to export: there is no config.yaml and no tokens were included")
+	}
+
+	passphrase, err := readNewPassphrase(opts.PassphraseEnv)
+	if err != nil {
+		return err
+	}
+	plaintext, err := json.Marshal(bundle)
+	if err != nil {
+		return fmt.Errorf("marshal export: %w", err)
+	}
+	sealed, err := auth.SealWithPassphrase(plaintext, passphrase)
+	if err != nil {
+		return fmt.Errorf("encrypt export: %w", err)
+	}
+	data, err = json.MarshalIndent(sealed, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal export: %w", err)
+	}
+	data = append(data, '\n')
+
+	if opts.OutputFile == "" {
+		_, err = out.Write(data)
+		return err
+	}
+	if err := writePrivateFile(opts.OutputFile, data); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "✓ Exported %s to %s\n", describeBundle(bundle), opts.OutputFile)
+	return err
+}
+
+// withoutProfiles returns config.yaml without its profiles section.
+func withoutProfiles(data []byte) (string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return "", err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return string(data), nil
+	}
+
+	mapping := doc.Content[0]
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == "profiles" {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			break
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// exportTokens loads the stored tokens of profile, and its reporter
+// token, or of every profile if all.
+func exportTokens(profile string, all bool) (map[string]*auth.TokenInfo, error) {
+	statuses, err := auth.InspectTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := map[string]*auth.TokenInfo{}
+	for _, status := range statuses {
+		if !all && status.Profile != profile {
+			continue
+		}
+		name := status.Profile
+		if status.Reporter {
+			name = auth.ReporterProfile(name)
+		}
+		token, err := auth.LoadToken(name)
+		if err != nil {
+			return nil, fmt.Errorf("load tokens of %s: %w", name, err)
+		}
+		tokens[name] = token
+	}
+	if len(tokens) == 0 && !all {
+		return nil, fmt.Errorf("profile %q has no stored tokens (use 'login' first, or leave out --include-tokens)", profile)
+	}
+	return tokens, nil
+}
+
+// readNewPassphrase reads the passphrase of a new export, asking twice
+// when it is typed in.
+func readNewPassphrase(passphraseEnv string) (string, error) {
+	passphrase, err := secureinput.ReadSecret(secureinput.ReadSecretOptions{
+		PromptMessage: "Passphrase for the export",
+		EnvVar:        passphraseEnv,
+	})
+	if err != nil {
+		return "", fmt.Errorf("read passphrase: %w", err)
+	}
+	if passphraseEnv == "" {
+		confirm, err := secureinput.ReadSecret(secureinput.ReadSecretOptions{
+			PromptMessage: "Repeat passphrase",
+		})
+		if err != nil {
+			return "", fmt.Errorf("read passphrase: %w", err)
+		}
+		if confirm != passphrase {
+			return "", errors.New("passphrases do not match")
+		}
+	}
+	if passphrase == "" {
+		return "", errors.New("passphrase is required")
+	}
+	return passphrase, nil
+}
+
+// runImport executes the config import command.
+func runImport(file, passphraseEnv string, force bool, in io.Reader, out io.Writer) error {
+	var (
+		data []byte
+		err  error
+	)
+	if file == "-" {
+		data, err = io.ReadAll(in)
+	} else {
+		data, err = os.ReadFile(file) //nolint:gosec // User-specified export file
+	}
+	if err != nil {
+		return fmt.Errorf("read export: %w", err)
+	}
+
+	var sealed auth.EncryptedTokenFile
+	if err := json.Unmarshal(data, &sealed); err != nil || sealed.Format != auth.PassphraseTokenFormat {
+		return errors.New("not a file written by 'config export'")
+	}
+	if passphraseEnv == "" && file == "-" {
+		return errors.New("use --passphrase-env when reading the export from standard input")
+	}
+	passphrase, err := secureinput.ReadSecret(secureinput.ReadSecretOptions{
+		PromptMessage: "Passphrase for the export",
+		EnvVar:        passphraseEnv,
+	})
+	if err != nil {
+		return fmt.Errorf("read passphrase: %w", err)
+	}
+	plaintext, err := auth.OpenWithPassphrase(&sealed, passphrase)
+	if err != nil {
+		return fmt.Errorf("decrypt export: %w", err)
+	}
+
+	var bundle setupBundle
+	if err := json.Unmarshal(plaintext, &bundle); err != nil || bundle.Format != setupFormat {
+		return errors.New("not a file written by 'config export' (for token bundles, use 'auth token import')")
+	}
+
+	configPath, err := pkgconfig.GetConfigFilePath()
+	if err != nil {
+		return err
+	}
+	if !force {
+		var conflicts []string
+		if _, err := os.Stat(configPath); bundle.Config != "" && err == nil {
+			conflicts = append(conflicts, configPath)
+		}
+		for _, name := range sortedNames(bundle.Tokens) {
+			if _, err := auth.LoadToken(name); err == nil {
+				conflicts = append(conflicts, "session of profile "+name)
+			}
+		}
+		if len(conflicts) > 0 {
+			return fmt.Errorf("would replace %s (use --force to replace)", strings.Join(conflicts, ", "))
+		}
+	}
+
+	if bundle.Config != "" {
+		if err := yaml.Unmarshal([]byte(bundle.Config), &pkgconfig.FileConfig{}); err != nil {
+			return fmt.Errorf("exported config.yaml: %w", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(configPath), 0700); err != nil {
+			return fmt.Errorf("create config directory: %w", err)
+		}
+		if err := writePrivateFile(configPath, []byte(bundle.Config)); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedNames(bundle.Tokens) {
+		if err := auth.SaveToken(name, bundle.Tokens[name]); err != nil {
+			return fmt.Errorf("save tokens of %s: %w", name, err)
+		}
+	}
+
+	_, err = fmt.Fprintf(out, "✓ Imported %s (exported %s)\n", describeBundle(&bundle), bundle.ExportedAt.Format(time.RFC3339))
+	return err
+}
+
+// sortedNames returns the token names of an export in order.
+func sortedNames(tokens map[string]*auth.TokenInfo) []string {
+	names := make([]string, 0, len(tokens))
+	for name := range tokens {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// describeBundle summarizes what an export holds, e.g. "config.yaml and
+// tokens of 2 profile(s)".
+func describeBundle(bundle *setupBundle) string {
+	var parts []string
+	if bundle.Config != "" {
+		parts = append(parts, "config.yaml")
+	}
+	if len(bundle.Tokens) > 0 {
+		parts = append(parts, fmt.Sprintf("tokens of %d profile(s)", len(bundle.Tokens)))
+	}
+	return strings.Join(parts, " and ")
+}
+
+// writePrivateFile writes data to path with owner-only permissions.
+func writePrivateFile(path string, data []byte) error {
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(path, 0600); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+)
+
+const testConfig = `# Site settings
+format: json
+hooks:
+  pre-delete: /usr/local/bin/change-ticket-check
+profiles:
+  production:
+    endpoint: abc.def.data.globus.org
+`
+
+// useConfigDir points the CLI at a new configuration directory holding
+// config, with tokens encrypted with a passphrase rather than the
+// keyring, and returns the directory.
+func useConfigDir(t *testing.T, config string) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR", dir)
+	t.Setenv(auth.PassphraseEnv, "token passphrase")
+	t.Setenv("SETUP_PASSPHRASE", "export passphrase")
+	if err := auth.SetTokenEncryption(auth.TokenEncryptionPassphrase); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = auth.SetTokenEncryption(auth.TokenEncryptionKeyring) })
+
+	if config != "" {
+		if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(config), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestExportImport(t *testing.T) {
+	useConfigDir(t, testConfig)
+	token := &auth.TokenInfo{AccessToken: "access", RefreshToken: "refresh", ExpiresAt: time.Now().Add(time.Hour).UTC()}
+	for _, name := range []string{"production", auth.ReporterProfile("production"), "testing"} {
+		if err := auth.SaveToken(name, token); err != nil {
+			t.Fatalf("SaveToken(%q) error = %v", name, err)
+		}
+	}
+
+	exported := filepath.Join(t.TempDir(), "setup.enc")
+	opts := exportOptions{OutputFile: exported, PassphraseEnv: "SETUP_PASSPHRASE", IncludeProfiles: true, IncludeTokens: true}
+	buf := &bytes.Buffer{}
+	if err := runExport("production", opts, buf); err != nil {
+		t.Fatalf("runExport() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "config.yaml and tokens of 3 profile(s)") {
+		t.Errorf("runExport() output = %q", buf.String())
+	}
+	data, _ := os.ReadFile(exported)
+	if strings.Contains(string(data), "abc.def") || strings.Contains(string(data), "refresh") {
+		t.Error("export is not encrypted")
+	}
+
+	// A new workstation
+	dir := useConfigDir(t, "")
+	buf.Reset()
+	if err := runImport(exported, "SETUP_PASSPHRASE", false, nil, buf); err != nil {
+		t.Fatalf("runImport() error = %v", err)
+	}
+	config, _ := os.ReadFile(filepath.Join(dir, "config.yaml"))
+	if string(config) != testConfig {
+		t.Errorf("imported config.yaml = %q, want it as exported", config)
+	}
+	for _, name := range []string{"production", auth.ReporterProfile("production"), "testing"} {
+		if got, err := auth.LoadToken(name); err != nil || got.RefreshToken != "refresh" {
+			t.Errorf("LoadToken(%q) = %+v, %v", name, got, err)
+		}
+	}
+
+	// Importing again would replace them
+	if err := runImport(exported, "SETUP_PASSPHRASE", false, nil, buf); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("runImport() over an existing setup error = %v, want a --force hint", err)
+	}
+	if err := runImport(exported, "SETUP_PASSPHRASE", true, nil, buf); err != nil {
+		t.Errorf("runImport() with --force error = %v", err)
+	}
+}
+
+func TestExport_WithoutProfiles(t *testing.T) {
+	useConfigDir(t, testConfig)
+
+	buf := &bytes.Buffer{}
+	if err := runExport("default", exportOptions{PassphraseEnv: "SETUP_PASSPHRASE"}, buf); err != nil {
+		t.Fatalf("runExport() error = %v", err)
+	}
+
+	dir := useConfigDir(t, "")
+	if err := runImport("-", "SETUP_PASSPHRASE", false, buf, &bytes.Buffer{}); err != nil {
+		t.Fatalf("runImport() error = %v", err)
+	}
+	config, _ := os.ReadFile(filepath.Join(dir, "config.yaml"))
+	if strings.Contains(string(config), "profiles") || !strings.Contains(string(config), "pre-delete: /usr/local/bin/change-ticket-check") {
+		t.Errorf("imported config.yaml = %q, want the settings without profiles", config)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "tokens")); err == nil {
+		t.Error("tokens were imported although none were exported")
+	}
+}
+
+func TestImport_WrongPassphrase(t *testing.T) {
+	useConfigDir(t, testConfig)
+	exported := filepath.Join(t.TempDir(), "setup.enc")
+	if err := runExport("default", exportOptions{OutputFile: exported, PassphraseEnv: "SETUP_PASSPHRASE"}, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := useConfigDir(t, "")
+	t.Setenv("SETUP_PASSPHRASE", "guess")
+	if err := runImport(exported, "SETUP_PASSPHRASE", false, nil, &bytes.Buffer{}); err == nil {
+		t.Error("runImport() with the wrong passphrase expected error")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "config.yaml")); err == nil {
+		t.Error("config.yaml written although the export could not be decrypted")
+	}
+}
+
+func TestExport_MissingTokens(t *testing.T) {
+	useConfigDir(t, "")
+	err := runExport("default", exportOptions{PassphraseEnv: "SETUP_PASSPHRASE", IncludeTokens: true}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "no stored tokens") {
+		t.Errorf("runExport() error = %v, want no stored tokens", err)
+	}
+}
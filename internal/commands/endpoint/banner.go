@@ -0,0 +1,507 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// bannerStateFile records the banners set on this host, with the user
+// messages they replaced, so that 'endpoint banner clear' can restore them.
+const bannerStateFile = "banners.json"
+
+// banner is a maintenance banner set across an endpoint's collections.
+type banner struct {
+	Endpoint    string             `json:"endpoint"`
+	Message     string             `json:"message"`
+	Link        string             `json:"link,omitempty"`
+	Until       *time.Time         `json:"until,omitempty"`
+	SetAt       time.Time          `json:"set_at"`
+	Collections []bannerCollection `json:"collections"`
+}
+
+// bannerCollection is one collection a banner was applied to.
+type bannerCollection struct {
+	ID                  string `json:"id"`
+	DisplayName         string `json:"display_name,omitempty"`
+	PreviousMessage     string `json:"previous_message,omitempty"`
+	PreviousMessageLink string `json:"previous_message_link,omitempty"`
+	Error               string `json:"error,omitempty"`
+}
+
+// expired reports whether the banner's --until time has passed.
+func (b *banner) expired(now time.Time) bool {
+	return b.Until != nil && !now.Before(*b.Until)
+}
+
+// NewBannerCmd creates the endpoint banner command with subcommands.
+func NewBannerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "banner",
+		Short: "Manage maintenance banners across collections",
+		Long: `Commands for broadcasting a maintenance banner to users.
+
+A banner sets the user message (and optional link) of every collection on the
+endpoint. Clearing it restores each collection's previous message. Banners are
+recorded in ~/.globus-connect-server/banners.json on the host that set them.`,
+	}
+
+	cmd.AddCommand(NewBannerSetCmd())
+	cmd.AddCommand(NewBannerClearCmd())
+	cmd.AddCommand(NewBannerShowCmd())
+
+	return cmd
+}
+
+// NewBannerSetCmd creates the endpoint banner set command.
+func NewBannerSetCmd() *cobra.Command {
+	var (
+		profile      string
+		format       string
+		endpointFQDN string
+		link         string
+		until        string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "set MESSAGE",
+		Short: "Show a maintenance banner on every collection",
+		Long: `Set the user message of every collection on the endpoint.
+
+Each collection's current message is saved so that 'endpoint banner clear'
+can restore it. With --until, the banner is removed by the first
+'endpoint banner clear --if-expired' run after that time; schedule that
+command (for example, hourly from cron) to clear banners automatically.
+
+Example:
+  globus-connect-server endpoint banner set "Maintenance Sunday 2-4 UTC" \
+    --endpoint example.data.globus.org \
+    --link https://status.example.edu \
+    --until 2025-06-01
+
+Requires an active authentication session (use 'login' first).`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			untilTime, err := parseUntil(until, time.Now())
+			if err != nil {
+				return err
+			}
+			return runBannerSet(cmd.Context(), profile, format, endpointFQDN, args[0], link, untilTime, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	cmd.Flags().StringVar(&link, "link", "", "Link shown with the banner")
+	cmd.Flags().StringVar(&until, "until", "", "When the banner expires (YYYY-MM-DD, RFC 3339 time, or duration like 48h)")
+
+	_ = cmd.MarkFlagRequired("endpoint")
+
+	return cmd
+}
+
+// NewBannerClearCmd creates the endpoint banner clear command.
+func NewBannerClearCmd() *cobra.Command {
+	var (
+		profile      string
+		format       string
+		endpointFQDN string
+		ifExpired    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Remove the maintenance banner",
+		Long: `Remove the banner set with 'endpoint banner set' and restore each
+collection's previous user message.
+
+Collections whose message was changed since the banner was set are left alone.
+
+With --if-expired, the banner is only cleared once its --until time has
+passed, which makes the command safe to run on a schedule.
+
+Example:
+  globus-connect-server endpoint banner clear --endpoint example.data.globus.org
+  globus-connect-server endpoint banner clear --endpoint example.data.globus.org --if-expired
+
+Requires an active authentication session (use 'login' first).`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runBannerClear(cmd.Context(), profile, format, endpointFQDN, ifExpired, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	cmd.Flags().BoolVar(&ifExpired, "if-expired", false, "Only clear the banner if its --until time has passed")
+
+	_ = cmd.MarkFlagRequired("endpoint")
+
+	return cmd
+}
+
+// NewBannerShowCmd creates the endpoint banner show command.
+func NewBannerShowCmd() *cobra.Command {
+	var (
+		format       string
+		endpointFQDN string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the current maintenance banner",
+		Long: `Show the banner recorded for the endpoint on this host, including when it
+expires and which collections it was applied to.
+
+Example:
+  globus-connect-server endpoint banner show --endpoint example.data.globus.org`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runBannerShow(format, endpointFQDN, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+
+	_ = cmd.MarkFlagRequired("endpoint")
+
+	return cmd
+}
+
+// runBannerSet executes the endpoint banner set command.
+func runBannerSet(ctx context.Context, profile, formatStr, endpointFQDN, message, link string, until *time.Time, out interface{ Write([]byte) (int, error) }) error {
+	if message == "" {
+		return fmt.Errorf("banner message is required")
+	}
+
+	existing, err := loadBanner(endpointFQDN)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("a banner is already set on %s (run 'endpoint banner clear' first)", endpointFQDN)
+	}
+
+	// Load token
+	token, err := cli.LoadToken(profile)
+	if err != nil {
+		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+
+	// Check if token is valid
+	if !token.IsValid() {
+		return fmt.Errorf("token expired, please login again")
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	// Create GCS client
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("create GCS client: %w", err)
+	}
+
+	collections, err := gcsClient.ListAllCollections(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("list collections: %w", err)
+	}
+
+	b := &banner{
+		Endpoint: endpointFQDN,
+		Message:  message,
+		Link:     link,
+		Until:    until,
+		SetAt:    time.Now().UTC(),
+	}
+
+	failed := 0
+	for _, collection := range collections {
+		// Leave collections taken offline by 'collection disable' alone
+		if collection.IsDisabled() {
+			continue
+		}
+
+		entry := bannerCollection{
+			ID:                  collection.ID,
+			DisplayName:         collection.DisplayName,
+			PreviousMessage:     collection.UserMessage,
+			PreviousMessageLink: collection.UserMessageLink,
+		}
+		if err := gcsClient.SetCollectionUserMessage(ctx, collection.ID, message, link); err != nil {
+			entry.Error = err.Error()
+			failed++
+		}
+		b.Collections = append(b.Collections, entry)
+	}
+
+	// Record the banner even after partial failure so that it can be cleared
+	if err := saveBanner(b); err != nil {
+		return fmt.Errorf("save banner state: %w", err)
+	}
+
+	if err := printBanner(formatter, b, "Banner set"); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("banner could not be set on %d of %d collections", failed, len(b.Collections))
+	}
+	return nil
+}
+
+// runBannerClear executes the endpoint banner clear command.
+func runBannerClear(ctx context.Context, profile, formatStr, endpointFQDN string, ifExpired bool, out interface{ Write([]byte) (int, error) }) error {
+	b, err := loadBanner(endpointFQDN)
+	if err != nil {
+		return err
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	if b == nil || (ifExpired && !b.expired(time.Now())) {
+		if formatter.IsStructured() {
+			return formatter.PrintData(map[string]interface{}{"endpoint": endpointFQDN, "cleared": false})
+		}
+		if b == nil {
+			return formatter.Println("No banner is set on this endpoint.")
+		}
+		return formatter.PrintText("Banner has not expired yet (until %s).\n", b.Until.Local().Format(time.RFC3339))
+	}
+
+	// Load token
+	token, err := cli.LoadToken(profile)
+	if err != nil {
+		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+
+	// Check if token is valid
+	if !token.IsValid() {
+		return fmt.Errorf("token expired, please login again")
+	}
+
+	// Create GCS client
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("create GCS client: %w", err)
+	}
+
+	var remaining []bannerCollection
+	for _, entry := range b.Collections {
+		if entry.Error != "" {
+			// The banner was never applied to this collection
+			continue
+		}
+
+		current, err := gcsClient.GetCollection(ctx, entry.ID)
+		if err != nil {
+			entry.Error = err.Error()
+			remaining = append(remaining, entry)
+			continue
+		}
+		if current.UserMessage != b.Message {
+			cli.Warnf("collection %s message changed since the banner was set; leaving it unchanged", entry.ID)
+			continue
+		}
+
+		if err := gcsClient.SetCollectionUserMessage(ctx, entry.ID, entry.PreviousMessage, entry.PreviousMessageLink); err != nil {
+			entry.Error = err.Error()
+			remaining = append(remaining, entry)
+		}
+	}
+
+	cleared := *b
+	cleared.Collections = remaining
+	if len(remaining) > 0 {
+		// Keep the collections that could not be restored for a retry
+		if err := saveBanner(&cleared); err != nil {
+			return fmt.Errorf("save banner state: %w", err)
+		}
+	} else if err := deleteBanner(endpointFQDN); err != nil {
+		return fmt.Errorf("save banner state: %w", err)
+	}
+
+	if formatter.IsStructured() {
+		return formatter.PrintData(map[string]interface{}{
+			"endpoint": endpointFQDN,
+			"cleared":  len(remaining) == 0,
+			"failed":   remaining,
+		})
+	}
+
+	if len(remaining) > 0 {
+		for _, entry := range remaining {
+			if err := formatter.PrintText("  %s: %s\n", entry.ID, entry.Error); err != nil {
+				return err
+			}
+		}
+		return fmt.Errorf("banner could not be cleared from %d collections (run 'endpoint banner clear' again to retry)", len(remaining))
+	}
+
+	return formatter.Println("Banner cleared; previous collection messages restored.")
+}
+
+// runBannerShow executes the endpoint banner show command.
+func runBannerShow(formatStr, endpointFQDN string, out interface{ Write([]byte) (int, error) }) error {
+	b, err := loadBanner(endpointFQDN)
+	if err != nil {
+		return err
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	if b == nil {
+		if formatter.IsStructured() {
+			return formatter.PrintData(nil)
+		}
+		return formatter.Println("No banner is set on this endpoint.")
+	}
+
+	return printBanner(formatter, b, "Current Banner")
+}
+
+// printBanner prints a banner and the collections it applies to.
+func printBanner(formatter *output.Formatter, b *banner, title string) error {
+	if formatter.IsStructured() {
+		return formatter.PrintData(b)
+	}
+
+	if err := formatter.PrintText("%s on %s\n\n", title, b.Endpoint); err != nil {
+		return err
+	}
+	if err := formatter.PrintText("%-20s%s\n", "Message:", b.Message); err != nil {
+		return err
+	}
+	if b.Link != "" {
+		if err := formatter.PrintText("%-20s%s\n", "Link:", b.Link); err != nil {
+			return err
+		}
+	}
+	if b.Until != nil {
+		if err := formatter.PrintText("%-20s%s\n", "Until:", b.Until.Local().Format(time.RFC3339)); err != nil {
+			return err
+		}
+	}
+	if err := formatter.PrintText("%-20s%s\n", "Set At:", b.SetAt.Local().Format(time.RFC3339)); err != nil {
+		return err
+	}
+
+	if err := formatter.PrintText("\nCollections (%d):\n", len(b.Collections)); err != nil {
+		return err
+	}
+	for _, entry := range b.Collections {
+		status := "ok"
+		if entry.Error != "" {
+			status = "failed: " + entry.Error
+		}
+		if err := formatter.PrintText("  %-38s %-30s %s\n", entry.ID, entry.DisplayName, status); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseUntil parses the --until flag: a date (YYYY-MM-DD, midnight UTC),
+// an RFC 3339 time, or a duration from now. An empty value means no expiry.
+func parseUntil(value string, now time.Time) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return &t, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		t := now.Add(d).UTC()
+		return &t, nil
+	}
+
+	return nil, fmt.Errorf("invalid --until %q: use YYYY-MM-DD, an RFC 3339 time, or a duration like 48h", value)
+}
+
+// bannerStatePath returns the path of the banner state file.
+func bannerStatePath() (string, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, bannerStateFile), nil
+}
+
+// readBanners reads all recorded banners, keyed by endpoint FQDN.
+func readBanners() (map[string]*banner, string, error) {
+	path, err := bannerStatePath()
+	if err != nil {
+		return nil, "", err
+	}
+
+	banners := map[string]*banner{}
+	data, err := os.ReadFile(path) //nolint:gosec // Path is in the config directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return banners, path, nil
+		}
+		return nil, "", fmt.Errorf("read banner state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &banners); err != nil {
+		return nil, "", fmt.Errorf("parse %s: %w", path, err)
+	}
+	return banners, path, nil
+}
+
+// writeBanners writes all recorded banners.
+func writeBanners(path string, banners map[string]*banner) error {
+	data, err := json.MarshalIndent(banners, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// loadBanner returns the banner recorded for endpointFQDN, or nil.
+func loadBanner(endpointFQDN string) (*banner, error) {
+	banners, _, err := readBanners()
+	if err != nil {
+		return nil, err
+	}
+	return banners[endpointFQDN], nil
+}
+
+// saveBanner records b for its endpoint.
+func saveBanner(b *banner) error {
+	banners, path, err := readBanners()
+	if err != nil {
+		return err
+	}
+	banners[b.Endpoint] = b
+	return writeBanners(path, banners)
+}
+
+// deleteBanner removes the banner recorded for endpointFQDN.
+func deleteBanner(endpointFQDN string) error {
+	banners, path, err := readBanners()
+	if err != nil {
+		return err
+	}
+	delete(banners, endpointFQDN)
+	return writeBanners(path, banners)
+}
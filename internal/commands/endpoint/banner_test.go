@@ -0,0 +1,166 @@
+package endpoint
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewBannerCmd(t *testing.T) {
+	cmd := NewBannerCmd()
+
+	if cmd.Use != "banner" {
+		t.Errorf("NewBannerCmd() Use = %q, want %q", cmd.Use, "banner")
+	}
+
+	want := map[string]bool{"set": false, "clear": false, "show": false}
+	for _, sub := range cmd.Commands() {
+		if _, ok := want[sub.Name()]; ok {
+			want[sub.Name()] = true
+		}
+	}
+	for name, found := range want {
+		if !found {
+			t.Errorf("NewBannerCmd() missing subcommand %q", name)
+		}
+	}
+
+	set := NewBannerSetCmd()
+	for _, flag := range []string{"link", "until", "endpoint"} {
+		if set.Flags().Lookup(flag) == nil {
+			t.Errorf("NewBannerSetCmd() missing --%s flag", flag)
+		}
+	}
+	if NewBannerClearCmd().Flags().Lookup("if-expired") == nil {
+		t.Error("NewBannerClearCmd() missing --if-expired flag")
+	}
+}
+
+func TestParseUntil(t *testing.T) {
+	now := time.Date(2025, 5, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantNil bool
+		wantErr bool
+	}{
+		{value: "", wantNil: true},
+		{value: "2025-06-01", want: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "2025-06-01T04:00:00Z", want: time.Date(2025, 6, 1, 4, 0, 0, 0, time.UTC)},
+		{value: "48h", want: now.Add(48 * time.Hour)},
+		{value: "-1h", wantErr: true},
+		{value: "next sunday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseUntil(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseUntil(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("parseUntil(%q) = %v, want nil", tt.value, got)
+				}
+				return
+			}
+			if got == nil || !got.Equal(tt.want) {
+				t.Errorf("parseUntil(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBannerState(t *testing.T) {
+	t.Setenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR", t.TempDir())
+
+	until := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	b := &banner{
+		Endpoint: "ep.example.org",
+		Message:  "Maintenance Sunday 2-4 UTC",
+		Until:    &until,
+		SetAt:    time.Date(2025, 5, 20, 0, 0, 0, 0, time.UTC),
+		Collections: []bannerCollection{
+			{ID: "col-1", PreviousMessage: "Welcome"},
+		},
+	}
+	if err := saveBanner(b); err != nil {
+		t.Fatalf("saveBanner() error = %v", err)
+	}
+
+	got, err := loadBanner("ep.example.org")
+	if err != nil {
+		t.Fatalf("loadBanner() error = %v", err)
+	}
+	if got == nil || got.Message != b.Message || len(got.Collections) != 1 || got.Collections[0].PreviousMessage != "Welcome" {
+		t.Fatalf("loadBanner() = %+v, want the saved banner", got)
+	}
+	if !got.expired(until) || got.expired(until.Add(-time.Minute)) {
+		t.Error("expired() does not honor the --until time")
+	}
+
+	if other, _ := loadBanner("other.example.org"); other != nil {
+		t.Errorf("loadBanner() for another endpoint = %+v, want nil", other)
+	}
+
+	if err := deleteBanner("ep.example.org"); err != nil {
+		t.Fatalf("deleteBanner() error = %v", err)
+	}
+	if got, _ := loadBanner("ep.example.org"); got != nil {
+		t.Errorf("loadBanner() after delete = %+v, want nil", got)
+	}
+}
+
+func TestRunBannerSet_AlreadySet(t *testing.T) {
+	t.Setenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR", t.TempDir())
+
+	if err := saveBanner(&banner{Endpoint: "ep.example.org", Message: "Earlier"}); err != nil {
+		t.Fatalf("saveBanner() error = %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	err := runBannerSet(context.Background(), "nonexistent-profile-test", "text", "ep.example.org", "Later", "", nil, buf)
+	if err == nil || !strings.Contains(err.Error(), "already set") {
+		t.Errorf("runBannerSet() error = %v, want already set error", err)
+	}
+}
+
+func TestRunBannerSet_NoToken(t *testing.T) {
+	t.Setenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR", t.TempDir())
+	buf := &bytes.Buffer{}
+
+	err := runBannerSet(context.Background(), "nonexistent-profile-test", "text", "ep.example.org", "Maintenance", "", nil, buf)
+	if err == nil {
+		t.Error("runBannerSet() expected error for nonexistent profile, got nil")
+	}
+	if buf.Len() > 0 {
+		t.Errorf("runBannerSet() wrote to buffer on error: %q", buf.String())
+	}
+}
+
+func TestRunBannerClear_NotExpired(t *testing.T) {
+	t.Setenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR", t.TempDir())
+
+	until := time.Now().Add(24 * time.Hour)
+	if err := saveBanner(&banner{Endpoint: "ep.example.org", Message: "Maintenance", Until: &until}); err != nil {
+		t.Fatalf("saveBanner() error = %v", err)
+	}
+
+	// No token is needed when there is nothing to clear yet
+	buf := &bytes.Buffer{}
+	if err := runBannerClear(context.Background(), "nonexistent-profile-test", "text", "ep.example.org", true, buf); err != nil {
+		t.Fatalf("runBannerClear() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "not expired") {
+		t.Errorf("runBannerClear() output = %q, want not expired message", buf.String())
+	}
+	if got, _ := loadBanner("ep.example.org"); got == nil {
+		t.Error("runBannerClear() removed a banner that has not expired")
+	}
+}
@@ -25,6 +25,9 @@ defining the service's identity and general settings.`,
 	cmd.AddCommand(NewSetSubscriptionIDCmd())
 	cmd.AddCommand(NewDomainCmd())
 	cmd.AddCommand(NewUpgradeCmd())
+	cmd.AddCommand(NewLimitsCmd())
+	cmd.AddCommand(NewFeaturesCmd())
+	cmd.AddCommand(NewBannerCmd())
 
 	return cmd
 }
@@ -0,0 +1,121 @@
+package endpoint
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// featureLabels are the display names of features in text output.
+var featureLabels = map[string]string{
+	gcs.FeatureHTTPS:             "HTTPS Access",
+	gcs.FeatureMappedCollections: "Mapped Collections",
+	gcs.FeatureSharing:           "Sharing (Guest)",
+	gcs.FeatureOIDC:              "OIDC Server",
+	gcs.FeatureCustomDomain:      "Custom Domain",
+	gcs.FeatureHighAvailability:  "High Availability",
+}
+
+// NewFeaturesCmd creates the endpoint features command.
+func NewFeaturesCmd() *cobra.Command {
+	var (
+		profile      string
+		format       string
+		endpointFQDN string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "features",
+		Short: "List the optional features enabled on the endpoint",
+		Long: `List which optional GCS features the endpoint has enabled, which are
+available but not configured, and which are unavailable.
+
+Features covered are HTTPS access, mapped collections, sharing through
+guest collections, an OIDC server, a custom domain, and high
+availability (multiple nodes). Availability is based on the endpoint's
+subscription and limits (see 'endpoint limits').
+
+Example:
+  globus-connect-server endpoint features \
+    --endpoint example.data.globus.org
+
+Requires an active authentication session (use 'login' first).`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runFeatures(cmd.Context(), profile, format, endpointFQDN, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	_ = cmd.MarkFlagRequired("endpoint")
+
+	return cmd
+}
+
+// runFeatures executes the endpoint features command.
+func runFeatures(ctx context.Context, profile, formatStr, endpointFQDN string, out interface{ Write([]byte) (int, error) }) error {
+	// Load token
+	token, err := cli.LoadToken(profile)
+	if err != nil {
+		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+
+	// Check if token is valid
+	if !token.IsValid() {
+		return fmt.Errorf("token expired, please login again")
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	// Create GCS client
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("create GCS client: %w", err)
+	}
+
+	features, err := gcsClient.GetFeatures(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Output based on format
+	if formatter.IsStructured() {
+		return formatter.PrintData(features)
+	}
+
+	// Text format
+	return formatFeaturesText(formatter, features)
+}
+
+// formatFeaturesText formats endpoint features as a table.
+func formatFeaturesText(formatter *output.Formatter, features *gcs.Features) error {
+	subscription := "none (unmanaged endpoint)"
+	if features.Managed {
+		subscription = features.SubscriptionID
+	}
+	if err := formatter.PrintText("%-22s%s\n\n", "Subscription:", subscription); err != nil {
+		return err
+	}
+
+	if err := formatter.PrintText("%-22s %-12s %s\n", "FEATURE", "STATUS", "DETAIL"); err != nil {
+		return err
+	}
+	for _, f := range features.Features {
+		label, ok := featureLabels[f.Name]
+		if !ok {
+			label = f.Name
+		}
+		if err := formatter.PrintText("%-22s %-12s %s\n", label, f.Status, f.Detail); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
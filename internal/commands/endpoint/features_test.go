@@ -0,0 +1,52 @@
+package endpoint
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+)
+
+func TestNewFeaturesCmd(t *testing.T) {
+	cmd := NewFeaturesCmd()
+
+	if cmd.Use != "features" {
+		t.Errorf("NewFeaturesCmd() Use = %q, want %q", cmd.Use, "features")
+	}
+	for _, flag := range []string{"profile", "format", "endpoint"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("NewFeaturesCmd() missing --%s flag", flag)
+		}
+	}
+}
+
+func TestRunFeatures_NoToken(t *testing.T) {
+	var buf bytes.Buffer
+	err := runFeatures(context.Background(), "nonexistent-profile-test", "text", "example.data.globus.org", &buf)
+	if err == nil {
+		t.Error("runFeatures() with no token should return error")
+	}
+}
+
+func TestFormatFeaturesText(t *testing.T) {
+	var buf bytes.Buffer
+	features := &gcs.Features{
+		Features: []gcs.Feature{
+			{Name: gcs.FeatureSharing, Status: gcs.FeatureUnavailable, Detail: "guest collections require subscription"},
+		},
+	}
+
+	if err := formatFeaturesText(output.NewFormatter(output.FormatText, &buf), features); err != nil {
+		t.Fatalf("formatFeaturesText() error = %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{"none (unmanaged endpoint)", "Sharing (Guest)", "unavailable"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+}
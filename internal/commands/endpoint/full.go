@@ -0,0 +1,195 @@
+package endpoint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+)
+
+// Snapshot sections, used as keys of deploymentSnapshot.Errors.
+const (
+	sectionStorageGateways = "storage_gateways"
+	sectionCollections     = "collections"
+	sectionNodes           = "nodes"
+	sectionRoles           = "roles"
+)
+
+// deploymentSnapshot is the consolidated document shown by
+// 'endpoint show --full'.
+type deploymentSnapshot struct {
+	Endpoint        *gcs.Endpoint        `json:"endpoint"`
+	StorageGateways []gcs.StorageGateway `json:"storage_gateways"`
+	Collections     []gcs.Collection     `json:"collections"`
+	Nodes           []gcs.Node           `json:"nodes"`
+	Roles           []gcs.Role           `json:"roles"`
+
+	// Errors maps the sections that could not be fetched to the error.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// fetchSnapshot fetches the endpoint's storage gateways, collections,
+// nodes, and roles concurrently. A section that cannot be fetched is
+// recorded in Errors and left empty.
+func fetchSnapshot(ctx context.Context, client gcs.GCSAPI, endpoint *gcs.Endpoint) *deploymentSnapshot {
+	snapshot := &deploymentSnapshot{
+		Endpoint:        endpoint,
+		StorageGateways: []gcs.StorageGateway{},
+		Collections:     []gcs.Collection{},
+		Nodes:           []gcs.Node{},
+		Roles:           []gcs.Role{},
+	}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	fetch := func(section string, fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				if snapshot.Errors == nil {
+					snapshot.Errors = map[string]string{}
+				}
+				snapshot.Errors[section] = err.Error()
+			}
+		}()
+	}
+
+	// Each goroutine writes only its own section
+	fetch(sectionStorageGateways, func() error {
+		gateways, err := client.ListAllStorageGateways(ctx, nil)
+		if err == nil {
+			snapshot.StorageGateways = gateways
+		}
+		return err
+	})
+	fetch(sectionCollections, func() error {
+		collections, err := client.ListAllCollections(ctx, nil)
+		if err == nil {
+			snapshot.Collections = collections
+		}
+		return err
+	})
+	fetch(sectionNodes, func() error {
+		nodes, err := client.ListAllNodes(ctx, nil)
+		if err == nil {
+			snapshot.Nodes = nodes
+		}
+		return err
+	})
+	fetch(sectionRoles, func() error {
+		roles, err := client.ListAllRoles(ctx, nil)
+		if err == nil {
+			snapshot.Roles = roles
+		}
+		return err
+	})
+
+	wg.Wait()
+	return snapshot
+}
+
+// printSnapshot prints a deployment snapshot as nested JSON or as text
+// sections.
+func printSnapshot(formatter *output.Formatter, snapshot *deploymentSnapshot) error {
+	for _, section := range sortedSections(snapshot.Errors) {
+		cli.Warnf("could not fetch %s: %s", section, snapshot.Errors[section])
+	}
+
+	if formatter.IsStructured() {
+		return formatter.PrintData(snapshot)
+	}
+
+	if err := formatEndpointText(formatter, snapshot.Endpoint); err != nil {
+		return err
+	}
+
+	if err := printSection(formatter, "Storage Gateways", sectionStorageGateways, snapshot, len(snapshot.StorageGateways), func() error {
+		for _, gw := range snapshot.StorageGateways {
+			if err := formatter.PrintText("  %-38s %-30s %s\n", gw.ID, gw.DisplayName, gw.ConnectorName); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := printSection(formatter, "Collections", sectionCollections, snapshot, len(snapshot.Collections), func() error {
+		for _, col := range snapshot.Collections {
+			if err := formatter.PrintText("  %-38s %-30s %-8s %s\n", col.ID, col.DisplayName, col.CollectionType, col.StorageGatewayID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := printSection(formatter, "Nodes", sectionNodes, snapshot, len(snapshot.Nodes), func() error {
+		for _, node := range snapshot.Nodes {
+			if err := formatter.PrintText("  %-38s %-30s %s\n", node.ID, node.Name, node.Status); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return printSection(formatter, "Roles", sectionRoles, snapshot, len(snapshot.Roles), func() error {
+		for _, role := range snapshot.Roles {
+			collection := role.Collection
+			if collection == "" {
+				collection = "(endpoint)"
+			}
+			if err := formatter.PrintText("  %-38s %-20s %-38s %s\n", role.ID, role.Role, collection, role.Principal); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// printSection prints a section heading followed by its rows, or the
+// error that kept the section from being fetched.
+func printSection(formatter *output.Formatter, title, section string, snapshot *deploymentSnapshot, count int, rows func() error) error {
+	if err := formatter.Println(); err != nil {
+		return err
+	}
+
+	if msg, ok := snapshot.Errors[section]; ok {
+		return formatter.PrintText("%s: unavailable (%s)\n", title, msg)
+	}
+
+	if err := formatter.PrintText("%s (%d):\n", title, count); err != nil {
+		return err
+	}
+	return rows()
+}
+
+// sortedSections returns the keys of errs in sorted order.
+func sortedSections(errs map[string]string) []string {
+	sections := make([]string, 0, len(errs))
+	for section := range errs {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+	return sections
+}
+
+// snapshotError returns an error if any section of snapshot is missing.
+func snapshotError(snapshot *deploymentSnapshot) error {
+	if len(snapshot.Errors) == 0 {
+		return nil
+	}
+	return fmt.Errorf("snapshot incomplete: could not fetch %d of 4 sections", len(snapshot.Errors))
+}
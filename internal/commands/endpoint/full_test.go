@@ -0,0 +1,99 @@
+package endpoint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+)
+
+// newSnapshotServer serves a small deployment. Roles fail with HTTP 500.
+func newSnapshotServer(t *testing.T) (*gcs.Client, func()) {
+	t.Helper()
+
+	writeJSON := func(w http.ResponseWriter, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/storage_gateways", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, gcs.StorageGatewayList{Data: []gcs.StorageGateway{{ID: "gw-1", DisplayName: "POSIX"}}})
+	})
+	mux.HandleFunc("/api/collections", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("marker") == "" {
+			writeJSON(w, gcs.CollectionList{Data: []gcs.Collection{{ID: "col-1"}}, HasNextPage: true, Marker: "m2"})
+			return
+		}
+		writeJSON(w, gcs.CollectionList{Data: []gcs.Collection{{ID: "col-2"}}})
+	})
+	mux.HandleFunc("/api/nodes", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, gcs.NodeList{Data: []gcs.Node{{ID: "node-1", Status: "active"}}})
+	})
+	mux.HandleFunc("/api/roles", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+
+	server := httptest.NewTLSServer(mux)
+	client, err := gcs.NewClient(
+		strings.TrimPrefix(server.URL, "https://"),
+		gcs.WithAccessToken("test-token"),
+		gcs.WithHTTPClient(server.Client()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client, server.Close
+}
+
+func TestFetchSnapshot(t *testing.T) {
+	client, done := newSnapshotServer(t)
+	defer done()
+
+	snapshot := fetchSnapshot(context.Background(), client, &gcs.Endpoint{ID: "ep-1", DisplayName: "Example"})
+
+	if len(snapshot.StorageGateways) != 1 || len(snapshot.Nodes) != 1 {
+		t.Errorf("fetchSnapshot() gateways = %d, nodes = %d, want 1 each", len(snapshot.StorageGateways), len(snapshot.Nodes))
+	}
+	if len(snapshot.Collections) != 2 {
+		t.Errorf("fetchSnapshot() collections = %d, want 2 (both pages)", len(snapshot.Collections))
+	}
+	if _, ok := snapshot.Errors[sectionRoles]; !ok || len(snapshot.Errors) != 1 {
+		t.Errorf("fetchSnapshot() errors = %v, want only roles", snapshot.Errors)
+	}
+	if snapshot.Roles == nil {
+		t.Error("fetchSnapshot() roles = nil, want empty slice for a failed section")
+	}
+	if err := snapshotError(snapshot); err == nil {
+		t.Error("snapshotError() = nil, want error for a missing section")
+	}
+}
+
+func TestPrintSnapshot_Text(t *testing.T) {
+	snapshot := &deploymentSnapshot{
+		Endpoint:        &gcs.Endpoint{ID: "ep-1", DisplayName: "Example"},
+		StorageGateways: []gcs.StorageGateway{{ID: "gw-1", DisplayName: "POSIX"}},
+		Collections:     []gcs.Collection{{ID: "col-1", DisplayName: "Data"}},
+		Nodes:           []gcs.Node{},
+		Roles:           []gcs.Role{{ID: "role-1", Role: "administrator"}},
+		Errors:          map[string]string{sectionNodes: "HTTP 500"},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := printSnapshot(output.NewFormatter(output.FormatText, buf), snapshot); err != nil {
+		t.Fatalf("printSnapshot() error = %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{"Endpoint Configuration:", "Storage Gateways (1):", "Collections (1):", "Nodes: unavailable (HTTP 500)", "Roles (1):", "(endpoint)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("printSnapshot() output missing %q:\n%s", want, got)
+		}
+	}
+}
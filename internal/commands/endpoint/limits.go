@@ -0,0 +1,149 @@
+package endpoint
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// NewLimitsCmd creates the endpoint limits command.
+func NewLimitsCmd() *cobra.Command {
+	var (
+		profile      string
+		format       string
+		endpointFQDN string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "limits",
+		Short: "Display subscription-based endpoint limits",
+		Long: `Display the subscription-based limits of the endpoint and its current usage.
+
+This shows whether the endpoint is managed by a subscription, whether guest
+collections and high availability (multiple nodes) are available, and how
+many collections and nodes exist compared to any maximum.
+
+Commands such as 'collection create' and 'node create' check these limits
+and warn before sending a request the endpoint would reject.
+
+Example:
+  globus-connect-server endpoint limits \
+    --endpoint example.data.globus.org
+
+Requires an active authentication session (use 'login' first).`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runLimits(cmd.Context(), profile, format, endpointFQDN, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	_ = cmd.MarkFlagRequired("endpoint")
+
+	return cmd
+}
+
+// runLimits executes the endpoint limits command.
+func runLimits(ctx context.Context, profile, formatStr, endpointFQDN string, out interface{ Write([]byte) (int, error) }) error {
+	// Load token
+	token, err := cli.LoadToken(profile)
+	if err != nil {
+		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+
+	// Check if token is valid
+	if !token.IsValid() {
+		return fmt.Errorf("token expired, please login again")
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	// Create GCS client
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("create GCS client: %w", err)
+	}
+
+	// Get limits
+	limits, err := gcsClient.GetLimits(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Output based on format
+	if formatter.IsStructured() {
+		return formatter.PrintData(limits)
+	}
+
+	// Text format
+	return formatLimitsText(formatter, limits)
+}
+
+// formatLimitsText formats endpoint limits as text.
+func formatLimitsText(formatter *output.Formatter, limits *gcs.Limits) error {
+	if err := formatter.Println("Endpoint Limits"); err != nil {
+		return err
+	}
+	if err := formatter.Println("==============="); err != nil {
+		return err
+	}
+	if err := formatter.Println(); err != nil {
+		return err
+	}
+
+	subscription := "none (unmanaged endpoint)"
+	if limits.Managed {
+		subscription = limits.SubscriptionID
+	}
+
+	rows := []struct {
+		label string
+		value string
+	}{
+		{"Subscription:", subscription},
+		{"Collections:", usage(limits.CollectionCount, limits.MaxCollections)},
+		{"Nodes:", usage(limits.NodeCount, limits.MaxNodes)},
+		{"Guest Collections:", available(limits.GuestCollections)},
+		{"High Availability:", available(limits.HighAvailability)},
+	}
+	for _, row := range rows {
+		if err := formatter.PrintText("%-22s%s\n", row.label, row.value); err != nil {
+			return err
+		}
+	}
+
+	if limits.Source == gcs.LimitsSourceDerived {
+		if err := formatter.Println(); err != nil {
+			return err
+		}
+		if err := formatter.Println("Note: Limits derived from subscription status (endpoint does not report limits)"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// usage formats a count against an optional maximum.
+func usage(count, limit int) string {
+	if limit <= 0 {
+		return fmt.Sprintf("%d (no limit)", count)
+	}
+	return strconv.Itoa(count) + " of " + strconv.Itoa(limit)
+}
+
+// available formats whether a feature is available.
+func available(ok bool) string {
+	if ok {
+		return "Available"
+	}
+	return "Requires subscription"
+}
@@ -0,0 +1,85 @@
+package endpoint
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+)
+
+// formatMarkdown is the extra output format accepted with --release-notes.
+const formatMarkdown = "markdown"
+
+// releaseNoteTitles are the display titles of release note sections.
+var releaseNoteTitles = map[string]string{
+	gcs.ReleaseNoteBreaking: "Breaking Changes",
+	gcs.ReleaseNoteSecurity: "Security Fixes",
+	gcs.ReleaseNoteFeatures: "New Features",
+	gcs.ReleaseNoteFixes:    "Bug Fixes",
+	gcs.ReleaseNoteOther:    "Other Changes",
+}
+
+// writeReleaseNotes renders release notes grouped by section, as Markdown
+// or as plain text. When the notes cover several releases, each item is
+// tagged with its version.
+func writeReleaseNotes(w io.Writer, notes *gcs.ReleaseNotes, markdown bool) error {
+	var b strings.Builder
+
+	title := fmt.Sprintf("GCS upgrade %s -> %s", notes.FromVersion, notes.ToVersion)
+	if markdown {
+		fmt.Fprintf(&b, "## %s\n", title)
+	} else {
+		fmt.Fprintf(&b, "%s\n%s\n", title, strings.Repeat("=", len(title)))
+	}
+
+	if len(notes.Releases) == 0 {
+		b.WriteString("\nNo release notes available.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	if len(notes.Releases) > 1 || notes.Releases[0].Date != "" {
+		versions := make([]string, 0, len(notes.Releases))
+		for _, r := range notes.Releases {
+			v := r.Version
+			if r.Date != "" {
+				v += " (" + r.Date + ")"
+			}
+			versions = append(versions, v)
+		}
+		fmt.Fprintf(&b, "\nReleases: %s\n", strings.Join(versions, ", "))
+	}
+
+	tagged := len(notes.Releases) > 1
+	for _, section := range gcs.ReleaseNoteSections {
+		var items []string
+		for _, r := range notes.Releases {
+			for _, item := range r.Section(section) {
+				if tagged {
+					item += " (" + r.Version + ")"
+				}
+				items = append(items, item)
+			}
+		}
+		if len(items) == 0 {
+			continue
+		}
+
+		if markdown {
+			fmt.Fprintf(&b, "\n### %s\n\n", releaseNoteTitles[section])
+		} else {
+			fmt.Fprintf(&b, "\n%s:\n", releaseNoteTitles[section])
+		}
+		for _, item := range items {
+			if markdown {
+				fmt.Fprintf(&b, "- %s\n", item)
+			} else {
+				fmt.Fprintf(&b, "  - %s\n", item)
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -5,8 +5,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -18,6 +18,7 @@ func NewShowCmd() *cobra.Command {
 		profile      string
 		format       string
 		endpointFQDN string
+		full         bool
 	)
 
 	cmd := &cobra.Command{
@@ -28,24 +29,29 @@ func NewShowCmd() *cobra.Command {
 This command retrieves and displays the current configuration of a GCS endpoint
 including display name, organization, contact information, and other settings.
 
+With --full, the endpoint's storage gateways, collections, nodes, and roles
+are fetched in parallel and shown with it as a single snapshot of the whole
+deployment (text sections, or one nested JSON document with --format json).
+
 Requires an active authentication session (use 'login' first).`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runShow(cmd.Context(), profile, format, endpointFQDN, cmd.OutOrStdout())
+			return runShow(cmd.Context(), profile, format, endpointFQDN, full, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	cmd.Flags().BoolVar(&full, "full", false, "Include storage gateways, collections, nodes, and roles")
 	_ = cmd.MarkFlagRequired("endpoint")
 
 	return cmd
 }
 
 // runShow executes the endpoint show command.
-func runShow(ctx context.Context, profile, formatStr, endpointFQDN string, out interface{ Write([]byte) (int, error) }) error {
+func runShow(ctx context.Context, profile, formatStr, endpointFQDN string, full bool, out interface{ Write([]byte) (int, error) }) error {
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -56,13 +62,10 @@ func runShow(ctx context.Context, profile, formatStr, endpointFQDN string, out i
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -73,9 +76,17 @@ func runShow(ctx context.Context, profile, formatStr, endpointFQDN string, out i
 		return fmt.Errorf("get endpoint: %w", err)
 	}
 
+	if full {
+		snapshot := fetchSnapshot(ctx, gcsClient, endpoint)
+		if err := printSnapshot(formatter, snapshot); err != nil {
+			return err
+		}
+		return snapshotError(snapshot)
+	}
+
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(endpoint)
+	if formatter.IsStructured() {
+		return formatter.PrintData(endpoint)
 	}
 
 	// Text format
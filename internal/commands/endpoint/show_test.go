@@ -5,7 +5,7 @@ import (
 	"context"
 	"testing"
 
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 )
 
 func TestNewShowCmd(t *testing.T) {
@@ -62,6 +62,13 @@ func TestNewShowCmd_Flags(t *testing.T) {
 			shorthand: "",
 			required:  true,
 		},
+		{
+			name:         "full flag",
+			flagName:     "full",
+			shorthand:    "",
+			defaultValue: "false",
+			required:     false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -97,7 +104,7 @@ func TestRunShow_NoToken(t *testing.T) {
 	buf := &bytes.Buffer{}
 
 	// Test with a profile that doesn't exist
-	err := runShow(ctx, "nonexistent-profile-test", "text", "test.example.org", buf)
+	err := runShow(ctx, "nonexistent-profile-test", "text", "test.example.org", false, buf)
 	if err == nil {
 		t.Error("runShow() expected error for nonexistent profile, got nil")
 	}
@@ -113,7 +120,7 @@ func TestRunShow_EmptyEndpoint(t *testing.T) {
 	buf := &bytes.Buffer{}
 
 	// Test with empty endpoint FQDN
-	err := runShow(ctx, "default", "text", "", buf)
+	err := runShow(ctx, "default", "text", "", false, buf)
 	if err == nil {
 		t.Error("runShow() expected error for empty endpoint FQDN, got nil")
 	}
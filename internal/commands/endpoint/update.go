@@ -5,8 +5,8 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -59,7 +59,7 @@ Requires an active authentication session (use 'login' first).`,
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 	cmd.Flags().StringVar(&displayName, "display-name", "", "Display name for the endpoint")
 	cmd.Flags().StringVar(&organization, "organization", "", "Organization name")
@@ -70,7 +70,7 @@ Requires an active authentication session (use 'login' first).`,
 	cmd.Flags().StringVar(&infoLink, "info-link", "", "Information link URL")
 
 	// Boolean flags need special handling
-	publicStr := cmd.Flags().String("public", "", "Make endpoint public (true/false)")
+	publicStr := cli.Enum(cmd, "public", "", "Make endpoint public (true/false)", cli.BoolChoices...)
 	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
 		if *publicStr != "" {
 			val := *publicStr == "true"
@@ -84,9 +84,10 @@ Requires an active authentication session (use 'login' first).`,
 		return nil
 	}
 
-	cmd.Flags().String("disable-anonymous-writes", "", "Disable anonymous writes (true/false)")
+	cli.Enum(cmd, "disable-anonymous-writes", "", "Disable anonymous writes (true/false)", cli.BoolChoices...)
 	cmd.Flags().StringVar(&defaultDirectory, "default-directory", "", "Default directory for transfers")
-	cmd.Flags().StringVar(&networkUse, "network-use", "", "Network use policy (normal, minimal, aggressive, custom)")
+	cli.EnumVar(cmd, &networkUse, "network-use", "", "Network use policy (normal, minimal, aggressive, custom)",
+		gcs.NetworkUseNormal, gcs.NetworkUseMinimal, gcs.NetworkUseAggressive, gcs.NetworkUseCustom)
 	cmd.Flags().IntVar(&maxConcurrency, "max-concurrency", 0, "Maximum transfer concurrency")
 	cmd.Flags().IntVar(&preferredConcurrency, "preferred-concurrency", 0, "Preferred transfer concurrency")
 	cmd.Flags().StringVar(&keywords, "keywords", "", "Comma-separated keywords")
@@ -105,7 +106,7 @@ func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN string,
 	out interface{ Write([]byte) (int, error) }) error {
 
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -116,13 +117,10 @@ func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN string,
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -140,8 +138,8 @@ func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN string,
 	}
 
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(updated)
+	if formatter.IsStructured() {
+		return formatter.PrintData(updated)
 	}
 
 	// Text format
@@ -6,22 +6,41 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
 )
 
+// upgradeOptions holds the endpoint upgrade command flags.
+type upgradeOptions struct {
+	force        bool
+	check        bool
+	exitCode     bool
+	noWait       bool
+	skipVerify   bool
+	releaseNotes bool
+	pollInterval time.Duration
+	waitTimeout  time.Duration
+}
+
+// Exit statuses of 'endpoint upgrade --check --exit-code' when an upgrade
+// is available. A current endpoint exits 0.
+const (
+	upgradeExitAvailable    = 10
+	upgradeExitIncompatible = 11
+)
+
 // NewUpgradeCmd creates the endpoint upgrade command.
 func NewUpgradeCmd() *cobra.Command {
 	var (
 		profile      string
 		format       string
 		endpointFQDN string
-		force        bool
-		check        bool
+		opts         upgradeOptions
 	)
 
 	cmd := &cobra.Command{
@@ -33,12 +52,39 @@ This command performs version compatibility checks, pre-upgrade validation,
 and post-upgrade verification. Use --check to see available upgrades without
 performing the upgrade.
 
+Use --release-notes to print what changed between the current and latest
+versions, grouped into breaking changes, security fixes, new features,
+and bug fixes, without upgrading. Add --format markdown for notes ready
+to paste into a change ticket.
+
+Upgrades can take many minutes. After starting the upgrade, the command
+polls the endpoint and displays progress until the upgrade finishes, then
+verifies that the manager version changed, all nodes are healthy, and all
+collections pass validation. Use --no-wait to return as soon as the upgrade
+has started, or --skip-verify to skip the post-upgrade checks.
+
+With --check --exit-code, the exit status reports the result for monitoring:
+  0   the endpoint is at the latest version
+  10  an upgrade is available
+  11  an upgrade is available but is not compatible with this endpoint
+Any other non-zero status means the check itself failed.
+
 Example:
   # Check for available upgrades
   globus-connect-server endpoint upgrade \
     --endpoint example.data.globus.org \
     --check
 
+  # Report upgrade availability through the exit status
+  globus-connect-server endpoint upgrade \
+    --endpoint example.data.globus.org \
+    --check --exit-code
+
+  # Release notes for a change ticket
+  globus-connect-server endpoint upgrade \
+    --endpoint example.data.globus.org \
+    --release-notes --format markdown
+
   # Perform upgrade
   globus-connect-server endpoint upgrade \
     --endpoint example.data.globus.org
@@ -48,17 +94,34 @@ Example:
     --endpoint example.data.globus.org \
     --force
 
+  # Start the upgrade without waiting for it to finish
+  globus-connect-server endpoint upgrade \
+    --endpoint example.data.globus.org \
+    --no-wait
+
 Requires an active authentication session (use 'login' first).`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runUpgrade(cmd.Context(), profile, format, endpointFQDN, force, check, cmd.OutOrStdout())
+			err := runUpgrade(cmd.Context(), profile, format, endpointFQDN, opts, cmd.OutOrStdout())
+			if err != nil && !cli.IsFailure(err) {
+				// A status-only exit is a result, not an error to report
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+			}
+			return err
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml; markdown with --release-notes)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
-	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt")
-	cmd.Flags().BoolVar(&check, "check", false, "Check for available upgrades without performing upgrade")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&opts.check, "check", false, "Check for available upgrades without performing upgrade")
+	cmd.Flags().BoolVar(&opts.exitCode, "exit-code", false, "With --check, exit 10 if an upgrade is available and 11 if it is incompatible")
+	cmd.Flags().BoolVar(&opts.noWait, "no-wait", false, "Return once the upgrade has started without monitoring progress")
+	cmd.Flags().BoolVar(&opts.skipVerify, "skip-verify", false, "Skip post-upgrade verification checks")
+	cmd.Flags().BoolVar(&opts.releaseNotes, "release-notes", false, "Show release notes between the current and latest versions without upgrading")
+	cmd.Flags().DurationVar(&opts.pollInterval, "poll-interval", 10*time.Second, "Interval between upgrade progress checks")
+	cmd.Flags().DurationVar(&opts.waitTimeout, "wait-timeout", 30*time.Minute, "Maximum time to wait for the upgrade to finish")
 
 	_ = cmd.MarkFlagRequired("endpoint")
 
@@ -66,9 +129,19 @@ Requires an active authentication session (use 'login' first).`,
 }
 
 // runUpgrade executes the endpoint upgrade command.
-func runUpgrade(ctx context.Context, profile, formatStr, endpointFQDN string, force, check bool, out interface{ Write([]byte) (int, error) }) error {
+func runUpgrade(ctx context.Context, profile, formatStr, endpointFQDN string, opts upgradeOptions, out interface{ Write([]byte) (int, error) }) error {
+	if opts.exitCode && !opts.check {
+		return fmt.Errorf("--exit-code requires --check")
+	}
+	if opts.releaseNotes && opts.check {
+		return fmt.Errorf("--release-notes cannot be combined with --check")
+	}
+	if formatStr == formatMarkdown && !opts.releaseNotes {
+		return fmt.Errorf("--format markdown requires --release-notes")
+	}
+
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -79,13 +152,10 @@ func runUpgrade(ctx context.Context, profile, formatStr, endpointFQDN string, fo
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -96,9 +166,20 @@ func runUpgrade(ctx context.Context, profile, formatStr, endpointFQDN string, fo
 		return fmt.Errorf("check endpoint upgrade: %w", err)
 	}
 
+	// If --release-notes flag, just display what changed
+	if opts.releaseNotes {
+		return displayReleaseNotes(ctx, gcsClient, formatStr, upgradeInfo, out)
+	}
+
 	// If --check flag, just display upgrade information
-	if check {
-		return displayUpgradeInfo(formatter, upgradeInfo)
+	if opts.check {
+		if err := displayUpgradeInfo(formatter, upgradeInfo); err != nil {
+			return err
+		}
+		if opts.exitCode {
+			return upgradeCheckExit(upgradeInfo)
+		}
+		return nil
 	}
 
 	// Check if upgrade is needed
@@ -112,26 +193,187 @@ func runUpgrade(ctx context.Context, profile, formatStr, endpointFQDN string, fo
 	}
 
 	// Confirmation prompt unless --force
-	if !force {
+	if !opts.force {
 		if err := confirmUpgrade(upgradeInfo); err != nil {
 			return err
 		}
 	}
 
-	// Perform upgrade
-	result, err := gcsClient.UpgradeEndpoint(ctx)
+	// Starting an upgrade can take longer than the usual request timeout
+	result, err := gcsClient.UpgradeEndpoint(gcs.WithRequestTimeout(ctx, opts.waitTimeout))
 	if err != nil {
 		return fmt.Errorf("upgrade endpoint: %w", err)
 	}
 
+	report := &upgradeReport{UpgradeResult: result}
+	if !result.Success || opts.noWait {
+		return displayUpgradeReport(formatter, report)
+	}
+
+	// Monitor progress until the upgrade finishes
+	waitCtx, cancel := context.WithTimeout(ctx, opts.waitTimeout)
+	defer cancel()
+
+	progress := newProgressPrinter(formatter)
+	report.Progress, err = gcsClient.WaitForUpgrade(waitCtx, upgradeInfo.LatestVersion, opts.pollInterval, progress.update)
+	progress.done()
+	if err != nil {
+		return err
+	}
+
+	// Verify the upgraded endpoint
+	if !opts.skipVerify {
+		report.Verification, err = gcsClient.VerifyUpgrade(ctx, upgradeInfo.CurrentVersion)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Display results
-	return displayUpgradeResult(formatter, result)
+	return displayUpgradeReport(formatter, report)
+}
+
+// displayReleaseNotes fetches and displays the release notes between the
+// current and latest versions.
+func displayReleaseNotes(ctx context.Context, gcsClient gcs.GCSAPI, formatStr string, info *gcs.UpgradeInfo, out interface{ Write([]byte) (int, error) }) error {
+	notes := &gcs.ReleaseNotes{FromVersion: info.CurrentVersion, ToVersion: info.CurrentVersion, Releases: []gcs.Release{}}
+	if info.UpgradeRequired {
+		var err error
+		notes, err = gcsClient.GetReleaseNotes(ctx, info.CurrentVersion, info.LatestVersion)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch formatStr {
+	case string(output.FormatJSON), string(output.FormatYAML):
+		return output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...).PrintData(notes)
+	case formatMarkdown:
+		return writeReleaseNotes(out, notes, true)
+	default:
+		if !info.UpgradeRequired {
+			return handleNoUpgradeNeeded(output.NewFormatter(output.FormatText, out), info)
+		}
+		return writeReleaseNotes(out, notes, false)
+	}
+}
+
+// upgradeCheckExit returns the status-only exit for an upgrade check, or
+// nil when the endpoint is current.
+func upgradeCheckExit(info *gcs.UpgradeInfo) error {
+	switch {
+	case !info.UpgradeRequired:
+		return nil
+	case !info.Compatible:
+		return &cli.ExitError{Code: upgradeExitIncompatible}
+	default:
+		return &cli.ExitError{Code: upgradeExitAvailable}
+	}
+}
+
+// upgradeReport combines the upgrade result with the final progress status
+// and the post-upgrade verification.
+type upgradeReport struct {
+	*gcs.UpgradeResult
+	Progress     *gcs.UpgradeStatus       `json:"progress,omitempty"`
+	Verification *gcs.UpgradeVerification `json:"verification,omitempty"`
+}
+
+// progressPrinter displays upgrade progress on stderr in text mode.
+type progressPrinter struct {
+	enabled bool
+	last    string
+}
+
+// newProgressPrinter creates a progress printer for the given formatter.
+func newProgressPrinter(formatter *output.Formatter) *progressPrinter {
+	return &progressPrinter{enabled: !formatter.IsStructured()}
+}
+
+// update displays a progress status.
+func (p *progressPrinter) update(status *gcs.UpgradeStatus) {
+	if !p.enabled {
+		return
+	}
+
+	line := fmt.Sprintf("[%3d%%] %s", status.Progress, status.State)
+	if status.Step != "" {
+		line += ": " + status.Step
+	} else if status.Message != "" {
+		line += ": " + status.Message
+	}
+	if line == p.last {
+		return
+	}
+	p.last = line
+	fmt.Fprintln(os.Stderr, line)
+}
+
+// done finishes the progress display.
+func (p *progressPrinter) done() {
+	if p.enabled && p.last != "" {
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+// displayUpgradeReport displays the upgrade result, progress, and verification.
+func displayUpgradeReport(formatter *output.Formatter, report *upgradeReport) error {
+	if formatter.IsStructured() {
+		if err := formatter.PrintData(report); err != nil {
+			return err
+		}
+		if !report.Success {
+			return fmt.Errorf("upgrade failed")
+		}
+		if report.Verification != nil && !report.Verification.Passed {
+			return fmt.Errorf("post-upgrade verification failed")
+		}
+		return nil
+	}
+
+	if err := displayUpgradeResult(formatter, report.UpgradeResult); err != nil {
+		return err
+	}
+
+	if report.Progress != nil && report.Progress.CurrentVersion != "" {
+		if err := formatter.PrintText("Running Version:  %s\n", report.Progress.CurrentVersion); err != nil {
+			return err
+		}
+	}
+
+	if report.Verification == nil {
+		return nil
+	}
+
+	if err := formatter.Println(); err != nil {
+		return err
+	}
+	if err := formatter.Println(formatter.Heading("Post-Upgrade Verification")); err != nil {
+		return err
+	}
+	if err := formatter.Println("========================="); err != nil {
+		return err
+	}
+	for _, check := range report.Verification.Checks {
+		mark := formatter.Success("✓")
+		if !check.Passed {
+			mark = formatter.Failure("✗")
+		}
+		if err := formatter.PrintText("%s %-12s %s\n", mark, check.Name, check.Message); err != nil {
+			return err
+		}
+	}
+
+	if !report.Verification.Passed {
+		return fmt.Errorf("post-upgrade verification failed")
+	}
+	return nil
 }
 
 // handleNoUpgradeNeeded handles the case when no upgrade is needed.
 func handleNoUpgradeNeeded(formatter *output.Formatter, info *gcs.UpgradeInfo) error {
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(map[string]interface{}{
+	if formatter.IsStructured() {
+		return formatter.PrintData(map[string]interface{}{
 			"message":         "Endpoint is already at the latest version",
 			"current_version": info.CurrentVersion,
 		})
@@ -141,11 +383,11 @@ func handleNoUpgradeNeeded(formatter *output.Formatter, info *gcs.UpgradeInfo) e
 
 // displayUpgradePrompt displays upgrade information before performing the upgrade.
 func displayUpgradePrompt(formatter *output.Formatter, info *gcs.UpgradeInfo) error {
-	if formatter.IsJSON() {
+	if formatter.IsStructured() {
 		return nil // Don't display in JSON mode
 	}
 
-	if err := formatter.Println("Upgrade Available"); err != nil {
+	if err := formatter.Println(formatter.Heading("Upgrade Available")); err != nil {
 		return err
 	}
 	if err := formatter.Println("================="); err != nil {
@@ -161,11 +403,11 @@ func displayUpgradePrompt(formatter *output.Formatter, info *gcs.UpgradeInfo) er
 		return err
 	}
 	if info.Compatible {
-		if err := formatter.Println("Compatibility:   Compatible"); err != nil {
+		if err := formatter.PrintText("Compatibility:   %s\n", formatter.Success("Compatible")); err != nil {
 			return err
 		}
 	} else {
-		if err := formatter.Println("Compatibility:   Incompatible (review release notes)"); err != nil {
+		if err := formatter.PrintText("Compatibility:   %s\n", formatter.Warning("Incompatible (review release notes)")); err != nil {
 			return err
 		}
 	}
@@ -178,7 +420,7 @@ func displayUpgradePrompt(formatter *output.Formatter, info *gcs.UpgradeInfo) er
 		if err := formatter.Println(); err != nil {
 			return err
 		}
-		if err := formatter.Println("Release Notes:"); err != nil {
+		if err := formatter.Println(formatter.Heading("Release Notes:")); err != nil {
 			return err
 		}
 		if err := formatter.PrintText("%s\n", info.ReleaseNotes); err != nil {
@@ -206,13 +448,13 @@ func confirmUpgrade(info *gcs.UpgradeInfo) error {
 
 // displayUpgradeResult displays the result of the upgrade operation.
 func displayUpgradeResult(formatter *output.Formatter, result *gcs.UpgradeResult) error {
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(result)
+	if formatter.IsStructured() {
+		return formatter.PrintData(result)
 	}
 
 	// Text format
 	if !result.Success {
-		if err := formatter.Println("Endpoint upgrade failed"); err != nil {
+		if err := formatter.Println(formatter.Failure("Endpoint upgrade failed")); err != nil {
 			return err
 		}
 		if result.Message != "" {
@@ -226,7 +468,7 @@ func displayUpgradeResult(formatter *output.Formatter, result *gcs.UpgradeResult
 		return fmt.Errorf("upgrade failed")
 	}
 
-	if err := formatter.Println("Endpoint upgraded successfully!"); err != nil {
+	if err := formatter.Println(formatter.Success("Endpoint upgraded successfully!")); err != nil {
 		return err
 	}
 	if err := formatter.Println(); err != nil {
@@ -263,12 +505,12 @@ func displayUpgradeResult(formatter *output.Formatter, result *gcs.UpgradeResult
 
 // displayUpgradeInfo displays upgrade information without performing the upgrade.
 func displayUpgradeInfo(formatter *output.Formatter, info *gcs.UpgradeInfo) error {
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(info)
+	if formatter.IsStructured() {
+		return formatter.PrintData(info)
 	}
 
 	// Text format
-	if err := formatter.Println("Endpoint Upgrade Information"); err != nil {
+	if err := formatter.Println(formatter.Heading("Endpoint Upgrade Information")); err != nil {
 		return err
 	}
 	if err := formatter.Println("============================"); err != nil {
@@ -289,7 +531,7 @@ func displayUpgradeInfo(formatter *output.Formatter, info *gcs.UpgradeInfo) erro
 		}
 	}
 	if info.UpgradeRequired {
-		if err := formatter.PrintText("%-20s%s\n", "Upgrade Required:", "Yes"); err != nil {
+		if err := formatter.PrintText("%-20s%s\n", "Upgrade Required:", formatter.Warning("Yes")); err != nil {
 			return err
 		}
 	} else {
@@ -302,7 +544,7 @@ func displayUpgradeInfo(formatter *output.Formatter, info *gcs.UpgradeInfo) erro
 			return err
 		}
 	} else {
-		if err := formatter.PrintText("%-20s%s\n", "Compatible:", "No"); err != nil {
+		if err := formatter.PrintText("%-20s%s\n", "Compatible:", formatter.Failure("No")); err != nil {
 			return err
 		}
 	}
@@ -316,7 +558,7 @@ func displayUpgradeInfo(formatter *output.Formatter, info *gcs.UpgradeInfo) erro
 		if err := formatter.Println(); err != nil {
 			return err
 		}
-		if err := formatter.Println("Release Notes:"); err != nil {
+		if err := formatter.Println(formatter.Heading("Release Notes:")); err != nil {
 			return err
 		}
 		if err := formatter.PrintText("%s\n", info.ReleaseNotes); err != nil {
@@ -0,0 +1,83 @@
+package endpoint
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+)
+
+func TestUpgradeCheckExit(t *testing.T) {
+	tests := []struct {
+		name string
+		info gcs.UpgradeInfo
+		want int
+	}{
+		{name: "current", info: gcs.UpgradeInfo{UpgradeRequired: false, Compatible: true}, want: 0},
+		{name: "available", info: gcs.UpgradeInfo{UpgradeRequired: true, Compatible: true}, want: upgradeExitAvailable},
+		{name: "incompatible", info: gcs.UpgradeInfo{UpgradeRequired: true, Compatible: false}, want: upgradeExitIncompatible},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := upgradeCheckExit(&tt.info)
+			if got := cli.ExitCode(err); got != tt.want {
+				t.Errorf("upgradeCheckExit() exit code = %d, want %d", got, tt.want)
+			}
+			if cli.IsFailure(err) {
+				t.Errorf("upgradeCheckExit() = %v, want a status-only result", err)
+			}
+		})
+	}
+}
+
+func TestRunUpgrade_ExitCodeRequiresCheck(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	err := runUpgrade(context.Background(), "nonexistent-profile-test", "text", "test.example.org", upgradeOptions{exitCode: true}, buf)
+	if err == nil || err.Error() != "--exit-code requires --check" {
+		t.Errorf("runUpgrade() error = %v, want --exit-code requires --check", err)
+	}
+}
+
+func TestRunUpgrade_MarkdownRequiresReleaseNotes(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	err := runUpgrade(context.Background(), "nonexistent-profile-test", formatMarkdown, "test.example.org", upgradeOptions{}, buf)
+	if err == nil || err.Error() != "--format markdown requires --release-notes" {
+		t.Errorf("runUpgrade() error = %v, want --format markdown requires --release-notes", err)
+	}
+}
+
+func TestWriteReleaseNotes_Markdown(t *testing.T) {
+	notes := &gcs.ReleaseNotes{
+		FromVersion: "5.4.60",
+		ToVersion:   "5.4.70",
+		Releases: []gcs.Release{
+			{Version: "5.4.70", SecurityFixes: []string{"Fix CVE-2024-0001 in the HTTPS server"}},
+			{Version: "5.4.65", BreakingChanges: []string{"Drop support for RHEL 7"}},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := writeReleaseNotes(buf, notes, true); err != nil {
+		t.Fatalf("writeReleaseNotes() error = %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{
+		"## GCS upgrade 5.4.60 -> 5.4.70",
+		"### Breaking Changes\n\n- Drop support for RHEL 7 (5.4.65)",
+		"### Security Fixes\n\n- Fix CVE-2024-0001 in the HTTPS server (5.4.70)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("writeReleaseNotes() missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "Breaking Changes") > strings.Index(got, "Security Fixes") {
+		t.Errorf("breaking changes should be listed before security fixes:\n%s", got)
+	}
+}
@@ -0,0 +1,349 @@
+// Package examples provides the command that prints multi-step recipes
+// for common endpoint tasks.
+package examples
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// NewExamplesCmd creates the examples command.
+func NewExamplesCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "examples [topic]",
+		Short: "Show step-by-step recipes for common tasks",
+		Long: `Show copy-pasteable recipes that chain several commands together.
+
+Without a topic, lists the available recipes. Each step is built from the
+installed commands: its description is the command's own summary, and a
+recipe that names a command or flag this version does not have is
+reported as an error rather than printed.
+
+Steps that create a resource capture its ID in a shell variable with
+--quiet, so later steps can refer to it.
+
+Example:
+  globus-connect-server examples
+  globus-connect-server examples gateway-collection`,
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: topics(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			topic := ""
+			if len(args) > 0 {
+				topic = args[0]
+			}
+			return runExamples(cmd.Root(), format, topic, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+
+	return cmd
+}
+
+// recipe is a sequence of commands that accomplishes a task.
+type recipe struct {
+	topic string
+	title string
+	// setup lists shell variable assignments the user edits first.
+	setup []string
+	steps []step
+	// notes is printed after the steps.
+	notes string
+}
+
+// step is one command of a recipe.
+type step struct {
+	// command is the command path without the root command name.
+	command string
+	args    []string
+	flags   []flagValue
+	// capture names a shell variable that receives the IDs printed by the
+	// command with --quiet.
+	capture string
+}
+
+// flagValue is a flag of a step. Boolean flags have an empty value.
+type flagValue struct {
+	name  string
+	value string
+}
+
+// recipes are the recipes shown by the examples command, in order.
+var recipes = []recipe{
+	{
+		topic: "gateway-collection",
+		title: "Create a storage gateway and collection, and delegate access management",
+		setup: []string{
+			"ENDPOINT=abc.def.data.globus.org",
+			"MANAGER=user@example.org",
+		},
+		steps: []step{
+			{
+				command: "storage-gateway create",
+				flags: []flagValue{
+					{"endpoint", "$ENDPOINT"},
+					{"display-name", "Project Storage"},
+					{"connector-id", "posix"},
+					{"root", "/data"},
+				},
+				capture: "GATEWAY_ID",
+			},
+			{
+				command: "collection create",
+				flags: []flagValue{
+					{"endpoint", "$ENDPOINT"},
+					{"display-name", "Project Data"},
+					{"storage-gateway-id", "$GATEWAY_ID"},
+					{"collection-base-path", "/project"},
+				},
+				capture: "COLLECTION_ID",
+			},
+			{
+				command: "role create",
+				flags: []flagValue{
+					{"endpoint", "$ENDPOINT"},
+					{"collection", "$COLLECTION_ID"},
+					{"principal", "$MANAGER"},
+					{"role", "access_manager"},
+				},
+			},
+		},
+	},
+	{
+		topic: "rotate-node-secrets",
+		title: "Rotate the authentication secret of every node",
+		setup: []string{
+			"ENDPOINT=abc.def.data.globus.org",
+		},
+		steps: []step{
+			{
+				command: "node list",
+				flags: []flagValue{
+					{"endpoint", "$ENDPOINT"},
+				},
+				capture: "NODE_IDS",
+			},
+			{
+				command: "node new-secret",
+				args:    []string{"$NODE_ID"},
+				flags: []flagValue{
+					{"endpoint", "$ENDPOINT"},
+				},
+			},
+		},
+		notes: `Run the second step for each ID in $NODE_IDS, e.g. in a
+"for NODE_ID in $NODE_IDS; do ...; done" loop. Each new secret is shown
+only once; store it before rotating the next node.`,
+	},
+	{
+		topic: "custom-domain",
+		title: "Serve the endpoint from a custom domain",
+		setup: []string{
+			"ENDPOINT=abc.def.data.globus.org",
+			"DOMAIN=data.example.org",
+		},
+		steps: []step{
+			{
+				command: "endpoint domain setup",
+				flags: []flagValue{
+					{"endpoint", "$ENDPOINT"},
+					{"domain", "$DOMAIN"},
+					{"certificate", "/etc/ssl/certs/$DOMAIN.pem"},
+					{"private-key", "/etc/ssl/private/$DOMAIN.key"},
+				},
+			},
+			{
+				command: "endpoint domain show",
+				flags: []flagValue{
+					{"endpoint", "$ENDPOINT"},
+				},
+			},
+		},
+		notes: `Point a DNS CNAME record for $DOMAIN at $ENDPOINT before running the
+first step. The certificate must cover $DOMAIN.`,
+	},
+}
+
+// topics returns the recipe topics.
+func topics() []string {
+	names := make([]string, len(recipes))
+	for i, r := range recipes {
+		names[i] = r.topic
+	}
+	return names
+}
+
+// findRecipe returns the recipe for topic.
+func findRecipe(topic string) (recipe, error) {
+	for _, r := range recipes {
+		if r.topic == topic {
+			return r, nil
+		}
+	}
+	names := topics()
+	sort.Strings(names)
+	return recipe{}, fmt.Errorf("unknown topic %q (available: %s)", topic, strings.Join(names, ", "))
+}
+
+// RecipeSummary describes a recipe in the topic list.
+type RecipeSummary struct {
+	Topic string `json:"topic"`
+	Title string `json:"title"`
+}
+
+// Recipe is a recipe resolved against the command tree.
+type Recipe struct {
+	Topic string   `json:"topic"`
+	Title string   `json:"title"`
+	Setup []string `json:"setup,omitempty"`
+	Steps []Step   `json:"steps"`
+	Notes string   `json:"notes,omitempty"`
+}
+
+// Step is a resolved recipe step.
+type Step struct {
+	// Description is the Short description of the command.
+	Description string `json:"description"`
+
+	// Command is the shell command line, continued over several lines.
+	Command string `json:"command"`
+}
+
+// runExamples executes the examples command.
+func runExamples(root *cobra.Command, formatStr, topic string, out interface{ Write([]byte) (int, error) }) error {
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	if topic == "" {
+		summaries := make([]RecipeSummary, len(recipes))
+		for i, r := range recipes {
+			summaries[i] = RecipeSummary{Topic: r.topic, Title: r.title}
+		}
+		if formatter.IsStructured() {
+			return formatter.PrintData(summaries)
+		}
+
+		if err := formatter.Println("Recipes:"); err != nil {
+			return err
+		}
+		if err := formatter.Println(); err != nil {
+			return err
+		}
+		for _, s := range summaries {
+			if err := formatter.PrintText("  %-22s %s\n", s.Topic, s.Title); err != nil {
+				return err
+			}
+		}
+		if err := formatter.Println(); err != nil {
+			return err
+		}
+		return formatter.PrintText("Run '%s examples TOPIC' to show a recipe.\n", root.Name())
+	}
+
+	r, err := findRecipe(topic)
+	if err != nil {
+		return err
+	}
+	resolved, err := resolve(root, r)
+	if err != nil {
+		return err
+	}
+
+	// Output based on format
+	if formatter.IsStructured() {
+		return formatter.PrintData(resolved)
+	}
+
+	// Text format
+	if err := formatter.PrintText("# %s\n", resolved.Title); err != nil {
+		return err
+	}
+	if len(resolved.Setup) > 0 {
+		if err := formatter.Println(); err != nil {
+			return err
+		}
+		for _, line := range resolved.Setup {
+			if err := formatter.Println(line); err != nil {
+				return err
+			}
+		}
+	}
+	for i, s := range resolved.Steps {
+		if err := formatter.PrintText("\n# %d. %s\n%s\n", i+1, s.Description, s.Command); err != nil {
+			return err
+		}
+	}
+	if resolved.Notes != "" {
+		if err := formatter.Println(); err != nil {
+			return err
+		}
+		for _, line := range strings.Split(resolved.Notes, "\n") {
+			if err := formatter.PrintText("# %s\n", line); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolve looks up each step of r in the command tree under root and
+// renders its command line. It fails if a command or flag no longer
+// exists, so recipes cannot drift from the commands they describe.
+func resolve(root *cobra.Command, r recipe) (Recipe, error) {
+	resolved := Recipe{Topic: r.topic, Title: r.title, Setup: r.setup, Notes: r.notes}
+
+	for _, s := range r.steps {
+		path := strings.Fields(s.command)
+		cmd, rest, err := root.Find(path)
+		if err != nil || len(rest) > 0 || cli.CommandPath(cmd) != s.command {
+			return Recipe{}, fmt.Errorf("recipe %q: no %q command", r.topic, s.command)
+		}
+
+		flags := s.flags
+		if s.capture != "" {
+			flags = append(flags[:len(flags):len(flags)], flagValue{name: "quiet"})
+		}
+
+		parts := append([]string{root.Name()}, path...)
+		for _, arg := range s.args {
+			parts = append(parts, shellQuote(arg))
+		}
+		lines := []string{strings.Join(parts, " ")}
+		for _, f := range flags {
+			if cmd.Flags().Lookup(f.name) == nil && cmd.InheritedFlags().Lookup(f.name) == nil {
+				return Recipe{}, fmt.Errorf("recipe %q: %q has no --%s flag", r.topic, s.command, f.name)
+			}
+			line := "--" + f.name
+			if f.value != "" {
+				line += " " + shellQuote(f.value)
+			}
+			lines = append(lines, line)
+		}
+
+		command := strings.Join(lines, " \\\n    ")
+		if s.capture != "" {
+			command = s.capture + "=$(" + command + ")"
+		}
+		resolved.Steps = append(resolved.Steps, Step{Description: cmd.Short, Command: command})
+	}
+
+	return resolved, nil
+}
+
+// shellQuote quotes s for a POSIX shell, leaving $VARIABLES expanded.
+func shellQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\n\"'`\\$;&|<>()*?[]{}#~") {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`")
+	return `"` + r.Replace(s) + `"`
+}
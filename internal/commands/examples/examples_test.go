@@ -0,0 +1,128 @@
+package examples
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/commands/collection"
+	"github.com/scttfrdmn/globus-go-gcs/internal/commands/endpoint"
+	"github.com/scttfrdmn/globus-go-gcs/internal/commands/node"
+	"github.com/scttfrdmn/globus-go-gcs/internal/commands/role"
+	"github.com/scttfrdmn/globus-go-gcs/internal/commands/storagegateway"
+	"github.com/spf13/cobra"
+)
+
+// newRoot returns a command tree with the commands used by the recipes.
+func newRoot() *cobra.Command {
+	root := &cobra.Command{Use: "globus-connect-server"}
+	root.AddCommand(collection.NewCollectionCmd())
+	root.AddCommand(endpoint.NewEndpointCmd())
+	root.AddCommand(node.NewNodeCmd())
+	root.AddCommand(role.NewRoleCmd())
+	root.AddCommand(storagegateway.NewStorageGatewayCmd())
+	return root
+}
+
+func TestNewExamplesCmd(t *testing.T) {
+	cmd := NewExamplesCmd()
+
+	if cmd.Use != "examples [topic]" {
+		t.Errorf("NewExamplesCmd() Use = %q, want %q", cmd.Use, "examples [topic]")
+	}
+	if cmd.Short == "" {
+		t.Error("NewExamplesCmd() Short description is empty")
+	}
+	if cmd.RunE == nil {
+		t.Error("NewExamplesCmd() RunE is nil")
+	}
+	if len(cmd.ValidArgs) != len(recipes) {
+		t.Errorf("NewExamplesCmd() ValidArgs = %v, want one per recipe", cmd.ValidArgs)
+	}
+}
+
+func TestRecipes_Resolve(t *testing.T) {
+	root := newRoot()
+	for _, r := range recipes {
+		t.Run(r.topic, func(t *testing.T) {
+			if _, err := resolve(root, r); err != nil {
+				t.Errorf("resolve() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestResolve_StaleFlag(t *testing.T) {
+	r := recipe{
+		topic: "stale",
+		steps: []step{{command: "node list", flags: []flagValue{{"no-such-flag", "x"}}}},
+	}
+	_, err := resolve(newRoot(), r)
+	if err == nil || !strings.Contains(err.Error(), "--no-such-flag") {
+		t.Errorf("resolve() error = %v, want unknown flag error", err)
+	}
+
+	r.steps = []step{{command: "node frobnicate"}}
+	if _, err := resolve(newRoot(), r); err == nil {
+		t.Error("resolve() with unknown command succeeded")
+	}
+}
+
+func TestRunExamples_Text(t *testing.T) {
+	var buf bytes.Buffer
+	if err := runExamples(newRoot(), "text", "gateway-collection", &buf); err != nil {
+		t.Fatalf("runExamples() error = %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{
+		"ENDPOINT=abc.def.data.globus.org\n",
+		"# 1. Create a new storage gateway\n",
+		"GATEWAY_ID=$(globus-connect-server storage-gateway create \\\n    --endpoint \"$ENDPOINT\" \\\n",
+		"--display-name \"Project Storage\"",
+		"--quiet)\n",
+		"--storage-gateway-id \"$GATEWAY_ID\"",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRunExamples_List(t *testing.T) {
+	var buf bytes.Buffer
+	if err := runExamples(newRoot(), "json", "", &buf); err != nil {
+		t.Fatalf("runExamples() error = %v", err)
+	}
+
+	var summaries []RecipeSummary
+	if err := json.Unmarshal(buf.Bytes(), &summaries); err != nil {
+		t.Fatalf("output is not JSON: %v", err)
+	}
+	if len(summaries) != len(recipes) || summaries[0].Topic != recipes[0].topic {
+		t.Errorf("summaries = %+v", summaries)
+	}
+}
+
+func TestRunExamples_UnknownTopic(t *testing.T) {
+	err := runExamples(newRoot(), "text", "nope", &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "custom-domain") {
+		t.Errorf("runExamples() error = %v, want list of topics", err)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"/data":           "/data",
+		"$ENDPOINT":       `"$ENDPOINT"`,
+		"Project Storage": `"Project Storage"`,
+		`say "hi"`:        `"say \"hi\""`,
+		"":                `""`,
+	}
+	for in, want := range tests {
+		if got := shellQuote(in); got != want {
+			t.Errorf("shellQuote(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Package history provides the command for browsing the CLI activity log.
+package history
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/history"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// historyOptions selects which activity log entries to show.
+type historyOptions struct {
+	limit   int
+	command string
+	failed  bool
+	since   time.Duration
+}
+
+// NewHistoryCmd creates the history command.
+func NewHistoryCmd() *cobra.Command {
+	var (
+		format string
+		opts   historyOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the log of CLI commands run on this host",
+		Long: `Show the local activity log of CLI commands.
+
+Every command is appended to ~/.globus-connect-server/history.jsonl with
+its arguments (secrets redacted), profile, endpoint, result, and duration.
+Pass --no-history to any command to leave it out of the log.
+
+Entries are shown oldest first.
+
+Example:
+  globus-connect-server history
+  globus-connect-server history --command "collection delete" --since 168h
+  globus-connect-server history --failed --format json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			path, err := history.GetFilePath()
+			if err != nil {
+				return err
+			}
+			return runHistory(format, path, opts, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "n", 20, "Show at most this many recent entries (0 for all)")
+	cmd.Flags().StringVar(&opts.command, "command", "", "Only show commands starting with this path (e.g., \"collection delete\")")
+	cmd.Flags().BoolVar(&opts.failed, "failed", false, "Only show failed commands")
+	cmd.Flags().DurationVar(&opts.since, "since", 0, "Only show commands run within this duration (e.g., 24h)")
+
+	return cmd
+}
+
+// runHistory executes the history command.
+func runHistory(formatStr, path string, opts historyOptions, out interface{ Write([]byte) (int, error) }) error {
+	entries, err := history.Load(path)
+	if err != nil {
+		return err
+	}
+
+	entries = filterEntries(entries, opts, time.Now())
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	// Output based on format
+	if formatter.IsStructured() {
+		if entries == nil {
+			entries = []history.Entry{}
+		}
+		return formatter.PrintData(entries)
+	}
+
+	// Text format
+	if len(entries) == 0 {
+		return formatter.Println("No history found.")
+	}
+
+	if err := formatter.PrintText("%-20s %-8s %-9s %-30s %s\n", "TIME", "RESULT", "DURATION", "ENDPOINT", "COMMAND"); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := formatter.PrintText("%-20s %-8s %-9s %-30s %s\n",
+			e.Time.Local().Format("2006-01-02 15:04:05"),
+			e.Result,
+			cli.Duration(e.Duration()),
+			displayEndpoint(e.Endpoint),
+			commandLine(e)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// filterEntries applies opts to entries, keeping the most recent limit
+// matches in chronological order.
+func filterEntries(entries []history.Entry, opts historyOptions, now time.Time) []history.Entry {
+	var matched []history.Entry
+	for _, e := range entries {
+		if opts.failed && e.Result != history.ResultFailure {
+			continue
+		}
+		if opts.command != "" && e.Command != opts.command && !strings.HasPrefix(e.Command, opts.command+" ") {
+			continue
+		}
+		if opts.since > 0 && e.Time.Before(now.Add(-opts.since)) {
+			continue
+		}
+		matched = append(matched, e)
+	}
+
+	if opts.limit > 0 && len(matched) > opts.limit {
+		matched = matched[len(matched)-opts.limit:]
+	}
+	return matched
+}
+
+// commandLine reconstructs a readable command line for an entry.
+func commandLine(e history.Entry) string {
+	parts := []string{e.Command}
+	parts = append(parts, e.Args...)
+	for _, name := range sortedKeys(e.Flags) {
+		parts = append(parts, fmt.Sprintf("--%s=%s", name, e.Flags[name]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// displayEndpoint returns a placeholder for commands without an endpoint.
+func displayEndpoint(endpoint string) string {
+	if endpoint == "" {
+		return "-"
+	}
+	return endpoint
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,152 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/history"
+)
+
+func TestNewHistoryCmd(t *testing.T) {
+	cmd := NewHistoryCmd()
+
+	if cmd == nil {
+		t.Fatal("NewHistoryCmd() returned nil")
+	}
+
+	if cmd.Use != "history" {
+		t.Errorf("NewHistoryCmd() Use = %q, want %q", cmd.Use, "history")
+	}
+
+	if cmd.Short == "" {
+		t.Error("NewHistoryCmd() Short description is empty")
+	}
+
+	if cmd.Long == "" {
+		t.Error("NewHistoryCmd() Long description is empty")
+	}
+
+	if cmd.RunE == nil {
+		t.Error("NewHistoryCmd() RunE is nil")
+	}
+}
+
+func TestNewHistoryCmd_Flags(t *testing.T) {
+	cmd := NewHistoryCmd()
+
+	tests := []struct {
+		flagName     string
+		shorthand    string
+		defaultValue string
+	}{
+		{flagName: "format", shorthand: "f", defaultValue: "text"},
+		{flagName: "limit", shorthand: "n", defaultValue: "20"},
+		{flagName: "command", defaultValue: ""},
+		{flagName: "failed", defaultValue: "false"},
+		{flagName: "since", defaultValue: "0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flagName, func(t *testing.T) {
+			flag := cmd.Flags().Lookup(tt.flagName)
+			if flag == nil {
+				t.Fatalf("flag %q not found", tt.flagName)
+			}
+			if flag.Shorthand != tt.shorthand {
+				t.Errorf("flag %q shorthand = %q, want %q", tt.flagName, flag.Shorthand, tt.shorthand)
+			}
+			if flag.DefValue != tt.defaultValue {
+				t.Errorf("flag %q default = %q, want %q", tt.flagName, flag.DefValue, tt.defaultValue)
+			}
+		})
+	}
+}
+
+func writeHistory(t *testing.T, entries ...history.Entry) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), history.FileName)
+	for i := range entries {
+		if err := history.Append(path, &entries[i]); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+	return path
+}
+
+func TestRunHistory_Text(t *testing.T) {
+	path := writeHistory(t,
+		history.Entry{Time: time.Now(), Command: "collection delete", Args: []string{"abc123"},
+			Endpoint: "ep.example.org", Result: history.ResultSuccess,
+			Flags: map[string]string{"endpoint": "ep.example.org"}},
+	)
+	buf := &bytes.Buffer{}
+
+	if err := runHistory("text", path, historyOptions{limit: 20}, buf); err != nil {
+		t.Fatalf("runHistory() error = %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{"COMMAND", "collection delete abc123 --endpoint=ep.example.org", "success"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("runHistory() output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRunHistory_JSONEmpty(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	if err := runHistory("json", filepath.Join(t.TempDir(), history.FileName), historyOptions{}, buf); err != nil {
+		t.Fatalf("runHistory() error = %v", err)
+	}
+
+	var got []history.Entry
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("runHistory() produced invalid JSON: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("runHistory() returned %d entries, want 0", len(got))
+	}
+}
+
+func TestFilterEntries(t *testing.T) {
+	now := time.Now()
+	entries := []history.Entry{
+		{Time: now.Add(-48 * time.Hour), Command: "collection delete", Result: history.ResultSuccess},
+		{Time: now.Add(-2 * time.Hour), Command: "collection list", Result: history.ResultFailure},
+		{Time: now.Add(-time.Hour), Command: "node delete", Result: history.ResultFailure},
+		{Time: now, Command: "collection delete", Result: history.ResultSuccess},
+	}
+
+	tests := []struct {
+		name string
+		opts historyOptions
+		want int
+	}{
+		{name: "all", opts: historyOptions{}, want: 4},
+		{name: "limit", opts: historyOptions{limit: 2}, want: 2},
+		{name: "failed", opts: historyOptions{failed: true}, want: 2},
+		{name: "command", opts: historyOptions{command: "collection delete"}, want: 2},
+		{name: "command group", opts: historyOptions{command: "collection"}, want: 3},
+		{name: "since", opts: historyOptions{since: 24 * time.Hour}, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := filterEntries(entries, tt.opts, now); len(got) != tt.want {
+				t.Errorf("filterEntries() returned %d entries, want %d", len(got), tt.want)
+			}
+		})
+	}
+
+	// The limit keeps the most recent entries
+	got := filterEntries(entries, historyOptions{limit: 1}, now)
+	if !got[0].Time.Equal(now) {
+		t.Errorf("filterEntries() with limit kept %v, want the most recent entry", got[0].Time)
+	}
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -16,6 +16,7 @@ func NewCreateCmd() *cobra.Command {
 	var (
 		profile      string
 		format       string
+		quiet        bool
 		endpointFQDN string
 		name         string
 		incoming     bool
@@ -40,12 +41,13 @@ Example:
 Requires an active authentication session (use 'login' first).`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return runCreate(cmd.Context(), profile, format, endpointFQDN,
-				name, incoming, outgoing, cmd.OutOrStdout())
+				name, incoming, outgoing, quiet, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Only print IDs, one per line")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 	cmd.Flags().StringVar(&name, "name", "", "Name for the node")
 	cmd.Flags().BoolVar(&incoming, "incoming", false, "Enable incoming transfers")
@@ -60,10 +62,14 @@ Requires an active authentication session (use 'login' first).`,
 // runCreate executes the node create command.
 func runCreate(ctx context.Context, profile, formatStr, endpointFQDN string,
 	name string, incoming, outgoing bool,
-	out interface{ Write([]byte) (int, error) }) error {
+	quiet bool, out interface{ Write([]byte) (int, error) }) error {
+
+	if quiet && output.Format(formatStr) != output.FormatText {
+		return fmt.Errorf("--quiet cannot be combined with --format %s", formatStr)
+	}
 
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -74,13 +80,10 @@ func runCreate(ctx context.Context, profile, formatStr, endpointFQDN string,
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions(output.WithQuiet(quiet))...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -92,15 +95,26 @@ func runCreate(ctx context.Context, profile, formatStr, endpointFQDN string,
 		Outgoing: outgoing,
 	}
 
+	// Warn about subscription limits the request would exceed
+	if limits, err := gcsClient.GetLimits(ctx); err == nil {
+		for _, problem := range limits.CheckNodeCreate() {
+			cli.Warnf("%v (see 'endpoint limits')", problem)
+		}
+	}
+
 	// Create node
 	created, err := gcsClient.CreateNode(ctx, node)
 	if err != nil {
 		return fmt.Errorf("create node: %w", err)
 	}
 
+	if formatter.IsQuiet() {
+		return formatter.PrintIDs(created.ID)
+	}
+
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(created)
+	if formatter.IsStructured() {
+		return formatter.PrintData(created)
 	}
 
 	// Text format
@@ -4,9 +4,11 @@ package node
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -17,8 +19,13 @@ func NewListCmd() *cobra.Command {
 	var (
 		profile      string
 		format       string
+		quiet        bool
+		all          bool
 		endpointFQDN string
+		columns      []string
 		filter       string
+		status       bool
+		probe        probeOptions
 	)
 
 	cmd := &cobra.Command{
@@ -30,25 +37,72 @@ Nodes represent the compute resources that handle data transfer operations
 for the endpoint. Each node can be configured for incoming and/or outgoing
 transfers.
 
+With --status, each node is probed in parallel: its current status is
+fetched from the API and a TCP connection is attempted to each of its IP
+addresses on --probe-port. The STATUS column shows:
+  up           all addresses reachable
+  degraded     some addresses reachable
+  unreachable  no address reachable
+  disabled     the node is not active
+  no-address   the node reports no IP addresses
+  error        the node could not be fetched
+Each node's probe is bounded by --timeout-per-node.
+
+Example:
+  globus-connect-server node list --endpoint example.data.globus.org --status
+
 Requires an active authentication session (use 'login' first).`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runList(cmd.Context(), profile, format, endpointFQDN, filter, cmd.OutOrStdout())
+			var probeOpts *probeOptions
+			if status {
+				probeOpts = &probe
+			}
+			return runList(cmd.Context(), profile, format, endpointFQDN, filter, probeOpts, columns, quiet, all, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml, table, wide, csv)")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Only print IDs, one per line")
+	cmd.Flags().BoolVar(&all, "all", false, "List every node, following pagination, not just the first page")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	cmd.Flags().StringSliceVar(&columns, "columns", nil, "Comma-separated columns to show, e.g. id,display_name (implies --format table)")
 	cmd.Flags().StringVar(&filter, "filter", "", "Filter nodes by name")
+	cmd.Flags().BoolVar(&status, "status", false, "Probe each node and show its live status")
+	cmd.Flags().DurationVar(&probe.TimeoutPerNode, "timeout-per-node", 5*time.Second, "Maximum time to probe each node with --status")
+	cmd.Flags().IntVar(&probe.Port, "probe-port", 443, "TCP port to probe on each node address with --status")
 	_ = cmd.MarkFlagRequired("endpoint")
 
 	return cmd
 }
 
-// runList executes the node list command.
-func runList(ctx context.Context, profile, formatStr, endpointFQDN, filter string, out interface{ Write([]byte) (int, error) }) error {
+// nodeWithStatus is a node with its probe result, for JSON output.
+type nodeWithStatus struct {
+	gcs.Node
+	Probe *nodeProbe `json:"probe"`
+}
+
+// runList executes the node list command. Nodes are probed when probe is
+// non-nil.
+func runList(ctx context.Context, profile, formatStr, endpointFQDN, filter string, probe *probeOptions, columns []string, quiet, all bool, out interface{ Write([]byte) (int, error) }) error {
+	if quiet && output.Format(formatStr) != output.FormatText {
+		return fmt.Errorf("--quiet cannot be combined with --format %s", formatStr)
+	}
+
+	// --columns implies table format
+	if len(columns) > 0 && output.Format(formatStr) == output.FormatText {
+		formatStr = string(output.FormatTable)
+	}
+	if len(columns) > 0 && !output.Format(formatStr).IsTabular() {
+		return fmt.Errorf("--columns requires --format table, wide, or csv")
+	}
+
+	if probe != nil && probe.TimeoutPerNode <= 0 {
+		return fmt.Errorf("--timeout-per-node must be positive")
+	}
+
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -59,13 +113,10 @@ func runList(ctx context.Context, profile, formatStr, endpointFQDN, filter strin
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions(output.WithColumns(columns), output.WithQuiet(quiet))...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -77,14 +128,51 @@ func runList(ctx context.Context, profile, formatStr, endpointFQDN, filter strin
 	}
 
 	// Get nodes
-	list, err := gcsClient.ListNodes(ctx, opts)
+	list, err := listNodes(ctx, gcsClient, opts, all)
 	if err != nil {
 		return fmt.Errorf("list nodes: %w", err)
 	}
+	if list.HasNextPage {
+		cli.Warnf("the endpoint has more results than this page; use --all to list them all")
+	}
+
+	if formatter.IsQuiet() {
+		ids := make([]string, 0, len(list.Data))
+		for _, item := range list.Data {
+			ids = append(ids, item.ID)
+		}
+		return formatter.PrintIDs(ids...)
+	}
+
+	var probes []*nodeProbe
+	if probe != nil {
+		probes = newNodeProber(gcsClient, *probe).probeAll(ctx, list.Data)
+	}
 
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(list)
+	if formatter.IsStructured() {
+		if probes == nil {
+			return formatter.PrintData(list)
+		}
+		nodes := make([]nodeWithStatus, len(list.Data))
+		for i := range list.Data {
+			nodes[i] = nodeWithStatus{Node: list.Data[i], Probe: probes[i]}
+		}
+		return formatter.PrintData(map[string]interface{}{"data": nodes})
+	}
+
+	if formatter.IsTabular() {
+		table := output.NewTable("ID", "Name", "Incoming", "Outgoing", "Status")
+		table.AddWideColumns("IP Addresses")
+		for i, node := range list.Data {
+			status := node.Status
+			if probes != nil {
+				status = probes[i].summary()
+			}
+			table.AddRow(node.ID, node.Name, fmt.Sprint(node.Incoming), fmt.Sprint(node.Outgoing), status,
+				strings.Join(node.IPAddresses, ","))
+		}
+		return formatter.PrintTable(table)
 	}
 
 	// Text format
@@ -118,7 +206,25 @@ func runList(ctx context.Context, profile, formatStr, endpointFQDN, filter strin
 		if err := formatter.PrintText("  Outgoing:     %t\n", node.Outgoing); err != nil {
 			return err
 		}
+		if probes != nil {
+			if err := formatter.PrintText("  Status:       %s\n", probes[i].summary()); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
+
+// listNodes returns the first page of nodes, or with all, every
+// page combined into one.
+func listNodes(ctx context.Context, client gcs.GCSAPI, opts *gcs.ListNodesOptions, all bool) (*gcs.NodeList, error) {
+	if !all {
+		return client.ListNodes(ctx, opts)
+	}
+	items, err := client.ListAllNodes(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &gcs.NodeList{Data: items, TotalResults: len(items)}, nil
+}
@@ -5,7 +5,7 @@ import (
 	"context"
 	"testing"
 
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 )
 
 func TestNewListCmd(t *testing.T) {
@@ -58,6 +58,12 @@ func TestNewListCmd_Flags(t *testing.T) {
 			flagName:  "endpoint",
 			shorthand: "",
 		},
+		{
+			name:         "all flag",
+			flagName:     "all",
+			shorthand:    "",
+			defaultValue: "false",
+		},
 		{
 			name:      "filter flag",
 			flagName:  "filter",
@@ -88,7 +94,7 @@ func TestRunList_NoToken(t *testing.T) {
 	buf := &bytes.Buffer{}
 
 	// Test with a profile that doesn't exist
-	err := runList(ctx, "nonexistent-profile-test", "text", "test.example.org", "", buf)
+	err := runList(ctx, "nonexistent-profile-test", "text", "test.example.org", "", nil, nil, false, false, buf)
 	if err == nil {
 		t.Error("runList() expected error for nonexistent profile, got nil")
 	}
@@ -0,0 +1,143 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+)
+
+// Node probe statuses shown in the STATUS column.
+const (
+	probeUp          = "up"
+	probeDegraded    = "degraded"
+	probeUnreachable = "unreachable"
+	probeDisabled    = "disabled"
+	probeNoAddress   = "no-address"
+	probeError       = "error"
+)
+
+// maxParallelProbes bounds the number of nodes probed at once.
+const maxParallelProbes = 16
+
+// probeOptions configures 'node list --status'.
+type probeOptions struct {
+	TimeoutPerNode time.Duration
+	Port           int
+}
+
+// nodeProbe is the live state of one node.
+type nodeProbe struct {
+	Status    string `json:"status"`
+	APIStatus string `json:"api_status,omitempty"`
+	Reachable int    `json:"reachable_addresses"`
+	Addresses int    `json:"addresses"`
+
+	// Errors lists the addresses that could not be reached, or why the
+	// node could not be fetched.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// summary describes the probe for text output, e.g. "up (2/2 reachable)".
+func (p *nodeProbe) summary() string {
+	switch p.Status {
+	case probeUp, probeDegraded, probeUnreachable:
+		return fmt.Sprintf("%s (%d/%d reachable)", p.Status, p.Reachable, p.Addresses)
+	case probeError:
+		return fmt.Sprintf("%s: %s", p.Status, strings.Join(p.Errors, "; "))
+	}
+	return p.Status
+}
+
+// nodeProber checks node health through the API and by connecting to
+// each of the node's data interfaces.
+type nodeProber struct {
+	getNode func(ctx context.Context, nodeID string) (*gcs.Node, error)
+	dial    func(ctx context.Context, network, address string) (net.Conn, error)
+	opts    probeOptions
+}
+
+// newNodeProber creates a prober that uses client for API status.
+func newNodeProber(client gcs.GCSAPI, opts probeOptions) *nodeProber {
+	var dialer net.Dialer
+	return &nodeProber{getNode: client.GetNode, dial: dialer.DialContext, opts: opts}
+}
+
+// probeAll probes nodes in parallel. Results are in the order of nodes.
+func (p *nodeProber) probeAll(ctx context.Context, nodes []gcs.Node) []*nodeProbe {
+	batch, _ := gcs.RunBatch(ctx, nodes, maxParallelProbes, func(ctx context.Context, node gcs.Node) (*nodeProbe, error) {
+		return p.probe(ctx, &node), nil
+	})
+
+	results := make([]*nodeProbe, len(batch))
+	for i, r := range batch {
+		results[i] = r.Value
+		if r.Err != nil {
+			// Not started because ctx was canceled
+			results[i] = &nodeProbe{Status: probeError, Errors: []string{r.Err.Error()}}
+		}
+	}
+	return results
+}
+
+// probe checks one node within the per-node timeout. The node's current
+// document is fetched first so the status and addresses are fresh.
+func (p *nodeProber) probe(ctx context.Context, listed *gcs.Node) *nodeProbe {
+	ctx, cancel := context.WithTimeout(ctx, p.opts.TimeoutPerNode)
+	defer cancel()
+
+	node, err := p.getNode(ctx, listed.ID)
+	if err != nil {
+		return &nodeProbe{Status: probeError, Errors: []string{err.Error()}}
+	}
+
+	result := &nodeProbe{APIStatus: node.Status, Addresses: len(node.IPAddresses)}
+	if node.Status != "" && !strings.EqualFold(node.Status, "active") {
+		result.Status = probeDisabled
+		return result
+	}
+	if len(node.IPAddresses) == 0 {
+		result.Status = probeNoAddress
+		return result
+	}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for _, ip := range node.IPAddresses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			address := net.JoinHostPort(ip, strconv.Itoa(p.opts.Port))
+			conn, err := p.dial(ctx, "tcp", address)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", address, err))
+				return
+			}
+			_ = conn.Close()
+			result.Reachable++
+		}()
+	}
+	wg.Wait()
+	sort.Strings(result.Errors)
+
+	switch result.Reachable {
+	case result.Addresses:
+		result.Status = probeUp
+	case 0:
+		result.Status = probeUnreachable
+	default:
+		result.Status = probeDegraded
+	}
+	return result
+}
@@ -0,0 +1,56 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+)
+
+func TestNodeProber_ProbeAll(t *testing.T) {
+	documents := map[string]*gcs.Node{
+		"up":       {ID: "up", Status: "active", IPAddresses: []string{"10.0.0.1", "10.0.0.2"}},
+		"degraded": {ID: "degraded", IPAddresses: []string{"10.0.0.1", "10.0.0.9"}},
+		"down":     {ID: "down", IPAddresses: []string{"10.0.0.9"}},
+		"disabled": {ID: "disabled", Status: "inactive", IPAddresses: []string{"10.0.0.1"}},
+		"bare":     {ID: "bare"},
+	}
+
+	prober := &nodeProber{
+		getNode: func(_ context.Context, id string) (*gcs.Node, error) {
+			if node, ok := documents[id]; ok {
+				return node, nil
+			}
+			return nil, errors.New("HTTP 404: not found")
+		},
+		dial: func(_ context.Context, _, address string) (net.Conn, error) {
+			if address == "10.0.0.9:443" {
+				return nil, errors.New("connection refused")
+			}
+			client, server := net.Pipe()
+			_ = server.Close()
+			return client, nil
+		},
+		opts: probeOptions{TimeoutPerNode: time.Second, Port: 443},
+	}
+
+	nodes := []gcs.Node{{ID: "up"}, {ID: "degraded"}, {ID: "down"}, {ID: "disabled"}, {ID: "bare"}, {ID: "missing"}}
+	want := []string{probeUp, probeDegraded, probeUnreachable, probeDisabled, probeNoAddress, probeError}
+
+	results := prober.probeAll(context.Background(), nodes)
+	for i, result := range results {
+		if result.Status != want[i] {
+			t.Errorf("node %s status = %q, want %q", nodes[i].ID, result.Status, want[i])
+		}
+	}
+
+	if got := results[1].summary(); got != "degraded (1/2 reachable)" {
+		t.Errorf("summary() = %q, want %q", got, "degraded (1/2 reachable)")
+	}
+	if len(results[1].Errors) != 1 {
+		t.Errorf("Errors = %v, want the unreachable address", results[1].Errors)
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"context"
 	"testing"
 
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 )
 
 func TestNewShowCmd(t *testing.T) {
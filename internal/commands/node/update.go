@@ -4,8 +4,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -45,12 +45,12 @@ Requires an active authentication session (use 'login' first).`,
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 	cmd.Flags().StringVar(&name, "name", "", "Name for the node")
 
 	// Boolean flags need special handling
-	incomingStr := cmd.Flags().String("incoming", "", "Enable incoming transfers (true/false)")
+	incomingStr := cli.Enum(cmd, "incoming", "", "Enable incoming transfers (true/false)", cli.BoolChoices...)
 	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
 		if *incomingStr != "" {
 			val := *incomingStr == "true"
@@ -64,7 +64,7 @@ Requires an active authentication session (use 'login' first).`,
 		return nil
 	}
 
-	cmd.Flags().String("outgoing", "", "Enable outgoing transfers (true/false)")
+	cli.Enum(cmd, "outgoing", "", "Enable outgoing transfers (true/false)", cli.BoolChoices...)
 
 	_ = cmd.MarkFlagRequired("endpoint")
 
@@ -77,7 +77,7 @@ func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN, nodeID str
 	out interface{ Write([]byte) (int, error) }) error {
 
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -88,13 +88,10 @@ func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN, nodeID str
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -119,8 +116,8 @@ func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN, nodeID str
 	}
 
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(updated)
+	if formatter.IsStructured() {
+		return formatter.PrintData(updated)
 	}
 
 	// Text format
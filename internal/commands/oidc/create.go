@@ -5,9 +5,9 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/internal/secureinput"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -18,6 +18,7 @@ func NewCreateCmd() *cobra.Command {
 	var (
 		profile      string
 		format       string
+		quiet        bool
 		endpointFQDN string
 		issuer       string
 		clientID     string
@@ -66,12 +67,13 @@ Examples:
 
 Requires an active authentication session (use 'login' first).`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runCreate(cmd.Context(), profile, format, endpointFQDN, issuer, clientID, secretStdin, secretEnv, audience, scopes, cmd.OutOrStdout())
+			return runCreate(cmd.Context(), profile, format, endpointFQDN, issuer, clientID, secretStdin, secretEnv, audience, scopes, quiet, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Only print IDs, one per line")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN")
 	cmd.Flags().StringVar(&issuer, "issuer", "", "OIDC issuer URL")
 	cmd.Flags().StringVar(&clientID, "client-id", "", "OAuth2 client ID")
@@ -88,8 +90,12 @@ Requires an active authentication session (use 'login' first).`,
 }
 
 // runCreate executes the oidc create command.
-func runCreate(ctx context.Context, profile, formatStr, endpointFQDN, issuer, clientID string, secretStdin bool, secretEnv, audience, scopes string, out interface{ Write([]byte) (int, error) }) error {
-	token, err := auth.LoadToken(profile)
+func runCreate(ctx context.Context, profile, formatStr, endpointFQDN, issuer, clientID string, secretStdin bool, secretEnv, audience, scopes string, quiet bool, out interface{ Write([]byte) (int, error) }) error {
+	if quiet && output.Format(formatStr) != output.FormatText {
+		return fmt.Errorf("--quiet cannot be combined with --format %s", formatStr)
+	}
+
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -109,8 +115,8 @@ func runCreate(ctx context.Context, profile, formatStr, endpointFQDN, issuer, cl
 		return fmt.Errorf("read client secret: %w", err)
 	}
 
-	formatter := output.NewFormatter(output.Format(formatStr), out)
-	gcsClient, err := gcs.NewClient(endpointFQDN, gcs.WithAccessToken(token.AccessToken))
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions(output.WithQuiet(quiet))...)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -135,8 +141,12 @@ func runCreate(ctx context.Context, profile, formatStr, endpointFQDN, issuer, cl
 		return fmt.Errorf("create OIDC server: %w", err)
 	}
 
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(created)
+	if formatter.IsQuiet() {
+		return formatter.PrintIDs(created.ID)
+	}
+
+	if formatter.IsStructured() {
+		return formatter.PrintData(created)
 	}
 
 	if err := formatter.Println("OIDC server created successfully!"); err != nil {
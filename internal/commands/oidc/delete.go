@@ -4,9 +4,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -41,7 +40,7 @@ Requires an active authentication session (use 'login' first).`,
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN")
 	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt")
 
@@ -52,7 +51,7 @@ Requires an active authentication session (use 'login' first).`,
 
 // runDelete executes the oidc delete command.
 func runDelete(ctx context.Context, profile, formatStr, endpointFQDN string, force bool, out interface{ Write([]byte) (int, error) }) error {
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -61,7 +60,7 @@ func runDelete(ctx context.Context, profile, formatStr, endpointFQDN string, for
 		return fmt.Errorf("token expired, please login again")
 	}
 
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	if !force {
 		if err := formatter.Println("WARNING: This will permanently delete the OIDC server configuration."); err != nil {
@@ -79,7 +78,7 @@ func runDelete(ctx context.Context, profile, formatStr, endpointFQDN string, for
 		return fmt.Errorf("delete cancelled (use --force to proceed)")
 	}
 
-	gcsClient, err := gcs.NewClient(endpointFQDN, gcs.WithAccessToken(token.AccessToken))
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -88,12 +87,12 @@ func runDelete(ctx context.Context, profile, formatStr, endpointFQDN string, for
 		return fmt.Errorf("delete OIDC server: %w", err)
 	}
 
-	if formatter.IsJSON() {
+	if formatter.IsStructured() {
 		result := map[string]string{
 			"status":  "success",
 			"message": "OIDC server deleted successfully",
 		}
-		return formatter.PrintJSON(result)
+		return formatter.PrintData(result)
 	}
 
 	if err := formatter.Println("OIDC server deleted successfully."); err != nil {
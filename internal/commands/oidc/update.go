@@ -5,9 +5,9 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/internal/secureinput"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -64,7 +64,7 @@ Requires an active authentication session (use 'login' first).`,
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN")
 	cmd.Flags().StringVar(&issuer, "issuer", "", "OIDC issuer URL")
 	cmd.Flags().StringVar(&clientID, "client-id", "", "OAuth2 client ID")
@@ -81,7 +81,7 @@ Requires an active authentication session (use 'login' first).`,
 
 // runUpdate executes the oidc update command.
 func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN, issuer, clientID string, updateSecret, secretStdin bool, secretEnv, audience, scopes string, out interface{ Write([]byte) (int, error) }) error {
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -90,8 +90,8 @@ func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN, issuer, cl
 		return fmt.Errorf("token expired, please login again")
 	}
 
-	formatter := output.NewFormatter(output.Format(formatStr), out)
-	gcsClient, err := gcs.NewClient(endpointFQDN, gcs.WithAccessToken(token.AccessToken))
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -135,8 +135,8 @@ func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN, issuer, cl
 		return fmt.Errorf("update OIDC server: %w", err)
 	}
 
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(updated)
+	if formatter.IsStructured() {
+		return formatter.PrintData(updated)
 	}
 
 	if err := formatter.Println("OIDC server updated successfully!"); err != nil {
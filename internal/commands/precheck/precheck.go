@@ -0,0 +1,471 @@
+// Package precheck implements the precheck command, which checks that a
+// host meets the network requirements of Globus Connect Server before it
+// is set up as an endpoint or added as a node.
+package precheck
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// Check results.
+const (
+	statusPass   = "pass"
+	statusWarn   = "warn"
+	statusFail   = "fail"
+	statusManual = "manual"
+)
+
+// exitFailed is the exit status when a check failed. Warnings and manual
+// checks do not change the exit status.
+const exitFailed = 2
+
+// Clock skew thresholds. Globus Auth rejects tokens and requests from
+// hosts whose clock is too far off.
+const (
+	clockSkewWarn = 5 * time.Second
+	clockSkewFail = time.Minute
+)
+
+// acmeService is contacted for the certificates of the endpoint's
+// data.globus.org domain.
+const acmeService = "acme-v02.api.letsencrypt.org:443"
+
+// options holds the flags of the precheck command.
+type options struct {
+	Hostname  string
+	DataPorts string
+	Services  []string
+	Timeout   time.Duration
+}
+
+// check is the outcome of one requirement check.
+type check struct {
+	Category    string `json:"category"`
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+	Detail      string `json:"detail,omitempty"`
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// report is the result of a precheck run.
+type report struct {
+	Hostname string  `json:"hostname"`
+	Passed   bool    `json:"passed"`
+	Checks   []check `json:"checks"`
+}
+
+// NewPrecheckCmd creates the precheck command.
+func NewPrecheckCmd() *cobra.Command {
+	var (
+		opts   options
+		format string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "precheck",
+		Short: "Check network requirements before endpoint setup",
+		Long: `Check that this host meets the network requirements of Globus Connect
+Server, before running 'endpoint setup' or adding it as a node.
+
+The following are checked:
+  - Outbound HTTPS to the Globus services of the selected environment and
+    to Let's Encrypt, which issues the endpoint's certificate
+  - That port 443 and the data port range are free to listen on
+  - That the clock agrees with Globus Auth
+  - That the hostname is fully qualified, resolves to a routable address,
+    and has matching reverse DNS
+
+Inbound access through site firewalls cannot be tested from the host
+itself; it is listed as a manual check. Failed, warned, and manual checks
+are summarized in a remediation checklist to hand to networking teams.
+
+Exit status is 0 when no check failed, 2 when one did, and 1 on error.
+
+Examples:
+  globus-connect-server precheck
+  globus-connect-server precheck --hostname gcs01.example.edu --data-ports 50000-51000`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runPrecheck(cmd.Context(), format, opts, newPrechecker(), cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().StringVar(&opts.Hostname, "hostname", "", "Public hostname of this host (default: the system hostname)")
+	cmd.Flags().StringVar(&opts.DataPorts, "data-ports", "50000-51000", "Port range of incoming data channels")
+	cmd.Flags().StringArrayVar(&opts.Services, "service", nil, "Additional host:port that must be reachable outbound (repeatable)")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 5*time.Second, "Timeout of each network check")
+
+	return cmd
+}
+
+// prechecker runs the checks. Its fields reach the network and the
+// system, and are replaced in tests.
+type prechecker struct {
+	hostname   func() (string, error)
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+	lookupAddr func(ctx context.Context, addr string) ([]string, error)
+	dial       func(ctx context.Context, network, address string) (net.Conn, error)
+	listen     func(network, address string) (net.Listener, error)
+	serverTime func(ctx context.Context, url string) (time.Time, error)
+	now        func() time.Time
+}
+
+// newPrechecker creates a prechecker for this host.
+func newPrechecker() *prechecker {
+	var dialer net.Dialer
+	return &prechecker{
+		hostname:   os.Hostname,
+		lookupHost: net.DefaultResolver.LookupHost,
+		lookupAddr: net.DefaultResolver.LookupAddr,
+		dial:       dialer.DialContext,
+		listen:     net.Listen,
+		serverTime: serverTime,
+		now:        time.Now,
+	}
+}
+
+// runPrecheck executes the precheck command.
+func runPrecheck(ctx context.Context, formatStr string, opts options, p *prechecker, out interface{ Write([]byte) (int, error) }) error {
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	low, high, err := parsePortRange(opts.DataPorts)
+	if err != nil {
+		return err
+	}
+	env, err := cli.GlobusEnvironment()
+	if err != nil {
+		return err
+	}
+
+	hostname := opts.Hostname
+	if hostname == "" {
+		if hostname, err = p.hostname(); err != nil {
+			return fmt.Errorf("get hostname: %w", err)
+		}
+	}
+
+	services := []string{hostPort(env.AuthURL), hostPort(env.TransferURL), acmeService}
+	services = append(services, opts.Services...)
+
+	r := &report{Hostname: hostname, Passed: true}
+	for _, service := range services {
+		r.Checks = append(r.Checks, p.checkOutbound(ctx, service, opts.Timeout))
+	}
+	r.Checks = append(r.Checks,
+		p.checkListen("HTTPS port", 443, 443),
+		p.checkListen("Data ports", low, high),
+		inboundCheck(low, high),
+		p.checkClock(ctx, env.AuthURL, opts.Timeout),
+	)
+	r.Checks = append(r.Checks, p.checkDNS(ctx, hostname, opts.Timeout)...)
+
+	for _, c := range r.Checks {
+		if c.Status == statusFail {
+			r.Passed = false
+		}
+	}
+
+	if err := printReport(formatter, r); err != nil {
+		return err
+	}
+	if !r.Passed {
+		return &cli.ExitError{Code: exitFailed}
+	}
+	return nil
+}
+
+// parsePortRange parses a port range such as "50000-51000".
+func parsePortRange(s string) (int, int, error) {
+	lowStr, highStr, ok := strings.Cut(s, "-")
+	if !ok {
+		highStr = lowStr
+	}
+	low, errLow := strconv.Atoi(strings.TrimSpace(lowStr))
+	high, errHigh := strconv.Atoi(strings.TrimSpace(highStr))
+	if errLow != nil || errHigh != nil || low < 1 || high > 65535 || low > high {
+		return 0, 0, fmt.Errorf("invalid --data-ports %q (expected a range such as 50000-51000)", s)
+	}
+	return low, high, nil
+}
+
+// hostPort returns the host:port of an HTTPS URL.
+func hostPort(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	if u.Port() != "" {
+		return u.Host
+	}
+	return net.JoinHostPort(u.Hostname(), "443")
+}
+
+// checkOutbound checks that a TCP connection to service can be opened.
+func (p *prechecker) checkOutbound(ctx context.Context, service string, timeout time.Duration) check {
+	c := check{Category: "outbound", Name: service}
+	host, port, err := net.SplitHostPort(service)
+	if err != nil {
+		c.Status = statusFail
+		c.Detail = err.Error()
+		c.Remediation = fmt.Sprintf("Specify services as host:port, not %q", service)
+		return c
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	addrs, err := p.lookupHost(ctx, host)
+	if err != nil {
+		c.Status = statusFail
+		c.Detail = err.Error()
+		c.Remediation = fmt.Sprintf("Make sure %s resolves from this host (check /etc/resolv.conf and DNS egress rules)", host)
+		return c
+	}
+
+	conn, err := p.dial(ctx, "tcp", service)
+	if err != nil {
+		c.Status = statusFail
+		c.Detail = err.Error()
+		c.Remediation = fmt.Sprintf("Allow outbound TCP %s to %s (currently %s)", port, host, strings.Join(addrs, ", "))
+		return c
+	}
+	_ = conn.Close()
+	c.Status = statusPass
+	c.Detail = "connected to " + conn.RemoteAddr().String()
+	return c
+}
+
+// checkListen checks that ports low to high are free to listen on.
+func (p *prechecker) checkListen(name string, low, high int) check {
+	c := check{Category: "ports", Name: name}
+	if low == high {
+		c.Name = fmt.Sprintf("%s %d", name, low)
+	} else {
+		c.Name = fmt.Sprintf("%s %d-%d", name, low, high)
+	}
+
+	var busy []string
+	for port := low; port <= high; port++ {
+		l, err := p.listen("tcp", ":"+strconv.Itoa(port))
+		if err != nil {
+			busy = append(busy, strconv.Itoa(port))
+			continue
+		}
+		_ = l.Close()
+	}
+
+	total := high - low + 1
+	switch {
+	case len(busy) == 0:
+		c.Status = statusPass
+		c.Detail = "free"
+		return c
+	case len(busy) == total:
+		c.Status = statusFail
+	default:
+		c.Status = statusWarn
+	}
+	c.Detail = fmt.Sprintf("%d of %d port(s) in use or not permitted", len(busy), total)
+	if len(busy) > 5 {
+		busy = append(busy[:5], "...")
+	}
+	c.Detail += ": " + strings.Join(busy, ", ")
+	c.Remediation = fmt.Sprintf("Stop the services using %s, or run as a user allowed to bind them", c.Name)
+	return c
+}
+
+// inboundCheck lists the inbound rules that must be confirmed at the
+// site firewall.
+func inboundCheck(low, high int) check {
+	return check{
+		Category: "inbound",
+		Name:     "Site firewall",
+		Status:   statusManual,
+		Detail:   "inbound access cannot be tested from this host",
+		Remediation: fmt.Sprintf("Allow inbound TCP 443 and TCP %d-%d from any address "+
+			"(Globus services and transfer peers connect from many networks)", low, high),
+	}
+}
+
+// checkClock compares the local clock with the Date of a Globus Auth
+// response.
+func (p *prechecker) checkClock(ctx context.Context, authURL string, timeout time.Duration) check {
+	c := check{Category: "time", Name: "Clock synchronization"}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	before := p.now()
+	remote, err := p.serverTime(ctx, authURL)
+	if err != nil {
+		c.Status = statusWarn
+		c.Detail = "could not get the time from Globus Auth: " + err.Error()
+		c.Remediation = "Make sure NTP (chronyd or ntpd) is running and synchronized"
+		return c
+	}
+	// The Date header has a resolution of one second
+	local := before.Add(p.now().Sub(before) / 2)
+	skew := local.Sub(remote).Round(time.Second)
+	if skew < 0 {
+		skew = -skew
+	}
+
+	c.Detail = fmt.Sprintf("clock differs from Globus Auth by %s", skew)
+	switch {
+	case skew >= clockSkewFail:
+		c.Status = statusFail
+	case skew >= clockSkewWarn:
+		c.Status = statusWarn
+	default:
+		c.Status = statusPass
+		return c
+	}
+	c.Remediation = "Synchronize the clock with NTP (e.g., enable chronyd) and allow outbound UDP 123 to the NTP servers"
+	return c
+}
+
+// serverTime returns the Date of the response to a HEAD request for url.
+func serverTime(ctx context.Context, url string) (time.Time, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return time.Time{}, err
+	}
+	_ = resp.Body.Close()
+	return http.ParseTime(resp.Header.Get("Date"))
+}
+
+// checkDNS checks that hostname is fully qualified, resolves to routable
+// addresses, and that those resolve back to it.
+func (p *prechecker) checkDNS(ctx context.Context, hostname string, timeout time.Duration) []check {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	name := check{Category: "dns", Name: "Hostname " + hostname, Status: statusPass, Detail: "fully qualified"}
+	if !strings.Contains(strings.TrimSuffix(hostname, "."), ".") {
+		name.Status = statusWarn
+		name.Detail = "not fully qualified"
+		name.Remediation = "Set the hostname to the host's fully qualified name, or pass it with --hostname"
+	}
+
+	forward := check{Category: "dns", Name: "Forward DNS"}
+	addrs, err := p.lookupHost(ctx, hostname)
+	if err != nil {
+		forward.Status = statusFail
+		forward.Detail = err.Error()
+		forward.Remediation = fmt.Sprintf("Add an A or AAAA record for %s", hostname)
+		return []check{name, forward}
+	}
+
+	var routable, loopback []string
+	for _, addr := range addrs {
+		if ip := net.ParseIP(addr); ip != nil && (ip.IsLoopback() || ip.IsUnspecified()) {
+			loopback = append(loopback, addr)
+		} else {
+			routable = append(routable, addr)
+		}
+	}
+	forward.Detail = strings.Join(addrs, ", ")
+	switch {
+	case len(routable) == 0:
+		forward.Status = statusFail
+		forward.Remediation = fmt.Sprintf("%s resolves only to loopback addresses; fix /etc/hosts and add a DNS record with the host's public address", hostname)
+		return []check{name, forward}
+	case len(loopback) > 0:
+		forward.Status = statusWarn
+		forward.Remediation = fmt.Sprintf("Remove the loopback entries for %s from /etc/hosts", hostname)
+	default:
+		forward.Status = statusPass
+	}
+
+	reverse := check{Category: "dns", Name: "Reverse DNS", Status: statusPass}
+	var mismatched []string
+	want := strings.TrimSuffix(strings.ToLower(hostname), ".")
+	for _, addr := range routable {
+		names, err := p.lookupAddr(ctx, addr)
+		found := false
+		for _, n := range names {
+			if strings.TrimSuffix(strings.ToLower(n), ".") == want {
+				found = true
+			}
+		}
+		switch {
+		case err != nil:
+			mismatched = append(mismatched, addr+" has no PTR record")
+		case !found:
+			mismatched = append(mismatched, fmt.Sprintf("%s resolves to %s", addr, strings.Join(names, ", ")))
+		}
+	}
+	if len(mismatched) > 0 {
+		reverse.Status = statusWarn
+		reverse.Detail = strings.Join(mismatched, "; ")
+		reverse.Remediation = fmt.Sprintf("Add PTR records mapping %s back to %s", strings.Join(routable, ", "), hostname)
+	} else {
+		reverse.Detail = "matches " + hostname
+	}
+	return []check{name, forward, reverse}
+}
+
+// statusMarks are the text output markers of check results.
+var statusMarks = map[string]string{
+	statusPass:   "✓",
+	statusWarn:   "!",
+	statusFail:   "✗",
+	statusManual: "?",
+}
+
+// printReport prints the checks and the remediation checklist.
+func printReport(formatter *output.Formatter, r *report) error {
+	if formatter.IsStructured() {
+		return formatter.PrintData(r)
+	}
+
+	if err := formatter.PrintText("Network requirements for %s\n\n", r.Hostname); err != nil {
+		return err
+	}
+	for _, c := range r.Checks {
+		if err := formatter.PrintText("  %s %-8s %-40s %s\n", statusMarks[c.Status], c.Category, c.Name, c.Detail); err != nil {
+			return err
+		}
+	}
+
+	var todo []check
+	for _, c := range r.Checks {
+		if c.Remediation != "" {
+			todo = append(todo, c)
+		}
+	}
+	if len(todo) > 0 {
+		if err := formatter.PrintText("\nRemediation checklist:\n"); err != nil {
+			return err
+		}
+		for i, c := range todo {
+			if err := formatter.PrintText("  %d. [%s] %s\n", i+1, c.Status, c.Remediation); err != nil {
+				return err
+			}
+		}
+	}
+
+	result := "PASS"
+	if !r.Passed {
+		result = "FAIL"
+	}
+	return formatter.PrintText("\nResult: %s\n", result)
+}
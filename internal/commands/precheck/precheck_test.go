@@ -0,0 +1,168 @@
+package precheck
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+)
+
+// fakeListener is a listener that accepts nothing.
+type fakeListener struct{}
+
+func (fakeListener) Accept() (net.Conn, error) { return nil, errors.New("closed") }
+func (fakeListener) Close() error              { return nil }
+func (fakeListener) Addr() net.Addr            { return &net.TCPAddr{} }
+
+// healthyPrechecker returns a prechecker for a host that meets every
+// requirement.
+func healthyPrechecker() *prechecker {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	return &prechecker{
+		hostname: func() (string, error) { return "gcs01.example.edu", nil },
+		lookupHost: func(_ context.Context, host string) ([]string, error) {
+			if host == "gcs01.example.edu" {
+				return []string{"192.0.2.10"}, nil
+			}
+			return []string{"198.51.100.1"}, nil
+		},
+		lookupAddr: func(_ context.Context, addr string) ([]string, error) {
+			return []string{"gcs01.example.edu."}, nil
+		},
+		dial: func(_ context.Context, _, _ string) (net.Conn, error) {
+			client, server := net.Pipe()
+			_ = server.Close()
+			return client, nil
+		},
+		listen:     func(_, _ string) (net.Listener, error) { return fakeListener{}, nil },
+		serverTime: func(context.Context, string) (time.Time, error) { return now, nil },
+		now:        func() time.Time { return now },
+	}
+}
+
+// statuses runs the precheck and returns the status of each check by name.
+func statuses(t *testing.T, p *prechecker, opts options) (map[string]string, error) {
+	t.Helper()
+	if opts.DataPorts == "" {
+		opts.DataPorts = "50000-50010"
+	}
+	buf := &bytes.Buffer{}
+	err := runPrecheck(context.Background(), "json", opts, p, buf)
+
+	var r report
+	if jsonErr := json.Unmarshal(buf.Bytes(), &r); jsonErr != nil {
+		t.Fatalf("output %q: %v", buf.String(), jsonErr)
+	}
+	got := map[string]string{}
+	for _, c := range r.Checks {
+		got[c.Name] = c.Status
+		if c.Status != statusPass && c.Remediation == "" {
+			t.Errorf("%s is %s without remediation", c.Name, c.Status)
+		}
+	}
+	return got, err
+}
+
+func TestRunPrecheck_Healthy(t *testing.T) {
+	got, err := statuses(t, healthyPrechecker(), options{Services: []string{"relay.example.org:2223"}})
+	if err != nil {
+		t.Fatalf("runPrecheck() error = %v", err)
+	}
+	for name, status := range got {
+		want := statusPass
+		if name == "Site firewall" {
+			want = statusManual
+		}
+		if status != want {
+			t.Errorf("%s = %s, want %s", name, status, want)
+		}
+	}
+	if got["relay.example.org:2223"] == "" || got["auth.globus.org:443"] == "" {
+		t.Errorf("checks = %v, want the Globus and additional services", got)
+	}
+}
+
+func TestRunPrecheck_Failures(t *testing.T) {
+	p := healthyPrechecker()
+	p.dial = func(_ context.Context, _, address string) (net.Conn, error) {
+		return nil, errors.New("connection timed out")
+	}
+	p.listen = func(_, address string) (net.Listener, error) {
+		if address == ":50003" {
+			return nil, errors.New("address already in use")
+		}
+		return fakeListener{}, nil
+	}
+	p.serverTime = func(context.Context, string) (time.Time, error) {
+		return p.now().Add(-2 * time.Minute), nil
+	}
+	p.lookupAddr = func(context.Context, string) ([]string, error) {
+		return []string{"host-192-0-2-10.isp.example.net."}, nil
+	}
+
+	got, err := statuses(t, p, options{})
+	var exitErr *cli.ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != exitFailed {
+		t.Errorf("runPrecheck() error = %v, want exit status %d", err, exitFailed)
+	}
+	want := map[string]string{
+		"auth.globus.org:443":    statusFail,
+		"Data ports 50000-50010": statusWarn,
+		"Clock synchronization":  statusFail,
+		"Reverse DNS":            statusWarn,
+	}
+	for name, status := range want {
+		if got[name] != status {
+			t.Errorf("%s = %q, want %s", name, got[name], status)
+		}
+	}
+}
+
+func TestCheckDNS(t *testing.T) {
+	p := healthyPrechecker()
+	p.lookupHost = func(context.Context, string) ([]string, error) {
+		return []string{"127.0.1.1"}, nil
+	}
+
+	checks := p.checkDNS(context.Background(), "gcs01", time.Second)
+	if len(checks) != 2 || checks[0].Status != statusWarn || checks[1].Status != statusFail {
+		t.Errorf("checkDNS() = %+v, want an unqualified name warning and a loopback failure", checks)
+	}
+}
+
+func TestPrintReport_Text(t *testing.T) {
+	buf := &bytes.Buffer{}
+	p := healthyPrechecker()
+	p.listen = func(string, string) (net.Listener, error) { return nil, errors.New("permission denied") }
+
+	err := runPrecheck(context.Background(), "text", options{DataPorts: "50000-50001"}, p, buf)
+	if err == nil {
+		t.Error("runPrecheck() expected failure when no port can be bound")
+	}
+	got := buf.String()
+	for _, want := range []string{"Remediation checklist:", "1. [fail]", "Allow inbound TCP 443 and TCP 50000-50001", "Result: FAIL"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestParsePortRange(t *testing.T) {
+	for input, want := range map[string][2]int{"50000-51000": {50000, 51000}, "443": {443, 443}} {
+		low, high, err := parsePortRange(input)
+		if err != nil || low != want[0] || high != want[1] {
+			t.Errorf("parsePortRange(%q) = %d, %d, %v", input, low, high, err)
+		}
+	}
+	for _, input := range []string{"51000-50000", "0-10", "a-b", "1-70000"} {
+		if _, _, err := parsePortRange(input); err == nil {
+			t.Errorf("parsePortRange(%q) expected error", input)
+		}
+	}
+}
@@ -4,9 +4,9 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/trash"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -17,6 +17,7 @@ func NewDeleteCmd() *cobra.Command {
 		profile      string
 		format       string
 		endpointFQDN string
+		noTrash      bool
 	)
 
 	cmd := &cobra.Command{
@@ -24,8 +25,10 @@ func NewDeleteCmd() *cobra.Command {
 		Short: "Delete a role assignment",
 		Long: `Delete a role assignment from a collection or endpoint.
 
-WARNING: This action is permanent and cannot be undone. The principal
-will immediately lose the permissions granted by this role.
+The principal immediately loses the permissions granted by this role. A
+copy of the role is kept in the local trash for 30 days and can be
+re-created with 'trash restore' (see 'trash list'), unless --no-trash is
+given.
 
 Example:
   globus-connect-server role delete abc123 \
@@ -35,13 +38,14 @@ Requires an active authentication session (use 'login' first).`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			roleID := args[0]
-			return runDelete(cmd.Context(), profile, format, endpointFQDN, roleID, cmd.OutOrStdout())
+			return runDelete(cmd.Context(), profile, format, endpointFQDN, roleID, noTrash, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	cmd.Flags().BoolVar(&noTrash, "no-trash", false, "Do not keep a copy in the local trash")
 
 	_ = cmd.MarkFlagRequired("endpoint")
 
@@ -49,9 +53,9 @@ Requires an active authentication session (use 'login' first).`,
 }
 
 // runDelete executes the role delete command.
-func runDelete(ctx context.Context, profile, formatStr, endpointFQDN, roleID string, out interface{ Write([]byte) (int, error) }) error {
+func runDelete(ctx context.Context, profile, formatStr, endpointFQDN, roleID string, noTrash bool, out interface{ Write([]byte) (int, error) }) error {
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -62,36 +66,54 @@ func runDelete(ctx context.Context, profile, formatStr, endpointFQDN, roleID str
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
 
+	// Keep a copy for 'trash restore'
+	var trashed *trash.Item
+	if !noTrash {
+		role, err := gcsClient.GetRole(ctx, roleID)
+		if err != nil {
+			return fmt.Errorf("get role: %w", err)
+		}
+		if trashed, err = trash.Save(trash.KindRole, roleID, endpointFQDN, role); err != nil {
+			return fmt.Errorf("%w (use --no-trash to delete without a copy)", err)
+		}
+	}
+
 	// Delete role
 	if err := gcsClient.DeleteRole(ctx, roleID); err != nil {
+		if trashed != nil {
+			_ = trash.Discard(trashed.ID)
+		}
 		return fmt.Errorf("delete role: %w", err)
 	}
 
 	// Output based on format
-	if formatter.IsJSON() {
+	if formatter.IsStructured() {
 		result := map[string]string{
 			"status":  "success",
 			"role_id": roleID,
 			"message": "Role deleted successfully",
 		}
-		return formatter.PrintJSON(result)
+		if trashed != nil {
+			result["trash_id"] = trashed.ID
+		}
+		return formatter.PrintData(result)
 	}
 
 	// Text format
 	if err := formatter.PrintText("Role %s deleted successfully.\n", roleID); err != nil {
 		return err
 	}
+	if trashed != nil {
+		return formatter.PrintText("Restore it with 'trash restore %s' within 30 days.\n", trashed.ID)
+	}
 
 	return nil
 }
@@ -0,0 +1,96 @@
+package role
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+)
+
+// Principal URN prefixes used in role assignments.
+const (
+	identityURNPrefix = "urn:globus:auth:identity:"
+	groupURNPrefix    = "urn:globus:groups:id:"
+)
+
+// Principal types reported in roleDetails.
+const (
+	principalIdentity = "identity"
+	principalGroup    = "group"
+)
+
+// roleDetails is a role together with the details resolved for its
+// collection and principal.
+type roleDetails struct {
+	*gcs.Role
+	CollectionDisplayName string         `json:"collection_display_name,omitempty"`
+	PrincipalType         string         `json:"principal_type,omitempty"`
+	PrincipalID           string         `json:"principal_id,omitempty"`
+	PrincipalIdentity     *auth.Identity `json:"principal_identity,omitempty"`
+}
+
+// resolve looks up the collection and principal of the role. Lookups are
+// best effort: a failure is reported as a warning and the raw value is
+// shown instead.
+func (d *roleDetails) resolve(ctx context.Context, client gcs.GCSAPI, accessToken string) {
+	d.PrincipalType, d.PrincipalID = parsePrincipal(d.Principal)
+
+	if d.Collection != "" {
+		collection, err := client.GetCollection(ctx, d.Collection)
+		if err != nil {
+			cli.Warnf("could not look up collection %s: %v", d.Collection, err)
+		} else {
+			d.CollectionDisplayName = collection.DisplayName
+		}
+	}
+
+	if d.PrincipalType == principalIdentity {
+		env, err := cli.GlobusEnvironment()
+		var identities []auth.Identity
+		if err == nil {
+			identities, err = auth.GetIdentities(ctx, env.AuthURL, accessToken, d.PrincipalID)
+		}
+		switch {
+		case err != nil:
+			cli.Warnf("could not look up identity %s: %v", d.PrincipalID, err)
+		case len(identities) > 0:
+			d.PrincipalIdentity = &identities[0]
+		}
+	}
+}
+
+// collectionLabel returns the collection ID with its display name, if known.
+func (d *roleDetails) collectionLabel() string {
+	if d.CollectionDisplayName == "" {
+		return d.Collection
+	}
+	return fmt.Sprintf("%s (%s)", d.CollectionDisplayName, d.Collection)
+}
+
+// principalLabel returns the principal with its username, if known.
+func (d *roleDetails) principalLabel() string {
+	switch {
+	case d.PrincipalIdentity != nil && d.PrincipalIdentity.Username != "":
+		return fmt.Sprintf("%s (%s)", d.PrincipalIdentity.Username, d.Principal)
+	case d.PrincipalType == principalGroup:
+		return fmt.Sprintf("group %s", d.PrincipalID)
+	default:
+		return d.Principal
+	}
+}
+
+// parsePrincipal splits a principal URN into its type and ID. Unknown
+// formats return an empty type and the principal unchanged.
+func parsePrincipal(principal string) (string, string) {
+	switch {
+	case strings.HasPrefix(principal, identityURNPrefix):
+		return principalIdentity, strings.TrimPrefix(principal, identityURNPrefix)
+	case strings.HasPrefix(principal, groupURNPrefix):
+		return principalGroup, strings.TrimPrefix(principal, groupURNPrefix)
+	default:
+		return "", principal
+	}
+}
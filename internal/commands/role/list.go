@@ -5,8 +5,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -17,7 +17,10 @@ func NewListCmd() *cobra.Command {
 	var (
 		profile      string
 		format       string
+		quiet        bool
+		all          bool
 		endpointFQDN string
+		columns      []string
 		collection   string
 		principal    string
 	)
@@ -33,13 +36,16 @@ a collection or the endpoint itself.
 
 Requires an active authentication session (use 'login' first).`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runList(cmd.Context(), profile, format, endpointFQDN, collection, principal, cmd.OutOrStdout())
+			return runList(cmd.Context(), profile, format, endpointFQDN, collection, principal, columns, quiet, all, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml, table, wide, csv)")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Only print IDs, one per line")
+	cmd.Flags().BoolVar(&all, "all", false, "List every role assignment, following pagination, not just the first page")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	cmd.Flags().StringSliceVar(&columns, "columns", nil, "Comma-separated columns to show, e.g. id,display_name (implies --format table)")
 	cmd.Flags().StringVarP(&collection, "collection", "c", "", "Filter roles by collection ID")
 	cmd.Flags().StringVar(&principal, "principal", "", "Filter roles by principal (identity)")
 	_ = cmd.MarkFlagRequired("endpoint")
@@ -48,9 +54,21 @@ Requires an active authentication session (use 'login' first).`,
 }
 
 // runList executes the role list command.
-func runList(ctx context.Context, profile, formatStr, endpointFQDN, collection, principal string, out interface{ Write([]byte) (int, error) }) error {
+func runList(ctx context.Context, profile, formatStr, endpointFQDN, collection, principal string, columns []string, quiet, all bool, out interface{ Write([]byte) (int, error) }) error {
+	if quiet && output.Format(formatStr) != output.FormatText {
+		return fmt.Errorf("--quiet cannot be combined with --format %s", formatStr)
+	}
+
+	// --columns implies table format
+	if len(columns) > 0 && output.Format(formatStr) == output.FormatText {
+		formatStr = string(output.FormatTable)
+	}
+	if len(columns) > 0 && !output.Format(formatStr).IsTabular() {
+		return fmt.Errorf("--columns requires --format table, wide, or csv")
+	}
+
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -61,13 +79,10 @@ func runList(ctx context.Context, profile, formatStr, endpointFQDN, collection,
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions(output.WithColumns(columns), output.WithQuiet(quiet))...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -82,14 +97,33 @@ func runList(ctx context.Context, profile, formatStr, endpointFQDN, collection,
 	}
 
 	// Get roles
-	list, err := gcsClient.ListRoles(ctx, opts)
+	list, err := listRoles(ctx, gcsClient, opts, all)
 	if err != nil {
 		return fmt.Errorf("list roles: %w", err)
 	}
+	if list.HasNextPage {
+		cli.Warnf("the endpoint has more results than this page; use --all to list them all")
+	}
+
+	if formatter.IsQuiet() {
+		ids := make([]string, 0, len(list.Data))
+		for _, item := range list.Data {
+			ids = append(ids, item.ID)
+		}
+		return formatter.PrintIDs(ids...)
+	}
 
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(list)
+	if formatter.IsStructured() {
+		return formatter.PrintData(list)
+	}
+
+	if formatter.IsTabular() {
+		table := output.NewTable("ID", "Collection", "Principal", "Role")
+		for _, role := range list.Data {
+			table.AddRow(role.ID, role.Collection, role.Principal, role.Role)
+		}
+		return formatter.PrintTable(table)
 	}
 
 	// Text format
@@ -133,3 +167,16 @@ func runList(ctx context.Context, profile, formatStr, endpointFQDN, collection,
 
 	return nil
 }
+
+// listRoles returns the first page of roles, or with all, every
+// page combined into one.
+func listRoles(ctx context.Context, client gcs.GCSAPI, opts *gcs.ListRolesOptions, all bool) (*gcs.RoleList, error) {
+	if !all {
+		return client.ListRoles(ctx, opts)
+	}
+	items, err := client.ListAllRoles(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &gcs.RoleList{Data: items, TotalResults: len(items)}, nil
+}
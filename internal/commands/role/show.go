@@ -4,9 +4,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -17,6 +16,7 @@ func NewShowCmd() *cobra.Command {
 		profile      string
 		format       string
 		endpointFQDN string
+		raw          bool
 	)
 
 	cmd := &cobra.Command{
@@ -27,26 +27,31 @@ func NewShowCmd() *cobra.Command {
 This command retrieves and displays the complete configuration of a role
 assignment including the collection, principal (user/group), and role type.
 
+The collection's display name and the principal's username and email are
+looked up and shown alongside the raw IDs. Use --raw to skip these extra
+lookups and show only what the role itself contains.
+
 Requires an active authentication session (use 'login' first).`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			roleID := args[0]
-			return runShow(cmd.Context(), profile, format, endpointFQDN, roleID, cmd.OutOrStdout())
+			return runShow(cmd.Context(), profile, format, endpointFQDN, roleID, raw, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Show raw IDs without looking up collection and principal details")
 	_ = cmd.MarkFlagRequired("endpoint")
 
 	return cmd
 }
 
 // runShow executes the role show command.
-func runShow(ctx context.Context, profile, formatStr, endpointFQDN, roleID string, out interface{ Write([]byte) (int, error) }) error {
+func runShow(ctx context.Context, profile, formatStr, endpointFQDN, roleID string, raw bool, out interface{ Write([]byte) (int, error) }) error {
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -57,13 +62,10 @@ func runShow(ctx context.Context, profile, formatStr, endpointFQDN, roleID strin
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -74,9 +76,17 @@ func runShow(ctx context.Context, profile, formatStr, endpointFQDN, roleID strin
 		return fmt.Errorf("get role: %w", err)
 	}
 
+	details := &roleDetails{Role: role}
+	if !raw {
+		details.resolve(ctx, gcsClient, token.AccessToken)
+	}
+
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(role)
+	if formatter.IsStructured() {
+		if raw {
+			return formatter.PrintData(role)
+		}
+		return formatter.PrintData(details)
 	}
 
 	// Text format
@@ -93,14 +103,19 @@ func runShow(ctx context.Context, profile, formatStr, endpointFQDN, roleID strin
 		}
 	}
 	if role.Collection != "" {
-		if err := formatter.PrintText("%-15s%s\n", "Collection:", role.Collection); err != nil {
+		if err := formatter.PrintText("%-15s%s\n", "Collection:", details.collectionLabel()); err != nil {
 			return err
 		}
 	}
 	if role.Principal != "" {
-		if err := formatter.PrintText("%-15s%s\n", "Principal:", role.Principal); err != nil {
+		if err := formatter.PrintText("%-15s%s\n", "Principal:", details.principalLabel()); err != nil {
 			return err
 		}
+		if details.PrincipalIdentity != nil && details.PrincipalIdentity.Email != "" {
+			if err := formatter.PrintText("%-15s%s\n", "Email:", details.PrincipalIdentity.Email); err != nil {
+				return err
+			}
+		}
 	}
 	if role.Role != "" {
 		if err := formatter.PrintText("%-15s%s\n", "Role:", role.Role); err != nil {
@@ -5,7 +5,9 @@ import (
 	"context"
 	"testing"
 
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 )
 
 func TestNewShowCmd(t *testing.T) {
@@ -62,6 +64,12 @@ func TestNewShowCmd_Flags(t *testing.T) {
 			flagName:  "endpoint",
 			shorthand: "",
 		},
+		{
+			name:         "raw flag",
+			flagName:     "raw",
+			shorthand:    "",
+			defaultValue: "false",
+		},
 	}
 
 	for _, tt := range tests {
@@ -87,7 +95,7 @@ func TestRunShow_NoToken(t *testing.T) {
 	buf := &bytes.Buffer{}
 
 	// Test with a profile that doesn't exist
-	err := runShow(ctx, "nonexistent-profile-test", "text", "test.example.org", "test-role-id", buf)
+	err := runShow(ctx, "nonexistent-profile-test", "text", "test.example.org", "test-role-id", false, buf)
 	if err == nil {
 		t.Error("runShow() expected error for nonexistent profile, got nil")
 	}
@@ -97,3 +105,48 @@ func TestRunShow_NoToken(t *testing.T) {
 		t.Errorf("runShow() wrote to buffer on error: %q", buf.String())
 	}
 }
+
+func TestParsePrincipal(t *testing.T) {
+	tests := []struct {
+		principal string
+		wantType  string
+		wantID    string
+	}{
+		{"urn:globus:auth:identity:abc-123", principalIdentity, "abc-123"},
+		{"urn:globus:groups:id:def-456", principalGroup, "def-456"},
+		{"anonymous", "", "anonymous"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.principal, func(t *testing.T) {
+			gotType, gotID := parsePrincipal(tt.principal)
+			if gotType != tt.wantType || gotID != tt.wantID {
+				t.Errorf("parsePrincipal() = %q, %q, want %q, %q", gotType, gotID, tt.wantType, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestRoleDetails_Labels(t *testing.T) {
+	d := &roleDetails{
+		Role:                  &gcs.Role{Collection: "col-1", Principal: "urn:globus:auth:identity:abc-123"},
+		CollectionDisplayName: "Project Data",
+		PrincipalIdentity:     &auth.Identity{ID: "abc-123", Username: "alice@example.edu"},
+	}
+
+	if got, want := d.collectionLabel(), "Project Data (col-1)"; got != want {
+		t.Errorf("collectionLabel() = %q, want %q", got, want)
+	}
+	if got, want := d.principalLabel(), "alice@example.edu (urn:globus:auth:identity:abc-123)"; got != want {
+		t.Errorf("principalLabel() = %q, want %q", got, want)
+	}
+
+	// Unresolved details fall back to the raw values
+	raw := &roleDetails{Role: &gcs.Role{Collection: "col-1", Principal: "urn:globus:auth:identity:abc-123"}}
+	if got := raw.collectionLabel(); got != "col-1" {
+		t.Errorf("collectionLabel() = %q, want %q", got, "col-1")
+	}
+	if got := raw.principalLabel(); got != "urn:globus:auth:identity:abc-123" {
+		t.Errorf("principalLabel() = %q, want the raw principal", got)
+	}
+}
@@ -0,0 +1,21 @@
+package selftest
+
+import "github.com/spf13/cobra"
+
+// NewSelftestCmd creates the selftest command with subcommands.
+func NewSelftestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "selftest",
+		Short: "Validate an endpoint end to end",
+		Long: `Commands for validating that an endpoint works end to end.
+
+Self-tests exercise the endpoint the way users do and report pass or fail
+for each step. They are intended to be run after installation, upgrades,
+and configuration changes.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(NewTransferCmd())
+
+	return cmd
+}
@@ -0,0 +1,396 @@
+package selftest
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/transfer"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// Step results.
+const (
+	stepPass    = "pass"
+	stepFail    = "fail"
+	stepSkipped = "skipped"
+)
+
+// pollInterval is how often task status is checked.
+var pollInterval = 2 * time.Second
+
+// transferOptions holds the flags of the selftest transfer command.
+type transferOptions struct {
+	CollectionID     string
+	StorageGatewayID string
+	BasePath         string
+	SourcePath       string
+	WorkDir          string
+	TaskTimeout      time.Duration
+}
+
+// step is the outcome of one self-test step.
+type step struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Detail     string `json:"detail,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// transferResult is the report of a transfer self-test.
+type transferResult struct {
+	Endpoint     string `json:"endpoint"`
+	CollectionID string `json:"collection_id,omitempty"`
+	Temporary    bool   `json:"temporary_collection"`
+	SourcePath   string `json:"source_path"`
+	WorkDir      string `json:"work_dir"`
+	Passed       bool   `json:"passed"`
+	Steps        []step `json:"steps"`
+}
+
+// NewTransferCmd creates the selftest transfer command.
+func NewTransferCmd() *cobra.Command {
+	var (
+		profile      string
+		format       string
+		endpointFQDN string
+		opts         transferOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "transfer",
+		Short: "Run a round-trip transfer smoke test",
+		Long: `Run a small round-trip transfer through the endpoint and verify its integrity.
+
+The test copies SOURCE_PATH to a scratch directory on the collection, copies
+it back, and then checks the returned copy against the original with a
+checksum-level sync: Globus Transfer compares the checksums of both files
+and the test passes only if nothing needed to be re-sent. The scratch
+directory is deleted afterwards.
+
+Use --collection to run the test on an existing test collection. Otherwise
+use --storage-gateway to create a temporary guest collection, which requires
+a user credential on that gateway and is deleted when the test finishes.
+
+The source file should be small; a few kilobytes is enough.
+
+Example:
+  globus-connect-server selftest transfer --endpoint example.data.globus.org \
+    --collection COLLECTION_ID --source-path /selftest/probe.dat
+
+  globus-connect-server selftest transfer --endpoint example.data.globus.org \
+    --storage-gateway GATEWAY_ID --base-path /scratch --source-path /probe.dat
+
+The command exits non-zero if any step fails.
+
+Requires an active authentication session (use 'login' first) with the
+Transfer scope.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTransfer(cmd.Context(), profile, format, endpointFQDN, opts, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	cmd.Flags().StringVar(&opts.CollectionID, "collection", "", "Existing collection to test")
+	cmd.Flags().StringVar(&opts.StorageGatewayID, "storage-gateway", "", "Storage gateway for a temporary guest collection")
+	cmd.Flags().StringVar(&opts.BasePath, "base-path", "/", "Base path of the temporary guest collection")
+	cmd.Flags().StringVar(&opts.SourcePath, "source-path", "", "Path of a small existing file on the collection")
+	cmd.Flags().StringVar(&opts.WorkDir, "work-dir", "/", "Directory on the collection for the scratch directory")
+	cmd.Flags().DurationVar(&opts.TaskTimeout, "task-timeout", 5*time.Minute, "Maximum time to wait for each transfer task")
+
+	_ = cmd.MarkFlagRequired("endpoint")
+	_ = cmd.MarkFlagRequired("source-path")
+	cmd.MarkFlagsMutuallyExclusive("collection", "storage-gateway")
+	cmd.MarkFlagsOneRequired("collection", "storage-gateway")
+
+	return cmd
+}
+
+// runTransfer executes the selftest transfer command.
+func runTransfer(ctx context.Context, profile, formatStr, endpointFQDN string, opts transferOptions, out interface{ Write([]byte) (int, error) }) error {
+	if opts.CollectionID == "" && opts.StorageGatewayID == "" {
+		return fmt.Errorf("one of --collection or --storage-gateway is required")
+	}
+	if opts.SourcePath == "" {
+		return fmt.Errorf("--source-path is required")
+	}
+
+	// Load token
+	token, err := cli.LoadToken(profile)
+	if err != nil {
+		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+
+	// Check if token is valid
+	if !token.IsValid() {
+		return fmt.Errorf("token expired, please login again")
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	// Create GCS client
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("create GCS client: %w", err)
+	}
+
+	transferClient, err := cli.NewTransferClient(token)
+	if err != nil {
+		return err
+	}
+
+	t := &transferTest{
+		gcs:      gcsClient,
+		transfer: transferClient,
+		opts:     opts,
+		result: &transferResult{
+			Endpoint:   endpointFQDN,
+			SourcePath: opts.SourcePath,
+			WorkDir:    path.Join(opts.WorkDir, fmt.Sprintf(".gcs-selftest-%d", time.Now().Unix())) + "/",
+		},
+	}
+	t.run(ctx)
+
+	if err := printResult(formatter, t.result); err != nil {
+		return err
+	}
+
+	if !t.result.Passed {
+		for _, s := range t.result.Steps {
+			if s.Status == stepFail {
+				return fmt.Errorf("transfer self-test failed at %s: %s", s.Name, s.Detail)
+			}
+		}
+		return fmt.Errorf("transfer self-test failed")
+	}
+	return nil
+}
+
+// transferTest runs the steps of a transfer self-test.
+type transferTest struct {
+	gcs      gcs.GCSAPI
+	transfer *transfer.Client
+	opts     transferOptions
+	result   *transferResult
+	failed   bool
+}
+
+// run executes every step. After a failure, the remaining test steps are
+// skipped, but cleanup always runs.
+func (t *transferTest) run(ctx context.Context) {
+	first := t.result.WorkDir + "outbound"
+	second := t.result.WorkDir + "returned"
+	var wroteFiles bool
+
+	t.step("collection", func() (string, error) {
+		return t.prepareCollection(ctx)
+	})
+	t.step("transfer-out", func() (string, error) {
+		wroteFiles = true
+		return t.copy(ctx, "selftest outbound", t.opts.SourcePath, first)
+	})
+	t.step("transfer-back", func() (string, error) {
+		return t.copy(ctx, "selftest return", first, second)
+	})
+	t.step("checksum", func() (string, error) {
+		return t.verify(ctx, second)
+	})
+
+	passed := !t.failed
+
+	// Cleanup runs even after a failure
+	if wroteFiles {
+		t.failed = false
+		t.step("cleanup-files", func() (string, error) {
+			return t.deleteWorkDir(ctx)
+		})
+		passed = passed && !t.failed
+	}
+	if t.result.Temporary {
+		t.failed = false
+		t.step("cleanup-collection", func() (string, error) {
+			if err := t.gcs.DeleteCollection(ctx, t.result.CollectionID); err != nil {
+				return "", err
+			}
+			return "deleted temporary collection " + t.result.CollectionID, nil
+		})
+		passed = passed && !t.failed
+	}
+	t.result.Passed = passed
+}
+
+// step runs fn as the named step and records its outcome. Steps after a
+// failure are recorded as skipped.
+func (t *transferTest) step(name string, fn func() (string, error)) {
+	if t.failed {
+		t.result.Steps = append(t.result.Steps, step{Name: name, Status: stepSkipped})
+		return
+	}
+
+	start := time.Now()
+	detail, err := fn()
+	s := step{Name: name, Status: stepPass, Detail: detail, DurationMS: time.Since(start).Milliseconds()}
+	if err != nil {
+		s.Status = stepFail
+		s.Detail = err.Error()
+		t.failed = true
+	}
+	t.result.Steps = append(t.result.Steps, s)
+}
+
+// prepareCollection checks the designated collection or creates a
+// temporary guest collection.
+func (t *transferTest) prepareCollection(ctx context.Context) (string, error) {
+	if t.opts.CollectionID != "" {
+		collection, err := t.gcs.GetCollection(ctx, t.opts.CollectionID)
+		if err != nil {
+			return "", err
+		}
+		t.result.CollectionID = collection.ID
+		return fmt.Sprintf("using collection %q (%s)", collection.DisplayName, collection.ID), nil
+	}
+
+	credentials, err := t.gcs.ListUserCredentials(ctx)
+	if err != nil {
+		return "", fmt.Errorf("list user credentials: %w", err)
+	}
+	var credentialID string
+	for _, credential := range credentials.Data {
+		if credential.StorageGatewayID == t.opts.StorageGatewayID {
+			credentialID = credential.ID
+			break
+		}
+	}
+	if credentialID == "" {
+		return "", fmt.Errorf("no user credential for storage gateway %s (create one, or use --collection)", t.opts.StorageGatewayID)
+	}
+
+	collection, err := t.gcs.CreateCollection(ctx, &gcs.Collection{
+		DisplayName:          fmt.Sprintf("selftest %s", time.Now().UTC().Format(time.RFC3339)),
+		CollectionType:       "guest",
+		StorageGatewayID:     t.opts.StorageGatewayID,
+		CollectionBaseFolder: t.opts.BasePath,
+		UserCredentialID:     credentialID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("create guest collection: %w", err)
+	}
+	t.result.CollectionID = collection.ID
+	t.result.Temporary = true
+	return "created temporary guest collection " + collection.ID, nil
+}
+
+// copy transfers src to dst on the test collection with checksum
+// verification, and waits for the task to finish.
+func (t *transferTest) copy(ctx context.Context, label, src, dst string) (string, error) {
+	task, err := t.runTask(ctx, &transfer.TransferRequest{
+		Label:               label,
+		SourceEndpoint:      t.result.CollectionID,
+		DestinationEndpoint: t.result.CollectionID,
+		VerifyChecksum:      true,
+		Items:               []transfer.Item{{SourcePath: src, DestinationPath: dst}},
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("task %s: %s", task.TaskID, cli.Bytes(task.BytesTransferred)), nil
+}
+
+// verify compares the returned copy with the source by checksum. A
+// checksum-level sync skips files whose checksums match, so the copy is
+// intact only if the sync transferred nothing.
+func (t *transferTest) verify(ctx context.Context, returned string) (string, error) {
+	level := transfer.SyncChecksum
+	task, err := t.runTask(ctx, &transfer.TransferRequest{
+		Label:               "selftest verify",
+		SourceEndpoint:      t.result.CollectionID,
+		DestinationEndpoint: t.result.CollectionID,
+		VerifyChecksum:      true,
+		SyncLevel:           &level,
+		Items:               []transfer.Item{{SourcePath: t.opts.SourcePath, DestinationPath: returned}},
+	})
+	if err != nil {
+		return "", err
+	}
+	if task.FilesTransferred != 0 || task.FilesSkipped != 1 {
+		return "", fmt.Errorf("returned copy does not match the source checksum (task %s re-sent %d files)", task.TaskID, task.FilesTransferred)
+	}
+	return "returned copy matches the source checksum", nil
+}
+
+// deleteWorkDir removes the scratch directory.
+func (t *transferTest) deleteWorkDir(ctx context.Context) (string, error) {
+	taskID, err := t.transfer.SubmitDelete(ctx, &transfer.DeleteRequest{
+		Label:     "selftest cleanup",
+		Endpoint:  t.result.CollectionID,
+		Recursive: true,
+		Items:     []transfer.DeleteItem{{Path: t.result.WorkDir}},
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, err := t.wait(ctx, taskID); err != nil {
+		return "", err
+	}
+	return "deleted " + t.result.WorkDir, nil
+}
+
+// runTask submits a transfer task and waits for it to succeed.
+func (t *transferTest) runTask(ctx context.Context, req *transfer.TransferRequest) (*transfer.Task, error) {
+	taskID, err := t.transfer.SubmitTransfer(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return t.wait(ctx, taskID)
+}
+
+// wait waits up to the task timeout for a task and requires it to succeed.
+func (t *transferTest) wait(ctx context.Context, taskID string) (*transfer.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.opts.TaskTimeout)
+	defer cancel()
+
+	task, err := t.transfer.WaitForTask(ctx, taskID, pollInterval)
+	if err != nil {
+		return nil, err
+	}
+	if task.Status != transfer.StatusSucceeded {
+		detail := task.NiceStatus
+		if detail == "" {
+			detail = strings.ToLower(task.Status)
+		}
+		return nil, fmt.Errorf("task %s %s (%s)", taskID, strings.ToLower(task.Status), detail)
+	}
+	return task, nil
+}
+
+// printResult prints the self-test report.
+func printResult(formatter *output.Formatter, result *transferResult) error {
+	if formatter.IsStructured() {
+		return formatter.PrintData(result)
+	}
+
+	if err := formatter.PrintText("Transfer self-test for %s\n\n", result.Endpoint); err != nil {
+		return err
+	}
+
+	for _, s := range result.Steps {
+		if err := formatter.PrintText("  %-8s %-20s %-8s %s\n", strings.ToUpper(s.Status), s.Name, cli.Duration(time.Duration(s.DurationMS)*time.Millisecond), s.Detail); err != nil {
+			return err
+		}
+	}
+
+	status := "PASS"
+	if !result.Passed {
+		status = "FAIL"
+	}
+	return formatter.PrintText("\nResult: %s\n", status)
+}
@@ -0,0 +1,150 @@
+package selftest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/transfer"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs/gcstest"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+)
+
+// recordingTransport records the Authorization header of every request
+// and answers each with a server error.
+type recordingTransport struct {
+	mu     sync.Mutex
+	tokens map[string][]string // host -> Authorization headers
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mu.Lock()
+	rt.tokens[req.URL.Host] = append(rt.tokens[req.URL.Host], req.Header.Get("Authorization"))
+	rt.mu.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Body:       io.NopCloser(strings.NewReader(`{"code":"Unavailable","message":"test"}`)),
+		Header:     http.Header{},
+		Request:    req,
+	}, nil
+}
+
+func TestNewTransferCmd(t *testing.T) {
+	cmd := NewTransferCmd()
+
+	if cmd.Use != "transfer" {
+		t.Errorf("NewTransferCmd() Use = %q, want %q", cmd.Use, "transfer")
+	}
+	if cmd.Short == "" || cmd.Long == "" {
+		t.Error("NewTransferCmd() description is empty")
+	}
+
+	for _, flag := range []string{"endpoint", "collection", "storage-gateway", "base-path", "source-path", "work-dir", "task-timeout"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("NewTransferCmd() missing --%s flag", flag)
+		}
+	}
+}
+
+func TestRunTransfer_RequiresCollection(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	err := runTransfer(context.Background(), "nonexistent-profile-test", "text", "test.example.org", transferOptions{SourcePath: "/probe"}, buf)
+	if err == nil {
+		t.Error("runTransfer() expected error without --collection or --storage-gateway, got nil")
+	}
+}
+
+func TestRunTransfer_NoToken(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	opts := transferOptions{CollectionID: "col-1", SourcePath: "/probe", WorkDir: "/", TaskTimeout: time.Minute}
+	err := runTransfer(context.Background(), "nonexistent-profile-test", "text", "test.example.org", opts, buf)
+	if err == nil {
+		t.Error("runTransfer() expected error for nonexistent profile, got nil")
+	}
+	if buf.Len() > 0 {
+		t.Errorf("runTransfer() wrote to buffer on error: %q", buf.String())
+	}
+}
+
+func TestRunTransfer_UsesTransferToken(t *testing.T) {
+	t.Setenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR", t.TempDir())
+	t.Setenv(auth.PassphraseEnv, "correct horse battery staple")
+	if err := auth.SetTokenEncryption(auth.TokenEncryptionPassphrase); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = auth.SetTokenEncryption(auth.TokenEncryptionKeyring) })
+	token := &auth.TokenInfo{
+		AccessToken:    "auth-token",
+		ResourceServer: "auth.globus.org",
+		ExpiresAt:      time.Now().Add(time.Hour),
+		OtherTokens: map[string]*auth.TokenInfo{
+			cli.TransferResourceServer: {AccessToken: "transfer-token", ResourceServer: cli.TransferResourceServer, ExpiresAt: time.Now().Add(time.Hour)},
+		},
+	}
+	if err := auth.SaveToken("mock", token); err != nil {
+		t.Fatalf("SaveToken() error = %v", err)
+	}
+
+	client := &gcstest.Client{
+		GetCollectionFunc: func(_ context.Context, id string) (*gcs.Collection, error) {
+			return &gcs.Collection{ID: id, DisplayName: "Selftest"}, nil
+		},
+	}
+	factory := cli.GCSClientFactory
+	cli.GCSClientFactory = func(string, string) (gcs.GCSAPI, error) { return client, nil }
+	t.Cleanup(func() { cli.GCSClientFactory = factory })
+
+	rt := &recordingTransport{tokens: map[string][]string{}}
+	transferFactory := cli.TransferClientFactory
+	cli.TransferClientFactory = func(baseURL, accessToken string, opts ...transfer.Option) *transfer.Client {
+		return transfer.NewClient(baseURL, accessToken, append(opts, transfer.WithHTTPClient(&http.Client{Transport: rt}))...)
+	}
+	t.Cleanup(func() { cli.TransferClientFactory = transferFactory })
+
+	opts := transferOptions{CollectionID: "col-1", SourcePath: "/probe", WorkDir: "/", TaskTimeout: time.Minute}
+	if err := runTransfer(context.Background(), "mock", "json", "test.example.org", opts, &bytes.Buffer{}); err == nil {
+		t.Fatal("runTransfer() expected error from the failing Transfer API, got nil")
+	}
+
+	headers := rt.tokens["transfer.api.globus.org"]
+	if len(headers) == 0 {
+		t.Fatalf("no request reached the Transfer API (requests: %v)", rt.tokens)
+	}
+	for _, header := range headers {
+		if header != "Bearer transfer-token" {
+			t.Errorf("Transfer API request Authorization = %q, want the Transfer token", header)
+		}
+	}
+}
+
+func TestPrintResult(t *testing.T) {
+	result := &transferResult{
+		Endpoint: "test.example.org",
+		Steps: []step{
+			{Name: "collection", Status: stepPass},
+			{Name: "transfer-out", Status: stepFail, Detail: "task failed"},
+			{Name: "transfer-back", Status: stepSkipped},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := printResult(output.NewFormatter(output.FormatText, buf), result); err != nil {
+		t.Fatalf("printResult() error = %v", err)
+	}
+
+	for _, want := range []string{"PASS     collection", "FAIL     transfer-out", "SKIPPED  transfer-back", "Result: FAIL"} {
+		if !bytes.Contains(buf.Bytes(), []byte(want)) {
+			t.Errorf("printResult() output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
@@ -7,9 +7,9 @@ import (
 	"os"
 	"strings"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/trash"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -21,6 +21,7 @@ func NewDeleteCmd() *cobra.Command {
 		format       string
 		endpointFQDN string
 		force        bool
+		noTrash      bool
 	)
 
 	cmd := &cobra.Command{
@@ -29,7 +30,9 @@ func NewDeleteCmd() *cobra.Command {
 		Long: `Delete a sharing policy.
 
 This removes the sharing policy configuration. Use --force to skip the
-confirmation prompt.
+confirmation prompt. A copy of the policy is kept in the local trash for
+30 days and can be re-created with 'trash restore', unless --no-trash is
+given.
 
 Example:
   globus-connect-server sharing-policy delete abc123 \
@@ -43,14 +46,15 @@ Example:
 Requires an active authentication session (use 'login' first).`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDelete(cmd.Context(), profile, format, endpointFQDN, args[0], force, cmd.OutOrStdout())
+			return runDelete(cmd.Context(), profile, format, endpointFQDN, args[0], force, noTrash, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&noTrash, "no-trash", false, "Do not keep a copy in the local trash")
 
 	_ = cmd.MarkFlagRequired("endpoint")
 
@@ -58,9 +62,9 @@ Requires an active authentication session (use 'login' first).`,
 }
 
 // runDelete executes the sharing-policy delete command.
-func runDelete(ctx context.Context, profile, formatStr, endpointFQDN, policyID string, force bool, out interface{ Write([]byte) (int, error) }) error {
+func runDelete(ctx context.Context, profile, formatStr, endpointFQDN, policyID string, force, noTrash bool, out interface{ Write([]byte) (int, error) }) error {
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -71,7 +75,7 @@ func runDelete(ctx context.Context, profile, formatStr, endpointFQDN, policyID s
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Confirmation prompt unless --force
 	if !force {
@@ -88,24 +92,46 @@ func runDelete(ctx context.Context, profile, formatStr, endpointFQDN, policyID s
 	}
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
 
+	// Keep a copy for 'trash restore'
+	var trashed *trash.Item
+	if !noTrash {
+		policy, err := gcsClient.GetSharingPolicy(ctx, policyID)
+		if err != nil {
+			return fmt.Errorf("get sharing policy: %w", err)
+		}
+		if trashed, err = trash.Save(trash.KindSharingPolicy, policyID, endpointFQDN, policy); err != nil {
+			return fmt.Errorf("%w (use --no-trash to delete without a copy)", err)
+		}
+	}
+
 	// Delete sharing policy
 	if err := gcsClient.DeleteSharingPolicy(ctx, policyID); err != nil {
+		if trashed != nil {
+			_ = trash.Discard(trashed.ID)
+		}
 		return fmt.Errorf("delete sharing policy: %w", err)
 	}
 
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(map[string]string{"status": "deleted", "policy_id": policyID})
+	if formatter.IsStructured() {
+		result := map[string]string{"status": "deleted", "policy_id": policyID}
+		if trashed != nil {
+			result["trash_id"] = trashed.ID
+		}
+		return formatter.PrintData(result)
 	}
 
 	// Text format
-	return formatter.PrintText("Sharing policy %s deleted successfully\n", policyID)
+	if err := formatter.PrintText("Sharing policy %s deleted successfully\n", policyID); err != nil {
+		return err
+	}
+	if trashed != nil {
+		return formatter.PrintText("Restore it with 'trash restore %s' within 30 days.\n", trashed.ID)
+	}
+	return nil
 }
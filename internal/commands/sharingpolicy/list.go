@@ -3,10 +3,10 @@ package sharingpolicy
 import (
 	"context"
 	"fmt"
+	"strings"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -16,7 +16,9 @@ func NewListCmd() *cobra.Command {
 	var (
 		profile      string
 		format       string
+		quiet        bool
 		endpointFQDN string
+		columns      []string
 	)
 
 	cmd := &cobra.Command{
@@ -33,13 +35,15 @@ Example:
 
 Requires an active authentication session (use 'login' first).`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runList(cmd.Context(), profile, format, endpointFQDN, cmd.OutOrStdout())
+			return runList(cmd.Context(), profile, format, endpointFQDN, columns, quiet, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml, table, wide, csv)")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Only print IDs, one per line")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	cmd.Flags().StringSliceVar(&columns, "columns", nil, "Comma-separated columns to show, e.g. id,display_name (implies --format table)")
 
 	_ = cmd.MarkFlagRequired("endpoint")
 
@@ -47,9 +51,21 @@ Requires an active authentication session (use 'login' first).`,
 }
 
 // runList executes the sharing-policy list command.
-func runList(ctx context.Context, profile, formatStr, endpointFQDN string, out interface{ Write([]byte) (int, error) }) error {
+func runList(ctx context.Context, profile, formatStr, endpointFQDN string, columns []string, quiet bool, out interface{ Write([]byte) (int, error) }) error {
+	if quiet && output.Format(formatStr) != output.FormatText {
+		return fmt.Errorf("--quiet cannot be combined with --format %s", formatStr)
+	}
+
+	// --columns implies table format
+	if len(columns) > 0 && output.Format(formatStr) == output.FormatText {
+		formatStr = string(output.FormatTable)
+	}
+	if len(columns) > 0 && !output.Format(formatStr).IsTabular() {
+		return fmt.Errorf("--columns requires --format table, wide, or csv")
+	}
+
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -60,13 +76,10 @@ func runList(ctx context.Context, profile, formatStr, endpointFQDN string, out i
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions(output.WithColumns(columns), output.WithQuiet(quiet))...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -77,9 +90,28 @@ func runList(ctx context.Context, profile, formatStr, endpointFQDN string, out i
 		return fmt.Errorf("list sharing policies: %w", err)
 	}
 
+	if formatter.IsQuiet() {
+		ids := make([]string, 0, len(policies.Data))
+		for _, item := range policies.Data {
+			ids = append(ids, item.ID)
+		}
+		return formatter.PrintIDs(ids...)
+	}
+
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(policies)
+	if formatter.IsStructured() {
+		return formatter.PrintData(policies)
+	}
+
+	if formatter.IsTabular() {
+		table := output.NewTable("ID", "Name", "Collection", "Description")
+		table.AddWideColumns("Restrict", "Users Allowed", "Users Denied", "Groups Allowed", "Groups Denied")
+		for _, policy := range policies.Data {
+			table.AddRow(policy.ID, policy.Name, policy.CollectionID, policy.Description,
+				policy.SharingRestrict, strings.Join(policy.SharingUsersAllow, ","), strings.Join(policy.SharingUsersDeny, ","),
+				strings.Join(policy.SharingGroupsAllow, ","), strings.Join(policy.SharingGroupsDeny, ","))
+		}
+		return formatter.PrintTable(table)
 	}
 
 	// Text format
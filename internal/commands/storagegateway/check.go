@@ -0,0 +1,328 @@
+package storagegateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// deprecatedConnectors maps deprecated connector IDs to their replacement.
+var deprecatedConnectors = map[string]string{
+	"ceph": "use the s3 connector with the Ceph Object Gateway",
+}
+
+// gatewayCheck is the validation result for one storage gateway.
+type gatewayCheck struct {
+	ID          string                `json:"id"`
+	DisplayName string                `json:"display_name,omitempty"`
+	Valid       bool                  `json:"valid"`
+	Errors      []gcs.ValidationError `json:"errors,omitempty"`
+	Warnings    []gcs.ValidationError `json:"warnings,omitempty"`
+}
+
+// checkSummary is the result of 'storage-gateway check'.
+type checkSummary struct {
+	Checked  int            `json:"checked"`
+	Failed   int            `json:"failed"`
+	Warnings int            `json:"warnings"`
+	Gateways []gatewayCheck `json:"gateways"`
+}
+
+// NewCheckCmd creates the storage gateway check command.
+func NewCheckCmd() *cobra.Command {
+	var (
+		profile      string
+		format       string
+		endpointFQDN string
+		all          bool
+		concurrency  int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "check [GATEWAY_ID]",
+		Short: "Validate storage gateway configuration",
+		Long: `Validate the configuration of a storage gateway, or of every storage
+gateway on the endpoint with --all.
+
+Each gateway is checked for:
+  - errors: a missing root path, or high assurance without allowed
+    identity domains (which GCS requires for high assurance gateways)
+  - warnings: no path restrictions, a deprecated connector, or MFA
+    required on a gateway that is not high assurance (where it has no
+    effect)
+
+With --all, gateways are fetched and checked in parallel, up to
+--concurrency at a time. The command exits non-zero if any gateway has
+errors or could not be fetched; warnings alone do not fail the check.
+
+With --format github-actions, errors and warnings are written as GitHub
+Actions annotations and a table of results is added to the job summary.
+With --format sarif, they are written as a SARIF log for code-scanning
+dashboards.
+
+Example:
+  globus-connect-server storage-gateway check abc123 \
+    --endpoint example.data.globus.org
+
+  globus-connect-server storage-gateway check --all \
+    --endpoint example.data.globus.org
+
+Requires an active authentication session (use 'login' first).`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if all == (len(args) == 1) {
+				return fmt.Errorf("specify a GATEWAY_ID or --all")
+			}
+			gatewayID := ""
+			if len(args) == 1 {
+				gatewayID = args[0]
+			}
+			return runCheck(cmd.Context(), profile, format, endpointFQDN, gatewayID, concurrency, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml, github-actions, sarif)")
+	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	cmd.Flags().BoolVar(&all, "all", false, "Check every storage gateway on the endpoint")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 4, "Maximum gateways to check at once with --all")
+
+	_ = cmd.MarkFlagRequired("endpoint")
+
+	return cmd
+}
+
+// runCheck executes the storage gateway check command. An empty gatewayID
+// checks every gateway.
+func runCheck(ctx context.Context, profile, formatStr, endpointFQDN, gatewayID string, concurrency int, out interface{ Write([]byte) (int, error) }) error {
+	if concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	// Load token
+	token, err := cli.LoadToken(profile)
+	if err != nil {
+		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+
+	// Check if token is valid
+	if !token.IsValid() {
+		return fmt.Errorf("token expired, please login again")
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	// Create GCS client
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("create GCS client: %w", err)
+	}
+
+	ids := []string{gatewayID}
+	if gatewayID == "" {
+		ids, err = listGatewayIDs(ctx, gcsClient)
+		if err != nil {
+			return err
+		}
+	}
+
+	summary := checkGateways(ctx, gcsClient, ids, concurrency)
+
+	switch {
+	case formatter.IsStructured():
+		err = formatter.PrintData(summary)
+	case formatter.IsAnnotated():
+		err = reportCheckSummary(formatter, summary)
+	default:
+		err = formatCheckSummary(formatter, summary)
+	}
+	if err != nil {
+		return err
+	}
+
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d storage gateway(s) failed validation", summary.Failed, summary.Checked)
+	}
+	return nil
+}
+
+// listGatewayIDs returns the IDs of all storage gateways on the endpoint.
+func listGatewayIDs(ctx context.Context, client gcs.GCSAPI) ([]string, error) {
+	ids := []string{}
+	for gateway, err := range client.StorageGateways(ctx, nil) {
+		if err != nil {
+			return nil, fmt.Errorf("list storage gateways: %w", err)
+		}
+		ids = append(ids, gateway.ID)
+	}
+	return ids, nil
+}
+
+// checkGateways fetches and validates the gateways with up to concurrency
+// requests in flight. Results are in the order of ids.
+func checkGateways(ctx context.Context, client gcs.GCSAPI, ids []string, concurrency int) *checkSummary {
+	// Fetch failures are recorded per gateway, so the batch error adds
+	// nothing and is ignored.
+	batch, _ := gcs.RunBatch(ctx, ids, concurrency, func(ctx context.Context, id string) (gatewayCheck, error) {
+		gateway, err := client.GetStorageGateway(ctx, id)
+		if err != nil {
+			return gatewayCheck{}, err
+		}
+		return validateGateway(gateway), nil
+	})
+
+	results := make([]gatewayCheck, len(batch))
+	for i, r := range batch {
+		results[i] = r.Value
+		if r.Err != nil {
+			results[i] = gatewayCheck{
+				ID:     r.Item,
+				Errors: []gcs.ValidationError{{Code: "FETCH_FAILED", Message: r.Err.Error()}},
+			}
+		}
+	}
+
+	summary := &checkSummary{Checked: len(results), Gateways: results}
+	for _, r := range results {
+		if !r.Valid {
+			summary.Failed++
+		}
+		summary.Warnings += len(r.Warnings)
+	}
+	return summary
+}
+
+// validateGateway checks a storage gateway's configuration.
+func validateGateway(gateway *gcs.StorageGateway) gatewayCheck {
+	check := gatewayCheck{ID: gateway.ID, DisplayName: gateway.DisplayName}
+
+	if gateway.Root == "" {
+		check.Errors = append(check.Errors, gcs.ValidationError{
+			Code:    "MISSING_ROOT",
+			Message: "no root path is configured",
+			Field:   "root",
+		})
+	}
+	if gateway.HighAssurance && len(gateway.AllowedDomains) == 0 {
+		check.Errors = append(check.Errors, gcs.ValidationError{
+			Code:    "HIGH_ASSURANCE_NO_DOMAINS",
+			Message: "high assurance gateways must restrict allowed identity domains",
+			Field:   "allowed_domains",
+		})
+	}
+
+	if gateway.RestrictPaths == nil ||
+		len(gateway.RestrictPaths.ReadOnly)+len(gateway.RestrictPaths.ReadWrite)+len(gateway.RestrictPaths.None) == 0 {
+		check.Warnings = append(check.Warnings, gcs.ValidationError{
+			Code:    "NO_RESTRICT_PATHS",
+			Message: "no path restrictions; the whole root is accessible",
+			Field:   "restrict_paths",
+		})
+	}
+	if replacement, ok := deprecatedConnectors[gateway.ConnectorID]; ok {
+		check.Warnings = append(check.Warnings, gcs.ValidationError{
+			Code:    "DEPRECATED_CONNECTOR",
+			Message: fmt.Sprintf("connector %q is deprecated; %s", gateway.ConnectorID, replacement),
+			Field:   "connector_id",
+		})
+	}
+	if gateway.RequireMFA && !gateway.HighAssurance {
+		check.Warnings = append(check.Warnings, gcs.ValidationError{
+			Code:    "MFA_WITHOUT_HIGH_ASSURANCE",
+			Message: "require_mfa only takes effect on high assurance gateways",
+			Field:   "require_mfa",
+		})
+	}
+
+	check.Valid = len(check.Errors) == 0
+	return check
+}
+
+// formatCheckSummary formats gateway check results as text.
+func formatCheckSummary(formatter *output.Formatter, summary *checkSummary) error {
+	for _, r := range summary.Gateways {
+		mark := formatter.Success("✓")
+		switch {
+		case !r.Valid:
+			mark = formatter.Failure("✗")
+		case len(r.Warnings) > 0:
+			mark = formatter.Warning("!")
+		}
+		name := r.ID
+		if r.DisplayName != "" {
+			name = fmt.Sprintf("%s %s", r.DisplayName, formatter.Dim("("+r.ID+")"))
+		}
+		if err := formatter.PrintText("%s %s\n", mark, name); err != nil {
+			return err
+		}
+		for _, issue := range r.Errors {
+			if err := formatter.PrintText("    %s [%s] %s\n", formatter.Failure("error:  "), issue.Code, issue.Message); err != nil {
+				return err
+			}
+		}
+		for _, issue := range r.Warnings {
+			if err := formatter.PrintText("    %s [%s] %s\n", formatter.Warning("warning:"), issue.Code, issue.Message); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := formatter.Println(); err != nil {
+		return err
+	}
+	return formatter.PrintText("Checked %d storage gateway(s): %d failed, %d warning(s)\n",
+		summary.Checked, summary.Failed, summary.Warnings)
+}
+
+// reportCheckSummary reports gateway check results as annotations (GitHub
+// Actions or SARIF) and a job summary table.
+func reportCheckSummary(formatter *output.Formatter, summary *checkSummary) error {
+	var annotations []output.Annotation
+	var b strings.Builder
+	b.WriteString("### Storage gateway check\n\n")
+	b.WriteString("| Gateway | Result | Errors | Warnings |\n")
+	b.WriteString("| --- | --- | --- | --- |\n")
+
+	for _, r := range summary.Gateways {
+		name := r.ID
+		if r.DisplayName != "" {
+			name = fmt.Sprintf("%s (%s)", r.DisplayName, r.ID)
+		}
+		for _, issue := range r.Errors {
+			annotations = append(annotations, output.Annotation{
+				Level:   output.AnnotationError,
+				Title:   fmt.Sprintf("storage gateway %s: %s", r.ID, issue.Code),
+				Rule:    issue.Code,
+				Message: issue.Message,
+			})
+		}
+		for _, issue := range r.Warnings {
+			annotations = append(annotations, output.Annotation{
+				Level:   output.AnnotationWarning,
+				Title:   fmt.Sprintf("storage gateway %s: %s", r.ID, issue.Code),
+				Rule:    issue.Code,
+				Message: issue.Message,
+			})
+		}
+
+		result := "✅ valid"
+		if !r.Valid {
+			result = "❌ invalid"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %d | %d |\n", strings.ReplaceAll(name, "|", "\\|"), result, len(r.Errors), len(r.Warnings))
+	}
+	fmt.Fprintf(&b, "\nChecked %d storage gateway(s): %d failed, %d warning(s)\n\n",
+		summary.Checked, summary.Failed, summary.Warnings)
+
+	if err := formatter.PrintAnnotations(annotations); err != nil {
+		return err
+	}
+	return formatter.WriteStepSummary(b.String())
+}
@@ -0,0 +1,132 @@
+package storagegateway
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+)
+
+func TestNewCheckCmd(t *testing.T) {
+	cmd := NewCheckCmd()
+
+	if cmd.Use != "check [GATEWAY_ID]" {
+		t.Errorf("NewCheckCmd() Use = %q, want %q", cmd.Use, "check [GATEWAY_ID]")
+	}
+	for _, flag := range []string{"all", "concurrency", "endpoint"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("NewCheckCmd() missing --%s flag", flag)
+		}
+	}
+
+	cmd.SetArgs([]string{"--endpoint", "example.data.globus.org"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	if err := cmd.Execute(); err == nil {
+		t.Error("check without GATEWAY_ID or --all should fail")
+	}
+}
+
+func TestRunCheck_NoToken(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := runCheck(context.Background(), "nonexistent-profile-test", "text", "example.data.globus.org", "", 4, buf)
+	if err == nil {
+		t.Error("runCheck() with no token should return error")
+	}
+}
+
+func TestValidateGateway(t *testing.T) {
+	restricted := &gcs.PathRestrictions{ReadWrite: []string{"/data"}}
+
+	tests := []struct {
+		name         string
+		gateway      gcs.StorageGateway
+		wantValid    bool
+		wantErrors   []string
+		wantWarnings []string
+	}{
+		{
+			name:      "clean",
+			gateway:   gcs.StorageGateway{ID: "g1", Root: "/", ConnectorID: "posix", RestrictPaths: restricted},
+			wantValid: true,
+		},
+		{
+			name:         "unrestricted",
+			gateway:      gcs.StorageGateway{ID: "g2", Root: "/"},
+			wantValid:    true,
+			wantWarnings: []string{"NO_RESTRICT_PATHS"},
+		},
+		{
+			name:         "high assurance mismatch",
+			gateway:      gcs.StorageGateway{ID: "g3", Root: "/", HighAssurance: true, RestrictPaths: restricted},
+			wantErrors:   []string{"HIGH_ASSURANCE_NO_DOMAINS"},
+			wantWarnings: nil,
+		},
+		{
+			name:         "deprecated connector with MFA",
+			gateway:      gcs.StorageGateway{ID: "g4", ConnectorID: "ceph", RequireMFA: true, RestrictPaths: restricted},
+			wantErrors:   []string{"MISSING_ROOT"},
+			wantWarnings: []string{"DEPRECATED_CONNECTOR", "MFA_WITHOUT_HIGH_ASSURANCE"},
+		},
+	}
+
+	codes := func(issues []gcs.ValidationError) []string {
+		var out []string
+		for _, issue := range issues {
+			out = append(out, issue.Code)
+		}
+		return out
+	}
+	equal := func(a, b []string) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateGateway(&tt.gateway)
+			if got.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v", got.Valid, tt.wantValid)
+			}
+			if !equal(codes(got.Errors), tt.wantErrors) {
+				t.Errorf("Errors = %v, want %v", codes(got.Errors), tt.wantErrors)
+			}
+			if !equal(codes(got.Warnings), tt.wantWarnings) {
+				t.Errorf("Warnings = %v, want %v", codes(got.Warnings), tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestReportCheckSummary(t *testing.T) {
+	summary := &checkSummary{
+		Checked: 1,
+		Failed:  1,
+		Gateways: []gatewayCheck{{
+			ID:          "g1",
+			DisplayName: "Lab",
+			Errors:      []gcs.ValidationError{{Code: "MISSING_ROOT", Message: "no root path is configured"}},
+			Warnings:    []gcs.ValidationError{{Code: "NO_RESTRICT_PATHS", Message: "no path restrictions"}},
+		}},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := reportCheckSummary(output.NewFormatter(output.FormatGitHubActions, buf), summary); err != nil {
+		t.Fatalf("reportCheckSummary() error = %v", err)
+	}
+
+	want := "::error title=storage gateway g1%3A MISSING_ROOT::no root path is configured\n" +
+		"::warning title=storage gateway g1%3A NO_RESTRICT_PATHS::no path restrictions\n"
+	if got := buf.String(); got != want {
+		t.Errorf("reportCheckSummary() =\n%q\nwant\n%q", got, want)
+	}
+}
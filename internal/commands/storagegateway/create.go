@@ -5,8 +5,9 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/s3check"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -17,6 +18,7 @@ func NewCreateCmd() *cobra.Command {
 	var (
 		profile            string
 		format             string
+		quiet              bool
 		endpointFQDN       string
 		displayName        string
 		connectorID        string
@@ -27,6 +29,7 @@ func NewCreateCmd() *cobra.Command {
 		posixStagingFolder string
 		posixUserIDMap     string
 		posixGroupIDMap    string
+		s3                 s3Options
 	)
 
 	cmd := &cobra.Command{
@@ -43,6 +46,14 @@ Common connector IDs:
   - azure-blob: For Azure Blob Storage
   - s3: For Amazon S3
 
+For S3 gateways, --s3-bucket and --s3-endpoint restrict the gateway to
+the given buckets. Add --validate-s3 to list each bucket with your AWS
+credentials before creating the gateway, catching typos in bucket names,
+regions, and keys that GCS would otherwise only report on the first
+transfer. Credentials come from --aws-profile if given, otherwise from
+the environment, the AWS_PROFILE (or default) profile in
+~/.aws/credentials, or the EC2 instance role.
+
 Example:
   globus-connect-server storagegateway create \
     --endpoint example.data.globus.org \
@@ -50,17 +61,27 @@ Example:
     --connector-id posix \
     --root /data
 
+  globus-connect-server storagegateway create \
+    --endpoint example.data.globus.org \
+    --display-name "Project Buckets" \
+    --connector-id s3 \
+    --root / \
+    --s3-bucket project-data \
+    --s3-endpoint https://s3.us-west-2.amazonaws.com \
+    --validate-s3 --aws-profile research
+
 Requires an active authentication session (use 'login' first).`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return runCreate(cmd.Context(), profile, format, endpointFQDN,
 				displayName, connectorID, root, allowedDomains,
 				highAssurance, requireMFA, posixStagingFolder,
-				posixUserIDMap, posixGroupIDMap, cmd.OutOrStdout())
+				posixUserIDMap, posixGroupIDMap, s3, quiet, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Only print IDs, one per line")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 	cmd.Flags().StringVar(&displayName, "display-name", "", "Display name for the storage gateway")
 	cmd.Flags().StringVar(&connectorID, "connector-id", "posix", "Connector ID (posix, s3, azure-blob, etc.)")
@@ -71,6 +92,11 @@ Requires an active authentication session (use 'login' first).`,
 	cmd.Flags().StringVar(&posixStagingFolder, "posix-staging-path", "", "POSIX staging folder path")
 	cmd.Flags().StringVar(&posixUserIDMap, "posix-user-id-map", "", "POSIX user ID mapping")
 	cmd.Flags().StringVar(&posixGroupIDMap, "posix-group-id-map", "", "POSIX group ID mapping")
+	cmd.Flags().StringSliceVar(&s3.Buckets, "s3-bucket", nil, "S3 bucket the gateway may access (repeatable)")
+	cmd.Flags().StringVar(&s3.Endpoint, "s3-endpoint", "", "S3 endpoint URL (default "+s3check.DefaultEndpoint+")")
+	cmd.Flags().BoolVar(&s3.Validate, "validate-s3", false, "List each S3 bucket with AWS credentials before creating")
+	cmd.Flags().StringVar(&s3.AWSProfile, "aws-profile", "", "AWS shared credentials profile for --validate-s3")
+	cmd.Flags().StringVar(&s3.AWSRegion, "aws-region", "", "AWS region for --validate-s3 (default: from the endpoint)")
 
 	_ = cmd.MarkFlagRequired("endpoint")
 	_ = cmd.MarkFlagRequired("display-name")
@@ -84,10 +110,15 @@ func runCreate(ctx context.Context, profile, formatStr, endpointFQDN string,
 	displayName, connectorID, root, allowedDomains string,
 	highAssurance, requireMFA bool,
 	posixStagingFolder, posixUserIDMap, posixGroupIDMap string,
-	out interface{ Write([]byte) (int, error) }) error {
+	s3 s3Options,
+	quiet bool, out interface{ Write([]byte) (int, error) }) error {
+
+	if quiet && output.Format(formatStr) != output.FormatText {
+		return fmt.Errorf("--quiet cannot be combined with --format %s", formatStr)
+	}
 
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -98,13 +129,10 @@ func runCreate(ctx context.Context, profile, formatStr, endpointFQDN string,
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions(output.WithQuiet(quiet))...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -130,15 +158,36 @@ func runCreate(ctx context.Context, profile, formatStr, endpointFQDN string,
 		}
 	}
 
+	if len(s3.Buckets) > 0 || s3.Endpoint != "" {
+		if connectorID != "s3" {
+			return fmt.Errorf("--s3-bucket and --s3-endpoint require --connector-id s3")
+		}
+		gateway.Policies = &gcs.StorageGatewayPolicies{
+			DataType:   gcs.S3StoragePolicies,
+			S3Endpoint: s3.Endpoint,
+			S3Buckets:  s3.Buckets,
+		}
+	}
+
+	if s3.Validate {
+		if err := validateS3(ctx, s3, s3check.DefaultProvider(s3.AWSProfile)); err != nil {
+			return err
+		}
+	}
+
 	// Create storage gateway
 	created, err := gcsClient.CreateStorageGateway(ctx, gateway)
 	if err != nil {
 		return fmt.Errorf("create storage gateway: %w", err)
 	}
 
+	if formatter.IsQuiet() {
+		return formatter.PrintIDs(created.ID)
+	}
+
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(created)
+	if formatter.IsStructured() {
+		return formatter.PrintData(created)
 	}
 
 	// Text format
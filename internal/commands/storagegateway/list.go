@@ -4,9 +4,10 @@ package storagegateway
 import (
 	"context"
 	"fmt"
+	"strings"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -17,7 +18,10 @@ func NewListCmd() *cobra.Command {
 	var (
 		profile      string
 		format       string
+		quiet        bool
+		all          bool
 		endpointFQDN string
+		columns      []string
 		filter       string
 	)
 
@@ -31,13 +35,16 @@ Storage gateways define the connection between GCS and storage backends
 
 Requires an active authentication session (use 'login' first).`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runList(cmd.Context(), profile, format, endpointFQDN, filter, cmd.OutOrStdout())
+			return runList(cmd.Context(), profile, format, endpointFQDN, filter, columns, quiet, all, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml, table, wide, csv)")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Only print IDs, one per line")
+	cmd.Flags().BoolVar(&all, "all", false, "List every storage gateway, following pagination, not just the first page")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	cmd.Flags().StringSliceVar(&columns, "columns", nil, "Comma-separated columns to show, e.g. id,display_name (implies --format table)")
 	cmd.Flags().StringVar(&filter, "filter", "", "Filter storage gateways by name")
 	_ = cmd.MarkFlagRequired("endpoint")
 
@@ -45,9 +52,21 @@ Requires an active authentication session (use 'login' first).`,
 }
 
 // runList executes the storage gateway list command.
-func runList(ctx context.Context, profile, formatStr, endpointFQDN, filter string, out interface{ Write([]byte) (int, error) }) error {
+func runList(ctx context.Context, profile, formatStr, endpointFQDN, filter string, columns []string, quiet, all bool, out interface{ Write([]byte) (int, error) }) error {
+	if quiet && output.Format(formatStr) != output.FormatText {
+		return fmt.Errorf("--quiet cannot be combined with --format %s", formatStr)
+	}
+
+	// --columns implies table format
+	if len(columns) > 0 && output.Format(formatStr) == output.FormatText {
+		formatStr = string(output.FormatTable)
+	}
+	if len(columns) > 0 && !output.Format(formatStr).IsTabular() {
+		return fmt.Errorf("--columns requires --format table, wide, or csv")
+	}
+
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -58,13 +77,10 @@ func runList(ctx context.Context, profile, formatStr, endpointFQDN, filter strin
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions(output.WithColumns(columns), output.WithQuiet(quiet))...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -76,14 +92,39 @@ func runList(ctx context.Context, profile, formatStr, endpointFQDN, filter strin
 	}
 
 	// Get storage gateways
-	list, err := gcsClient.ListStorageGateways(ctx, opts)
+	list, err := listStorageGateways(ctx, gcsClient, opts, all)
 	if err != nil {
 		return fmt.Errorf("list storage gateways: %w", err)
 	}
+	if list.HasNextPage {
+		cli.Warnf("the endpoint has more results than this page; use --all to list them all")
+	}
+
+	if formatter.IsQuiet() {
+		ids := make([]string, 0, len(list.Data))
+		for _, item := range list.Data {
+			ids = append(ids, item.ID)
+		}
+		return formatter.PrintIDs(ids...)
+	}
 
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(list)
+	if formatter.IsStructured() {
+		return formatter.PrintData(list)
+	}
+
+	if formatter.IsTabular() {
+		table := output.NewTable("ID", "Display Name", "Connector", "Root", "High Assurance")
+		table.AddWideColumns("Require MFA", "Allowed Domains", "Identity Mappings")
+		for _, gateway := range list.Data {
+			connector := gateway.ConnectorName
+			if connector == "" {
+				connector = gateway.ConnectorID
+			}
+			table.AddRow(gateway.ID, gateway.DisplayName, connector, gateway.Root, fmt.Sprint(gateway.HighAssurance),
+				fmt.Sprint(gateway.RequireMFA), strings.Join(gateway.AllowedDomains, ","), fmt.Sprint(len(gateway.IdentityMappings)))
+		}
+		return formatter.PrintTable(table)
 	}
 
 	// Text format
@@ -128,3 +169,16 @@ func runList(ctx context.Context, profile, formatStr, endpointFQDN, filter strin
 
 	return nil
 }
+
+// listStorageGateways returns the first page of storage gateways, or with all, every
+// page combined into one.
+func listStorageGateways(ctx context.Context, client gcs.GCSAPI, opts *gcs.ListStorageGatewaysOptions, all bool) (*gcs.StorageGatewayList, error) {
+	if !all {
+		return client.ListStorageGateways(ctx, opts)
+	}
+	items, err := client.ListAllStorageGateways(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &gcs.StorageGatewayList{Data: items, TotalResults: len(items)}, nil
+}
@@ -0,0 +1,50 @@
+package storagegateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/s3check"
+)
+
+// s3Options holds the S3 connector flags of 'storagegateway create'.
+type s3Options struct {
+	Buckets    []string
+	Endpoint   string
+	Validate   bool
+	AWSProfile string
+	AWSRegion  string
+}
+
+// validateS3 lists each configured bucket with credentials from provider
+// and reports every bucket that fails.
+func validateS3(ctx context.Context, opts s3Options, provider s3check.Provider) error {
+	if len(opts.Buckets) == 0 {
+		return fmt.Errorf("--validate-s3 requires at least one --s3-bucket")
+	}
+
+	creds, err := provider.Retrieve(ctx)
+	if err != nil {
+		if errors.Is(err, s3check.ErrNoCredentials) {
+			return fmt.Errorf("validate S3: %w (set --aws-profile or AWS_ACCESS_KEY_ID, or omit --validate-s3)", err)
+		}
+		return fmt.Errorf("validate S3: %w", err)
+	}
+
+	var failures []error
+	for _, bucket := range opts.Buckets {
+		err := s3check.ValidateBucket(ctx, nil, s3check.Bucket{
+			Endpoint: opts.Endpoint,
+			Region:   opts.AWSRegion,
+			Name:     bucket,
+		}, creds)
+		if err != nil {
+			failures = append(failures, err)
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("validate S3 with credentials from %s: %w", creds.Source, errors.Join(failures...))
+	}
+	return nil
+}
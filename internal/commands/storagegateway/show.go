@@ -4,8 +4,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -14,9 +14,10 @@ import (
 // NewShowCmd creates the storage gateway show command.
 func NewShowCmd() *cobra.Command {
 	var (
-		profile      string
-		format       string
-		endpointFQDN string
+		profile            string
+		format             string
+		endpointFQDN       string
+		includeCollections bool
 	)
 
 	cmd := &cobra.Command{
@@ -27,26 +28,40 @@ func NewShowCmd() *cobra.Command {
 This command retrieves and displays the complete configuration of a storage gateway
 including connector type, root path, identity mappings, and security settings.
 
+With --include-collections, also lists every collection that uses the gateway
+(ID, name, type, and base path), which is useful before changing the gateway.
+
+Example:
+  globus-connect-server storage-gateway show abc123 \
+    --endpoint example.data.globus.org --include-collections
+
 Requires an active authentication session (use 'login' first).`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			gatewayID := args[0]
-			return runShow(cmd.Context(), profile, format, endpointFQDN, gatewayID, cmd.OutOrStdout())
+			return runShow(cmd.Context(), profile, format, endpointFQDN, gatewayID, includeCollections, cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
+	cmd.Flags().BoolVar(&includeCollections, "include-collections", false, "Also list the collections that use this gateway")
 	_ = cmd.MarkFlagRequired("endpoint")
 
 	return cmd
 }
 
+// gatewayWithCollections is the JSON output of show --include-collections.
+type gatewayWithCollections struct {
+	*gcs.StorageGateway
+	Collections []gcs.Collection `json:"collections"`
+}
+
 // runShow executes the storage gateway show command.
-func runShow(ctx context.Context, profile, formatStr, endpointFQDN, gatewayID string, out interface{ Write([]byte) (int, error) }) error {
+func runShow(ctx context.Context, profile, formatStr, endpointFQDN, gatewayID string, includeCollections bool, out interface{ Write([]byte) (int, error) }) error {
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -57,13 +72,10 @@ func runShow(ctx context.Context, profile, formatStr, endpointFQDN, gatewayID st
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -74,13 +86,61 @@ func runShow(ctx context.Context, profile, formatStr, endpointFQDN, gatewayID st
 		return fmt.Errorf("get storage gateway: %w", err)
 	}
 
+	if !includeCollections {
+		// Output based on format
+		if formatter.IsStructured() {
+			return formatter.PrintData(gateway)
+		}
+
+		// Text format
+		return formatGatewayText(formatter, gateway)
+	}
+
+	// Find the collections that use this gateway
+	collections, err := gcsClient.ListCollectionsForGateway(ctx, gatewayID)
+	if err != nil {
+		return fmt.Errorf("list collections for storage gateway: %w", err)
+	}
+
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(gateway)
+	if formatter.IsStructured() {
+		if collections == nil {
+			collections = []gcs.Collection{}
+		}
+		return formatter.PrintData(gatewayWithCollections{StorageGateway: gateway, Collections: collections})
 	}
 
 	// Text format
-	return formatGatewayText(formatter, gateway)
+	if err := formatGatewayText(formatter, gateway); err != nil {
+		return err
+	}
+	return printGatewayCollections(formatter, collections)
+}
+
+// printGatewayCollections prints the collections that use a gateway.
+func printGatewayCollections(formatter *output.Formatter, collections []gcs.Collection) error {
+	if err := formatter.Println(); err != nil {
+		return err
+	}
+	if err := formatter.PrintText("Collections (%d):\n", len(collections)); err != nil {
+		return err
+	}
+
+	if len(collections) == 0 {
+		return formatter.Println("  No collections use this storage gateway.")
+	}
+
+	if err := formatter.PrintText("  %-38s %-30s %-8s %s\n", "ID", "DISPLAY NAME", "TYPE", "BASE PATH"); err != nil {
+		return err
+	}
+	for _, collection := range collections {
+		if err := formatter.PrintText("  %-38s %-30s %-8s %s\n",
+			collection.ID, collection.DisplayName, collection.CollectionType, collection.CollectionBaseFolder); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // formatGatewayText formats the storage gateway in text format.
@@ -3,9 +3,12 @@ package storagegateway
 import (
 	"bytes"
 	"context"
+	"strings"
 	"testing"
 
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 )
 
 func TestNewShowCmd(t *testing.T) {
@@ -62,6 +65,12 @@ func TestNewShowCmd_Flags(t *testing.T) {
 			flagName:  "endpoint",
 			shorthand: "",
 		},
+		{
+			name:         "include-collections flag",
+			flagName:     "include-collections",
+			shorthand:    "",
+			defaultValue: "false",
+		},
 	}
 
 	for _, tt := range tests {
@@ -87,7 +96,7 @@ func TestRunShow_NoToken(t *testing.T) {
 	buf := &bytes.Buffer{}
 
 	// Test with a profile that doesn't exist
-	err := runShow(ctx, "nonexistent-profile-test", "text", "test.example.org", "test-gateway-id", buf)
+	err := runShow(ctx, "nonexistent-profile-test", "text", "test.example.org", "test-gateway-id", false, buf)
 	if err == nil {
 		t.Error("runShow() expected error for nonexistent profile, got nil")
 	}
@@ -97,3 +106,22 @@ func TestRunShow_NoToken(t *testing.T) {
 		t.Errorf("runShow() wrote to buffer on error: %q", buf.String())
 	}
 }
+
+func TestPrintGatewayCollections(t *testing.T) {
+	buf := &bytes.Buffer{}
+	formatter := output.NewFormatter(output.FormatText, buf)
+
+	collections := []gcs.Collection{
+		{ID: "col-1", DisplayName: "Project Data", CollectionType: "mapped", CollectionBaseFolder: "/data/project"},
+	}
+	if err := printGatewayCollections(formatter, collections); err != nil {
+		t.Fatalf("printGatewayCollections() error = %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{"Collections (1):", "col-1", "Project Data", "mapped", "/data/project"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("printGatewayCollections() output missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -18,7 +18,9 @@ Amazon S3, Azure Blob Storage, Google Cloud Storage, and others.`,
 	cmd.AddCommand(NewShowCmd())
 	cmd.AddCommand(NewCreateCmd())
 	cmd.AddCommand(NewUpdateCmd())
+	cmd.AddCommand(NewEditCmd())
 	cmd.AddCommand(NewDeleteCmd())
+	cmd.AddCommand(NewCheckCmd())
 
 	return cmd
 }
@@ -5,8 +5,8 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
@@ -51,13 +51,13 @@ Requires an active authentication session (use 'login' first).`,
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 	cmd.Flags().StringVar(&displayName, "display-name", "", "Display name for the storage gateway")
 	cmd.Flags().StringVar(&allowedDomains, "allowed-domains", "", "Comma-separated allowed authentication domains")
 
 	// Boolean flags need special handling
-	highAssuranceStr := cmd.Flags().String("high-assurance", "", "Require high assurance for access (true/false)")
+	highAssuranceStr := cli.Enum(cmd, "high-assurance", "", "Require high assurance for access (true/false)", cli.BoolChoices...)
 	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
 		if *highAssuranceStr != "" {
 			val := *highAssuranceStr == "true"
@@ -71,7 +71,7 @@ Requires an active authentication session (use 'login' first).`,
 		return nil
 	}
 
-	cmd.Flags().String("require-mfa", "", "Require multi-factor authentication (true/false)")
+	cli.Enum(cmd, "require-mfa", "", "Require multi-factor authentication (true/false)", cli.BoolChoices...)
 	cmd.Flags().StringVar(&posixStagingFolder, "posix-staging-path", "", "POSIX staging folder path")
 	cmd.Flags().StringVar(&posixUserIDMap, "posix-user-id-map", "", "POSIX user ID mapping")
 	cmd.Flags().StringVar(&posixGroupIDMap, "posix-group-id-map", "", "POSIX group ID mapping")
@@ -88,7 +88,7 @@ func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN, gatewayID
 	out interface{ Write([]byte) (int, error) }) error {
 
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -99,13 +99,10 @@ func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN, gatewayID
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -148,8 +145,8 @@ func runUpdate(ctx context.Context, profile, formatStr, endpointFQDN, gatewayID
 	}
 
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(updated)
+	if formatter.IsStructured() {
+		return formatter.PrintData(updated)
 	}
 
 	// Text format
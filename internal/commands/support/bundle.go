@@ -0,0 +1,443 @@
+package support
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/history"
+	"github.com/scttfrdmn/globus-go-gcs/internal/i18n"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+// defaultHistoryLimit is how many activity log entries a bundle includes.
+const defaultHistoryLimit = 50
+
+// Test hooks.
+var (
+	interactive = cli.Interactive
+	confirm     = cli.Confirm
+	now         = time.Now
+)
+
+// bundleOptions are the options of 'support bundle'.
+type bundleOptions struct {
+	outputFile   string
+	historyLimit int
+	review       bool
+	yes          bool
+	version      string
+}
+
+// BundleFile describes one file of a support bundle.
+type BundleFile struct {
+	Name       string   `json:"name"`
+	Size       int      `json:"size"`
+	Redactions []string `json:"redactions,omitempty"`
+}
+
+// Manifest describes a support bundle. It is stored in the bundle as
+// manifest.json.
+type Manifest struct {
+	GeneratedAt time.Time    `json:"generated_at"`
+	CLIVersion  string       `json:"cli_version"`
+	Profile     string       `json:"profile"`
+	Endpoint    string       `json:"endpoint,omitempty"`
+	Files       []BundleFile `json:"files"`
+	// Errors lists the information that could not be collected.
+	Errors  []string `json:"errors,omitempty"`
+	Archive string   `json:"archive,omitempty"`
+}
+
+// bundle holds the files of a support bundle before it is written.
+type bundle struct {
+	manifest Manifest
+	data     map[string][]byte
+}
+
+// NewBundleCmd creates the support bundle command.
+func NewBundleCmd() *cobra.Command {
+	var (
+		profile      string
+		endpointFQDN string
+		opts         bundleOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Create a diagnostics archive for a support ticket",
+		Long: `Gather diagnostics into a gzipped tar archive to attach to a Globus
+support ticket.
+
+The archive contains:
+  version.json      CLI version, Go version, and platform
+  config.yaml       config.yaml, with secrets and hook commands redacted
+  effective.json    The effective configuration (see 'config effective')
+  token.json        Whether the profile is logged in, and until when;
+                    never the tokens themselves
+  endpoint.json     Endpoint information and configuration (with --endpoint)
+  connection.json   DNS, TCP, and TLS diagnostics (with --endpoint)
+  history.json      The most recent activity log entries
+  manifest.json     The list of files and what was redacted in each
+
+Values of keys naming a secret, password, passphrase, token, or
+credential are replaced with REDACTED, as are the profile's tokens wherever
+they appear. Before the archive is written, its files and redactions are
+listed for review; --review also prints the full contents. Confirm on the
+terminal, or pass --yes once you have reviewed them.
+
+Example:
+  globus-connect-server support bundle --endpoint abc.def.data.globus.org --review`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, _ := cmd.Flags().GetString("format")
+			opts.version = cmd.Root().Version
+			return runBundle(cmd.Context(), profile, format, endpointFQDN, opts, cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "GCS endpoint FQDN to diagnose")
+	cmd.Flags().StringVarP(&opts.outputFile, "output", "o", "", "Archive file (default gcs-support-<time>.tar.gz)")
+	cmd.Flags().IntVar(&opts.historyLimit, "history", defaultHistoryLimit, "Number of recent activity log entries to include")
+	cmd.Flags().BoolVar(&opts.review, "review", false, "Print the full contents of the bundle before writing it")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Write the bundle without asking for confirmation")
+
+	return cmd
+}
+
+// runBundle executes the support bundle command. The review of the
+// bundle's contents is written to review, the result to out.
+func runBundle(ctx context.Context, profile, formatStr, endpointFQDN string, opts bundleOptions, out, review io.Writer) error {
+	if opts.historyLimit < 0 {
+		return fmt.Errorf("--history must not be negative")
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	generated := now().UTC()
+	b := &bundle{
+		manifest: Manifest{
+			GeneratedAt: generated,
+			CLIVersion:  opts.version,
+			Profile:     profile,
+			Endpoint:    endpointFQDN,
+		},
+		data: map[string][]byte{},
+	}
+
+	b.addJSON("version.json", map[string]string{
+		"cli_version": opts.version,
+		"go_version":  runtime.Version(),
+		"os":          runtime.GOOS,
+		"arch":        runtime.GOARCH,
+	}, nil)
+	b.collectConfig()
+
+	token, tokenErr := cli.LoadToken(profile)
+	b.collectToken(profile, token, tokenErr)
+	if endpointFQDN != "" {
+		b.collectEndpoint(ctx, endpointFQDN, token)
+		b.addJSON("connection.json", diagnoseConnection(ctx, endpointFQDN), nil)
+	}
+	b.collectHistory(opts.historyLimit)
+
+	if token != nil {
+		b.scrub(map[string]string{
+			"access token":  token.AccessToken,
+			"refresh token": token.RefreshToken,
+		})
+	}
+
+	if err := b.printReview(review, opts.review); err != nil {
+		return err
+	}
+
+	path := opts.outputFile
+	if path == "" {
+		path = fmt.Sprintf("gcs-support-%s.tar.gz", generated.Format("20060102T150405Z"))
+	}
+	if !opts.yes {
+		if !interactive() {
+			return errors.New("review the bundle contents above, then rerun with --yes to write it")
+		}
+		if !confirm(i18n.Sprintf("Write support bundle to %s? [y/N]: ", path)) {
+			return errors.New("support bundle cancelled")
+		}
+	}
+
+	b.manifest.Archive = path
+	if err := b.write(path, strings.TrimSuffix(filepath.Base(path), ".tar.gz")); err != nil {
+		return err
+	}
+
+	if formatter.IsStructured() {
+		return formatter.PrintData(b.manifest)
+	}
+	if err := formatter.PrintText("%s Wrote support bundle %s (%d files)\n", formatter.Success("✓"), path, len(b.manifest.Files)+1); err != nil {
+		return err
+	}
+	return formatter.Println("Attach it to your Globus support ticket.")
+}
+
+// add stores a file of the bundle.
+func (b *bundle) add(name string, data []byte, redactions []string) {
+	b.data[name] = data
+	b.manifest.Files = append(b.manifest.Files, BundleFile{Name: name, Size: len(data), Redactions: redactions})
+}
+
+// addJSON stores v as an indented JSON file, redacted by redactDocument in
+// addition to redactions.
+func (b *bundle) addJSON(name string, v interface{}, redactions []string) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		b.fail("encode %s: %v", name, err)
+		return
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		b.fail("encode %s: %v", name, err)
+		return
+	}
+	redactions = append(redactions, redactDocument(doc)...)
+	data, err = json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		b.fail("encode %s: %v", name, err)
+		return
+	}
+	b.add(name, append(data, '\n'), redactions)
+}
+
+// fail records information that could not be collected.
+func (b *bundle) fail(format string, args ...interface{}) {
+	b.manifest.Errors = append(b.manifest.Errors, fmt.Sprintf(format, args...))
+}
+
+// collectConfig adds config.yaml and the effective configuration.
+func (b *bundle) collectConfig() {
+	if path, err := config.GetConfigFilePath(); err != nil {
+		b.fail("locate config file: %v", err)
+	} else if data, err := os.ReadFile(path); err == nil { //nolint:gosec // Path is in the config directory
+		var doc map[string]interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			// The raw file may hold secrets, so it is not included
+			b.fail("parse config file: %v", err)
+		} else {
+			redactions := redactDocument(doc)
+			out, err := yaml.Marshal(doc)
+			if err != nil {
+				b.fail("encode config file: %v", err)
+			} else {
+				b.add("config.yaml", out, redactions)
+			}
+		}
+	} else if !os.IsNotExist(err) {
+		b.fail("read config file: %v", err)
+	}
+
+	eff := cli.Effective()
+	if eff == nil {
+		return
+	}
+	copied := *eff
+	var redactions []string
+	copied.Settings = append([]config.Setting(nil), eff.Settings...)
+	for i, s := range copied.Settings {
+		if isSecretKey(s.Key) && s.Value != "" {
+			copied.Settings[i].Value = redacted
+			redactions = append(redactions, "settings."+s.Key)
+		}
+	}
+	b.addJSON("effective.json", copied, redactions)
+}
+
+// tokenStatus describes the stored session of a profile without its
+// tokens.
+type tokenStatus struct {
+	Profile        string    `json:"profile"`
+	LoggedIn       bool      `json:"logged_in"`
+	Valid          bool      `json:"valid"`
+	ExpiresAt      time.Time `json:"expires_at,omitempty"`
+	Scopes         []string  `json:"scopes,omitempty"`
+	ResourceServer string    `json:"resource_server,omitempty"`
+	Refreshable    bool      `json:"refreshable"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// collectToken adds the status of the profile's session.
+func (b *bundle) collectToken(profile string, token *auth.TokenInfo, err error) {
+	status := tokenStatus{Profile: profile}
+	if err != nil {
+		status.Error = err.Error()
+	} else {
+		status.LoggedIn = true
+		status.Valid = token.IsValid()
+		status.ExpiresAt = token.ExpiresAt
+		status.Scopes = token.Scopes
+		status.ResourceServer = token.ResourceServer
+		status.Refreshable = token.RefreshToken != ""
+	}
+	b.addJSON("token.json", status, nil)
+}
+
+// collectEndpoint adds the endpoint's service information and, with a
+// valid token, its configuration.
+func (b *bundle) collectEndpoint(ctx context.Context, endpointFQDN string, token *auth.TokenInfo) {
+	accessToken := ""
+	if token != nil && token.IsValid() {
+		accessToken = token.AccessToken
+	}
+	client, err := cli.NewGCSClient(endpointFQDN, accessToken)
+	if err != nil {
+		b.fail("create GCS client: %v", err)
+		return
+	}
+
+	doc := map[string]interface{}{}
+	if info, err := client.GetInfo(ctx); err != nil {
+		b.fail("%v", err)
+	} else {
+		doc["info"] = info
+	}
+	if accessToken == "" {
+		b.fail("get endpoint: skipped, no valid token for profile %s", b.manifest.Profile)
+	} else if endpoint, err := client.GetEndpoint(ctx); err != nil {
+		b.fail("%v", err)
+	} else {
+		doc["endpoint"] = endpoint
+	}
+	b.addJSON("endpoint.json", doc, nil)
+}
+
+// collectHistory adds the last limit entries of the activity log.
+func (b *bundle) collectHistory(limit int) {
+	path, err := history.GetFilePath()
+	if err != nil {
+		b.fail("locate activity log: %v", err)
+		return
+	}
+	entries, err := history.Load(path)
+	if err != nil {
+		b.fail("%v", err)
+		return
+	}
+	if len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	if entries == nil {
+		entries = []history.Entry{}
+	}
+	b.addJSON("history.json", entries, nil)
+}
+
+// scrub replaces the named secrets wherever they appear in the bundle.
+func (b *bundle) scrub(secrets map[string]string) {
+	names := make([]string, 0, len(secrets))
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i, file := range b.manifest.Files {
+		for _, name := range names {
+			data, n := scrub(b.data[file.Name], []string{secrets[name]})
+			if n == 0 {
+				continue
+			}
+			b.data[file.Name] = data
+			b.manifest.Files[i].Size = len(data)
+			b.manifest.Files[i].Redactions = append(b.manifest.Files[i].Redactions, fmt.Sprintf("%s (%d occurrences)", name, n))
+		}
+	}
+}
+
+// printReview lists the files of the bundle and what was redacted in
+// each, followed by their contents if full is set.
+func (b *bundle) printReview(w io.Writer, full bool) error {
+	var sb strings.Builder
+	sb.WriteString("The support bundle contains:\n")
+	for _, file := range b.manifest.Files {
+		fmt.Fprintf(&sb, "  %-16s %s\n", file.Name, cli.Bytes(int64(file.Size)))
+		for _, r := range file.Redactions {
+			fmt.Fprintf(&sb, "      redacted: %s\n", r)
+		}
+	}
+	if len(b.manifest.Errors) > 0 {
+		sb.WriteString("Not collected:\n")
+		for _, e := range b.manifest.Errors {
+			fmt.Fprintf(&sb, "  %s\n", e)
+		}
+	}
+	if full {
+		for _, file := range b.manifest.Files {
+			fmt.Fprintf(&sb, "\n==> %s <==\n%s", file.Name, b.data[file.Name])
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// write writes the bundle to path as a gzipped tar archive, with the
+// files in the directory dir. The archive is readable by its owner only.
+func (b *bundle) write(path, dir string) error {
+	manifest, err := json.MarshalIndent(b.manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode manifest: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600) //nolint:gosec // User-specified archive path
+	if err != nil {
+		return fmt.Errorf("create %s: %w", path, err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	files := append([]BundleFile{{Name: "manifest.json"}}, b.manifest.Files...)
+	for _, file := range files {
+		data := b.data[file.Name]
+		if file.Name == "manifest.json" {
+			data = append(manifest, '\n')
+		}
+		header := &tar.Header{
+			Name:    dir + "/" + file.Name,
+			Mode:    0600,
+			Size:    int64(len(data)),
+			ModTime: b.manifest.GeneratedAt,
+		}
+		if err = tw.WriteHeader(header); err == nil {
+			_, err = tw.Write(data)
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = tw.Close()
+	}
+	if err == nil {
+		err = gz.Close()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
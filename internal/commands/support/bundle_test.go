@@ -0,0 +1,249 @@
+package support
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/history"
+)
+
+func TestNewBundleCmd(t *testing.T) {
+	cmd := NewBundleCmd()
+
+	if cmd.Use != "bundle" {
+		t.Errorf("Use = %q, want %q", cmd.Use, "bundle")
+	}
+
+	for _, name := range []string{"profile", "endpoint", "output", "history", "review", "yes"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("flag %q not defined", name)
+		}
+	}
+}
+
+func TestIsSecretKey(t *testing.T) {
+	tests := map[string]bool{
+		"client_secret":    true,
+		"api_token":        true,
+		"Password":         true,
+		"private_key":      true,
+		"token_encryption": false,
+		"endpoint":         false,
+		"ticket":           false,
+	}
+	for key, want := range tests {
+		if got := isSecretKey(key); got != want {
+			t.Errorf("isSecretKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestRedactDocument(t *testing.T) {
+	doc := map[string]interface{}{
+		"endpoint": "abc.data.globus.org",
+		"hooks":    map[string]interface{}{"pre-delete": "curl -H 'Authorization: x' ..."},
+		"annotations": map[string]interface{}{
+			"ticket":    "CHG1",
+			"api_token": "s3cr3t",
+		},
+		"nodes": []interface{}{map[string]interface{}{"secret": "x"}},
+	}
+
+	paths := redactDocument(doc)
+
+	want := []string{"hooks.pre-delete", "annotations.api_token", "nodes[0].secret"}
+	if !slices.Equal(paths, want) {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+	if doc["hooks"].(map[string]interface{})["pre-delete"] != redacted {
+		t.Error("hook command not redacted")
+	}
+	if annotations := doc["annotations"].(map[string]interface{}); annotations["ticket"] != "CHG1" {
+		t.Errorf("ticket = %v, want it kept", annotations["ticket"])
+	}
+	if doc["endpoint"] != "abc.data.globus.org" {
+		t.Errorf("endpoint = %v, want it kept", doc["endpoint"])
+	}
+}
+
+func TestScrub(t *testing.T) {
+	data, n := scrub([]byte("Bearer AgTokenValue123 failed; retry AgTokenValue123"), []string{"AgTokenValue123", "", "short"})
+	if n != 2 {
+		t.Errorf("n = %d, want 2", n)
+	}
+	if strings.Contains(string(data), "AgTokenValue123") {
+		t.Errorf("data = %q, token not scrubbed", data)
+	}
+}
+
+// setupBundleHome creates a configuration directory with a config file
+// and activity log.
+func setupBundleHome(t *testing.T) string {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	t.Setenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR", dir)
+
+	config := "endpoint: abc.data.globus.org\n" +
+		"hooks:\n  pre-delete: /usr/local/bin/check --password hunter2\n" +
+		"annotations:\n  ticket: CHG1\n  api_token: s3cr3t\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := history.GetFilePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		entry := &history.Entry{Time: time.Now(), Command: "collection list", Result: history.ResultSuccess}
+		if err := history.Append(path, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// readArchive returns the files of a bundle archive by name.
+func readArchive(t *testing.T, path string) map[string]string {
+	t.Helper()
+	f, err := os.Open(path) //nolint:gosec // Test file
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	files := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[header.Name] = string(data)
+	}
+	return files
+}
+
+func TestRunBundle(t *testing.T) {
+	setupBundleHome(t)
+	archive := filepath.Join(t.TempDir(), "support.tar.gz")
+
+	var out, review bytes.Buffer
+	opts := bundleOptions{outputFile: archive, historyLimit: 2, yes: true, version: "1.2.3"}
+	if err := runBundle(context.Background(), "nonexistent-profile-test", "text", "", opts, &out, &review); err != nil {
+		t.Fatalf("runBundle() error = %v", err)
+	}
+
+	info, err := os.Stat(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("archive mode = %v, want 0600", mode)
+	}
+
+	files := readArchive(t, archive)
+	for _, name := range []string{"manifest.json", "version.json", "config.yaml", "token.json", "history.json"} {
+		if _, ok := files["support/"+name]; !ok {
+			t.Errorf("archive lacks %s; has %v", name, files)
+		}
+	}
+	for name, data := range files {
+		for _, secret := range []string{"hunter2", "s3cr3t"} {
+			if strings.Contains(data, secret) {
+				t.Errorf("%s contains %q", name, secret)
+			}
+		}
+	}
+	if !strings.Contains(files["support/config.yaml"], "CHG1") {
+		t.Errorf("config.yaml lost non-secret annotation:\n%s", files["support/config.yaml"])
+	}
+
+	var entries []history.Entry
+	if err := json.Unmarshal([]byte(files["support/history.json"]), &entries); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("history entries = %d, want 2", len(entries))
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal([]byte(files["support/manifest.json"]), &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if manifest.CLIVersion != "1.2.3" {
+		t.Errorf("CLIVersion = %q, want 1.2.3", manifest.CLIVersion)
+	}
+
+	if !strings.Contains(review.String(), "redacted: hooks.pre-delete") {
+		t.Errorf("review does not list redactions:\n%s", review.String())
+	}
+	if !strings.Contains(out.String(), "Wrote support bundle") {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+func TestRunBundleConfirmation(t *testing.T) {
+	setupBundleHome(t)
+
+	oldInteractive, oldConfirm := interactive, confirm
+	t.Cleanup(func() { interactive, confirm = oldInteractive, oldConfirm })
+
+	tests := []struct {
+		name        string
+		interactive bool
+		answer      bool
+		wantErr     string
+	}{
+		{name: "not interactive", interactive: false, wantErr: "--yes"},
+		{name: "declined", interactive: true, answer: false, wantErr: "cancelled"},
+		{name: "confirmed", interactive: true, answer: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interactive = func() bool { return tt.interactive }
+			confirm = func(string) bool { return tt.answer }
+			archive := filepath.Join(t.TempDir(), "support.tar.gz")
+
+			var out, review bytes.Buffer
+			opts := bundleOptions{outputFile: archive, historyLimit: 1, review: true}
+			err := runBundle(context.Background(), "nonexistent-profile-test", "text", "", opts, &out, &review)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("runBundle() error = %v, want containing %q", err, tt.wantErr)
+				}
+				if _, err := os.Stat(archive); !os.IsNotExist(err) {
+					t.Error("archive written without confirmation")
+				}
+			} else if err != nil {
+				t.Fatalf("runBundle() error = %v", err)
+			}
+			if !strings.Contains(review.String(), "==> config.yaml <==") {
+				t.Errorf("--review did not print contents:\n%s", review.String())
+			}
+		})
+	}
+}
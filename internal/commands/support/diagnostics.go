@@ -0,0 +1,109 @@
+package support
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"strings"
+	"time"
+)
+
+// dialTimeout bounds each network step of the connection diagnostics.
+const dialTimeout = 10 * time.Second
+
+// Connection records how a connection to the endpoint was established.
+type Connection struct {
+	Host      string   `json:"host"`
+	Addresses []string `json:"addresses,omitempty"`
+	DNSError  string   `json:"dns_error,omitempty"`
+	DNSMS     int64    `json:"dns_ms"`
+
+	Address    string `json:"address,omitempty"`
+	ConnectMS  int64  `json:"connect_ms"`
+	ConnectErr string `json:"connect_error,omitempty"`
+
+	TLSVersion    string    `json:"tls_version,omitempty"`
+	CipherSuite   string    `json:"cipher_suite,omitempty"`
+	HandshakeMS   int64     `json:"handshake_ms"`
+	HandshakeErr  string    `json:"handshake_error,omitempty"`
+	CertSubject   string    `json:"cert_subject,omitempty"`
+	CertIssuer    string    `json:"cert_issuer,omitempty"`
+	CertDNSNames  []string  `json:"cert_dns_names,omitempty"`
+	CertNotAfter  time.Time `json:"cert_not_after,omitempty"`
+	CertVerifyErr string    `json:"cert_verify_error,omitempty"`
+}
+
+// diagnoseConnection resolves the endpoint host and opens a TLS
+// connection to it, recording each step. The certificate chain is
+// verified separately so that its details are reported even when it is
+// not trusted. It is a test hook.
+var diagnoseConnection = func(ctx context.Context, endpointFQDN string) *Connection {
+	host, port := endpointFQDN, "443"
+	if h, p, err := net.SplitHostPort(endpointFQDN); err == nil {
+		host, port = h, p
+	}
+	c := &Connection{Host: host}
+
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+
+	start := time.Now()
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	c.DNSMS = time.Since(start).Milliseconds()
+	if err != nil {
+		c.DNSError = err.Error()
+		return c
+	}
+	c.Addresses = addrs
+
+	c.Address = net.JoinHostPort(host, port)
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	start = time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", c.Address)
+	c.ConnectMS = time.Since(start).Milliseconds()
+	if err != nil {
+		c.ConnectErr = err.Error()
+		return c
+	}
+	defer func() { _ = conn.Close() }()
+
+	// Verification is done below, to report an untrusted certificate
+	tlsConn := tls.Client(conn, &tls.Config{ServerName: host, InsecureSkipVerify: true}) //nolint:gosec // Diagnostics only; the chain is verified below
+	start = time.Now()
+	err = tlsConn.HandshakeContext(ctx)
+	c.HandshakeMS = time.Since(start).Milliseconds()
+	if err != nil {
+		c.HandshakeErr = err.Error()
+		return c
+	}
+
+	state := tlsConn.ConnectionState()
+	c.TLSVersion = tls.VersionName(state.Version)
+	c.CipherSuite = tls.CipherSuiteName(state.CipherSuite)
+	if len(state.PeerCertificates) > 0 {
+		leaf := state.PeerCertificates[0]
+		c.CertSubject = leaf.Subject.String()
+		c.CertIssuer = leaf.Issuer.String()
+		c.CertDNSNames = leaf.DNSNames
+		c.CertNotAfter = leaf.NotAfter
+		if err := verifyChain(host, state.PeerCertificates); err != nil {
+			c.CertVerifyErr = err.Error()
+		}
+	}
+	return c
+}
+
+// verifyChain verifies the certificates presented by host against the
+// system roots.
+func verifyChain(host string, certs []*x509.Certificate) error {
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		DNSName:       strings.TrimSuffix(host, "."),
+		Intermediates: intermediates,
+	})
+	return err
+}
@@ -0,0 +1,102 @@
+package support
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// redacted replaces secret values in the bundle.
+const redacted = "REDACTED"
+
+// secretWords mark configuration keys whose values are redacted.
+var secretWords = []string{"secret", "password", "passphrase", "token", "credential", "private_key", "api_key"}
+
+// publicKeys are keys that match secretWords but hold no secret.
+var publicKeys = map[string]bool{"token_encryption": true, "secret_store": true}
+
+// isSecretKey reports whether a configuration key names a secret.
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	if publicKeys[key] {
+		return false
+	}
+	for _, word := range secretWords {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactTree replaces the values of secret keys in a decoded YAML or JSON
+// document and returns the dotted paths of the values it replaced.
+func redactTree(doc interface{}, path string) []string {
+	var paths []string
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child := key
+			if path != "" {
+				child = path + "." + key
+			}
+			if isSecretKey(key) && v[key] != nil {
+				v[key] = redacted
+				paths = append(paths, child)
+				continue
+			}
+			paths = append(paths, redactTree(v[key], child)...)
+		}
+	case []interface{}:
+		for i, item := range v {
+			paths = append(paths, redactTree(item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return paths
+}
+
+// redactDocument redacts a decoded configuration document: the values of
+// secret keys, and the commands of its hooks, which may embed credentials.
+// It returns the paths of the values it replaced.
+func redactDocument(doc interface{}) []string {
+	var paths []string
+	if m, ok := doc.(map[string]interface{}); ok {
+		if hooks, ok := m["hooks"].(map[string]interface{}); ok {
+			names := make([]string, 0, len(hooks))
+			for name := range hooks {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				hooks[name] = redacted
+				paths = append(paths, "hooks."+name)
+			}
+		}
+	}
+	return append(paths, redactTree(doc, "")...)
+}
+
+// scrub replaces every occurrence of secrets in data, as a last line of
+// defense against a token that found its way into an error message or log
+// entry. It returns the number of replacements.
+func scrub(data []byte, secrets []string) ([]byte, int) {
+	count := 0
+	for _, secret := range secrets {
+		if len(secret) < 8 {
+			continue
+		}
+		n := bytes.Count(data, []byte(secret))
+		if n == 0 {
+			continue
+		}
+		count += n
+		data = bytes.ReplaceAll(data, []byte(secret), []byte(redacted))
+	}
+	return data, count
+}
@@ -0,0 +1,22 @@
+package support
+
+import "github.com/spf13/cobra"
+
+// NewSupportCmd creates the support command with subcommands.
+func NewSupportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "support",
+		Short: "Collect information for Globus support",
+		Long: `Commands that help when reporting a problem to Globus support.
+
+'support bundle' gathers the CLI version, configuration, endpoint details,
+connection diagnostics, and recent activity into one archive to attach to
+a support ticket. Secrets are redacted, and the contents are shown for
+review before the archive is written.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(NewBundleCmd())
+
+	return cmd
+}
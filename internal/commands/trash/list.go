@@ -0,0 +1,69 @@
+package trash
+
+import (
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/trash"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// NewListCmd creates the trash list command.
+func NewListCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List deleted resources that can be restored",
+		Long: `List the roles and policies in the local trash, most recently deleted
+first. Items older than 30 days are purged.
+
+Example:
+  globus-connect-server trash list`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			dir, err := trash.GetDir()
+			if err != nil {
+				return err
+			}
+			return runList(format, dir, time.Now(), cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+
+	return cmd
+}
+
+// runList executes the trash list command.
+func runList(formatStr, dir string, now time.Time, out interface{ Write([]byte) (int, error) }) error {
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	items, err := trash.List(dir, now)
+	if err != nil {
+		return err
+	}
+
+	if formatter.IsStructured() {
+		if items == nil {
+			items = []trash.Item{}
+		}
+		return formatter.PrintData(items)
+	}
+
+	if len(items) == 0 {
+		return formatter.Println("The trash is empty.")
+	}
+
+	if err := formatter.PrintText("%-27s %-15s %-38s %-17s %s\n", "TRASH ID", "KIND", "RESOURCE ID", "DELETED", "ENDPOINT"); err != nil {
+		return err
+	}
+	for _, item := range items {
+		deleted := item.DeletedAt.Local().Format("2006-01-02 15:04")
+		if err := formatter.PrintText("%-27s %-15s %-38s %-17s %s\n", item.ID, item.Kind, item.ResourceID, deleted, item.Endpoint); err != nil {
+			return err
+		}
+	}
+
+	return formatter.PrintText("\n%d item(s). Restore one with 'trash restore TRASH_ID'.\n", len(items))
+}
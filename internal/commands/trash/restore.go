@@ -0,0 +1,159 @@
+package trash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+	"github.com/scttfrdmn/globus-go-gcs/internal/trash"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// restoreResult is the outcome of 'trash restore'.
+type restoreResult struct {
+	TrashID    string `json:"trash_id"`
+	Kind       string `json:"kind"`
+	Endpoint   string `json:"endpoint"`
+	OldID      string `json:"old_id"`
+	RestoredID string `json:"restored_id"`
+}
+
+// NewRestoreCmd creates the trash restore command.
+func NewRestoreCmd() *cobra.Command {
+	var (
+		profile    string
+		format     string
+		toEndpoint string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restore TRASH_ID",
+		Short: "Re-create a deleted role or policy",
+		Long: `Re-create a deleted role, sharing policy, or auth policy from the local
+trash and remove it from the trash.
+
+The resource is re-created on the endpoint it was deleted from, or on
+--to-endpoint. The API assigns it a new ID.
+
+Example:
+  globus-connect-server trash list
+  globus-connect-server trash restore 20250114T093000-1a2b3c4d
+
+Requires an active authentication session (use 'login' first).`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := trash.GetDir()
+			if err != nil {
+				return err
+			}
+			return runRestore(cmd.Context(), profile, format, dir, args[0], toEndpoint, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
+	cmd.Flags().StringVar(&toEndpoint, "to-endpoint", "", "Restore to this endpoint FQDN instead of the original one")
+
+	return cmd
+}
+
+// runRestore executes the trash restore command.
+func runRestore(ctx context.Context, profile, formatStr, dir, trashID, toEndpoint string, out interface{ Write([]byte) (int, error) }) error {
+	item, err := trash.Get(dir, trashID)
+	if err != nil {
+		return err
+	}
+	endpointFQDN := item.Endpoint
+	if toEndpoint != "" {
+		endpointFQDN = toEndpoint
+	}
+
+	// Load token
+	token, err := cli.LoadToken(profile)
+	if err != nil {
+		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
+	}
+
+	// Check if token is valid
+	if !token.IsValid() {
+		return fmt.Errorf("token expired, please login again")
+	}
+
+	// Create output formatter
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
+
+	// Create GCS client
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
+	if err != nil {
+		return fmt.Errorf("create GCS client: %w", err)
+	}
+
+	restoredID, err := restoreItem(ctx, gcsClient, item)
+	if err != nil {
+		return fmt.Errorf("restore %s: %w", describe(item), err)
+	}
+	if err := trash.Remove(dir, item.ID); err != nil {
+		return err
+	}
+
+	result := restoreResult{
+		TrashID:    item.ID,
+		Kind:       item.Kind,
+		Endpoint:   endpointFQDN,
+		OldID:      item.ResourceID,
+		RestoredID: restoredID,
+	}
+	if formatter.IsStructured() {
+		return formatter.PrintData(result)
+	}
+	return formatter.PrintText("Restored %s on %s as %s.\n", describe(item), endpointFQDN, restoredID)
+}
+
+// restoreItem re-creates the resource in item and returns its new ID.
+func restoreItem(ctx context.Context, client gcs.GCSAPI, item *trash.Item) (string, error) {
+	switch item.Kind {
+	case trash.KindRole:
+		var role gcs.Role
+		if err := json.Unmarshal(item.Object, &role); err != nil {
+			return "", fmt.Errorf("parse role: %w", err)
+		}
+		role.ID = ""
+		created, err := client.CreateRole(ctx, &role)
+		if err != nil {
+			return "", err
+		}
+		return created.ID, nil
+	case trash.KindSharingPolicy:
+		var policy gcs.SharingPolicy
+		if err := json.Unmarshal(item.Object, &policy); err != nil {
+			return "", fmt.Errorf("parse sharing policy: %w", err)
+		}
+		policy.ID = ""
+		created, err := client.CreateSharingPolicy(ctx, &policy)
+		if err != nil {
+			return "", err
+		}
+		return created.ID, nil
+	case trash.KindAuthPolicy:
+		var policy gcs.AuthPolicy
+		if err := json.Unmarshal(item.Object, &policy); err != nil {
+			return "", fmt.Errorf("parse auth policy: %w", err)
+		}
+		policy.ID = ""
+		created, err := client.CreateAuthPolicy(ctx, &policy)
+		if err != nil {
+			return "", err
+		}
+		return created.ID, nil
+	}
+	return "", fmt.Errorf("unsupported kind %q", item.Kind)
+}
+
+// describe returns a short description of an item for messages.
+func describe(item *trash.Item) string {
+	return fmt.Sprintf("%s %s", item.Kind, item.ResourceID)
+}
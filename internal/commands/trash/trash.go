@@ -0,0 +1,24 @@
+// Package trash provides the commands for restoring deleted roles and
+// policies from the local trash.
+package trash
+
+import "github.com/spf13/cobra"
+
+// NewTrashCmd creates the trash command with subcommands.
+func NewTrashCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trash",
+		Short: "List and restore deleted roles and policies",
+		Long: `Commands for the local trash of deleted resources.
+
+Before a role, sharing policy, or auth policy is deleted, a copy is saved
+in ~/.globus-connect-server/trash. Deleted resources can be re-created
+with 'trash restore' for 30 days, after which they are purged.`,
+	}
+
+	// Add subcommands
+	cmd.AddCommand(NewListCmd())
+	cmd.AddCommand(NewRestoreCmd())
+
+	return cmd
+}
@@ -0,0 +1,105 @@
+package trash
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/trash"
+)
+
+func TestNewTrashCmd(t *testing.T) {
+	cmd := NewTrashCmd()
+
+	if cmd.Use != "trash" {
+		t.Errorf("NewTrashCmd() Use = %q, want %q", cmd.Use, "trash")
+	}
+
+	want := map[string]bool{"list": false, "restore": false}
+	for _, sub := range cmd.Commands() {
+		if _, ok := want[sub.Name()]; ok {
+			want[sub.Name()] = true
+		}
+	}
+	for name, found := range want {
+		if !found {
+			t.Errorf("subcommand %q not found", name)
+		}
+	}
+}
+
+func TestNewRestoreCmd_Flags(t *testing.T) {
+	cmd := NewRestoreCmd()
+
+	for _, name := range []string{"profile", "format", "to-endpoint"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("flag %q not found", name)
+		}
+	}
+	if err := cmd.Args(cmd, []string{}); err == nil {
+		t.Error("restore accepted no arguments")
+	}
+}
+
+func TestRunList(t *testing.T) {
+	dir := t.TempDir()
+
+	var buf bytes.Buffer
+	if err := runList("text", dir, time.Now(), &buf); err != nil {
+		t.Fatalf("runList() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "empty") {
+		t.Errorf("runList() on empty trash = %q", buf.String())
+	}
+
+	item, err := trash.Put(dir, trash.KindRole, "role-1", "gcs.example.org", map[string]string{"id": "role-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf.Reset()
+	if err := runList("text", dir, time.Now(), &buf); err != nil {
+		t.Fatalf("runList() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), item.ID) || !strings.Contains(buf.String(), "role-1") {
+		t.Errorf("runList() text output missing item:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := runList("json", dir, time.Now(), &buf); err != nil {
+		t.Fatalf("runList() error = %v", err)
+	}
+	var items []trash.Item
+	if err := json.Unmarshal(buf.Bytes(), &items); err != nil {
+		t.Fatalf("runList() JSON output: %v", err)
+	}
+	if len(items) != 1 || items[0].ID != item.ID {
+		t.Errorf("runList() JSON = %+v", items)
+	}
+}
+
+func TestRunRestore_NotFound(t *testing.T) {
+	err := runRestore(context.Background(), "nonexistent-profile-test", "text", t.TempDir(), "missing", "", &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("runRestore() error = %v, want not found", err)
+	}
+}
+
+func TestRunRestore_NoToken(t *testing.T) {
+	dir := t.TempDir()
+	item, err := trash.Put(dir, trash.KindRole, "role-1", "gcs.example.org", map[string]string{"id": "role-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = runRestore(context.Background(), "nonexistent-profile-test", "text", dir, item.ID, "", &bytes.Buffer{})
+	if err == nil {
+		t.Fatal("runRestore() with no token should fail")
+	}
+	if _, err := trash.Get(dir, item.ID); err != nil {
+		t.Errorf("item removed after failed restore: %v", err)
+	}
+}
@@ -7,9 +7,8 @@ import (
 	"os"
 	"strings"
 
-	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/config"
-	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/internal/cli"
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
 	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -54,7 +53,7 @@ Requires an active authentication session (use 'login' first).`,
 	}
 
 	cmd.Flags().StringVarP(&profile, "profile", "p", config.DefaultProfile, "Profile name")
-	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json)")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, yaml)")
 	cmd.Flags().StringVar(&endpointFQDN, "endpoint", "", "Endpoint FQDN (e.g., abc.def.data.globus.org)")
 	cmd.Flags().StringVar(&credentialID, "credential", "", "User credential ID")
 	cmd.Flags().StringVar(&accessKeyID, "access-key-id", "", "S3 access key ID to delete")
@@ -71,7 +70,7 @@ Requires an active authentication session (use 'login' first).`,
 func runS3KeysDelete(ctx context.Context, profile, formatStr, endpointFQDN, credentialID,
 	accessKeyID string, force bool, out interface{ Write([]byte) (int, error) }) error {
 	// Load token
-	token, err := auth.LoadToken(profile)
+	token, err := cli.LoadToken(profile)
 	if err != nil {
 		return fmt.Errorf("not logged in: %w (use 'login' command first)", err)
 	}
@@ -82,7 +81,7 @@ func runS3KeysDelete(ctx context.Context, profile, formatStr, endpointFQDN, cred
 	}
 
 	// Create output formatter
-	formatter := output.NewFormatter(output.Format(formatStr), out)
+	formatter := output.NewFormatter(output.Format(formatStr), out, cli.FormatterOptions()...)
 
 	// Confirmation prompt unless --force
 	if !force {
@@ -99,10 +98,7 @@ func runS3KeysDelete(ctx context.Context, profile, formatStr, endpointFQDN, cred
 	}
 
 	// Create GCS client
-	gcsClient, err := gcs.NewClient(
-		endpointFQDN,
-		gcs.WithAccessToken(token.AccessToken),
-	)
+	gcsClient, err := cli.NewGCSClient(endpointFQDN, token.AccessToken)
 	if err != nil {
 		return fmt.Errorf("create GCS client: %w", err)
 	}
@@ -113,8 +109,8 @@ func runS3KeysDelete(ctx context.Context, profile, formatStr, endpointFQDN, cred
 	}
 
 	// Output based on format
-	if formatter.IsJSON() {
-		return formatter.PrintJSON(map[string]string{"status": "deleted", "access_key_id": accessKeyID})
+	if formatter.IsStructured() {
+		return formatter.PrintData(map[string]string{"status": "deleted", "access_key_id": accessKeyID})
 	}
 
 	// Text format
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Globus environments. Production is the public Globus service; preview and
+// sandbox are used to test against upcoming Globus releases.
+const (
+	EnvironmentProduction = "production"
+	EnvironmentPreview    = "preview"
+	EnvironmentSandbox    = "sandbox"
+)
+
+// DefaultEnvironment is the Globus environment used when none is
+// configured.
+const DefaultEnvironment = EnvironmentProduction
+
+// Environment holds the Globus service URLs of one Globus environment.
+//
+// GCS Manager API URLs are not listed: they are derived from the endpoint
+// FQDN, which already belongs to a single environment.
+type Environment struct {
+	// Name is the environment name (e.g., "preview").
+	Name string `json:"name"`
+
+	// AuthURL is the Globus Auth API base URL, ending in "/".
+	AuthURL string `json:"auth_url"`
+
+	// TransferURL is the Globus Transfer API base URL, ending in "/".
+	TransferURL string `json:"transfer_url"`
+}
+
+// environments lists the known Globus environments.
+var environments = []Environment{
+	{
+		Name:        EnvironmentProduction,
+		AuthURL:     "https://auth.globus.org/v2/",
+		TransferURL: "https://transfer.api.globus.org/v0.10/",
+	},
+	{
+		Name:        EnvironmentPreview,
+		AuthURL:     "https://auth.preview.globus.org/v2/",
+		TransferURL: "https://transfer.api.preview.globus.org/v0.10/",
+	},
+	{
+		Name:        EnvironmentSandbox,
+		AuthURL:     "https://auth.sandbox.globuscs.info/v2/",
+		TransferURL: "https://transfer.api.sandbox.globuscs.info/v0.10/",
+	},
+}
+
+// LookupEnvironment returns the Globus environment with the given name.
+func LookupEnvironment(name string) (Environment, error) {
+	names := make([]string, len(environments))
+	for i, env := range environments {
+		if env.Name == name {
+			return env, nil
+		}
+		names[i] = env.Name
+	}
+	return Environment{}, fmt.Errorf("unknown Globus environment %q (valid: %s)", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,457 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// ConfigFileName is the name of the CLI configuration file inside the
+	// configuration directory.
+	ConfigFileName = "config.yaml"
+
+	// DefaultFormat is the output format used when none is configured.
+	DefaultFormat = "text"
+
+	// DefaultTimeout is the HTTP timeout used when none is configured.
+	DefaultTimeout = "30s"
+
+	// DefaultRateLimit does not limit the rate of GCS Manager API
+	// requests.
+	DefaultRateLimit = "0"
+
+	// DefaultOutputStyle is the text output style used when none is
+	// configured.
+	DefaultOutputStyle = "default"
+
+	// DefaultTokenEncryption encrypts token files with a key kept in the
+	// system keyring.
+	DefaultTokenEncryption = "keyring"
+
+	// DefaultSecretStore keeps the token encryption key in the system
+	// keyring.
+	DefaultSecretStore = "keyring"
+)
+
+// Environment variables that override configuration file values.
+const (
+	// EnvProfile selects the active profile.
+	EnvProfile = "GLOBUS_GCS_PROFILE"
+
+	// EnvEndpoint selects the endpoint FQDN.
+	EnvEndpoint = "GLOBUS_GCS_ENDPOINT"
+
+	// EnvFormat selects the output format.
+	EnvFormat = "GLOBUS_GCS_FORMAT"
+
+	// EnvTimeout sets the HTTP timeout (Go duration syntax, e.g. "45s").
+	EnvTimeout = "GLOBUS_GCS_TIMEOUT"
+
+	// EnvRateLimit limits GCS Manager API requests per second.
+	EnvRateLimit = "GLOBUS_GCS_RATE_LIMIT"
+
+	// EnvClientCert and EnvClientKey name the PEM files of a TLS client
+	// certificate and its key.
+	EnvClientCert = "GLOBUS_GCS_CLIENT_CERT"
+	EnvClientKey  = "GLOBUS_GCS_CLIENT_KEY"
+
+	// EnvProxy names the proxy for GCS Manager API requests, overriding
+	// HTTPS_PROXY.
+	EnvProxy = "GLOBUS_GCS_PROXY"
+
+	// EnvCABundle names a PEM file of additional CA certificates trusted
+	// for endpoint connections.
+	EnvCABundle = "GLOBUS_GCS_CA_BUNDLE"
+
+	// EnvUserAgentSuffix is appended to the User-Agent of GCS Manager API
+	// requests.
+	EnvUserAgentSuffix = "GLOBUS_GCS_USER_AGENT_SUFFIX"
+
+	// EnvOutputStyle selects the text output style ("default" or "plain").
+	EnvOutputStyle = "GLOBUS_GCS_OUTPUT_STYLE"
+
+	// EnvEnvironment selects the Globus environment. The name matches the
+	// variable read by the Globus Python SDK.
+	EnvEnvironment = "GLOBUS_SDK_ENVIRONMENT"
+
+	// EnvTokenEncryption selects how token files are encrypted ("keyring"
+	// or "passphrase").
+	EnvTokenEncryption = "GLOBUS_GCS_TOKEN_ENCRYPTION"
+
+	// EnvSecretStore selects where the token encryption key is kept
+	// ("keyring", "file", or "vault").
+	EnvSecretStore = "GLOBUS_GCS_SECRET_STORE"
+)
+
+// FileConfig represents the contents of config.yaml.
+//
+// Top-level values apply to every profile. Values in a profile section
+// take precedence over the top-level values when that profile is active:
+//
+//	profile: production
+//	format: text
+//	timeout: 30s
+//	output_style: plain
+//	profiles:
+//	  production:
+//	    endpoint: abc.def.data.globus.org
+//	  testing:
+//	    endpoint: test.def.data.globus.org
+//	    format: json
+//	  preview:
+//	    environment: preview
+//	    endpoint: abc.def.data.preview.globus.org
+//	hooks:
+//	  pre-delete: /usr/local/bin/change-ticket-check
+//	annotations:
+//	  site: research-computing
+type FileConfig struct {
+	// Profile is the profile used when --profile is not given.
+	Profile string `yaml:"profile,omitempty"`
+
+	// Endpoint is the default endpoint FQDN.
+	Endpoint string `yaml:"endpoint,omitempty"`
+
+	// Format is the default output format.
+	Format string `yaml:"format,omitempty"`
+
+	// Timeout is the default HTTP timeout (Go duration syntax).
+	Timeout string `yaml:"timeout,omitempty"`
+
+	// RateLimit caps GCS Manager API requests per second (e.g. "5" or
+	// "0.5"); 0 means no limit.
+	RateLimit string `yaml:"rate_limit,omitempty"`
+
+	// ClientCert and ClientKey are the PEM files of a TLS client
+	// certificate presented to endpoints behind proxies that require one.
+	// ClientKey defaults to ClientCert.
+	ClientCert string `yaml:"client_cert,omitempty"`
+	ClientKey  string `yaml:"client_key,omitempty"`
+
+	// Proxy is the URL of an HTTP or SOCKS5 proxy for GCS Manager API
+	// requests (e.g. "http://proxy.example.org:3128"). Without it,
+	// HTTPS_PROXY applies.
+	Proxy string `yaml:"proxy,omitempty"`
+
+	// CABundle is a PEM file of CA certificates trusted in addition to
+	// the system's, for endpoints with certificates from an internal CA.
+	CABundle string `yaml:"ca_bundle,omitempty"`
+
+	// UserAgentSuffix is appended to the User-Agent of GCS Manager API
+	// requests, so that endpoint logs can tell automation apart (e.g.
+	// "nightly-sync/2").
+	UserAgentSuffix string `yaml:"user_agent_suffix,omitempty"`
+
+	// Environment is the default Globus environment (production,
+	// preview, or sandbox).
+	Environment string `yaml:"environment,omitempty"`
+
+	// OutputStyle is the text output style ("default" or "plain"). It
+	// applies to every profile.
+	OutputStyle string `yaml:"output_style,omitempty"`
+
+	// TokenEncryption selects how token files are encrypted: "keyring"
+	// (the default) or "passphrase" for hosts without a system keyring.
+	// It applies to every profile.
+	TokenEncryption string `yaml:"token_encryption,omitempty"`
+
+	// SecretStore selects where the token encryption key is kept:
+	// "keyring" (the default), "file" for a file encrypted with the key in
+	// GLOBUS_GCS_SECRET_KEY, or "vault" for HashiCorp Vault. It applies to
+	// every profile.
+	SecretStore string `yaml:"secret_store,omitempty"`
+
+	// Profiles holds per-profile settings keyed by profile name.
+	Profiles map[string]ProfileConfig `yaml:"profiles,omitempty"`
+
+	// Hooks maps hook names (e.g., "pre-delete") to the program run for
+	// them. Hooks apply to every profile.
+	Hooks map[string]string `yaml:"hooks,omitempty"`
+
+	// Annotations are sent as request headers on every GCS request and
+	// recorded in the activity log (see the --annotate flag).
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+// ProfileConfig holds settings that apply to a single profile.
+type ProfileConfig struct {
+	// Endpoint is the endpoint FQDN used with this profile.
+	Endpoint string `yaml:"endpoint,omitempty"`
+
+	// Format is the output format used with this profile.
+	Format string `yaml:"format,omitempty"`
+
+	// Timeout is the HTTP timeout used with this profile.
+	Timeout string `yaml:"timeout,omitempty"`
+
+	// RateLimit caps GCS Manager API requests per second with this
+	// profile.
+	RateLimit string `yaml:"rate_limit,omitempty"`
+
+	// ClientCert and ClientKey are the TLS client certificate files used
+	// with this profile.
+	ClientCert string `yaml:"client_cert,omitempty"`
+	ClientKey  string `yaml:"client_key,omitempty"`
+
+	// Proxy is the proxy used with this profile.
+	Proxy string `yaml:"proxy,omitempty"`
+
+	// CABundle is the CA bundle used with this profile.
+	CABundle string `yaml:"ca_bundle,omitempty"`
+
+	// UserAgentSuffix is the User-Agent suffix used with this profile.
+	UserAgentSuffix string `yaml:"user_agent_suffix,omitempty"`
+
+	// Environment is the Globus environment the profile logs in to.
+	Environment string `yaml:"environment,omitempty"`
+}
+
+// GetConfigFilePath returns the path to config.yaml.
+func GetConfigFilePath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, ConfigFileName), nil
+}
+
+// LoadFileConfig loads config.yaml from the configuration directory.
+//
+// A missing file is not an error; an empty FileConfig is returned instead.
+func LoadFileConfig() (*FileConfig, error) {
+	path, err := GetConfigFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadFileConfigFrom(path)
+}
+
+// LoadFileConfigFrom loads a configuration file from the given path.
+//
+// A missing file is not an error; an empty FileConfig is returned instead.
+func LoadFileConfigFrom(path string) (*FileConfig, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Intentional file read from config directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &FileConfig{}, nil
+		}
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	var cfg FileConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
+
+// Source identifies where an effective configuration value came from.
+type Source string
+
+const (
+	// SourceFlag means the value was given on the command line.
+	SourceFlag Source = "flag"
+
+	// SourceEnv means the value came from an environment variable.
+	SourceEnv Source = "env"
+
+	// SourceConfig means the value came from config.yaml.
+	SourceConfig Source = "config"
+
+	// SourceDefault means no override was found and the built-in default applies.
+	SourceDefault Source = "default"
+)
+
+// Setting keys used in Effective.
+const (
+	KeyProfile         = "profile"
+	KeyEndpoint        = "endpoint"
+	KeyFormat          = "format"
+	KeyTimeout         = "timeout"
+	KeyRateLimit       = "rate_limit"
+	KeyClientCert      = "client_cert"
+	KeyClientKey       = "client_key"
+	KeyProxy           = "proxy"
+	KeyCABundle        = "ca_bundle"
+	KeyUserAgentSuffix = "user_agent_suffix"
+	KeyOutputStyle     = "output_style"
+	KeyEnvironment     = "environment"
+	KeyTokenEncryption = "token_encryption"
+	KeySecretStore     = "secret_store"
+	KeyClientID        = "client_id"
+	KeyConfigDir       = "config_dir"
+)
+
+// Setting is a single resolved configuration value and its origin.
+type Setting struct {
+	// Key is the setting name (e.g., "endpoint").
+	Key string `json:"key"`
+
+	// Value is the effective value.
+	Value string `json:"value"`
+
+	// Source is where the value came from.
+	Source Source `json:"source"`
+
+	// Origin names the specific flag, environment variable, or config
+	// file key that supplied the value.
+	Origin string `json:"origin,omitempty"`
+}
+
+// Effective is the fully resolved configuration for one CLI invocation.
+type Effective struct {
+	// ConfigFile is the path of the configuration file that was consulted.
+	ConfigFile string `json:"config_file"`
+
+	// ConfigFileFound reports whether the configuration file exists.
+	ConfigFileFound bool `json:"config_file_found"`
+
+	// Settings holds the resolved values in display order.
+	Settings []Setting `json:"settings"`
+
+	// Hooks holds the command hooks from config.yaml.
+	Hooks map[string]string `json:"hooks,omitempty"`
+
+	// Annotations holds the request annotations from config.yaml and
+	// --annotate flags.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Lookup returns the setting with the given key.
+func (e *Effective) Lookup(key string) (Setting, bool) {
+	if e == nil {
+		return Setting{}, false
+	}
+	for _, s := range e.Settings {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return Setting{}, false
+}
+
+// Get returns the value of the setting with the given key, or "" if unset.
+func (e *Effective) Get(key string) string {
+	s, _ := e.Lookup(key)
+	return s.Value
+}
+
+// Resolve computes the effective configuration.
+//
+// Each value is taken from the first source that provides it:
+//  1. Command-line flags (flags maps flag name to the value explicitly set)
+//  2. Environment variables
+//  3. The active profile's section of config.yaml
+//  4. Top-level values in config.yaml
+//  5. Built-in defaults
+//
+// The profile is resolved first because it selects which profile section
+// of the configuration file applies to the remaining settings.
+func Resolve(flags map[string]string, file *FileConfig) *Effective {
+	if file == nil {
+		file = &FileConfig{}
+	}
+
+	eff := &Effective{Hooks: file.Hooks}
+	if len(file.Annotations) > 0 {
+		eff.Annotations = make(map[string]string, len(file.Annotations))
+		for key, value := range file.Annotations {
+			eff.Annotations[key] = value
+		}
+	}
+
+	profile := resolveOne(KeyProfile, flags, EnvProfile, DefaultProfile,
+		configValue{KeyProfile, file.Profile})
+	eff.Settings = append(eff.Settings, profile)
+
+	section := file.Profiles[profile.Value]
+	sectionKey := func(key string) string {
+		return fmt.Sprintf("profiles.%s.%s", profile.Value, key)
+	}
+
+	eff.Settings = append(eff.Settings,
+		resolveOne(KeyEndpoint, flags, EnvEndpoint, "",
+			configValue{sectionKey(KeyEndpoint), section.Endpoint},
+			configValue{KeyEndpoint, file.Endpoint}),
+		resolveOne(KeyFormat, flags, EnvFormat, DefaultFormat,
+			configValue{sectionKey(KeyFormat), section.Format},
+			configValue{KeyFormat, file.Format}),
+		resolveOne(KeyTimeout, flags, EnvTimeout, DefaultTimeout,
+			configValue{sectionKey(KeyTimeout), section.Timeout},
+			configValue{KeyTimeout, file.Timeout}),
+		resolveOne(KeyRateLimit, flags, EnvRateLimit, DefaultRateLimit,
+			configValue{sectionKey(KeyRateLimit), section.RateLimit},
+			configValue{KeyRateLimit, file.RateLimit}),
+		resolveOne(KeyClientCert, flags, EnvClientCert, "",
+			configValue{sectionKey(KeyClientCert), section.ClientCert},
+			configValue{KeyClientCert, file.ClientCert}),
+		resolveOne(KeyClientKey, flags, EnvClientKey, "",
+			configValue{sectionKey(KeyClientKey), section.ClientKey},
+			configValue{KeyClientKey, file.ClientKey}),
+		resolveOne(KeyProxy, flags, EnvProxy, "",
+			configValue{sectionKey(KeyProxy), section.Proxy},
+			configValue{KeyProxy, file.Proxy}),
+		resolveOne(KeyCABundle, flags, EnvCABundle, "",
+			configValue{sectionKey(KeyCABundle), section.CABundle},
+			configValue{KeyCABundle, file.CABundle}),
+		resolveOne(KeyUserAgentSuffix, flags, EnvUserAgentSuffix, "",
+			configValue{sectionKey(KeyUserAgentSuffix), section.UserAgentSuffix},
+			configValue{KeyUserAgentSuffix, file.UserAgentSuffix}),
+		resolveOne(KeyEnvironment, flags, EnvEnvironment, DefaultEnvironment,
+			configValue{sectionKey(KeyEnvironment), section.Environment},
+			configValue{KeyEnvironment, file.Environment}),
+		resolveOne(KeyOutputStyle, flags, EnvOutputStyle, DefaultOutputStyle,
+			configValue{KeyOutputStyle, file.OutputStyle}),
+		resolveOne(KeyTokenEncryption, flags, EnvTokenEncryption, DefaultTokenEncryption,
+			configValue{KeyTokenEncryption, file.TokenEncryption}),
+		resolveOne(KeySecretStore, flags, EnvSecretStore, DefaultSecretStore,
+			configValue{KeySecretStore, file.SecretStore}),
+		resolveOne(KeyClientID, flags, "GLOBUS_CLIENT_ID", DefaultClientID),
+	)
+
+	configDir := Setting{Key: KeyConfigDir, Source: SourceDefault}
+	if dir := os.Getenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR"); dir != "" {
+		configDir.Value = dir
+		configDir.Source = SourceEnv
+		configDir.Origin = "GLOBUS_CONNECT_SERVER_CONFIG_DIR"
+	} else if dir, err := GetConfigDir(); err == nil {
+		configDir.Value = dir
+	}
+	eff.Settings = append(eff.Settings, configDir)
+
+	return eff
+}
+
+// configValue is a candidate value from the configuration file.
+type configValue struct {
+	key   string
+	value string
+}
+
+// resolveOne resolves a single setting using the standard precedence.
+func resolveOne(key string, flags map[string]string, envVar, def string, fileValues ...configValue) Setting {
+	if v, ok := flags[key]; ok {
+		return Setting{Key: key, Value: v, Source: SourceFlag, Origin: "--" + strings.ReplaceAll(key, "_", "-")}
+	}
+
+	if envVar != "" {
+		if v := os.Getenv(envVar); v != "" {
+			return Setting{Key: key, Value: v, Source: SourceEnv, Origin: envVar}
+		}
+	}
+
+	for _, fv := range fileValues {
+		if fv.value != "" {
+			return Setting{Key: key, Value: fv.value, Source: SourceConfig, Origin: fv.key}
+		}
+	}
+
+	return Setting{Key: key, Value: def, Source: SourceDefault}
+}
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadFileConfigFrom_Missing(t *testing.T) {
+	cfg, err := LoadFileConfigFrom(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("LoadFileConfigFrom() error = %v", err)
+	}
+	if cfg == nil {
+		t.Fatal("LoadFileConfigFrom() returned nil config")
+	}
+	if cfg.Endpoint != "" || cfg.Profile != "" {
+		t.Errorf("LoadFileConfigFrom() = %+v, want empty config", cfg)
+	}
+}
+
+func TestLoadFileConfigFrom(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ConfigFileName)
+	data := []byte(`profile: production
+format: json
+profiles:
+  production:
+    endpoint: prod.example.org
+    timeout: 45s
+hooks:
+  pre-delete: /usr/local/bin/change-ticket-check
+`)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := LoadFileConfigFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFileConfigFrom() error = %v", err)
+	}
+
+	if cfg.Profile != "production" {
+		t.Errorf("Profile = %q, want %q", cfg.Profile, "production")
+	}
+	if cfg.Format != "json" {
+		t.Errorf("Format = %q, want %q", cfg.Format, "json")
+	}
+	if got := cfg.Profiles["production"].Endpoint; got != "prod.example.org" {
+		t.Errorf("Profiles[production].Endpoint = %q, want %q", got, "prod.example.org")
+	}
+	if got := cfg.Hooks["pre-delete"]; got != "/usr/local/bin/change-ticket-check" {
+		t.Errorf("Hooks[pre-delete] = %q, want %q", got, "/usr/local/bin/change-ticket-check")
+	}
+}
+
+func TestLoadFileConfigFrom_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ConfigFileName)
+	if err := os.WriteFile(path, []byte("profiles: [unclosed"), 0600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	if _, err := LoadFileConfigFrom(path); err == nil {
+		t.Error("LoadFileConfigFrom() expected error for invalid YAML, got nil")
+	}
+}
+
+func TestResolve_Precedence(t *testing.T) {
+	t.Setenv(EnvProfile, "")
+	t.Setenv(EnvEndpoint, "")
+	t.Setenv(EnvFormat, "")
+	t.Setenv(EnvTimeout, "")
+	t.Setenv(EnvEnvironment, "")
+	t.Setenv(EnvTokenEncryption, "")
+	t.Setenv(EnvRateLimit, "")
+
+	file := &FileConfig{
+		Profile:         "production",
+		Endpoint:        "top.example.org",
+		Format:          "json",
+		TokenEncryption: "passphrase",
+		Profiles: map[string]ProfileConfig{
+			"production": {Endpoint: "prod.example.org", RateLimit: "5"},
+			"testing":    {Endpoint: "test.example.org", Environment: EnvironmentPreview},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		flags      map[string]string
+		env        map[string]string
+		key        string
+		wantValue  string
+		wantSource Source
+		wantOrigin string
+	}{
+		{
+			name:       "profile from config",
+			key:        KeyProfile,
+			wantValue:  "production",
+			wantSource: SourceConfig,
+			wantOrigin: "profile",
+		},
+		{
+			name:       "endpoint from profile section",
+			key:        KeyEndpoint,
+			wantValue:  "prod.example.org",
+			wantSource: SourceConfig,
+			wantOrigin: "profiles.production.endpoint",
+		},
+		{
+			name:       "flag profile selects section",
+			flags:      map[string]string{KeyProfile: "testing"},
+			key:        KeyEndpoint,
+			wantValue:  "test.example.org",
+			wantSource: SourceConfig,
+			wantOrigin: "profiles.testing.endpoint",
+		},
+		{
+			name:       "top-level value when section is empty",
+			key:        KeyFormat,
+			wantValue:  "json",
+			wantSource: SourceConfig,
+			wantOrigin: "format",
+		},
+		{
+			name:       "environment overrides config",
+			env:        map[string]string{EnvEndpoint: "env.example.org"},
+			key:        KeyEndpoint,
+			wantValue:  "env.example.org",
+			wantSource: SourceEnv,
+			wantOrigin: EnvEndpoint,
+		},
+		{
+			name:       "flag overrides environment",
+			flags:      map[string]string{KeyEndpoint: "flag.example.org"},
+			env:        map[string]string{EnvEndpoint: "env.example.org"},
+			key:        KeyEndpoint,
+			wantValue:  "flag.example.org",
+			wantSource: SourceFlag,
+			wantOrigin: "--endpoint",
+		},
+		{
+			name:       "flag origin uses the flag name",
+			flags:      map[string]string{KeyOutputStyle: "plain"},
+			key:        KeyOutputStyle,
+			wantValue:  "plain",
+			wantSource: SourceFlag,
+			wantOrigin: "--output-style",
+		},
+		{
+			name:       "environment from profile section",
+			flags:      map[string]string{KeyProfile: "testing"},
+			key:        KeyEnvironment,
+			wantValue:  EnvironmentPreview,
+			wantSource: SourceConfig,
+			wantOrigin: "profiles.testing.environment",
+		},
+		{
+			name:       "default environment",
+			key:        KeyEnvironment,
+			wantValue:  EnvironmentProduction,
+			wantSource: SourceDefault,
+		},
+		{
+			name:       "token encryption from config",
+			key:        KeyTokenEncryption,
+			wantValue:  "passphrase",
+			wantSource: SourceConfig,
+			wantOrigin: "token_encryption",
+		},
+		{
+			name:       "rate limit from profile section",
+			key:        KeyRateLimit,
+			wantValue:  "5",
+			wantSource: SourceConfig,
+			wantOrigin: "profiles.production.rate_limit",
+		},
+		{
+			name:       "default when unset",
+			key:        KeyTimeout,
+			wantValue:  DefaultTimeout,
+			wantSource: SourceDefault,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			eff := Resolve(tt.flags, file)
+			got, ok := eff.Lookup(tt.key)
+			if !ok {
+				t.Fatalf("Lookup(%q) not found", tt.key)
+			}
+
+			if got.Value != tt.wantValue {
+				t.Errorf("%s value = %q, want %q", tt.key, got.Value, tt.wantValue)
+			}
+			if got.Source != tt.wantSource {
+				t.Errorf("%s source = %q, want %q", tt.key, got.Source, tt.wantSource)
+			}
+			if got.Origin != tt.wantOrigin {
+				t.Errorf("%s origin = %q, want %q", tt.key, got.Origin, tt.wantOrigin)
+			}
+		})
+	}
+}
+
+func TestResolve_NilFile(t *testing.T) {
+	t.Setenv(EnvProfile, "")
+	t.Setenv(EnvFormat, "")
+
+	eff := Resolve(nil, nil)
+	if got := eff.Get(KeyProfile); got != DefaultProfile {
+		t.Errorf("profile = %q, want %q", got, DefaultProfile)
+	}
+	if got := eff.Get(KeyFormat); got != DefaultFormat {
+		t.Errorf("format = %q, want %q", got, DefaultFormat)
+	}
+}
+
+func TestLookupEnvironment(t *testing.T) {
+	env, err := LookupEnvironment(EnvironmentPreview)
+	if err != nil {
+		t.Fatalf("LookupEnvironment() error = %v", err)
+	}
+	if env.AuthURL != "https://auth.preview.globus.org/v2/" {
+		t.Errorf("AuthURL = %q", env.AuthURL)
+	}
+
+	if _, err := LookupEnvironment("staging"); err == nil || !strings.Contains(err.Error(), "sandbox") {
+		t.Errorf("LookupEnvironment(staging) error = %v, want list of environments", err)
+	}
+}
@@ -0,0 +1,137 @@
+// Package history records an append-only log of CLI command executions.
+//
+// Each execution is appended as one JSON object per line (JSONL) to
+// ~/.globus-connect-server/history.jsonl with 0600 permissions. Entries
+// are never rewritten, so the file can be shipped to a central log store
+// for audits of administrative actions.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+)
+
+// FileName is the name of the history file inside the configuration
+// directory.
+const FileName = "history.jsonl"
+
+// Results recorded in Entry.Result.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Entry is one recorded command execution.
+type Entry struct {
+	// Time is when the command started.
+	Time time.Time `json:"time"`
+
+	// Command is the command path without the root name.
+	Command string `json:"command"`
+
+	// Args holds the positional arguments.
+	Args []string `json:"args,omitempty"`
+
+	// Flags holds the flags given on the command line, with secret
+	// values redacted.
+	Flags map[string]string `json:"flags,omitempty"`
+
+	// Profile and Endpoint are the profile and endpoint the command used.
+	Profile  string `json:"profile,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// User is the local user who ran the command.
+	User string `json:"user,omitempty"`
+
+	// Annotations holds the request annotations the command sent, such as
+	// a change ticket ID.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Result is ResultSuccess or ResultFailure.
+	Result string `json:"result"`
+
+	// Error is the error message of a failed command.
+	Error string `json:"error,omitempty"`
+
+	// DurationMS is how long the command ran, in milliseconds.
+	DurationMS int64 `json:"duration_ms"`
+}
+
+// Duration returns the command's run time.
+func (e *Entry) Duration() time.Duration {
+	return time.Duration(e.DurationMS) * time.Millisecond
+}
+
+// GetFilePath returns the path to the history file.
+func GetFilePath() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, FileName), nil
+}
+
+// Append writes entry to the end of the history file at path, creating
+// the file and its directory if needed.
+func Append(path string, entry *Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode history entry: %w", err)
+	}
+	data = append(data, '\n')
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("create history directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) //nolint:gosec // Path is in the config directory
+	if err != nil {
+		return fmt.Errorf("open history file: %w", err)
+	}
+
+	// A single write of one line keeps concurrent appends from interleaving.
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write history file: %w", err)
+	}
+
+	return f.Close()
+}
+
+// Load reads all entries from the history file at path, oldest first.
+//
+// A missing file is not an error. Lines that cannot be parsed (for
+// example, a partial line from an interrupted write) are skipped.
+func Load(path string) ([]Entry, error) {
+	f, err := os.Open(path) //nolint:gosec // Path is in the config directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("open history file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read history file: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,75 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", FileName)
+
+	first := &Entry{Time: time.Now().UTC(), Command: "collection list", Result: ResultSuccess, DurationMS: 120}
+	second := &Entry{Time: time.Now().UTC(), Command: "collection delete", Args: []string{"abc"}, Result: ResultFailure, Error: "HTTP 404: not found"}
+
+	for _, e := range []*Entry{first, second} {
+		if err := Append(path, e); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	entries, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Load() returned %d entries, want 2", len(entries))
+	}
+	if entries[0].Command != "collection list" || entries[1].Command != "collection delete" {
+		t.Errorf("Load() commands = %q, %q, want oldest first", entries[0].Command, entries[1].Command)
+	}
+	if entries[0].Duration() != 120*time.Millisecond {
+		t.Errorf("Duration() = %v, want 120ms", entries[0].Duration())
+	}
+	if entries[1].Error != "HTTP 404: not found" {
+		t.Errorf("Error = %q, want %q", entries[1].Error, "HTTP 404: not found")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat history file: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("history file permissions = %o, want 600", perm)
+	}
+}
+
+func TestLoad_Missing(t *testing.T) {
+	entries, err := Load(filepath.Join(t.TempDir(), FileName))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Load() returned %d entries, want 0", len(entries))
+	}
+}
+
+func TestLoad_SkipsCorruptLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	data := `{"command":"node list","result":"success"}
+{"command":"node cre
+{"command":"node show","result":"success"}
+`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("Load() returned %d entries, want 2", len(entries))
+	}
+}
@@ -0,0 +1,78 @@
+package i18n
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// usageHeadings are the fixed strings of cobra's default usage template,
+// ordered so that no heading is replaced inside a longer one.
+var usageHeadings = []string{
+	"Usage:",
+	"Aliases:",
+	"Examples:",
+	"Available Commands:",
+	"Additional Commands:",
+	"Global Flags:",
+	"Flags:",
+	"Additional help topics:",
+	`Use "{{.CommandPath}} [command] --help" for more information about a command.`,
+}
+
+// LocalizeCommand translates the help text of root and all of its
+// subcommands (short and long descriptions, examples, flag usage, and
+// the usage template) into the selected language. It does nothing for
+// English.
+//
+// Call it once the command tree is complete and before Execute. It adds
+// cobra's default help and completion commands and help flags first so
+// that they are translated too.
+func LocalizeCommand(root *cobra.Command) {
+	if Language() == English {
+		return
+	}
+
+	root.InitDefaultHelpCmd()
+	root.InitDefaultCompletionCmd()
+
+	template := root.UsageTemplate()
+	for _, heading := range usageHeadings {
+		template = strings.ReplaceAll(template, heading, T(heading))
+	}
+	root.SetUsageTemplate(template)
+
+	localize(root, map[*pflag.Flag]bool{})
+}
+
+// localize translates cmd and its subcommands. Flags already in seen are
+// not translated again.
+func localize(cmd *cobra.Command, seen map[*pflag.Flag]bool) {
+	cmd.InitDefaultHelpFlag()
+	cmd.InitDefaultVersionFlag()
+
+	cmd.Short = T(cmd.Short)
+	cmd.Long = T(cmd.Long)
+	cmd.Example = T(cmd.Example)
+
+	translateFlag := func(f *pflag.Flag) {
+		if seen[f] {
+			return
+		}
+		seen[f] = true
+		for _, format := range []string{"help for %s", "version for %s"} {
+			if name, ok := strings.CutPrefix(f.Usage, strings.TrimSuffix(format, "%s")); ok {
+				f.Usage = Sprintf(format, name)
+				return
+			}
+		}
+		f.Usage = T(f.Usage)
+	}
+	cmd.LocalFlags().VisitAll(translateFlag)
+	cmd.PersistentFlags().VisitAll(translateFlag)
+
+	for _, sub := range cmd.Commands() {
+		localize(sub, seen)
+	}
+}
@@ -0,0 +1,295 @@
+package i18n
+
+// spanish is the Spanish catalog, keyed by the English message.
+var spanish = map[string]string{
+	// Replies to [y/N] prompts
+	"y":   "s",
+	"yes": "sí",
+
+	// Root command
+	"Globus Connect Server command-line interface": "Interfaz de línea de comandos de Globus Connect Server",
+	`globus-connect-server is a CLI for managing Globus Connect Server v5 endpoints.
+
+This is a complete Go port of the Python globus-connect-server CLI with 100% feature parity.
+
+For more information, see: https://docs.globus.org/globus-connect-server/v5/`: `globus-connect-server es una CLI para administrar endpoints de Globus Connect Server v5.
+
+Es una versión completa en Go de la CLI globus-connect-server de Python, con el 100% de paridad de funciones.
+
+Más información en: https://docs.globus.org/globus-connect-server/v5/`,
+
+	// Usage template
+	"Usage:":                  "Uso:",
+	"Aliases:":                "Alias:",
+	"Examples:":               "Ejemplos:",
+	"Available Commands:":     "Comandos disponibles:",
+	"Additional Commands:":    "Comandos adicionales:",
+	"Flags:":                  "Opciones:",
+	"Global Flags:":           "Opciones globales:",
+	"Additional help topics:": "Temas de ayuda adicionales:",
+	`Use "{{.CommandPath}} [command] --help" for more information about a command.`: `Use "{{.CommandPath}} [comando] --help" para más información sobre un comando.`,
+
+	// Cobra built-ins
+	"help for %s":            "ayuda para %s",
+	"version for %s":         "versión de %s",
+	"Help about any command": "Ayuda sobre cualquier comando",
+	"Generate the autocompletion script for the specified shell": "Genera el script de autocompletado para el shell indicado",
+
+	// Global and common flags
+	"Output format (text, json, yaml, table, wide, csv)":                                                            "Formato de salida (text, json, yaml, table, wide, csv)",
+	"Output format (text, json, yaml)":                                                                              "Formato de salida (text, json, yaml)",
+	"Output format (text, json, yaml, github-actions, sarif)":                                                       "Formato de salida (text, json, yaml, github-actions, sarif)",
+	"Enable verbose output":                                                                                         "Activa la salida detallada",
+	"Log each GCS API request to stderr (method, path, status, duration, request ID)":                               "Registra cada solicitud a la API de GCS en stderr (método, ruta, estado, duración, ID de solicitud)",
+	"Like --debug, and also log request and response headers and bodies, with tokens redacted":                      "Como --debug, y además registra las cabeceras y los cuerpos de solicitudes y respuestas, con los tokens ocultos",
+	"Do not keep a copy in the local trash":                                                                         "No guarda una copia en la papelera local",
+	"List and restore deleted roles and policies":                                                                   "Lista y restaura roles y políticas eliminados",
+	"List deleted resources that can be restored":                                                                   "Lista los recursos eliminados que se pueden restaurar",
+	"Re-create a deleted role or policy":                                                                            "Vuelve a crear un rol o una política eliminados",
+	"Restore to this endpoint FQDN instead of the original one":                                                     "Restaura en este FQDN de endpoint en lugar del original",
+	"Maximum time for a single audit database statement (0 for no limit)":                                           "Tiempo máximo para una sola sentencia en la base de datos de auditoría (0 para no limitar)",
+	"Only print IDs, one per line":                                                                                  "Muestra solo los ID, uno por línea",
+	"Maximum number of rows to export (0 for no limit)":                                                             "Número máximo de filas a exportar (0 para no limitar)",
+	"Number of rows to write between flushes":                                                                       "Número de filas a escribir entre vaciados del búfer",
+	"Do not page long output on a terminal (also disabled by PAGER=cat)":                                            "No pagina la salida larga en un terminal (también se desactiva con PAGER=cat)",
+	"Filter by event type (transfer, access, authentication)":                                                       "Filtra por tipo de evento (transfer, access, authentication)",
+	"Show step-by-step recipes for common tasks":                                                                    "Muestra recetas paso a paso para tareas comunes",
+	"Do not tag collections with the manifest's commit, path, and pipeline":                                         "No etiqueta las colecciones con el commit, la ruta y el pipeline del manifiesto",
+	"Manage stored authentication tokens":                                                                           "Gestiona los tokens de autenticación almacenados",
+	"Move tokens between machines":                                                                                  "Traslada tokens entre máquinas",
+	"Export the tokens of a profile":                                                                                "Exporta los tokens de un perfil",
+	"Import tokens exported from another machine":                                                                   "Importa tokens exportados desde otra máquina",
+	"Write the bundle to this file instead of standard output":                                                      "Escribe el paquete en este archivo en lugar de la salida estándar",
+	"Read the passphrase from this environment variable":                                                            "Lee la frase de contraseña de esta variable de entorno",
+	"Export the tokens unencrypted":                                                                                 "Exporta los tokens sin cifrar",
+	"Replace an existing session of the profile":                                                                    "Reemplaza una sesión existente del perfil",
+	"Check roles on this collection ID instead of the endpoint":                                                     "Comprueba los roles en este ID de colección en lugar del endpoint",
+	"Fail unless you hold these roles (comma-separated)":                                                            "Falla si no tiene estos roles (separados por comas)",
+	"Fail unless the token was granted this scope (repeatable)":                                                     "Falla si el token no obtuvo este alcance (repetible)",
+	"Fail unless the token stays valid this long":                                                                   "Falla si el token no sigue siendo válido durante este tiempo",
+	"Rotate the token encryption key":                                                                               "Rota la clave de cifrado de los tokens",
+	"Edit a collection in your editor":                                                                              "Editar una colección en su editor",
+	"Edit a storage gateway in your editor":                                                                         "Editar un gateway de almacenamiento en su editor",
+	"Edit an authentication policy in your editor":                                                                  "Editar una política de autenticación en su editor",
+	"Collect information for Globus support":                                                                        "Recopilar información para el soporte de Globus",
+	"Create a diagnostics archive for a support ticket":                                                             "Crear un archivo de diagnóstico para un ticket de soporte",
+	"GCS endpoint FQDN to diagnose":                                                                                 "FQDN del endpoint GCS a diagnosticar",
+	"Archive file (default gcs-support-<time>.tar.gz)":                                                              "Archivo de salida (por defecto gcs-support-<hora>.tar.gz)",
+	"Number of recent activity log entries to include":                                                              "Número de entradas recientes del registro de actividad a incluir",
+	"Print the full contents of the bundle before writing it":                                                       "Muestra el contenido completo del paquete antes de escribirlo",
+	"Write the bundle without asking for confirmation":                                                              "Escribe el paquete sin pedir confirmación",
+	"Write support bundle to %s? [y/N]: ":                                                                           "¿Escribir el paquete de soporte en %s? [s/N]: ",
+	"Fail a fraction of GCS API requests, e.g. 503:0.1 or reset:0.05 (for testing)":                                 "Hace fallar una fracción de las solicitudes a la API de GCS, p. ej. 503:0.1 o reset:0.05 (para pruebas)",
+	"Delay every GCS API request, e.g. 2s (for testing)":                                                            "Retrasa cada solicitud a la API de GCS, p. ej. 2s (para pruebas)",
+	"injecting faults into GCS API requests (--%s, --%s)":                                                           "inyectando fallos en las solicitudes a la API de GCS (--%s, --%s)",
+	"Show the stored tokens of all profiles":                                                                        "Mostrar los tokens almacenados de todos los perfiles",
+	"Export audit logs to a file or object storage":                                                                 "Exportar registros de auditoría a un archivo o a almacenamiento de objetos",
+	"Output file path, or s3:// or gs:// URL":                                                                       "Ruta del archivo de salida, o URL s3:// o gs://",
+	"Gzip-compress the export (default true for s3:// and gs:// outputs)":                                           "Comprimir la exportación con gzip (de forma predeterminada en salidas s3:// y gs://)",
+	"S3 server-side encryption (AES256, aws:kms)":                                                                   "Cifrado del lado del servidor de S3 (AES256, aws:kms)",
+	"KMS key for --sse aws:kms, or Cloud KMS key for gs:// outputs":                                                 "Clave KMS para --sse aws:kms, o clave de Cloud KMS para salidas gs://",
+	"Minutes after which users must authenticate again to access the collection":                                    "Minutos tras los cuales los usuarios deben volver a autenticarse para acceder a la colección",
+	"Identity allowed to create shares; comma-separated lists are accepted (repeatable)":                            "Identidad autorizada a crear recursos compartidos; se aceptan listas separadas por comas (repetible)",
+	"Identity denied from creating shares; comma-separated lists are accepted (repeatable)":                         "Identidad a la que se niega crear recursos compartidos; se aceptan listas separadas por comas (repetible)",
+	"Sharing restriction level":                                                                                     "Nivel de restricción para compartir",
+	"Stop keychain prompts for the encryption key":                                                                  "Evita las solicitudes del llavero para la clave de cifrado",
+	"Delete the tokens without revoking them":                                                                       "Elimina los tokens sin revocarlos",
+	"Check network requirements before endpoint setup":                                                              "Comprueba los requisitos de red antes de configurar el endpoint",
+	"Public hostname of this host (default: the system hostname)":                                                   "Nombre público de este host (por defecto: el nombre del sistema)",
+	"Port range of incoming data channels":                                                                          "Rango de puertos de los canales de datos entrantes",
+	"Additional host:port that must be reachable outbound (repeatable)":                                             "host:puerto adicional que debe ser accesible hacia fuera (repetible)",
+	"Timeout of each network check":                                                                                 "Tiempo de espera de cada comprobación de red",
+	"Export the CLI setup to provision another workstation":                                                         "Exporta la configuración de la CLI para preparar otra estación de trabajo",
+	"Set up this workstation from a config export":                                                                  "Configura esta estación de trabajo a partir de una exportación",
+	"Profile whose tokens --include-tokens exports":                                                                 "Perfil cuyos tokens exporta --include-tokens",
+	"Write the export to this file instead of standard output":                                                      "Escribe la exportación en este archivo en lugar de la salida estándar",
+	"Include the profiles sections of config.yaml":                                                                  "Incluye las secciones profiles de config.yaml",
+	"Include stored tokens":                                                                                         "Incluye los tokens almacenados",
+	"Replace an existing config.yaml and sessions":                                                                  "Reemplaza el config.yaml y las sesiones existentes",
+	"Read the access token from the first line of stdin instead of the stored token (also GLOBUS_GCS_ACCESS_TOKEN)": "Lee el token de acceso de la primera línea de la entrada estándar en lugar del token guardado (también GLOBUS_GCS_ACCESS_TOKEN)",
+	"Limit GCS API requests per second, e.g. 5 or 0.5 (0 for no limit)":                                             "Limita las solicitudes a la API de GCS por segundo, p. ej., 5 o 0.5 (0 para no limitar)",
+	"the endpoint has more results than this page; use --all to list them all":                                      "el endpoint tiene más resultados que esta página; use --all para listarlos todos",
+	"Print the DNS, connect, TLS, and time-to-first-byte timing of each GCS API request to stderr":                  "Muestra en stderr los tiempos de DNS, conexión, TLS y primer byte de cada solicitud a la API de GCS",
+	"Timeout of each GCS API request, e.g. 10s or 2m (0 for none; default 30s)":                                     "Tiempo máximo de cada solicitud a la API de GCS, p. ej. 10s o 2m (0 para ninguno; 30s por defecto)",
+	"Print the requests that would change the endpoint (method, path, body) instead of sending them":                "Muestra las solicitudes que cambiarían el endpoint (método, ruta, cuerpo) en lugar de enviarlas",
+	"dry run: requests that change the endpoint are printed, not sent":                                              "simulación: las solicitudes que cambian el endpoint se muestran, no se envían",
+	"access token for profile %q expires soon and could not be refreshed: %v":                                       "el token de acceso del perfil %q caduca pronto y no se pudo renovar: %v",
+	"PEM file of a TLS client certificate for endpoints behind proxies that require one":                            "Archivo PEM de un certificado de cliente TLS para endpoints detrás de proxies que lo exigen",
+	"PEM file of the client certificate's private key (default: the --client-cert file)":                            "Archivo PEM de la clave privada del certificado de cliente (predeterminado: el archivo de --client-cert)",
+	"Proxy for GCS API requests, e.g. http://proxy:3128 or socks5://127.0.0.1:1080 (default: HTTPS_PROXY)":          "Proxy para las solicitudes a la API de GCS, p. ej. http://proxy:3128 o socks5://127.0.0.1:1080 (predeterminado: HTTPS_PROXY)",
+	"PEM file of CA certificates to trust in addition to the system's, for endpoints with internal certificates":    "Archivo PEM de certificados de CA en los que confiar además de los del sistema, para endpoints con certificados internos",
+	"Do not verify endpoint TLS certificates (INSECURE: for test endpoints only)":                                   "No verificar los certificados TLS del endpoint (INSEGURO: solo para endpoints de prueba)",
+	"Text appended to the User-Agent of GCS API requests, e.g. nightly-sync/2":                                      "Texto que se añade al User-Agent de las solicitudes a la API de GCS, p. ej. nightly-sync/2",
+	"TLS certificate verification is DISABLED (--%s): anyone between you and the endpoint can read and change requests, including your access token. Use it only against test endpoints.": "La verificación de certificados TLS está DESACTIVADA (--%s): cualquiera entre usted y el endpoint puede leer y modificar las solicitudes, incluido su token de acceso. Úsela solo con endpoints de prueba.",
+	"the endpoint has more results than this page; use --all to list them all, or --marker %s for the next page":                                                                          "el endpoint tiene más resultados que esta página; use --all para listarlos todos, o --marker %s para la página siguiente",
+	"Number of collections per page (default: the endpoint's)":                                                                                                                            "Número de colecciones por página (predeterminado: el del endpoint)",
+	"Start at the page of this pagination marker":                                                                                                                                         "Comenzar en la página de este marcador de paginación",
+	"Only list collections on this storage gateway":                                                                                                                                       "Listar solo las colecciones de este storage gateway",
+	"Only list collections of this type (mapped, guest)":                                                                                                                                  "Listar solo las colecciones de este tipo (mapped, guest)",
+	"Only list public (true) or private (false) collections":                                                                                                                              "Listar solo las colecciones públicas (true) o privadas (false)",
+	"Only list collections created after this time":                                                                                                                                       "Listar solo las colecciones creadas después de este momento",
+	"Only list collections created before this time":                                                                                                                                      "Listar solo las colecciones creadas antes de este momento",
+	"Only list collections modified after this time":                                                                                                                                      "Listar solo las colecciones modificadas después de este momento",
+	"Only list collections modified before this time":                                                                                                                                     "Listar solo las colecciones modificadas antes de este momento",
+	"Create a guest collection on a mapped collection":                                                                                                                                    "Crear una colección de invitado en una colección mapeada",
+	"ID of the mapped collection to share":                                                                                                                                                "ID de la colección mapeada que se comparte",
+	"ID of the user credential the guest collection accesses storage with":                                                                                                                "ID de la credencial de usuario con la que la colección de invitado accede al almacenamiento",
+	"Path within the mapped collection to share":                                                                                                                                          "Ruta dentro de la colección mapeada que se comparte",
+	"Manage guest collection permissions":                                                                                                                                                 "Gestionar los permisos de colecciones de invitado",
+	"List the access rules of a guest collection":                                                                                                                                         "Listar las reglas de acceso de una colección de invitado",
+	"Grant access to a path of a guest collection":                                                                                                                                        "Conceder acceso a una ruta de una colección de invitado",
+	"Remove an access rule from a guest collection":                                                                                                                                       "Eliminar una regla de acceso de una colección de invitado",
+	"Kind of principal (identity, group, all_authenticated_users, anonymous)":                                                                                                             "Tipo de principal (identity, group, all_authenticated_users, anonymous)",
+	"Identity or group ID":                                                                                                                                                                "ID de identidad o de grupo",
+	"Directory to grant access to":                                                                                                                                                        "Directorio al que se concede acceso",
+	"Access to grant (r, rw)":                                                                                                                                                             "Acceso que se concede (r, rw)",
+	"Email address to send an invitation to":                                                                                                                                              "Dirección de correo a la que enviar una invitación",
+	"Read collection IDs from this file, one per line (- for standard input)":                                                                                                             "Leer los ID de colección de este archivo, uno por línea (- para la entrada estándar)",
+	"Keep deleting after a request in which a deletion failed":                                                                                                                            "Seguir eliminando tras una solicitud en la que falló una eliminación",
+	"Number of collections deleted per request":                                                                                                                                           "Número de colecciones eliminadas por solicitud",
+	"Export a collection as a YAML file":                                                                                                                                                  "Exportar una colección como archivo YAML",
+	"Write the collection to a file instead of stdout":                                                                                                                                    "Escribir la colección en un archivo en lugar de la salida estándar",
+	"Create the collection defined in a file written by 'collection export' (- for stdin)":                                                                                                "Crear la colección definida en un archivo escrito por 'collection export' (- para la entrada estándar)",
+	"Apply a file written by 'collection export' (- for stdin)":                                                                                                                           "Aplicar un archivo escrito por 'collection export' (- para la entrada estándar)",
+	"Compare a collection to a collection file":                                                                                                                                           "Comparar una colección con un archivo de colección",
+	"Collection file to compare with, written by 'collection export' (- for stdin)":                                                                                                       "Archivo de colección con el que comparar, escrito por 'collection export' (- para la entrada estándar)",
+	"Do not record this command in the activity log":                                                                                                                                      "No registrar este comando en el registro de actividad",
+	"Annotate requests with key=value (e.g., ticket=CHG12345); sent as a header and recorded in the activity log":                                                                         "Anota las solicitudes con clave=valor (p. ej., ticket=CHG12345); se envía como encabezado y se guarda en el registro de actividad",
+	"Text output style: default, or plain for screen readers (no color, box drawing, or padding)":                                                                                         "Estilo de la salida de texto: default, o plain para lectores de pantalla (sin color, recuadros ni relleno)",
+	"Go template for each item of output, e.g. '{{.ID}} {{.DisplayName}}' (implies --format template)":                                                                                    "Plantilla de Go para cada elemento de la salida, p. ej. '{{.ID}} {{.DisplayName}}' (implica --format template)",
+	"JMESPath expression to filter JSON or YAML output, e.g. 'data[?public].id' (implies --format json)":                                                                                  "Expresión JMESPath para filtrar la salida JSON o YAML, p. ej. 'data[?public].id' (implica --format json)",
+	"Disable colored output (also set by NO_COLOR)":                                                                                                                                       "Desactiva la salida en color (también con NO_COLOR)",
+	"Print exact byte counts and durations in seconds instead of 1.2 GiB, 3m42s":                                                                                                          "Muestra bytes exactos y duraciones en segundos en lugar de 1.2 GiB, 3m42s",
+	"Language for messages and help (en, es)":                                                                                                                                             "Idioma de los mensajes y la ayuda (en, es)",
+	"Profile name":  "Nombre del perfil",
+	"Endpoint FQDN": "FQDN del endpoint",
+	"Endpoint FQDN (e.g., abc.def.data.globus.org)":                                  "FQDN del endpoint (p. ej., abc.def.data.globus.org)",
+	"Comma-separated columns to show, e.g. id,display_name (implies --format table)": "Columnas separadas por comas para mostrar, p. ej. id,display_name (implica --format table)",
+	"Collection ID": "ID de la colección",
+
+	// Runtime messages
+	"Warning: ": "Advertencia: ",
+	"%s is a high-risk command. Type %q to continue: ": "%s es un comando de alto riesgo. Escriba %q para continuar: ",
+	"Run %s? [y/N]: ": "¿Ejecutar %s? [s/N]: ",
+	"Confirm a high-risk command by naming the resource it acts on (e.g., the storage gateway ID)": "Confirma un comando de alto riesgo nombrando el recurso afectado (p. ej., el ID del gateway de almacenamiento)",
+	"Log in again to continue? [y/N]: ":                                   "¿Iniciar sesión de nuevo para continuar? [s/N]: ",
+	"%v (see 'endpoint limits')":                                          "%v (consulte 'endpoint limits')",
+	"%s hook failed: %v":                                                  "falló el hook %s: %v",
+	"could not fetch %s: %s":                                              "no se pudo obtener %s: %s",
+	"could not look up collection %s: %v":                                 "no se pudo consultar la colección %s: %v",
+	"could not look up identity %s: %v":                                   "no se pudo consultar la identidad %s: %v",
+	"could not record command history: %v (use --%s to disable)":          "no se pudo registrar el historial de comandos: %v (use --%s para desactivarlo)",
+	"access token for profile %q was rejected; refreshed it and retrying": "se rechazó el token de acceso del perfil %q; se renovó y se reintenta",
+	"refresh audit metrics: %v":                                           "actualizar métricas de auditoría: %v",
+
+	// Command groups
+	"Authenticate with Globus Auth":                     "Autenticarse con Globus Auth",
+	"Remove stored authentication tokens":               "Eliminar los tokens de autenticación guardados",
+	"Show current authenticated identity":               "Mostrar la identidad autenticada actual",
+	"Manage audit logs":                                 "Administrar registros de auditoría",
+	"Manage authentication policies":                    "Administrar políticas de autenticación",
+	"Manage GCS collections":                            "Administrar colecciones de GCS",
+	"Inspect and replicate CLI configuration":           "Inspeccionar y replicar la configuración de la CLI",
+	"Manage GCS endpoints":                              "Administrar endpoints de GCS",
+	"Show the log of CLI commands run on this host":     "Mostrar el registro de comandos de la CLI ejecutados en este equipo",
+	"Manage GCS nodes":                                  "Administrar nodos de GCS",
+	"Manage OpenID Connect (OIDC) server configuration": "Administrar la configuración del servidor OpenID Connect (OIDC)",
+	"Manage GCS roles":                                  "Administrar roles de GCS",
+	"Validate an endpoint end to end":                   "Validar un endpoint de extremo a extremo",
+	"Manage CLI authentication session":                 "Administrar la sesión de autenticación de la CLI",
+	"Manage collection sharing policies":                "Administrar políticas de uso compartido de colecciones",
+	"Manage GCS storage gateways":                       "Administrar gateways de almacenamiento de GCS",
+	"Manage user storage credentials":                   "Administrar credenciales de almacenamiento de usuarios",
+
+	// Subcommands
+	"Add S3 IAM access keys":                                     "Agregar claves de acceso IAM de S3",
+	"Assign endpoint owner role to a principal":                  "Asignar el rol de propietario del endpoint a un principal",
+	"Bring a disabled collection back online":                    "Volver a poner en línea una colección deshabilitada",
+	"Configure and initialize a new node":                        "Configurar e inicializar un nodo nuevo",
+	"Configure custom domain for collection":                     "Configurar un dominio personalizado para la colección",
+	"Configure custom domain for endpoint":                       "Configurar un dominio personalizado para el endpoint",
+	"Convert deployment key to new format":                       "Convertir la clave de despliegue al formato nuevo",
+	"Create ActiveScale user credential":                         "Crear una credencial de usuario de ActiveScale",
+	"Create OAuth2 user credential":                              "Crear una credencial de usuario de OAuth2",
+	"Create OIDC server configuration":                           "Crear la configuración del servidor OIDC",
+	"Create S3 user credential":                                  "Crear una credencial de usuario de S3",
+	"Create a new authentication policy":                         "Crear una política de autenticación",
+	"Create a new collection":                                    "Crear una colección",
+	"Create a new node":                                          "Crear un nodo",
+	"Create a new role assignment":                               "Crear una asignación de rol",
+	"Create a new sharing policy":                                "Crear una política de uso compartido",
+	"Create a new storage gateway":                               "Crear un gateway de almacenamiento",
+	"Create and initialize a new GCS endpoint":                   "Crear e inicializar un endpoint de GCS",
+	"Delete OIDC server configuration":                           "Eliminar la configuración del servidor OIDC",
+	"Delete S3 IAM access keys":                                  "Eliminar claves de acceso IAM de S3",
+	"Delete a collection":                                        "Eliminar una colección",
+	"Delete a node":                                              "Eliminar un nodo",
+	"Delete a role assignment":                                   "Eliminar una asignación de rol",
+	"Delete a sharing policy":                                    "Eliminar una política de uso compartido",
+	"Delete a storage gateway":                                   "Eliminar un gateway de almacenamiento",
+	"Delete a user credential":                                   "Eliminar una credencial de usuario",
+	"Delete an authentication policy":                            "Eliminar una política de autenticación",
+	"Delete multiple collections in one operation":               "Eliminar varias colecciones en una sola operación",
+	"Designate the owner of a collection":                        "Designar al propietario de una colección",
+	"Disable a node to prevent data transfers":                   "Deshabilitar un nodo para impedir transferencias de datos",
+	"Display OIDC server configuration":                          "Mostrar la configuración del servidor OIDC",
+	"Display authentication policy details":                      "Mostrar los detalles de una política de autenticación",
+	"Display collection details":                                 "Mostrar los detalles de una colección",
+	"Display collection domain configuration":                    "Mostrar la configuración de dominio de la colección",
+	"Display current authentication session":                     "Mostrar la sesión de autenticación actual",
+	"Display details of a sharing policy":                        "Mostrar los detalles de una política de uso compartido",
+	"Display details of a user credential":                       "Mostrar los detalles de una credencial de usuario",
+	"Display endpoint configuration":                             "Mostrar la configuración del endpoint",
+	"Display endpoint domain configuration":                      "Mostrar la configuración de dominio del endpoint",
+	"Display node details":                                       "Mostrar los detalles de un nodo",
+	"Display role details":                                       "Mostrar los detalles de un rol",
+	"Display storage gateway details":                            "Mostrar los detalles de un gateway de almacenamiento",
+	"Display subscription-based endpoint limits":                 "Mostrar los límites del endpoint según la suscripción",
+	"Enable a node for data transfers":                           "Habilitar un nodo para transferencias de datos",
+	"Generate a new authentication secret for a node":            "Generar un secreto de autenticación nuevo para un nodo",
+	"List all authentication policies":                           "Listar todas las políticas de autenticación",
+	"List all sharing policies":                                  "Listar todas las políticas de uso compartido",
+	"List all user credentials":                                  "Listar todas las credenciales de usuario",
+	"List the optional features enabled on the endpoint":         "Listar las funciones opcionales habilitadas en el endpoint",
+	"List collections on an endpoint":                            "Listar las colecciones de un endpoint",
+	"List nodes on an endpoint":                                  "Listar los nodos de un endpoint",
+	"List roles on an endpoint":                                  "Listar los roles de un endpoint",
+	"List storage gateways on an endpoint":                       "Listar los gateways de almacenamiento de un endpoint",
+	"Load audit logs into local database":                        "Cargar registros de auditoría en la base de datos local",
+	"Manage collection custom domain":                            "Administrar el dominio personalizado de la colección",
+	"Manage endpoint custom domain":                              "Administrar el dominio personalizado del endpoint",
+	"Manage maintenance banners across collections":              "Administrar avisos de mantenimiento en las colecciones",
+	"Permanently remove endpoint configuration":                  "Eliminar de forma permanente la configuración del endpoint",
+	"Query audit logs from local database":                       "Consultar registros de auditoría en la base de datos local",
+	"Register existing OIDC server":                              "Registrar un servidor OIDC existente",
+	"Remove collection custom domain configuration":              "Quitar la configuración de dominio personalizado de la colección",
+	"Remove endpoint custom domain configuration":                "Quitar la configuración de dominio personalizado del endpoint",
+	"Remove node and its configuration":                          "Quitar un nodo y su configuración",
+	"Remove the maintenance banner":                              "Quitar el aviso de mantenimiento",
+	"Reset collection owner string to default":                   "Restablecer el nombre del propietario de la colección",
+	"Reset endpoint owner string to default (ClientID)":          "Restablecer el nombre del propietario del endpoint (ClientID)",
+	"Run a round-trip transfer smoke test":                       "Ejecutar una prueba rápida de transferencia de ida y vuelta",
+	"Serve audit log metrics for Prometheus":                     "Publicar métricas de auditoría para Prometheus",
+	"Suggest collections for the project folders in a directory": "Sugerir colecciones para las carpetas de proyecto de un directorio",
+	"Create the collections listed in a manifest":                "Crear las colecciones indicadas en un manifiesto",
+	"Set custom display name for collection owner":               "Definir un nombre visible para el propietario de la colección",
+	"Set custom display name for endpoint owner":                 "Definir un nombre visible para el propietario del endpoint",
+	"Set subscription admin verification status for collection":  "Definir el estado de verificación del administrador de suscripción de la colección",
+	"Show a maintenance banner on every collection":              "Mostrar un aviso de mantenimiento en todas las colecciones",
+	"Show the current maintenance banner":                        "Mostrar el aviso de mantenimiento actual",
+	"Show the fully resolved configuration":                      "Mostrar la configuración resuelta por completo",
+	"Take a collection offline for maintenance":                  "Poner una colección fuera de línea por mantenimiento",
+	"Update OIDC server configuration":                           "Actualizar la configuración del servidor OIDC",
+	"Update S3 IAM access keys":                                  "Actualizar claves de acceso IAM de S3",
+	"Update an authentication policy":                            "Actualizar una política de autenticación",
+	"Update an existing collection":                              "Actualizar una colección existente",
+	"Update an existing node":                                    "Actualizar un nodo existente",
+	"Update an existing storage gateway":                         "Actualizar un gateway de almacenamiento existente",
+	"Update endpoint configuration":                              "Actualizar la configuración del endpoint",
+	"Update session consents":                                    "Actualizar los consentimientos de la sesión",
+	"Update session settings":                                    "Actualizar la configuración de la sesión",
+	"Update subscription assignment for endpoint":                "Actualizar la suscripción asignada al endpoint",
+	"Upgrade endpoint to latest version":                         "Actualizar el endpoint a la versión más reciente",
+	"Validate storage gateway configuration":                     "Validar la configuración del gateway de almacenamiento",
+	"Validate collection configuration":                          "Validar la configuración de la colección",
+}
@@ -0,0 +1,145 @@
+// Package i18n translates the CLI's user-facing strings.
+//
+// Messages are looked up by their English text, so English needs no
+// catalog and any message without a translation falls back to English.
+// The language is chosen with the --lang flag or, failing that, the
+// LC_ALL, LC_MESSAGES, and LANG environment variables.
+//
+// Only human-readable text is translated. JSON output, error codes, flag
+// names, and configuration keys stay the same in every language so that
+// scripts keep working.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// LangFlag is the root persistent flag that selects the language.
+const LangFlag = "lang"
+
+// English is the source language of every message.
+const English = "en"
+
+// catalogs maps a language code to its translations, keyed by the
+// English message.
+var catalogs = map[string]map[string]string{
+	"es": spanish,
+}
+
+var (
+	mu       sync.RWMutex
+	language = English
+)
+
+// Languages returns the supported language codes.
+func Languages() []string {
+	langs := []string{English}
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs[1:])
+	return langs
+}
+
+// Normalize reduces a locale such as "es_MX.UTF-8" to its language code
+// ("es").
+func Normalize(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "_.@-"); i >= 0 {
+		locale = locale[:i]
+	}
+	return locale
+}
+
+// Supported reports whether a locale's language has a catalog.
+func Supported(locale string) bool {
+	lang := Normalize(locale)
+	if lang == English {
+		return true
+	}
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// SetLanguage selects the language of later translations.
+func SetLanguage(locale string) error {
+	if !Supported(locale) {
+		return fmt.Errorf("unsupported language %q (supported: %s)", locale, strings.Join(Languages(), ", "))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	language = Normalize(locale)
+	return nil
+}
+
+// Language returns the selected language code.
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return language
+}
+
+// Detect returns the language requested by a --lang flag in args or,
+// without one, by the locale environment variables. Unsupported
+// environment locales yield English; an unsupported --lang value is
+// returned as given so that SetLanguage can report it.
+//
+// Detect runs before the command line is parsed, because help text is
+// translated before cobra renders it.
+func Detect(args []string, getenv func(string) string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if value, ok := strings.CutPrefix(arg, "--"+LangFlag+"="); ok {
+			return value
+		}
+		if arg == "--"+LangFlag && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+
+	// LC_ALL overrides LC_MESSAGES, which overrides LANG
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := getenv(name); value != "" {
+			if Supported(value) {
+				return Normalize(value)
+			}
+			return English
+		}
+	}
+	return English
+}
+
+// T returns the translation of msg in the selected language, or msg
+// itself if there is none.
+func T(msg string) string {
+	lang := Language()
+	if lang == English {
+		return msg
+	}
+	if translated, ok := catalogs[lang][msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// Sprintf translates format and then formats it with args.
+func Sprintf(format string, args ...interface{}) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// IsYes reports whether answer is an affirmative reply to a [y/N]
+// prompt, in English or the selected language.
+func IsYes(answer string) bool {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	switch answer {
+	case "y", "yes", T("y"), T("yes"):
+		return true
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// useLanguage selects lang for the duration of a test.
+func useLanguage(t *testing.T, lang string) {
+	t.Helper()
+	if err := SetLanguage(lang); err != nil {
+		t.Fatalf("SetLanguage(%q) error = %v", lang, err)
+	}
+	t.Cleanup(func() { _ = SetLanguage(English) })
+}
+
+func TestDetect(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(name string) string { return vars[name] }
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+		want string
+	}{
+		{"default", nil, nil, "en"},
+		{"LANG", nil, map[string]string{"LANG": "es_MX.UTF-8"}, "es"},
+		{"LC_ALL wins", nil, map[string]string{"LC_ALL": "en_US.UTF-8", "LANG": "es_ES.UTF-8"}, "en"},
+		{"unsupported env", nil, map[string]string{"LANG": "fr_FR.UTF-8"}, "en"},
+		{"flag", []string{"collection", "list", "--lang", "es"}, map[string]string{"LANG": "en_US"}, "es"},
+		{"flag with equals", []string{"--lang=es"}, nil, "es"},
+		{"unsupported flag", []string{"--lang=fr"}, nil, "fr"},
+		{"after terminator", []string{"--", "--lang=es"}, nil, "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(tt.args, env(tt.env)); got != tt.want {
+				t.Errorf("Detect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetLanguage_Unsupported(t *testing.T) {
+	if err := SetLanguage("fr"); err == nil {
+		t.Error("SetLanguage(\"fr\") expected error")
+	}
+	if got := Language(); got != English {
+		t.Errorf("Language() = %q after failed SetLanguage, want %q", got, English)
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T("Manage GCS collections"); got != "Manage GCS collections" {
+		t.Errorf("T() in English = %q", got)
+	}
+
+	useLanguage(t, "es_ES.UTF-8")
+	if got := T("Manage GCS collections"); got != "Administrar colecciones de GCS" {
+		t.Errorf("T() in Spanish = %q", got)
+	}
+	if got := T("no translation for this"); got != "no translation for this" {
+		t.Errorf("T() without translation = %q", got)
+	}
+	if got := Sprintf("could not fetch %s: %s", "nodes", "boom"); got != "no se pudo obtener nodes: boom" {
+		t.Errorf("Sprintf() = %q", got)
+	}
+}
+
+func TestIsYes(t *testing.T) {
+	if !IsYes("Y\n") || IsYes("s") || IsYes("") {
+		t.Error("IsYes() in English accepted or rejected the wrong answers")
+	}
+
+	useLanguage(t, "es")
+	for _, answer := range []string{"s", "sí", "y", "yes"} {
+		if !IsYes(answer) {
+			t.Errorf("IsYes(%q) = false in Spanish", answer)
+		}
+	}
+	if IsYes("n") {
+		t.Error("IsYes(\"n\") = true")
+	}
+}
+
+func TestSpanishCatalogFormats(t *testing.T) {
+	// A translation must keep the verbs of its English format string
+	for msg, translated := range spanish {
+		if got, want := strings.Count(translated, "%"), strings.Count(msg, "%"); got != want {
+			t.Errorf("%q has %d verbs, translation %q has %d", msg, want, translated, got)
+		}
+	}
+}
+
+func TestLocalizeCommand(t *testing.T) {
+	root := &cobra.Command{Use: "globus-connect-server", Short: "Globus Connect Server command-line interface"}
+	root.PersistentFlags().String("format", "text", "Output format (text, json, yaml)")
+	group := &cobra.Command{Use: "collection", Short: "Manage GCS collections"}
+	list := &cobra.Command{Use: "list", Short: "List collections on an endpoint", Run: func(*cobra.Command, []string) {}}
+	list.Flags().StringP("profile", "p", "default", "Profile name")
+	group.AddCommand(list)
+	root.AddCommand(group)
+
+	useLanguage(t, "es")
+	LocalizeCommand(root)
+
+	if group.Short != "Administrar colecciones de GCS" {
+		t.Errorf("group Short = %q", group.Short)
+	}
+	if got := list.Flags().Lookup("profile").Usage; got != "Nombre del perfil" {
+		t.Errorf("profile usage = %q", got)
+	}
+	if got := list.Flags().Lookup("help").Usage; got != "ayuda para list" {
+		t.Errorf("help usage = %q", got)
+	}
+	if got := root.PersistentFlags().Lookup("format").Usage; got != "Formato de salida (text, json, yaml)" {
+		t.Errorf("format usage = %q", got)
+	}
+
+	usage := list.UsageString()
+	for _, want := range []string{"Uso:", "Opciones:", "Opciones globales:"} {
+		if !strings.Contains(usage, want) {
+			t.Errorf("usage missing %q:\n%s", want, usage)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// gcsScope is the OAuth scope requested for uploads.
+const gcsScope = "https://www.googleapis.com/auth/devstorage.read_write"
+
+// gcsChunkAlign is the size every chunk of a resumable upload but the
+// last must be a multiple of.
+const gcsChunkAlign = 256 << 10
+
+// gcsWriter uploads an object with a GCS resumable upload.
+type gcsWriter struct {
+	ctx     context.Context
+	client  *http.Client
+	loc     *Location
+	session string
+	offset  int64
+}
+
+// newGCSWriter starts a resumable upload to loc.
+//
+// The access token is GOOGLE_OAUTH_ACCESS_TOKEN, or obtained for the
+// service account key in GOOGLE_APPLICATION_CREDENTIALS, or from the
+// metadata server on Google Cloud. STORAGE_EMULATOR_HOST selects a GCS
+// emulator.
+func newGCSWriter(ctx context.Context, loc *Location, opts Options) (Writer, error) {
+	if opts.SSE != "" {
+		return nil, errors.New("GCS always encrypts objects; choose a Cloud KMS key with the KMS key option instead of server-side encryption")
+	}
+	if opts.PartSize%gcsChunkAlign != 0 {
+		return nil, fmt.Errorf("GCS part size must be a multiple of 256 KiB")
+	}
+
+	token, err := gcsAccessToken(ctx, opts.HTTPClient)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{"uploadType": {"resumable"}, "name": {loc.Key}}
+	if opts.KMSKeyID != "" {
+		query.Set("kmsKeyName", opts.KMSKeyID)
+	}
+	metadata, _ := json.Marshal(map[string]string{"name": loc.Key, "contentType": opts.ContentType})
+	u := gcsBaseURL() + "/upload/storage/v1/b/" + url.PathEscape(loc.Bucket) + "/o?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(metadata))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	if opts.ContentType != "" {
+		req.Header.Set("X-Upload-Content-Type", opts.ContentType)
+	}
+	resp, err := opts.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("start upload to %s: %w", loc, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError("start upload to "+loc.String(), resp)
+	}
+	session := resp.Header.Get("Location")
+	if session == "" {
+		return nil, fmt.Errorf("start upload to %s: no upload session in response", loc)
+	}
+
+	// The session URI authorizes the upload; no token is needed from here
+	w := &gcsWriter{ctx: ctx, client: opts.HTTPClient, loc: loc, session: session}
+	return &partWriter{
+		partSize: opts.PartSize,
+		upload:   w.uploadChunk,
+		complete: func() error { return nil },
+		abort:    w.abort,
+	}, nil
+}
+
+// gcsBaseURL returns the GCS API URL, or that of an emulator.
+func gcsBaseURL() string {
+	host := os.Getenv("STORAGE_EMULATOR_HOST")
+	if host == "" {
+		return "https://storage.googleapis.com"
+	}
+	if !strings.Contains(host, "://") {
+		host = "http://" + host
+	}
+	return strings.TrimSuffix(host, "/")
+}
+
+// uploadChunk uploads the next chunk; the last chunk completes the upload.
+func (w *gcsWriter) uploadChunk(chunk []byte, last bool) error {
+	end := w.offset + int64(len(chunk))
+	total := "*"
+	if last {
+		total = fmt.Sprint(end)
+	}
+	contentRange := fmt.Sprintf("bytes %d-%d/%s", w.offset, end-1, total)
+	if len(chunk) == 0 {
+		contentRange = "bytes */" + total
+	}
+	op := fmt.Sprintf("upload bytes %d-%d of %s", w.offset, end, w.loc)
+
+	for attempt := 1; ; attempt++ {
+		req, err := http.NewRequestWithContext(w.ctx, http.MethodPut, w.session, bytes.NewReader(chunk))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Range", contentRange)
+		resp, err := w.client.Do(req)
+		if attempt < maxAttempts && retryable(resp, err) {
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			if err := backoff(w.ctx, attempt); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		want := http.StatusPermanentRedirect // 308: chunk stored, upload incomplete
+		if last {
+			want = http.StatusOK
+		}
+		if resp.StatusCode != want && !(last && resp.StatusCode == http.StatusCreated) {
+			return responseError(op, resp)
+		}
+		w.offset = end
+		return nil
+	}
+}
+
+// abort cancels the resumable upload.
+func (w *gcsWriter) abort() error {
+	req, err := http.NewRequestWithContext(context.WithoutCancel(w.ctx), http.MethodDelete, w.session, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("abort upload to %s: %w", w.loc, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	// GCS answers a cancelled upload with 499
+	if resp.StatusCode != 499 && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return responseError("abort upload to "+w.loc.String(), resp)
+	}
+	return nil
+}
+
+// gcsAccessToken returns an OAuth access token for uploads.
+func gcsAccessToken(ctx context.Context, client *http.Client) (string, error) {
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return token, nil
+	}
+	if path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); path != "" {
+		return serviceAccountToken(ctx, client, path)
+	}
+	if os.Getenv("STORAGE_EMULATOR_HOST") != "" {
+		// Emulators do not check credentials
+		return "emulator", nil
+	}
+	token, err := metadataToken(ctx, client)
+	if err != nil {
+		return "", fmt.Errorf("no Google Cloud credentials found (set GOOGLE_APPLICATION_CREDENTIALS or GOOGLE_OAUTH_ACCESS_TOKEN, or run on Google Cloud): %w", err)
+	}
+	return token, nil
+}
+
+// tokenResponse is the response of an OAuth token endpoint.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+}
+
+// metadataHost is the Google Cloud metadata server. It is a test hook.
+var metadataHost = "metadata.google.internal"
+
+// metadataToken fetches the token of the instance's service account from
+// the metadata server.
+func metadataToken(ctx context.Context, client *http.Client) (string, error) {
+	host := metadataHost
+	if h := os.Getenv("GCE_METADATA_HOST"); h != "" {
+		host = h
+	}
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+host+"/computeMetadata/v1/instance/service-accounts/default/token", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	return fetchToken(client, req)
+}
+
+// serviceAccountToken exchanges a signed JWT for the service account key
+// at path for an access token.
+func serviceAccountToken(ctx context.Context, client *http.Client, path string) (string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // The user's credentials file
+	if err != nil {
+		return "", fmt.Errorf("read Google Cloud credentials: %w", err)
+	}
+	var key struct {
+		Type        string `json:"type"`
+		ClientEmail string `json:"client_email"`
+		PrivateKey  string `json:"private_key"`
+		TokenURI    string `json:"token_uri"`
+	}
+	if err := json.Unmarshal(data, &key); err != nil {
+		return "", fmt.Errorf("parse Google Cloud credentials: %w", err)
+	}
+	if key.Type != "service_account" {
+		return "", fmt.Errorf("unsupported Google Cloud credentials type %q in %s (expected a service account key)", key.Type, path)
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+
+	assertion, err := signJWT(key.PrivateKey, map[string]interface{}{
+		"iss":   key.ClientEmail,
+		"scope": gcsScope,
+		"aud":   key.TokenURI,
+		"iat":   now().Unix(),
+		"exp":   now().Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("sign Google Cloud token request: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, key.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return fetchToken(client, req)
+}
+
+// fetchToken sends a token request and returns the access token.
+func fetchToken(client *http.Client, req *http.Request) (string, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("get Google Cloud access token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", responseError("get Google Cloud access token", resp)
+	}
+	var token tokenResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&token); err != nil || token.AccessToken == "" {
+		return "", errors.New("get Google Cloud access token: no token in response")
+	}
+	return token.AccessToken, nil
+}
+
+// signJWT returns claims as a JWT signed with RS256 by the PEM-encoded
+// PKCS#8 RSA key.
+func signJWT(privateKeyPEM string, claims map[string]interface{}) (string, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return "", errors.New("no PEM private key")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return "", err
+	}
+	rsaKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return "", errors.New("private key is not an RSA key")
+	}
+
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(nil, rsaKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,170 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeGCS is a GCS resumable upload API for tests.
+type fakeGCS struct {
+	mu       sync.Mutex
+	query    map[string]string
+	ranges   []string
+	data     []byte
+	aborted  bool
+	finished bool
+}
+
+func newFakeGCS(t *testing.T) *fakeGCS {
+	t.Helper()
+	f := &fakeGCS{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/upload/storage/v1/b/audit/o":
+			f.query = map[string]string{}
+			for key := range r.URL.Query() {
+				f.query[key] = r.URL.Query().Get(key)
+			}
+			w.Header().Set("Location", server.URL+"/session/1")
+		case r.Method == http.MethodPut && r.URL.Path == "/session/1":
+			contentRange := r.Header.Get("Content-Range")
+			f.ranges = append(f.ranges, contentRange)
+			f.data = append(f.data, body...)
+			if strings.HasSuffix(contentRange, "/*") {
+				w.WriteHeader(http.StatusPermanentRedirect)
+				return
+			}
+			f.finished = true
+		case r.Method == http.MethodDelete && r.URL.Path == "/session/1":
+			f.aborted = true
+			w.WriteHeader(499)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("STORAGE_EMULATOR_HOST", server.URL)
+	t.Setenv("GOOGLE_OAUTH_ACCESS_TOKEN", "")
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+	return f
+}
+
+func TestGCSWriter(t *testing.T) {
+	fake := newFakeGCS(t)
+
+	loc := &Location{Scheme: SchemeGCS, Bucket: "audit", Key: "nightly/dump.csv.gz"}
+	w, err := NewWriter(context.Background(), loc, Options{
+		ContentType: "application/gzip",
+		KMSKeyID:    "projects/p/locations/global/keyRings/r/cryptoKeys/k",
+		PartSize:    gcsChunkAlign,
+	})
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+
+	data := bytes.Repeat([]byte("x"), 2*gcsChunkAlign+10)
+	if _, err := w.Write(data); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if fake.query["name"] != "nightly/dump.csv.gz" || fake.query["uploadType"] != "resumable" {
+		t.Errorf("upload query = %v", fake.query)
+	}
+	if fake.query["kmsKeyName"] == "" {
+		t.Error("upload did not request the Cloud KMS key")
+	}
+	want := []string{
+		fmt.Sprintf("bytes 0-%d/*", gcsChunkAlign-1),
+		fmt.Sprintf("bytes %d-%d/*", gcsChunkAlign, 2*gcsChunkAlign-1),
+		fmt.Sprintf("bytes %d-%d/%d", 2*gcsChunkAlign, len(data)-1, len(data)),
+	}
+	if strings.Join(fake.ranges, ",") != strings.Join(want, ",") {
+		t.Errorf("Content-Range = %q, want %q", fake.ranges, want)
+	}
+	if !fake.finished || !bytes.Equal(fake.data, data) {
+		t.Error("uploaded data does not match the data written")
+	}
+}
+
+func TestGCSWriter_Abort(t *testing.T) {
+	fake := newFakeGCS(t)
+
+	w, err := NewWriter(context.Background(), &Location{Scheme: SchemeGCS, Bucket: "audit", Key: "k"}, Options{})
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	if err := w.Abort(); err != nil {
+		t.Fatalf("Abort() error = %v", err)
+	}
+	if !fake.aborted || fake.finished {
+		t.Errorf("aborted = %v, finished = %v; want aborted only", fake.aborted, fake.finished)
+	}
+}
+
+func TestGCSWriter_RejectsSSE(t *testing.T) {
+	newFakeGCS(t)
+	_, err := NewWriter(context.Background(), &Location{Scheme: SchemeGCS, Bucket: "audit", Key: "k"}, Options{SSE: "AES256"})
+	if err == nil {
+		t.Error("NewWriter() with S3 server-side encryption expected error for GCS")
+	}
+}
+
+func TestSignJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	token, err := signJWT(keyPEM, map[string]interface{}{"iss": "exporter@project.iam.gserviceaccount.com"})
+	if err != nil {
+		t.Fatalf("signJWT() error = %v", err)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("signJWT() = %q, want three parts", token)
+	}
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	var claims map[string]string
+	if err := json.Unmarshal(payload, &claims); err != nil || claims["iss"] != "exporter@project.iam.gserviceaccount.com" {
+		t.Errorf("claims = %s", payload)
+	}
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("signature does not verify: %v", err)
+	}
+
+	if _, err := signJWT("not a key", nil); err == nil {
+		t.Error("signJWT() with an invalid key expected error")
+	}
+}
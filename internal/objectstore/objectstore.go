@@ -0,0 +1,223 @@
+// Package objectstore uploads streams to Amazon S3 and Google Cloud
+// Storage without buffering them on disk.
+//
+// A Writer uploads what is written to it in parts of Options.PartSize
+// bytes: an S3 multipart upload, or a GCS resumable upload. Close
+// completes the upload and makes the object visible; Abort discards it, so
+// that a failed export never leaves a partial object behind. Only one
+// part is held in memory at a time.
+//
+// Credentials are taken from the usual places of each provider (see
+// newS3Writer and newGCSWriter); no cloud SDK is required.
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultPartSize is the size of each uploaded part. S3 requires at least
+// 5 MiB and GCS a multiple of 256 KiB; 16 MiB allows objects of up to
+// 160 GB with S3's limit of 10,000 parts.
+const DefaultPartSize = 16 << 20
+
+// maxAttempts is how often a part upload is tried before giving up.
+const maxAttempts = 3
+
+// now returns the signing time. It is a test hook.
+var now = time.Now
+
+// URL schemes of the supported object stores.
+const (
+	SchemeS3  = "s3"
+	SchemeGCS = "gs"
+)
+
+// Location is an object, or with a Key ending in "/" a prefix, in a
+// bucket.
+type Location struct {
+	Scheme string
+	Bucket string
+	Key    string
+}
+
+// IsURL reports whether s is an object storage URL (s3:// or gs://).
+func IsURL(s string) bool {
+	return strings.HasPrefix(s, SchemeS3+"://") || strings.HasPrefix(s, SchemeGCS+"://")
+}
+
+// Parse parses an object storage URL such as s3://bucket/prefix/.
+func Parse(s string) (*Location, error) {
+	scheme, rest, ok := strings.Cut(s, "://")
+	if !ok || (scheme != SchemeS3 && scheme != SchemeGCS) {
+		return nil, fmt.Errorf("invalid object storage URL %q: expected s3://bucket/key or gs://bucket/key", s)
+	}
+	bucket, key, _ := strings.Cut(rest, "/")
+	if bucket == "" {
+		return nil, fmt.Errorf("invalid object storage URL %q: no bucket", s)
+	}
+	return &Location{Scheme: scheme, Bucket: bucket, Key: key}, nil
+}
+
+// IsPrefix reports whether l names a prefix rather than an object.
+func (l *Location) IsPrefix() bool {
+	return l.Key == "" || strings.HasSuffix(l.Key, "/")
+}
+
+// Object returns the object named name under the prefix l. If l names an
+// object, it is returned unchanged.
+func (l *Location) Object(name string) *Location {
+	if !l.IsPrefix() {
+		return l
+	}
+	return &Location{Scheme: l.Scheme, Bucket: l.Bucket, Key: l.Key + name}
+}
+
+// String returns l as a URL.
+func (l *Location) String() string {
+	return l.Scheme + "://" + l.Bucket + "/" + l.Key
+}
+
+// Options configure an upload.
+type Options struct {
+	// ContentType is the media type stored with the object.
+	ContentType string
+
+	// SSE selects S3 server-side encryption, "AES256" or "aws:kms"; empty
+	// uses the bucket's default. GCS always encrypts objects and does not
+	// accept it.
+	SSE string
+
+	// KMSKeyID is the KMS key for SSE "aws:kms" (S3), or the Cloud KMS key
+	// that encrypts the object (GCS).
+	KMSKeyID string
+
+	// PartSize is the size of each uploaded part; DefaultPartSize if zero.
+	PartSize int
+
+	// HTTPClient is used for all requests; http.DefaultClient if nil.
+	HTTPClient *http.Client
+}
+
+// Writer uploads an object as it is written.
+type Writer interface {
+	io.Writer
+
+	// Close uploads the remaining data and completes the upload. The
+	// object is visible only once Close returns without error.
+	Close() error
+
+	// Abort discards the upload. It is safe to call after Close.
+	Abort() error
+}
+
+// NewWriter starts an upload of the object at loc, which must not be a
+// prefix.
+func NewWriter(ctx context.Context, loc *Location, opts Options) (Writer, error) {
+	if loc.IsPrefix() {
+		return nil, fmt.Errorf("%s is a prefix, not an object", loc)
+	}
+	if opts.PartSize == 0 {
+		opts.PartSize = DefaultPartSize
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+
+	switch loc.Scheme {
+	case SchemeS3:
+		return newS3Writer(ctx, loc, opts)
+	case SchemeGCS:
+		return newGCSWriter(ctx, loc, opts)
+	}
+	return nil, fmt.Errorf("unsupported object storage scheme %q", loc.Scheme)
+}
+
+// partWriter buffers writes into parts and hands each full part to
+// upload. It implements Writer for both providers.
+type partWriter struct {
+	buf      []byte
+	partSize int
+	upload   func(part []byte, last bool) error
+	complete func() error
+	abort    func() error
+	err      error
+	done     bool
+}
+
+func (w *partWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	if w.done {
+		return 0, fmt.Errorf("write to closed upload")
+	}
+
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), w.partSize-len(w.buf))
+		w.buf = append(w.buf, p[:n]...)
+		p = p[n:]
+		written += n
+		if len(w.buf) == w.partSize {
+			if w.err = w.upload(w.buf, false); w.err != nil {
+				return written, w.err
+			}
+			w.buf = w.buf[:0]
+		}
+	}
+	return written, nil
+}
+
+func (w *partWriter) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.done {
+		return nil
+	}
+	if w.err = w.upload(w.buf, true); w.err != nil {
+		return w.err
+	}
+	if w.err = w.complete(); w.err != nil {
+		return w.err
+	}
+	w.done = true
+	return nil
+}
+
+func (w *partWriter) Abort() error {
+	if w.done {
+		return nil
+	}
+	w.done = true
+	return w.abort()
+}
+
+// retryable reports whether a failed request should be tried again.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// backoff waits before attempt (counted from 1) of a retried request.
+func backoff(ctx context.Context, attempt int) error {
+	select {
+	case <-time.After(time.Duration(attempt*attempt) * 200 * time.Millisecond):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// responseError describes a failed request from its response.
+func responseError(op string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return fmt.Errorf("%s: HTTP %d: %s", op, resp.StatusCode, strings.TrimSpace(string(body)))
+}
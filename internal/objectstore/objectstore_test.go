@@ -0,0 +1,120 @@
+package objectstore
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		url      string
+		want     Location
+		isPrefix bool
+		wantErr  bool
+	}{
+		{url: "s3://bucket/audit/", want: Location{Scheme: "s3", Bucket: "bucket", Key: "audit/"}, isPrefix: true},
+		{url: "gs://bucket/audit/2026.json.gz", want: Location{Scheme: "gs", Bucket: "bucket", Key: "audit/2026.json.gz"}},
+		{url: "s3://bucket", want: Location{Scheme: "s3", Bucket: "bucket"}, isPrefix: true},
+		{url: "s3:///key", wantErr: true},
+		{url: "ftp://bucket/key", wantErr: true},
+		{url: "audit.json", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, err := Parse(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if *got != tt.want {
+				t.Errorf("Parse() = %+v, want %+v", *got, tt.want)
+			}
+			if got.IsPrefix() != tt.isPrefix {
+				t.Errorf("IsPrefix() = %v, want %v", got.IsPrefix(), tt.isPrefix)
+			}
+		})
+	}
+}
+
+func TestLocationObject(t *testing.T) {
+	prefix := &Location{Scheme: "s3", Bucket: "b", Key: "audit/"}
+	if got := prefix.Object("dump.json.gz").String(); got != "s3://b/audit/dump.json.gz" {
+		t.Errorf("Object() = %s", got)
+	}
+	object := &Location{Scheme: "s3", Bucket: "b", Key: "audit/fixed.json"}
+	if got := object.Object("dump.json.gz"); got != object {
+		t.Errorf("Object() of an object = %s, want it unchanged", got)
+	}
+}
+
+func TestIsURL(t *testing.T) {
+	for url, want := range map[string]bool{
+		"s3://bucket/key": true,
+		"gs://bucket/":    true,
+		"./s3/key":        false,
+		"audit.json":      false,
+	} {
+		if got := IsURL(url); got != want {
+			t.Errorf("IsURL(%q) = %v, want %v", url, got, want)
+		}
+	}
+}
+
+func TestPartWriter(t *testing.T) {
+	var parts []string
+	var lastSeen, completed, aborted bool
+	w := &partWriter{
+		partSize: 4,
+		upload: func(part []byte, last bool) error {
+			parts = append(parts, string(part))
+			lastSeen = last
+			return nil
+		},
+		complete: func() error { completed = true; return nil },
+		abort:    func() error { aborted = true; return nil },
+	}
+
+	if _, err := w.Write([]byte("abcdef")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("ghij")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) != 3 || parts[0] != "abcd" || parts[1] != "efgh" || parts[2] != "ij" || !lastSeen {
+		t.Errorf("parts = %q (last %v), want abcd, efgh, ij (last)", parts, lastSeen)
+	}
+	if !completed {
+		t.Error("Close() did not complete the upload")
+	}
+	if err := w.Abort(); err != nil || aborted {
+		t.Errorf("Abort() after Close() = %v, aborted %v; want no-op", err, aborted)
+	}
+}
+
+func TestPartWriter_UploadError(t *testing.T) {
+	failed := errors.New("boom")
+	var aborted bool
+	w := &partWriter{
+		partSize: 2,
+		upload:   func([]byte, bool) error { return failed },
+		complete: func() error { t.Error("complete called after failure"); return nil },
+		abort:    func() error { aborted = true; return nil },
+	}
+
+	if _, err := w.Write(bytes.Repeat([]byte("x"), 3)); !errors.Is(err, failed) {
+		t.Errorf("Write() error = %v, want %v", err, failed)
+	}
+	if err := w.Close(); !errors.Is(err, failed) {
+		t.Errorf("Close() error = %v, want %v", err, failed)
+	}
+	if err := w.Abort(); err != nil || !aborted {
+		t.Errorf("Abort() = %v, aborted %v", err, aborted)
+	}
+}
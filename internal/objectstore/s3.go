@@ -0,0 +1,226 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/s3check"
+)
+
+// s3Writer uploads an object with the S3 multipart upload API.
+type s3Writer struct {
+	ctx      context.Context
+	client   *http.Client
+	creds    s3check.Credentials
+	region   string
+	endpoint *url.URL
+	// pathStyle puts the bucket in the path instead of the host name, as
+	// S3-compatible stores reached through AWS_ENDPOINT_URL usually need.
+	pathStyle bool
+	loc       *Location
+	uploadID  string
+	parts     []completedPart
+}
+
+// completedPart is one part of a CompleteMultipartUpload request.
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// newS3Writer starts a multipart upload to loc.
+//
+// Credentials come from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// AWS_SESSION_TOKEN, the shared credentials file (AWS_PROFILE), or the
+// EC2 instance role. The region is AWS_REGION or AWS_DEFAULT_REGION
+// (default us-east-1); AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL selects an
+// S3-compatible service.
+func newS3Writer(ctx context.Context, loc *Location, opts Options) (Writer, error) {
+	if opts.PartSize < 5<<20 {
+		return nil, fmt.Errorf("S3 part size must be at least 5 MiB")
+	}
+	switch opts.SSE {
+	case "", "AES256", "aws:kms":
+	default:
+		return nil, fmt.Errorf("unsupported S3 server-side encryption %q (use AES256 or aws:kms)", opts.SSE)
+	}
+	if opts.KMSKeyID != "" && opts.SSE != "aws:kms" {
+		return nil, fmt.Errorf("a KMS key requires server-side encryption aws:kms")
+	}
+
+	creds, err := s3check.DefaultProvider("").Retrieve(ctx)
+	if errors.Is(err, s3check.ErrNoCredentials) {
+		return nil, errors.New("no AWS credentials found: set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY, " +
+			"configure ~/.aws/credentials, or run on an EC2 instance with an instance role")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	w := &s3Writer{
+		ctx:    ctx,
+		client: opts.HTTPClient,
+		creds:  creds,
+		region: firstEnv("AWS_REGION", "AWS_DEFAULT_REGION"),
+		loc:    loc,
+	}
+	if w.region == "" {
+		w.region = "us-east-1"
+	}
+	if endpoint := firstEnv("AWS_ENDPOINT_URL_S3", "AWS_ENDPOINT_URL"); endpoint != "" {
+		if w.endpoint, err = url.Parse(endpoint); err != nil {
+			return nil, fmt.Errorf("invalid S3 endpoint %q: %w", endpoint, err)
+		}
+		w.pathStyle = true
+	} else {
+		w.endpoint = &url.URL{Scheme: "https", Host: fmt.Sprintf("%s.s3.%s.amazonaws.com", loc.Bucket, w.region)}
+	}
+
+	header := http.Header{}
+	if opts.ContentType != "" {
+		header.Set("Content-Type", opts.ContentType)
+	}
+	if opts.SSE != "" {
+		header.Set("X-Amz-Server-Side-Encryption", opts.SSE)
+	}
+	if opts.KMSKeyID != "" {
+		header.Set("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id", opts.KMSKeyID)
+	}
+
+	resp, err := w.do(http.MethodPost, url.Values{"uploads": {""}}, header, nil)
+	if err != nil {
+		return nil, fmt.Errorf("start upload to %s: %w", loc, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError("start upload to "+loc.String(), resp)
+	}
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil || result.UploadID == "" {
+		return nil, fmt.Errorf("start upload to %s: no upload ID in response", loc)
+	}
+	w.uploadID = result.UploadID
+
+	return &partWriter{
+		partSize: opts.PartSize,
+		upload:   w.uploadPart,
+		complete: w.complete,
+		abort:    w.abort,
+	}, nil
+}
+
+// uploadPart uploads the next part.
+func (w *s3Writer) uploadPart(part []byte, last bool) error {
+	// A final empty part is only needed for an empty object
+	if last && len(part) == 0 && len(w.parts) > 0 {
+		return nil
+	}
+
+	number := len(w.parts) + 1
+	query := url.Values{"partNumber": {strconv.Itoa(number)}, "uploadId": {w.uploadID}}
+	op := fmt.Sprintf("upload part %d of %s", number, w.loc)
+
+	for attempt := 1; ; attempt++ {
+		resp, err := w.do(http.MethodPut, query, nil, part)
+		if attempt < maxAttempts && retryable(resp, err) {
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			if err := backoff(w.ctx, attempt); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != http.StatusOK {
+			return responseError(op, resp)
+		}
+		w.parts = append(w.parts, completedPart{PartNumber: number, ETag: resp.Header.Get("ETag")})
+		return nil
+	}
+}
+
+// complete completes the multipart upload.
+func (w *s3Writer) complete() error {
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{Parts: w.parts})
+	if err != nil {
+		return fmt.Errorf("complete upload to %s: %w", w.loc, err)
+	}
+
+	resp, err := w.do(http.MethodPost, url.Values{"uploadId": {w.uploadID}}, http.Header{"Content-Type": {"application/xml"}}, body)
+	if err != nil {
+		return fmt.Errorf("complete upload to %s: %w", w.loc, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// S3 may report an error with status 200 once it has started replying
+	data, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || bytes.Contains(data, []byte("<Error>")) {
+		return fmt.Errorf("complete upload to %s: HTTP %d: %s", w.loc, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return nil
+}
+
+// abort aborts the multipart upload, discarding the uploaded parts.
+func (w *s3Writer) abort() error {
+	// The upload's context may already be cancelled
+	w.ctx = context.WithoutCancel(w.ctx)
+	resp, err := w.do(http.MethodDelete, url.Values{"uploadId": {w.uploadID}}, nil, nil)
+	if err != nil {
+		return fmt.Errorf("abort upload to %s: %w", w.loc, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return responseError("abort upload to "+w.loc.String(), resp)
+	}
+	return nil
+}
+
+// do sends a signed request for the object.
+func (w *s3Writer) do(method string, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	u := *w.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + w.loc.Key
+	if w.pathStyle {
+		u.Path = strings.TrimSuffix(w.endpoint.Path, "/") + "/" + w.loc.Bucket + "/" + w.loc.Key
+	}
+	// Encode the path the way it is signed
+	u.RawPath = s3check.CanonicalPath(u.Path)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(w.ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	s3check.Sign(req, body, w.creds, w.region, "s3", now())
+	return w.client.Do(req)
+}
+
+// firstEnv returns the first non-empty environment variable of names.
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,144 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeS3 is an S3 multipart upload API for tests.
+type fakeS3 struct {
+	mu        sync.Mutex
+	createHdr http.Header
+	parts     map[string][]byte
+	completed []byte
+	aborted   bool
+	paths     map[string]bool
+}
+
+func newFakeS3(t *testing.T) *fakeS3 {
+	t.Helper()
+	f := &fakeS3{parts: map[string][]byte{}, paths: map[string]bool{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+			t.Errorf("%s %s: Authorization = %q", r.Method, r.URL, r.Header.Get("Authorization"))
+		}
+		f.paths[r.URL.EscapedPath()] = true
+		body, _ := io.ReadAll(r.Body)
+		query := r.URL.Query()
+		switch {
+		case r.Method == http.MethodPost && query.Has("uploads"):
+			f.createHdr = r.Header.Clone()
+			_, _ = io.WriteString(w, `<InitiateMultipartUploadResult><UploadId>upload-1</UploadId></InitiateMultipartUploadResult>`)
+		case r.Method == http.MethodPut && query.Get("uploadId") == "upload-1":
+			f.parts[query.Get("partNumber")] = body
+			w.Header().Set("ETag", `"etag-`+query.Get("partNumber")+`"`)
+		case r.Method == http.MethodPost && query.Get("uploadId") == "upload-1":
+			f.completed = body
+			_, _ = io.WriteString(w, `<CompleteMultipartUploadResult/>`)
+		case r.Method == http.MethodDelete:
+			f.aborted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_ENDPOINT_URL", server.URL)
+	return f
+}
+
+func TestS3Writer(t *testing.T) {
+	fake := newFakeS3(t)
+
+	loc := &Location{Scheme: SchemeS3, Bucket: "audit", Key: "nightly/dump 2026-10-16T00:00:00Z.json.gz"}
+	w, err := NewWriter(context.Background(), loc, Options{
+		ContentType: "application/gzip",
+		SSE:         "aws:kms",
+		KMSKeyID:    "alias/audit",
+		PartSize:    5 << 20,
+	})
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+
+	data := bytes.Repeat([]byte("0123456789"), 1<<20+1) // just over 10 MiB
+	if _, err := w.Write(data); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if got := fake.createHdr.Get("X-Amz-Server-Side-Encryption"); got != "aws:kms" {
+		t.Errorf("server-side encryption = %q, want aws:kms", got)
+	}
+	if got := fake.createHdr.Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"); got != "alias/audit" {
+		t.Errorf("KMS key = %q, want alias/audit", got)
+	}
+	if !fake.paths["/audit/nightly/dump%202026-10-16T00%3A00%3A00Z.json.gz"] {
+		t.Errorf("requests used paths %v, want the encoded object key in path style", fake.paths)
+	}
+
+	if len(fake.parts) != 3 {
+		t.Fatalf("uploaded %d parts, want 3", len(fake.parts))
+	}
+	joined := append(append(append([]byte(nil), fake.parts["1"]...), fake.parts["2"]...), fake.parts["3"]...)
+	if !bytes.Equal(joined, data) {
+		t.Error("uploaded parts do not add up to the data written")
+	}
+	var complete struct {
+		Parts []completedPart `xml:"Part"`
+	}
+	if err := xml.Unmarshal(fake.completed, &complete); err != nil {
+		t.Fatalf("complete request %s: %v", fake.completed, err)
+	}
+	if len(complete.Parts) != 3 || complete.Parts[2] != (completedPart{PartNumber: 3, ETag: `"etag-3"`}) {
+		t.Errorf("completed parts = %+v", complete.Parts)
+	}
+}
+
+func TestS3Writer_Abort(t *testing.T) {
+	fake := newFakeS3(t)
+
+	w, err := NewWriter(context.Background(), &Location{Scheme: SchemeS3, Bucket: "b", Key: "k"}, Options{})
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	if _, err := w.Write([]byte("partial")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Abort(); err != nil {
+		t.Fatalf("Abort() error = %v", err)
+	}
+	if !fake.aborted || fake.completed != nil {
+		t.Errorf("aborted = %v, completed = %q; want aborted only", fake.aborted, fake.completed)
+	}
+}
+
+func TestS3Writer_InvalidOptions(t *testing.T) {
+	newFakeS3(t)
+	loc := &Location{Scheme: SchemeS3, Bucket: "b", Key: "k"}
+
+	for _, opts := range []Options{
+		{SSE: "rot13"},
+		{KMSKeyID: "alias/x"},
+		{PartSize: 1 << 20},
+	} {
+		if _, err := NewWriter(context.Background(), loc, opts); err == nil {
+			t.Errorf("NewWriter(%+v) expected error", opts)
+		}
+	}
+}
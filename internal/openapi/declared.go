@@ -0,0 +1,44 @@
+package openapi
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DeclaredTypes returns the names of the types declared in the Go package
+// in dir, excluding test files and the file named generated.
+func DeclaredTypes(dir, generated string) (map[string]bool, error) {
+	fset := token.NewFileSet()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read package directory: %w", err)
+	}
+
+	types := map[string]bool{}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || name == generated || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", name, err)
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, s := range gen.Specs {
+				types[s.(*ast.TypeSpec).Name.Name] = true
+			}
+		}
+	}
+	return types, nil
+}
@@ -0,0 +1,69 @@
+package openapi
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MissingFields returns the properties of the named schema that have no
+// corresponding JSON field in the Go struct type t, in sorted order.
+//
+// Embedded structs are followed, and a field tagged `json:"-"` does not
+// count as covering a property.
+func (s *Spec) MissingFields(name string, t reflect.Type) ([]string, error) {
+	props, err := s.Properties(name)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := JSONFields(t)
+
+	var missing []string
+	for prop, schema := range props {
+		if schema.Deprecated {
+			continue
+		}
+		if !fields[prop] {
+			missing = append(missing, prop)
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// JSONFields returns the set of JSON field names encoded for struct type t.
+func JSONFields(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	fields := map[string]bool{}
+	if t.Kind() != reflect.Struct {
+		return fields
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			for embedded := range JSONFields(f.Type) {
+				fields[embedded] = true
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = true
+	}
+	return fields
+}
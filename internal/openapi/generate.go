@@ -0,0 +1,192 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// GenerateOptions configures Generate.
+type GenerateOptions struct {
+	// Package is the Go package name of the generated file.
+	Package string
+
+	// Skip lists Go type names that are maintained by hand; schemas that
+	// map to these names are not generated.
+	Skip map[string]bool
+
+	// Source is recorded in the generated file header (e.g., the spec path).
+	Source string
+}
+
+// Generate renders Go struct definitions for every object schema in spec
+// that is not listed in opts.Skip.
+func Generate(spec *Spec, opts GenerateOptions) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by gcsgen from %s; DO NOT EDIT.\n\n", opts.Source)
+	fmt.Fprintf(&buf, "package %s\n\n", opts.Package)
+
+	var body bytes.Buffer
+	usesTime := false
+
+	for _, name := range spec.SchemaNames() {
+		typeName := GoName(name)
+		if opts.Skip[typeName] {
+			continue
+		}
+
+		schema := spec.Components.Schemas[name]
+		if schema.Type != "object" && len(schema.Properties) == 0 && len(schema.AllOf) == 0 {
+			continue
+		}
+
+		props, required, err := spec.resolve(name)
+		if err != nil {
+			return nil, err
+		}
+
+		fmt.Fprintf(&body, "// %s is generated from the %s schema.\n", typeName, name)
+		if line := firstLine(schema.Description); line != "" {
+			fmt.Fprintf(&body, "//\n// %s\n", line)
+		}
+		fmt.Fprintf(&body, "type %s struct {\n", typeName)
+
+		for _, propName := range sortedKeys(props) {
+			prop := props[propName]
+			goType := spec.GoType(prop)
+			if strings.Contains(goType, "time.Time") {
+				usesTime = true
+			}
+
+			tag := propName
+			if !required[propName] {
+				tag += ",omitempty"
+			}
+			if prop.Description != "" {
+				fmt.Fprintf(&body, "\t// %s\n", firstLine(prop.Description))
+			}
+			fmt.Fprintf(&body, "\t%s %s `json:%q`\n", GoName(propName), goType, tag)
+		}
+		body.WriteString("}\n\n")
+	}
+
+	if usesTime {
+		buf.WriteString("import \"time\"\n\n")
+	}
+	buf.Write(body.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated source: %w", err)
+	}
+	return src, nil
+}
+
+// GoType returns the Go type used for schema. References to object
+// schemas become pointers to the generated (or hand-written) type;
+// references to scalar schemas use the scalar type directly.
+func (s *Spec) GoType(schema *Schema) string {
+	if schema == nil {
+		return "interface{}"
+	}
+	if len(schema.AllOf) == 1 && schema.AllOf[0].Ref != "" {
+		schema = schema.AllOf[0]
+	}
+	if schema.Ref != "" {
+		name := RefName(schema.Ref)
+		target, ok := s.Components.Schemas[name]
+		if !ok {
+			return "interface{}"
+		}
+		if target.Type == "object" || len(target.Properties) > 0 || len(target.AllOf) > 0 {
+			return "*" + GoName(name)
+		}
+		return s.GoType(target)
+	}
+
+	switch schema.Type {
+	case "string":
+		if schema.Format == "date-time" {
+			return "time.Time"
+		}
+		return "string"
+	case "integer":
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		elem := s.GoType(schema.Items)
+		return "[]" + strings.TrimPrefix(elem, "*")
+	case "object":
+		if len(schema.Properties) == 0 {
+			return "map[string]interface{}"
+		}
+	}
+	return "interface{}"
+}
+
+// initialisms are rendered in upper case by GoName.
+var initialisms = map[string]bool{
+	"API": true, "DN": true, "FQDN": true, "HTTP": true, "HTTPS": true,
+	"ID": true, "IDS": true, "IP": true, "JSON": true, "OIDC": true,
+	"S3": true, "SSL": true, "TLS": true, "URI": true, "URL": true,
+	"URN": true, "UUID": true,
+}
+
+// GoName converts a schema or property name (snake_case, kebab-case, or
+// dotted versions such as "Endpoint_1_2_0") to an exported Go identifier.
+func GoName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-' || r == '.' || r == ' '
+	})
+
+	var b strings.Builder
+	for _, part := range parts {
+		upper := strings.ToUpper(part)
+		switch {
+		case initialisms[upper]:
+			if upper == "IDS" {
+				upper = "IDs"
+			}
+			b.WriteString(upper)
+		default:
+			if part == upper {
+				part = strings.ToLower(part)
+			}
+			r := []rune(part)
+			r[0] = unicode.ToUpper(r[0])
+			b.WriteString(string(r))
+		}
+	}
+
+	out := b.String()
+	if out == "" || unicode.IsDigit([]rune(out)[0]) {
+		out = "X" + out
+	}
+	return out
+}
+
+// firstLine returns the first non-empty line of s, trimmed.
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+func sortedKeys(m map[string]*Schema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
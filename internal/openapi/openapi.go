@@ -0,0 +1,151 @@
+// Package openapi reads the GCS Manager OpenAPI specification and generates
+// Go types from its component schemas.
+//
+// It backs the gcsgen tool ('make generate') and the drift tests in
+// pkg/gcs, which fail when the published spec has properties that the
+// hand-written pkg/gcs types do not.
+package openapi
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Spec is the subset of an OpenAPI 3 document used by the generator.
+type Spec struct {
+	OpenAPI    string     `yaml:"openapi"`
+	Info       SpecInfo   `yaml:"info"`
+	Components Components `yaml:"components"`
+}
+
+// SpecInfo holds the document metadata.
+type SpecInfo struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+// Components holds the reusable schema definitions.
+type Components struct {
+	Schemas map[string]*Schema `yaml:"schemas"`
+}
+
+// Schema is the subset of an OpenAPI schema object used by the generator.
+type Schema struct {
+	Ref                  string             `yaml:"$ref"`
+	Type                 string             `yaml:"type"`
+	Format               string             `yaml:"format"`
+	Description          string             `yaml:"description"`
+	Properties           map[string]*Schema `yaml:"properties"`
+	Required             []string           `yaml:"required"`
+	Items                *Schema            `yaml:"items"`
+	AllOf                []*Schema          `yaml:"allOf"`
+	AdditionalProperties interface{}        `yaml:"additionalProperties"`
+	Nullable             bool               `yaml:"nullable"`
+	Deprecated           bool               `yaml:"deprecated"`
+}
+
+// Load reads an OpenAPI document in JSON or YAML format.
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is supplied by the developer
+	if err != nil {
+		return nil, fmt.Errorf("read spec: %w", err)
+	}
+
+	return Parse(data)
+}
+
+// Parse parses an OpenAPI document in JSON or YAML format.
+func Parse(data []byte) (*Spec, error) {
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parse spec: %w", err)
+	}
+	if spec.Components.Schemas == nil {
+		return nil, fmt.Errorf("parse spec: no components.schemas found")
+	}
+
+	return &spec, nil
+}
+
+// SchemaNames returns the component schema names in sorted order.
+func (s *Spec) SchemaNames() []string {
+	names := make([]string, 0, len(s.Components.Schemas))
+	for name := range s.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Properties returns the properties of the named schema, following allOf
+// composition, keyed by JSON name.
+func (s *Spec) Properties(name string) (map[string]*Schema, error) {
+	props, _, err := s.resolve(name)
+	return props, err
+}
+
+// Required returns the set of required properties of the named schema,
+// following allOf composition.
+func (s *Spec) Required(name string) (map[string]bool, error) {
+	_, required, err := s.resolve(name)
+	return required, err
+}
+
+// resolve collects the properties and required set of the named schema.
+func (s *Spec) resolve(name string) (map[string]*Schema, map[string]bool, error) {
+	schema, ok := s.Components.Schemas[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("schema %q not found", name)
+	}
+
+	props := map[string]*Schema{}
+	required := map[string]bool{}
+	if err := s.collectProperties(schema, props, required, map[string]bool{name: true}); err != nil {
+		return nil, nil, fmt.Errorf("schema %q: %w", name, err)
+	}
+	return props, required, nil
+}
+
+// collectProperties merges the properties and required names of schema
+// (and its allOf parts) into props and required.
+func (s *Spec) collectProperties(schema *Schema, props map[string]*Schema, required, seen map[string]bool) error {
+	if schema.Ref != "" {
+		ref := RefName(schema.Ref)
+		if seen[ref] {
+			return fmt.Errorf("circular allOf reference to %q", ref)
+		}
+		target, ok := s.Components.Schemas[ref]
+		if !ok {
+			return fmt.Errorf("unresolved reference %q", schema.Ref)
+		}
+		seen[ref] = true
+		return s.collectProperties(target, props, required, seen)
+	}
+
+	for _, part := range schema.AllOf {
+		if err := s.collectProperties(part, props, required, seen); err != nil {
+			return err
+		}
+	}
+	for name, prop := range schema.Properties {
+		props[name] = prop
+	}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	return nil
+}
+
+// RefName returns the schema name referenced by a local $ref such as
+// "#/components/schemas/Endpoint".
+func RefName(ref string) string {
+	for i := len(ref) - 1; i >= 0; i-- {
+		if ref[i] == '/' {
+			return ref[i+1:]
+		}
+	}
+	return ref
+}
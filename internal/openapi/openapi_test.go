@@ -0,0 +1,148 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func loadTestSpec(t *testing.T) *Spec {
+	t.Helper()
+
+	spec, err := Load("testdata/spec.yaml")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	return spec
+}
+
+func TestLoad(t *testing.T) {
+	spec := loadTestSpec(t)
+
+	want := []string{"Base", "Status", "Widget", "WidgetPolicies"}
+	if got := spec.SchemaNames(); !reflect.DeepEqual(got, want) {
+		t.Errorf("SchemaNames() = %v, want %v", got, want)
+	}
+}
+
+func TestParse_NoSchemas(t *testing.T) {
+	if _, err := Parse([]byte(`{"openapi": "3.0.3"}`)); err == nil {
+		t.Error("Parse() expected error for spec without schemas, got nil")
+	}
+}
+
+func TestProperties_AllOf(t *testing.T) {
+	spec := loadTestSpec(t)
+
+	props, err := spec.Properties("Widget")
+	if err != nil {
+		t.Fatalf("Properties() error = %v", err)
+	}
+
+	for _, name := range []string{"id", "DATA_TYPE", "display_name", "policies"} {
+		if _, ok := props[name]; !ok {
+			t.Errorf("Properties(Widget) missing %q", name)
+		}
+	}
+
+	if _, err := spec.Properties("Missing"); err == nil {
+		t.Error("Properties() expected error for unknown schema, got nil")
+	}
+}
+
+func TestGoName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"display_name", "DisplayName"},
+		{"storage_gateway_id", "StorageGatewayID"},
+		{"DATA_TYPE", "DataType"},
+		{"owner_ids", "OwnerIDs"},
+		{"https_url", "HTTPSURL"},
+		{"Endpoint_1_2_0", "Endpoint120"},
+		{"2fa", "X2fa"},
+	}
+
+	for _, tt := range tests {
+		if got := GoName(tt.in); got != tt.want {
+			t.Errorf("GoName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	spec := loadTestSpec(t)
+
+	src, err := Generate(spec, GenerateOptions{
+		Package: "gcs",
+		Skip:    map[string]bool{"Base": true},
+		Source:  "testdata/spec.yaml",
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	got := string(src)
+	for _, want := range []string{
+		"// Code generated by gcsgen from testdata/spec.yaml; DO NOT EDIT.",
+		`import "time"`,
+		"type Widget struct {",
+		"DataType string `json:\"DATA_TYPE\"`",
+		"DisplayName string `json:\"display_name,omitempty\"`",
+		"LastModified time.Time `json:\"last_modified,omitempty\"`",
+		"OwnerIDs []string `json:\"owner_ids,omitempty\"`",
+		"Policies *WidgetPolicies `json:\"policies,omitempty\"`",
+		"Status string `json:\"status,omitempty\"`",
+		"Extra map[string]interface{} `json:\"extra,omitempty\"`",
+	} {
+		if !strings.Contains(strings.Join(strings.Fields(got), " "), strings.Join(strings.Fields(want), " ")) {
+			t.Errorf("Generate() output missing %q:\n%s", want, got)
+		}
+	}
+
+	if strings.Contains(got, "type Base struct") {
+		t.Error("Generate() emitted a skipped (hand-written) type")
+	}
+	if strings.Contains(got, "type Status") {
+		t.Error("Generate() emitted a type for a non-object schema")
+	}
+}
+
+func TestMissingFields(t *testing.T) {
+	spec := loadTestSpec(t)
+
+	type base struct {
+		ID string `json:"id"`
+	}
+	type widget struct {
+		base
+		DataType    string `json:"DATA_TYPE"`
+		DisplayName string `json:"display_name,omitempty"`
+		Policies    string `json:"-"`
+	}
+
+	missing, err := spec.MissingFields("Widget", reflect.TypeOf(widget{}))
+	if err != nil {
+		t.Fatalf("MissingFields() error = %v", err)
+	}
+
+	want := []string{"last_modified", "max_concurrency", "owner_ids", "policies", "status"}
+	if !reflect.DeepEqual(missing, want) {
+		t.Errorf("MissingFields() = %v, want %v", missing, want)
+	}
+}
+
+func TestDeclaredTypes(t *testing.T) {
+	types, err := DeclaredTypes(".", "generate.go")
+	if err != nil {
+		t.Fatalf("DeclaredTypes() error = %v", err)
+	}
+
+	if !types["Spec"] || !types["Schema"] {
+		t.Errorf("DeclaredTypes() = %v, want Spec and Schema", types)
+	}
+	if types["GenerateOptions"] {
+		t.Error("DeclaredTypes() included a type from the excluded file")
+	}
+}
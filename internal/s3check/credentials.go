@@ -0,0 +1,281 @@
+// Package s3check validates S3 storage gateway settings before they are
+// sent to the endpoint.
+//
+// GCS only reports a misconfigured S3 bucket when a transfer first
+// touches it, which may be hours after the gateway was created. This
+// package lists the bucket directly with AWS credentials from a pluggable
+// Provider so typos in bucket names, regions, and keys surface at create
+// time. Requests are signed with AWS Signature Version 4 using only the
+// standard library; Sign and the credential providers are also used to
+// upload audit exports to S3.
+package s3check
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Credentials are AWS credentials used to sign requests.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// Source describes where the credentials came from, for messages.
+	Source string
+}
+
+// Provider supplies AWS credentials.
+type Provider interface {
+	Retrieve(ctx context.Context) (Credentials, error)
+}
+
+// ErrNoCredentials is returned by providers that find no credentials.
+var ErrNoCredentials = errors.New("no AWS credentials found")
+
+// StaticProvider returns fixed credentials, such as keys given on the
+// command line.
+type StaticProvider Credentials
+
+// Retrieve returns the static credentials.
+func (p StaticProvider) Retrieve(_ context.Context) (Credentials, error) {
+	if p.AccessKeyID == "" || p.SecretAccessKey == "" {
+		return Credentials{}, ErrNoCredentials
+	}
+	creds := Credentials(p)
+	if creds.Source == "" {
+		creds.Source = "static"
+	}
+	return creds, nil
+}
+
+// EnvProvider reads AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, and
+// AWS_SESSION_TOKEN.
+type EnvProvider struct {
+	// Getenv looks up environment variables; os.Getenv if nil.
+	Getenv func(string) string
+}
+
+// Retrieve returns credentials from the environment.
+func (p EnvProvider) Retrieve(_ context.Context) (Credentials, error) {
+	getenv := p.Getenv
+	if getenv == nil {
+		getenv = os.Getenv
+	}
+	return StaticProvider{
+		AccessKeyID:     getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    getenv("AWS_SESSION_TOKEN"),
+		Source:          "environment",
+	}.Retrieve(context.Background())
+}
+
+// ProfileProvider reads a profile from the AWS shared credentials file.
+type ProfileProvider struct {
+	// Profile is the profile name; "default" if empty.
+	Profile string
+
+	// Path is the credentials file; AWS_SHARED_CREDENTIALS_FILE or
+	// ~/.aws/credentials if empty.
+	Path string
+}
+
+// Retrieve returns the profile's credentials.
+func (p ProfileProvider) Retrieve(_ context.Context) (Credentials, error) {
+	profile := p.Profile
+	if profile == "" {
+		profile = "default"
+	}
+
+	path := p.Path
+	if path == "" {
+		path = os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	}
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return Credentials{}, fmt.Errorf("find AWS credentials file: %w", err)
+		}
+		path = filepath.Join(home, ".aws", "credentials")
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Credentials{}, ErrNoCredentials
+	}
+	if err != nil {
+		return Credentials{}, fmt.Errorf("read AWS credentials file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	values, found, err := readINISection(file, profile)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("read AWS credentials file %s: %w", path, err)
+	}
+	if !found {
+		return Credentials{}, fmt.Errorf("%w: profile %q not in %s", ErrNoCredentials, profile, path)
+	}
+
+	return StaticProvider{
+		AccessKeyID:     values["aws_access_key_id"],
+		SecretAccessKey: values["aws_secret_access_key"],
+		SessionToken:    values["aws_session_token"],
+		Source:          fmt.Sprintf("profile %s", profile),
+	}.Retrieve(context.Background())
+}
+
+// readINISection returns the key/value pairs of one [section] of an INI
+// file.
+func readINISection(r io.Reader, section string) (map[string]string, bool, error) {
+	values := map[string]string{}
+	found, inSection := false, false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			inSection = strings.TrimSpace(line[1:len(line)-1]) == section
+			found = found || inSection
+			continue
+		}
+		if !inSection {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, "="); ok {
+			values[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+		}
+	}
+	return values, found, scanner.Err()
+}
+
+// InstanceRoleProvider reads the credentials of the IAM role attached to
+// the EC2 instance from the instance metadata service (IMDSv2).
+type InstanceRoleProvider struct {
+	// Client is used for metadata requests; a client with a short timeout
+	// if nil.
+	Client *http.Client
+
+	// BaseURL is the metadata service URL; http://169.254.169.254 if empty.
+	BaseURL string
+}
+
+// Retrieve returns the instance role's temporary credentials.
+func (p InstanceRoleProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	client := p.Client
+	if client == nil {
+		client = &http.Client{Timeout: 2 * time.Second}
+	}
+	base := strings.TrimSuffix(p.BaseURL, "/")
+	if base == "" {
+		base = "http://169.254.169.254"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, base+"/latest/api/token", nil)
+	if err != nil {
+		return Credentials{}, err
+	}
+	req.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", "60")
+	token, err := metadataGet(client, req)
+	if err != nil {
+		// Not running on EC2 (or metadata disabled).
+		return Credentials{}, ErrNoCredentials
+	}
+
+	get := func(path string) (string, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+path, nil)
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("X-aws-ec2-metadata-token", token)
+		return metadataGet(client, req)
+	}
+
+	role, err := get("/latest/meta-data/iam/security-credentials/")
+	if err != nil || role == "" {
+		return Credentials{}, ErrNoCredentials
+	}
+	role = strings.TrimSpace(strings.SplitN(role, "\n", 2)[0])
+
+	body, err := get("/latest/meta-data/iam/security-credentials/" + role)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("get instance role credentials: %w", err)
+	}
+	var doc struct {
+		AccessKeyID     string `json:"AccessKeyId"`
+		SecretAccessKey string `json:"SecretAccessKey"`
+		Token           string `json:"Token"`
+	}
+	if err := json.Unmarshal([]byte(body), &doc); err != nil {
+		return Credentials{}, fmt.Errorf("decode instance role credentials: %w", err)
+	}
+
+	return StaticProvider{
+		AccessKeyID:     doc.AccessKeyID,
+		SecretAccessKey: doc.SecretAccessKey,
+		SessionToken:    doc.Token,
+		Source:          fmt.Sprintf("instance role %s", role),
+	}.Retrieve(ctx)
+}
+
+// metadataGet performs a metadata service request and returns its body.
+func metadataGet(client *http.Client, req *http.Request) (string, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("metadata service returned HTTP %d", resp.StatusCode)
+	}
+	return string(body), nil
+}
+
+// ChainProvider tries providers in order and returns the first
+// credentials found. Providers returning ErrNoCredentials are skipped;
+// any other error stops the chain.
+type ChainProvider []Provider
+
+// Retrieve returns the first credentials found.
+func (c ChainProvider) Retrieve(ctx context.Context) (Credentials, error) {
+	for _, p := range c {
+		creds, err := p.Retrieve(ctx)
+		if err == nil {
+			return creds, nil
+		}
+		if !errors.Is(err, ErrNoCredentials) {
+			return Credentials{}, err
+		}
+	}
+	return Credentials{}, ErrNoCredentials
+}
+
+// DefaultProvider returns the ambient credential chain: the environment,
+// the AWS_PROFILE (or default) shared credentials profile, and the EC2
+// instance role. If profile is set, only that profile is used.
+func DefaultProvider(profile string) Provider {
+	if profile != "" {
+		return ProfileProvider{Profile: profile}
+	}
+	return ChainProvider{
+		EnvProvider{},
+		ProfileProvider{Profile: os.Getenv("AWS_PROFILE")},
+		InstanceRoleProvider{},
+	}
+}
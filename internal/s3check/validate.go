@@ -0,0 +1,154 @@
+package s3check
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultEndpoint is the AWS S3 endpoint used when a gateway does not set
+// one.
+const DefaultEndpoint = "https://s3.amazonaws.com"
+
+// DefaultRegion is the region assumed when none is given or implied by
+// the endpoint.
+const DefaultRegion = "us-east-1"
+
+// Bucket identifies a bucket to validate.
+type Bucket struct {
+	// Endpoint is the S3 endpoint URL; DefaultEndpoint if empty.
+	Endpoint string
+
+	// Region is the signing region; derived from Endpoint if empty.
+	Region string
+
+	Name string
+}
+
+// BucketError is returned when S3 rejects the bucket listing.
+type BucketError struct {
+	Bucket     string
+	StatusCode int
+	Code       string
+	Message    string
+
+	// Region is the bucket's actual region when S3 reports a mismatch.
+	Region string
+}
+
+// Error describes the failure with a hint for common misconfigurations.
+func (e *BucketError) Error() string {
+	switch {
+	case e.Code == "NoSuchBucket" || (e.StatusCode == http.StatusNotFound && e.Code == ""):
+		return fmt.Sprintf("bucket %q does not exist (check the bucket name)", e.Bucket)
+	case e.Region != "":
+		return fmt.Sprintf("bucket %q is in region %s (check the region or endpoint)", e.Bucket, e.Region)
+	case e.Code == "InvalidAccessKeyId" || e.Code == "SignatureDoesNotMatch":
+		return fmt.Sprintf("bucket %q: credentials rejected (%s)", e.Bucket, e.Code)
+	case e.Code == "AccessDenied":
+		return fmt.Sprintf("bucket %q: access denied (check the IAM role or key permissions for s3:ListBucket)", e.Bucket)
+	}
+	msg := fmt.Sprintf("bucket %q: HTTP %d", e.Bucket, e.StatusCode)
+	if e.Code != "" {
+		msg += " " + e.Code
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// ValidateBucket checks that creds can list bucket by requesting at most
+// one key. A nil client uses http.DefaultClient.
+func ValidateBucket(ctx context.Context, client *http.Client, bucket Bucket, creds Credentials) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if bucket.Name == "" {
+		return fmt.Errorf("bucket name is required")
+	}
+
+	endpoint := bucket.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	base, err := url.Parse(endpoint)
+	if err != nil || base.Host == "" {
+		return fmt.Errorf("invalid S3 endpoint %q", endpoint)
+	}
+	region := bucket.Region
+	if region == "" {
+		region = RegionFromEndpoint(base.Host)
+	}
+
+	target := *base
+	target.Path = strings.TrimSuffix(base.Path, "/") + "/" + bucket.Name
+	target.RawQuery = "list-type=2&max-keys=1"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return err
+	}
+	Sign(req, nil, creds, region, "s3", time.Now())
+
+	// Region mismatches come back as redirects, which must be reported
+	// rather than followed.
+	noRedirect := *client
+	noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	resp, err := noRedirect.Do(req)
+	if err != nil {
+		return fmt.Errorf("list bucket %q: %w", bucket.Name, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	var doc struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+		Region  string `xml:"Region"`
+	}
+	_ = xml.Unmarshal(body, &doc)
+
+	bucketErr := &BucketError{Bucket: bucket.Name, StatusCode: resp.StatusCode, Code: doc.Code, Message: doc.Message}
+	actual := resp.Header.Get("X-Amz-Bucket-Region")
+	if actual == "" {
+		actual = doc.Region
+	}
+	if actual != "" && actual != region {
+		bucketErr.Region = actual
+	}
+	return bucketErr
+}
+
+// RegionFromEndpoint derives the signing region from an AWS S3 host name
+// such as s3.us-west-2.amazonaws.com or s3-us-west-2.amazonaws.com,
+// returning DefaultRegion for other hosts.
+func RegionFromEndpoint(host string) string {
+	host = strings.ToLower(host)
+	if h, _, ok := strings.Cut(host, ":"); ok {
+		host = h
+	}
+	if !strings.HasSuffix(host, ".amazonaws.com") {
+		return DefaultRegion
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(host, ".amazonaws.com"), ".") {
+		label = strings.TrimPrefix(label, "s3-")
+		if label == "s3" || label == "dualstack" || label == "s3-external-1" {
+			continue
+		}
+		if strings.Count(label, "-") >= 2 {
+			return label
+		}
+	}
+	return DefaultRegion
+}
@@ -0,0 +1,43 @@
+// Command gcsapigen generates the gcs.GCSAPI interface and the
+// gcstest.Client mock from the exported methods of *gcs.Client. Run it
+// via 'make generate-api' or 'go generate ./pkg/gcs/gcstest' after adding
+// or changing a client method.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/apigen"
+)
+
+func main() {
+	var dir string
+	flag.StringVar(&dir, "dir", "pkg/gcs", "Directory of the gcs package")
+	flag.Parse()
+
+	if err := run(dir); err != nil {
+		fmt.Fprintf(os.Stderr, "gcsapigen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir string) error {
+	api, mock, err := apigen.Generate(dir)
+	if err != nil {
+		return err
+	}
+
+	outputs := map[string][]byte{
+		filepath.Join(dir, apigen.APIFile):             api,
+		filepath.Join(dir, "gcstest", apigen.MockFile): mock,
+	}
+	for path, src := range outputs {
+		if err := os.WriteFile(path, src, 0600); err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+	}
+	return nil
+}
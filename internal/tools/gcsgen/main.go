@@ -0,0 +1,63 @@
+// Command gcsgen generates pkg/gcs types from the GCS Manager OpenAPI spec.
+//
+// Types that are already declared by hand in the target package are left
+// alone; their coverage of the spec is checked by the drift tests in
+// pkg/gcs instead. Run it via 'make generate' or 'go generate ./pkg/gcs'.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/openapi"
+)
+
+func main() {
+	var (
+		specPath string
+		outPath  string
+		pkgName  string
+	)
+
+	flag.StringVar(&specPath, "spec", "", "Path to the GCS Manager OpenAPI spec (JSON or YAML)")
+	flag.StringVar(&outPath, "out", "zz_generated_types.go", "Output file")
+	flag.StringVar(&pkgName, "package", "gcs", "Go package name of the output file")
+	flag.Parse()
+
+	if err := run(specPath, outPath, pkgName); err != nil {
+		fmt.Fprintf(os.Stderr, "gcsgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, outPath, pkgName string) error {
+	if specPath == "" {
+		return fmt.Errorf("-spec is required")
+	}
+
+	spec, err := openapi.Load(specPath)
+	if err != nil {
+		return fmt.Errorf("%w (run 'make fetch-openapi' first)", err)
+	}
+
+	skip, err := openapi.DeclaredTypes(filepath.Dir(outPath), filepath.Base(outPath))
+	if err != nil {
+		return err
+	}
+
+	src, err := openapi.Generate(spec, openapi.GenerateOptions{
+		Package: pkgName,
+		Skip:    skip,
+		Source:  filepath.ToSlash(specPath),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(outPath, src, 0600); err != nil {
+		return fmt.Errorf("write %s: %w", outPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Principal types of access rules.
+const (
+	PrincipalIdentity              = "identity"
+	PrincipalGroup                 = "group"
+	PrincipalAllAuthenticatedUsers = "all_authenticated_users"
+	PrincipalAnonymous             = "anonymous"
+)
+
+// Access rule permissions.
+const (
+	PermissionRead      = "r"
+	PermissionReadWrite = "rw"
+)
+
+// AccessRule grants a principal access to a path of a guest collection.
+// Guest collection permissions are kept by the Transfer API rather than
+// the GCS Manager API.
+type AccessRule struct {
+	DataType string `json:"DATA_TYPE"`
+	ID       string `json:"id,omitempty"`
+
+	// PrincipalType is one of the Principal constants. Principal is the
+	// identity or group ID, and empty for the other types.
+	PrincipalType string `json:"principal_type"`
+	Principal     string `json:"principal"`
+
+	// Path is the directory the rule applies to, ending in "/".
+	Path string `json:"path"`
+
+	// Permissions is PermissionRead or PermissionReadWrite.
+	Permissions string `json:"permissions"`
+
+	// NotifyEmail, if set when the rule is created, is sent an
+	// invitation to the shared path.
+	NotifyEmail string `json:"notify_email,omitempty"`
+
+	RoleID     string `json:"role_id,omitempty"`
+	CreateTime string `json:"create_time,omitempty"`
+}
+
+// ListAccessRules returns the access rules of a guest collection.
+func (c *Client) ListAccessRules(ctx context.Context, collectionID string) ([]AccessRule, error) {
+	var list struct {
+		Data []AccessRule `json:"DATA"`
+	}
+	if err := c.do(ctx, http.MethodGet, "endpoint/"+url.PathEscape(collectionID)+"/access_list", nil, nil, &list); err != nil {
+		return nil, fmt.Errorf("list access rules: %w", err)
+	}
+	return list.Data, nil
+}
+
+// CreateAccessRule adds an access rule to a guest collection and returns
+// its ID. The rule's DataType is filled in if empty.
+func (c *Client) CreateAccessRule(ctx context.Context, collectionID string, rule *AccessRule) (string, error) {
+	if rule.DataType == "" {
+		rule.DataType = "access"
+	}
+	var resp struct {
+		AccessID string `json:"access_id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "endpoint/"+url.PathEscape(collectionID)+"/access", nil, rule, &resp); err != nil {
+		return "", fmt.Errorf("create access rule: %w", err)
+	}
+	return resp.AccessID, nil
+}
+
+// DeleteAccessRule removes an access rule from a guest collection.
+func (c *Client) DeleteAccessRule(ctx context.Context, collectionID, ruleID string) error {
+	path := "endpoint/" + url.PathEscape(collectionID) + "/access/" + url.PathEscape(ruleID)
+	if err := c.do(ctx, http.MethodDelete, path, nil, nil, nil); err != nil {
+		return fmt.Errorf("delete access rule: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package transfer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+)
+
+func TestAccessRules(t *testing.T) {
+	var created map[string]any
+	var deleted string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/endpoint/col-1/access_list":
+			_, _ = w.Write([]byte(`{"DATA_TYPE": "access_list", "DATA": [
+				{"DATA_TYPE": "access", "id": "rule-1", "principal_type": "identity", "principal": "id-1", "path": "/shared/", "permissions": "rw"}
+			]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/endpoint/col-1/access":
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Errorf("decode rule: %v", err)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"code": "Created", "access_id": "rule-2"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/endpoint/col-1/access/rule-1":
+			deleted = "rule-1"
+			_, _ = w.Write([]byte(`{"code": "Deleted"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server)
+	ctx := context.Background()
+
+	rules, err := client.ListAccessRules(ctx, "col-1")
+	if err != nil {
+		t.Fatalf("ListAccessRules() error = %v", err)
+	}
+	if len(rules) != 1 || rules[0].ID != "rule-1" || rules[0].Permissions != PermissionReadWrite {
+		t.Errorf("rules = %+v, want rule-1 with rw", rules)
+	}
+
+	id, err := client.CreateAccessRule(ctx, "col-1", &AccessRule{PrincipalType: PrincipalAllAuthenticatedUsers, Path: "/pub/", Permissions: PermissionRead})
+	if err != nil {
+		t.Fatalf("CreateAccessRule() error = %v", err)
+	}
+	if id != "rule-2" {
+		t.Errorf("CreateAccessRule() = %q, want rule-2", id)
+	}
+	if created["DATA_TYPE"] != "access" || created["principal"] != "" {
+		t.Errorf("created rule = %v, want DATA_TYPE access and an empty principal", created)
+	}
+
+	if err := client.DeleteAccessRule(ctx, "col-1", "rule-1"); err != nil {
+		t.Fatalf("DeleteAccessRule() error = %v", err)
+	}
+	if deleted != "rule-1" {
+		t.Error("DeleteAccessRule() did not delete rule-1")
+	}
+}
+
+func TestAccessRules_DryRun(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if got := r.Header.Get("X-Globus-Annotation-Ticket"); got != "CHG1" {
+			t.Errorf("annotation header = %q, want CHG1", got)
+		}
+		_, _ = w.Write([]byte(`{"DATA": []}`))
+	}))
+	defer server.Close()
+
+	var skipped []gcs.DryRunRequest
+	client := NewClient(server.URL+"/", "test-token",
+		WithHeader("X-Globus-Annotation-Ticket", "CHG1"),
+		WithDryRun(func(req gcs.DryRunRequest) { skipped = append(skipped, req) }))
+	ctx := context.Background()
+
+	if _, err := client.ListAccessRules(ctx, "col-1"); err != nil {
+		t.Fatalf("ListAccessRules() error = %v", err)
+	}
+	if _, err := client.CreateAccessRule(ctx, "col-1", &AccessRule{PrincipalType: "anonymous", Path: "/", Permissions: "r"}); !errors.Is(err, gcs.ErrDryRun) {
+		t.Errorf("CreateAccessRule() error = %v, want ErrDryRun", err)
+	}
+	if err := client.DeleteAccessRule(ctx, "col-1", "rule-1"); !errors.Is(err, gcs.ErrDryRun) {
+		t.Errorf("DeleteAccessRule() error = %v, want ErrDryRun", err)
+	}
+
+	if len(requests) != 1 || requests[0] != "GET /endpoint/col-1/access_list" {
+		t.Errorf("requests sent = %q, want only the list", requests)
+	}
+	if len(skipped) != 2 || skipped[0].Method != http.MethodPost || skipped[0].Path != "/endpoint/col-1/access" ||
+		!strings.Contains(string(skipped[0].Body), `"principal_type":"anonymous"`) || skipped[1].Path != "/endpoint/col-1/access/rule-1" {
+		t.Errorf("skipped requests = %+v", skipped)
+	}
+}
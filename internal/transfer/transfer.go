@@ -0,0 +1,353 @@
+// Package transfer is a minimal client for the Globus Transfer API.
+//
+// It covers only what the CLI needs to exercise an endpoint end to end:
+// submitting transfer and delete tasks, waiting for them to finish, and
+// listing directories. The access token must include the
+// urn:globus:auth:scope:transfer.api.globus.org:all scope, which the
+// 'login' command requests by default.
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+)
+
+// DefaultBaseURL is the Globus Transfer API base URL.
+const DefaultBaseURL = "https://transfer.api.globus.org/v0.10/"
+
+// Task statuses.
+const (
+	StatusActive    = "ACTIVE"
+	StatusInactive  = "INACTIVE"
+	StatusSucceeded = "SUCCEEDED"
+	StatusFailed    = "FAILED"
+)
+
+// Sync levels for TransferRequest.SyncLevel.
+const (
+	SyncExists   = 0
+	SyncSize     = 1
+	SyncMtime    = 2
+	SyncChecksum = 3
+)
+
+// Client is a Globus Transfer API client.
+type Client struct {
+	baseURL     string
+	httpClient  *http.Client
+	accessToken string
+	headers     http.Header
+	logger      *slog.Logger
+	dryRun      gcs.DryRunFunc
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client, for example one from
+// gcs.NewHTTPClient with the TLS and proxy settings of the GCS client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = client
+	}
+}
+
+// WithHeader adds a header sent with every request. It may be given more
+// than once.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		if c.headers == nil {
+			c.headers = http.Header{}
+		}
+		c.headers.Add(key, value)
+	}
+}
+
+// WithLogger logs the method, path, status, and duration of each request
+// to logger at slog.LevelDebug.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithDryRun makes the client send only GET requests. Other requests are
+// passed to fn instead and fail with an error wrapping gcs.ErrDryRun.
+func WithDryRun(fn gcs.DryRunFunc) Option {
+	return func(c *Client) {
+		c.dryRun = fn
+	}
+}
+
+// NewClient creates a Transfer API client for the API at baseURL
+// (DefaultBaseURL if empty) that authenticates with accessToken.
+func NewClient(baseURL, accessToken string, opts ...Option) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	c := &Client{
+		baseURL:     baseURL,
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		accessToken: accessToken,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Item is one file or directory in a transfer task.
+type Item struct {
+	DataType        string `json:"DATA_TYPE"`
+	SourcePath      string `json:"source_path"`
+	DestinationPath string `json:"destination_path"`
+	Recursive       bool   `json:"recursive,omitempty"`
+}
+
+// TransferRequest is a transfer task submission.
+type TransferRequest struct {
+	DataType            string `json:"DATA_TYPE"`
+	SubmissionID        string `json:"submission_id"`
+	Label               string `json:"label,omitempty"`
+	SourceEndpoint      string `json:"source_endpoint"`
+	DestinationEndpoint string `json:"destination_endpoint"`
+	VerifyChecksum      bool   `json:"verify_checksum"`
+	SyncLevel           *int   `json:"sync_level,omitempty"`
+	Items               []Item `json:"DATA"`
+}
+
+// DeleteItem is one path in a delete task.
+type DeleteItem struct {
+	DataType string `json:"DATA_TYPE"`
+	Path     string `json:"path"`
+}
+
+// DeleteRequest is a delete task submission.
+type DeleteRequest struct {
+	DataType     string       `json:"DATA_TYPE"`
+	SubmissionID string       `json:"submission_id"`
+	Label        string       `json:"label,omitempty"`
+	Endpoint     string       `json:"endpoint"`
+	Recursive    bool         `json:"recursive"`
+	Items        []DeleteItem `json:"DATA"`
+}
+
+// Task is the status of a transfer or delete task.
+type Task struct {
+	TaskID           string `json:"task_id"`
+	Type             string `json:"type"`
+	Status           string `json:"status"`
+	NiceStatus       string `json:"nice_status,omitempty"`
+	FilesTransferred int    `json:"files_transferred"`
+	FilesSkipped     int    `json:"files_skipped"`
+	BytesTransferred int64  `json:"bytes_transferred"`
+	Faults           int    `json:"faults"`
+}
+
+// Done reports whether the task has finished, successfully or not.
+func (t *Task) Done() bool {
+	return t.Status == StatusSucceeded || t.Status == StatusFailed
+}
+
+// File is an entry in a directory listing.
+type File struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Size int64  `json:"size"`
+}
+
+// SubmitTransfer submits a transfer task and returns its task ID. The
+// request's DataType and SubmissionID are filled in if empty.
+func (c *Client) SubmitTransfer(ctx context.Context, req *TransferRequest) (string, error) {
+	if req.DataType == "" {
+		req.DataType = "transfer"
+	}
+	for i := range req.Items {
+		if req.Items[i].DataType == "" {
+			req.Items[i].DataType = "transfer_item"
+		}
+	}
+	if req.SubmissionID == "" {
+		id, err := c.submissionID(ctx)
+		if err != nil {
+			return "", err
+		}
+		req.SubmissionID = id
+	}
+
+	return c.submit(ctx, "transfer", req)
+}
+
+// SubmitDelete submits a delete task and returns its task ID. The request's
+// DataType and SubmissionID are filled in if empty.
+func (c *Client) SubmitDelete(ctx context.Context, req *DeleteRequest) (string, error) {
+	if req.DataType == "" {
+		req.DataType = "delete"
+	}
+	for i := range req.Items {
+		if req.Items[i].DataType == "" {
+			req.Items[i].DataType = "delete_item"
+		}
+	}
+	if req.SubmissionID == "" {
+		id, err := c.submissionID(ctx)
+		if err != nil {
+			return "", err
+		}
+		req.SubmissionID = id
+	}
+
+	return c.submit(ctx, "delete", req)
+}
+
+// GetTask returns the status of a task.
+func (c *Client) GetTask(ctx context.Context, taskID string) (*Task, error) {
+	var task Task
+	if err := c.do(ctx, http.MethodGet, "task/"+url.PathEscape(taskID), nil, nil, &task); err != nil {
+		return nil, fmt.Errorf("get task: %w", err)
+	}
+	return &task, nil
+}
+
+// WaitForTask polls a task every interval until it finishes or ctx is
+// done, and returns its final status.
+func (c *Client) WaitForTask(ctx context.Context, taskID string, interval time.Duration) (*Task, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *Task
+	for {
+		task, err := c.GetTask(ctx, taskID)
+		if err != nil {
+			if last != nil && ctx.Err() != nil {
+				return last, fmt.Errorf("task %s still %s: %w", taskID, last.Status, ctx.Err())
+			}
+			return nil, err
+		}
+		if task.Done() {
+			return task, nil
+		}
+		last = task
+
+		select {
+		case <-ctx.Done():
+			return task, fmt.Errorf("task %s still %s: %w", taskID, task.Status, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// ListDirectory lists the entries of path on a collection.
+func (c *Client) ListDirectory(ctx context.Context, collectionID, path string) ([]File, error) {
+	var list struct {
+		Data []File `json:"DATA"`
+	}
+	query := url.Values{"path": {path}}
+	if err := c.do(ctx, http.MethodGet, "operation/endpoint/"+url.PathEscape(collectionID)+"/ls", query, nil, &list); err != nil {
+		return nil, fmt.Errorf("list directory: %w", err)
+	}
+	return list.Data, nil
+}
+
+// submissionID obtains a submission ID, which makes a task submission
+// safe to retry.
+func (c *Client) submissionID(ctx context.Context) (string, error) {
+	var resp struct {
+		Value string `json:"value"`
+	}
+	if err := c.do(ctx, http.MethodGet, "submission_id", nil, nil, &resp); err != nil {
+		return "", fmt.Errorf("get submission ID: %w", err)
+	}
+	return resp.Value, nil
+}
+
+// submit posts a task document and returns the new task ID.
+func (c *Client) submit(ctx context.Context, path string, doc interface{}) (string, error) {
+	var resp struct {
+		TaskID string `json:"task_id"`
+	}
+	if err := c.do(ctx, http.MethodPost, path, nil, doc, &resp); err != nil {
+		return "", fmt.Errorf("submit %s task: %w", path, err)
+	}
+	return resp.TaskID, nil
+}
+
+// do performs an API request and decodes the JSON response into target.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, target interface{}) error {
+	reqURL := c.baseURL + path
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("marshal request: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	if c.dryRun != nil && method != http.MethodGet {
+		c.dryRun(gcs.DryRunRequest{Method: method, Path: req.URL.RequestURI(), Body: data})
+		return fmt.Errorf("%w: %s %s not sent", gcs.ErrDryRun, method, req.URL.RequestURI())
+	}
+	for key, values := range c.headers {
+		req.Header[key] = append(req.Header[key], values...)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.log(ctx, "HTTP request failed", req, slog.Duration("duration", time.Since(start)), slog.String("error", err.Error()))
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	c.log(ctx, "HTTP response", req, slog.Int("status", resp.StatusCode), slog.Duration("duration", time.Since(start)))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		}
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("HTTP %d: %s: %s", resp.StatusCode, apiErr.Code, apiErr.Message)
+		}
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(data))
+	}
+
+	if target == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// log logs msg about req with attrs, if the client has a logger.
+func (c *Client) log(ctx context.Context, msg string, req *http.Request, attrs ...slog.Attr) {
+	if c.logger == nil {
+		return
+	}
+	attrs = append([]slog.Attr{slog.String("method", req.Method), slog.String("path", req.URL.RequestURI())}, attrs...)
+	c.logger.LogAttrs(ctx, slog.LevelDebug, msg, attrs...)
+}
@@ -0,0 +1,117 @@
+package transfer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client for server.
+func newTestClient(server *httptest.Server) *Client {
+	return &Client{
+		baseURL:     server.URL + "/",
+		httpClient:  &http.Client{},
+		accessToken: "test-token",
+	}
+}
+
+func TestSubmitTransfer(t *testing.T) {
+	var submitted TransferRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization = %q, want bearer token", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/submission_id":
+			_, _ = w.Write([]byte(`{"value": "sub-1"}`))
+		case "/transfer":
+			if err := json.NewDecoder(r.Body).Decode(&submitted); err != nil {
+				t.Errorf("decode transfer: %v", err)
+			}
+			_, _ = w.Write([]byte(`{"task_id": "task-1"}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	level := SyncChecksum
+	taskID, err := newTestClient(server).SubmitTransfer(context.Background(), &TransferRequest{
+		SourceEndpoint:      "col-1",
+		DestinationEndpoint: "col-1",
+		VerifyChecksum:      true,
+		SyncLevel:           &level,
+		Items:               []Item{{SourcePath: "/a", DestinationPath: "/b"}},
+	})
+	if err != nil {
+		t.Fatalf("SubmitTransfer() error: %v", err)
+	}
+
+	if taskID != "task-1" {
+		t.Errorf("SubmitTransfer() = %q, want task-1", taskID)
+	}
+	if submitted.SubmissionID != "sub-1" || submitted.DataType != "transfer" || submitted.Items[0].DataType != "transfer_item" {
+		t.Errorf("submitted document = %+v, want submission ID and data types filled in", submitted)
+	}
+	if submitted.SyncLevel == nil || *submitted.SyncLevel != SyncChecksum {
+		t.Errorf("submitted sync_level = %v, want %d", submitted.SyncLevel, SyncChecksum)
+	}
+}
+
+func TestWaitForTask(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		polls++
+		status := StatusActive
+		if polls >= 3 {
+			status = StatusSucceeded
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Task{TaskID: "task-1", Status: status})
+	}))
+	defer server.Close()
+
+	task, err := newTestClient(server).WaitForTask(context.Background(), "task-1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitForTask() error: %v", err)
+	}
+	if task.Status != StatusSucceeded || polls != 3 {
+		t.Errorf("WaitForTask() = %s after %d polls, want SUCCEEDED after 3", task.Status, polls)
+	}
+}
+
+func TestWaitForTask_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Task{TaskID: "task-1", Status: StatusActive})
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := newTestClient(server).WaitForTask(ctx, "task-1", 5*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "still ACTIVE") {
+		t.Errorf("WaitForTask() error = %v, want still ACTIVE error", err)
+	}
+}
+
+func TestClient_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"code": "PermissionDenied", "message": "No access"}`))
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server).ListDirectory(context.Background(), "col-1", "/")
+	if err == nil || !strings.Contains(err.Error(), "HTTP 403: PermissionDenied: No access") {
+		t.Errorf("ListDirectory() error = %v, want API error", err)
+	}
+}
@@ -0,0 +1,209 @@
+// Package trash keeps local copies of deleted resources so that they can
+// be re-created.
+//
+// Before a role, sharing policy, or auth policy is deleted, its full JSON
+// is saved as one file per item in ~/.globus-connect-server/trash with
+// 0600 permissions. Items are kept for Retention and purged after that.
+package trash
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/config"
+)
+
+// DirName is the name of the trash directory inside the configuration
+// directory.
+const DirName = "trash"
+
+// Retention is how long deleted resources are kept.
+const Retention = 30 * 24 * time.Hour
+
+// Kinds of resources kept in the trash.
+const (
+	KindRole          = "role"
+	KindSharingPolicy = "sharing-policy"
+	KindAuthPolicy    = "auth-policy"
+)
+
+// ErrNotFound is returned by Get for an unknown or purged item.
+var ErrNotFound = errors.New("not found in trash")
+
+// Item is a deleted resource.
+type Item struct {
+	// ID identifies the item in the trash. It differs from ResourceID so
+	// that a resource deleted twice is kept twice.
+	ID string `json:"id"`
+
+	// Kind is one of the Kind constants.
+	Kind string `json:"kind"`
+
+	// ResourceID is the ID the resource had on the endpoint.
+	ResourceID string `json:"resource_id"`
+
+	// Endpoint is the FQDN of the endpoint the resource was deleted from.
+	Endpoint string `json:"endpoint"`
+
+	// DeletedAt is when the resource was deleted.
+	DeletedAt time.Time `json:"deleted_at"`
+
+	// Object is the resource as returned by the API before deletion.
+	Object json.RawMessage `json:"object"`
+}
+
+// ExpiresAt returns when the item will be purged.
+func (i *Item) ExpiresAt() time.Time {
+	return i.DeletedAt.Add(Retention)
+}
+
+// GetDir returns the path to the trash directory.
+func GetDir() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, DirName), nil
+}
+
+// Put saves the resource object of the given kind in the trash at dir and
+// returns the new item.
+func Put(dir, kind, resourceID, endpoint string, object interface{}) (*Item, error) {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s: %w", kind, err)
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, fmt.Errorf("generate trash ID: %w", err)
+	}
+
+	item := &Item{
+		ID:         fmt.Sprintf("%s-%s", time.Now().UTC().Format("20060102T150405"), hex.EncodeToString(suffix)),
+		Kind:       kind,
+		ResourceID: resourceID,
+		Endpoint:   endpoint,
+		DeletedAt:  time.Now().UTC(),
+		Object:     data,
+	}
+
+	encoded, err := json.MarshalIndent(item, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode trash item: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("create trash directory: %w", err)
+	}
+	if err := os.WriteFile(itemPath(dir, item.ID), encoded, 0600); err != nil {
+		return nil, fmt.Errorf("write trash item: %w", err)
+	}
+
+	return item, nil
+}
+
+// List returns the items in the trash at dir, most recently deleted
+// first. Items older than Retention are purged first. A missing directory
+// is an empty trash.
+func List(dir string, now time.Time) ([]Item, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read trash directory: %w", err)
+	}
+
+	var items []Item
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		item, err := load(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			// Leave unreadable files for the user to inspect
+			continue
+		}
+		if now.After(item.ExpiresAt()) {
+			if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil && !os.IsNotExist(err) {
+				return nil, fmt.Errorf("purge trash item: %w", err)
+			}
+			continue
+		}
+		items = append(items, *item)
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].DeletedAt.After(items[j].DeletedAt) })
+	return items, nil
+}
+
+// Get returns the item with the given ID from the trash at dir.
+func Get(dir, id string) (*Item, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.HasPrefix(id, ".") {
+		return nil, fmt.Errorf("%q: %w", id, ErrNotFound)
+	}
+
+	item, err := load(itemPath(dir, id))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%q: %w", id, ErrNotFound)
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+// Remove deletes the item with the given ID from the trash at dir.
+func Remove(dir, id string) error {
+	if err := os.Remove(itemPath(dir, id)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove trash item: %w", err)
+	}
+	return nil
+}
+
+// itemPath returns the file that holds the item with the given ID.
+func itemPath(dir, id string) string {
+	return filepath.Join(dir, id+".json")
+}
+
+// load reads one item file.
+func load(path string) (*Item, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is in the trash directory
+	if err != nil {
+		return nil, fmt.Errorf("read trash item: %w", err)
+	}
+
+	var item Item
+	if err := json.Unmarshal(data, &item); err != nil {
+		return nil, fmt.Errorf("parse trash item %s: %w", filepath.Base(path), err)
+	}
+	return &item, nil
+}
+
+// Save puts object in the trash in the configuration directory.
+func Save(kind, resourceID, endpoint string, object interface{}) (*Item, error) {
+	dir, err := GetDir()
+	if err != nil {
+		return nil, err
+	}
+	return Put(dir, kind, resourceID, endpoint, object)
+}
+
+// Discard removes an item from the trash in the configuration directory,
+// e.g. when the deletion it was saved for failed.
+func Discard(id string) error {
+	dir, err := GetDir()
+	if err != nil {
+		return err
+	}
+	return Remove(dir, id)
+}
@@ -0,0 +1,116 @@
+package trash
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPutGet(t *testing.T) {
+	dir := t.TempDir()
+
+	object := map[string]string{"id": "role-1", "role": "administrator"}
+	item, err := Put(dir, KindRole, "role-1", "gcs.example.org", object)
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, item.ID+".json"))
+	if err != nil {
+		t.Fatalf("item file not written: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("item file permissions = %o, want 600", perm)
+	}
+
+	got, err := Get(dir, item.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Kind != KindRole || got.ResourceID != "role-1" || got.Endpoint != "gcs.example.org" {
+		t.Errorf("Get() = %+v", got)
+	}
+
+	var decoded map[string]string
+	if err := json.Unmarshal(got.Object, &decoded); err != nil {
+		t.Fatalf("unmarshal object: %v", err)
+	}
+	if decoded["role"] != "administrator" {
+		t.Errorf("object role = %q, want administrator", decoded["role"])
+	}
+}
+
+func TestGet_NotFound(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, id := range []string{"", "missing", "../etc/passwd", ".hidden"} {
+		if _, err := Get(dir, id); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get(%q) error = %v, want ErrNotFound", id, err)
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	dir := t.TempDir()
+
+	if items, err := List(dir+"/missing", time.Now()); err != nil || len(items) != 0 {
+		t.Errorf("List() on missing dir = %v, %v; want empty", items, err)
+	}
+
+	first, err := Put(dir, KindRole, "role-1", "gcs.example.org", map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := Put(dir, KindAuthPolicy, "policy-1", "gcs.example.org", map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Make the first item older than the second
+	first.DeletedAt = first.DeletedAt.Add(-time.Hour)
+	data, _ := json.Marshal(first)
+	if err := os.WriteFile(filepath.Join(dir, first.ID+".json"), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := List(dir, time.Now())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(items) != 2 || items[0].ID != second.ID || items[1].ID != first.ID {
+		t.Errorf("List() = %+v, want newest first", items)
+	}
+
+	// Purge the first item only
+	items, err = List(dir, first.DeletedAt.Add(Retention+time.Minute))
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(items) != 1 || items[0].ID != second.ID {
+		t.Errorf("List() after expiry = %+v, want only %s", items, second.ID)
+	}
+	if _, err := Get(dir, first.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expired item not purged: %v", err)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	dir := t.TempDir()
+
+	item, err := Put(dir, KindSharingPolicy, "policy-1", "gcs.example.org", map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Remove(dir, item.ID); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := Get(dir, item.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Remove() error = %v, want ErrNotFound", err)
+	}
+	if err := Remove(dir, item.ID); err != nil {
+		t.Errorf("Remove() of missing item error = %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DisabledMessagePrefix marks the user message that DisableCollection
+// set on a collection.
+const DisabledMessagePrefix = "[DISABLED] "
+
+// Availability methods reported in AvailabilityChange.Method.
+const (
+	// AvailabilityFlag means the server's "disabled" flag was used.
+	AvailabilityFlag = "availability_flag"
+
+	// AvailabilityVisibility means the collection was hidden (public=false)
+	// and its user message replaced, because the server has no
+	// availability flag.
+	AvailabilityVisibility = "visibility"
+)
+
+// disabledField is the collection document field that newer GCS versions
+// use to take a collection offline.
+const disabledField = "disabled"
+
+// AvailabilityChange describes a collection being disabled or enabled.
+type AvailabilityChange struct {
+	CollectionID string `json:"collection_id"`
+	Disabled     bool   `json:"disabled"`
+	Method       string `json:"method"`
+	UserMessage  string `json:"user_message,omitempty"`
+
+	// PreviousPublic and PreviousUserMessage record the visibility state
+	// replaced by the visibility fallback, and PreviousUserMessage the
+	// message replaced by a maintenance message with the availability
+	// flag, so that they can be restored.
+	PreviousPublic      bool   `json:"previous_public"`
+	PreviousUserMessage string `json:"previous_user_message,omitempty"`
+}
+
+// DisableCollection takes a collection offline for maintenance without
+// deleting it.
+//
+// If the server's collection document has a "disabled" flag, it is set,
+// and a non-empty message replaces the user message. Otherwise the
+// collection is made non-public and its user message is replaced with
+// message. Either way the new message is prefixed with
+// DisabledMessagePrefix, and the returned change records the previous
+// state for EnableCollection.
+func (c *Client) DisableCollection(ctx context.Context, collectionID, message string) (*AvailabilityChange, error) {
+	doc, err := c.GetCollectionDocument(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &AvailabilityChange{CollectionID: collectionID, Disabled: true}
+
+	if disabled, ok := doc[disabledField].(bool); ok {
+		change.Method = AvailabilityFlag
+		if disabled {
+			return nil, fmt.Errorf("collection %s is already disabled", collectionID)
+		}
+		patch := map[string]interface{}{disabledField: true}
+		if message != "" {
+			change.PreviousUserMessage, _ = doc["user_message"].(string)
+			change.UserMessage = DisabledMessagePrefix + message
+			patch["user_message"] = change.UserMessage
+		}
+		return change, c.PatchCollection(ctx, collectionID, patch)
+	}
+
+	change.Method = AvailabilityVisibility
+	change.PreviousPublic, _ = doc["public"].(bool)
+	change.PreviousUserMessage, _ = doc["user_message"].(string)
+	if strings.HasPrefix(change.PreviousUserMessage, DisabledMessagePrefix) {
+		return nil, fmt.Errorf("collection %s is already disabled", collectionID)
+	}
+
+	if message == "" {
+		message = "This collection is temporarily unavailable for maintenance."
+	}
+	change.UserMessage = DisabledMessagePrefix + message
+
+	return change, c.PatchCollection(ctx, collectionID, map[string]interface{}{
+		"public":       false,
+		"user_message": change.UserMessage,
+	})
+}
+
+// EnableCollection brings a disabled collection back online.
+//
+// previous is the change returned by DisableCollection, if it is still
+// available; it is used to restore the collection's visibility and user
+// message. Without it, a maintenance message set by DisableCollection is
+// cleared but the collection's visibility is left unchanged.
+func (c *Client) EnableCollection(ctx context.Context, collectionID string, previous *AvailabilityChange) (*AvailabilityChange, error) {
+	doc, err := c.GetCollectionDocument(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &AvailabilityChange{CollectionID: collectionID}
+
+	if disabled, ok := doc[disabledField].(bool); ok {
+		change.Method = AvailabilityFlag
+		if !disabled {
+			return nil, fmt.Errorf("collection %s is not disabled", collectionID)
+		}
+		patch := map[string]interface{}{disabledField: false}
+		if current, _ := doc["user_message"].(string); strings.HasPrefix(current, DisabledMessagePrefix) {
+			patch["user_message"] = ""
+			if previous != nil {
+				patch["user_message"] = previous.PreviousUserMessage
+				change.UserMessage = previous.PreviousUserMessage
+			}
+		}
+		return change, c.PatchCollection(ctx, collectionID, patch)
+	}
+
+	change.Method = AvailabilityVisibility
+	current, _ := doc["user_message"].(string)
+	if !strings.HasPrefix(current, DisabledMessagePrefix) {
+		return nil, fmt.Errorf("collection %s is not disabled", collectionID)
+	}
+
+	patch := map[string]interface{}{"user_message": ""}
+	change.PreviousPublic, _ = doc["public"].(bool)
+	if previous != nil {
+		patch["public"] = previous.PreviousPublic
+		patch["user_message"] = previous.PreviousUserMessage
+		change.PreviousPublic = previous.PreviousPublic
+		change.UserMessage = previous.PreviousUserMessage
+	}
+
+	return change, c.PatchCollection(ctx, collectionID, patch)
+}
+
+// IsDisabled reports whether the collection carries the maintenance
+// message of DisableCollection.
+func (col *Collection) IsDisabled() bool {
+	return strings.HasPrefix(col.UserMessage, DisabledMessagePrefix)
+}
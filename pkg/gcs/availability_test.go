@@ -0,0 +1,246 @@
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newAvailabilityServer serves a single collection document and records
+// the PATCH requests made to it.
+func newAvailabilityServer(t *testing.T, doc map[string]interface{}) (*Client, *[]map[string]interface{}, func()) {
+	t.Helper()
+
+	var patches []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/collections/col-1" {
+			t.Errorf("request path = %q, want %q", r.URL.Path, "/api/collections/col-1")
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(doc)
+		case http.MethodPatch:
+			var patch map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+				t.Errorf("decode patch: %v", err)
+			}
+			patches = append(patches, patch)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}))
+
+	client := &Client{
+		baseURL:    server.URL + "/api/",
+		httpClient: &http.Client{},
+		userAgent:  "test-agent",
+	}
+	return client, &patches, server.Close
+}
+
+func TestDisableCollection_AvailabilityFlag(t *testing.T) {
+	client, patches, cleanup := newAvailabilityServer(t, map[string]interface{}{"id": "col-1", "disabled": false})
+	defer cleanup()
+
+	change, err := client.DisableCollection(context.Background(), "col-1", "")
+	if err != nil {
+		t.Fatalf("DisableCollection() error: %v", err)
+	}
+
+	if change.Method != AvailabilityFlag {
+		t.Errorf("Method = %q, want %q", change.Method, AvailabilityFlag)
+	}
+	if len(*patches) != 1 || (*patches)[0]["disabled"] != true {
+		t.Errorf("patches = %v, want disabled=true", *patches)
+	}
+}
+
+func TestDisableCollection_AvailabilityFlagMessage(t *testing.T) {
+	client, patches, cleanup := newAvailabilityServer(t, map[string]interface{}{
+		"id":           "col-1",
+		"disabled":     false,
+		"user_message": "Welcome",
+	})
+	defer cleanup()
+
+	change, err := client.DisableCollection(context.Background(), "col-1", "Back Monday")
+	if err != nil {
+		t.Fatalf("DisableCollection() error: %v", err)
+	}
+
+	if change.PreviousUserMessage != "Welcome" {
+		t.Errorf("PreviousUserMessage = %q, want %q", change.PreviousUserMessage, "Welcome")
+	}
+	patch := (*patches)[0]
+	if patch["disabled"] != true || patch["user_message"] != DisabledMessagePrefix+"Back Monday" {
+		t.Errorf("patch = %v, want disabled=true and the maintenance message", patch)
+	}
+}
+
+func TestDisableCollection_AvailabilityFlagAlreadyDisabled(t *testing.T) {
+	client, patches, cleanup := newAvailabilityServer(t, map[string]interface{}{"id": "col-1", "disabled": true})
+	defer cleanup()
+
+	if _, err := client.DisableCollection(context.Background(), "col-1", "Back Monday"); err == nil {
+		t.Error("DisableCollection() expected error for disabled collection, got nil")
+	}
+	if len(*patches) != 0 {
+		t.Errorf("patches = %v, want none", *patches)
+	}
+}
+
+func TestDisableCollection_VisibilityFallback(t *testing.T) {
+	client, patches, cleanup := newAvailabilityServer(t, map[string]interface{}{
+		"id":           "col-1",
+		"public":       true,
+		"user_message": "Welcome",
+	})
+	defer cleanup()
+
+	change, err := client.DisableCollection(context.Background(), "col-1", "Back Monday")
+	if err != nil {
+		t.Fatalf("DisableCollection() error: %v", err)
+	}
+
+	if change.Method != AvailabilityVisibility {
+		t.Errorf("Method = %q, want %q", change.Method, AvailabilityVisibility)
+	}
+	if !change.PreviousPublic || change.PreviousUserMessage != "Welcome" {
+		t.Errorf("previous state = %v, %q, want true, %q", change.PreviousPublic, change.PreviousUserMessage, "Welcome")
+	}
+
+	patch := (*patches)[0]
+	if patch["public"] != false {
+		t.Errorf("patch public = %v, want false", patch["public"])
+	}
+	if patch["user_message"] != DisabledMessagePrefix+"Back Monday" {
+		t.Errorf("patch user_message = %v, want %q", patch["user_message"], DisabledMessagePrefix+"Back Monday")
+	}
+}
+
+func TestDisableCollection_AlreadyDisabled(t *testing.T) {
+	client, _, cleanup := newAvailabilityServer(t, map[string]interface{}{
+		"id":           "col-1",
+		"user_message": DisabledMessagePrefix + "maintenance",
+	})
+	defer cleanup()
+
+	if _, err := client.DisableCollection(context.Background(), "col-1", ""); err == nil {
+		t.Error("DisableCollection() expected error for disabled collection, got nil")
+	}
+}
+
+func TestEnableCollection_RestoresPreviousState(t *testing.T) {
+	client, patches, cleanup := newAvailabilityServer(t, map[string]interface{}{
+		"id":           "col-1",
+		"public":       false,
+		"user_message": DisabledMessagePrefix + "maintenance",
+	})
+	defer cleanup()
+
+	previous := &AvailabilityChange{PreviousPublic: true, PreviousUserMessage: "Welcome"}
+	if _, err := client.EnableCollection(context.Background(), "col-1", previous); err != nil {
+		t.Fatalf("EnableCollection() error: %v", err)
+	}
+
+	patch := (*patches)[0]
+	if patch["public"] != true || patch["user_message"] != "Welcome" {
+		t.Errorf("patch = %v, want public=true and the previous user message", patch)
+	}
+}
+
+func TestEnableCollection_AvailabilityFlagMessage(t *testing.T) {
+	doc := map[string]interface{}{
+		"id":           "col-1",
+		"disabled":     true,
+		"user_message": DisabledMessagePrefix + "Back Monday",
+	}
+
+	tests := []struct {
+		name     string
+		previous *AvailabilityChange
+		want     string
+	}{
+		{"restores previous message", &AvailabilityChange{Method: AvailabilityFlag, PreviousUserMessage: "Welcome"}, "Welcome"},
+		{"clears message without previous state", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, patches, cleanup := newAvailabilityServer(t, doc)
+			defer cleanup()
+
+			if _, err := client.EnableCollection(context.Background(), "col-1", tt.previous); err != nil {
+				t.Fatalf("EnableCollection() error: %v", err)
+			}
+
+			patch := (*patches)[0]
+			if patch["disabled"] != false || patch["user_message"] != tt.want {
+				t.Errorf("patch = %v, want disabled=false and user_message %q", patch, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnableCollection_AvailabilityFlagKeepsOwnMessage(t *testing.T) {
+	client, patches, cleanup := newAvailabilityServer(t, map[string]interface{}{
+		"id":           "col-1",
+		"disabled":     true,
+		"user_message": "Welcome",
+	})
+	defer cleanup()
+
+	if _, err := client.EnableCollection(context.Background(), "col-1", nil); err != nil {
+		t.Fatalf("EnableCollection() error: %v", err)
+	}
+	if _, ok := (*patches)[0]["user_message"]; ok {
+		t.Errorf("patch = %v, want the user message left alone", (*patches)[0])
+	}
+}
+
+func TestEnableCollection_NotDisabled(t *testing.T) {
+	client, _, cleanup := newAvailabilityServer(t, map[string]interface{}{"id": "col-1", "public": true})
+	defer cleanup()
+
+	if _, err := client.EnableCollection(context.Background(), "col-1", nil); err == nil {
+		t.Error("EnableCollection() expected error for enabled collection, got nil")
+	}
+}
+
+func TestCollection_IsDisabled(t *testing.T) {
+	if (&Collection{UserMessage: "Welcome"}).IsDisabled() {
+		t.Error("IsDisabled() = true for normal collection")
+	}
+	if !(&Collection{UserMessage: DisabledMessagePrefix + "maintenance"}).IsDisabled() {
+		t.Error("IsDisabled() = false for disabled collection")
+	}
+}
+
+func TestSetCollectionUserMessage(t *testing.T) {
+	client, patches, done := newAvailabilityServer(t, map[string]interface{}{"id": "col-1"})
+	defer done()
+
+	if err := client.SetCollectionUserMessage(context.Background(), "col-1", "Maintenance Sunday", "https://status.example.edu"); err != nil {
+		t.Fatalf("SetCollectionUserMessage() error: %v", err)
+	}
+	// Clearing sends empty values rather than omitting them
+	if err := client.SetCollectionUserMessage(context.Background(), "col-1", "", ""); err != nil {
+		t.Fatalf("SetCollectionUserMessage() clear error: %v", err)
+	}
+
+	if len(*patches) != 2 {
+		t.Fatalf("got %d PATCH requests, want 2", len(*patches))
+	}
+	if got := (*patches)[0]["user_message_link"]; got != "https://status.example.edu" {
+		t.Errorf("user_message_link = %v, want the link", got)
+	}
+	if got, ok := (*patches)[1]["user_message"]; !ok || got != "" {
+		t.Errorf("clear user_message = %v (present %v), want empty string", got, ok)
+	}
+}
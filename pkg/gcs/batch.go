@@ -0,0 +1,159 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrNotRun is the error of batch items that were not started because
+// the batch stopped at a failure (see Batch.StopOnError).
+var ErrNotRun = errors.New("not run: batch stopped after a failure")
+
+// BatchResult is the outcome of one item of a Batch.
+type BatchResult[T, R any] struct {
+	Item  T
+	Value R
+	Err   error
+}
+
+// Batch runs a function over many items with bounded concurrency, for
+// bulk operations such as deleting collections or applying roles.
+type Batch[T, R any] struct {
+	// Workers is the maximum number of items processed at once. Values
+	// below 1 process one item at a time.
+	Workers int
+
+	// StopOnError stops starting new items once an item fails. Items
+	// already running finish, so with one worker nothing runs after the
+	// first failure.
+	StopOnError bool
+
+	// OnResult, if set, is called with the result of each item that ran,
+	// in the order they complete. Calls are not concurrent.
+	OnResult func(BatchResult[T, R])
+}
+
+// BatchError is the aggregate error of a Batch in which items failed or
+// did not run. Unwrap exposes the item errors to errors.Is and errors.As.
+type BatchError struct {
+	Total  int
+	Failed int
+	NotRun int
+	Errs   []error
+}
+
+func (e *BatchError) Error() string {
+	msg := fmt.Sprintf("%d of %d items failed", e.Failed, e.Total)
+	if e.NotRun > 0 {
+		msg += fmt.Sprintf(", %d not run", e.NotRun)
+	}
+	if len(e.Errs) > 0 {
+		msg += fmt.Sprintf(" (first error: %v)", e.Errs[0])
+	}
+	return msg
+}
+
+// Unwrap returns the errors of the failed items.
+func (e *BatchError) Unwrap() []error {
+	return e.Errs
+}
+
+// RunBatch calls fn for each item with up to workers calls at once. See
+// Batch.Run.
+func RunBatch[T, R any](ctx context.Context, items []T, workers int, fn func(context.Context, T) (R, error)) ([]BatchResult[T, R], error) {
+	return Batch[T, R]{Workers: workers}.Run(ctx, items, fn)
+}
+
+// Run calls fn for each item and returns the results in the order of
+// items. The error is a *BatchError if any item failed or did not run.
+// Items not started because ctx was canceled fail with its error.
+func (b Batch[T, R]) Run(ctx context.Context, items []T, fn func(context.Context, T) (R, error)) ([]BatchResult[T, R], error) {
+	results := make([]BatchResult[T, R], len(items))
+	ran := make([]bool, len(items))
+
+	indexes := make(chan int)
+	done := make(chan int)
+	stop := make(chan struct{})
+
+	var stopOnce sync.Once
+	var wg sync.WaitGroup
+	for range min(max(b.Workers, 1), len(items)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				select {
+				case <-stop:
+					continue
+				case <-ctx.Done():
+					continue
+				default:
+				}
+
+				// Each worker writes only the results of its items
+				value, err := fn(ctx, items[i])
+				results[i] = BatchResult[T, R]{Item: items[i], Value: value, Err: err}
+				ran[i] = true
+				if err != nil && b.StopOnError {
+					// Stop before reporting, so no worker starts another item
+					stopOnce.Do(func() { close(stop) })
+				}
+				done <- i
+			}
+		}()
+	}
+	go func() {
+		defer close(indexes)
+		for i := range items {
+			select {
+			case <-stop:
+				return
+			case <-ctx.Done():
+				return
+			default:
+			}
+			select {
+			case indexes <- i:
+			case <-stop:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	for i := range done {
+		if b.OnResult != nil {
+			b.OnResult(results[i])
+		}
+	}
+
+	batchErr := &BatchError{Total: len(items)}
+	for i := range results {
+		switch {
+		case !ran[i]:
+			results[i].Item = items[i]
+			results[i].Err = ErrNotRun
+			if err := ctx.Err(); err != nil {
+				results[i].Err = err
+			}
+			batchErr.NotRun++
+		case results[i].Err != nil:
+			batchErr.Failed++
+			batchErr.Errs = append(batchErr.Errs, results[i].Err)
+		}
+	}
+	if batchErr.Failed == 0 && batchErr.NotRun == 0 {
+		return results, nil
+	}
+	if err := ctx.Err(); err != nil && batchErr.NotRun > 0 {
+		batchErr.Errs = append(batchErr.Errs, err)
+	}
+	return results, batchErr
+}
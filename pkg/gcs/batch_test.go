@@ -0,0 +1,129 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBatchOrderAndConcurrency(t *testing.T) {
+	items := make([]int, 50)
+	for i := range items {
+		items[i] = i
+	}
+
+	var running, peak atomic.Int32
+	results, err := RunBatch(context.Background(), items, 4, func(_ context.Context, n int) (int, error) {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := peak.Load()
+			if cur <= old || peak.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		return n * n, nil
+	})
+	if err != nil {
+		t.Fatalf("RunBatch: %v", err)
+	}
+	if got := peak.Load(); got > 4 {
+		t.Errorf("peak concurrency = %d, want at most 4", got)
+	}
+	for i, r := range results {
+		if r.Item != i || r.Value != i*i || r.Err != nil {
+			t.Errorf("results[%d] = %+v, want item %d value %d", i, r, i, i*i)
+		}
+	}
+}
+
+func TestRunBatchAggregatesErrors(t *testing.T) {
+	errOdd := errors.New("odd")
+	results, err := RunBatch(context.Background(), []int{1, 2, 3, 4}, 2, func(_ context.Context, n int) (int, error) {
+		if n%2 == 1 {
+			return 0, fmt.Errorf("item %d: %w", n, errOdd)
+		}
+		return n, nil
+	})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("err = %v, want *BatchError", err)
+	}
+	if batchErr.Total != 4 || batchErr.Failed != 2 || batchErr.NotRun != 0 {
+		t.Errorf("BatchError = %+v, want 2 of 4 failed", batchErr)
+	}
+	if !errors.Is(err, errOdd) {
+		t.Errorf("errors.Is(err, errOdd) = false for %v", err)
+	}
+	if results[1].Value != 2 || results[0].Err == nil {
+		t.Errorf("results = %+v", results)
+	}
+}
+
+func TestBatchStopOnError(t *testing.T) {
+	var calls atomic.Int32
+	var reported int
+	batch := Batch[int, int]{
+		Workers:     1,
+		StopOnError: true,
+		OnResult:    func(BatchResult[int, int]) { reported++ },
+	}
+	results, err := batch.Run(context.Background(), []int{1, 2, 3, 4}, func(_ context.Context, n int) (int, error) {
+		calls.Add(1)
+		if n == 2 {
+			return 0, errors.New("boom")
+		}
+		return n, nil
+	})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("err = %v, want *BatchError", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("fn called %d times, want 2", got)
+	}
+	if batchErr.Failed != 1 || batchErr.NotRun != 2 {
+		t.Errorf("BatchError = %+v, want 1 failed and 2 not run", batchErr)
+	}
+	for _, r := range results[2:] {
+		if !errors.Is(r.Err, ErrNotRun) {
+			t.Errorf("result for %d: Err = %v, want ErrNotRun", r.Item, r.Err)
+		}
+	}
+	if reported != int(calls.Load()) {
+		t.Errorf("OnResult called %d times, want %d", reported, calls.Load())
+	}
+}
+
+func TestRunBatchCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	results, err := RunBatch(ctx, []int{1, 2, 3}, 1, func(_ context.Context, n int) (int, error) {
+		cancel()
+		return n, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if results[0].Err != nil {
+		t.Errorf("results[0].Err = %v, want nil", results[0].Err)
+	}
+	if !errors.Is(results[2].Err, context.Canceled) {
+		t.Errorf("results[2].Err = %v, want context.Canceled", results[2].Err)
+	}
+}
+
+func TestRunBatchEmpty(t *testing.T) {
+	results, err := RunBatch(context.Background(), nil, 4, func(context.Context, int) (int, error) {
+		t.Fatal("fn called for empty batch")
+		return 0, nil
+	})
+	if err != nil || len(results) != 0 {
+		t.Errorf("RunBatch(nil) = %v, %v", results, err)
+	}
+}
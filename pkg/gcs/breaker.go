@@ -0,0 +1,121 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is the error of requests refused by an open
+// CircuitBreaker. Test for it with errors.Is.
+var ErrCircuitOpen = errors.New("endpoint unreachable")
+
+// CircuitBreaker stops a client from sending requests to an endpoint that
+// appears to be down. After Threshold consecutive failures (connection
+// errors and HTTP 502, 503, or 504) it opens, and requests fail at once
+// with ErrCircuitOpen instead of waiting for their own timeouts. After
+// Cooldown it lets one request through: if that succeeds, it closes again;
+// if not, it stays open for another Cooldown.
+//
+// Share one breaker between the clients of an endpoint so that they trip
+// together. A CircuitBreaker is safe for concurrent use; a nil one never
+// opens.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+	lastErr  string
+
+	// now is the clock. It is a test hook.
+	now func() time.Time
+}
+
+// NewCircuitBreaker returns a breaker that opens after threshold
+// consecutive failures and tries again after cooldown. A threshold below
+// 1 is 1.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: max(threshold, 1), cooldown: cooldown, now: time.Now}
+}
+
+// allow returns ErrCircuitOpen, with the reason, if a request must not be
+// sent now.
+func (b *CircuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return nil
+	}
+	if wait := b.openedAt.Add(b.cooldown).Sub(b.now()); wait > 0 || b.probing {
+		if wait < 0 {
+			wait = 0
+		}
+		return fmt.Errorf("%w: %d consecutive requests failed (last: %s); trying again in %s",
+			ErrCircuitOpen, b.failures, b.lastErr, wait.Round(time.Second))
+	}
+
+	// Let one request test whether the endpoint is back
+	b.probing = true
+	return nil
+}
+
+// record notes the outcome of a request allowed by allow.
+func (b *CircuitBreaker) record(ctx context.Context, resp *http.Response, err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if resp == nil && ctx.Err() != nil {
+		// Canceled by the caller, which says nothing about the endpoint
+		b.probing = false
+		return
+	}
+
+	failed := err != nil
+	if resp != nil {
+		switch resp.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			failed, err = true, fmt.Errorf("HTTP %d", resp.StatusCode)
+		}
+	}
+	if !failed {
+		b.failures, b.probing = 0, false
+		return
+	}
+
+	b.failures++
+	b.lastErr = err.Error()
+	if b.failures >= b.threshold {
+		b.openedAt, b.probing = b.now(), false
+	}
+}
+
+// State returns "closed", "open", or "half-open" (cooldown over, ready
+// to let a request through), for diagnostics.
+func (b *CircuitBreaker) State() string {
+	if b == nil {
+		return "closed"
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case b.failures < b.threshold:
+		return "closed"
+	case b.probing || b.now().Before(b.openedAt.Add(b.cooldown)):
+		return "open"
+	}
+	return "half-open"
+}
@@ -0,0 +1,93 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_States(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := NewCircuitBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+	ctx := context.Background()
+	unavailable := &http.Response{StatusCode: http.StatusServiceUnavailable}
+	ok := &http.Response{StatusCode: http.StatusOK}
+
+	b.record(ctx, unavailable, nil)
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow() after one failure = %v", err)
+	}
+	b.record(ctx, nil, errors.New("connection refused"))
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("allow() after two failures = %v, want ErrCircuitOpen", err)
+	}
+	if b.State() != "open" {
+		t.Errorf("State() = %q, want open", b.State())
+	}
+
+	// After the cooldown, one probe goes through and others still wait
+	now = now.Add(time.Minute)
+	if b.State() != "half-open" {
+		t.Errorf("State() = %q, want half-open", b.State())
+	}
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow() of the probe = %v", err)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("allow() during the probe = %v, want ErrCircuitOpen", err)
+	}
+
+	// A failed probe reopens; a successful one closes
+	b.record(ctx, unavailable, nil)
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("allow() after a failed probe = %v, want ErrCircuitOpen", err)
+	}
+	now = now.Add(time.Minute)
+	_ = b.allow()
+	b.record(ctx, ok, nil)
+	if b.State() != "closed" {
+		t.Errorf("State() after a successful probe = %q, want closed", b.State())
+	}
+}
+
+func TestCircuitBreaker_IgnoresCallerCancel(t *testing.T) {
+	b := NewCircuitBreaker(1, time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	b.record(ctx, nil, context.Canceled)
+	if err := b.allow(); err != nil {
+		t.Errorf("allow() after a canceled request = %v", err)
+	}
+}
+
+func TestClient_WithCircuitBreaker(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:    server.URL + "/api/",
+		httpClient: &http.Client{},
+		breaker:    NewCircuitBreaker(3, time.Hour),
+	}
+
+	var err error
+	for range 10 {
+		_, err = client.GetCollection(context.Background(), "c1")
+	}
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("GetCollection() error = %v, want ErrCircuitOpen", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("server got %d requests, want 3", got)
+	}
+}
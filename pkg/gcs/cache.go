@@ -0,0 +1,169 @@
+package gcs
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// DefaultETagCacheSize is the number of responses NewETagCache keeps when
+// given a size below 1.
+const DefaultETagCacheSize = 256
+
+// ETagCache remembers the entity tags of GET responses, so that a client
+// created with WithETagCache can:
+//
+//   - revalidate a resource it fetched before with If-None-Match, and
+//     reuse the cached body when the server answers 304 Not Modified;
+//   - send If-Match with a PATCH of a resource it fetched before, so that
+//     the update fails with HTTP 412 (see IsPreconditionFailed) instead of
+//     overwriting a change someone else made in the meantime.
+//
+// Responses are keyed by URL only: share a cache only between clients
+// that use the same access token. An ETagCache is safe for concurrent
+// use.
+type ETagCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*cacheEntry
+
+	// order holds the URLs of entries from oldest to newest, for eviction
+	order []string
+}
+
+// cacheEntry is a cached GET response.
+type cacheEntry struct {
+	etag   string
+	header http.Header
+	body   []byte
+}
+
+// NewETagCache returns a cache that keeps the latest size responses.
+func NewETagCache(size int) *ETagCache {
+	if size < 1 {
+		size = DefaultETagCacheSize
+	}
+	return &ETagCache{size: size, entries: map[string]*cacheEntry{}}
+}
+
+// Len returns the number of cached responses.
+func (c *ETagCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// conditionalHeader returns header with If-None-Match for a GET, or
+// If-Match for a PATCH, of a cached URL. header is not modified.
+func (c *ETagCache) conditionalHeader(method, url string, header http.Header) http.Header {
+	var name string
+	switch method {
+	case http.MethodGet:
+		name = "If-None-Match"
+	case http.MethodPatch:
+		name = "If-Match"
+	default:
+		return header
+	}
+	if header.Get(name) != "" {
+		return header
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[url]
+	c.mu.Unlock()
+	if !ok {
+		return header
+	}
+
+	header = header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set(name, entry.etag)
+	return header
+}
+
+// update records resp, the response to a method request of url, and
+// returns the response to give the caller: for 304 Not Modified, the
+// cached response.
+func (c *ETagCache) update(method, url string, resp *http.Response) (*http.Response, error) {
+	if method != http.MethodGet && method != http.MethodHead {
+		// A write may change the resource; a failed conditional one
+		// means the cached copy is stale
+		c.remove(url)
+		return resp, nil
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		c.mu.Lock()
+		entry, ok := c.entries[url]
+		c.mu.Unlock()
+		if ok {
+			_ = resp.Body.Close()
+			cached := *resp
+			cached.StatusCode = http.StatusOK
+			cached.Status = "200 OK"
+			cached.Header = entry.header.Clone()
+			cached.Body = io.NopCloser(bytes.NewReader(entry.body))
+			cached.ContentLength = int64(len(entry.body))
+			return &cached, nil
+		}
+		// Evicted by a concurrent request since the header was set
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("HTTP 304 for %s, but the cached response was evicted", url)
+	}
+
+	etag := resp.Header.Get("ETag")
+	if method != http.MethodGet || resp.StatusCode != http.StatusOK || etag == "" {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	c.store(url, &cacheEntry{etag: etag, header: resp.Header.Clone(), body: body})
+	return resp, nil
+}
+
+// store caches entry for url, evicting the oldest entry if the cache is
+// full.
+func (c *ETagCache) store(url string, entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[url]; ok {
+		c.removeLocked(url)
+	}
+	for len(c.order) >= c.size {
+		c.removeLocked(c.order[0])
+	}
+	c.entries[url] = entry
+	c.order = append(c.order, url)
+}
+
+// remove drops the cached response of url, if any.
+func (c *ETagCache) remove(url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeLocked(url)
+}
+
+// removeLocked drops the cached response of url; c.mu must be held.
+func (c *ETagCache) removeLocked(url string) {
+	if _, ok := c.entries[url]; !ok {
+		return
+	}
+	delete(c.entries, url)
+	for i, u := range c.order {
+		if u == url {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// etagServer serves one collection document with an ETag that changes on
+// every successful PATCH, honoring If-None-Match and If-Match.
+type etagServer struct {
+	mu      sync.Mutex
+	version int
+	headers []http.Header
+}
+
+func (s *etagServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.headers = append(s.headers, r.Header.Clone())
+
+	etag := fmt.Sprintf(`"v%d"`, s.version)
+	switch r.Method {
+	case http.MethodGet:
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = fmt.Fprintf(w, `{"id":"c1","display_name":"v%d"}`, s.version)
+	case http.MethodPatch:
+		if match := r.Header.Get("If-Match"); match != "" && match != etag {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = w.Write([]byte(`{"code":"PreconditionFailed","detail":"stale"}`))
+			return
+		}
+		s.version++
+		_, _ = w.Write([]byte(`{"id":"c1"}`))
+	}
+}
+
+func TestETagCache_Revalidate(t *testing.T) {
+	srv := &etagServer{}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	cache := NewETagCache(0)
+	client := &Client{baseURL: server.URL + "/api/", httpClient: &http.Client{}, cache: cache}
+	ctx := context.Background()
+
+	for i := range 2 {
+		collection, err := client.GetCollection(ctx, "c1")
+		if err != nil {
+			t.Fatalf("GetCollection() #%d error = %v", i+1, err)
+		}
+		if collection.DisplayName != "v0" {
+			t.Errorf("GetCollection() #%d DisplayName = %q, want v0", i+1, collection.DisplayName)
+		}
+	}
+
+	if got := srv.headers[0].Get("If-None-Match"); got != "" {
+		t.Errorf("first GET If-None-Match = %q, want none", got)
+	}
+	if got := srv.headers[1].Get("If-None-Match"); got != `"v0"` {
+		t.Errorf("second GET If-None-Match = %q, want \"v0\"", got)
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", cache.Len())
+	}
+}
+
+func TestETagCache_IfMatch(t *testing.T) {
+	srv := &etagServer{}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	ctx := context.Background()
+	alice := &Client{baseURL: server.URL + "/api/", httpClient: &http.Client{}, cache: NewETagCache(0)}
+	bob := &Client{baseURL: server.URL + "/api/", httpClient: &http.Client{}, cache: NewETagCache(0)}
+
+	for _, client := range []*Client{alice, bob} {
+		if _, err := client.GetCollectionDocument(ctx, "c1"); err != nil {
+			t.Fatalf("GetCollectionDocument() error = %v", err)
+		}
+	}
+
+	if err := alice.PatchCollection(ctx, "c1", map[string]interface{}{"display_name": "a"}); err != nil {
+		t.Fatalf("first PatchCollection() error = %v", err)
+	}
+	err := bob.PatchCollection(ctx, "c1", map[string]interface{}{"display_name": "b"})
+	if !IsPreconditionFailed(err) {
+		t.Fatalf("stale PatchCollection() error = %v, want HTTP 412", err)
+	}
+
+	// The stale entry is dropped, so fetching again allows the update
+	if _, err := bob.GetCollectionDocument(ctx, "c1"); err != nil {
+		t.Fatalf("GetCollectionDocument() error = %v", err)
+	}
+	if err := bob.PatchCollection(ctx, "c1", map[string]interface{}{"display_name": "b"}); err != nil {
+		t.Errorf("PatchCollection() after refetch error = %v", err)
+	}
+}
+
+func TestETagCache_Evicts(t *testing.T) {
+	cache := NewETagCache(2)
+	for _, url := range []string{"a", "b", "c"} {
+		cache.store(url, &cacheEntry{etag: url})
+	}
+	if cache.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", cache.Len())
+	}
+	if got := cache.conditionalHeader(http.MethodGet, "a", nil).Get("If-None-Match"); got != "" {
+		t.Errorf("evicted entry still sent: %q", got)
+	}
+	if got := cache.conditionalHeader(http.MethodGet, "c", nil).Get("If-None-Match"); got != "c" {
+		t.Errorf("If-None-Match = %q, want c", got)
+	}
+}
@@ -1,12 +1,16 @@
 package gcs
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Client is a client for the Globus Connect Server Manager API.
@@ -17,8 +21,47 @@ type Client struct {
 	httpClient  *http.Client
 	accessToken string
 	userAgent   string
+	headers     http.Header
+	refresher   TokenRefresher
+	provider    TokenProvider
+	retry       RetryPolicy
+	limiter     *RateLimiter
+	breaker     *CircuitBreaker
+	logger      *slog.Logger
+	timing      TimingFunc
+	cache       *ETagCache
+	dryRun      DryRunFunc
+	tracer      Tracer
+	compressMin int
+
+	// resourceServerTokens are the candidate tokens of
+	// WithResourceServerTokens; resourceServer is the endpoint's resource
+	// server once selectToken has looked it up.
+	resourceServerTokens map[string]string
+	resourceServer       string
+	selected             bool
+
+	// info is the endpoint's info document once GetInfo has fetched it.
+	info *Info
+
+	// mu guards accessToken and resourceServer; refreshMu serializes
+	// token refreshes; selectMu serializes the resource server lookup;
+	// infoMu guards info.
+	mu        sync.Mutex
+	refreshMu sync.Mutex
+	selectMu  sync.Mutex
+	infoMu    sync.Mutex
 }
 
+// TokenProvider returns the access token to use for a request. It is
+// called before every request and must be safe for concurrent use.
+type TokenProvider func(ctx context.Context) (string, error)
+
+// TokenRefresher obtains a new access token after the server rejected the
+// current one with HTTP 401, for example because it was revoked. It is
+// called at most once per request.
+type TokenRefresher func(ctx context.Context) (string, error)
+
 // NewClient creates a new GCS Manager API client.
 // The endpointFQDN is the fully qualified domain name of the GCS endpoint
 // (e.g., "abc.def.data.globus.org").
@@ -34,31 +77,270 @@ func NewClient(endpointFQDN string, opts ...ClientOption) (*Client, error) {
 	for _, opt := range opts {
 		opt(options)
 	}
+	if options.err != nil {
+		return nil, options.err
+	}
 
 	// Construct base URL
 	baseURL := fmt.Sprintf("https://%s/api/", endpointFQDN)
 
 	client := &Client{
 		baseURL:     baseURL,
-		httpClient:  options.httpClient,
+		httpClient:  options.wrappedHTTPClient(),
 		accessToken: options.accessToken,
 		userAgent:   options.userAgent,
+		headers:     options.headers,
+		refresher:   options.tokenRefresher,
+		provider:    options.tokenProvider,
+		retry:       options.retry,
+		limiter:     options.rateLimiter,
+		breaker:     options.circuitBreaker,
+		logger:      options.logger,
+		timing:      options.timing,
+		cache:       options.cache,
+		dryRun:      options.dryRun,
+		tracer:      options.tracer,
+		compressMin: options.compressMin,
+
+		resourceServerTokens: options.resourceServerTokens,
+	}
+	if options.resourceServer != "" {
+		client.resourceServer, client.selected = options.resourceServer, true
+		if token, ok := options.resourceServerTokens[options.resourceServer]; ok {
+			client.accessToken = token
+		}
 	}
 
 	return client, nil
 }
 
+// NewHTTPClient returns the HTTP client that NewClient would use with
+// opts: its timeout, TLS configuration, proxy, and transport wrappers.
+// Clients of other Globus services use it to connect the way a GCS client
+// does. Options that only concern GCS API requests are ignored.
+func NewHTTPClient(opts ...ClientOption) (*http.Client, error) {
+	options := defaultOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.err != nil {
+		return nil, options.err
+	}
+	return options.wrappedHTTPClient(), nil
+}
+
+// wrappedHTTPClient returns the HTTP client of the options with the
+// transport wrappers applied.
+func (o *clientOptions) wrappedHTTPClient() *http.Client {
+	if len(o.transportWrappers) == 0 {
+		return o.httpClient
+	}
+	wrapped := *o.httpClient
+	transport := wrapped.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for _, wrap := range o.transportWrappers {
+		transport = wrap(transport)
+	}
+	wrapped.Transport = transport
+	return &wrapped
+}
+
+// ResourceServer returns the Globus Auth resource server of the endpoint,
+// its endpoint ID. It is known from the start for a client created with
+// WithResourceServer, after the first request of a client created with
+// WithResourceServerTokens, and empty otherwise.
+func (c *Client) ResourceServer() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.resourceServer
+}
+
+// selectToken switches to the token of the endpoint's resource server, if
+// one of the tokens from WithResourceServerTokens matches. The lookup is
+// done once per client; a failed lookup keeps the current token.
+func (c *Client) selectToken(ctx context.Context) {
+	c.selectMu.Lock()
+	defer c.selectMu.Unlock()
+	if c.selected {
+		return
+	}
+	c.selected = true
+
+	info, err := c.GetInfo(ctx)
+	if err != nil || info.EndpointID == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resourceServer = info.EndpointID
+	if token, ok := c.resourceServerTokens[info.EndpointID]; ok {
+		c.accessToken = token
+	}
+}
+
 // SetAccessToken sets the access token for authentication.
 // This can be used to update the token after the client is created.
 func (c *Client) SetAccessToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.accessToken = token
 }
 
+// token returns the current access token.
+func (c *Client) token() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.accessToken
+}
+
+// requestToken returns the access token for a request: the
+// TokenProvider's if the client has one, otherwise the current token.
+func (c *Client) requestToken(ctx context.Context) (string, error) {
+	if c.provider == nil {
+		return c.token(), nil
+	}
+	token, err := c.provider(ctx)
+	if err != nil {
+		return "", fmt.Errorf("get access token: %w", err)
+	}
+	return token, nil
+}
+
+// refreshToken replaces a rejected access token using the configured
+// TokenRefresher. If another request already replaced it, the new token
+// is returned without refreshing again.
+func (c *Client) refreshToken(ctx context.Context, rejected string) (string, error) {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	if c.provider != nil {
+		// The refresher updates what the provider returns
+		if _, err := c.refresher(ctx); err != nil {
+			return "", err
+		}
+		return c.requestToken(ctx)
+	}
+	if current := c.token(); current != rejected {
+		return current, nil
+	}
+
+	token, err := c.refresher(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.SetAccessToken(token)
+	return token, nil
+}
+
 // doRequest performs an HTTP request with authentication.
 func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	return c.doRequestWithHeaders(ctx, method, path, body, nil)
+}
+
+// doRequestWithHeaders performs an HTTP request with authentication and
+// additional request headers.
+func (c *Client) doRequestWithHeaders(ctx context.Context, method, path string, body io.Reader, header http.Header) (*http.Response, error) {
 	// Construct full URL
 	url := c.baseURL + strings.TrimPrefix(path, "/")
 
+	return c.traced(ctx, method, url, header, func(ctx context.Context, header http.Header) (*http.Response, error) {
+		return c.do(ctx, method, path, url, body, header)
+	})
+}
+
+// do performs the request of doRequestWithHeaders.
+func (c *Client) do(ctx context.Context, method, path, url string, body io.Reader, header http.Header) (*http.Response, error) {
+	if c.dryRun != nil && isWrite(ctx, method) {
+		return nil, c.skipWrite(method, url, body)
+	}
+
+	// Buffer the body so the request can be replayed after a token
+	// refresh or a transient failure
+	var payload []byte
+	if body != nil && c.compressMin > 0 {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("read request body: %w", err)
+		}
+		if len(data) >= c.compressMin {
+			if data, err = compressBody(data); err != nil {
+				return nil, err
+			}
+			header = header.Clone()
+			if header == nil {
+				header = http.Header{}
+			}
+			header.Set("Content-Encoding", "gzip")
+		}
+		payload = data
+		body = bytes.NewReader(payload)
+	} else if body != nil && (c.refresher != nil || c.retry.enabled()) {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("read request body: %w", err)
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	// The info document needs no token and tells which one to use
+	if len(c.resourceServerTokens) > 0 && path != "info" {
+		c.selectToken(ctx)
+	}
+
+	if c.cache != nil {
+		header = c.cache.conditionalHeader(method, url, header)
+	}
+
+	token, err := c.requestToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendWithRetry(ctx, method, url, body, payload, header, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Refresh a rejected token once and retry
+	if resp.StatusCode == http.StatusUnauthorized && c.refresher != nil {
+		apiErr := newAPIError(resp)
+
+		token, err = c.refreshToken(ctx, token)
+		if err != nil {
+			return nil, fmt.Errorf("%w (re-authentication failed: %v)", apiErr, err)
+		}
+
+		if payload != nil {
+			body = bytes.NewReader(payload)
+		}
+		if resp, err = c.sendWithRetry(ctx, method, url, body, payload, header, token); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.cache != nil {
+		if resp, err = c.cache.update(method, url, resp); err != nil {
+			return nil, err
+		}
+	}
+
+	// Check for HTTP errors
+	if resp.StatusCode >= 400 {
+		return nil, newAPIError(resp)
+	}
+
+	return resp, nil
+}
+
+// send builds and executes a single HTTP request.
+func (c *Client) send(ctx context.Context, method, url string, body io.Reader, header http.Header, token string) (*http.Response, error) {
+	logDebug := c.logEnabled(ctx, slog.LevelDebug)
+	var timer *requestTimer
+	if logDebug || c.timing != nil {
+		timer, ctx = newRequestTimer(ctx)
+	}
+
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
@@ -67,29 +349,73 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 
 	// Set headers
 	req.Header.Set("User-Agent", c.userAgent)
-	if c.accessToken != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.accessToken))
+	if token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	}
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	if acceptGzip {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	for key, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(req)
+	if c.logEnabled(ctx, LevelTrace) {
+		c.traceRequest(ctx, req)
+	}
+	resp, err := c.httpClientFor(ctx).Do(req)
+	if err == nil && acceptGzip {
+		err = decompressResponse(resp)
+	}
+	if timer != nil {
+		timing := timer.finish(req, resp)
+		if logDebug {
+			c.logResponse(ctx, req, resp, err, timing)
+		}
+		if c.timing != nil {
+			c.timing(timing)
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("execute request: %w", err)
 	}
 
-	// Check for HTTP errors
-	if resp.StatusCode >= 400 {
-		defer func() { _ = resp.Body.Close() }()
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(bodyBytes))
-	}
-
 	return resp, nil
 }
 
+// requestTimeoutKey is the context key of WithRequestTimeout.
+type requestTimeoutKey struct{}
+
+// WithRequestTimeout returns a copy of ctx in which requests use timeout
+// instead of the client's WithTimeout, for example to allow a long-running
+// operation more time than the quick requests around it. A timeout of 0
+// means none; a deadline of ctx still applies.
+func WithRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutKey{}, timeout)
+}
+
+// httpClientFor returns the HTTP client for a request made with ctx,
+// with the timeout of WithRequestTimeout if ctx has one.
+func (c *Client) httpClientFor(ctx context.Context) *http.Client {
+	timeout, ok := ctx.Value(requestTimeoutKey{}).(time.Duration)
+	if !ok || timeout == c.httpClient.Timeout {
+		return c.httpClient
+	}
+	client := *c.httpClient
+	client.Timeout = timeout
+	return &client
+}
+
 // decodeResponse decodes a JSON response into the target struct.
 func (c *Client) decodeResponse(resp *http.Response, target interface{}) error {
 	defer func() { _ = resp.Body.Close() }()
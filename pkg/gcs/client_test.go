@@ -2,8 +2,12 @@ package gcs
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -193,3 +197,366 @@ func TestDoRequest(t *testing.T) {
 		}
 	})
 }
+
+func TestClient_WithHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-GCS-Annotation-Ticket"); got != "CHG12345" {
+			t.Errorf("X-GCS-Annotation-Ticket = %q, want %q", got, "CHG12345")
+		}
+		if got := r.Header.Values("X-Site"); len(got) != 2 {
+			t.Errorf("X-Site values = %v, want 2 values", got)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("unused.example.org",
+		WithHTTPClient(&http.Client{}),
+		WithHeader("X-GCS-Annotation-Ticket", "CHG12345"),
+		WithHeader("X-Site", "a"),
+		WithHeader("X-Site", "b"),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	client.baseURL = server.URL + "/"
+
+	resp, err := client.doRequest(context.Background(), http.MethodGet, "test", nil)
+	if err != nil {
+		t.Fatalf("doRequest() error: %v", err)
+	}
+	_ = resp.Body.Close()
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClient_WithTransportWrapper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Wrapped"); got != "outer,inner" {
+			t.Errorf("X-Wrapped = %q, want %q", got, "outer,inner")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tag := func(name string) func(http.RoundTripper) http.RoundTripper {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				if prev := req.Header.Get("X-Wrapped"); prev != "" {
+					name = prev + "," + name
+				}
+				req.Header.Set("X-Wrapped", name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	httpClient := &http.Client{}
+	client, err := NewClient("unused.example.org",
+		WithHTTPClient(httpClient),
+		WithTransportWrapper(tag("inner")),
+		WithTransportWrapper(tag("outer")),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	client.baseURL = server.URL + "/"
+
+	resp, err := client.doRequest(context.Background(), http.MethodGet, "test", nil)
+	if err != nil {
+		t.Fatalf("doRequest() error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if httpClient.Transport != nil {
+		t.Error("WithTransportWrapper modified the HTTP client passed to WithHTTPClient")
+	}
+}
+
+func TestClient_TokenRefresher(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+
+		if r.Header.Get("Authorization") != "Bearer new-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code": "unauthorized"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	refreshes := 0
+	client := &Client{
+		baseURL:     server.URL + "/api/",
+		httpClient:  &http.Client{},
+		accessToken: "revoked-token",
+		userAgent:   "test-agent",
+		refresher: func(context.Context) (string, error) {
+			refreshes++
+			return "new-token", nil
+		},
+	}
+
+	resp, err := client.doRequest(context.Background(), http.MethodPatch, "collections/col-1", strings.NewReader(`{"public": true}`))
+	if err != nil {
+		t.Fatalf("doRequest() error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if refreshes != 1 {
+		t.Errorf("refresher called %d times, want 1", refreshes)
+	}
+	if len(bodies) != 2 || bodies[1] != `{"public": true}` {
+		t.Errorf("request bodies = %q, want the body replayed on retry", bodies)
+	}
+
+	// The refreshed token is kept for later requests
+	resp, err = client.doRequest(context.Background(), http.MethodGet, "endpoint", nil)
+	if err != nil {
+		t.Fatalf("doRequest() after refresh error: %v", err)
+	}
+	_ = resp.Body.Close()
+	if refreshes != 1 {
+		t.Errorf("refresher called %d times after a successful refresh, want 1", refreshes)
+	}
+}
+
+func TestClient_WithResourceServerTokens(t *testing.T) {
+	var auths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auths = append(auths, r.URL.Path+" "+r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/info" {
+			_, _ = w.Write([]byte(`{"endpoint_id": "ep-1"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("example.org",
+		WithHTTPClient(&http.Client{}),
+		WithAccessToken("transfer-token"),
+		WithResourceServerTokens(map[string]string{
+			"transfer.api.globus.org": "transfer-token",
+			"ep-1":                    "gcs-token",
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.baseURL = server.URL + "/api/"
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.doRequest(context.Background(), http.MethodGet, "endpoint", nil)
+		if err != nil {
+			t.Fatalf("doRequest() error: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	want := []string{
+		"/api/info Bearer transfer-token",
+		"/api/endpoint Bearer gcs-token",
+		"/api/endpoint Bearer gcs-token",
+	}
+	if strings.Join(auths, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %q, want %q", auths, want)
+	}
+	if got := client.ResourceServer(); got != "ep-1" {
+		t.Errorf("ResourceServer() = %q, want ep-1", got)
+	}
+}
+
+func TestClient_WithResourceServer(t *testing.T) {
+	var auths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auths = append(auths, r.URL.Path+" "+r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("example.org",
+		WithHTTPClient(&http.Client{}),
+		WithAccessToken("transfer-token"),
+		WithResourceServer("ep-2"),
+		WithResourceServerTokens(map[string]string{
+			"ep-1": "gcs-token-1",
+			"ep-2": "gcs-token-2",
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.baseURL = server.URL + "/api/"
+
+	resp, err := client.doRequest(context.Background(), http.MethodGet, "endpoint", nil)
+	if err != nil {
+		t.Fatalf("doRequest() error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if want := []string{"/api/endpoint Bearer gcs-token-2"}; strings.Join(auths, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %q, want %q (no info lookup)", auths, want)
+	}
+	if got := client.ResourceServer(); got != "ep-2" {
+		t.Errorf("ResourceServer() = %q, want ep-2", got)
+	}
+}
+
+func TestClient_WithTokenProvider(t *testing.T) {
+	var auths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auths = append(auths, r.URL.Path+" "+r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/info":
+			_, _ = w.Write([]byte(`{"endpoint_id": "ep-1"}`))
+		case r.Header.Get("Authorization") == "Bearer ep-1-token-3":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code": "unauthorized"}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	// The provider hands out a new token per request, as if each expired,
+	// and asks the client for its resource server while the client looks
+	// it up
+	var (
+		client   *Client
+		issued   int
+		rejected bool
+	)
+	provider := func(context.Context) (string, error) {
+		issued++
+		if rejected {
+			return "refreshed-token", nil
+		}
+		return fmt.Sprintf("%s-token-%d", client.ResourceServer(), issued), nil
+	}
+	refresher := func(context.Context) (string, error) {
+		rejected = true
+		return "ignored", nil
+	}
+
+	var err error
+	client, err = NewClient("example.org",
+		WithHTTPClient(&http.Client{}),
+		WithAccessToken("static-token"),
+		WithResourceServerTokens(map[string]string{"ep-1": "static-token"}),
+		WithTokenProvider(provider),
+		WithTokenRefresher(refresher))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.baseURL = server.URL + "/api/"
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.doRequest(context.Background(), http.MethodGet, "endpoint", nil)
+		if err != nil {
+			t.Fatalf("doRequest() error: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	want := []string{
+		"/api/info Bearer -token-1",
+		"/api/endpoint Bearer ep-1-token-2",
+		"/api/endpoint Bearer ep-1-token-3",
+		"/api/endpoint Bearer refreshed-token",
+	}
+	if strings.Join(auths, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %q, want %q", auths, want)
+	}
+}
+
+func TestClient_WithTokenProviderError(t *testing.T) {
+	client, err := NewClient("example.org", WithTokenProvider(func(context.Context) (string, error) {
+		return "", errors.New("token store locked")
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.doRequest(context.Background(), http.MethodGet, "endpoint", nil)
+	if err == nil || !strings.Contains(err.Error(), "get access token: token store locked") {
+		t.Errorf("doRequest() error = %v, want the provider error", err)
+	}
+}
+
+func TestClient_TokenRefresherFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:     server.URL + "/api/",
+		httpClient:  &http.Client{},
+		accessToken: "revoked-token",
+		userAgent:   "test-agent",
+		refresher: func(context.Context) (string, error) {
+			return "", errors.New("no refresh token")
+		},
+	}
+
+	_, err := client.doRequest(context.Background(), http.MethodGet, "endpoint", nil)
+	if err == nil || !strings.Contains(err.Error(), "HTTP 401") || !strings.Contains(err.Error(), "no refresh token") {
+		t.Errorf("doRequest() error = %v, want HTTP 401 with the refresh error", err)
+	}
+}
+
+func TestClient_WithRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"id":"c1"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test.example.org", WithTimeout(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	client.baseURL = server.URL + "/api/"
+
+	if _, err := client.GetCollection(context.Background(), "c1"); err == nil {
+		t.Fatal("GetCollection() error = nil, want the client timeout")
+	}
+
+	ctx := WithRequestTimeout(context.Background(), 5*time.Second)
+	if _, err := client.GetCollection(ctx, "c1"); err != nil {
+		t.Errorf("GetCollection() with a request timeout error = %v", err)
+	}
+	if client.httpClient.Timeout != 20*time.Millisecond {
+		t.Errorf("httpClient.Timeout = %v; WithRequestTimeout must not change the client", client.httpClient.Timeout)
+	}
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	t.Setenv("NO_PROXY", "")
+	client, err := NewHTTPClient(WithTimeout(5*time.Second), WithProxy("http://proxy.example.org:3128"))
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+	if client.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %v, want 5s", client.Timeout)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://transfer.api.globus.org/v0.10/task_list", nil)
+	if u, err := client.Transport.(*http.Transport).Proxy(req); err != nil || u == nil || u.Host != "proxy.example.org:3128" {
+		t.Errorf("Proxy() = %v, %v, want the configured proxy", u, err)
+	}
+
+	if _, err := NewHTTPClient(WithProxy("ftp://proxy")); err == nil {
+		t.Error("NewHTTPClient() with an ftp proxy succeeded, want an error")
+	}
+}
@@ -5,8 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
+	"strconv"
+	"time"
 )
 
 // ListCollectionsOptions contains options for listing collections.
@@ -14,6 +17,52 @@ type ListCollectionsOptions struct {
 	Filter   string // Filter collections by name
 	PageSize int    // Number of results per page
 	Marker   string // Pagination marker
+
+	// StorageGatewayID limits results to collections using this gateway.
+	StorageGatewayID string
+
+	// CollectionType limits results to "mapped" or "guest" collections.
+	CollectionType string
+
+	// Public, if set, limits results to public (true) or private (false)
+	// collections.
+	Public *bool
+
+	// CreatedAfter, CreatedBefore, ModifiedAfter, and ModifiedBefore limit
+	// results by creation and last modification time. Zero times are
+	// ignored.
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
+	ModifiedAfter  time.Time
+	ModifiedBefore time.Time
+}
+
+// matches reports whether collection meets opts' type, visibility, gateway,
+// and time limits. Times the collection does not report are not checked.
+func (opts *ListCollectionsOptions) matches(collection *Collection) bool {
+	if opts.StorageGatewayID != "" && collection.StorageGatewayID != "" && collection.StorageGatewayID != opts.StorageGatewayID {
+		return false
+	}
+	if opts.CollectionType != "" && collection.CollectionType != "" && collection.CollectionType != opts.CollectionType {
+		return false
+	}
+	if opts.Public != nil && collection.Public != *opts.Public {
+		return false
+	}
+	return inTimeRange(collection.CreatedAt, opts.CreatedAfter, opts.CreatedBefore) &&
+		inTimeRange(collection.LastModified, opts.ModifiedAfter, opts.ModifiedBefore)
+}
+
+// inTimeRange reports whether t is after after and before before, ignoring
+// zero bounds. A nil t is in every range.
+func inTimeRange(t *time.Time, after, before time.Time) bool {
+	if t == nil {
+		return true
+	}
+	if !after.IsZero() && !t.After(after) {
+		return false
+	}
+	return before.IsZero() || t.Before(before)
 }
 
 // CollectionList represents a paginated list of collections.
@@ -38,6 +87,19 @@ func (c *Client) ListCollections(ctx context.Context, opts *ListCollectionsOptio
 		if opts.Marker != "" {
 			query.Set("marker", opts.Marker)
 		}
+		if opts.StorageGatewayID != "" {
+			query.Set("storage_gateway_id", opts.StorageGatewayID)
+		}
+		if opts.CollectionType != "" {
+			query.Set("collection_type", opts.CollectionType)
+		}
+		if opts.Public != nil {
+			query.Set("public", strconv.FormatBool(*opts.Public))
+		}
+		setTimeQuery(query, "created_after", opts.CreatedAfter)
+		setTimeQuery(query, "created_before", opts.CreatedBefore)
+		setTimeQuery(query, "modified_after", opts.ModifiedAfter)
+		setTimeQuery(query, "modified_before", opts.ModifiedBefore)
 	}
 
 	path := "collections"
@@ -55,9 +117,84 @@ func (c *Client) ListCollections(ctx context.Context, opts *ListCollectionsOptio
 		return nil, err
 	}
 
+	// Older GCS versions ignore some of the filters
+	if opts != nil {
+		data := list.Data[:0]
+		for _, collection := range list.Data {
+			if opts.matches(&collection) {
+				data = append(data, collection)
+			}
+		}
+		list.Data = data
+	}
+
 	return &list, nil
 }
 
+// setTimeQuery sets key to t in RFC 3339 format, unless t is zero.
+func setTimeQuery(query url.Values, key string, t time.Time) {
+	if !t.IsZero() {
+		query.Set(key, t.UTC().Format(time.RFC3339))
+	}
+}
+
+// Collections returns an iterator over every collection matching opts,
+// following pagination markers. It starts at the page of opts.Marker, or
+// the first page if it is empty.
+func (c *Client) Collections(ctx context.Context, opts *ListCollectionsOptions) iter.Seq2[Collection, error] {
+	pageOpts := ListCollectionsOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	return PaginateFrom(ctx, pageOpts.Marker, func(ctx context.Context, marker string) ([]Collection, string, bool, error) {
+		pageOpts.Marker = marker
+		list, err := c.ListCollections(ctx, &pageOpts)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return list.Data, list.Marker, list.HasNextPage, nil
+	})
+}
+
+// ListAllCollections returns every collection matching opts, following
+// pagination markers from opts.Marker.
+func (c *Client) ListAllCollections(ctx context.Context, opts *ListCollectionsOptions) ([]Collection, error) {
+	return CollectAll(c.Collections(ctx, opts))
+}
+
+// ListCollectionsForGateway returns every collection that uses the given
+// storage gateway, following pagination markers.
+//
+// Results are also filtered client-side, since older GCS versions ignore
+// the storage_gateway_id query parameter.
+func (c *Client) ListCollectionsForGateway(ctx context.Context, gatewayID string) ([]Collection, error) {
+	all, err := c.ListAllCollections(ctx, &ListCollectionsOptions{StorageGatewayID: gatewayID})
+	if err != nil {
+		return nil, err
+	}
+
+	var collections []Collection
+	for _, collection := range all {
+		if collection.StorageGatewayID == gatewayID {
+			collections = append(collections, collection)
+		}
+	}
+	return collections, nil
+}
+
+// SetCollectionUserMessage sets the message (and link) shown to users of a
+// collection. Empty values clear the message.
+func (c *Client) SetCollectionUserMessage(ctx context.Context, collectionID, message, link string) error {
+	if collectionID == "" {
+		return fmt.Errorf("collection ID is required")
+	}
+
+	return c.PatchCollection(ctx, collectionID, map[string]interface{}{
+		"user_message":      message,
+		"user_message_link": link,
+	})
+}
+
 // GetCollection retrieves a specific collection by ID.
 func (c *Client) GetCollection(ctx context.Context, collectionID string) (*Collection, error) {
 	if collectionID == "" {
@@ -172,7 +309,7 @@ func (c *Client) CheckCollection(ctx context.Context, collectionID string) (*Col
 	}
 
 	path := fmt.Sprintf("collections/%s/check", collectionID)
-	resp, err := c.doRequest(ctx, http.MethodPost, path, nil)
+	resp, err := c.doRequest(readOnly(ctx), http.MethodPost, path, nil)
 	if err != nil {
 		return nil, fmt.Errorf("check collection: %w", err)
 	}
@@ -306,6 +443,10 @@ func (c *Client) SetSubscriptionAdminVerified(ctx context.Context, collectionID
 		return fmt.Errorf("collection ID is required")
 	}
 
+	if err := c.requireAPIVersion(ctx, "subscription admin verification", MinAPIVersionSubscriptionAdminVerified); err != nil {
+		return fmt.Errorf("set subscription admin verified: %w", err)
+	}
+
 	payload := map[string]bool{
 		"subscription_admin_verified": verified,
 	}
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestListCollections(t *testing.T) {
@@ -89,6 +90,110 @@ func TestListCollections(t *testing.T) {
 	})
 }
 
+func TestListCollections_Filters(t *testing.T) {
+	created := func(day int) *time.Time {
+		t := time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		want := map[string]string{
+			"storage_gateway_id": "gateway-1",
+			"collection_type":    "mapped",
+			"public":             "false",
+			"created_after":      "2026-01-05T00:00:00Z",
+			"modified_before":    "2026-02-01T00:00:00Z",
+		}
+		for key, value := range want {
+			if got := query.Get(key); got != value {
+				t.Errorf("query %s = %q, want %q", key, got, value)
+			}
+		}
+		if query.Has("created_before") {
+			t.Errorf("query = %q, want no created_before for a zero time", r.URL.RawQuery)
+		}
+
+		// Answer like a server that ignores the filters
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(CollectionList{Data: []Collection{
+			{ID: "match", CollectionType: "mapped", StorageGatewayID: "gateway-1", CreatedAt: created(10)},
+			{ID: "no-timestamps", CollectionType: "mapped", StorageGatewayID: "gateway-1"},
+			{ID: "guest", CollectionType: "guest", StorageGatewayID: "gateway-1"},
+			{ID: "public", CollectionType: "mapped", StorageGatewayID: "gateway-1", Public: true},
+			{ID: "other-gateway", CollectionType: "mapped", StorageGatewayID: "gateway-2"},
+			{ID: "too-old", CollectionType: "mapped", StorageGatewayID: "gateway-1", CreatedAt: created(1)},
+		}})
+	}))
+	defer server.Close()
+
+	client := &Client{baseURL: server.URL + "/api/", httpClient: &http.Client{}}
+	private := false
+	list, err := client.ListCollections(context.Background(), &ListCollectionsOptions{
+		StorageGatewayID: "gateway-1",
+		CollectionType:   "mapped",
+		Public:           &private,
+		CreatedAfter:     *created(5),
+		ModifiedBefore:   time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("ListCollections() error = %v", err)
+	}
+
+	var ids []string
+	for _, collection := range list.Data {
+		ids = append(ids, collection.ID)
+	}
+	if strings.Join(ids, ",") != "match,no-timestamps" {
+		t.Errorf("collections = %v, want match, no-timestamps", ids)
+	}
+}
+
+func TestListCollectionsForGateway(t *testing.T) {
+	pages := map[string]CollectionList{
+		"": {
+			Data: []Collection{
+				{ID: "collection-1", StorageGatewayID: "gateway-1"},
+				{ID: "collection-2", StorageGatewayID: "gateway-2"},
+			},
+			HasNextPage: true,
+			Marker:      "page-2",
+		},
+		"page-2": {
+			Data: []Collection{
+				{ID: "collection-3", StorageGatewayID: "gateway-1"},
+			},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("storage_gateway_id"); got != "gateway-1" {
+			t.Errorf("storage_gateway_id = %q, want %q", got, "gateway-1")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(pages[r.URL.Query().Get("marker")])
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:    server.URL + "/api/",
+		httpClient: &http.Client{},
+		userAgent:  "test-agent",
+	}
+
+	collections, err := client.ListCollectionsForGateway(context.Background(), "gateway-1")
+	if err != nil {
+		t.Fatalf("ListCollectionsForGateway() error: %v", err)
+	}
+
+	if len(collections) != 2 {
+		t.Fatalf("ListCollectionsForGateway() returned %d collections, want 2", len(collections))
+	}
+	if collections[0].ID != "collection-1" || collections[1].ID != "collection-3" {
+		t.Errorf("ListCollectionsForGateway() IDs = %q, %q, want collection-1, collection-3", collections[0].ID, collections[1].ID)
+	}
+}
+
 func TestGetCollection(t *testing.T) {
 	expectedCollection := &Collection{
 		ID:               "test-collection-id",
@@ -0,0 +1,87 @@
+package gcs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+)
+
+// acceptGzip reports whether the client asks for gzip responses and
+// decompresses them itself. In the browser, fetch negotiates compression
+// and hands over decompressed bodies.
+var acceptGzip = runtime.GOOS != "js"
+
+// DefaultCompressionThreshold is a request body size above which
+// compression usually pays off.
+const DefaultCompressionThreshold = 8 << 10
+
+// gzipBody is a response body decompressed from gzip.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	_ = b.Reader.Close()
+	return b.body.Close()
+}
+
+// decompressResponse replaces a gzip-encoded body of resp with its
+// decompressed content.
+func decompressResponse(resp *http.Response) error {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+
+	reader, err := gzip.NewReader(resp.Body)
+	if errors.Is(err, io.EOF) {
+		// No body, as in a 304 or HEAD response
+		return nil
+	}
+	if err != nil {
+		_ = resp.Body.Close()
+		return fmt.Errorf("decompress response: %w", err)
+	}
+	resp.Body = &gzipBody{Reader: reader, body: resp.Body}
+	return nil
+}
+
+// compressBody returns data compressed with gzip.
+func compressBody(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, fmt.Errorf("compress request body: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("compress request body: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeBody returns a request body for logging, decompressing it if
+// header says it is gzip-encoded.
+func decodeBody(header http.Header, data []byte) []byte {
+	if !strings.EqualFold(header.Get("Content-Encoding"), "gzip") {
+		return data
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return data
+	}
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		return data
+	}
+	return decoded
+}
@@ -0,0 +1,101 @@
+package gcs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func gzipData(t *testing.T, data string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, _ = writer.Write([]byte(data))
+	if err := writer.Close(); err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestClient_GzipResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("Accept-Encoding = %q, want gzip", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		if r.URL.Path == "/api/collections/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write(gzipData(t, `{"code":"NotFound","detail":"no such collection"}`))
+			return
+		}
+		_, _ = w.Write(gzipData(t, `{"id":"c1","display_name":"Archive"}`))
+	}))
+	defer server.Close()
+
+	client := &Client{baseURL: server.URL + "/api/", httpClient: server.Client()}
+
+	coll, err := client.GetCollection(context.Background(), "c1")
+	if err != nil {
+		t.Fatalf("GetCollection() error = %v", err)
+	}
+	if coll.DisplayName != "Archive" {
+		t.Errorf("DisplayName = %q, want Archive", coll.DisplayName)
+	}
+
+	_, err = client.GetCollection(context.Background(), "missing")
+	if !IsNotFound(err) || !strings.Contains(err.Error(), "no such collection") {
+		t.Errorf("GetCollection() error = %v, want the decompressed API error", err)
+	}
+}
+
+func TestWithRequestCompression(t *testing.T) {
+	var encodings, bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := r.Header.Get("Content-Encoding")
+		var body io.Reader = r.Body
+		if encoding == "gzip" {
+			reader, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Fatalf("gzip.NewReader() error = %v", err)
+			}
+			body = reader
+		}
+		data, _ := io.ReadAll(body)
+		encodings = append(encodings, encoding)
+		bodies = append(bodies, string(data))
+
+		if len(encodings) == 2 {
+			// Fail once to check the compressed body is replayed
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"c1"}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:     server.URL + "/api/",
+		httpClient:  server.Client(),
+		compressMin: 64,
+		retry:       RetryPolicy{MaxAttempts: 2, StatusCodes: []int{http.StatusServiceUnavailable}},
+	}
+
+	if err := client.SetEndpointOwnerString(context.Background(), "short"); err != nil {
+		t.Fatalf("SetEndpointOwnerString() error = %v", err)
+	}
+	if err := client.SetEndpointOwnerString(context.Background(), strings.Repeat("x", 100)); err != nil {
+		t.Fatalf("SetEndpointOwnerString() error = %v", err)
+	}
+
+	if want := []string{"", "gzip", "gzip"}; strings.Join(encodings, ",") != strings.Join(want, ",") {
+		t.Errorf("Content-Encoding = %q, want %q", encodings, want)
+	}
+	if !strings.Contains(bodies[1], strings.Repeat("x", 100)) || bodies[1] != bodies[2] {
+		t.Errorf("bodies = %q, want the long body sent twice", bodies)
+	}
+}
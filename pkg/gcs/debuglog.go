@@ -0,0 +1,106 @@
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// LevelTrace is the slog level below slog.LevelDebug at which the client
+// also logs request and response headers and bodies.
+const LevelTrace = slog.LevelDebug - 4
+
+// redacted replaces secret values in logged headers and bodies.
+const redacted = "REDACTED"
+
+// secretHeaders are the request headers whose values are never logged.
+var secretHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+}
+
+// secretFieldPattern matches JSON string fields that hold tokens,
+// secrets, or passwords, such as "access_token" or "secret_access_key".
+var secretFieldPattern = regexp.MustCompile(`("[A-Za-z_]*(?:token|secret|password|passphrase|private_key)[A-Za-z_]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// logEnabled reports whether the client logs at level.
+func (c *Client) logEnabled(ctx context.Context, level slog.Level) bool {
+	return c.logger != nil && c.logger.Enabled(ctx, level)
+}
+
+// traceRequest logs the headers and body of req at LevelTrace, leaving
+// the body readable.
+func (c *Client) traceRequest(ctx context.Context, req *http.Request) {
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.RequestURI()),
+		slog.Any("header", redactHeader(req.Header)),
+	}
+	if req.Body != nil {
+		data, _ := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(data))
+		attrs = append(attrs, slog.String("body", redactBody(decodeBody(req.Header, data))))
+	}
+	c.logger.LogAttrs(ctx, LevelTrace, "HTTP request", attrs...)
+}
+
+// logResponse logs the outcome of req: a line with the status, timing,
+// and request ID at slog.LevelDebug, and the headers and body at
+// LevelTrace, leaving the body readable.
+func (c *Client) logResponse(ctx context.Context, req *http.Request, resp *http.Response, err error, timing RequestTiming) {
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.RequestURI()),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Duration("duration", timing.Total), slog.String("error", err.Error()))
+		c.logger.LogAttrs(ctx, slog.LevelDebug, "HTTP request failed", attrs...)
+		return
+	}
+
+	attrs = append(attrs, slog.Int("status", resp.StatusCode), slog.Duration("duration", timing.Total))
+	if id := requestID(resp.Header); id != "" {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+	attrs = append(attrs,
+		slog.Duration("dns", timing.DNSLookup),
+		slog.Duration("connect", timing.Connect),
+		slog.Duration("tls", timing.TLSHandshake),
+		slog.Duration("ttfb", timing.TimeToFirstByte),
+		slog.Bool("reused", timing.ConnectionReused))
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "HTTP response", attrs...)
+
+	if c.logEnabled(ctx, LevelTrace) {
+		data, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		c.logger.LogAttrs(ctx, LevelTrace, "HTTP response body",
+			slog.Any("header", redactHeader(resp.Header)),
+			slog.String("body", redactBody(data)))
+	}
+}
+
+// redactHeader returns header as a map for logging, with the values of
+// secret headers redacted.
+func redactHeader(header http.Header) map[string]string {
+	out := make(map[string]string, len(header))
+	for key, values := range header {
+		value := strings.Join(values, ", ")
+		if secretHeaders[http.CanonicalHeaderKey(key)] {
+			value = redacted
+		}
+		out[key] = value
+	}
+	return out
+}
+
+// redactBody returns a JSON body for logging, with the values of fields
+// that look like secrets redacted.
+func redactBody(body []byte) string {
+	return secretFieldPattern.ReplaceAllString(string(body), `${1}"`+redacted+`"`)
+}
@@ -0,0 +1,82 @@
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newLogTestClient returns a client for a server that answers every
+// request with a secret-bearing body, logging at level to the returned
+// buffer.
+func newLogTestClient(t *testing.T, level slog.Level) (*Client, *bytes.Buffer) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Request-Id", "req-42")
+		_, _ = w.Write([]byte(`{"id":"c1","client_secret":"s3cret"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: level}))
+	client := &Client{
+		baseURL:     server.URL + "/api/",
+		httpClient:  &http.Client{},
+		accessToken: "bearer-token-value",
+		logger:      logger,
+	}
+	return client, &buf
+}
+
+func TestWithLogger_Debug(t *testing.T) {
+	client, buf := newLogTestClient(t, slog.LevelDebug)
+
+	if _, err := client.GetCollection(context.Background(), "c1"); err != nil {
+		t.Fatalf("GetCollection() error = %v", err)
+	}
+
+	log := buf.String()
+	for _, want := range []string{"method=GET", "path=/api/collections/c1", "status=200", "duration=", "request_id=req-42"} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log missing %q:\n%s", want, log)
+		}
+	}
+	if strings.Contains(log, "client_secret") {
+		t.Errorf("debug log contains the body:\n%s", log)
+	}
+}
+
+func TestWithLogger_TraceRedacts(t *testing.T) {
+	client, buf := newLogTestClient(t, LevelTrace)
+
+	collection, err := client.CreateCollection(context.Background(), &Collection{DisplayName: "x"})
+	if err != nil {
+		t.Fatalf("CreateCollection() error = %v", err)
+	}
+	if collection.ID != "c1" {
+		t.Errorf("ID = %q; the logged response body must stay readable", collection.ID)
+	}
+
+	log := buf.String()
+	if !strings.Contains(log, `display_name`) || !strings.Contains(log, `client_secret`) {
+		t.Errorf("trace log missing bodies:\n%s", log)
+	}
+	for _, secret := range []string{"bearer-token-value", "s3cret"} {
+		if strings.Contains(log, secret) {
+			t.Errorf("trace log leaks %q:\n%s", secret, log)
+		}
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	got := redactBody([]byte(`{"access_token": "a\"b", "secret_access_key":"k", "name":"n"}`))
+	want := `{"access_token": "REDACTED", "secret_access_key":"REDACTED", "name":"n"}`
+	if got != want {
+		t.Errorf("redactBody() = %s, want %s", got, want)
+	}
+}
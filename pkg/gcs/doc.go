@@ -0,0 +1,33 @@
+// Package gcs provides a client for the Globus Connect Server Manager API.
+//
+// Create a Client with NewClient, passing the endpoint FQDN and an access
+// token for the endpoint's GCS Manager API scope:
+//
+//	client, err := gcs.NewClient("abc.def.data.globus.org",
+//		gcs.WithAccessToken(token),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	collections, err := client.ListCollections(ctx, nil)
+//
+// Package gcsauth provides token sources (static tokens, CLI profiles and
+// client credentials) and a NewClient helper that wires them together.
+//
+// The client enforces TLS 1.2+ with NIST-approved cipher suites by
+// default; see SecureTLSConfig.
+//
+// # WebAssembly
+//
+// The package builds for GOOS=js GOARCH=wasm, so browser tools can use the
+// same client; requests are made with the browser's fetch API. Pass a token
+// from gcsauth.StaticToken there: profile tokens are encrypted with a key
+// in the OS keyring, which the browser does not have.
+//
+// # Compatibility
+//
+// This package is a supported public API. Within a major version,
+// exported identifiers are not removed and their signatures do not
+// change; new types, fields, methods and options may be added in minor
+// releases. Code under internal/ carries no such guarantee.
+package gcs
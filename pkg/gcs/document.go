@@ -0,0 +1,138 @@
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// A document is a resource as the raw JSON object returned by the GCS
+// Manager API. Unlike the typed structs, it includes fields this package
+// does not model, and a partial update built from it can send false, empty,
+// and null values, which the typed Update methods omit.
+
+// IncludePrivatePolicies asks GetCollectionDocument for the collection's
+// policies, which the GCS Manager API leaves out unless requested.
+const IncludePrivatePolicies = "private_policies"
+
+// GetCollectionDocument retrieves a collection as a raw JSON document.
+// include names optional parts of the collection to return as well, such
+// as IncludePrivatePolicies.
+func (c *Client) GetCollectionDocument(ctx context.Context, collectionID string, include ...string) (map[string]interface{}, error) {
+	if collectionID == "" {
+		return nil, fmt.Errorf("collection ID is required")
+	}
+	path := "collections/" + collectionID
+	if len(include) > 0 {
+		path += "?" + url.Values{"include": {strings.Join(include, ",")}}.Encode()
+	}
+	return c.getDocument(ctx, path, "collection")
+}
+
+// PatchCollection sends a partial update with exactly the given fields.
+// Unlike UpdateCollection, false and empty values are sent, and nil
+// values are sent as null.
+func (c *Client) PatchCollection(ctx context.Context, collectionID string, fields map[string]interface{}) error {
+	if collectionID == "" {
+		return fmt.Errorf("collection ID is required")
+	}
+	return c.patchDocument(ctx, "collections/"+collectionID, "collection", fields)
+}
+
+// CreateCollectionDocument creates a collection from a raw JSON document,
+// such as one saved from GetCollectionDocument. Unlike CreateCollection,
+// every field of doc is sent, including false and empty values.
+func (c *Client) CreateCollectionDocument(ctx context.Context, doc map[string]interface{}) (*Collection, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("collection document is required")
+	}
+
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("marshal collection: %w", err)
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "collections", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create collection: %w", err)
+	}
+
+	var created Collection
+	if err := c.decodeResponse(resp, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// GetStorageGatewayDocument retrieves a storage gateway as a raw JSON
+// document.
+func (c *Client) GetStorageGatewayDocument(ctx context.Context, gatewayID string) (map[string]interface{}, error) {
+	if gatewayID == "" {
+		return nil, fmt.Errorf("storage gateway ID is required")
+	}
+	return c.getDocument(ctx, "storage_gateways/"+gatewayID, "storage gateway")
+}
+
+// PatchStorageGateway sends a partial update with exactly the given
+// fields, like PatchCollection.
+func (c *Client) PatchStorageGateway(ctx context.Context, gatewayID string, fields map[string]interface{}) error {
+	if gatewayID == "" {
+		return fmt.Errorf("storage gateway ID is required")
+	}
+	return c.patchDocument(ctx, "storage_gateways/"+gatewayID, "storage gateway", fields)
+}
+
+// GetAuthPolicyDocument retrieves an authentication policy as a raw JSON
+// document.
+func (c *Client) GetAuthPolicyDocument(ctx context.Context, policyID string) (map[string]interface{}, error) {
+	if policyID == "" {
+		return nil, fmt.Errorf("policy ID is required")
+	}
+	return c.getDocument(ctx, "auth-policies/"+policyID, "auth policy")
+}
+
+// PatchAuthPolicy sends a partial update with exactly the given fields,
+// like PatchCollection.
+func (c *Client) PatchAuthPolicy(ctx context.Context, policyID string, fields map[string]interface{}) error {
+	if policyID == "" {
+		return fmt.Errorf("policy ID is required")
+	}
+	return c.patchDocument(ctx, "auth-policies/"+policyID, "auth policy", fields)
+}
+
+// getDocument retrieves the resource at path as a raw JSON document. what
+// names the resource in errors.
+func (c *Client) getDocument(ctx context.Context, path, what string) (map[string]interface{}, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", what, err)
+	}
+
+	var doc map[string]interface{}
+	if err := c.decodeResponse(resp, &doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// patchDocument sends fields as a partial update of the resource at path.
+func (c *Client) patchDocument(ctx context.Context, path, what string, fields map[string]interface{}) error {
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", what, err)
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPatch, path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("update %s: %w", what, err)
+	}
+	_ = resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestPatchStorageGateway_SendsZeroValues(t *testing.T) {
+	var patch map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/storage_gateways/gw-1" {
+			t.Errorf("request = %s %s, want PATCH /api/storage_gateways/gw-1", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			t.Errorf("decode patch: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:    server.URL + "/api/",
+		httpClient: &http.Client{},
+		userAgent:  "test-agent",
+	}
+
+	err := client.PatchStorageGateway(context.Background(), "gw-1", map[string]interface{}{
+		"require_mfa":     false,
+		"allowed_domains": nil,
+	})
+	if err != nil {
+		t.Fatalf("PatchStorageGateway() error: %v", err)
+	}
+
+	if v, ok := patch["require_mfa"]; !ok || v != false {
+		t.Errorf("require_mfa = %v (sent %v), want false", v, ok)
+	}
+	if v, ok := patch["allowed_domains"]; !ok || v != nil {
+		t.Errorf("allowed_domains = %v (sent %v), want null", v, ok)
+	}
+}
+
+func TestGetAuthPolicyDocument_KeepsUnmodeledFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/auth-policies/pol-1" {
+			t.Errorf("request path = %q, want /api/auth-policies/pol-1", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "pol-1", "DATA_TYPE": "authentication_policies#1.1.0", "future_field": 7}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:    server.URL + "/api/",
+		httpClient: &http.Client{},
+		userAgent:  "test-agent",
+	}
+
+	doc, err := client.GetAuthPolicyDocument(context.Background(), "pol-1")
+	if err != nil {
+		t.Fatalf("GetAuthPolicyDocument() error: %v", err)
+	}
+	if doc["future_field"] != float64(7) {
+		t.Errorf("future_field = %v, want 7", doc["future_field"])
+	}
+}
+
+func TestCreateCollectionDocument_SendsEveryField(t *testing.T) {
+	var sent map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/collections" {
+			t.Errorf("request = %s %s, want POST /api/collections", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "col-1", "display_name": "Data"}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:    server.URL + "/api/",
+		httpClient: &http.Client{},
+		userAgent:  "test-agent",
+	}
+
+	created, err := client.CreateCollectionDocument(context.Background(), map[string]interface{}{
+		"display_name": "Data",
+		"public":       false,
+		"future_field": "kept",
+		"policies":     map[string]interface{}{"sharing_restrict": "private"},
+	})
+	if err != nil {
+		t.Fatalf("CreateCollectionDocument() error: %v", err)
+	}
+	if created.ID != "col-1" {
+		t.Errorf("created ID = %q, want col-1", created.ID)
+	}
+
+	if v, ok := sent["public"]; !ok || v != false {
+		t.Errorf("public = %v (sent %v), want false", v, ok)
+	}
+	if sent["future_field"] != "kept" {
+		t.Errorf("future_field = %v, want kept", sent["future_field"])
+	}
+	if policies, _ := sent["policies"].(map[string]interface{}); policies["sharing_restrict"] != "private" {
+		t.Errorf("policies = %v, want sharing_restrict private", sent["policies"])
+	}
+}
+
+func TestGetCollectionDocument_Include(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/collections/c1" {
+			t.Errorf("request path = %q, want /api/collections/c1", r.URL.Path)
+		}
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "c1"}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:    server.URL + "/api/",
+		httpClient: &http.Client{},
+		userAgent:  "test-agent",
+	}
+
+	ctx := context.Background()
+	if _, err := client.GetCollectionDocument(ctx, "c1"); err != nil {
+		t.Fatalf("GetCollectionDocument() error: %v", err)
+	}
+	if _, err := client.GetCollectionDocument(ctx, "c1", IncludePrivatePolicies); err != nil {
+		t.Fatalf("GetCollectionDocument(IncludePrivatePolicies) error: %v", err)
+	}
+	if want := []string{"", "include=private_policies"}; !reflect.DeepEqual(queries, want) {
+		t.Errorf("queries = %q, want %q", queries, want)
+	}
+}
@@ -0,0 +1,60 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrDryRun is the error of a request that a client created with
+// WithDryRun did not send. Test for it with errors.Is.
+var ErrDryRun = errors.New("dry run")
+
+// DryRunRequest is a request that was not sent because of WithDryRun.
+type DryRunRequest struct {
+	Method string
+	// Path is the request path, e.g. "/api/collections/abc".
+	Path string
+	// Body is the JSON body, or nil if the request has none.
+	Body []byte
+}
+
+// DryRunFunc receives each request that a dry-run client did not send.
+type DryRunFunc func(DryRunRequest)
+
+// readOnlyKey marks a context whose requests do not change anything even
+// though their method suggests so, such as a POST that runs a check.
+type readOnlyKey struct{}
+
+// readOnly returns a context whose requests are sent in dry-run mode.
+func readOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyKey{}, true)
+}
+
+// isWrite reports whether a request with method may change the endpoint.
+func isWrite(ctx context.Context, method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return ctx.Value(readOnlyKey{}) == nil
+}
+
+// skipWrite reports the write request of a dry-run client to its
+// DryRunFunc instead of sending it, and returns the ErrDryRun error.
+func (c *Client) skipWrite(method, url string, body io.Reader) error {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	dry := DryRunRequest{Method: method, Path: req.URL.RequestURI()}
+	if body != nil {
+		if dry.Body, err = io.ReadAll(body); err != nil {
+			return fmt.Errorf("read request body: %w", err)
+		}
+	}
+	c.dryRun(dry)
+	return fmt.Errorf("%w: %s %s not sent", ErrDryRun, method, dry.Path)
+}
@@ -0,0 +1,51 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithDryRun(t *testing.T) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{"id":"c1"}`))
+	}))
+	defer server.Close()
+
+	var skipped []DryRunRequest
+	client := &Client{
+		baseURL:    server.URL + "/api/",
+		httpClient: &http.Client{},
+		dryRun:     func(req DryRunRequest) { skipped = append(skipped, req) },
+	}
+	ctx := context.Background()
+
+	if _, err := client.GetCollection(ctx, "c1"); err != nil {
+		t.Fatalf("GetCollection() error = %v", err)
+	}
+	if _, err := client.CheckCollection(ctx, "c1"); err != nil {
+		t.Fatalf("CheckCollection() error = %v", err)
+	}
+	_, err := client.BatchDeleteCollections(ctx, []string{"c1", "c2"})
+	if !errors.Is(err, ErrDryRun) {
+		t.Fatalf("BatchDeleteCollections() error = %v, want ErrDryRun", err)
+	}
+	if _, err := client.CreateCollection(ctx, nil); err == nil || errors.Is(err, ErrDryRun) {
+		t.Errorf("CreateCollection(nil) error = %v, want a validation error", err)
+	}
+
+	if len(sent) != 2 || sent[0] != "GET /api/collections/c1" || sent[1] != "POST /api/collections/c1/check" {
+		t.Errorf("sent = %v, want only the GET and the check", sent)
+	}
+	if len(skipped) != 1 {
+		t.Fatalf("skipped %d requests, want 1", len(skipped))
+	}
+	got := skipped[0]
+	if got.Method != http.MethodPost || got.Path != "/api/collections/batch-delete" || string(got.Body) != `{"collection_ids":["c1","c2"]}` {
+		t.Errorf("skipped = %s %s %s", got.Method, got.Path, got.Body)
+	}
+}
@@ -21,6 +21,8 @@ func (c *Client) GetInfo(ctx context.Context) (*Info, error) {
 		return nil, err
 	}
 
+	recorded := info
+	c.recordInfo(&recorded)
 	return &info, nil
 }
 
@@ -0,0 +1,29 @@
+package gcs
+
+// Collection types, the values of Collection.CollectionType.
+const (
+	CollectionTypeMapped = "mapped"
+	CollectionTypeGuest  = "guest"
+)
+
+// Network use policies, the values of Endpoint.NetworkUse. With
+// NetworkUseCustom, MaxConcurrency and PreferredConcurrency apply.
+const (
+	NetworkUseNormal     = "normal"
+	NetworkUseMinimal    = "minimal"
+	NetworkUseAggressive = "aggressive"
+	NetworkUseCustom     = "custom"
+)
+
+// Audit event types, the values of AuditLog.EventType.
+const (
+	AuditEventTransfer       = "transfer"
+	AuditEventAccess         = "access"
+	AuditEventAuthentication = "authentication"
+)
+
+// Audit results, the values of AuditLog.Result.
+const (
+	AuditResultSuccess = "success"
+	AuditResultFailure = "failure"
+)
@@ -0,0 +1,146 @@
+package gcs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// requestIDHeaders are the response headers that may carry the ID of a
+// request, in order of preference.
+var requestIDHeaders = []string{"X-Request-Id", "X-Globus-Request-Id"}
+
+// APIError is an error response of the GCS Manager API.
+//
+// Use errors.As to get it from an error returned by a Client method:
+//
+//	var apiErr *gcs.APIError
+//	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+//	    ...
+//	}
+type APIError struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int `json:"status_code"`
+
+	// Code is the GCS error code, such as "NotFound" or
+	// "PermissionDenied", if the response was a GCS error document.
+	Code string `json:"code,omitempty"`
+
+	// Message describes the error: the document's detail, or the raw
+	// response body if it was not a GCS error document.
+	Message string `json:"message,omitempty"`
+
+	// RequestID identifies the request in the server's logs, for support
+	// tickets, when the server reported one.
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// Error formats the error as "HTTP <status>: <code>: <message>".
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "HTTP %d: ", e.StatusCode)
+	if e.Code != "" {
+		b.WriteString(e.Code)
+		if e.Message != "" {
+			b.WriteString(": ")
+		}
+	}
+	b.WriteString(e.Message)
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// errorDocument is the error document of the GCS Manager API.
+type errorDocument struct {
+	Code      string `json:"code"`
+	Detail    any    `json:"detail"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
+}
+
+// newAPIError reads the error response resp and closes its body.
+func newAPIError(resp *http.Response) *APIError {
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(resp.Body)
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    strings.TrimSpace(string(body)),
+		RequestID:  requestID(resp.Header),
+	}
+
+	var doc errorDocument
+	if err := json.Unmarshal(body, &doc); err != nil || doc.Code == "" {
+		return apiErr
+	}
+	apiErr.Code = doc.Code
+	switch detail := doc.Detail.(type) {
+	case string:
+		apiErr.Message = detail
+	case nil:
+		apiErr.Message = doc.Message
+	default:
+		// Validation errors carry structured details
+		data, _ := json.Marshal(detail)
+		apiErr.Message = string(data)
+	}
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = doc.RequestID
+	}
+	return apiErr
+}
+
+// requestID returns the request ID reported in the response header, or
+// "" if there is none.
+func requestID(header http.Header) string {
+	for _, name := range requestIDHeaders {
+		if id := header.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// StatusCode returns the HTTP status of the APIError in err's chain, or 0
+// if there is none.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is an API error for a missing resource.
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsPermissionDenied reports whether err is an API error for a request
+// the caller's roles do not allow.
+func IsPermissionDenied(err error) bool {
+	return StatusCode(err) == http.StatusForbidden
+}
+
+// IsConflict reports whether err is an API error for a request that
+// conflicts with the current state, such as creating a duplicate.
+func IsConflict(err error) bool {
+	return StatusCode(err) == http.StatusConflict
+}
+
+// IsPreconditionFailed reports whether err is an API error for a
+// conditional request whose resource changed, such as a PATCH sent with
+// If-Match by a client with an ETagCache.
+func IsPreconditionFailed(err error) bool {
+	return StatusCode(err) == http.StatusPreconditionFailed
+}
+
+// isHTTPStatus reports whether err is an API error with the given status.
+func isHTTPStatus(err error, code int) bool {
+	return StatusCode(err) == code
+}
@@ -0,0 +1,106 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError_FromResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		header string
+		body   string
+		want   APIError
+	}{
+		{
+			name:   "error document",
+			status: http.StatusNotFound,
+			body:   `{"DATA_TYPE":"result#1.0.0","code":"NotFound","http_response_code":404,"detail":"no collection","request_id":"doc-id"}`,
+			want:   APIError{StatusCode: 404, Code: "NotFound", Message: "no collection", RequestID: "doc-id"},
+		},
+		{
+			name:   "request ID header",
+			status: http.StatusForbidden,
+			header: "hdr-id",
+			body:   `{"code":"PermissionDenied","detail":"no role","request_id":"doc-id"}`,
+			want:   APIError{StatusCode: 403, Code: "PermissionDenied", Message: "no role", RequestID: "hdr-id"},
+		},
+		{
+			name:   "structured detail",
+			status: http.StatusBadRequest,
+			body:   `{"code":"ValidationError","detail":[{"loc":["display_name"]}]}`,
+			want:   APIError{StatusCode: 400, Code: "ValidationError", Message: `[{"loc":["display_name"]}]`},
+		},
+		{
+			name:   "plain body",
+			status: http.StatusBadGateway,
+			body:   "bad gateway\n",
+			want:   APIError{StatusCode: 502, Message: "bad gateway"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tt.header != "" {
+					w.Header().Set("X-Request-Id", tt.header)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := &Client{baseURL: server.URL + "/api/", httpClient: &http.Client{}}
+			_, err := client.GetCollection(context.Background(), "c1")
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("GetCollection() error = %v, want *APIError", err)
+			}
+			if *apiErr != tt.want {
+				t.Errorf("APIError = %+v, want %+v", *apiErr, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIError_Error(t *testing.T) {
+	tests := []struct {
+		err  APIError
+		want string
+	}{
+		{APIError{StatusCode: 404, Code: "NotFound", Message: "gone", RequestID: "r1"}, "HTTP 404: NotFound: gone (request ID r1)"},
+		{APIError{StatusCode: 409, Code: "Conflict"}, "HTTP 409: Conflict"},
+		{APIError{StatusCode: 502, Message: "bad gateway"}, "HTTP 502: bad gateway"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestErrorPredicates(t *testing.T) {
+	wrapped := func(status int) error {
+		return fmt.Errorf("get role: %w", &APIError{StatusCode: status})
+	}
+
+	if !IsNotFound(wrapped(404)) || IsNotFound(wrapped(403)) {
+		t.Error("IsNotFound() misclassified")
+	}
+	if !IsPermissionDenied(wrapped(403)) || IsPermissionDenied(wrapped(401)) {
+		t.Error("IsPermissionDenied() misclassified")
+	}
+	if !IsConflict(wrapped(409)) {
+		t.Error("IsConflict(409) = false")
+	}
+	if StatusCode(errors.New("HTTP 404: text")) != 0 {
+		t.Error("StatusCode() of a plain error should be 0")
+	}
+}
@@ -0,0 +1,125 @@
+package gcs_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+)
+
+// newExampleServer starts a fake GCS Manager API serving fixed responses
+// and returns a client configured to talk to it.
+func newExampleServer() (*gcs.Client, func()) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/endpoint", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, gcs.Endpoint{
+			ID:           "a1b2c3",
+			DisplayName:  "Example Endpoint",
+			Organization: "Example University",
+		})
+	})
+	mux.HandleFunc("/api/collections", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, gcs.CollectionList{
+			Data: []gcs.Collection{
+				{ID: "col-1", DisplayName: "Project Data", CollectionType: "mapped"},
+				{ID: "col-2", DisplayName: "Shared Results", CollectionType: "guest"},
+			},
+		})
+	})
+
+	server := httptest.NewTLSServer(mux)
+	client, err := gcs.NewClient(
+		strings.TrimPrefix(server.URL, "https://"),
+		gcs.WithAccessToken("example-token"),
+		gcs.WithHTTPClient(server.Client()),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return client, server.Close
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func ExampleNewClient() {
+	client, err := gcs.NewClient("abc.def.data.globus.org",
+		gcs.WithAccessToken("ACCESS_TOKEN"),
+		gcs.WithTimeout(60*time.Second),
+		gcs.WithUserAgent("my-service/1.0"),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_ = client
+}
+
+func ExampleClient_ListCollections() {
+	client, cleanup := newExampleServer()
+	defer cleanup()
+
+	list, err := client.ListCollections(context.Background(), &gcs.ListCollectionsOptions{PageSize: 100})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, c := range list.Data {
+		fmt.Printf("%s %s (%s)\n", c.ID, c.DisplayName, c.CollectionType)
+	}
+	// Output:
+	// col-1 Project Data (mapped)
+	// col-2 Shared Results (guest)
+}
+
+func ExampleClient_GetEndpoint() {
+	client, cleanup := newExampleServer()
+	defer cleanup()
+
+	endpoint, err := client.GetEndpoint(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(endpoint.DisplayName)
+	fmt.Println(endpoint.Organization)
+	// Output:
+	// Example Endpoint
+	// Example University
+}
+
+func ExamplePoll() {
+	client, cleanup := newExampleServer()
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fetch := func(ctx context.Context, v *gcs.Validators) (*gcs.Endpoint, error) {
+		var endpoint gcs.Endpoint
+		if err := client.GetConditional(ctx, "endpoint", v, &endpoint); err != nil {
+			return nil, err
+		}
+		return &endpoint, nil
+	}
+
+	err := gcs.Poll(ctx, fetch, time.Second, func(e *gcs.Endpoint) error {
+		fmt.Println("endpoint:", e.DisplayName)
+		cancel() // stop after the first value
+		return nil
+	})
+	if err != nil && !errors.Is(err, context.Canceled) {
+		log.Fatal(err)
+	}
+	// Output:
+	// endpoint: Example Endpoint
+}
@@ -0,0 +1,166 @@
+package gcs
+
+import (
+	"context"
+	"fmt"
+)
+
+// Feature statuses.
+const (
+	// FeatureEnabled means the feature is in use on the endpoint.
+	FeatureEnabled = "enabled"
+
+	// FeatureAvailable means the endpoint may use the feature but has not
+	// configured it.
+	FeatureAvailable = "available"
+
+	// FeatureUnavailable means the endpoint cannot use the feature,
+	// usually because it is not managed by a subscription.
+	FeatureUnavailable = "unavailable"
+)
+
+// Feature names reported by GetFeatures.
+const (
+	FeatureHTTPS             = "https_access"
+	FeatureMappedCollections = "mapped_collections"
+	FeatureSharing           = "sharing"
+	FeatureOIDC              = "oidc_server"
+	FeatureCustomDomain      = "custom_domain"
+	FeatureHighAvailability  = "high_availability"
+)
+
+// Feature is the status of one optional endpoint feature.
+type Feature struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Features lists which optional features an endpoint has enabled or can
+// enable.
+type Features struct {
+	SubscriptionID string    `json:"subscription_id,omitempty"`
+	Managed        bool      `json:"managed"`
+	Features       []Feature `json:"features"`
+}
+
+// GetFeatures reports the optional features of the endpoint, combining
+// its limits (see GetLimits) with its collections, OIDC server, and
+// custom domain configuration.
+//
+// Only the first page of collections is inspected, so collection counts
+// in feature details are lower bounds on large endpoints.
+func (c *Client) GetFeatures(ctx context.Context) (*Features, error) {
+	limits, err := c.GetLimits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get features: %w", err)
+	}
+
+	collections, err := c.ListCollections(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get features: %w", err)
+	}
+
+	var oidc *OIDCServer
+	if server, err := c.GetOIDCServer(ctx); err == nil {
+		oidc = server
+	} else if !isHTTPStatus(err, 404) {
+		return nil, fmt.Errorf("get features: %w", err)
+	}
+
+	var domain *DomainConfig
+	if config, err := c.GetEndpointDomain(ctx); err == nil {
+		domain = config
+	} else if !isHTTPStatus(err, 404) {
+		return nil, fmt.Errorf("get features: %w", err)
+	}
+
+	return &Features{
+		SubscriptionID: limits.SubscriptionID,
+		Managed:        limits.Managed,
+		Features:       deriveFeatures(limits, collections.Data, oidc, domain),
+	}, nil
+}
+
+// deriveFeatures computes feature statuses. oidc and domain are nil when
+// the endpoint has none configured.
+func deriveFeatures(limits *Limits, collections []Collection, oidc *OIDCServer, domain *DomainConfig) []Feature {
+	var mapped, guest, https int
+	for _, coll := range collections {
+		switch coll.CollectionType {
+		case CollectionTypeMapped:
+			mapped++
+		case CollectionTypeGuest:
+			guest++
+		}
+		if coll.HTTPSURL != "" {
+			https++
+		}
+	}
+
+	// subscriptionStatus is the status of a feature that requires a
+	// subscription and is not yet in use.
+	subscriptionStatus, subscriptionDetail := FeatureAvailable, ""
+	if !limits.Managed {
+		subscriptionStatus, subscriptionDetail = FeatureUnavailable, "requires subscription"
+	}
+
+	features := make([]Feature, 0, 6)
+
+	httpsFeature := Feature{Name: FeatureHTTPS, Status: subscriptionStatus, Detail: subscriptionDetail}
+	if https > 0 {
+		httpsFeature.Status = FeatureEnabled
+		httpsFeature.Detail = fmt.Sprintf("%d collection(s) with an HTTPS URL", https)
+	}
+	features = append(features, httpsFeature)
+
+	mappedFeature := Feature{Name: FeatureMappedCollections, Status: FeatureAvailable}
+	if mapped > 0 {
+		mappedFeature.Status = FeatureEnabled
+		mappedFeature.Detail = fmt.Sprintf("%d mapped collection(s)", mapped)
+	}
+	features = append(features, mappedFeature)
+
+	sharing := Feature{Name: FeatureSharing, Status: FeatureAvailable}
+	switch {
+	case !limits.GuestCollections:
+		sharing.Status = FeatureUnavailable
+		sharing.Detail = "guest collections require subscription"
+	case guest > 0:
+		sharing.Status = FeatureEnabled
+		sharing.Detail = fmt.Sprintf("%d guest collection(s)", guest)
+	}
+	features = append(features, sharing)
+
+	oidcFeature := Feature{Name: FeatureOIDC, Status: FeatureAvailable, Detail: "not configured"}
+	if oidc != nil {
+		oidcFeature.Status = FeatureEnabled
+		oidcFeature.Detail = oidc.Issuer
+	}
+	features = append(features, oidcFeature)
+
+	domainFeature := Feature{Name: FeatureCustomDomain, Status: subscriptionStatus, Detail: subscriptionDetail}
+	if domain != nil && domain.Domain != "" {
+		domainFeature.Status = FeatureEnabled
+		domainFeature.Detail = domain.Domain
+		if !domain.Verified {
+			domainFeature.Detail += " (unverified)"
+		}
+	}
+	features = append(features, domainFeature)
+
+	ha := Feature{Name: FeatureHighAvailability, Status: FeatureAvailable}
+	switch {
+	case !limits.HighAvailability:
+		ha.Status = FeatureUnavailable
+		ha.Detail = "multiple nodes require subscription"
+	case limits.NodeCount > 1:
+		ha.Status = FeatureEnabled
+		ha.Detail = fmt.Sprintf("%d nodes", limits.NodeCount)
+	default:
+		ha.Detail = fmt.Sprintf("%d node(s)", limits.NodeCount)
+	}
+	features = append(features, ha)
+
+	return features
+}
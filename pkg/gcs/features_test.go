@@ -0,0 +1,82 @@
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetFeatures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/endpoint/limits":
+			_ = json.NewEncoder(w).Encode(Limits{SubscriptionID: "sub-1", Managed: true, GuestCollections: true, HighAvailability: true})
+		case "/api/collections":
+			_ = json.NewEncoder(w).Encode(CollectionList{Data: []Collection{
+				{ID: "c1", CollectionType: "mapped", HTTPSURL: "https://g-1.data.globus.org"},
+				{ID: "c2", CollectionType: "guest"},
+			}})
+		case "/api/nodes":
+			_ = json.NewEncoder(w).Encode(NodeList{Data: []Node{{ID: "n1"}, {ID: "n2"}}})
+		case "/api/oidc", "/api/endpoint/domain":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected request path %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:    server.URL + "/api/",
+		httpClient: &http.Client{},
+		userAgent:  "test-agent",
+	}
+
+	features, err := client.GetFeatures(context.Background())
+	if err != nil {
+		t.Fatalf("GetFeatures() error = %v", err)
+	}
+	if !features.Managed || features.SubscriptionID != "sub-1" {
+		t.Errorf("features = %+v, want managed by sub-1", features)
+	}
+
+	want := map[string]string{
+		FeatureHTTPS:             FeatureEnabled,
+		FeatureMappedCollections: FeatureEnabled,
+		FeatureSharing:           FeatureEnabled,
+		FeatureOIDC:              FeatureAvailable,
+		FeatureCustomDomain:      FeatureAvailable,
+		FeatureHighAvailability:  FeatureEnabled,
+	}
+	if len(features.Features) != len(want) {
+		t.Fatalf("got %d features, want %d", len(features.Features), len(want))
+	}
+	for _, f := range features.Features {
+		if f.Status != want[f.Name] {
+			t.Errorf("%s status = %q, want %q", f.Name, f.Status, want[f.Name])
+		}
+	}
+}
+
+func TestDeriveFeatures_Unmanaged(t *testing.T) {
+	limits := deriveLimits(&Endpoint{ID: "ep-1"})
+	limits.NodeCount = 1
+
+	features := deriveFeatures(&limits, nil, &OIDCServer{Issuer: "https://idp.example.org"}, nil)
+
+	want := map[string]string{
+		FeatureHTTPS:             FeatureUnavailable,
+		FeatureMappedCollections: FeatureAvailable,
+		FeatureSharing:           FeatureUnavailable,
+		FeatureOIDC:              FeatureEnabled,
+		FeatureCustomDomain:      FeatureUnavailable,
+		FeatureHighAvailability:  FeatureUnavailable,
+	}
+	for _, f := range features {
+		if f.Status != want[f.Name] {
+			t.Errorf("%s status = %q, want %q", f.Name, f.Status, want[f.Name])
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Package gcstest provides a mock gcs.GCSAPI for tests of code that uses
+// the GCS Manager API, so that they need no HTTP server.
+//
+// Set the functions of the methods a test expects to be called; any other
+// method fails with ErrNotMocked:
+//
+//	client := &gcstest.Client{
+//	    GetCollectionFunc: func(ctx context.Context, id string) (*gcs.Collection, error) {
+//	        return &gcs.Collection{ID: id, DisplayName: "Archive"}, nil
+//	    },
+//	}
+//	... run the code under test with client ...
+//	if calls := client.CallsTo("GetCollection"); len(calls) != 1 {
+//	    t.Errorf("GetCollection called %d times", len(calls))
+//	}
+package gcstest
+
+//go:generate go run ../../../internal/tools/gcsapigen -dir ..
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"sync"
+)
+
+// ErrNotMocked is the error of a method whose function is not set.
+var ErrNotMocked = errors.New("gcstest: method not mocked")
+
+// Call is a recorded method call.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// recorder records the calls of a Client. It is safe for concurrent use.
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// record notes a call of method with args.
+func (r *recorder) record(method string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns the calls made so far, in order.
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns the calls of method made so far, in order.
+func (r *recorder) CallsTo(method string) []Call {
+	var calls []Call
+	for _, call := range r.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// notMocked returns the error of method when its function is not set.
+func notMocked(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotMocked, method)
+}
+
+// notMockedSeq returns the iterator of method when its function is not
+// set: it yields the not-mocked error.
+func notMockedSeq[T any](method string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, notMocked(method))
+	}
+}
@@ -0,0 +1,65 @@
+package gcstest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/apigen"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+)
+
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	api, mock, err := apigen.Generate("..")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	for path, want := range map[string][]byte{
+		filepath.Join("..", apigen.APIFile): api,
+		apigen.MockFile:                     mock,
+	} {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date; run 'make generate-api'", path)
+		}
+	}
+}
+
+func TestClient(t *testing.T) {
+	client := &Client{
+		GetCollectionFunc: func(_ context.Context, id string) (*gcs.Collection, error) {
+			return &gcs.Collection{ID: id}, nil
+		},
+	}
+	var api gcs.GCSAPI = client
+	ctx := context.Background()
+
+	collection, err := api.GetCollection(ctx, "c1")
+	if err != nil || collection.ID != "c1" {
+		t.Errorf("GetCollection() = %+v, %v, want c1", collection, err)
+	}
+	if _, err := api.GetNode(ctx, "n1"); !errors.Is(err, ErrNotMocked) {
+		t.Errorf("GetNode() error = %v, want ErrNotMocked", err)
+	}
+	for _, err := range api.Roles(ctx, nil) {
+		if !errors.Is(err, ErrNotMocked) {
+			t.Errorf("Roles() yielded %v, want ErrNotMocked", err)
+		}
+	}
+	api.SetAccessToken("t")
+
+	calls := client.CallsTo("GetCollection")
+	if len(calls) != 1 || calls[0].Args[1] != "c1" {
+		t.Errorf("CallsTo(GetCollection) = %+v, want one call with c1", calls)
+	}
+	if got := len(client.Calls()); got != 4 {
+		t.Errorf("Calls() has %d calls, want 4", got)
+	}
+}
@@ -0,0 +1,1163 @@
+// Code generated by gcsapigen from the methods of *gcs.Client; DO NOT EDIT.
+
+package gcstest
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+)
+
+// Client is a mock gcs.GCSAPI. Each method calls the function in the
+// field of the same name with a Func suffix, after recording the call;
+// if the field is nil, the method fails with ErrNotMocked.
+type Client struct {
+	recorder
+
+	APIVersionFunc                   func(ctx context.Context) (string, error)
+	AddS3KeyFunc                     func(ctx context.Context, credentialID string, key *gcs.S3Key) (*gcs.UserCredential, error)
+	ApplyRolesFunc                   func(ctx context.Context, ops []gcs.RoleOp, opts *gcs.RoleBatchOptions) (*gcs.RoleBatchSummary, error)
+	BatchDeleteCollectionsFunc       func(ctx context.Context, collectionIDs []string) (*gcs.BatchDeleteResult, error)
+	CheckCollectionFunc              func(ctx context.Context, collectionID string) (*gcs.CollectionValidation, error)
+	CheckEndpointUpgradeFunc         func(ctx context.Context) (*gcs.UpgradeInfo, error)
+	CleanupEndpointFunc              func(ctx context.Context) error
+	CleanupNodeFunc                  func(ctx context.Context, nodeID string) error
+	CollectionsFunc                  func(ctx context.Context, opts *gcs.ListCollectionsOptions) iter.Seq2[gcs.Collection, error]
+	ConvertDeploymentKeyFunc         func(ctx context.Context, oldKey string) (*gcs.DeploymentKeyResult, error)
+	CreateActivescaleCredentialFunc  func(ctx context.Context, credential *gcs.UserCredential) (*gcs.UserCredential, error)
+	CreateAuthPolicyFunc             func(ctx context.Context, policy *gcs.AuthPolicy) (*gcs.AuthPolicy, error)
+	CreateCollectionFunc             func(ctx context.Context, collection *gcs.Collection) (*gcs.Collection, error)
+	CreateCollectionDocumentFunc     func(ctx context.Context, doc map[string]interface{}) (*gcs.Collection, error)
+	CreateGuestCollectionFunc        func(ctx context.Context, guest *gcs.Collection) (*gcs.Collection, error)
+	CreateNodeFunc                   func(ctx context.Context, node *gcs.Node) (*gcs.Node, error)
+	CreateOAuthCredentialFunc        func(ctx context.Context, credential *gcs.UserCredential) (*gcs.UserCredential, error)
+	CreateOIDCServerFunc             func(ctx context.Context, server *gcs.OIDCServer) (*gcs.OIDCServer, error)
+	CreateRoleFunc                   func(ctx context.Context, role *gcs.Role) (*gcs.Role, error)
+	CreateS3CredentialFunc           func(ctx context.Context, credential *gcs.UserCredential) (*gcs.UserCredential, error)
+	CreateSharingPolicyFunc          func(ctx context.Context, policy *gcs.SharingPolicy) (*gcs.SharingPolicy, error)
+	CreateStorageGatewayFunc         func(ctx context.Context, gateway *gcs.StorageGateway) (*gcs.StorageGateway, error)
+	DeleteAuthPolicyFunc             func(ctx context.Context, policyID string) error
+	DeleteCollectionFunc             func(ctx context.Context, collectionID string) error
+	DeleteCollectionDomainFunc       func(ctx context.Context, collectionID string) error
+	DeleteEndpointDomainFunc         func(ctx context.Context) error
+	DeleteNodeFunc                   func(ctx context.Context, nodeID string) error
+	DeleteOIDCServerFunc             func(ctx context.Context) error
+	DeleteRoleFunc                   func(ctx context.Context, roleID string) error
+	DeleteS3KeyFunc                  func(ctx context.Context, credentialID string, accessKeyID string) error
+	DeleteSharingPolicyFunc          func(ctx context.Context, policyID string) error
+	DeleteStorageGatewayFunc         func(ctx context.Context, gatewayID string) error
+	DeleteUserCredentialFunc         func(ctx context.Context, credentialID string) error
+	DisableCollectionFunc            func(ctx context.Context, collectionID string, message string) (*gcs.AvailabilityChange, error)
+	DisableNodeFunc                  func(ctx context.Context, nodeID string) error
+	EnableCollectionFunc             func(ctx context.Context, collectionID string, previous *gcs.AvailabilityChange) (*gcs.AvailabilityChange, error)
+	EnableNodeFunc                   func(ctx context.Context, nodeID string) error
+	GenerateNodeSecretFunc           func(ctx context.Context, nodeID string) (*gcs.NodeSecret, error)
+	GetAuditLogsFunc                 func(ctx context.Context, params *gcs.AuditQueryParams) (*gcs.AuditLogList, error)
+	GetAuthPolicyFunc                func(ctx context.Context, policyID string) (*gcs.AuthPolicy, error)
+	GetAuthPolicyDocumentFunc        func(ctx context.Context, policyID string) (map[string]interface{}, error)
+	GetCollectionFunc                func(ctx context.Context, collectionID string) (*gcs.Collection, error)
+	GetCollectionDocumentFunc        func(ctx context.Context, collectionID string, include ...string) (map[string]interface{}, error)
+	GetCollectionDomainFunc          func(ctx context.Context, collectionID string) (*gcs.DomainConfig, error)
+	GetConditionalFunc               func(ctx context.Context, path string, v *gcs.Validators, target interface{}) error
+	GetEndpointFunc                  func(ctx context.Context) (*gcs.Endpoint, error)
+	GetEndpointDomainFunc            func(ctx context.Context) (*gcs.DomainConfig, error)
+	GetFeaturesFunc                  func(ctx context.Context) (*gcs.Features, error)
+	GetInfoFunc                      func(ctx context.Context) (*gcs.Info, error)
+	GetLimitsFunc                    func(ctx context.Context) (*gcs.Limits, error)
+	GetNodeFunc                      func(ctx context.Context, nodeID string) (*gcs.Node, error)
+	GetOIDCServerFunc                func(ctx context.Context) (*gcs.OIDCServer, error)
+	GetReleaseNotesFunc              func(ctx context.Context, fromVersion string, toVersion string) (*gcs.ReleaseNotes, error)
+	GetRoleFunc                      func(ctx context.Context, roleID string) (*gcs.Role, error)
+	GetSessionFunc                   func(ctx context.Context) (*gcs.Session, error)
+	GetSharingPolicyFunc             func(ctx context.Context, policyID string) (*gcs.SharingPolicy, error)
+	GetStorageGatewayFunc            func(ctx context.Context, gatewayID string) (*gcs.StorageGateway, error)
+	GetStorageGatewayDocumentFunc    func(ctx context.Context, gatewayID string) (map[string]interface{}, error)
+	GetUpgradeStatusFunc             func(ctx context.Context) (*gcs.UpgradeStatus, error)
+	GetUserCredentialFunc            func(ctx context.Context, credentialID string) (*gcs.UserCredential, error)
+	ListAllCollectionsFunc           func(ctx context.Context, opts *gcs.ListCollectionsOptions) ([]gcs.Collection, error)
+	ListAllNodesFunc                 func(ctx context.Context, opts *gcs.ListNodesOptions) ([]gcs.Node, error)
+	ListAllRolesFunc                 func(ctx context.Context, opts *gcs.ListRolesOptions) ([]gcs.Role, error)
+	ListAllStorageGatewaysFunc       func(ctx context.Context, opts *gcs.ListStorageGatewaysOptions) ([]gcs.StorageGateway, error)
+	ListAuthPoliciesFunc             func(ctx context.Context) (*gcs.AuthPolicyList, error)
+	ListCollectionsFunc              func(ctx context.Context, opts *gcs.ListCollectionsOptions) (*gcs.CollectionList, error)
+	ListCollectionsForGatewayFunc    func(ctx context.Context, gatewayID string) ([]gcs.Collection, error)
+	ListNodesFunc                    func(ctx context.Context, opts *gcs.ListNodesOptions) (*gcs.NodeList, error)
+	ListRolesFunc                    func(ctx context.Context, opts *gcs.ListRolesOptions) (*gcs.RoleList, error)
+	ListSharingPoliciesFunc          func(ctx context.Context) (*gcs.SharingPolicyList, error)
+	ListStorageGatewaysFunc          func(ctx context.Context, opts *gcs.ListStorageGatewaysOptions) (*gcs.StorageGatewayList, error)
+	ListUserCredentialsFunc          func(ctx context.Context) (*gcs.UserCredentialList, error)
+	NodesFunc                        func(ctx context.Context, opts *gcs.ListNodesOptions) iter.Seq2[gcs.Node, error]
+	PatchAuthPolicyFunc              func(ctx context.Context, policyID string, fields map[string]interface{}) error
+	PatchCollectionFunc              func(ctx context.Context, collectionID string, fields map[string]interface{}) error
+	PatchStorageGatewayFunc          func(ctx context.Context, gatewayID string, fields map[string]interface{}) error
+	RegisterOIDCServerFunc           func(ctx context.Context, server *gcs.OIDCServer) (*gcs.OIDCServer, error)
+	ResetCollectionOwnerStringFunc   func(ctx context.Context, collectionID string) error
+	ResetEndpointOwnerStringFunc     func(ctx context.Context) error
+	ResourceServerFunc               func() string
+	RolesFunc                        func(ctx context.Context, opts *gcs.ListRolesOptions) iter.Seq2[gcs.Role, error]
+	SetAccessTokenFunc               func(token string)
+	SetCollectionOwnerFunc           func(ctx context.Context, collectionID string, principalURN string) error
+	SetCollectionOwnerStringFunc     func(ctx context.Context, collectionID string, ownerString string) error
+	SetCollectionUserMessageFunc     func(ctx context.Context, collectionID string, message string, link string) error
+	SetEndpointOwnerFunc             func(ctx context.Context, principalURN string) error
+	SetEndpointOwnerStringFunc       func(ctx context.Context, ownerString string) error
+	SetSubscriptionAdminVerifiedFunc func(ctx context.Context, collectionID string, verified bool) error
+	SetSubscriptionIDFunc            func(ctx context.Context, subscriptionID string) error
+	SetupCollectionDomainFunc        func(ctx context.Context, collectionID string, config *gcs.DomainConfig) error
+	SetupEndpointFunc                func(ctx context.Context, endpoint *gcs.Endpoint) (*gcs.Endpoint, error)
+	SetupEndpointDomainFunc          func(ctx context.Context, config *gcs.DomainConfig) error
+	SetupNodeFunc                    func(ctx context.Context, node *gcs.Node) (*gcs.Node, error)
+	StorageGatewaysFunc              func(ctx context.Context, opts *gcs.ListStorageGatewaysOptions) iter.Seq2[gcs.StorageGateway, error]
+	UpdateAuthPolicyFunc             func(ctx context.Context, policyID string, policy *gcs.AuthPolicy) (*gcs.AuthPolicy, error)
+	UpdateCollectionFunc             func(ctx context.Context, collectionID string, collection *gcs.Collection) (*gcs.Collection, error)
+	UpdateEndpointFunc               func(ctx context.Context, endpoint *gcs.Endpoint) (*gcs.Endpoint, error)
+	UpdateNodeFunc                   func(ctx context.Context, nodeID string, node *gcs.Node) (*gcs.Node, error)
+	UpdateOIDCServerFunc             func(ctx context.Context, server *gcs.OIDCServer) (*gcs.OIDCServer, error)
+	UpdateRoleFunc                   func(ctx context.Context, roleID string, role *gcs.Role) (*gcs.Role, error)
+	UpdateS3KeyFunc                  func(ctx context.Context, credentialID string, accessKeyID string, key *gcs.S3Key) (*gcs.UserCredential, error)
+	UpdateSessionFunc                func(ctx context.Context, session *gcs.Session) (*gcs.Session, error)
+	UpdateSessionConsentsFunc        func(ctx context.Context, consents []string) (*gcs.Session, error)
+	UpdateStorageGatewayFunc         func(ctx context.Context, gatewayID string, gateway *gcs.StorageGateway) (*gcs.StorageGateway, error)
+	UpgradeEndpointFunc              func(ctx context.Context) (*gcs.UpgradeResult, error)
+	VerifyUpgradeFunc                func(ctx context.Context, previousVersion string) (*gcs.UpgradeVerification, error)
+	WaitForUpgradeFunc               func(ctx context.Context, targetVersion string, interval time.Duration, onProgress func(*gcs.UpgradeStatus)) (*gcs.UpgradeStatus, error)
+}
+
+// Client implements gcs.GCSAPI.
+var _ gcs.GCSAPI = (*Client)(nil)
+
+// APIVersion calls APIVersionFunc.
+func (m *Client) APIVersion(ctx context.Context) (string, error) {
+	m.record("APIVersion", ctx)
+	if m.APIVersionFunc == nil {
+		var r0 string
+		return r0, notMocked("APIVersion")
+	}
+	return m.APIVersionFunc(ctx)
+}
+
+// AddS3Key calls AddS3KeyFunc.
+func (m *Client) AddS3Key(ctx context.Context, credentialID string, key *gcs.S3Key) (*gcs.UserCredential, error) {
+	m.record("AddS3Key", ctx, credentialID, key)
+	if m.AddS3KeyFunc == nil {
+		var r0 *gcs.UserCredential
+		return r0, notMocked("AddS3Key")
+	}
+	return m.AddS3KeyFunc(ctx, credentialID, key)
+}
+
+// ApplyRoles calls ApplyRolesFunc.
+func (m *Client) ApplyRoles(ctx context.Context, ops []gcs.RoleOp, opts *gcs.RoleBatchOptions) (*gcs.RoleBatchSummary, error) {
+	m.record("ApplyRoles", ctx, ops, opts)
+	if m.ApplyRolesFunc == nil {
+		var r0 *gcs.RoleBatchSummary
+		return r0, notMocked("ApplyRoles")
+	}
+	return m.ApplyRolesFunc(ctx, ops, opts)
+}
+
+// BatchDeleteCollections calls BatchDeleteCollectionsFunc.
+func (m *Client) BatchDeleteCollections(ctx context.Context, collectionIDs []string) (*gcs.BatchDeleteResult, error) {
+	m.record("BatchDeleteCollections", ctx, collectionIDs)
+	if m.BatchDeleteCollectionsFunc == nil {
+		var r0 *gcs.BatchDeleteResult
+		return r0, notMocked("BatchDeleteCollections")
+	}
+	return m.BatchDeleteCollectionsFunc(ctx, collectionIDs)
+}
+
+// CheckCollection calls CheckCollectionFunc.
+func (m *Client) CheckCollection(ctx context.Context, collectionID string) (*gcs.CollectionValidation, error) {
+	m.record("CheckCollection", ctx, collectionID)
+	if m.CheckCollectionFunc == nil {
+		var r0 *gcs.CollectionValidation
+		return r0, notMocked("CheckCollection")
+	}
+	return m.CheckCollectionFunc(ctx, collectionID)
+}
+
+// CheckEndpointUpgrade calls CheckEndpointUpgradeFunc.
+func (m *Client) CheckEndpointUpgrade(ctx context.Context) (*gcs.UpgradeInfo, error) {
+	m.record("CheckEndpointUpgrade", ctx)
+	if m.CheckEndpointUpgradeFunc == nil {
+		var r0 *gcs.UpgradeInfo
+		return r0, notMocked("CheckEndpointUpgrade")
+	}
+	return m.CheckEndpointUpgradeFunc(ctx)
+}
+
+// CleanupEndpoint calls CleanupEndpointFunc.
+func (m *Client) CleanupEndpoint(ctx context.Context) error {
+	m.record("CleanupEndpoint", ctx)
+	if m.CleanupEndpointFunc == nil {
+		return notMocked("CleanupEndpoint")
+	}
+	return m.CleanupEndpointFunc(ctx)
+}
+
+// CleanupNode calls CleanupNodeFunc.
+func (m *Client) CleanupNode(ctx context.Context, nodeID string) error {
+	m.record("CleanupNode", ctx, nodeID)
+	if m.CleanupNodeFunc == nil {
+		return notMocked("CleanupNode")
+	}
+	return m.CleanupNodeFunc(ctx, nodeID)
+}
+
+// Collections calls CollectionsFunc.
+func (m *Client) Collections(ctx context.Context, opts *gcs.ListCollectionsOptions) iter.Seq2[gcs.Collection, error] {
+	m.record("Collections", ctx, opts)
+	if m.CollectionsFunc == nil {
+		return notMockedSeq[gcs.Collection]("Collections")
+	}
+	return m.CollectionsFunc(ctx, opts)
+}
+
+// ConvertDeploymentKey calls ConvertDeploymentKeyFunc.
+func (m *Client) ConvertDeploymentKey(ctx context.Context, oldKey string) (*gcs.DeploymentKeyResult, error) {
+	m.record("ConvertDeploymentKey", ctx, oldKey)
+	if m.ConvertDeploymentKeyFunc == nil {
+		var r0 *gcs.DeploymentKeyResult
+		return r0, notMocked("ConvertDeploymentKey")
+	}
+	return m.ConvertDeploymentKeyFunc(ctx, oldKey)
+}
+
+// CreateActivescaleCredential calls CreateActivescaleCredentialFunc.
+func (m *Client) CreateActivescaleCredential(ctx context.Context, credential *gcs.UserCredential) (*gcs.UserCredential, error) {
+	m.record("CreateActivescaleCredential", ctx, credential)
+	if m.CreateActivescaleCredentialFunc == nil {
+		var r0 *gcs.UserCredential
+		return r0, notMocked("CreateActivescaleCredential")
+	}
+	return m.CreateActivescaleCredentialFunc(ctx, credential)
+}
+
+// CreateAuthPolicy calls CreateAuthPolicyFunc.
+func (m *Client) CreateAuthPolicy(ctx context.Context, policy *gcs.AuthPolicy) (*gcs.AuthPolicy, error) {
+	m.record("CreateAuthPolicy", ctx, policy)
+	if m.CreateAuthPolicyFunc == nil {
+		var r0 *gcs.AuthPolicy
+		return r0, notMocked("CreateAuthPolicy")
+	}
+	return m.CreateAuthPolicyFunc(ctx, policy)
+}
+
+// CreateCollection calls CreateCollectionFunc.
+func (m *Client) CreateCollection(ctx context.Context, collection *gcs.Collection) (*gcs.Collection, error) {
+	m.record("CreateCollection", ctx, collection)
+	if m.CreateCollectionFunc == nil {
+		var r0 *gcs.Collection
+		return r0, notMocked("CreateCollection")
+	}
+	return m.CreateCollectionFunc(ctx, collection)
+}
+
+// CreateCollectionDocument calls CreateCollectionDocumentFunc.
+func (m *Client) CreateCollectionDocument(ctx context.Context, doc map[string]interface{}) (*gcs.Collection, error) {
+	m.record("CreateCollectionDocument", ctx, doc)
+	if m.CreateCollectionDocumentFunc == nil {
+		var r0 *gcs.Collection
+		return r0, notMocked("CreateCollectionDocument")
+	}
+	return m.CreateCollectionDocumentFunc(ctx, doc)
+}
+
+// CreateGuestCollection calls CreateGuestCollectionFunc.
+func (m *Client) CreateGuestCollection(ctx context.Context, guest *gcs.Collection) (*gcs.Collection, error) {
+	m.record("CreateGuestCollection", ctx, guest)
+	if m.CreateGuestCollectionFunc == nil {
+		var r0 *gcs.Collection
+		return r0, notMocked("CreateGuestCollection")
+	}
+	return m.CreateGuestCollectionFunc(ctx, guest)
+}
+
+// CreateNode calls CreateNodeFunc.
+func (m *Client) CreateNode(ctx context.Context, node *gcs.Node) (*gcs.Node, error) {
+	m.record("CreateNode", ctx, node)
+	if m.CreateNodeFunc == nil {
+		var r0 *gcs.Node
+		return r0, notMocked("CreateNode")
+	}
+	return m.CreateNodeFunc(ctx, node)
+}
+
+// CreateOAuthCredential calls CreateOAuthCredentialFunc.
+func (m *Client) CreateOAuthCredential(ctx context.Context, credential *gcs.UserCredential) (*gcs.UserCredential, error) {
+	m.record("CreateOAuthCredential", ctx, credential)
+	if m.CreateOAuthCredentialFunc == nil {
+		var r0 *gcs.UserCredential
+		return r0, notMocked("CreateOAuthCredential")
+	}
+	return m.CreateOAuthCredentialFunc(ctx, credential)
+}
+
+// CreateOIDCServer calls CreateOIDCServerFunc.
+func (m *Client) CreateOIDCServer(ctx context.Context, server *gcs.OIDCServer) (*gcs.OIDCServer, error) {
+	m.record("CreateOIDCServer", ctx, server)
+	if m.CreateOIDCServerFunc == nil {
+		var r0 *gcs.OIDCServer
+		return r0, notMocked("CreateOIDCServer")
+	}
+	return m.CreateOIDCServerFunc(ctx, server)
+}
+
+// CreateRole calls CreateRoleFunc.
+func (m *Client) CreateRole(ctx context.Context, role *gcs.Role) (*gcs.Role, error) {
+	m.record("CreateRole", ctx, role)
+	if m.CreateRoleFunc == nil {
+		var r0 *gcs.Role
+		return r0, notMocked("CreateRole")
+	}
+	return m.CreateRoleFunc(ctx, role)
+}
+
+// CreateS3Credential calls CreateS3CredentialFunc.
+func (m *Client) CreateS3Credential(ctx context.Context, credential *gcs.UserCredential) (*gcs.UserCredential, error) {
+	m.record("CreateS3Credential", ctx, credential)
+	if m.CreateS3CredentialFunc == nil {
+		var r0 *gcs.UserCredential
+		return r0, notMocked("CreateS3Credential")
+	}
+	return m.CreateS3CredentialFunc(ctx, credential)
+}
+
+// CreateSharingPolicy calls CreateSharingPolicyFunc.
+func (m *Client) CreateSharingPolicy(ctx context.Context, policy *gcs.SharingPolicy) (*gcs.SharingPolicy, error) {
+	m.record("CreateSharingPolicy", ctx, policy)
+	if m.CreateSharingPolicyFunc == nil {
+		var r0 *gcs.SharingPolicy
+		return r0, notMocked("CreateSharingPolicy")
+	}
+	return m.CreateSharingPolicyFunc(ctx, policy)
+}
+
+// CreateStorageGateway calls CreateStorageGatewayFunc.
+func (m *Client) CreateStorageGateway(ctx context.Context, gateway *gcs.StorageGateway) (*gcs.StorageGateway, error) {
+	m.record("CreateStorageGateway", ctx, gateway)
+	if m.CreateStorageGatewayFunc == nil {
+		var r0 *gcs.StorageGateway
+		return r0, notMocked("CreateStorageGateway")
+	}
+	return m.CreateStorageGatewayFunc(ctx, gateway)
+}
+
+// DeleteAuthPolicy calls DeleteAuthPolicyFunc.
+func (m *Client) DeleteAuthPolicy(ctx context.Context, policyID string) error {
+	m.record("DeleteAuthPolicy", ctx, policyID)
+	if m.DeleteAuthPolicyFunc == nil {
+		return notMocked("DeleteAuthPolicy")
+	}
+	return m.DeleteAuthPolicyFunc(ctx, policyID)
+}
+
+// DeleteCollection calls DeleteCollectionFunc.
+func (m *Client) DeleteCollection(ctx context.Context, collectionID string) error {
+	m.record("DeleteCollection", ctx, collectionID)
+	if m.DeleteCollectionFunc == nil {
+		return notMocked("DeleteCollection")
+	}
+	return m.DeleteCollectionFunc(ctx, collectionID)
+}
+
+// DeleteCollectionDomain calls DeleteCollectionDomainFunc.
+func (m *Client) DeleteCollectionDomain(ctx context.Context, collectionID string) error {
+	m.record("DeleteCollectionDomain", ctx, collectionID)
+	if m.DeleteCollectionDomainFunc == nil {
+		return notMocked("DeleteCollectionDomain")
+	}
+	return m.DeleteCollectionDomainFunc(ctx, collectionID)
+}
+
+// DeleteEndpointDomain calls DeleteEndpointDomainFunc.
+func (m *Client) DeleteEndpointDomain(ctx context.Context) error {
+	m.record("DeleteEndpointDomain", ctx)
+	if m.DeleteEndpointDomainFunc == nil {
+		return notMocked("DeleteEndpointDomain")
+	}
+	return m.DeleteEndpointDomainFunc(ctx)
+}
+
+// DeleteNode calls DeleteNodeFunc.
+func (m *Client) DeleteNode(ctx context.Context, nodeID string) error {
+	m.record("DeleteNode", ctx, nodeID)
+	if m.DeleteNodeFunc == nil {
+		return notMocked("DeleteNode")
+	}
+	return m.DeleteNodeFunc(ctx, nodeID)
+}
+
+// DeleteOIDCServer calls DeleteOIDCServerFunc.
+func (m *Client) DeleteOIDCServer(ctx context.Context) error {
+	m.record("DeleteOIDCServer", ctx)
+	if m.DeleteOIDCServerFunc == nil {
+		return notMocked("DeleteOIDCServer")
+	}
+	return m.DeleteOIDCServerFunc(ctx)
+}
+
+// DeleteRole calls DeleteRoleFunc.
+func (m *Client) DeleteRole(ctx context.Context, roleID string) error {
+	m.record("DeleteRole", ctx, roleID)
+	if m.DeleteRoleFunc == nil {
+		return notMocked("DeleteRole")
+	}
+	return m.DeleteRoleFunc(ctx, roleID)
+}
+
+// DeleteS3Key calls DeleteS3KeyFunc.
+func (m *Client) DeleteS3Key(ctx context.Context, credentialID string, accessKeyID string) error {
+	m.record("DeleteS3Key", ctx, credentialID, accessKeyID)
+	if m.DeleteS3KeyFunc == nil {
+		return notMocked("DeleteS3Key")
+	}
+	return m.DeleteS3KeyFunc(ctx, credentialID, accessKeyID)
+}
+
+// DeleteSharingPolicy calls DeleteSharingPolicyFunc.
+func (m *Client) DeleteSharingPolicy(ctx context.Context, policyID string) error {
+	m.record("DeleteSharingPolicy", ctx, policyID)
+	if m.DeleteSharingPolicyFunc == nil {
+		return notMocked("DeleteSharingPolicy")
+	}
+	return m.DeleteSharingPolicyFunc(ctx, policyID)
+}
+
+// DeleteStorageGateway calls DeleteStorageGatewayFunc.
+func (m *Client) DeleteStorageGateway(ctx context.Context, gatewayID string) error {
+	m.record("DeleteStorageGateway", ctx, gatewayID)
+	if m.DeleteStorageGatewayFunc == nil {
+		return notMocked("DeleteStorageGateway")
+	}
+	return m.DeleteStorageGatewayFunc(ctx, gatewayID)
+}
+
+// DeleteUserCredential calls DeleteUserCredentialFunc.
+func (m *Client) DeleteUserCredential(ctx context.Context, credentialID string) error {
+	m.record("DeleteUserCredential", ctx, credentialID)
+	if m.DeleteUserCredentialFunc == nil {
+		return notMocked("DeleteUserCredential")
+	}
+	return m.DeleteUserCredentialFunc(ctx, credentialID)
+}
+
+// DisableCollection calls DisableCollectionFunc.
+func (m *Client) DisableCollection(ctx context.Context, collectionID string, message string) (*gcs.AvailabilityChange, error) {
+	m.record("DisableCollection", ctx, collectionID, message)
+	if m.DisableCollectionFunc == nil {
+		var r0 *gcs.AvailabilityChange
+		return r0, notMocked("DisableCollection")
+	}
+	return m.DisableCollectionFunc(ctx, collectionID, message)
+}
+
+// DisableNode calls DisableNodeFunc.
+func (m *Client) DisableNode(ctx context.Context, nodeID string) error {
+	m.record("DisableNode", ctx, nodeID)
+	if m.DisableNodeFunc == nil {
+		return notMocked("DisableNode")
+	}
+	return m.DisableNodeFunc(ctx, nodeID)
+}
+
+// EnableCollection calls EnableCollectionFunc.
+func (m *Client) EnableCollection(ctx context.Context, collectionID string, previous *gcs.AvailabilityChange) (*gcs.AvailabilityChange, error) {
+	m.record("EnableCollection", ctx, collectionID, previous)
+	if m.EnableCollectionFunc == nil {
+		var r0 *gcs.AvailabilityChange
+		return r0, notMocked("EnableCollection")
+	}
+	return m.EnableCollectionFunc(ctx, collectionID, previous)
+}
+
+// EnableNode calls EnableNodeFunc.
+func (m *Client) EnableNode(ctx context.Context, nodeID string) error {
+	m.record("EnableNode", ctx, nodeID)
+	if m.EnableNodeFunc == nil {
+		return notMocked("EnableNode")
+	}
+	return m.EnableNodeFunc(ctx, nodeID)
+}
+
+// GenerateNodeSecret calls GenerateNodeSecretFunc.
+func (m *Client) GenerateNodeSecret(ctx context.Context, nodeID string) (*gcs.NodeSecret, error) {
+	m.record("GenerateNodeSecret", ctx, nodeID)
+	if m.GenerateNodeSecretFunc == nil {
+		var r0 *gcs.NodeSecret
+		return r0, notMocked("GenerateNodeSecret")
+	}
+	return m.GenerateNodeSecretFunc(ctx, nodeID)
+}
+
+// GetAuditLogs calls GetAuditLogsFunc.
+func (m *Client) GetAuditLogs(ctx context.Context, params *gcs.AuditQueryParams) (*gcs.AuditLogList, error) {
+	m.record("GetAuditLogs", ctx, params)
+	if m.GetAuditLogsFunc == nil {
+		var r0 *gcs.AuditLogList
+		return r0, notMocked("GetAuditLogs")
+	}
+	return m.GetAuditLogsFunc(ctx, params)
+}
+
+// GetAuthPolicy calls GetAuthPolicyFunc.
+func (m *Client) GetAuthPolicy(ctx context.Context, policyID string) (*gcs.AuthPolicy, error) {
+	m.record("GetAuthPolicy", ctx, policyID)
+	if m.GetAuthPolicyFunc == nil {
+		var r0 *gcs.AuthPolicy
+		return r0, notMocked("GetAuthPolicy")
+	}
+	return m.GetAuthPolicyFunc(ctx, policyID)
+}
+
+// GetAuthPolicyDocument calls GetAuthPolicyDocumentFunc.
+func (m *Client) GetAuthPolicyDocument(ctx context.Context, policyID string) (map[string]interface{}, error) {
+	m.record("GetAuthPolicyDocument", ctx, policyID)
+	if m.GetAuthPolicyDocumentFunc == nil {
+		var r0 map[string]interface{}
+		return r0, notMocked("GetAuthPolicyDocument")
+	}
+	return m.GetAuthPolicyDocumentFunc(ctx, policyID)
+}
+
+// GetCollection calls GetCollectionFunc.
+func (m *Client) GetCollection(ctx context.Context, collectionID string) (*gcs.Collection, error) {
+	m.record("GetCollection", ctx, collectionID)
+	if m.GetCollectionFunc == nil {
+		var r0 *gcs.Collection
+		return r0, notMocked("GetCollection")
+	}
+	return m.GetCollectionFunc(ctx, collectionID)
+}
+
+// GetCollectionDocument calls GetCollectionDocumentFunc.
+func (m *Client) GetCollectionDocument(ctx context.Context, collectionID string, include ...string) (map[string]interface{}, error) {
+	m.record("GetCollectionDocument", ctx, collectionID, include)
+	if m.GetCollectionDocumentFunc == nil {
+		var r0 map[string]interface{}
+		return r0, notMocked("GetCollectionDocument")
+	}
+	return m.GetCollectionDocumentFunc(ctx, collectionID, include...)
+}
+
+// GetCollectionDomain calls GetCollectionDomainFunc.
+func (m *Client) GetCollectionDomain(ctx context.Context, collectionID string) (*gcs.DomainConfig, error) {
+	m.record("GetCollectionDomain", ctx, collectionID)
+	if m.GetCollectionDomainFunc == nil {
+		var r0 *gcs.DomainConfig
+		return r0, notMocked("GetCollectionDomain")
+	}
+	return m.GetCollectionDomainFunc(ctx, collectionID)
+}
+
+// GetConditional calls GetConditionalFunc.
+func (m *Client) GetConditional(ctx context.Context, path string, v *gcs.Validators, target interface{}) error {
+	m.record("GetConditional", ctx, path, v, target)
+	if m.GetConditionalFunc == nil {
+		return notMocked("GetConditional")
+	}
+	return m.GetConditionalFunc(ctx, path, v, target)
+}
+
+// GetEndpoint calls GetEndpointFunc.
+func (m *Client) GetEndpoint(ctx context.Context) (*gcs.Endpoint, error) {
+	m.record("GetEndpoint", ctx)
+	if m.GetEndpointFunc == nil {
+		var r0 *gcs.Endpoint
+		return r0, notMocked("GetEndpoint")
+	}
+	return m.GetEndpointFunc(ctx)
+}
+
+// GetEndpointDomain calls GetEndpointDomainFunc.
+func (m *Client) GetEndpointDomain(ctx context.Context) (*gcs.DomainConfig, error) {
+	m.record("GetEndpointDomain", ctx)
+	if m.GetEndpointDomainFunc == nil {
+		var r0 *gcs.DomainConfig
+		return r0, notMocked("GetEndpointDomain")
+	}
+	return m.GetEndpointDomainFunc(ctx)
+}
+
+// GetFeatures calls GetFeaturesFunc.
+func (m *Client) GetFeatures(ctx context.Context) (*gcs.Features, error) {
+	m.record("GetFeatures", ctx)
+	if m.GetFeaturesFunc == nil {
+		var r0 *gcs.Features
+		return r0, notMocked("GetFeatures")
+	}
+	return m.GetFeaturesFunc(ctx)
+}
+
+// GetInfo calls GetInfoFunc.
+func (m *Client) GetInfo(ctx context.Context) (*gcs.Info, error) {
+	m.record("GetInfo", ctx)
+	if m.GetInfoFunc == nil {
+		var r0 *gcs.Info
+		return r0, notMocked("GetInfo")
+	}
+	return m.GetInfoFunc(ctx)
+}
+
+// GetLimits calls GetLimitsFunc.
+func (m *Client) GetLimits(ctx context.Context) (*gcs.Limits, error) {
+	m.record("GetLimits", ctx)
+	if m.GetLimitsFunc == nil {
+		var r0 *gcs.Limits
+		return r0, notMocked("GetLimits")
+	}
+	return m.GetLimitsFunc(ctx)
+}
+
+// GetNode calls GetNodeFunc.
+func (m *Client) GetNode(ctx context.Context, nodeID string) (*gcs.Node, error) {
+	m.record("GetNode", ctx, nodeID)
+	if m.GetNodeFunc == nil {
+		var r0 *gcs.Node
+		return r0, notMocked("GetNode")
+	}
+	return m.GetNodeFunc(ctx, nodeID)
+}
+
+// GetOIDCServer calls GetOIDCServerFunc.
+func (m *Client) GetOIDCServer(ctx context.Context) (*gcs.OIDCServer, error) {
+	m.record("GetOIDCServer", ctx)
+	if m.GetOIDCServerFunc == nil {
+		var r0 *gcs.OIDCServer
+		return r0, notMocked("GetOIDCServer")
+	}
+	return m.GetOIDCServerFunc(ctx)
+}
+
+// GetReleaseNotes calls GetReleaseNotesFunc.
+func (m *Client) GetReleaseNotes(ctx context.Context, fromVersion string, toVersion string) (*gcs.ReleaseNotes, error) {
+	m.record("GetReleaseNotes", ctx, fromVersion, toVersion)
+	if m.GetReleaseNotesFunc == nil {
+		var r0 *gcs.ReleaseNotes
+		return r0, notMocked("GetReleaseNotes")
+	}
+	return m.GetReleaseNotesFunc(ctx, fromVersion, toVersion)
+}
+
+// GetRole calls GetRoleFunc.
+func (m *Client) GetRole(ctx context.Context, roleID string) (*gcs.Role, error) {
+	m.record("GetRole", ctx, roleID)
+	if m.GetRoleFunc == nil {
+		var r0 *gcs.Role
+		return r0, notMocked("GetRole")
+	}
+	return m.GetRoleFunc(ctx, roleID)
+}
+
+// GetSession calls GetSessionFunc.
+func (m *Client) GetSession(ctx context.Context) (*gcs.Session, error) {
+	m.record("GetSession", ctx)
+	if m.GetSessionFunc == nil {
+		var r0 *gcs.Session
+		return r0, notMocked("GetSession")
+	}
+	return m.GetSessionFunc(ctx)
+}
+
+// GetSharingPolicy calls GetSharingPolicyFunc.
+func (m *Client) GetSharingPolicy(ctx context.Context, policyID string) (*gcs.SharingPolicy, error) {
+	m.record("GetSharingPolicy", ctx, policyID)
+	if m.GetSharingPolicyFunc == nil {
+		var r0 *gcs.SharingPolicy
+		return r0, notMocked("GetSharingPolicy")
+	}
+	return m.GetSharingPolicyFunc(ctx, policyID)
+}
+
+// GetStorageGateway calls GetStorageGatewayFunc.
+func (m *Client) GetStorageGateway(ctx context.Context, gatewayID string) (*gcs.StorageGateway, error) {
+	m.record("GetStorageGateway", ctx, gatewayID)
+	if m.GetStorageGatewayFunc == nil {
+		var r0 *gcs.StorageGateway
+		return r0, notMocked("GetStorageGateway")
+	}
+	return m.GetStorageGatewayFunc(ctx, gatewayID)
+}
+
+// GetStorageGatewayDocument calls GetStorageGatewayDocumentFunc.
+func (m *Client) GetStorageGatewayDocument(ctx context.Context, gatewayID string) (map[string]interface{}, error) {
+	m.record("GetStorageGatewayDocument", ctx, gatewayID)
+	if m.GetStorageGatewayDocumentFunc == nil {
+		var r0 map[string]interface{}
+		return r0, notMocked("GetStorageGatewayDocument")
+	}
+	return m.GetStorageGatewayDocumentFunc(ctx, gatewayID)
+}
+
+// GetUpgradeStatus calls GetUpgradeStatusFunc.
+func (m *Client) GetUpgradeStatus(ctx context.Context) (*gcs.UpgradeStatus, error) {
+	m.record("GetUpgradeStatus", ctx)
+	if m.GetUpgradeStatusFunc == nil {
+		var r0 *gcs.UpgradeStatus
+		return r0, notMocked("GetUpgradeStatus")
+	}
+	return m.GetUpgradeStatusFunc(ctx)
+}
+
+// GetUserCredential calls GetUserCredentialFunc.
+func (m *Client) GetUserCredential(ctx context.Context, credentialID string) (*gcs.UserCredential, error) {
+	m.record("GetUserCredential", ctx, credentialID)
+	if m.GetUserCredentialFunc == nil {
+		var r0 *gcs.UserCredential
+		return r0, notMocked("GetUserCredential")
+	}
+	return m.GetUserCredentialFunc(ctx, credentialID)
+}
+
+// ListAllCollections calls ListAllCollectionsFunc.
+func (m *Client) ListAllCollections(ctx context.Context, opts *gcs.ListCollectionsOptions) ([]gcs.Collection, error) {
+	m.record("ListAllCollections", ctx, opts)
+	if m.ListAllCollectionsFunc == nil {
+		var r0 []gcs.Collection
+		return r0, notMocked("ListAllCollections")
+	}
+	return m.ListAllCollectionsFunc(ctx, opts)
+}
+
+// ListAllNodes calls ListAllNodesFunc.
+func (m *Client) ListAllNodes(ctx context.Context, opts *gcs.ListNodesOptions) ([]gcs.Node, error) {
+	m.record("ListAllNodes", ctx, opts)
+	if m.ListAllNodesFunc == nil {
+		var r0 []gcs.Node
+		return r0, notMocked("ListAllNodes")
+	}
+	return m.ListAllNodesFunc(ctx, opts)
+}
+
+// ListAllRoles calls ListAllRolesFunc.
+func (m *Client) ListAllRoles(ctx context.Context, opts *gcs.ListRolesOptions) ([]gcs.Role, error) {
+	m.record("ListAllRoles", ctx, opts)
+	if m.ListAllRolesFunc == nil {
+		var r0 []gcs.Role
+		return r0, notMocked("ListAllRoles")
+	}
+	return m.ListAllRolesFunc(ctx, opts)
+}
+
+// ListAllStorageGateways calls ListAllStorageGatewaysFunc.
+func (m *Client) ListAllStorageGateways(ctx context.Context, opts *gcs.ListStorageGatewaysOptions) ([]gcs.StorageGateway, error) {
+	m.record("ListAllStorageGateways", ctx, opts)
+	if m.ListAllStorageGatewaysFunc == nil {
+		var r0 []gcs.StorageGateway
+		return r0, notMocked("ListAllStorageGateways")
+	}
+	return m.ListAllStorageGatewaysFunc(ctx, opts)
+}
+
+// ListAuthPolicies calls ListAuthPoliciesFunc.
+func (m *Client) ListAuthPolicies(ctx context.Context) (*gcs.AuthPolicyList, error) {
+	m.record("ListAuthPolicies", ctx)
+	if m.ListAuthPoliciesFunc == nil {
+		var r0 *gcs.AuthPolicyList
+		return r0, notMocked("ListAuthPolicies")
+	}
+	return m.ListAuthPoliciesFunc(ctx)
+}
+
+// ListCollections calls ListCollectionsFunc.
+func (m *Client) ListCollections(ctx context.Context, opts *gcs.ListCollectionsOptions) (*gcs.CollectionList, error) {
+	m.record("ListCollections", ctx, opts)
+	if m.ListCollectionsFunc == nil {
+		var r0 *gcs.CollectionList
+		return r0, notMocked("ListCollections")
+	}
+	return m.ListCollectionsFunc(ctx, opts)
+}
+
+// ListCollectionsForGateway calls ListCollectionsForGatewayFunc.
+func (m *Client) ListCollectionsForGateway(ctx context.Context, gatewayID string) ([]gcs.Collection, error) {
+	m.record("ListCollectionsForGateway", ctx, gatewayID)
+	if m.ListCollectionsForGatewayFunc == nil {
+		var r0 []gcs.Collection
+		return r0, notMocked("ListCollectionsForGateway")
+	}
+	return m.ListCollectionsForGatewayFunc(ctx, gatewayID)
+}
+
+// ListNodes calls ListNodesFunc.
+func (m *Client) ListNodes(ctx context.Context, opts *gcs.ListNodesOptions) (*gcs.NodeList, error) {
+	m.record("ListNodes", ctx, opts)
+	if m.ListNodesFunc == nil {
+		var r0 *gcs.NodeList
+		return r0, notMocked("ListNodes")
+	}
+	return m.ListNodesFunc(ctx, opts)
+}
+
+// ListRoles calls ListRolesFunc.
+func (m *Client) ListRoles(ctx context.Context, opts *gcs.ListRolesOptions) (*gcs.RoleList, error) {
+	m.record("ListRoles", ctx, opts)
+	if m.ListRolesFunc == nil {
+		var r0 *gcs.RoleList
+		return r0, notMocked("ListRoles")
+	}
+	return m.ListRolesFunc(ctx, opts)
+}
+
+// ListSharingPolicies calls ListSharingPoliciesFunc.
+func (m *Client) ListSharingPolicies(ctx context.Context) (*gcs.SharingPolicyList, error) {
+	m.record("ListSharingPolicies", ctx)
+	if m.ListSharingPoliciesFunc == nil {
+		var r0 *gcs.SharingPolicyList
+		return r0, notMocked("ListSharingPolicies")
+	}
+	return m.ListSharingPoliciesFunc(ctx)
+}
+
+// ListStorageGateways calls ListStorageGatewaysFunc.
+func (m *Client) ListStorageGateways(ctx context.Context, opts *gcs.ListStorageGatewaysOptions) (*gcs.StorageGatewayList, error) {
+	m.record("ListStorageGateways", ctx, opts)
+	if m.ListStorageGatewaysFunc == nil {
+		var r0 *gcs.StorageGatewayList
+		return r0, notMocked("ListStorageGateways")
+	}
+	return m.ListStorageGatewaysFunc(ctx, opts)
+}
+
+// ListUserCredentials calls ListUserCredentialsFunc.
+func (m *Client) ListUserCredentials(ctx context.Context) (*gcs.UserCredentialList, error) {
+	m.record("ListUserCredentials", ctx)
+	if m.ListUserCredentialsFunc == nil {
+		var r0 *gcs.UserCredentialList
+		return r0, notMocked("ListUserCredentials")
+	}
+	return m.ListUserCredentialsFunc(ctx)
+}
+
+// Nodes calls NodesFunc.
+func (m *Client) Nodes(ctx context.Context, opts *gcs.ListNodesOptions) iter.Seq2[gcs.Node, error] {
+	m.record("Nodes", ctx, opts)
+	if m.NodesFunc == nil {
+		return notMockedSeq[gcs.Node]("Nodes")
+	}
+	return m.NodesFunc(ctx, opts)
+}
+
+// PatchAuthPolicy calls PatchAuthPolicyFunc.
+func (m *Client) PatchAuthPolicy(ctx context.Context, policyID string, fields map[string]interface{}) error {
+	m.record("PatchAuthPolicy", ctx, policyID, fields)
+	if m.PatchAuthPolicyFunc == nil {
+		return notMocked("PatchAuthPolicy")
+	}
+	return m.PatchAuthPolicyFunc(ctx, policyID, fields)
+}
+
+// PatchCollection calls PatchCollectionFunc.
+func (m *Client) PatchCollection(ctx context.Context, collectionID string, fields map[string]interface{}) error {
+	m.record("PatchCollection", ctx, collectionID, fields)
+	if m.PatchCollectionFunc == nil {
+		return notMocked("PatchCollection")
+	}
+	return m.PatchCollectionFunc(ctx, collectionID, fields)
+}
+
+// PatchStorageGateway calls PatchStorageGatewayFunc.
+func (m *Client) PatchStorageGateway(ctx context.Context, gatewayID string, fields map[string]interface{}) error {
+	m.record("PatchStorageGateway", ctx, gatewayID, fields)
+	if m.PatchStorageGatewayFunc == nil {
+		return notMocked("PatchStorageGateway")
+	}
+	return m.PatchStorageGatewayFunc(ctx, gatewayID, fields)
+}
+
+// RegisterOIDCServer calls RegisterOIDCServerFunc.
+func (m *Client) RegisterOIDCServer(ctx context.Context, server *gcs.OIDCServer) (*gcs.OIDCServer, error) {
+	m.record("RegisterOIDCServer", ctx, server)
+	if m.RegisterOIDCServerFunc == nil {
+		var r0 *gcs.OIDCServer
+		return r0, notMocked("RegisterOIDCServer")
+	}
+	return m.RegisterOIDCServerFunc(ctx, server)
+}
+
+// ResetCollectionOwnerString calls ResetCollectionOwnerStringFunc.
+func (m *Client) ResetCollectionOwnerString(ctx context.Context, collectionID string) error {
+	m.record("ResetCollectionOwnerString", ctx, collectionID)
+	if m.ResetCollectionOwnerStringFunc == nil {
+		return notMocked("ResetCollectionOwnerString")
+	}
+	return m.ResetCollectionOwnerStringFunc(ctx, collectionID)
+}
+
+// ResetEndpointOwnerString calls ResetEndpointOwnerStringFunc.
+func (m *Client) ResetEndpointOwnerString(ctx context.Context) error {
+	m.record("ResetEndpointOwnerString", ctx)
+	if m.ResetEndpointOwnerStringFunc == nil {
+		return notMocked("ResetEndpointOwnerString")
+	}
+	return m.ResetEndpointOwnerStringFunc(ctx)
+}
+
+// ResourceServer calls ResourceServerFunc.
+func (m *Client) ResourceServer() string {
+	m.record("ResourceServer")
+	if m.ResourceServerFunc == nil {
+		var r0 string
+		return r0
+	}
+	return m.ResourceServerFunc()
+}
+
+// Roles calls RolesFunc.
+func (m *Client) Roles(ctx context.Context, opts *gcs.ListRolesOptions) iter.Seq2[gcs.Role, error] {
+	m.record("Roles", ctx, opts)
+	if m.RolesFunc == nil {
+		return notMockedSeq[gcs.Role]("Roles")
+	}
+	return m.RolesFunc(ctx, opts)
+}
+
+// SetAccessToken calls SetAccessTokenFunc.
+func (m *Client) SetAccessToken(token string) {
+	m.record("SetAccessToken", token)
+	if m.SetAccessTokenFunc != nil {
+		m.SetAccessTokenFunc(token)
+	}
+}
+
+// SetCollectionOwner calls SetCollectionOwnerFunc.
+func (m *Client) SetCollectionOwner(ctx context.Context, collectionID string, principalURN string) error {
+	m.record("SetCollectionOwner", ctx, collectionID, principalURN)
+	if m.SetCollectionOwnerFunc == nil {
+		return notMocked("SetCollectionOwner")
+	}
+	return m.SetCollectionOwnerFunc(ctx, collectionID, principalURN)
+}
+
+// SetCollectionOwnerString calls SetCollectionOwnerStringFunc.
+func (m *Client) SetCollectionOwnerString(ctx context.Context, collectionID string, ownerString string) error {
+	m.record("SetCollectionOwnerString", ctx, collectionID, ownerString)
+	if m.SetCollectionOwnerStringFunc == nil {
+		return notMocked("SetCollectionOwnerString")
+	}
+	return m.SetCollectionOwnerStringFunc(ctx, collectionID, ownerString)
+}
+
+// SetCollectionUserMessage calls SetCollectionUserMessageFunc.
+func (m *Client) SetCollectionUserMessage(ctx context.Context, collectionID string, message string, link string) error {
+	m.record("SetCollectionUserMessage", ctx, collectionID, message, link)
+	if m.SetCollectionUserMessageFunc == nil {
+		return notMocked("SetCollectionUserMessage")
+	}
+	return m.SetCollectionUserMessageFunc(ctx, collectionID, message, link)
+}
+
+// SetEndpointOwner calls SetEndpointOwnerFunc.
+func (m *Client) SetEndpointOwner(ctx context.Context, principalURN string) error {
+	m.record("SetEndpointOwner", ctx, principalURN)
+	if m.SetEndpointOwnerFunc == nil {
+		return notMocked("SetEndpointOwner")
+	}
+	return m.SetEndpointOwnerFunc(ctx, principalURN)
+}
+
+// SetEndpointOwnerString calls SetEndpointOwnerStringFunc.
+func (m *Client) SetEndpointOwnerString(ctx context.Context, ownerString string) error {
+	m.record("SetEndpointOwnerString", ctx, ownerString)
+	if m.SetEndpointOwnerStringFunc == nil {
+		return notMocked("SetEndpointOwnerString")
+	}
+	return m.SetEndpointOwnerStringFunc(ctx, ownerString)
+}
+
+// SetSubscriptionAdminVerified calls SetSubscriptionAdminVerifiedFunc.
+func (m *Client) SetSubscriptionAdminVerified(ctx context.Context, collectionID string, verified bool) error {
+	m.record("SetSubscriptionAdminVerified", ctx, collectionID, verified)
+	if m.SetSubscriptionAdminVerifiedFunc == nil {
+		return notMocked("SetSubscriptionAdminVerified")
+	}
+	return m.SetSubscriptionAdminVerifiedFunc(ctx, collectionID, verified)
+}
+
+// SetSubscriptionID calls SetSubscriptionIDFunc.
+func (m *Client) SetSubscriptionID(ctx context.Context, subscriptionID string) error {
+	m.record("SetSubscriptionID", ctx, subscriptionID)
+	if m.SetSubscriptionIDFunc == nil {
+		return notMocked("SetSubscriptionID")
+	}
+	return m.SetSubscriptionIDFunc(ctx, subscriptionID)
+}
+
+// SetupCollectionDomain calls SetupCollectionDomainFunc.
+func (m *Client) SetupCollectionDomain(ctx context.Context, collectionID string, config *gcs.DomainConfig) error {
+	m.record("SetupCollectionDomain", ctx, collectionID, config)
+	if m.SetupCollectionDomainFunc == nil {
+		return notMocked("SetupCollectionDomain")
+	}
+	return m.SetupCollectionDomainFunc(ctx, collectionID, config)
+}
+
+// SetupEndpoint calls SetupEndpointFunc.
+func (m *Client) SetupEndpoint(ctx context.Context, endpoint *gcs.Endpoint) (*gcs.Endpoint, error) {
+	m.record("SetupEndpoint", ctx, endpoint)
+	if m.SetupEndpointFunc == nil {
+		var r0 *gcs.Endpoint
+		return r0, notMocked("SetupEndpoint")
+	}
+	return m.SetupEndpointFunc(ctx, endpoint)
+}
+
+// SetupEndpointDomain calls SetupEndpointDomainFunc.
+func (m *Client) SetupEndpointDomain(ctx context.Context, config *gcs.DomainConfig) error {
+	m.record("SetupEndpointDomain", ctx, config)
+	if m.SetupEndpointDomainFunc == nil {
+		return notMocked("SetupEndpointDomain")
+	}
+	return m.SetupEndpointDomainFunc(ctx, config)
+}
+
+// SetupNode calls SetupNodeFunc.
+func (m *Client) SetupNode(ctx context.Context, node *gcs.Node) (*gcs.Node, error) {
+	m.record("SetupNode", ctx, node)
+	if m.SetupNodeFunc == nil {
+		var r0 *gcs.Node
+		return r0, notMocked("SetupNode")
+	}
+	return m.SetupNodeFunc(ctx, node)
+}
+
+// StorageGateways calls StorageGatewaysFunc.
+func (m *Client) StorageGateways(ctx context.Context, opts *gcs.ListStorageGatewaysOptions) iter.Seq2[gcs.StorageGateway, error] {
+	m.record("StorageGateways", ctx, opts)
+	if m.StorageGatewaysFunc == nil {
+		return notMockedSeq[gcs.StorageGateway]("StorageGateways")
+	}
+	return m.StorageGatewaysFunc(ctx, opts)
+}
+
+// UpdateAuthPolicy calls UpdateAuthPolicyFunc.
+func (m *Client) UpdateAuthPolicy(ctx context.Context, policyID string, policy *gcs.AuthPolicy) (*gcs.AuthPolicy, error) {
+	m.record("UpdateAuthPolicy", ctx, policyID, policy)
+	if m.UpdateAuthPolicyFunc == nil {
+		var r0 *gcs.AuthPolicy
+		return r0, notMocked("UpdateAuthPolicy")
+	}
+	return m.UpdateAuthPolicyFunc(ctx, policyID, policy)
+}
+
+// UpdateCollection calls UpdateCollectionFunc.
+func (m *Client) UpdateCollection(ctx context.Context, collectionID string, collection *gcs.Collection) (*gcs.Collection, error) {
+	m.record("UpdateCollection", ctx, collectionID, collection)
+	if m.UpdateCollectionFunc == nil {
+		var r0 *gcs.Collection
+		return r0, notMocked("UpdateCollection")
+	}
+	return m.UpdateCollectionFunc(ctx, collectionID, collection)
+}
+
+// UpdateEndpoint calls UpdateEndpointFunc.
+func (m *Client) UpdateEndpoint(ctx context.Context, endpoint *gcs.Endpoint) (*gcs.Endpoint, error) {
+	m.record("UpdateEndpoint", ctx, endpoint)
+	if m.UpdateEndpointFunc == nil {
+		var r0 *gcs.Endpoint
+		return r0, notMocked("UpdateEndpoint")
+	}
+	return m.UpdateEndpointFunc(ctx, endpoint)
+}
+
+// UpdateNode calls UpdateNodeFunc.
+func (m *Client) UpdateNode(ctx context.Context, nodeID string, node *gcs.Node) (*gcs.Node, error) {
+	m.record("UpdateNode", ctx, nodeID, node)
+	if m.UpdateNodeFunc == nil {
+		var r0 *gcs.Node
+		return r0, notMocked("UpdateNode")
+	}
+	return m.UpdateNodeFunc(ctx, nodeID, node)
+}
+
+// UpdateOIDCServer calls UpdateOIDCServerFunc.
+func (m *Client) UpdateOIDCServer(ctx context.Context, server *gcs.OIDCServer) (*gcs.OIDCServer, error) {
+	m.record("UpdateOIDCServer", ctx, server)
+	if m.UpdateOIDCServerFunc == nil {
+		var r0 *gcs.OIDCServer
+		return r0, notMocked("UpdateOIDCServer")
+	}
+	return m.UpdateOIDCServerFunc(ctx, server)
+}
+
+// UpdateRole calls UpdateRoleFunc.
+func (m *Client) UpdateRole(ctx context.Context, roleID string, role *gcs.Role) (*gcs.Role, error) {
+	m.record("UpdateRole", ctx, roleID, role)
+	if m.UpdateRoleFunc == nil {
+		var r0 *gcs.Role
+		return r0, notMocked("UpdateRole")
+	}
+	return m.UpdateRoleFunc(ctx, roleID, role)
+}
+
+// UpdateS3Key calls UpdateS3KeyFunc.
+func (m *Client) UpdateS3Key(ctx context.Context, credentialID string, accessKeyID string, key *gcs.S3Key) (*gcs.UserCredential, error) {
+	m.record("UpdateS3Key", ctx, credentialID, accessKeyID, key)
+	if m.UpdateS3KeyFunc == nil {
+		var r0 *gcs.UserCredential
+		return r0, notMocked("UpdateS3Key")
+	}
+	return m.UpdateS3KeyFunc(ctx, credentialID, accessKeyID, key)
+}
+
+// UpdateSession calls UpdateSessionFunc.
+func (m *Client) UpdateSession(ctx context.Context, session *gcs.Session) (*gcs.Session, error) {
+	m.record("UpdateSession", ctx, session)
+	if m.UpdateSessionFunc == nil {
+		var r0 *gcs.Session
+		return r0, notMocked("UpdateSession")
+	}
+	return m.UpdateSessionFunc(ctx, session)
+}
+
+// UpdateSessionConsents calls UpdateSessionConsentsFunc.
+func (m *Client) UpdateSessionConsents(ctx context.Context, consents []string) (*gcs.Session, error) {
+	m.record("UpdateSessionConsents", ctx, consents)
+	if m.UpdateSessionConsentsFunc == nil {
+		var r0 *gcs.Session
+		return r0, notMocked("UpdateSessionConsents")
+	}
+	return m.UpdateSessionConsentsFunc(ctx, consents)
+}
+
+// UpdateStorageGateway calls UpdateStorageGatewayFunc.
+func (m *Client) UpdateStorageGateway(ctx context.Context, gatewayID string, gateway *gcs.StorageGateway) (*gcs.StorageGateway, error) {
+	m.record("UpdateStorageGateway", ctx, gatewayID, gateway)
+	if m.UpdateStorageGatewayFunc == nil {
+		var r0 *gcs.StorageGateway
+		return r0, notMocked("UpdateStorageGateway")
+	}
+	return m.UpdateStorageGatewayFunc(ctx, gatewayID, gateway)
+}
+
+// UpgradeEndpoint calls UpgradeEndpointFunc.
+func (m *Client) UpgradeEndpoint(ctx context.Context) (*gcs.UpgradeResult, error) {
+	m.record("UpgradeEndpoint", ctx)
+	if m.UpgradeEndpointFunc == nil {
+		var r0 *gcs.UpgradeResult
+		return r0, notMocked("UpgradeEndpoint")
+	}
+	return m.UpgradeEndpointFunc(ctx)
+}
+
+// VerifyUpgrade calls VerifyUpgradeFunc.
+func (m *Client) VerifyUpgrade(ctx context.Context, previousVersion string) (*gcs.UpgradeVerification, error) {
+	m.record("VerifyUpgrade", ctx, previousVersion)
+	if m.VerifyUpgradeFunc == nil {
+		var r0 *gcs.UpgradeVerification
+		return r0, notMocked("VerifyUpgrade")
+	}
+	return m.VerifyUpgradeFunc(ctx, previousVersion)
+}
+
+// WaitForUpgrade calls WaitForUpgradeFunc.
+func (m *Client) WaitForUpgrade(ctx context.Context, targetVersion string, interval time.Duration, onProgress func(*gcs.UpgradeStatus)) (*gcs.UpgradeStatus, error) {
+	m.record("WaitForUpgrade", ctx, targetVersion, interval, onProgress)
+	if m.WaitForUpgradeFunc == nil {
+		var r0 *gcs.UpgradeStatus
+		return r0, notMocked("WaitForUpgrade")
+	}
+	return m.WaitForUpgradeFunc(ctx, targetVersion, interval, onProgress)
+}
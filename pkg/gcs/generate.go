@@ -0,0 +1,7 @@
+package gcs
+
+// Types for GCS Manager API schemas that are not maintained by hand in this
+// package are generated from the published OpenAPI spec. Hand-written types
+// are checked against the spec by TestOpenAPIDrift.
+//
+//go:generate go run ../../internal/tools/gcsgen -spec ../../api/gcs-manager-openapi.json -out zz_generated_types.go
@@ -0,0 +1,82 @@
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// CreateGuestCollection creates a guest collection sharing part of the
+// mapped collection guest.MappedCollectionID. guest.CollectionBaseFolder
+// is the shared path within the mapped collection ("/" for all of it) and
+// guest.UserCredentialID the credential the guest collection accesses
+// storage with.
+//
+// Before creating it, the mapped collection is checked to be a mapped
+// collection that allows guest collections, and the user credential to
+// be on the same storage gateway, so that mistakes fail with a clear
+// message instead of a generic HTTP 400.
+func (c *Client) CreateGuestCollection(ctx context.Context, guest *Collection) (*Collection, error) {
+	if guest == nil {
+		return nil, fmt.Errorf("collection is required")
+	}
+	if guest.MappedCollectionID == "" {
+		return nil, fmt.Errorf("mapped collection ID is required")
+	}
+	if guest.UserCredentialID == "" {
+		return nil, fmt.Errorf("user credential ID is required")
+	}
+	if guest.DisplayName == "" {
+		return nil, fmt.Errorf("display name is required")
+	}
+	basePath, err := guestBasePath(guest.CollectionBaseFolder)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped, err := c.GetCollection(ctx, guest.MappedCollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("get mapped collection: %w", err)
+	}
+	if mapped.CollectionType != "" && mapped.CollectionType != CollectionTypeMapped {
+		return nil, fmt.Errorf("collection %s is a %s collection; guest collections share a mapped collection", mapped.ID, mapped.CollectionType)
+	}
+	if mapped.AllowGuestCollections != nil && !*mapped.AllowGuestCollections {
+		return nil, fmt.Errorf("mapped collection %s does not allow guest collections", mapped.ID)
+	}
+
+	credential, err := c.GetUserCredential(ctx, guest.UserCredentialID)
+	if err != nil {
+		return nil, fmt.Errorf("get user credential: %w", err)
+	}
+	if credential.StorageGatewayID != "" && mapped.StorageGatewayID != "" && credential.StorageGatewayID != mapped.StorageGatewayID {
+		return nil, fmt.Errorf("user credential %s is for storage gateway %s, but mapped collection %s uses %s",
+			guest.UserCredentialID, credential.StorageGatewayID, mapped.ID, mapped.StorageGatewayID)
+	}
+
+	collection := *guest
+	collection.CollectionType = CollectionTypeGuest
+	collection.CollectionBaseFolder = basePath
+	// The gateway is the mapped collection's and may not be set
+	collection.StorageGatewayID = ""
+	return c.CreateCollection(ctx, &collection)
+}
+
+// guestBasePath returns the cleaned base path of a guest collection, which
+// must be absolute within its mapped collection and may not climb out of
+// it. An empty path shares the whole mapped collection.
+func guestBasePath(basePath string) (string, error) {
+	if basePath == "" {
+		return "/", nil
+	}
+	if !strings.HasPrefix(basePath, "/") {
+		return "", fmt.Errorf("base path %q must start with /; it is relative to the mapped collection's root", basePath)
+	}
+	for _, part := range strings.Split(basePath, "/") {
+		if part == ".." {
+			return "", fmt.Errorf("base path %q may not contain ..", basePath)
+		}
+	}
+	return path.Clean(basePath), nil
+}
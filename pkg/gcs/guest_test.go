@@ -0,0 +1,148 @@
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newGuestServer returns a client for a fake API with the mapped
+// collections "mapped" (gateway gw-1), "closed" (no guest collections),
+// and "guest", and the user credentials "cred-1" (gw-1) and "cred-2"
+// (gw-2). Created collections are passed to created.
+func newGuestServer(t *testing.T, created func(Collection)) *Client {
+	t.Helper()
+
+	deny := false
+	collections := map[string]Collection{
+		"mapped": {ID: "mapped", CollectionType: CollectionTypeMapped, StorageGatewayID: "gw-1"},
+		"closed": {ID: "closed", CollectionType: CollectionTypeMapped, StorageGatewayID: "gw-1", AllowGuestCollections: &deny},
+		"guest":  {ID: "guest", CollectionType: CollectionTypeGuest, StorageGatewayID: "gw-1"},
+	}
+	credentials := map[string]UserCredential{
+		"cred-1": {ID: "cred-1", StorageGatewayID: "gw-1"},
+		"cred-2": {ID: "cred-2", StorageGatewayID: "gw-2"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/collections":
+			var collection Collection
+			if err := json.NewDecoder(r.Body).Decode(&collection); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			created(collection)
+			collection.ID = "new-guest"
+			_ = json.NewEncoder(w).Encode(collection)
+		case strings.HasPrefix(r.URL.Path, "/api/collections/"):
+			collection, ok := collections[strings.TrimPrefix(r.URL.Path, "/api/collections/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(collection)
+		case strings.HasPrefix(r.URL.Path, "/api/user-credentials/"):
+			credential, ok := credentials[strings.TrimPrefix(r.URL.Path, "/api/user-credentials/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(credential)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return &Client{baseURL: server.URL + "/api/", httpClient: &http.Client{}}
+}
+
+func TestCreateGuestCollection(t *testing.T) {
+	var sent Collection
+	client := newGuestServer(t, func(c Collection) { sent = c })
+
+	created, err := client.CreateGuestCollection(context.Background(), &Collection{
+		DisplayName:          "Share",
+		MappedCollectionID:   "mapped",
+		UserCredentialID:     "cred-1",
+		CollectionBaseFolder: "/projects//shared/",
+		StorageGatewayID:     "ignored",
+	})
+	if err != nil {
+		t.Fatalf("CreateGuestCollection() error = %v", err)
+	}
+	if created.ID != "new-guest" {
+		t.Errorf("created ID = %q, want new-guest", created.ID)
+	}
+	if sent.CollectionType != CollectionTypeGuest || sent.MappedCollectionID != "mapped" || sent.UserCredentialID != "cred-1" {
+		t.Errorf("sent = %+v, want a guest collection of mapped with cred-1", sent)
+	}
+	if sent.CollectionBaseFolder != "/projects/shared" {
+		t.Errorf("sent base path = %q, want /projects/shared", sent.CollectionBaseFolder)
+	}
+	if sent.StorageGatewayID != "" {
+		t.Errorf("sent storage gateway = %q, want none", sent.StorageGatewayID)
+	}
+}
+
+func TestCreateGuestCollection_Invalid(t *testing.T) {
+	client := newGuestServer(t, func(c Collection) {
+		t.Errorf("collection %q created, want the request rejected first", c.DisplayName)
+	})
+
+	tests := []struct {
+		name    string
+		guest   Collection
+		wantErr string
+	}{
+		{
+			name:    "no mapped collection",
+			guest:   Collection{DisplayName: "x", UserCredentialID: "cred-1"},
+			wantErr: "mapped collection ID is required",
+		},
+		{
+			name:    "no user credential",
+			guest:   Collection{DisplayName: "x", MappedCollectionID: "mapped"},
+			wantErr: "user credential ID is required",
+		},
+		{
+			name:    "relative base path",
+			guest:   Collection{DisplayName: "x", MappedCollectionID: "mapped", UserCredentialID: "cred-1", CollectionBaseFolder: "projects"},
+			wantErr: "must start with /",
+		},
+		{
+			name:    "base path climbs out",
+			guest:   Collection{DisplayName: "x", MappedCollectionID: "mapped", UserCredentialID: "cred-1", CollectionBaseFolder: "/a/../../etc"},
+			wantErr: "may not contain ..",
+		},
+		{
+			name:    "parent is a guest collection",
+			guest:   Collection{DisplayName: "x", MappedCollectionID: "guest", UserCredentialID: "cred-1"},
+			wantErr: "is a guest collection",
+		},
+		{
+			name:    "guest collections not allowed",
+			guest:   Collection{DisplayName: "x", MappedCollectionID: "closed", UserCredentialID: "cred-1"},
+			wantErr: "does not allow guest collections",
+		},
+		{
+			name:    "credential on another gateway",
+			guest:   Collection{DisplayName: "x", MappedCollectionID: "mapped", UserCredentialID: "cred-2"},
+			wantErr: "is for storage gateway gw-2, but mapped collection mapped uses gw-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.CreateGuestCollection(context.Background(), &tt.guest)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CreateGuestCollection() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Sources of Limits.
+const (
+	// LimitsSourceServer means the endpoint reported its limits directly.
+	LimitsSourceServer = "server"
+
+	// LimitsSourceDerived means the limits were derived from the endpoint's
+	// subscription status because the endpoint does not report them.
+	LimitsSourceDerived = "derived"
+)
+
+// Limits describes the subscription-dependent limits of an endpoint and
+// its current usage.
+type Limits struct {
+	Source           string `json:"source"`
+	SubscriptionID   string `json:"subscription_id,omitempty"`
+	Managed          bool   `json:"managed"`
+	MaxCollections   int    `json:"max_collections"` // 0 means unlimited
+	CollectionCount  int    `json:"collection_count"`
+	GuestCollections bool   `json:"guest_collections"`
+	HighAvailability bool   `json:"high_availability"`
+	MaxNodes         int    `json:"max_nodes"` // 0 means unlimited
+	NodeCount        int    `json:"node_count"`
+}
+
+// GetLimits retrieves the endpoint's limits and current usage.
+//
+// Endpoints that do not report limits have them derived from the
+// subscription: unmanaged endpoints (no subscription) cannot create guest
+// collections or run more than one node (high availability).
+func (c *Client) GetLimits(ctx context.Context) (*Limits, error) {
+	var limits Limits
+	err := c.GetConditional(ctx, "endpoint/limits", nil, &limits)
+	switch {
+	case err == nil:
+		limits.Source = LimitsSourceServer
+	case isHTTPStatus(err, 404):
+		endpoint, err := c.GetEndpoint(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get limits: %w", err)
+		}
+		limits = deriveLimits(endpoint)
+	default:
+		return nil, fmt.Errorf("get limits: %w", err)
+	}
+
+	// Usage is always counted client-side, across all pages, so it is
+	// current.
+	collections, err := c.ListAllCollections(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get limits: %w", err)
+	}
+	limits.CollectionCount = len(collections)
+
+	nodes, err := c.ListAllNodes(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get limits: %w", err)
+	}
+	limits.NodeCount = len(nodes)
+
+	return &limits, nil
+}
+
+// deriveLimits derives limits from the endpoint's subscription status.
+func deriveLimits(endpoint *Endpoint) Limits {
+	limits := Limits{
+		Source:           LimitsSourceDerived,
+		SubscriptionID:   endpoint.SubscriptionID,
+		Managed:          endpoint.SubscriptionID != "",
+		GuestCollections: true,
+		HighAvailability: true,
+	}
+	if !limits.Managed {
+		limits.GuestCollections = false
+		limits.HighAvailability = false
+		limits.MaxNodes = 1
+	}
+	return limits
+}
+
+// ErrLimitExceeded indicates an operation would exceed an endpoint limit.
+var ErrLimitExceeded = errors.New("endpoint limit exceeded")
+
+// CheckCollectionCreate reports the limits that creating a collection of
+// collectionType would exceed. The returned errors wrap ErrLimitExceeded.
+func (l *Limits) CheckCollectionCreate(collectionType string) []error {
+	var problems []error
+	if collectionType == "guest" && !l.GuestCollections {
+		problems = append(problems, fmt.Errorf("%w: guest collections require the endpoint to be managed by a subscription", ErrLimitExceeded))
+	}
+	if l.MaxCollections > 0 && l.CollectionCount >= l.MaxCollections {
+		problems = append(problems, fmt.Errorf("%w: endpoint already has %d of %d allowed collections", ErrLimitExceeded, l.CollectionCount, l.MaxCollections))
+	}
+	return problems
+}
+
+// CheckNodeCreate reports the limits that adding a node would exceed. The
+// returned errors wrap ErrLimitExceeded.
+func (l *Limits) CheckNodeCreate() []error {
+	var problems []error
+	if l.MaxNodes > 0 && l.NodeCount >= l.MaxNodes {
+		reason := fmt.Sprintf("endpoint already has %d of %d allowed nodes", l.NodeCount, l.MaxNodes)
+		if !l.HighAvailability {
+			reason = "multiple nodes (high availability) require the endpoint to be managed by a subscription"
+		}
+		problems = append(problems, fmt.Errorf("%w: %s", ErrLimitExceeded, reason))
+	}
+	return problems
+}
@@ -0,0 +1,153 @@
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetLimits_Derived(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/endpoint/limits":
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/endpoint":
+			_ = json.NewEncoder(w).Encode(Endpoint{ID: "ep-1"})
+		case "/api/collections":
+			_ = json.NewEncoder(w).Encode(CollectionList{Data: []Collection{{ID: "c1"}, {ID: "c2"}}})
+		case "/api/nodes":
+			_ = json.NewEncoder(w).Encode(NodeList{Data: []Node{{ID: "n1"}}})
+		default:
+			t.Errorf("unexpected request path %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:    server.URL + "/api/",
+		httpClient: &http.Client{},
+		userAgent:  "test-agent",
+	}
+
+	limits, err := client.GetLimits(context.Background())
+	if err != nil {
+		t.Fatalf("GetLimits() error = %v", err)
+	}
+
+	if limits.Source != LimitsSourceDerived {
+		t.Errorf("Source = %q, want %q", limits.Source, LimitsSourceDerived)
+	}
+	if limits.Managed || limits.GuestCollections || limits.HighAvailability {
+		t.Errorf("limits = %+v, want unmanaged endpoint without guest collections or HA", limits)
+	}
+	if limits.CollectionCount != 2 || limits.NodeCount != 1 {
+		t.Errorf("counts = %d collections, %d nodes, want 2 and 1", limits.CollectionCount, limits.NodeCount)
+	}
+}
+
+func TestGetLimits_Server(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/endpoint/limits":
+			_ = json.NewEncoder(w).Encode(Limits{Managed: true, MaxCollections: 10, GuestCollections: true, HighAvailability: true})
+		case "/api/collections":
+			// Usage is counted across all pages
+			if r.URL.Query().Get("marker") == "" {
+				_ = json.NewEncoder(w).Encode(CollectionList{Data: []Collection{{ID: "c1"}, {ID: "c2"}}, HasNextPage: true, Marker: "m2"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(CollectionList{Data: []Collection{{ID: "c3"}}})
+		case "/api/nodes":
+			if r.URL.Query().Get("marker") == "" {
+				_ = json.NewEncoder(w).Encode(NodeList{Data: []Node{{ID: "n1"}}, HasNextPage: true, Marker: "m2"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(NodeList{Data: []Node{{ID: "n2"}}})
+		default:
+			t.Errorf("unexpected request path %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:    server.URL + "/api/",
+		httpClient: &http.Client{},
+		userAgent:  "test-agent",
+	}
+
+	limits, err := client.GetLimits(context.Background())
+	if err != nil {
+		t.Fatalf("GetLimits() error = %v", err)
+	}
+	if limits.Source != LimitsSourceServer {
+		t.Errorf("Source = %q, want %q", limits.Source, LimitsSourceServer)
+	}
+	if limits.CollectionCount != 3 || limits.NodeCount != 2 {
+		t.Errorf("counts = %d collections, %d nodes, want 3 and 2 from both pages", limits.CollectionCount, limits.NodeCount)
+	}
+}
+
+func TestLimits_CheckCollectionCreate(t *testing.T) {
+	tests := []struct {
+		name           string
+		limits         Limits
+		collectionType string
+		wantProblems   int
+	}{
+		{
+			name:           "mapped on unmanaged endpoint",
+			limits:         deriveLimits(&Endpoint{}),
+			collectionType: "mapped",
+			wantProblems:   0,
+		},
+		{
+			name:           "guest on unmanaged endpoint",
+			limits:         deriveLimits(&Endpoint{}),
+			collectionType: "guest",
+			wantProblems:   1,
+		},
+		{
+			name:           "guest on managed endpoint",
+			limits:         deriveLimits(&Endpoint{SubscriptionID: "sub-1"}),
+			collectionType: "guest",
+			wantProblems:   0,
+		},
+		{
+			name:           "at collection maximum",
+			limits:         Limits{GuestCollections: true, MaxCollections: 5, CollectionCount: 5},
+			collectionType: "mapped",
+			wantProblems:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := tt.limits.CheckCollectionCreate(tt.collectionType)
+			if len(problems) != tt.wantProblems {
+				t.Fatalf("CheckCollectionCreate() = %v, want %d problem(s)", problems, tt.wantProblems)
+			}
+			for _, p := range problems {
+				if !errors.Is(p, ErrLimitExceeded) {
+					t.Errorf("problem %v does not wrap ErrLimitExceeded", p)
+				}
+			}
+		})
+	}
+}
+
+func TestLimits_CheckNodeCreate(t *testing.T) {
+	unmanaged := deriveLimits(&Endpoint{})
+	unmanaged.NodeCount = 1
+	if problems := unmanaged.CheckNodeCreate(); len(problems) != 1 {
+		t.Errorf("CheckNodeCreate() on unmanaged endpoint = %v, want 1 problem", problems)
+	}
+
+	managed := deriveLimits(&Endpoint{SubscriptionID: "sub-1"})
+	managed.NodeCount = 3
+	if problems := managed.CheckNodeCreate(); len(problems) != 0 {
+		t.Errorf("CheckNodeCreate() on managed endpoint = %v, want none", problems)
+	}
+}
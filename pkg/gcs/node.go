@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -58,6 +59,29 @@ func (c *Client) ListNodes(ctx context.Context, opts *ListNodesOptions) (*NodeLi
 	return &list, nil
 }
 
+// Nodes returns an iterator over every node matching opts,
+// following pagination markers. opts.Marker is ignored.
+func (c *Client) Nodes(ctx context.Context, opts *ListNodesOptions) iter.Seq2[Node, error] {
+	pageOpts := ListNodesOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	return Paginate(ctx, func(ctx context.Context, marker string) ([]Node, string, bool, error) {
+		pageOpts.Marker = marker
+		list, err := c.ListNodes(ctx, &pageOpts)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return list.Data, list.Marker, list.HasNextPage, nil
+	})
+}
+
+// ListAllNodes returns every node matching opts, following
+// pagination markers. opts.Marker is ignored.
+func (c *Client) ListAllNodes(ctx context.Context, opts *ListNodesOptions) ([]Node, error) {
+	return CollectAll(c.Nodes(ctx, opts))
+}
+
 // GetNode retrieves a specific node by ID.
 func (c *Client) GetNode(ctx context.Context, nodeID string) (*Node, error) {
 	if nodeID == "" {
@@ -0,0 +1,98 @@
+package gcs
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/openapi"
+)
+
+// openAPISpecPath is the published GCS Manager OpenAPI spec, fetched with
+// 'make fetch-openapi'.
+const openAPISpecPath = "../../api/gcs-manager-openapi.json"
+
+// handWrittenSchemas maps OpenAPI schema names to the hand-written types
+// that model them.
+var handWrittenSchemas = map[string]interface{}{
+	"AuthPolicy":     AuthPolicy{},
+	"Collection":     Collection{},
+	"Endpoint":       Endpoint{},
+	"Info":           Info{},
+	"Node":           Node{},
+	"OIDCServer":     OIDCServer{},
+	"Role":           Role{},
+	"SharingPolicy":  SharingPolicy{},
+	"StorageGateway": StorageGateway{},
+	"UserCredential": UserCredential{},
+}
+
+func loadOpenAPISpec(t *testing.T) *openapi.Spec {
+	t.Helper()
+
+	if _, err := os.Stat(openAPISpecPath); os.IsNotExist(err) {
+		// CI must check for drift; only local runs may go without the spec
+		if os.Getenv("CI") != "" {
+			t.Fatalf("%s not present; CI must run 'make fetch-openapi' before the tests", openAPISpecPath)
+		}
+		t.Skipf("%s not present; run 'make fetch-openapi' to enable drift checks", openAPISpecPath)
+	}
+
+	spec, err := openapi.Load(openAPISpecPath)
+	if err != nil {
+		t.Fatalf("load OpenAPI spec: %v", err)
+	}
+	return spec
+}
+
+// TestOpenAPIDrift fails when the spec defines fields that the hand-written
+// types do not model.
+func TestOpenAPIDrift(t *testing.T) {
+	spec := loadOpenAPISpec(t)
+
+	for schema, value := range handWrittenSchemas {
+		t.Run(schema, func(t *testing.T) {
+			if _, ok := spec.Components.Schemas[schema]; !ok {
+				t.Skipf("schema %q not in spec", schema)
+			}
+
+			missing, err := spec.MissingFields(schema, reflect.TypeOf(value))
+			if err != nil {
+				t.Fatalf("MissingFields() error = %v", err)
+			}
+			if len(missing) > 0 {
+				t.Errorf("%T is missing fields from the %s schema: %v", value, schema, missing)
+			}
+		})
+	}
+}
+
+// TestGeneratedTypesUpToDate fails when zz_generated_types.go was not
+// regenerated after the spec changed.
+func TestGeneratedTypesUpToDate(t *testing.T) {
+	spec := loadOpenAPISpec(t)
+
+	current, err := os.ReadFile("zz_generated_types.go")
+	if err != nil {
+		t.Fatalf("read generated types: %v (run 'make generate')", err)
+	}
+
+	skip, err := openapi.DeclaredTypes(".", "zz_generated_types.go")
+	if err != nil {
+		t.Fatalf("DeclaredTypes() error = %v", err)
+	}
+
+	want, err := openapi.Generate(spec, openapi.GenerateOptions{
+		Package: "gcs",
+		Skip:    skip,
+		Source:  "../../api/gcs-manager-openapi.json",
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if !bytes.Equal(current, want) {
+		t.Error("zz_generated_types.go is out of date; run 'make generate'")
+	}
+}
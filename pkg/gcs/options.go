@@ -2,6 +2,8 @@ package gcs
 
 import (
 	"crypto/tls"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -16,9 +18,27 @@ type clientOptions struct {
 	httpClient   *http.Client
 	authClient   *globusauth.Client
 	accessToken  string
+	resourceServerTokens map[string]string
+	resourceServer string
+	tokenRefresher TokenRefresher
+	tokenProvider TokenProvider
+	retry        RetryPolicy
+	rateLimiter  *RateLimiter
+	circuitBreaker *CircuitBreaker
+	logger       *slog.Logger
+	timing       TimingFunc
+	cache        *ETagCache
+	dryRun       DryRunFunc
+	tracer       Tracer
+	compressMin  int
 	timeout      time.Duration
 	userAgent    string
+	headers      http.Header
 	tlsConfig    *tls.Config
+	transportWrappers []func(http.RoundTripper) http.RoundTripper
+
+	// err is an option's failure, returned by NewClient.
+	err error
 }
 
 // defaultOptions returns the default client options.
@@ -56,7 +76,140 @@ func WithAccessToken(token string) ClientOption {
 	}
 }
 
-// WithTimeout sets the HTTP request timeout.
+// WithResourceServerTokens supplies access tokens keyed by Globus Auth
+// resource server, as returned for a login that requested scopes of
+// several services. The GCS Manager API of an endpoint is its own resource
+// server, named by the endpoint ID: before its first authenticated request
+// the client looks the ID up with GetInfo and uses the matching token. If
+// there is none, or the lookup fails, the token from WithAccessToken is
+// used.
+func WithResourceServerTokens(tokens map[string]string) ClientOption {
+	return func(opts *clientOptions) {
+		opts.resourceServerTokens = tokens
+	}
+}
+
+// WithResourceServer names the endpoint's resource server, its endpoint
+// ID, when it is already known, for example from the endpoints a login
+// requested scopes for. The token of WithResourceServerTokens for it is
+// used from the first request, without looking the ID up.
+func WithResourceServer(endpointID string) ClientOption {
+	return func(opts *clientOptions) {
+		opts.resourceServer = endpointID
+	}
+}
+
+// WithTokenRefresher sets a function that supplies a new access token when
+// the server rejects the current one with HTTP 401. The failed request is
+// retried once with the new token. Without a refresher, 401 responses are
+// returned as errors.
+func WithTokenRefresher(refresher TokenRefresher) ClientOption {
+	return func(opts *clientOptions) {
+		opts.tokenRefresher = refresher
+	}
+}
+
+// WithTokenProvider makes the client ask provider for the access token of
+// every request, so that a token refreshed during a long operation is
+// picked up. It takes precedence over WithAccessToken and
+// WithResourceServerTokens. A TokenRefresher still handles HTTP 401; it
+// should make provider return the new token.
+func WithTokenProvider(provider TokenProvider) ClientOption {
+	return func(opts *clientOptions) {
+		opts.tokenProvider = provider
+	}
+}
+
+// WithRetry makes the client retry requests that fail with a transient
+// status, waiting with exponential backoff between attempts (see
+// RetryPolicy). Without it, every response is returned as received.
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(opts *clientOptions) {
+		opts.retry = policy
+	}
+}
+
+// WithRateLimit limits the client to perSecond requests per second on
+// average, with bursts of up to burst requests, so that bulk operations
+// stay below the GCS Manager API's throttling. Parallel requests of the
+// client share the limit; use WithRateLimiter to share it between clients.
+func WithRateLimit(perSecond float64, burst int) ClientOption {
+	return WithRateLimiter(NewRateLimiter(perSecond, burst))
+}
+
+// WithRateLimiter makes the client wait for limiter before each request,
+// including retries.
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(opts *clientOptions) {
+		opts.rateLimiter = limiter
+	}
+}
+
+// WithLogger logs each request to logger: at slog.LevelDebug, a line with
+// the method, path, status, duration, and request ID of every attempt; at
+// LevelTrace, also the headers and bodies. Tokens and other secrets are
+// redacted.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(opts *clientOptions) {
+		opts.logger = logger
+	}
+}
+
+// WithTiming calls fn with the DNS, connect, TLS, and time-to-first-byte
+// breakdown of each request, to find where slow requests spend their
+// time.
+func WithTiming(fn TimingFunc) ClientOption {
+	return func(opts *clientOptions) {
+		opts.timing = fn
+	}
+}
+
+// WithETagCache makes the client send conditional requests with the
+// entity tags remembered in cache: GETs are revalidated, and PATCHes fail
+// with HTTP 412 if the resource changed since the client last fetched it.
+func WithETagCache(cache *ETagCache) ClientOption {
+	return func(opts *clientOptions) {
+		opts.cache = cache
+	}
+}
+
+// WithCircuitBreaker makes the client fail fast with ErrCircuitOpen while
+// breaker is open, instead of sending requests to an endpoint that keeps
+// failing. Retries count as separate requests.
+func WithCircuitBreaker(breaker *CircuitBreaker) ClientOption {
+	return func(opts *clientOptions) {
+		opts.circuitBreaker = breaker
+	}
+}
+
+// WithDryRun makes the client send only requests that change nothing.
+// Each other request is passed to fn instead of being sent, and its method
+// returns an error wrapping ErrDryRun; input validation still runs first.
+func WithDryRun(fn DryRunFunc) ClientOption {
+	return func(opts *clientOptions) {
+		opts.dryRun = fn
+	}
+}
+
+// WithRequestCompression compresses request bodies of at least minSize
+// bytes with gzip. Only enable it for endpoints that accept compressed
+// requests; responses are decompressed regardless.
+func WithRequestCompression(minSize int) ClientOption {
+	return func(opts *clientOptions) {
+		opts.compressMin = minSize
+	}
+}
+
+// WithTracer records a span with tracer for each API call, with the
+// method, URL, status, and GCS request ID as attributes.
+func WithTracer(tracer Tracer) ClientOption {
+	return func(opts *clientOptions) {
+		opts.tracer = tracer
+	}
+}
+
+// WithTimeout sets the timeout of each HTTP request, including reading
+// the response. Override it for single requests with WithRequestTimeout.
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(opts *clientOptions) {
 		opts.timeout = timeout
@@ -73,6 +226,29 @@ func WithUserAgent(userAgent string) ClientOption {
 	}
 }
 
+// WithHeader adds a header sent with every request, for example a change
+// ticket ID that should appear in the GCS server logs. It may be given
+// more than once.
+func WithHeader(key, value string) ClientOption {
+	return func(opts *clientOptions) {
+		if opts.headers == nil {
+			opts.headers = http.Header{}
+		}
+		opts.headers.Add(key, value)
+	}
+}
+
+// WithTransportWrapper wraps the HTTP transport of the client, for
+// example to log, record, or inject faults into requests. Wrappers are
+// applied after all other options, in the order given, so the last one
+// sees each request first. The HTTP client passed to WithHTTPClient is
+// copied, not modified.
+func WithTransportWrapper(wrap func(http.RoundTripper) http.RoundTripper) ClientOption {
+	return func(opts *clientOptions) {
+		opts.transportWrappers = append(opts.transportWrappers, wrap)
+	}
+}
+
 // WithTLSConfig sets a custom TLS configuration.
 //
 // Use this to customize TLS settings beyond the secure defaults.
@@ -96,6 +272,7 @@ func WithTLSConfig(config *tls.Config) ClientOption {
 				// Create new transport with TLS config
 				opts.httpClient.Transport = &http.Transport{
 					TLSClientConfig:     config,
+					Proxy:               http.ProxyFromEnvironment,
 					MaxIdleConns:        100,
 					MaxIdleConnsPerHost: 10,
 					IdleConnTimeout:     90 * time.Second,
@@ -147,3 +324,55 @@ func WithMinTLSVersion(version uint16) ClientOption {
 		}
 	}
 }
+
+// WithClientCertificate presents the certificate in certFile, with the
+// private key in keyFile, to servers that request one, such as reverse
+// proxies in front of an endpoint that require mutual TLS. Both files are
+// PEM-encoded and may be the same file. NewClient fails if they cannot be
+// loaded.
+func WithClientCertificate(certFile, keyFile string) ClientOption {
+	return func(opts *clientOptions) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			opts.err = fmt.Errorf("load client certificate: %w", err)
+			return
+		}
+
+		if opts.tlsConfig == nil {
+			opts.tlsConfig = SecureTLSConfig()
+		}
+		opts.tlsConfig.Certificates = []tls.Certificate{cert}
+
+		// Update HTTP client transport
+		if opts.httpClient != nil {
+			if transport, ok := opts.httpClient.Transport.(*http.Transport); ok {
+				transport.TLSClientConfig = opts.tlsConfig
+			}
+		}
+	}
+}
+
+// WithProxy sends requests through the proxy at proxyURL instead of the
+// one from the HTTPS_PROXY environment variable. HTTP, HTTPS, and SOCKS5
+// proxies are supported (see ParseProxyURL); hosts listed in NO_PROXY are
+// still reached directly. NewClient fails if proxyURL is invalid or the
+// client's transport, set with WithHTTPClient, is not an *http.Transport.
+func WithProxy(proxyURL string) ClientOption {
+	return func(opts *clientOptions) {
+		u, err := ParseProxyURL(proxyURL)
+		if err != nil {
+			opts.err = err
+			return
+		}
+
+		var transport *http.Transport
+		if opts.httpClient != nil {
+			transport, _ = opts.httpClient.Transport.(*http.Transport)
+		}
+		if transport == nil {
+			opts.err = fmt.Errorf("proxy requires an HTTP client with an *http.Transport")
+			return
+		}
+		transport.Proxy = proxyFunc(u)
+	}
+}
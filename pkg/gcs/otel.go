@@ -0,0 +1,64 @@
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the spans recorded by
+// WithTracerProvider.
+const tracerName = "github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+
+// WithTracerProvider records a client span from provider for each API
+// call, like WithTracer, and propagates the trace to the endpoint with
+// the global OpenTelemetry propagator.
+func WithTracerProvider(provider trace.TracerProvider) ClientOption {
+	return WithTracer(otelTracer{provider.Tracer(tracerName)})
+}
+
+// otelTracer adapts an OpenTelemetry tracer to Tracer.
+type otelTracer struct {
+	tracer trace.Tracer
+}
+
+func (t otelTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, otelSpan{ctx: ctx, span: span}
+}
+
+// otelSpan adapts an OpenTelemetry span to Span and HeaderInjector.
+type otelSpan struct {
+	ctx  context.Context
+	span trace.Span
+}
+
+func (s otelSpan) SetAttribute(key string, value any) {
+	switch v := value.(type) {
+	case string:
+		s.span.SetAttributes(attribute.String(key, v))
+	case int:
+		s.span.SetAttributes(attribute.Int(key, v))
+	default:
+		s.span.SetAttributes(attribute.String(key, fmt.Sprint(v)))
+	}
+}
+
+func (s otelSpan) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s otelSpan) End() {
+	s.span.End()
+}
+
+func (s otelSpan) Inject(header http.Header) {
+	otel.GetTextMapPropagator().Inject(s.ctx, propagation.HeaderCarrier(header))
+}
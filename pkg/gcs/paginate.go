@@ -0,0 +1,60 @@
+package gcs
+
+import (
+	"context"
+	"iter"
+)
+
+// PageFunc fetches the page of a list starting at marker ("" for the first
+// page). It returns the page's items, the marker of the next page, and
+// whether there is a next page.
+type PageFunc[T any] func(ctx context.Context, marker string) (items []T, next string, hasNext bool, err error)
+
+// Paginate returns an iterator over the items of every page of fetch,
+// following pagination markers. Pages are fetched as the iteration needs
+// them, so breaking out of the loop early saves the remaining requests.
+//
+// An error ends the iteration; it is yielded with the zero T. A server
+// that returns the marker it was given is treated as being on its last
+// page rather than looping forever.
+func Paginate[T any](ctx context.Context, fetch PageFunc[T]) iter.Seq2[T, error] {
+	return PaginateFrom(ctx, "", fetch)
+}
+
+// PaginateFrom is Paginate starting at the page of marker, such as the
+// marker of a page fetched earlier.
+func PaginateFrom[T any](ctx context.Context, marker string, fetch PageFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		marker := marker
+		for {
+			items, next, hasNext, err := fetch(ctx, marker)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !hasNext || next == "" || next == marker {
+				return
+			}
+			marker = next
+		}
+	}
+}
+
+// CollectAll returns the items of seq, or the first error. The result is
+// never nil on success, so that an empty list encodes as [] in JSON.
+func CollectAll[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	items := []T{}
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,158 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	pages := map[string]struct {
+		items []int
+		next  string
+	}{
+		"":   {[]int{1, 2}, "m1"},
+		"m1": {[]int{3}, "m2"},
+		"m2": {[]int{4, 5}, ""},
+	}
+	var fetched []string
+	fetch := func(_ context.Context, marker string) ([]int, string, bool, error) {
+		fetched = append(fetched, marker)
+		page := pages[marker]
+		return page.items, page.next, page.next != "", nil
+	}
+
+	items, err := CollectAll(Paginate(context.Background(), fetch))
+	if err != nil {
+		t.Fatalf("CollectAll() error = %v", err)
+	}
+	if len(items) != 5 || items[0] != 1 || items[4] != 5 {
+		t.Errorf("items = %v, want 1..5", items)
+	}
+
+	// Breaking out early fetches no further pages
+	fetched = nil
+	for item := range Paginate(context.Background(), fetch) {
+		if item == 2 {
+			break
+		}
+	}
+	if len(fetched) != 1 {
+		t.Errorf("pages fetched after break = %q, want only the first", fetched)
+	}
+}
+
+func TestPaginate_Error(t *testing.T) {
+	fetch := func(_ context.Context, marker string) ([]int, string, bool, error) {
+		if marker == "" {
+			return []int{1}, "m1", true, nil
+		}
+		return nil, "", false, errors.New("boom")
+	}
+
+	if _, err := CollectAll(Paginate(context.Background(), fetch)); err == nil || err.Error() != "boom" {
+		t.Errorf("CollectAll() error = %v, want boom", err)
+	}
+}
+
+func TestPaginate_RepeatedMarker(t *testing.T) {
+	calls := 0
+	fetch := func(_ context.Context, _ string) ([]int, string, bool, error) {
+		calls++
+		return []int{calls}, "same", true, nil
+	}
+
+	items, err := CollectAll(Paginate(context.Background(), fetch))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 || len(items) != 2 {
+		t.Errorf("calls = %d, items = %v; want 2 pages before the repeated marker stops it", calls, items)
+	}
+}
+
+func TestPaginateFrom(t *testing.T) {
+	var fetched []string
+	fetch := func(_ context.Context, marker string) ([]int, string, bool, error) {
+		fetched = append(fetched, marker)
+		if marker == "m1" {
+			return []int{3}, "m2", true, nil
+		}
+		return []int{4}, "", false, nil
+	}
+
+	items, err := CollectAll(PaginateFrom(context.Background(), "m1", fetch))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0] != 3 || items[1] != 4 {
+		t.Errorf("items = %v, want 3, 4", items)
+	}
+	if len(fetched) != 2 || fetched[0] != "m1" {
+		t.Errorf("markers fetched = %q, want m1 then m2", fetched)
+	}
+}
+
+func TestClient_CollectionsFromMarker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page_size") != "1" {
+			t.Errorf("query = %q, want page_size=1 on every page", r.URL.RawQuery)
+		}
+		switch r.URL.Query().Get("marker") {
+		case "m1":
+			_, _ = w.Write([]byte(`{"data": [{"id": "c2"}], "has_next_page": true, "marker": "m2"}`))
+		case "m2":
+			_, _ = w.Write([]byte(`{"data": [{"id": "c3"}], "has_next_page": false}`))
+		default:
+			t.Errorf("unexpected marker in %q", r.URL.RawQuery)
+			_, _ = w.Write([]byte(`{"data": []}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient("example.org", WithHTTPClient(&http.Client{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.baseURL = server.URL + "/api/"
+
+	collections, err := client.ListAllCollections(context.Background(), &ListCollectionsOptions{PageSize: 1, Marker: "m1"})
+	if err != nil {
+		t.Fatalf("ListAllCollections() error = %v", err)
+	}
+	if len(collections) != 2 || collections[0].ID != "c2" || collections[1].ID != "c3" {
+		t.Errorf("collections = %+v, want c2, c3", collections)
+	}
+}
+
+func TestClient_ListAllRoles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("collection") != "col-1" {
+			t.Errorf("query = %q, want the collection filter on every page", r.URL.RawQuery)
+		}
+		if r.URL.Query().Get("marker") == "" {
+			_, _ = w.Write([]byte(`{"data": [{"id": "r1"}], "has_next_page": true, "marker": "next"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": [{"id": "r2"}], "has_next_page": false}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("example.org", WithHTTPClient(&http.Client{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.baseURL = server.URL + "/api/"
+
+	roles, err := client.ListAllRoles(context.Background(), &ListRolesOptions{Collection: "col-1", Marker: "ignored"})
+	if err != nil {
+		t.Fatalf("ListAllRoles() error = %v", err)
+	}
+	if len(roles) != 2 || roles[0].ID != "r1" || roles[1].ID != "r2" {
+		t.Errorf("roles = %+v, want r1, r2", roles)
+	}
+}
@@ -0,0 +1,149 @@
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrNotModified is returned by conditional fetches when the resource has
+// not changed since the validators were recorded.
+var ErrNotModified = errors.New("not modified")
+
+// maxPollBackoff caps the delay between polls after repeated errors.
+const maxPollBackoff = 5 * time.Minute
+
+// Validators holds the HTTP cache validators of the last response seen
+// for a resource, used to make conditional GET requests.
+type Validators struct {
+	// ETag is the entity tag from the last response.
+	ETag string
+
+	// LastModified is the Last-Modified header from the last response.
+	LastModified string
+}
+
+// IsZero reports whether no validators have been recorded.
+func (v *Validators) IsZero() bool {
+	return v == nil || (v.ETag == "" && v.LastModified == "")
+}
+
+// GetConditional performs a conditional GET of path and decodes the
+// response into target.
+//
+// If v holds validators from a previous response, they are sent as
+// If-None-Match and If-Modified-Since; a 304 response returns
+// ErrNotModified and leaves target untouched. On a full response, v is
+// updated with the new validators.
+func (c *Client) GetConditional(ctx context.Context, path string, v *Validators, target interface{}) error {
+	header := http.Header{}
+	if v != nil {
+		if v.ETag != "" {
+			header.Set("If-None-Match", v.ETag)
+		}
+		if v.LastModified != "" {
+			header.Set("If-Modified-Since", v.LastModified)
+		}
+	}
+
+	resp, err := c.doRequestWithHeaders(ctx, http.MethodGet, path, nil, header)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		_ = resp.Body.Close()
+		return ErrNotModified
+	}
+
+	if v != nil {
+		v.ETag = resp.Header.Get("ETag")
+		v.LastModified = resp.Header.Get("Last-Modified")
+	}
+
+	return c.decodeResponse(resp, target)
+}
+
+// FetchFunc fetches the current value of a polled resource.
+//
+// Implementations should pass v to GetConditional (or otherwise honor it)
+// and return ErrNotModified when the resource is unchanged.
+type FetchFunc[T any] func(ctx context.Context, v *Validators) (T, error)
+
+// Poll calls fetch every interval until ctx is done, calling onChange with
+// each new value.
+//
+// Poll is the shared engine behind watch, tail, and progress-monitoring
+// features. Conditional requests keep unchanged polls cheap, and a full
+// response is only reported when its JSON encoding differs from the last
+// value seen, so servers without ETag or Last-Modified support work too.
+// The first successful fetch is always reported.
+//
+// Fetch errors do not stop polling: the delay doubles after each
+// consecutive error (up to five minutes) and resets after a success. Poll
+// returns ctx.Err() when the context ends, or the error returned by
+// onChange.
+func Poll[T any](ctx context.Context, fetch FetchFunc[T], interval time.Duration, onChange func(T) error) error {
+	if interval <= 0 {
+		return fmt.Errorf("poll interval must be positive")
+	}
+
+	var (
+		validators Validators
+		last       []byte
+		seen       bool
+		delay      = interval
+	)
+
+	for {
+		value, err := fetch(ctx, &validators)
+		switch {
+		case errors.Is(err, ErrNotModified):
+			delay = interval
+		case err != nil:
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			delay = nextBackoff(delay, interval)
+		default:
+			delay = interval
+
+			// Servers that ignore the validators answer every poll with a
+			// full response, so compare the values as well.
+			encoded, encErr := json.Marshal(value)
+			changed := !seen || encErr != nil || !bytes.Equal(encoded, last)
+			last = encoded
+			seen = true
+
+			if changed {
+				if err := onChange(value); err != nil {
+					return err
+				}
+			}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// nextBackoff doubles delay after an error, bounded by maxPollBackoff.
+func nextBackoff(delay, interval time.Duration) time.Duration {
+	if delay < interval {
+		delay = interval
+	}
+	delay *= 2
+	if delay > maxPollBackoff {
+		delay = maxPollBackoff
+	}
+	return delay
+}
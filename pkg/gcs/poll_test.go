@@ -0,0 +1,121 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetConditional(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		_, _ = w.Write([]byte(`{"id":"ep-1","display_name":"Test"}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:    server.URL + "/",
+		httpClient: &http.Client{},
+		userAgent:  "test-agent",
+	}
+
+	ctx := context.Background()
+	var v Validators
+
+	var endpoint Endpoint
+	if err := client.GetConditional(ctx, "endpoint", &v, &endpoint); err != nil {
+		t.Fatalf("GetConditional() error = %v", err)
+	}
+	if endpoint.ID != "ep-1" {
+		t.Errorf("ID = %q, want %q", endpoint.ID, "ep-1")
+	}
+	if v.ETag != `"v1"` || v.LastModified == "" {
+		t.Errorf("Validators = %+v, want ETag and Last-Modified recorded", v)
+	}
+
+	err := client.GetConditional(ctx, "endpoint", &v, &endpoint)
+	if !errors.Is(err, ErrNotModified) {
+		t.Errorf("GetConditional() error = %v, want ErrNotModified", err)
+	}
+}
+
+func TestPoll(t *testing.T) {
+	type result struct {
+		value string
+		err   error
+	}
+	script := []result{
+		{value: "a"},
+		{err: ErrNotModified},
+		{err: errors.New("temporary failure")},
+		{value: "a"},
+		{value: "b"},
+	}
+	var calls int32
+
+	fetch := func(_ context.Context, _ *Validators) (string, error) {
+		n := int(atomic.AddInt32(&calls, 1)) - 1
+		if n >= len(script) {
+			n = len(script) - 1
+		}
+		return script[n].value, script[n].err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var changes []string
+	err := Poll(ctx, fetch, time.Millisecond, func(v string) error {
+		changes = append(changes, v)
+		if v == "b" {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Poll() error = %v, want context.Canceled", err)
+	}
+
+	want := []string{"a", "b"}
+	if len(changes) != len(want) || changes[0] != want[0] || changes[1] != want[1] {
+		t.Errorf("changes = %v, want %v", changes, want)
+	}
+}
+
+func TestPoll_OnChangeError(t *testing.T) {
+	stop := errors.New("stop")
+	fetch := func(_ context.Context, _ *Validators) (int, error) { return 1, nil }
+
+	err := Poll(context.Background(), fetch, time.Millisecond, func(int) error { return stop })
+	if !errors.Is(err, stop) {
+		t.Errorf("Poll() error = %v, want %v", err, stop)
+	}
+}
+
+func TestPoll_InvalidInterval(t *testing.T) {
+	fetch := func(_ context.Context, _ *Validators) (int, error) { return 1, nil }
+
+	if err := Poll(context.Background(), fetch, 0, func(int) error { return nil }); err == nil {
+		t.Error("Poll() expected error for zero interval, got nil")
+	}
+}
+
+func TestNextBackoff(t *testing.T) {
+	interval := time.Second
+
+	if got := nextBackoff(interval, interval); got != 2*time.Second {
+		t.Errorf("nextBackoff() = %v, want %v", got, 2*time.Second)
+	}
+	if got := nextBackoff(4*time.Minute, interval); got != maxPollBackoff {
+		t.Errorf("nextBackoff() = %v, want %v", got, maxPollBackoff)
+	}
+}
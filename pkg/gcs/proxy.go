@@ -0,0 +1,91 @@
+package gcs
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+)
+
+// proxySchemes are the proxy URL schemes the HTTP transport supports.
+var proxySchemes = []string{"http", "https", "socks5", "socks5h"}
+
+// ParseProxyURL parses a proxy URL such as http://proxy.example.org:3128
+// or socks5://127.0.0.1:1080. A URL without a scheme is an HTTP proxy.
+func ParseProxyURL(rawURL string) (*url.URL, error) {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "http://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	if !slices.Contains(proxySchemes, u.Scheme) {
+		return nil, fmt.Errorf("invalid proxy URL %q: scheme must be one of %s", rawURL, strings.Join(proxySchemes, ", "))
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: no host", rawURL)
+	}
+	return u, nil
+}
+
+// proxyFunc returns a Transport.Proxy function that sends requests
+// through proxyURL, except to hosts matched by the NO_PROXY environment
+// variable.
+func proxyFunc(proxyURL *url.URL) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		noProxy := os.Getenv("NO_PROXY")
+		if noProxy == "" {
+			noProxy = os.Getenv("no_proxy")
+		}
+		if bypassProxy(req.URL, noProxy) {
+			return nil, nil
+		}
+		return proxyURL, nil
+	}
+}
+
+// bypassProxy reports whether u is matched by noProxy, a comma-separated
+// list in the NO_PROXY format: "*", host names (matching subdomains too,
+// with or without a leading dot), IP addresses, and CIDR ranges, each
+// optionally with a port.
+func bypassProxy(u *url.URL, noProxy string) bool {
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	if port == "" {
+		port = "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+	}
+	ip := net.ParseIP(host)
+
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return true
+		}
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if h, p, err := net.SplitHostPort(entry); err == nil {
+			if p != port {
+				continue
+			}
+			entry = h
+		}
+		entry = strings.TrimPrefix(entry, "*")
+		if host == strings.TrimPrefix(entry, ".") || strings.HasSuffix(host, "."+strings.TrimPrefix(entry, ".")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Burst(t *testing.T) {
+	limiter := NewRateLimiter(20, 3)
+
+	start := time.Now()
+	for range 3 {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("burst of 3 took %s, want no wait", elapsed)
+	}
+
+	// The next two must wait for tokens, 50ms each
+	for range 2 {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("5 requests at 20/s with burst 3 took %s, want at least 100ms", elapsed)
+	}
+}
+
+func TestRateLimiter_Concurrent(t *testing.T) {
+	limiter := NewRateLimiter(100, 1)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = limiter.Wait(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	// One request at once, then nine more 10ms apart
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("10 parallel requests at 100/s took %s, want at least 90ms", elapsed)
+	}
+}
+
+func TestRateLimiter_Canceled(t *testing.T) {
+	limiter := NewRateLimiter(0.1, 1)
+	_ = limiter.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestClient_WithRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("example.org", WithHTTPClient(&http.Client{}), WithRateLimit(50, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.baseURL = server.URL + "/api/"
+
+	start := time.Now()
+	for range 3 {
+		resp, err := client.doRequest(context.Background(), http.MethodGet, "endpoint", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("3 requests at 50/s took %s, want at least 40ms", elapsed)
+	}
+}
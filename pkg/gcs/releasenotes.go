@@ -0,0 +1,230 @@
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Release note sections, in display order.
+const (
+	ReleaseNoteBreaking = "breaking_changes"
+	ReleaseNoteSecurity = "security_fixes"
+	ReleaseNoteFeatures = "features"
+	ReleaseNoteFixes    = "bug_fixes"
+	ReleaseNoteOther    = "other"
+)
+
+// ReleaseNoteSections lists the release note sections in display order.
+var ReleaseNoteSections = []string{
+	ReleaseNoteBreaking,
+	ReleaseNoteSecurity,
+	ReleaseNoteFeatures,
+	ReleaseNoteFixes,
+	ReleaseNoteOther,
+}
+
+// Release is the notes for one GCS version.
+type Release struct {
+	Version         string   `json:"version"`
+	Date            string   `json:"date,omitempty"`
+	BreakingChanges []string `json:"breaking_changes,omitempty"`
+	SecurityFixes   []string `json:"security_fixes,omitempty"`
+	Features        []string `json:"features,omitempty"`
+	BugFixes        []string `json:"bug_fixes,omitempty"`
+	Other           []string `json:"other,omitempty"`
+}
+
+// Section returns the items of the named section (see ReleaseNoteSections).
+func (r *Release) Section(name string) []string {
+	switch name {
+	case ReleaseNoteBreaking:
+		return r.BreakingChanges
+	case ReleaseNoteSecurity:
+		return r.SecurityFixes
+	case ReleaseNoteFeatures:
+		return r.Features
+	case ReleaseNoteFixes:
+		return r.BugFixes
+	default:
+		return r.Other
+	}
+}
+
+// add appends an item to the named section.
+func (r *Release) add(name, item string) {
+	switch name {
+	case ReleaseNoteBreaking:
+		r.BreakingChanges = append(r.BreakingChanges, item)
+	case ReleaseNoteSecurity:
+		r.SecurityFixes = append(r.SecurityFixes, item)
+	case ReleaseNoteFeatures:
+		r.Features = append(r.Features, item)
+	case ReleaseNoteFixes:
+		r.BugFixes = append(r.BugFixes, item)
+	default:
+		r.Other = append(r.Other, item)
+	}
+}
+
+// ReleaseNotes are the structured notes for the releases after
+// FromVersion up to and including ToVersion, newest first.
+type ReleaseNotes struct {
+	FromVersion string    `json:"from_version"`
+	ToVersion   string    `json:"to_version"`
+	Releases    []Release `json:"releases"`
+}
+
+// GetReleaseNotes retrieves the release notes between two versions.
+//
+// Endpoints that do not serve structured release notes have the free-form
+// notes from CheckEndpointUpgrade parsed into sections instead (see
+// ParseReleaseNotes).
+func (c *Client) GetReleaseNotes(ctx context.Context, fromVersion, toVersion string) (*ReleaseNotes, error) {
+	query := url.Values{}
+	query.Set("from", fromVersion)
+	query.Set("to", toVersion)
+
+	var notes ReleaseNotes
+	err := c.GetConditional(ctx, "endpoint/upgrade/release_notes?"+query.Encode(), nil, &notes)
+	switch {
+	case err == nil:
+	case isHTTPStatus(err, 404):
+		info, err := c.CheckEndpointUpgrade(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get release notes: %w", err)
+		}
+		notes.Releases = ParseReleaseNotes(info.ReleaseNotes, toVersion)
+	default:
+		return nil, fmt.Errorf("get release notes: %w", err)
+	}
+
+	if notes.FromVersion == "" {
+		notes.FromVersion = fromVersion
+	}
+	if notes.ToVersion == "" {
+		notes.ToVersion = toVersion
+	}
+	if notes.Releases == nil {
+		notes.Releases = []Release{}
+	}
+	return &notes, nil
+}
+
+var (
+	// releaseHeading matches a version heading such as "## 5.4.70" or
+	// "Version 5.4.70 (2024-03-01)".
+	releaseHeading = regexp.MustCompile(`^(?:#+\s*)?(?:[Vv]ersion\s+|v)?(\d+\.\d+(?:\.\d+)*)\s*(?:[-–(]\s*(\d{4}-\d{2}-\d{2})\)?)?\s*:?$`)
+
+	// listItem matches a bullet or numbered list item.
+	listItem = regexp.MustCompile(`^(?:[-*•]|\d+[.)])\s+`)
+)
+
+// sectionHeadings maps lower-case heading keywords to sections. The first
+// matching keyword wins.
+var sectionHeadings = []struct {
+	keyword string
+	section string
+}{
+	{"breaking", ReleaseNoteBreaking},
+	{"incompatib", ReleaseNoteBreaking},
+	{"security", ReleaseNoteSecurity},
+	{"cve", ReleaseNoteSecurity},
+	{"feature", ReleaseNoteFeatures},
+	{"enhancement", ReleaseNoteFeatures},
+	{"new", ReleaseNoteFeatures},
+	{"fix", ReleaseNoteFixes},
+	{"bug", ReleaseNoteFixes},
+}
+
+// ParseReleaseNotes parses free-form release notes into releases.
+//
+// Version headings ("## 5.4.70", "Version 5.4.70") start a release and
+// headings such as "Breaking Changes" or "Security Fixes" start a
+// section; list items are added to the current section. Text before any
+// version heading belongs to defaultVersion. Items outside a recognized
+// section are classified by keywords (e.g. a "CVE-" reference is a
+// security fix), falling back to ReleaseNoteOther.
+func ParseReleaseNotes(text, defaultVersion string) []Release {
+	var releases []Release
+	current := -1
+	section := ""
+
+	ensure := func() {
+		if current < 0 {
+			releases = append(releases, Release{Version: defaultVersion})
+			current = 0
+		}
+	}
+
+	for _, raw := range strings.Split(text, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+
+		if m := releaseHeading.FindStringSubmatch(line); m != nil && !listItem.MatchString(line) {
+			releases = append(releases, Release{Version: m[1], Date: m[2]})
+			current = len(releases) - 1
+			section = ""
+			continue
+		}
+
+		if item := listItem.ReplaceAllString(line, ""); item != line {
+			ensure()
+			name := section
+			if name == "" {
+				name = classifyItem(item)
+			}
+			releases[current].add(name, item)
+			continue
+		}
+
+		heading := strings.TrimRight(strings.TrimLeft(line, "# "), ":")
+		if name := headingSection(heading); name != "" && (strings.HasPrefix(line, "#") || strings.HasSuffix(line, ":") || len(strings.Fields(heading)) <= 2) {
+			section = name
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			// An unrecognized heading ends the current section.
+			section = ""
+			continue
+		}
+
+		// A plain sentence is an item of its own.
+		ensure()
+		name := section
+		if name == "" {
+			name = classifyItem(line)
+		}
+		releases[current].add(name, line)
+	}
+
+	return releases
+}
+
+// headingSection returns the section a heading names, or "".
+func headingSection(heading string) string {
+	lower := strings.ToLower(heading)
+	for _, h := range sectionHeadings {
+		if strings.Contains(lower, h.keyword) {
+			return h.section
+		}
+	}
+	return ""
+}
+
+// classifyItem guesses the section of an item outside any section.
+func classifyItem(item string) string {
+	lower := strings.ToLower(item)
+	switch {
+	case strings.Contains(lower, "breaking") || strings.Contains(lower, "no longer supported") || strings.Contains(lower, "removed"):
+		return ReleaseNoteBreaking
+	case strings.Contains(lower, "cve-") || strings.Contains(lower, "security") || strings.Contains(lower, "vulnerab"):
+		return ReleaseNoteSecurity
+	default:
+		return ReleaseNoteOther
+	}
+}
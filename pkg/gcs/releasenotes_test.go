@@ -0,0 +1,90 @@
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseReleaseNotes(t *testing.T) {
+	text := `## 5.4.70 (2024-03-01)
+### Security Fixes
+- Update OpenSSL for CVE-2024-0001
+### Bug Fixes
+- Fix collection listing with long names
+
+Version 5.4.65
+Breaking Changes:
+* RHEL 7 is no longer supported
+New Features:
+1. Add HTTPS range requests
+Improved startup time.
+`
+
+	got := ParseReleaseNotes(text, "5.4.70")
+	want := []Release{
+		{
+			Version:       "5.4.70",
+			Date:          "2024-03-01",
+			SecurityFixes: []string{"Update OpenSSL for CVE-2024-0001"},
+			BugFixes:      []string{"Fix collection listing with long names"},
+		},
+		{
+			Version:         "5.4.65",
+			BreakingChanges: []string{"RHEL 7 is no longer supported"},
+			Features:        []string{"Add HTTPS range requests", "Improved startup time."},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseReleaseNotes() =\n  %+v\nwant\n  %+v", got, want)
+	}
+}
+
+func TestParseReleaseNotes_Unstructured(t *testing.T) {
+	got := ParseReleaseNotes("- Fixes CVE-2024-0002\n- Minor cleanups", "5.4.71")
+	want := []Release{{
+		Version:       "5.4.71",
+		SecurityFixes: []string{"Fixes CVE-2024-0002"},
+		Other:         []string{"Minor cleanups"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseReleaseNotes() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGetReleaseNotes_Fallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/endpoint/upgrade/release_notes":
+			if r.URL.Query().Get("from") != "5.4.60" || r.URL.Query().Get("to") != "5.4.70" {
+				t.Errorf("query = %q, want from and to versions", r.URL.RawQuery)
+			}
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/endpoint/upgrade/check":
+			_ = json.NewEncoder(w).Encode(UpgradeInfo{ReleaseNotes: "Security:\n- Patch CVE-2024-0003"})
+		default:
+			t.Errorf("unexpected request path %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:    server.URL + "/api/",
+		httpClient: &http.Client{},
+		userAgent:  "test-agent",
+	}
+
+	notes, err := client.GetReleaseNotes(context.Background(), "5.4.60", "5.4.70")
+	if err != nil {
+		t.Fatalf("GetReleaseNotes() error = %v", err)
+	}
+	if notes.FromVersion != "5.4.60" || notes.ToVersion != "5.4.70" {
+		t.Errorf("versions = %s -> %s, want 5.4.60 -> 5.4.70", notes.FromVersion, notes.ToVersion)
+	}
+	if len(notes.Releases) != 1 || len(notes.Releases[0].SecurityFixes) != 1 {
+		t.Errorf("Releases = %+v, want one release with a security fix", notes.Releases)
+	}
+}
@@ -0,0 +1,152 @@
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how a Client retries requests that failed with a
+// transient status, such as throttling (429) or an overloaded server (503).
+//
+// 429 and 503 mean the server did not process the request, so they are
+// retried for every method. Other statuses, such as 502 and 504, may come
+// from a request that reached the server and are only retried for
+// idempotent methods.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts, including the first. A
+	// value of 1 or less disables retries.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry; it doubles with each
+	// further retry, up to MaxDelay. Delays are jittered between half and
+	// all of their value so that parallel clients spread out.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay before a retry, including one requested by
+	// the server with Retry-After.
+	MaxDelay time.Duration
+
+	// StatusCodes are the HTTP statuses to retry.
+	StatusCodes []int
+}
+
+// DefaultRetryPolicy returns the retry policy used by the CLI: four
+// attempts, starting with a 500ms delay, for 429, 502, 503, and 504.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		StatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// enabled reports whether p retries at all.
+func (p RetryPolicy) enabled() bool {
+	return p.MaxAttempts > 1 && len(p.StatusCodes) > 0
+}
+
+// shouldRetry reports whether a response with status to the attempt-th
+// request with method is retried.
+func (p RetryPolicy) shouldRetry(method string, status, attempt int) bool {
+	if attempt >= p.MaxAttempts || !slices.Contains(p.StatusCodes, status) {
+		return false
+	}
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// delay returns how long to wait after the attempt-th request failed,
+// honoring retryAfter, the response's Retry-After header, if set.
+func (p RetryPolicy) delay(attempt int, retryAfter string) time.Duration {
+	if d, ok := parseRetryAfter(retryAfter); ok {
+		return min(d, p.MaxDelay)
+	}
+
+	d := p.BaseDelay
+	for i := 1; i < attempt && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	d = min(d, p.MaxDelay)
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1) //nolint:gosec // Jitter needs no cryptographic randomness
+}
+
+// parseRetryAfter parses a Retry-After header, in seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		return max(time.Until(when), 0), true
+	}
+	return 0, false
+}
+
+// noRetryKey marks a context whose requests are not retried.
+type noRetryKey struct{}
+
+// withoutRetry returns a context whose requests are sent once, for callers
+// that handle transient failures themselves.
+func withoutRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
+// sendWithRetry sends a request, retrying transient failures according
+// to the client's retry policy. Every attempt waits for the client's rate
+// limiter. The body is replayed from payload, which holds it for retries.
+func (c *Client) sendWithRetry(ctx context.Context, method, url string, body io.Reader, payload []byte, header http.Header, token string) (*http.Response, error) {
+	retry := c.retry.enabled() && ctx.Value(noRetryKey{}) == nil
+	for attempt := 1; ; attempt++ {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		if err := c.breaker.allow(); err != nil {
+			return nil, err
+		}
+		resp, err := c.send(ctx, method, url, body, header, token)
+		c.breaker.record(ctx, resp, err)
+		if err != nil || !retry || !c.retry.shouldRetry(method, resp.StatusCode, attempt) {
+			return resp, err
+		}
+
+		delay := c.retry.delay(attempt, resp.Header.Get("Retry-After"))
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if payload != nil {
+			body = bytes.NewReader(payload)
+		}
+	}
+}
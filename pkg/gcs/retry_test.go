@@ -0,0 +1,145 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testRetryPolicy retries quickly.
+func testRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 10 * time.Millisecond
+	return policy
+}
+
+// newRetryTestClient returns a client for a server answering with the
+// given statuses in turn (200 once they run out), and a pointer to the
+// request bodies it received.
+func newRetryTestClient(t *testing.T, statuses ...int) (*Client, *[]string) {
+	t.Helper()
+
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+
+		if len(bodies) <= len(statuses) {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(statuses[len(bodies)-1])
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient("example.org", WithHTTPClient(&http.Client{}), WithRetry(testRetryPolicy()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.baseURL = server.URL + "/api/"
+	return client, &bodies
+}
+
+func TestClient_RetriesTransientStatus(t *testing.T) {
+	client, bodies := newRetryTestClient(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+
+	resp, err := client.doRequest(context.Background(), http.MethodPost, "roles", strings.NewReader(`{"role":"administrator"}`))
+	if err != nil {
+		t.Fatalf("doRequest() error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if len(*bodies) != 3 {
+		t.Fatalf("requests = %d, want 3", len(*bodies))
+	}
+	for i, body := range *bodies {
+		if body != `{"role":"administrator"}` {
+			t.Errorf("request %d body = %q, want the replayed payload", i+1, body)
+		}
+	}
+}
+
+func TestClient_RetryGivesUp(t *testing.T) {
+	client, bodies := newRetryTestClient(t, 503, 503, 503, 503, 503)
+
+	_, err := client.doRequest(context.Background(), http.MethodGet, "endpoint", nil)
+	if err == nil || !strings.Contains(err.Error(), "HTTP 503") {
+		t.Errorf("doRequest() error = %v, want HTTP 503", err)
+	}
+	if len(*bodies) != 4 {
+		t.Errorf("requests = %d, want 4 (MaxAttempts)", len(*bodies))
+	}
+}
+
+func TestClient_RetryOnlyIdempotentGatewayErrors(t *testing.T) {
+	client, bodies := newRetryTestClient(t, http.StatusBadGateway)
+
+	if _, err := client.doRequest(context.Background(), http.MethodPost, "roles", strings.NewReader(`{}`)); err == nil {
+		t.Error("POST after 502: expected error, got nil")
+	}
+	if len(*bodies) != 1 {
+		t.Errorf("POST requests = %d, want 1", len(*bodies))
+	}
+
+	client, bodies = newRetryTestClient(t, http.StatusBadGateway)
+	resp, err := client.doRequest(context.Background(), http.MethodGet, "endpoint", nil)
+	if err != nil {
+		t.Fatalf("GET after 502 error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if len(*bodies) != 2 {
+		t.Errorf("GET requests = %d, want 2", len(*bodies))
+	}
+}
+
+func TestClient_WithoutRetry(t *testing.T) {
+	client, bodies := newRetryTestClient(t, http.StatusTooManyRequests)
+
+	if _, err := client.doRequest(withoutRetry(context.Background()), http.MethodGet, "endpoint", nil); err == nil {
+		t.Error("doRequest() without retry: expected error, got nil")
+	}
+	if len(*bodies) != 1 {
+		t.Errorf("requests = %d, want 1", len(*bodies))
+	}
+}
+
+func TestClient_RetryCanceled(t *testing.T) {
+	client, _ := newRetryTestClient(t, 503, 503, 503)
+	client.retry.BaseDelay, client.retry.MaxDelay = time.Hour, time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.doRequest(ctx, http.MethodGet, "endpoint", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("doRequest() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		got := policy.delay(attempt, "")
+		if got < want/2 || got > want {
+			t.Errorf("delay(%d) = %s, want between %s and %s", attempt, got, want/2, want)
+		}
+	}
+
+	if got := policy.delay(1, "0"); got != 0 {
+		t.Errorf("delay with Retry-After: 0 = %s, want 0", got)
+	}
+	if got := policy.delay(1, "120"); got != time.Second {
+		t.Errorf("delay with Retry-After: 120 = %s, want MaxDelay", got)
+	}
+	date := time.Now().Add(500 * time.Millisecond).UTC().Format(http.TimeFormat)
+	if got := policy.delay(1, date); got > time.Second {
+		t.Errorf("delay with a Retry-After date = %s, want at most 1s", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -62,6 +63,29 @@ func (c *Client) ListRoles(ctx context.Context, opts *ListRolesOptions) (*RoleLi
 	return &list, nil
 }
 
+// Roles returns an iterator over every role matching opts,
+// following pagination markers. opts.Marker is ignored.
+func (c *Client) Roles(ctx context.Context, opts *ListRolesOptions) iter.Seq2[Role, error] {
+	pageOpts := ListRolesOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	return Paginate(ctx, func(ctx context.Context, marker string) ([]Role, string, bool, error) {
+		pageOpts.Marker = marker
+		list, err := c.ListRoles(ctx, &pageOpts)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return list.Data, list.Marker, list.HasNextPage, nil
+	})
+}
+
+// ListAllRoles returns every role matching opts, following
+// pagination markers. opts.Marker is ignored.
+func (c *Client) ListAllRoles(ctx context.Context, opts *ListRolesOptions) ([]Role, error) {
+	return CollectAll(c.Roles(ctx, opts))
+}
+
 // GetRole retrieves a specific role by ID.
 func (c *Client) GetRole(ctx context.Context, roleID string) (*Role, error) {
 	if roleID == "" {
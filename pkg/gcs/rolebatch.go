@@ -0,0 +1,443 @@
+package gcs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RoleAction is the kind of change made by a RoleOp.
+type RoleAction string
+
+// Role actions.
+const (
+	RoleActionCreate RoleAction = "create"
+	RoleActionDelete RoleAction = "delete"
+)
+
+// Defaults for RoleBatchOptions.
+const (
+	DefaultRoleBatchRate        = 5.0
+	DefaultRoleBatchMaxRate     = 20.0
+	DefaultRoleBatchMinRate     = 0.5
+	DefaultRoleBatchWorkers     = 4
+	DefaultRoleBatchMaxAttempts = 5
+)
+
+// RoleOp is one role change applied by ApplyRoles.
+type RoleOp struct {
+	Action RoleAction `json:"action"`
+
+	// Role is the role to create. For a delete, only Role.ID is used.
+	Role Role `json:"role"`
+}
+
+// Key identifies the op in a Checkpoint. Creates are keyed by collection,
+// principal and role, since the ID is not known until the role exists.
+func (op RoleOp) Key() string {
+	if op.Action == RoleActionDelete {
+		return "delete " + op.Role.ID
+	}
+	return strings.Join([]string{string(op.Action), op.Role.Collection, op.Role.Principal, op.Role.Role}, " ")
+}
+
+// RoleResult reports the outcome of one RoleOp.
+type RoleResult struct {
+	Op RoleOp
+
+	// Role is the created role. It is nil for deletes, skipped ops, and
+	// creates of a role that already existed.
+	Role *Role
+
+	// Err is the error of the last attempt, if the op failed.
+	Err error
+
+	// Attempts is the number of requests made for the op.
+	Attempts int
+
+	// Skipped is set for ops the Checkpoint records as done.
+	Skipped bool
+}
+
+// RoleBatchSummary counts the outcomes of ApplyRoles.
+type RoleBatchSummary struct {
+	Created int `json:"created"`
+	Deleted int `json:"deleted"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+
+	// Throttled is the number of HTTP 429 responses received.
+	Throttled int `json:"throttled"`
+}
+
+// Checkpoint records completed ops so that an interrupted ApplyRoles can
+// be resumed by running it again with the same ops.
+type Checkpoint interface {
+	// Done reports whether the op with key has completed.
+	Done(key string) bool
+
+	// MarkDone records that the op with key has completed.
+	MarkDone(key string) error
+}
+
+// RoleBatchOptions configures ApplyRoles. The zero value uses the
+// defaults.
+type RoleBatchOptions struct {
+	// Rate is the initial request rate in requests per second.
+	Rate float64
+
+	// MinRate and MaxRate bound the adaptive rate. The rate is halved on
+	// each HTTP 429 response and raised by about one request per second
+	// for every second of successful requests.
+	MinRate float64
+	MaxRate float64
+
+	// Workers is the number of requests in flight at once.
+	Workers int
+
+	// MaxAttempts is the number of requests made for an op that keeps
+	// failing with HTTP 429 or a 5xx status before it is reported failed.
+	MaxAttempts int
+
+	// Checkpoint, if set, skips ops already done and records new ones.
+	Checkpoint Checkpoint
+
+	// OnResult, if set, is called with the result of each op, one call
+	// at a time.
+	OnResult func(RoleResult)
+}
+
+// withDefaults returns opts with unset fields replaced by defaults.
+func (opts *RoleBatchOptions) withDefaults() RoleBatchOptions {
+	o := RoleBatchOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Rate <= 0 {
+		o.Rate = DefaultRoleBatchRate
+	}
+	if o.MaxRate <= 0 {
+		o.MaxRate = DefaultRoleBatchMaxRate
+	}
+	if o.MinRate <= 0 {
+		o.MinRate = DefaultRoleBatchMinRate
+	}
+	if o.MaxRate < o.Rate {
+		o.MaxRate = o.Rate
+	}
+	if o.MinRate > o.Rate {
+		o.MinRate = o.Rate
+	}
+	if o.Workers <= 0 {
+		o.Workers = DefaultRoleBatchWorkers
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultRoleBatchMaxAttempts
+	}
+	return o
+}
+
+// ApplyRoles creates and deletes roles in bulk.
+//
+// Creates and deletes are interleaved, so that a sync that replaces many
+// roles does not first double the number of roles on the endpoint. The
+// request rate adapts to the server: it backs off when the server answers
+// HTTP 429 and climbs back while requests succeed. Ops that fail with 429
+// or a 5xx status are retried up to opts.MaxAttempts times.
+//
+// Creating a role that already exists (HTTP 409) and deleting one that is
+// already gone (HTTP 404) count as success, so a batch that was
+// interrupted between a request and its checkpoint can be run again.
+//
+// Failed ops are reported through opts.OnResult and counted in the
+// summary; the returned error is only set if ctx is done or the
+// checkpoint cannot be written. The summary is valid either way.
+func (c *Client) ApplyRoles(ctx context.Context, ops []RoleOp, opts *RoleBatchOptions) (*RoleBatchSummary, error) {
+	o := opts.withDefaults()
+	summary := &RoleBatchSummary{}
+
+	report := func(r RoleResult) {
+		if o.OnResult != nil {
+			o.OnResult(r)
+		}
+	}
+
+	var pending []RoleOp
+	for _, op := range interleaveRoleOps(ops) {
+		if op.Action != RoleActionCreate && op.Action != RoleActionDelete {
+			summary.Failed++
+			report(RoleResult{Op: op, Err: fmt.Errorf("unknown role action %q", op.Action)})
+			continue
+		}
+		if o.Checkpoint != nil && o.Checkpoint.Done(op.Key()) {
+			summary.Skipped++
+			report(RoleResult{Op: op, Skipped: true})
+			continue
+		}
+		pending = append(pending, op)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	limiter := newAdaptiveLimiter(o.Rate, o.MinRate, o.MaxRate)
+	var checkpointErr error
+	batch := Batch[RoleOp, RoleResult]{
+		Workers: o.Workers,
+		OnResult: func(br BatchResult[RoleOp, RoleResult]) {
+			r := br.Value
+			switch {
+			case r.Err != nil:
+				summary.Failed++
+			case r.Op.Action == RoleActionCreate:
+				summary.Created++
+			default:
+				summary.Deleted++
+			}
+			if r.Err == nil && o.Checkpoint != nil && checkpointErr == nil {
+				if err := o.Checkpoint.MarkDone(r.Op.Key()); err != nil {
+					checkpointErr = fmt.Errorf("record checkpoint: %w", err)
+					cancel()
+				}
+			}
+			report(r)
+		},
+	}
+	// Failures are counted in the summary; ops not started because ctx
+	// was canceled are left unreported, so the batch error is not needed.
+	_, _ = batch.Run(ctx, pending, func(ctx context.Context, op RoleOp) (RoleResult, error) {
+		r := c.applyRoleOp(ctx, op, o.MaxAttempts, limiter)
+		return r, r.Err
+	})
+	summary.Throttled = limiter.throttledCount()
+
+	if checkpointErr != nil {
+		return summary, checkpointErr
+	}
+	return summary, ctx.Err()
+}
+
+// applyRoleOp performs op, retrying throttled and server errors.
+func (c *Client) applyRoleOp(ctx context.Context, op RoleOp, maxAttempts int, limiter *adaptiveLimiter) RoleResult {
+	result := RoleResult{Op: op}
+	for result.Attempts < maxAttempts {
+		if err := limiter.wait(ctx); err != nil {
+			if result.Err == nil {
+				result.Err = err
+			}
+			return result
+		}
+		result.Attempts++
+
+		// The limiter paces the batch by the throttled responses, so the
+		// client must not retry them out of sight
+		opCtx := withoutRetry(ctx)
+		var err error
+		switch op.Action {
+		case RoleActionCreate:
+			role := op.Role
+			role.ID = ""
+			result.Role, err = c.CreateRole(opCtx, &role)
+			if isHTTPStatus(err, 409) {
+				err = nil
+			}
+		case RoleActionDelete:
+			err = c.DeleteRole(opCtx, op.Role.ID)
+			if isHTTPStatus(err, 404) {
+				err = nil
+			}
+		}
+		result.Err = err
+
+		switch {
+		case err == nil:
+			limiter.success()
+			return result
+		case isHTTPStatus(err, 429):
+			limiter.throttled()
+		case !isRetryableStatus(err) || ctx.Err() != nil:
+			return result
+		}
+	}
+	return result
+}
+
+// isRetryableStatus reports whether err is a server error worth retrying.
+func isRetryableStatus(err error) bool {
+	for _, code := range []int{500, 502, 503, 504} {
+		if isHTTPStatus(err, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// interleaveRoleOps alternates creates and deletes, keeping the order of
+// each. Ops with other actions come last.
+func interleaveRoleOps(ops []RoleOp) []RoleOp {
+	var creates, deletes, other []RoleOp
+	for _, op := range ops {
+		switch op.Action {
+		case RoleActionCreate:
+			creates = append(creates, op)
+		case RoleActionDelete:
+			deletes = append(deletes, op)
+		default:
+			other = append(other, op)
+		}
+	}
+
+	merged := make([]RoleOp, 0, len(ops))
+	for i := 0; i < len(creates) || i < len(deletes); i++ {
+		if i < len(creates) {
+			merged = append(merged, creates[i])
+		}
+		if i < len(deletes) {
+			merged = append(merged, deletes[i])
+		}
+	}
+	return append(merged, other...)
+}
+
+// adaptiveLimiter spaces requests at a rate that is halved on throttling
+// and raised additively on success.
+type adaptiveLimiter struct {
+	mu        sync.Mutex
+	rate      float64
+	min, max  float64
+	next      time.Time
+	throttles int
+}
+
+// newAdaptiveLimiter returns a limiter starting at rate requests per
+// second.
+func newAdaptiveLimiter(rate, minRate, maxRate float64) *adaptiveLimiter {
+	return &adaptiveLimiter{rate: rate, min: minRate, max: maxRate}
+}
+
+// interval returns the time between requests at the current rate. The
+// caller holds l.mu.
+func (l *adaptiveLimiter) interval() time.Duration {
+	return time.Duration(float64(time.Second) / l.rate)
+}
+
+// wait blocks until the next request may be sent.
+func (l *adaptiveLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	start := l.next
+	if start.Before(now) {
+		start = now
+	}
+	l.next = start.Add(l.interval())
+	l.mu.Unlock()
+
+	delay := time.Until(start)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// success raises the rate by one request per second for every second's
+// worth of successful requests.
+func (l *adaptiveLimiter) success() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rate = min(l.max, l.rate+1/l.rate)
+}
+
+// throttled halves the rate and holds off all requests for one interval
+// at the new rate.
+func (l *adaptiveLimiter) throttled() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.throttles++
+	l.rate = max(l.min, l.rate/2)
+	if pause := time.Now().Add(l.interval()); l.next.Before(pause) {
+		l.next = pause
+	}
+}
+
+// throttledCount returns the number of throttled responses seen.
+func (l *adaptiveLimiter) throttledCount() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.throttles
+}
+
+// FileCheckpoint is a Checkpoint stored in a file with one completed op
+// key per line. Keys are appended as ops complete, so progress survives a
+// crash.
+type FileCheckpoint struct {
+	mu   sync.Mutex
+	file *os.File
+	done map[string]bool
+}
+
+// OpenFileCheckpoint opens or creates the checkpoint file at path and
+// loads the keys already recorded in it.
+func OpenFileCheckpoint(path string) (*FileCheckpoint, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600) //nolint:gosec // Path chosen by the caller
+	if err != nil {
+		return nil, fmt.Errorf("open checkpoint: %w", err)
+	}
+
+	cp := &FileCheckpoint{file: file, done: map[string]bool{}}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if key := scanner.Text(); key != "" {
+			cp.done[key] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("read checkpoint: %w", err)
+	}
+	return cp, nil
+}
+
+// Done reports whether key is recorded in the checkpoint.
+func (cp *FileCheckpoint) Done(key string) bool {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return cp.done[key]
+}
+
+// MarkDone appends key to the checkpoint file.
+func (cp *FileCheckpoint) MarkDone(key string) error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if strings.ContainsAny(key, "\r\n") {
+		return errors.New("checkpoint key contains a newline")
+	}
+	if _, err := cp.file.WriteString(key + "\n"); err != nil {
+		return err
+	}
+	cp.done[key] = true
+	return nil
+}
+
+// Len returns the number of keys recorded.
+func (cp *FileCheckpoint) Len() int {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return len(cp.done)
+}
+
+// Close closes the checkpoint file.
+func (cp *FileCheckpoint) Close() error {
+	return cp.file.Close()
+}
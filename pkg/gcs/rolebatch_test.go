@@ -0,0 +1,190 @@
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newRoleBatchServer returns a client for a fake roles API. The first
+// throttle POSTs are answered with HTTP 429; deleting "gone" returns 404
+// and deleting "broken" returns 400.
+func newRoleBatchServer(t *testing.T, throttle int) (*Client, func() []string) {
+	t.Helper()
+
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPost:
+			if throttle > 0 {
+				throttle--
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			var role Role
+			_ = json.NewDecoder(r.Body).Decode(&role)
+			requests = append(requests, "create "+role.Principal)
+			role.ID = "id-" + role.Principal
+			_ = json.NewEncoder(w).Encode(role)
+		case http.MethodDelete:
+			id := strings.TrimPrefix(r.URL.Path, "/roles/")
+			requests = append(requests, "delete "+id)
+			switch id {
+			case "gone":
+				w.WriteHeader(http.StatusNotFound)
+			case "broken":
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := &Client{baseURL: server.URL + "/", httpClient: server.Client()}
+	return client, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), requests...)
+	}
+}
+
+// fastBatch returns options that do not slow tests down.
+func fastBatch() *RoleBatchOptions {
+	return &RoleBatchOptions{Rate: 1000, MinRate: 500, MaxRate: 2000, Workers: 1}
+}
+
+func createOp(principal string) RoleOp {
+	return RoleOp{Action: RoleActionCreate, Role: Role{Collection: "col-1", Principal: principal, Role: "access_manager"}}
+}
+
+func deleteOp(id string) RoleOp {
+	return RoleOp{Action: RoleActionDelete, Role: Role{ID: id}}
+}
+
+func TestApplyRoles(t *testing.T) {
+	client, requests := newRoleBatchServer(t, 2)
+
+	ops := []RoleOp{createOp("a"), createOp("b"), createOp("c"), deleteOp("x"), deleteOp("gone"), deleteOp("broken")}
+	var results []RoleResult
+	opts := fastBatch()
+	opts.OnResult = func(r RoleResult) { results = append(results, r) }
+
+	summary, err := client.ApplyRoles(context.Background(), ops, opts)
+	if err != nil {
+		t.Fatalf("ApplyRoles() error = %v", err)
+	}
+
+	want := RoleBatchSummary{Created: 3, Deleted: 2, Failed: 1, Throttled: 2}
+	if *summary != want {
+		t.Errorf("summary = %+v, want %+v", *summary, want)
+	}
+
+	got := strings.Join(requests(), ", ")
+	if wantOrder := "create a, delete x, create b, delete gone, create c, delete broken"; got != wantOrder {
+		t.Errorf("requests = %s, want %s", got, wantOrder)
+	}
+
+	if len(results) != len(ops) {
+		t.Fatalf("OnResult called %d times, want %d", len(results), len(ops))
+	}
+	if results[0].Attempts != 3 || results[0].Role == nil || results[0].Role.ID != "id-a" {
+		t.Errorf("first result = %+v, want created after 3 attempts", results[0])
+	}
+}
+
+func TestApplyRoles_GivesUpWhenThrottled(t *testing.T) {
+	client, _ := newRoleBatchServer(t, 100)
+
+	opts := fastBatch()
+	opts.MaxAttempts = 2
+	summary, err := client.ApplyRoles(context.Background(), []RoleOp{createOp("a")}, opts)
+	if err != nil {
+		t.Fatalf("ApplyRoles() error = %v", err)
+	}
+	if summary.Failed != 1 || summary.Throttled != 2 {
+		t.Errorf("summary = %+v, want 1 failed after 2 throttled attempts", *summary)
+	}
+}
+
+func TestApplyRoles_ResumesFromCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "roles.checkpoint")
+	ops := []RoleOp{createOp("a"), createOp("b"), deleteOp("x")}
+
+	cp, err := OpenFileCheckpoint(path)
+	if err != nil {
+		t.Fatalf("OpenFileCheckpoint() error = %v", err)
+	}
+	if err := cp.MarkDone(ops[0].Key()); err != nil {
+		t.Fatal(err)
+	}
+	if err := cp.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	cp, err = OpenFileCheckpoint(path)
+	if err != nil {
+		t.Fatalf("OpenFileCheckpoint() error = %v", err)
+	}
+	defer func() { _ = cp.Close() }()
+
+	client, requests := newRoleBatchServer(t, 0)
+	opts := fastBatch()
+	opts.Checkpoint = cp
+	summary, err := client.ApplyRoles(context.Background(), ops, opts)
+	if err != nil {
+		t.Fatalf("ApplyRoles() error = %v", err)
+	}
+
+	if summary.Skipped != 1 || summary.Created != 1 || summary.Deleted != 1 {
+		t.Errorf("summary = %+v, want 1 skipped, 1 created, 1 deleted", *summary)
+	}
+	if got := strings.Join(requests(), ", "); got != "delete x, create b" {
+		t.Errorf("requests = %s, want the ops not in the checkpoint", got)
+	}
+	if cp.Len() != 3 {
+		t.Errorf("checkpoint has %d keys, want 3", cp.Len())
+	}
+}
+
+func TestApplyRoles_Canceled(t *testing.T) {
+	client, requests := newRoleBatchServer(t, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.ApplyRoles(ctx, []RoleOp{createOp("a"), createOp("b")}, fastBatch())
+	if err == nil {
+		t.Error("ApplyRoles() with canceled context succeeded")
+	}
+	if n := len(requests()); n != 0 {
+		t.Errorf("%d requests sent after cancel", n)
+	}
+}
+
+func TestAdaptiveLimiter(t *testing.T) {
+	l := newAdaptiveLimiter(10, 1, 12)
+
+	l.throttled()
+	l.throttled()
+	if l.rate != 2.5 {
+		t.Errorf("rate after two throttles = %v, want 2.5", l.rate)
+	}
+	for i := 0; i < 100; i++ {
+		l.success()
+	}
+	if l.rate != 12 {
+		t.Errorf("rate after successes = %v, want capped at 12", l.rate)
+	}
+	if l.throttledCount() != 2 {
+		t.Errorf("throttledCount() = %d, want 2", l.throttledCount())
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -58,6 +59,29 @@ func (c *Client) ListStorageGateways(ctx context.Context, opts *ListStorageGatew
 	return &list, nil
 }
 
+// StorageGateways returns an iterator over every storage gateway matching opts,
+// following pagination markers. opts.Marker is ignored.
+func (c *Client) StorageGateways(ctx context.Context, opts *ListStorageGatewaysOptions) iter.Seq2[StorageGateway, error] {
+	pageOpts := ListStorageGatewaysOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	return Paginate(ctx, func(ctx context.Context, marker string) ([]StorageGateway, string, bool, error) {
+		pageOpts.Marker = marker
+		list, err := c.ListStorageGateways(ctx, &pageOpts)
+		if err != nil {
+			return nil, "", false, err
+		}
+		return list.Data, list.Marker, list.HasNextPage, nil
+	})
+}
+
+// ListAllStorageGateways returns every storage gateway matching opts, following
+// pagination markers. opts.Marker is ignored.
+func (c *Client) ListAllStorageGateways(ctx context.Context, opts *ListStorageGatewaysOptions) ([]StorageGateway, error) {
+	return CollectAll(c.StorageGateways(ctx, opts))
+}
+
 // GetStorageGateway retrieves a specific storage gateway by ID.
 func (c *Client) GetStorageGateway(ctx context.Context, gatewayID string) (*StorageGateway, error) {
 	if gatewayID == "" {
@@ -0,0 +1,113 @@
+package gcs
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// RequestTiming is the breakdown of one GCS Manager API request, measured
+// with net/http/httptrace. Phases that did not happen, such as DNS and TLS
+// on a reused connection, are zero.
+type RequestTiming struct {
+	Method string
+	Path   string
+
+	// Status is the HTTP status, or 0 if the request failed.
+	Status int
+
+	// DNSLookup, Connect, and TLSHandshake are the durations of the
+	// connection setup phases.
+	DNSLookup    time.Duration
+	Connect      time.Duration
+	TLSHandshake time.Duration
+
+	// TimeToFirstByte is the time from the start of the request to the
+	// first byte of the response; Total is the time to the end of the
+	// response headers, or to the failure.
+	TimeToFirstByte time.Duration
+	Total           time.Duration
+
+	// ConnectionReused reports whether a pooled connection was used.
+	ConnectionReused bool
+}
+
+// TimingFunc receives the timing of each request of a client created with
+// WithTiming, including retries. It may be called concurrently.
+type TimingFunc func(RequestTiming)
+
+// requestTimer records the phases of one request from httptrace
+// callbacks, which may run on other goroutines.
+type requestTimer struct {
+	mu    sync.Mutex
+	start time.Time
+
+	dnsStart, connectStart, tlsStart time.Time
+	timing                           RequestTiming
+}
+
+// newRequestTimer starts timing a request and returns ctx with the trace
+// hooks that record its phases.
+func newRequestTimer(ctx context.Context) (*requestTimer, context.Context) {
+	t := &requestTimer{start: time.Now()}
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { t.mark(&t.dnsStart) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { t.since(&t.dnsStart, &t.timing.DNSLookup) },
+		ConnectStart: func(string, string) {
+			t.mark(&t.connectStart)
+		},
+		ConnectDone: func(string, string, error) {
+			t.since(&t.connectStart, &t.timing.Connect)
+		},
+		TLSHandshakeStart: func() { t.mark(&t.tlsStart) },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.since(&t.tlsStart, &t.timing.TLSHandshake)
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			t.timing.ConnectionReused = info.Reused
+			t.mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			t.timing.TimeToFirstByte = time.Since(t.start)
+			t.mu.Unlock()
+		},
+	}
+	return t, httptrace.WithClientTrace(ctx, trace)
+}
+
+// mark records the start of a phase.
+func (t *requestTimer) mark(start *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	*start = time.Now()
+}
+
+// since records the duration of a phase that started at start. Parallel
+// dial attempts each end the connect phase; the first one wins.
+func (t *requestTimer) since(start *time.Time, d *time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if *d == 0 && !start.IsZero() {
+		*d = time.Since(*start)
+	}
+}
+
+// finish returns the timing of req, which got resp.
+func (t *requestTimer) finish(req *http.Request, resp *http.Response) RequestTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timing := t.timing
+	timing.Method = req.Method
+	timing.Path = req.URL.RequestURI()
+	timing.Total = time.Since(t.start)
+	if resp != nil {
+		timing.Status = resp.StatusCode
+	}
+	return timing
+}
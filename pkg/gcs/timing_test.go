@@ -0,0 +1,69 @@
+package gcs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestWithTiming(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"c1"}`))
+	}))
+	defer server.Close()
+
+	var (
+		mu      sync.Mutex
+		timings []RequestTiming
+	)
+	client := &Client{
+		baseURL:    server.URL + "/api/",
+		httpClient: server.Client(),
+		timing: func(timing RequestTiming) {
+			mu.Lock()
+			defer mu.Unlock()
+			timings = append(timings, timing)
+		},
+	}
+
+	for range 2 {
+		if _, err := client.GetCollection(context.Background(), "c1"); err != nil {
+			t.Fatalf("GetCollection() error = %v", err)
+		}
+	}
+
+	if len(timings) != 2 {
+		t.Fatalf("got %d timings, want 2", len(timings))
+	}
+	first, second := timings[0], timings[1]
+	if first.Method != http.MethodGet || first.Path != "/api/collections/c1" || first.Status != 200 {
+		t.Errorf("first = %+v, want GET /api/collections/c1 200", first)
+	}
+	if first.ConnectionReused || first.Connect == 0 || first.TLSHandshake == 0 {
+		t.Errorf("first = %+v, want a new connection with connect and TLS times", first)
+	}
+	if first.TimeToFirstByte == 0 || first.Total < first.TimeToFirstByte {
+		t.Errorf("first = %+v, want 0 < TTFB <= Total", first)
+	}
+	if !second.ConnectionReused || second.TLSHandshake != 0 {
+		t.Errorf("second = %+v, want a reused connection without a handshake", second)
+	}
+}
+
+func TestWithTiming_Failure(t *testing.T) {
+	var got RequestTiming
+	client := &Client{
+		baseURL:    "http://127.0.0.1:1/api/",
+		httpClient: &http.Client{},
+		timing:     func(timing RequestTiming) { got = timing },
+	}
+
+	if _, err := client.GetCollection(context.Background(), "c1"); err == nil {
+		t.Fatal("GetCollection() error = nil, want a connection error")
+	}
+	if got.Status != 0 || got.Total == 0 {
+		t.Errorf("timing = %+v, want status 0 and a total", got)
+	}
+}
@@ -61,12 +61,15 @@ func SecureTLSConfig() *tls.Config {
 // SecureHTTPClient creates an HTTP client with secure TLS configuration.
 //
 // This is the recommended way to create HTTP clients for production use,
-// as it enforces TLS 1.2+ and NIST-approved cipher suites.
+// as it enforces TLS 1.2+ and NIST-approved cipher suites. Requests go
+// through the proxy named by HTTPS_PROXY, unless NO_PROXY excludes the
+// endpoint.
 func SecureHTTPClient(timeout time.Duration) *http.Client {
 	return &http.Client{
 		Timeout: timeout,
 		Transport: &http.Transport{
 			TLSClientConfig:     SecureTLSConfig(),
+			Proxy:               http.ProxyFromEnvironment,
 			MaxIdleConns:        100,
 			MaxIdleConnsPerHost: 10,
 			IdleConnTimeout:     90 * time.Second,
@@ -114,6 +117,14 @@ func WithServerName(serverName string) TLSConfigOption {
 	}
 }
 
+// WithClientCertificates sets the certificates presented to servers that
+// request one, for mutual TLS with CustomTLSConfig.
+func WithClientCertificates(certs ...tls.Certificate) TLSConfigOption {
+	return func(cfg *tls.Config) {
+		cfg.Certificates = certs
+	}
+}
+
 // CustomTLSConfig creates a TLS configuration with custom options.
 // Starts with secure defaults and applies the provided options.
 func CustomTLSConfig(opts ...TLSConfigOption) *tls.Config {
@@ -1,9 +1,20 @@
 package gcs
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -419,3 +430,92 @@ func contains(s, substr string) bool {
 	}
 	return false
 }
+
+// writeClientCertificate writes a self-signed client certificate and its
+// key to PEM files in a temporary directory.
+func writeClientCertificate(t *testing.T) (certFile, keyFile string, cert *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "gcs-admin"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cert, err = x509.ParseCertificate(der); err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certFile, keyFile = filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile, cert
+}
+
+func TestWithClientCertificate(t *testing.T) {
+	certFile, keyFile, cert := writeClientCertificate(t)
+
+	var subject string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject = r.TLS.PeerCertificates[0].Subject.CommonName
+		_, _ = w.Write([]byte(`{"api_version": "1.30.0"}`))
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(cert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+	fqdn := strings.TrimPrefix(server.URL, "https://")
+
+	client, err := NewClient(fqdn,
+		WithTLSConfig(CustomTLSConfig(WithRootCAs(rootCAs))),
+		WithClientCertificate(certFile, keyFile))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, err := client.GetInfo(context.Background()); err != nil {
+		t.Fatalf("GetInfo() error = %v", err)
+	}
+	if subject != "gcs-admin" {
+		t.Errorf("client certificate subject = %q, want gcs-admin", subject)
+	}
+
+	// Without the certificate the proxy refuses the connection
+	client, err = NewClient(fqdn, WithTLSConfig(CustomTLSConfig(WithRootCAs(rootCAs))))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, err := client.GetInfo(context.Background()); err == nil {
+		t.Error("GetInfo() without a client certificate succeeded, want an error")
+	}
+}
+
+func TestWithClientCertificate_LoadError(t *testing.T) {
+	certFile, _, _ := writeClientCertificate(t)
+
+	_, err := NewClient("example.org", WithClientCertificate(certFile, filepath.Join(t.TempDir(), "missing.key")))
+	if err == nil || !strings.Contains(err.Error(), "load client certificate") {
+		t.Errorf("NewClient() error = %v, want a load error", err)
+	}
+}
@@ -0,0 +1,104 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// Tracer starts a span for each API call of a client created with
+// WithTracer. WithTracerProvider adapts an OpenTelemetry TracerProvider;
+// implement Tracer directly for other tracing systems.
+type Tracer interface {
+	// Start starts a span named name as a child of any span in ctx, and
+	// returns ctx with the new span.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	// SetAttribute records a string or int attribute.
+	SetAttribute(key string, value any)
+
+	// RecordError records err and marks the span as failed.
+	RecordError(err error)
+
+	// End ends the span.
+	End()
+}
+
+// HeaderInjector is implemented by spans that propagate their context to
+// the server in request headers, such as the W3C traceparent header.
+type HeaderInjector interface {
+	Inject(header http.Header)
+}
+
+// Span attribute keys, following the OpenTelemetry HTTP semantic
+// conventions, and the GCS request ID that correlates a span with the
+// endpoint's logs.
+const (
+	AttrHTTPMethod    = "http.request.method"
+	AttrURLFull       = "url.full"
+	AttrServerAddress = "server.address"
+	AttrHTTPStatus    = "http.response.status_code"
+	AttrErrorType     = "error.type"
+	AttrRequestID     = "gcs.request_id"
+)
+
+// traced runs do, the API call method url, in a span of the client's
+// tracer, if it has one. The span covers retries and token refreshes.
+func (c *Client) traced(ctx context.Context, method, rawURL string, header http.Header,
+	do func(context.Context, http.Header) (*http.Response, error)) (*http.Response, error) {
+	if c.tracer == nil {
+		return do(ctx, header)
+	}
+
+	ctx, span := c.tracer.Start(ctx, "GCS "+method)
+	defer span.End()
+
+	span.SetAttribute(AttrHTTPMethod, method)
+	if u, err := url.Parse(rawURL); err == nil {
+		span.SetAttribute(AttrURLFull, redactURL(u))
+		span.SetAttribute(AttrServerAddress, u.Hostname())
+	}
+	if injector, ok := span.(HeaderInjector); ok {
+		header = header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		injector.Inject(header)
+	}
+
+	resp, err := do(ctx, header)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			span.SetAttribute(AttrHTTPStatus, apiErr.StatusCode)
+			span.SetAttribute(AttrErrorType, apiErr.Code)
+			if apiErr.RequestID != "" {
+				span.SetAttribute(AttrRequestID, apiErr.RequestID)
+			}
+		}
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttribute(AttrHTTPStatus, resp.StatusCode)
+	if id := requestID(resp.Header); id != "" {
+		span.SetAttribute(AttrRequestID, id)
+	}
+	return resp, nil
+}
+
+// redactURL returns u without credentials, query or fragment, which may
+// carry tokens or identifiers that do not belong in a trace.
+func redactURL(u *url.URL) string {
+	redacted := *u
+	redacted.User = nil
+	redacted.RawQuery = ""
+	redacted.ForceQuery = false
+	redacted.Fragment = ""
+	redacted.RawFragment = ""
+	return redacted.String()
+}
@@ -1,4 +1,3 @@
-// Package gcs provides a client for the Globus Connect Server Manager API.
 package gcs
 
 import "time"
@@ -50,7 +49,22 @@ type Collection struct {
 	UserMessage         string            `json:"user_message,omitempty"`
 	UserMessageLink     string            `json:"user_message_link,omitempty"`
 	IdentityID          string            `json:"identity_id,omitempty"`
+	UserCredentialID    string            `json:"user_credential_id,omitempty"`
+	HTTPSURL            string            `json:"https_url,omitempty"`
 	Policies            *CollectionPolicies `json:"policies,omitempty"`
+
+	// MappedCollectionID is the mapped collection a guest collection
+	// shares data from.
+	MappedCollectionID string `json:"mapped_collection_id,omitempty"`
+
+	// AllowGuestCollections reports whether guest collections may be
+	// created on a mapped collection. It is nil if the server omits it.
+	AllowGuestCollections *bool `json:"allow_guest_collections,omitempty"`
+
+	// CreatedAt and LastModified are set by the server, when it reports
+	// them, and never sent.
+	CreatedAt    *time.Time `json:"created_timestamp,omitempty"`
+	LastModified *time.Time `json:"last_modified,omitempty"`
 }
 
 // CollectionPolicies represents access policies for a collection.
@@ -98,8 +112,15 @@ type PathRestrictions struct {
 // StorageGatewayPolicies represents policies for a storage gateway.
 type StorageGatewayPolicies struct {
 	DataType string `json:"DATA_TYPE,omitempty"`
+
+	// S3 connector settings (DATA_TYPE S3StoragePolicies).
+	S3Endpoint string   `json:"s3_endpoint,omitempty"`
+	S3Buckets  []string `json:"s3_buckets,omitempty"`
 }
 
+// S3StoragePolicies is the DATA_TYPE of S3 connector policies.
+const S3StoragePolicies = "s3_storage_policies#1.0.0"
+
 // Role represents an access role assignment.
 type Role struct {
 	ID         string `json:"id,omitempty"`
@@ -114,6 +135,8 @@ type Node struct {
 	Name      string `json:"name,omitempty"`
 	Incoming  bool   `json:"incoming,omitempty"`
 	Outgoing  bool   `json:"outgoing,omitempty"`
+	Status    string `json:"status,omitempty"`
+	IPAddresses []string `json:"ip_addresses,omitempty"`
 }
 
 // DomainConfig represents custom domain configuration.
@@ -0,0 +1,215 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Upgrade states reported by UpgradeStatus.
+const (
+	UpgradeStatePending   = "pending"
+	UpgradeStateRunning   = "running"
+	UpgradeStateSucceeded = "succeeded"
+	UpgradeStateFailed    = "failed"
+)
+
+// UpgradeStatus represents the progress of an endpoint upgrade.
+type UpgradeStatus struct {
+	State          string `json:"state"`
+	Progress       int    `json:"progress,omitempty"` // Percent complete (0-100)
+	Step           string `json:"step,omitempty"`
+	Message        string `json:"message,omitempty"`
+	CurrentVersion string `json:"current_version,omitempty"`
+	TargetVersion  string `json:"target_version,omitempty"`
+}
+
+// Done reports whether the upgrade has finished, successfully or not.
+func (s *UpgradeStatus) Done() bool {
+	return s != nil && (s.State == UpgradeStateSucceeded || s.State == UpgradeStateFailed)
+}
+
+// GetUpgradeStatus retrieves the progress of a running endpoint upgrade.
+func (c *Client) GetUpgradeStatus(ctx context.Context) (*UpgradeStatus, error) {
+	var status UpgradeStatus
+	if err := c.GetConditional(ctx, "endpoint/upgrade/status", nil, &status); err != nil {
+		return nil, fmt.Errorf("get upgrade status: %w", err)
+	}
+
+	return &status, nil
+}
+
+// errUpgradeDone stops polling once the upgrade has finished.
+var errUpgradeDone = errors.New("upgrade done")
+
+// WaitForUpgrade polls the endpoint until an upgrade to targetVersion
+// finishes, calling onProgress whenever the status changes.
+//
+// The upgrade status endpoint is used when the endpoint provides it.
+// Older endpoints without it are monitored through repeated /info checks,
+// and the upgrade is considered complete once the reported manager version
+// matches targetVersion. Connection errors while the manager restarts are
+// retried with backoff; use ctx to bound the total wait.
+func (c *Client) WaitForUpgrade(ctx context.Context, targetVersion string, interval time.Duration, onProgress func(*UpgradeStatus)) (*UpgradeStatus, error) {
+	useInfo := false
+
+	fetch := func(ctx context.Context, v *Validators) (*UpgradeStatus, error) {
+		if !useInfo {
+			var status UpgradeStatus
+			err := c.GetConditional(ctx, "endpoint/upgrade/status", v, &status)
+			if err == nil || errors.Is(err, ErrNotModified) {
+				return &status, err
+			}
+			if !isHTTPStatus(err, 404) {
+				return nil, err
+			}
+			useInfo = true
+		}
+
+		info, err := c.GetInfo(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return upgradeStatusFromInfo(info, targetVersion), nil
+	}
+
+	var last *UpgradeStatus
+	err := Poll(ctx, fetch, interval, func(status *UpgradeStatus) error {
+		last = status
+		if onProgress != nil {
+			onProgress(status)
+		}
+		if status.Done() {
+			return errUpgradeDone
+		}
+		return nil
+	})
+	if !errors.Is(err, errUpgradeDone) {
+		return last, fmt.Errorf("wait for upgrade: %w", err)
+	}
+
+	if last.State == UpgradeStateFailed {
+		return last, fmt.Errorf("upgrade failed: %s", last.Message)
+	}
+	return last, nil
+}
+
+// upgradeStatusFromInfo derives an upgrade status from service information.
+func upgradeStatusFromInfo(info *Info, targetVersion string) *UpgradeStatus {
+	status := &UpgradeStatus{
+		State:          UpgradeStateRunning,
+		Message:        "Waiting for the GCS Manager to report the new version",
+		CurrentVersion: info.ManagerVersion,
+		TargetVersion:  targetVersion,
+	}
+	if targetVersion == "" || info.ManagerVersion == targetVersion {
+		status.State = UpgradeStateSucceeded
+		status.Progress = 100
+		status.Message = "GCS Manager reports the new version"
+	}
+	return status
+}
+
+// UpgradeCheck is the result of a single post-upgrade verification check.
+type UpgradeCheck struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+// UpgradeVerification summarizes the post-upgrade verification checks.
+type UpgradeVerification struct {
+	Passed bool           `json:"passed"`
+	Checks []UpgradeCheck `json:"checks"`
+}
+
+// unhealthyNodeStatuses are node statuses that fail post-upgrade verification.
+var unhealthyNodeStatuses = map[string]bool{
+	"error":       true,
+	"failed":      true,
+	"offline":     true,
+	"unhealthy":   true,
+	"unreachable": true,
+}
+
+// verifyUpgradeWorkers bounds the number of collections VerifyUpgrade
+// checks at once.
+const verifyUpgradeWorkers = 8
+
+// VerifyUpgrade runs post-upgrade checks: the manager version changed from
+// previousVersion, every node is healthy, and every collection passes
+// validation. Nodes and collections are listed across all pages, and
+// collections are checked concurrently.
+func (c *Client) VerifyUpgrade(ctx context.Context, previousVersion string) (*UpgradeVerification, error) {
+	v := &UpgradeVerification{Passed: true}
+	add := func(check UpgradeCheck) {
+		v.Checks = append(v.Checks, check)
+		if !check.Passed {
+			v.Passed = false
+		}
+	}
+
+	// Version bumped
+	info, err := c.GetInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("verify upgrade: %w", err)
+	}
+	versionCheck := UpgradeCheck{Name: "version", Passed: true,
+		Message: fmt.Sprintf("%s -> %s", previousVersion, info.ManagerVersion)}
+	if previousVersion != "" && info.ManagerVersion == previousVersion {
+		versionCheck.Passed = false
+		versionCheck.Message = fmt.Sprintf("manager version is still %s", info.ManagerVersion)
+	}
+	add(versionCheck)
+
+	// Nodes healthy
+	nodeCheck := UpgradeCheck{Name: "nodes", Passed: true}
+	nodes, err := c.ListAllNodes(ctx, nil)
+	if err != nil {
+		nodeCheck.Passed = false
+		nodeCheck.Message = err.Error()
+	} else {
+		var unhealthy []string
+		for _, node := range nodes {
+			if unhealthyNodeStatuses[strings.ToLower(node.Status)] {
+				unhealthy = append(unhealthy, fmt.Sprintf("%s (%s)", node.ID, node.Status))
+			}
+		}
+		if len(unhealthy) > 0 {
+			nodeCheck.Passed = false
+			nodeCheck.Message = "unhealthy: " + strings.Join(unhealthy, ", ")
+		} else {
+			nodeCheck.Message = fmt.Sprintf("%d node(s) healthy", len(nodes))
+		}
+	}
+	add(nodeCheck)
+
+	// Collections valid
+	collectionCheck := UpgradeCheck{Name: "collections", Passed: true}
+	collections, err := c.ListAllCollections(ctx, nil)
+	if err != nil {
+		collectionCheck.Passed = false
+		collectionCheck.Message = err.Error()
+	} else {
+		results, _ := RunBatch(ctx, collections, verifyUpgradeWorkers, func(ctx context.Context, collection Collection) (*CollectionValidation, error) {
+			return c.CheckCollection(ctx, collection.ID)
+		})
+		var invalid []string
+		for _, r := range results {
+			if r.Err != nil || !r.Value.Valid {
+				invalid = append(invalid, r.Item.ID)
+			}
+		}
+		if len(invalid) > 0 {
+			collectionCheck.Passed = false
+			collectionCheck.Message = "invalid: " + strings.Join(invalid, ", ")
+		} else {
+			collectionCheck.Message = fmt.Sprintf("%d collection(s) valid", len(collections))
+		}
+	}
+	add(collectionCheck)
+
+	return v, nil
+}
@@ -0,0 +1,195 @@
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitForUpgrade_StatusEndpoint(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/endpoint/upgrade/status" {
+			t.Errorf("request path = %q, want %q", r.URL.Path, "/api/endpoint/upgrade/status")
+		}
+
+		status := UpgradeStatus{State: UpgradeStateRunning, Progress: 50, Step: "Installing packages"}
+		if atomic.AddInt32(&calls, 1) >= 3 {
+			status = UpgradeStatus{State: UpgradeStateSucceeded, Progress: 100, CurrentVersion: "5.4.1"}
+		}
+		_ = json.NewEncoder(w).Encode(status)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:    server.URL + "/api/",
+		httpClient: &http.Client{},
+		userAgent:  "test-agent",
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var updates []*UpgradeStatus
+	status, err := client.WaitForUpgrade(ctx, "5.4.1", time.Millisecond, func(s *UpgradeStatus) {
+		updates = append(updates, s)
+	})
+	if err != nil {
+		t.Fatalf("WaitForUpgrade() error = %v", err)
+	}
+
+	if status.State != UpgradeStateSucceeded {
+		t.Errorf("State = %q, want %q", status.State, UpgradeStateSucceeded)
+	}
+	if len(updates) != 2 {
+		t.Errorf("got %d progress updates, want 2 (unchanged statuses are not reported)", len(updates))
+	}
+}
+
+func TestWaitForUpgrade_InfoFallback(t *testing.T) {
+	var infoCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/endpoint/upgrade/status":
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/info":
+			version := "5.4.0"
+			if atomic.AddInt32(&infoCalls, 1) >= 2 {
+				version = "5.4.1"
+			}
+			_ = json.NewEncoder(w).Encode(Info{ManagerVersion: version})
+		default:
+			t.Errorf("unexpected request path %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:    server.URL + "/api/",
+		httpClient: &http.Client{},
+		userAgent:  "test-agent",
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	status, err := client.WaitForUpgrade(ctx, "5.4.1", time.Millisecond, nil)
+	if err != nil {
+		t.Fatalf("WaitForUpgrade() error = %v", err)
+	}
+	if status.State != UpgradeStateSucceeded || status.CurrentVersion != "5.4.1" {
+		t.Errorf("status = %+v, want succeeded at 5.4.1", status)
+	}
+}
+
+func TestWaitForUpgrade_Failed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(UpgradeStatus{State: UpgradeStateFailed, Message: "disk full"})
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:    server.URL + "/api/",
+		httpClient: &http.Client{},
+		userAgent:  "test-agent",
+	}
+
+	_, err := client.WaitForUpgrade(context.Background(), "5.4.1", time.Millisecond, nil)
+	if err == nil {
+		t.Error("WaitForUpgrade() expected error for failed upgrade, got nil")
+	}
+}
+
+func TestVerifyUpgrade(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/info":
+			_ = json.NewEncoder(w).Encode(Info{ManagerVersion: "5.4.1"})
+		case "/api/nodes":
+			_ = json.NewEncoder(w).Encode(NodeList{Data: []Node{
+				{ID: "node-1", Status: "active"},
+				{ID: "node-2", Status: "offline"},
+			}})
+		case "/api/collections":
+			_ = json.NewEncoder(w).Encode(CollectionList{Data: []Collection{{ID: "col-1"}}})
+		case "/api/collections/col-1/check":
+			_ = json.NewEncoder(w).Encode(CollectionValidation{CollectionID: "col-1", Valid: true})
+		default:
+			t.Errorf("unexpected request path %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:    server.URL + "/api/",
+		httpClient: &http.Client{},
+		userAgent:  "test-agent",
+	}
+
+	v, err := client.VerifyUpgrade(context.Background(), "5.4.0")
+	if err != nil {
+		t.Fatalf("VerifyUpgrade() error = %v", err)
+	}
+
+	if v.Passed {
+		t.Error("VerifyUpgrade() Passed = true, want false (node-2 is offline)")
+	}
+
+	want := map[string]bool{"version": true, "nodes": false, "collections": true}
+	for _, check := range v.Checks {
+		if check.Passed != want[check.Name] {
+			t.Errorf("check %q Passed = %v, want %v (%s)", check.Name, check.Passed, want[check.Name], check.Message)
+		}
+	}
+}
+
+func TestVerifyUpgrade_AllPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		marker := r.URL.Query().Get("marker")
+		switch r.URL.Path {
+		case "/api/info":
+			_ = json.NewEncoder(w).Encode(Info{ManagerVersion: "5.4.1"})
+		case "/api/nodes":
+			if marker == "" {
+				_ = json.NewEncoder(w).Encode(NodeList{Data: []Node{{ID: "node-1", Status: "active"}}, HasNextPage: true, Marker: "m2"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(NodeList{Data: []Node{{ID: "node-2", Status: "offline"}}})
+		case "/api/collections":
+			if marker == "" {
+				_ = json.NewEncoder(w).Encode(CollectionList{Data: []Collection{{ID: "col-1"}, {ID: "col-2"}}, HasNextPage: true, Marker: "m2"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(CollectionList{Data: []Collection{{ID: "col-3"}}})
+		case "/api/collections/col-1/check", "/api/collections/col-2/check":
+			_ = json.NewEncoder(w).Encode(CollectionValidation{Valid: true})
+		case "/api/collections/col-3/check":
+			_ = json.NewEncoder(w).Encode(CollectionValidation{Valid: false})
+		default:
+			t.Errorf("unexpected request path %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:    server.URL + "/api/",
+		httpClient: &http.Client{},
+		userAgent:  "test-agent",
+	}
+
+	v, err := client.VerifyUpgrade(context.Background(), "5.4.0")
+	if err != nil {
+		t.Fatalf("VerifyUpgrade() error = %v", err)
+	}
+
+	want := map[string]string{"nodes": "unhealthy: node-2 (offline)", "collections": "invalid: col-3"}
+	for _, check := range v.Checks {
+		if msg, ok := want[check.Name]; ok && (check.Passed || check.Message != msg) {
+			t.Errorf("check %q = %v, %q, want failed with %q", check.Name, check.Passed, check.Message, msg)
+		}
+	}
+}
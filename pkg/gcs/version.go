@@ -0,0 +1,91 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrUnsupportedVersion indicates the endpoint's GCS Manager API is too
+// old for a request.
+var ErrUnsupportedVersion = errors.New("unsupported by the endpoint's GCS version")
+
+// MinAPIVersionSubscriptionAdminVerified is the first GCS Manager API
+// version with the collection subscription-admin-verified endpoint.
+const MinAPIVersionSubscriptionAdminVerified = "1.23.0"
+
+// APIVersion returns the GCS Manager API version of the endpoint, as
+// reported by GetInfo. The info document is requested once per client.
+func (c *Client) APIVersion(ctx context.Context) (string, error) {
+	c.infoMu.Lock()
+	info := c.info
+	c.infoMu.Unlock()
+	if info != nil {
+		return info.APIVersion, nil
+	}
+
+	info, err := c.GetInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	return info.APIVersion, nil
+}
+
+// recordInfo keeps the info document for APIVersion.
+func (c *Client) recordInfo(info *Info) {
+	c.infoMu.Lock()
+	defer c.infoMu.Unlock()
+	c.info = info
+}
+
+// requireAPIVersion returns an error wrapping ErrUnsupportedVersion if
+// the endpoint's API is older than minVersion. If the version cannot be
+// determined, the request is left to the server.
+func (c *Client) requireAPIVersion(ctx context.Context, feature, minVersion string) error {
+	version, err := c.APIVersion(ctx)
+	if err != nil || version == "" {
+		return nil
+	}
+	if cmp, ok := compareVersions(version, minVersion); !ok || cmp >= 0 {
+		return nil
+	}
+
+	c.infoMu.Lock()
+	manager := c.info.ManagerVersion
+	c.infoMu.Unlock()
+	running := "API " + version
+	if manager != "" {
+		running = fmt.Sprintf("GCS %s (API %s)", manager, version)
+	}
+	return fmt.Errorf("%w: %s requires GCS Manager API %s or later; the endpoint runs %s",
+		ErrUnsupportedVersion, feature, minVersion, running)
+}
+
+// compareVersions compares dotted numeric versions, treating missing
+// components as zero. ok is false if either version is not numeric.
+func compareVersions(a, b string) (cmp int, ok bool) {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := range max(len(as), len(bs)) {
+		var x, y int
+		var err error
+		if i < len(as) {
+			if x, err = strconv.Atoi(as[i]); err != nil {
+				return 0, false
+			}
+		}
+		if i < len(bs) {
+			if y, err = strconv.Atoi(bs[i]); err != nil {
+				return 0, false
+			}
+		}
+		if x != y {
+			if x < y {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	return 0, true
+}
@@ -0,0 +1,93 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		want   int
+		wantOK bool
+	}{
+		{"1.23.0", "1.23.0", 0, true},
+		{"1.23", "1.23.0", 0, true},
+		{"1.9.0", "1.23.0", -1, true},
+		{"1.30.1", "1.23.0", 1, true},
+		{"2", "1.99.99", 1, true},
+		{"1.x", "1.23.0", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := compareVersions(tt.a, tt.b)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("compareVersions(%q, %q) = %d, %v, want %d, %v", tt.a, tt.b, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+// newVersionServer serves an info document with apiVersion and accepts
+// every other request, counting both.
+func newVersionServer(t *testing.T, apiVersion string, infoRequests *atomic.Int32, writes *atomic.Int32) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/info" {
+			infoRequests.Add(1)
+			_, _ = w.Write([]byte(`{"api_version":"` + apiVersion + `","manager_version":"5.4.60"}`))
+			return
+		}
+		writes.Add(1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	return &Client{baseURL: server.URL + "/api/", httpClient: server.Client()}
+}
+
+func TestSetSubscriptionAdminVerified_Version(t *testing.T) {
+	t.Run("supported", func(t *testing.T) {
+		var infoRequests, writes atomic.Int32
+		client := newVersionServer(t, "1.30.0", &infoRequests, &writes)
+
+		for range 2 {
+			if err := client.SetSubscriptionAdminVerified(context.Background(), "c1", true); err != nil {
+				t.Fatalf("SetSubscriptionAdminVerified() error = %v", err)
+			}
+		}
+		if infoRequests.Load() != 1 || writes.Load() != 2 {
+			t.Errorf("got %d info requests and %d writes, want 1 and 2", infoRequests.Load(), writes.Load())
+		}
+	})
+
+	t.Run("too old", func(t *testing.T) {
+		var infoRequests, writes atomic.Int32
+		client := newVersionServer(t, "1.20.0", &infoRequests, &writes)
+
+		err := client.SetSubscriptionAdminVerified(context.Background(), "c1", true)
+		if !errors.Is(err, ErrUnsupportedVersion) {
+			t.Fatalf("SetSubscriptionAdminVerified() error = %v, want ErrUnsupportedVersion", err)
+		}
+		if !strings.Contains(err.Error(), "requires GCS Manager API 1.23.0 or later; the endpoint runs GCS 5.4.60 (API 1.20.0)") {
+			t.Errorf("error = %q, want the required and running versions", err)
+		}
+		if writes.Load() != 0 {
+			t.Errorf("got %d writes, want none", writes.Load())
+		}
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		var infoRequests, writes atomic.Int32
+		client := newVersionServer(t, "", &infoRequests, &writes)
+
+		if err := client.SetSubscriptionAdminVerified(context.Background(), "c1", true); err != nil {
+			t.Fatalf("SetSubscriptionAdminVerified() error = %v", err)
+		}
+		if writes.Load() != 1 {
+			t.Errorf("got %d writes, want the request left to the server", writes.Load())
+		}
+	})
+}
@@ -0,0 +1,377 @@
+// Code generated by gcsapigen from the methods of *gcs.Client; DO NOT EDIT.
+
+package gcs
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// GCSAPI is the GCS Manager API as implemented by *Client. Code that
+// depends on GCSAPI rather than *Client can be tested with the mock
+// client of package gcstest.
+type GCSAPI interface {
+	// APIVersion returns the GCS Manager API version of the endpoint, as
+	// reported by GetInfo. The info document is requested once per client.
+	APIVersion(ctx context.Context) (string, error)
+
+	// AddS3Key adds an S3 IAM key to a credential.
+	AddS3Key(ctx context.Context, credentialID string, key *S3Key) (*UserCredential, error)
+
+	// ApplyRoles creates and deletes roles in bulk.
+	ApplyRoles(ctx context.Context, ops []RoleOp, opts *RoleBatchOptions) (*RoleBatchSummary, error)
+
+	// BatchDeleteCollections deletes multiple collections in a single operation.
+	BatchDeleteCollections(ctx context.Context, collectionIDs []string) (*BatchDeleteResult, error)
+
+	// CheckCollection validates a collection's configuration.
+	CheckCollection(ctx context.Context, collectionID string) (*CollectionValidation, error)
+
+	// CheckEndpointUpgrade checks if an endpoint upgrade is available.
+	CheckEndpointUpgrade(ctx context.Context) (*UpgradeInfo, error)
+
+	// CleanupEndpoint permanently removes the endpoint configuration.
+	CleanupEndpoint(ctx context.Context) error
+
+	// CleanupNode removes a node and its configuration.
+	CleanupNode(ctx context.Context, nodeID string) error
+
+	// Collections returns an iterator over every collection matching opts,
+	// following pagination markers. It starts at the page of opts.Marker, or
+	// the first page if it is empty.
+	Collections(ctx context.Context, opts *ListCollectionsOptions) iter.Seq2[Collection, error]
+
+	// ConvertDeploymentKey converts an old deployment key to a new one.
+	ConvertDeploymentKey(ctx context.Context, oldKey string) (*DeploymentKeyResult, error)
+
+	// CreateActivescaleCredential creates an ActiveScale user credential.
+	CreateActivescaleCredential(ctx context.Context, credential *UserCredential) (*UserCredential, error)
+
+	// CreateAuthPolicy creates a new authentication policy.
+	CreateAuthPolicy(ctx context.Context, policy *AuthPolicy) (*AuthPolicy, error)
+
+	// CreateCollection creates a new collection.
+	CreateCollection(ctx context.Context, collection *Collection) (*Collection, error)
+
+	// CreateCollectionDocument creates a collection from a raw JSON document,
+	// such as one saved from GetCollectionDocument. Unlike CreateCollection,
+	// every field of doc is sent, including false and empty values.
+	CreateCollectionDocument(ctx context.Context, doc map[string]interface{}) (*Collection, error)
+
+	// CreateGuestCollection creates a guest collection sharing part of the
+	// mapped collection guest.MappedCollectionID. guest.CollectionBaseFolder
+	// is the shared path within the mapped collection ("/" for all of it) and
+	// guest.UserCredentialID the credential the guest collection accesses
+	// storage with.
+	CreateGuestCollection(ctx context.Context, guest *Collection) (*Collection, error)
+
+	// CreateNode creates a new node.
+	CreateNode(ctx context.Context, node *Node) (*Node, error)
+
+	// CreateOAuthCredential creates an OAuth2 user credential.
+	CreateOAuthCredential(ctx context.Context, credential *UserCredential) (*UserCredential, error)
+
+	// CreateOIDCServer creates a new OIDC server configuration.
+	CreateOIDCServer(ctx context.Context, server *OIDCServer) (*OIDCServer, error)
+
+	// CreateRole creates a new role assignment.
+	CreateRole(ctx context.Context, role *Role) (*Role, error)
+
+	// CreateS3Credential creates an S3 user credential.
+	CreateS3Credential(ctx context.Context, credential *UserCredential) (*UserCredential, error)
+
+	// CreateSharingPolicy creates a new sharing policy.
+	CreateSharingPolicy(ctx context.Context, policy *SharingPolicy) (*SharingPolicy, error)
+
+	// CreateStorageGateway creates a new storage gateway.
+	CreateStorageGateway(ctx context.Context, gateway *StorageGateway) (*StorageGateway, error)
+
+	// DeleteAuthPolicy deletes an authentication policy.
+	DeleteAuthPolicy(ctx context.Context, policyID string) error
+
+	// DeleteCollection deletes a collection.
+	DeleteCollection(ctx context.Context, collectionID string) error
+
+	// DeleteCollectionDomain removes the custom domain configuration from a collection.
+	DeleteCollectionDomain(ctx context.Context, collectionID string) error
+
+	// DeleteEndpointDomain removes the custom domain configuration from the endpoint.
+	DeleteEndpointDomain(ctx context.Context) error
+
+	// DeleteNode deletes a node.
+	DeleteNode(ctx context.Context, nodeID string) error
+
+	// DeleteOIDCServer deletes the OIDC server configuration.
+	DeleteOIDCServer(ctx context.Context) error
+
+	// DeleteRole deletes a role assignment.
+	DeleteRole(ctx context.Context, roleID string) error
+
+	// DeleteS3Key deletes an S3 IAM key from a credential.
+	DeleteS3Key(ctx context.Context, credentialID string, accessKeyID string) error
+
+	// DeleteSharingPolicy deletes a sharing policy.
+	DeleteSharingPolicy(ctx context.Context, policyID string) error
+
+	// DeleteStorageGateway deletes a storage gateway.
+	DeleteStorageGateway(ctx context.Context, gatewayID string) error
+
+	// DeleteUserCredential deletes a user credential.
+	DeleteUserCredential(ctx context.Context, credentialID string) error
+
+	// DisableCollection takes a collection offline for maintenance without
+	// deleting it.
+	DisableCollection(ctx context.Context, collectionID string, message string) (*AvailabilityChange, error)
+
+	// DisableNode deactivates a node, preventing new transfers.
+	DisableNode(ctx context.Context, nodeID string) error
+
+	// EnableCollection brings a disabled collection back online.
+	EnableCollection(ctx context.Context, collectionID string, previous *AvailabilityChange) (*AvailabilityChange, error)
+
+	// EnableNode activates a node for data transfers.
+	EnableNode(ctx context.Context, nodeID string) error
+
+	// GenerateNodeSecret generates a new authentication secret for a node.
+	GenerateNodeSecret(ctx context.Context, nodeID string) (*NodeSecret, error)
+
+	// GetAuditLogs retrieves audit logs from the GCS Manager API.
+	GetAuditLogs(ctx context.Context, params *AuditQueryParams) (*AuditLogList, error)
+
+	// GetAuthPolicy retrieves a specific authentication policy by ID.
+	GetAuthPolicy(ctx context.Context, policyID string) (*AuthPolicy, error)
+
+	// GetAuthPolicyDocument retrieves an authentication policy as a raw JSON
+	// document.
+	GetAuthPolicyDocument(ctx context.Context, policyID string) (map[string]interface{}, error)
+
+	// GetCollection retrieves a specific collection by ID.
+	GetCollection(ctx context.Context, collectionID string) (*Collection, error)
+
+	// GetCollectionDocument retrieves a collection as a raw JSON document.
+	// include names optional parts of the collection to return as well, such
+	// as IncludePrivatePolicies.
+	GetCollectionDocument(ctx context.Context, collectionID string, include ...string) (map[string]interface{}, error)
+
+	// GetCollectionDomain retrieves the custom domain configuration for a collection.
+	GetCollectionDomain(ctx context.Context, collectionID string) (*DomainConfig, error)
+
+	// GetConditional performs a conditional GET of path and decodes the
+	// response into target.
+	GetConditional(ctx context.Context, path string, v *Validators, target interface{}) error
+
+	// GetEndpoint retrieves the endpoint configuration.
+	GetEndpoint(ctx context.Context) (*Endpoint, error)
+
+	// GetEndpointDomain retrieves the custom domain configuration for the endpoint.
+	GetEndpointDomain(ctx context.Context) (*DomainConfig, error)
+
+	// GetFeatures reports the optional features of the endpoint, combining
+	// its limits (see GetLimits) with its collections, OIDC server, and
+	// custom domain configuration.
+	GetFeatures(ctx context.Context) (*Features, error)
+
+	// GetInfo retrieves the GCS Manager service information.
+	// This endpoint does not require authentication.
+	GetInfo(ctx context.Context) (*Info, error)
+
+	// GetLimits retrieves the endpoint's limits and current usage.
+	GetLimits(ctx context.Context) (*Limits, error)
+
+	// GetNode retrieves a specific node by ID.
+	GetNode(ctx context.Context, nodeID string) (*Node, error)
+
+	// GetOIDCServer retrieves the OIDC server configuration.
+	GetOIDCServer(ctx context.Context) (*OIDCServer, error)
+
+	// GetReleaseNotes retrieves the release notes between two versions.
+	GetReleaseNotes(ctx context.Context, fromVersion string, toVersion string) (*ReleaseNotes, error)
+
+	// GetRole retrieves a specific role by ID.
+	GetRole(ctx context.Context, roleID string) (*Role, error)
+
+	// GetSession retrieves the current CLI authentication session.
+	GetSession(ctx context.Context) (*Session, error)
+
+	// GetSharingPolicy retrieves a specific sharing policy.
+	GetSharingPolicy(ctx context.Context, policyID string) (*SharingPolicy, error)
+
+	// GetStorageGateway retrieves a specific storage gateway by ID.
+	GetStorageGateway(ctx context.Context, gatewayID string) (*StorageGateway, error)
+
+	// GetStorageGatewayDocument retrieves a storage gateway as a raw JSON
+	// document.
+	GetStorageGatewayDocument(ctx context.Context, gatewayID string) (map[string]interface{}, error)
+
+	// GetUpgradeStatus retrieves the progress of a running endpoint upgrade.
+	GetUpgradeStatus(ctx context.Context) (*UpgradeStatus, error)
+
+	// GetUserCredential retrieves a specific user credential.
+	GetUserCredential(ctx context.Context, credentialID string) (*UserCredential, error)
+
+	// ListAllCollections returns every collection matching opts, following
+	// pagination markers from opts.Marker.
+	ListAllCollections(ctx context.Context, opts *ListCollectionsOptions) ([]Collection, error)
+
+	// ListAllNodes returns every node matching opts, following
+	// pagination markers. opts.Marker is ignored.
+	ListAllNodes(ctx context.Context, opts *ListNodesOptions) ([]Node, error)
+
+	// ListAllRoles returns every role matching opts, following
+	// pagination markers. opts.Marker is ignored.
+	ListAllRoles(ctx context.Context, opts *ListRolesOptions) ([]Role, error)
+
+	// ListAllStorageGateways returns every storage gateway matching opts, following
+	// pagination markers. opts.Marker is ignored.
+	ListAllStorageGateways(ctx context.Context, opts *ListStorageGatewaysOptions) ([]StorageGateway, error)
+
+	// ListAuthPolicies retrieves all authentication policies.
+	ListAuthPolicies(ctx context.Context) (*AuthPolicyList, error)
+
+	// ListCollections retrieves a list of collections on the endpoint.
+	ListCollections(ctx context.Context, opts *ListCollectionsOptions) (*CollectionList, error)
+
+	// ListCollectionsForGateway returns every collection that uses the given
+	// storage gateway, following pagination markers.
+	ListCollectionsForGateway(ctx context.Context, gatewayID string) ([]Collection, error)
+
+	// ListNodes retrieves a list of nodes on the endpoint.
+	ListNodes(ctx context.Context, opts *ListNodesOptions) (*NodeList, error)
+
+	// ListRoles retrieves a list of roles on the endpoint.
+	ListRoles(ctx context.Context, opts *ListRolesOptions) (*RoleList, error)
+
+	// ListSharingPolicies retrieves all sharing policies.
+	ListSharingPolicies(ctx context.Context) (*SharingPolicyList, error)
+
+	// ListStorageGateways retrieves a list of storage gateways on the endpoint.
+	ListStorageGateways(ctx context.Context, opts *ListStorageGatewaysOptions) (*StorageGatewayList, error)
+
+	// ListUserCredentials retrieves all user credentials.
+	ListUserCredentials(ctx context.Context) (*UserCredentialList, error)
+
+	// Nodes returns an iterator over every node matching opts,
+	// following pagination markers. opts.Marker is ignored.
+	Nodes(ctx context.Context, opts *ListNodesOptions) iter.Seq2[Node, error]
+
+	// PatchAuthPolicy sends a partial update with exactly the given fields,
+	// like PatchCollection.
+	PatchAuthPolicy(ctx context.Context, policyID string, fields map[string]interface{}) error
+
+	// PatchCollection sends a partial update with exactly the given fields.
+	// Unlike UpdateCollection, false and empty values are sent, and nil
+	// values are sent as null.
+	PatchCollection(ctx context.Context, collectionID string, fields map[string]interface{}) error
+
+	// PatchStorageGateway sends a partial update with exactly the given
+	// fields, like PatchCollection.
+	PatchStorageGateway(ctx context.Context, gatewayID string, fields map[string]interface{}) error
+
+	// RegisterOIDCServer registers an existing OIDC server with the endpoint.
+	RegisterOIDCServer(ctx context.Context, server *OIDCServer) (*OIDCServer, error)
+
+	// ResetCollectionOwnerString resets the owner string to the default.
+	ResetCollectionOwnerString(ctx context.Context, collectionID string) error
+
+	// ResetEndpointOwnerString resets the owner string to the default (ClientID).
+	ResetEndpointOwnerString(ctx context.Context) error
+
+	// ResourceServer returns the Globus Auth resource server of the endpoint,
+	// its endpoint ID. It is known from the start for a client created with
+	// WithResourceServer, after the first request of a client created with
+	// WithResourceServerTokens, and empty otherwise.
+	ResourceServer() string
+
+	// Roles returns an iterator over every role matching opts,
+	// following pagination markers. opts.Marker is ignored.
+	Roles(ctx context.Context, opts *ListRolesOptions) iter.Seq2[Role, error]
+
+	// SetAccessToken sets the access token for authentication.
+	// This can be used to update the token after the client is created.
+	SetAccessToken(token string)
+
+	// SetCollectionOwner designates the owner of a collection.
+	SetCollectionOwner(ctx context.Context, collectionID string, principalURN string) error
+
+	// SetCollectionOwnerString sets a custom display name for the collection owner.
+	SetCollectionOwnerString(ctx context.Context, collectionID string, ownerString string) error
+
+	// SetCollectionUserMessage sets the message (and link) shown to users of a
+	// collection. Empty values clear the message.
+	SetCollectionUserMessage(ctx context.Context, collectionID string, message string, link string) error
+
+	// SetEndpointOwner assigns the endpoint owner role to a specified principal.
+	SetEndpointOwner(ctx context.Context, principalURN string) error
+
+	// SetEndpointOwnerString sets a custom display name for the endpoint owner.
+	SetEndpointOwnerString(ctx context.Context, ownerString string) error
+
+	// SetSubscriptionAdminVerified sets the subscription admin verification status for a collection.
+	SetSubscriptionAdminVerified(ctx context.Context, collectionID string, verified bool) error
+
+	// SetSubscriptionID updates the subscription assignment for the endpoint.
+	SetSubscriptionID(ctx context.Context, subscriptionID string) error
+
+	// SetupCollectionDomain configures a custom domain for a collection.
+	SetupCollectionDomain(ctx context.Context, collectionID string, config *DomainConfig) error
+
+	// SetupEndpoint creates and initializes a new GCS endpoint.
+	SetupEndpoint(ctx context.Context, endpoint *Endpoint) (*Endpoint, error)
+
+	// SetupEndpointDomain configures a custom domain for the endpoint.
+	SetupEndpointDomain(ctx context.Context, config *DomainConfig) error
+
+	// SetupNode configures and initializes a new node.
+	SetupNode(ctx context.Context, node *Node) (*Node, error)
+
+	// StorageGateways returns an iterator over every storage gateway matching opts,
+	// following pagination markers. opts.Marker is ignored.
+	StorageGateways(ctx context.Context, opts *ListStorageGatewaysOptions) iter.Seq2[StorageGateway, error]
+
+	// UpdateAuthPolicy updates an existing authentication policy.
+	UpdateAuthPolicy(ctx context.Context, policyID string, policy *AuthPolicy) (*AuthPolicy, error)
+
+	// UpdateCollection updates an existing collection.
+	UpdateCollection(ctx context.Context, collectionID string, collection *Collection) (*Collection, error)
+
+	// UpdateEndpoint updates the endpoint configuration.
+	UpdateEndpoint(ctx context.Context, endpoint *Endpoint) (*Endpoint, error)
+
+	// UpdateNode updates an existing node.
+	UpdateNode(ctx context.Context, nodeID string, node *Node) (*Node, error)
+
+	// UpdateOIDCServer updates the OIDC server configuration.
+	UpdateOIDCServer(ctx context.Context, server *OIDCServer) (*OIDCServer, error)
+
+	// UpdateRole updates an existing role assignment.
+	UpdateRole(ctx context.Context, roleID string, role *Role) (*Role, error)
+
+	// UpdateS3Key updates an S3 IAM key.
+	UpdateS3Key(ctx context.Context, credentialID string, accessKeyID string, key *S3Key) (*UserCredential, error)
+
+	// UpdateSession updates the current session settings.
+	UpdateSession(ctx context.Context, session *Session) (*Session, error)
+
+	// UpdateSessionConsents updates the consents for the current session.
+	UpdateSessionConsents(ctx context.Context, consents []string) (*Session, error)
+
+	// UpdateStorageGateway updates an existing storage gateway.
+	UpdateStorageGateway(ctx context.Context, gatewayID string, gateway *StorageGateway) (*StorageGateway, error)
+
+	// UpgradeEndpoint upgrades the endpoint to the latest version.
+	UpgradeEndpoint(ctx context.Context) (*UpgradeResult, error)
+
+	// VerifyUpgrade runs post-upgrade checks: the manager version changed from
+	// previousVersion, every node is healthy, and every collection passes
+	// validation. Nodes and collections are listed across all pages, and
+	// collections are checked concurrently.
+	VerifyUpgrade(ctx context.Context, previousVersion string) (*UpgradeVerification, error)
+
+	// WaitForUpgrade polls the endpoint until an upgrade to targetVersion
+	// finishes, calling onProgress whenever the status changes.
+	WaitForUpgrade(ctx context.Context, targetVersion string, interval time.Duration, onProgress func(*UpgradeStatus)) (*UpgradeStatus, error)
+}
+
+// Client implements GCSAPI.
+var _ GCSAPI = (*Client)(nil)
@@ -0,0 +1,81 @@
+package gcsauth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	globusauth "github.com/scttfrdmn/globus-go-sdk/v3/pkg/services/auth"
+)
+
+// ClientCredentials returns a TokenSource that obtains tokens for a
+// confidential Globus Auth client using the client credentials grant.
+//
+// Tokens are cached and a new one is requested shortly before the cached
+// token expires.
+func ClientCredentials(clientID, clientSecret string, scopes ...string) (TokenSource, error) {
+	if clientID == "" || clientSecret == "" {
+		return nil, fmt.Errorf("client ID and client secret are required")
+	}
+
+	client, err := globusauth.NewClient(
+		globusauth.WithClientID(clientID),
+		globusauth.WithClientSecret(clientSecret),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create auth client: %w", err)
+	}
+
+	return &cachingSource{
+		fetch: func(ctx context.Context) (*Token, error) {
+			resp, err := client.GetClientCredentialsToken(ctx, scopes...)
+			if err != nil {
+				return nil, fmt.Errorf("client credentials grant: %w", err)
+			}
+			return fromTokenResponse(resp), nil
+		},
+	}, nil
+}
+
+// cachingSource caches the token returned by fetch until it expires.
+type cachingSource struct {
+	fetch func(ctx context.Context) (*Token, error)
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// Token returns the cached token, fetching a new one if needed.
+func (s *cachingSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.Valid() {
+		return s.token, nil
+	}
+
+	token, err := s.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.token = token
+	return token, nil
+}
+
+// fromTokenResponse converts a Globus Auth token response to a Token.
+func fromTokenResponse(resp *globusauth.TokenResponse) *Token {
+	token := &Token{
+		AccessToken:    resp.AccessToken,
+		RefreshToken:   resp.RefreshToken,
+		ResourceServer: resp.ResourceServer,
+	}
+	if resp.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+	}
+	if resp.Scope != "" {
+		token.Scopes = strings.Fields(resp.Scope)
+	}
+	return token
+}
@@ -0,0 +1,62 @@
+package gcsauth_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcsauth"
+)
+
+func ExampleNewClient() {
+	ctx := context.Background()
+
+	client, err := gcsauth.NewClient(ctx, "abc.def.data.globus.org",
+		gcsauth.StaticToken(os.Getenv("GCS_ACCESS_TOKEN")),
+		gcs.WithUserAgent("my-service/1.0"),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_ = client
+}
+
+func ExampleClientCredentials() {
+	ctx := context.Background()
+
+	// The scope is the GCS Manager API scope of the target endpoint.
+	src, err := gcsauth.ClientCredentials(
+		os.Getenv("GLOBUS_CLIENT_ID"),
+		os.Getenv("GLOBUS_CLIENT_SECRET"),
+		"urn:globus:auth:scope:abc.def.data.globus.org:manage_collections",
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := gcsauth.NewClient(ctx, "abc.def.data.globus.org", src)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	endpoint, err := client.GetEndpoint(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(endpoint.DisplayName)
+}
+
+func ExampleProfileToken() {
+	ctx := context.Background()
+
+	// Reuse the tokens saved by 'globus-connect-server login --profile production'.
+	client, err := gcsauth.NewClient(ctx, "abc.def.data.globus.org", gcsauth.ProfileToken("production"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_ = client
+}
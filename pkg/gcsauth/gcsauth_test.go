@@ -0,0 +1,179 @@
+package gcsauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/gcs"
+	globusauth "github.com/scttfrdmn/globus-go-sdk/v3/pkg/services/auth"
+)
+
+func TestToken_Valid(t *testing.T) {
+	tests := []struct {
+		name  string
+		token *Token
+		want  bool
+	}{
+		{name: "nil", token: nil, want: false},
+		{name: "empty access token", token: &Token{}, want: false},
+		{name: "no expiry", token: &Token{AccessToken: "abc"}, want: true},
+		{name: "valid", token: &Token{AccessToken: "abc", ExpiresAt: time.Now().Add(time.Hour)}, want: true},
+		{name: "expired", token: &Token{AccessToken: "abc", ExpiresAt: time.Now().Add(-time.Hour)}, want: false},
+		{name: "expires soon", token: &Token{AccessToken: "abc", ExpiresAt: time.Now().Add(time.Minute)}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.token.Valid(); got != tt.want {
+				t.Errorf("Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStaticToken(t *testing.T) {
+	token, err := StaticToken("abc").Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if token.AccessToken != "abc" {
+		t.Errorf("AccessToken = %q, want %q", token.AccessToken, "abc")
+	}
+}
+
+func TestProfileToken_NotLoggedIn(t *testing.T) {
+	t.Setenv("GLOBUS_CONNECT_SERVER_CONFIG_DIR", t.TempDir())
+
+	if _, err := ProfileToken("nonexistent-profile-test").Token(context.Background()); err == nil {
+		t.Error("Token() expected error for nonexistent profile, got nil")
+	}
+}
+
+func TestCachingSource(t *testing.T) {
+	calls := 0
+	src := &cachingSource{
+		fetch: func(_ context.Context) (*Token, error) {
+			calls++
+			return &Token{AccessToken: "abc", ExpiresAt: time.Now().Add(time.Hour)}, nil
+		},
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := src.Token(context.Background()); err != nil {
+			t.Fatalf("Token() error = %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fetch called %d times, want 1", calls)
+	}
+
+	// An expired token is replaced
+	src.token.ExpiresAt = time.Now().Add(-time.Minute)
+	if _, err := src.Token(context.Background()); err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("fetch called %d times, want 2", calls)
+	}
+}
+
+func TestCachingSource_Error(t *testing.T) {
+	wantErr := errors.New("boom")
+	src := &cachingSource{
+		fetch: func(_ context.Context) (*Token, error) {
+			return nil, wantErr
+		},
+	}
+
+	if _, err := src.Token(context.Background()); !errors.Is(err, wantErr) {
+		t.Errorf("Token() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestClientCredentials_MissingCredentials(t *testing.T) {
+	if _, err := ClientCredentials("", "secret"); err == nil {
+		t.Error("ClientCredentials() expected error for empty client ID, got nil")
+	}
+	if _, err := ClientCredentials("id", ""); err == nil {
+		t.Error("ClientCredentials() expected error for empty client secret, got nil")
+	}
+}
+
+func TestFromTokenResponse(t *testing.T) {
+	token := fromTokenResponse(&globusauth.TokenResponse{
+		AccessToken:    "abc",
+		ExpiresIn:      3600,
+		ResourceServer: "example.data.globus.org",
+		Scope:          "scope-a scope-b",
+	})
+
+	if token.AccessToken != "abc" {
+		t.Errorf("AccessToken = %q, want %q", token.AccessToken, "abc")
+	}
+	if len(token.Scopes) != 2 {
+		t.Errorf("Scopes = %v, want 2 scopes", token.Scopes)
+	}
+	if !token.Valid() {
+		t.Error("Valid() = false, want true")
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	client, err := NewClient(context.Background(), "example.data.globus.org", StaticToken("abc"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client == nil {
+		t.Fatal("NewClient() returned nil client")
+	}
+}
+
+func TestNewClient_TokenPerRequest(t *testing.T) {
+	var auths []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auths = append(auths, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	calls := 0
+	src := TokenSourceFunc(func(_ context.Context) (*Token, error) {
+		calls++
+		return &Token{AccessToken: fmt.Sprintf("token-%d", calls)}, nil
+	})
+	client, err := NewClient(context.Background(), strings.TrimPrefix(server.URL, "https://"), src,
+		gcs.WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	for range 2 {
+		if _, err := client.GetEndpoint(context.Background()); err != nil {
+			t.Fatalf("GetEndpoint() error = %v", err)
+		}
+	}
+
+	if want := []string{"Bearer token-2", "Bearer token-3"}; strings.Join(auths, ",") != strings.Join(want, ",") {
+		t.Errorf("Authorization = %q, want %q", auths, want)
+	}
+}
+
+func TestNewClient_NilSource(t *testing.T) {
+	if _, err := NewClient(context.Background(), "example.data.globus.org", nil); err == nil {
+		t.Error("NewClient() expected error for nil token source, got nil")
+	}
+}
+
+func TestNewClient_SourceError(t *testing.T) {
+	src := TokenSourceFunc(func(_ context.Context) (*Token, error) {
+		return nil, errors.New("boom")
+	})
+	if _, err := NewClient(context.Background(), "example.data.globus.org", src); err == nil {
+		t.Error("NewClient() expected error from token source, got nil")
+	}
+}
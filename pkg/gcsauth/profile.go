@@ -0,0 +1,36 @@
+package gcsauth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/scttfrdmn/globus-go-gcs/internal/auth"
+)
+
+// ProfileToken returns a TokenSource that reads the tokens stored by the
+// CLI's 'login' command for profile.
+//
+// Tokens are read from the CLI configuration directory
+// (~/.globus-connect-server, or $GLOBUS_CONNECT_SERVER_CONFIG_DIR) on every
+// call, so a re-login is picked up without restarting the program. An
+// expired token yields an error wrapping ErrTokenExpired.
+func ProfileToken(profile string) TokenSource {
+	return TokenSourceFunc(func(_ context.Context) (*Token, error) {
+		stored, err := auth.LoadToken(profile)
+		if err != nil {
+			return nil, err
+		}
+
+		token := &Token{
+			AccessToken:    stored.AccessToken,
+			RefreshToken:   stored.RefreshToken,
+			ExpiresAt:      stored.ExpiresAt,
+			Scopes:         stored.Scopes,
+			ResourceServer: stored.ResourceServer,
+		}
+		if !token.Valid() {
+			return nil, fmt.Errorf("profile %q: %w (run 'globus-connect-server login')", profile, ErrTokenExpired)
+		}
+		return token, nil
+	})
+}
@@ -0,0 +1,123 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Annotation levels for PrintAnnotations.
+const (
+	AnnotationError   = "error"
+	AnnotationWarning = "warning"
+	AnnotationNotice  = "notice"
+)
+
+// Annotation is a finding reported by a check command. In github-actions
+// format it is written as a workflow command, which GitHub shows on the
+// run and, when File is set, on the matching line of a pull request. In
+// SARIF format it is a result for code-scanning dashboards.
+type Annotation struct {
+	Level   string
+	Title   string
+	Message string
+
+	// Rule identifies the kind of finding, e.g. a validation error code.
+	// It is the SARIF rule ID.
+	Rule string
+
+	// File and Line locate the finding in the repository, e.g. in a
+	// manifest. Both are optional.
+	File string
+	Line int
+}
+
+// PrintAnnotations outputs annotations as GitHub Actions workflow commands
+// or a SARIF log.
+//
+// If the formatter is set to github-actions format, writes one
+// "::error ...::", "::warning ...::", or "::notice ...::" line per
+// annotation. In SARIF format, writes a SARIF 2.1.0 log with a result per
+// annotation. Otherwise, does nothing.
+func (f *Formatter) PrintAnnotations(annotations []Annotation) error {
+	switch f.format {
+	case FormatGitHubActions:
+	case FormatSARIF:
+		return f.printSARIF(annotations)
+	default:
+		return nil
+	}
+
+	for _, a := range annotations {
+		level := a.Level
+		if level == "" {
+			level = AnnotationNotice
+		}
+
+		var props []string
+		if a.File != "" {
+			props = append(props, "file="+escapeProperty(a.File))
+			if a.Line > 0 {
+				props = append(props, fmt.Sprintf("line=%d", a.Line))
+			}
+		}
+		if a.Title != "" {
+			props = append(props, "title="+escapeProperty(a.Title))
+		}
+
+		command := "::" + level
+		if len(props) > 0 {
+			command += " " + strings.Join(props, ",")
+		}
+		if _, err := fmt.Fprintf(f.writer, "%s::%s\n", command, escapeData(a.Message)); err != nil {
+			return fmt.Errorf("write annotation: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// WriteStepSummary appends markdown to the job summary of a GitHub Actions
+// step.
+//
+// If the formatter is set to github-actions format and the runner sets
+// GITHUB_STEP_SUMMARY, appends markdown to that file. Otherwise, does
+// nothing.
+func (f *Formatter) WriteStepSummary(markdown string) error {
+	if f.format != FormatGitHubActions {
+		return nil
+	}
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open step summary: %w", err)
+	}
+	if _, err := file.WriteString(markdown); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("write step summary: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("write step summary: %w", err)
+	}
+
+	return nil
+}
+
+// escapeData escapes a workflow command message.
+func escapeData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+// escapeProperty escapes a workflow command property value, which also
+// may not contain the property separators.
+func escapeProperty(s string) string {
+	s = escapeData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return strings.ReplaceAll(s, ",", "%2C")
+}
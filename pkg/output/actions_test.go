@@ -0,0 +1,112 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFormatter_PrintAnnotations(t *testing.T) {
+	buf := &bytes.Buffer{}
+	formatter := NewFormatter(FormatGitHubActions, buf)
+
+	err := formatter.PrintAnnotations([]Annotation{
+		{Level: AnnotationError, Title: "gateway a: MISSING_ROOT", Message: "no root\npath"},
+		{Level: AnnotationWarning, Message: "100% open", File: "gcs/collections.yaml", Line: 12},
+		{Message: "checked"},
+	})
+	if err != nil {
+		t.Fatalf("PrintAnnotations() error = %v", err)
+	}
+
+	want := "::error title=gateway a%3A MISSING_ROOT::no root%0Apath\n" +
+		"::warning file=gcs/collections.yaml,line=12::100%25 open\n" +
+		"::notice::checked\n"
+	if got := buf.String(); got != want {
+		t.Errorf("PrintAnnotations() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestFormatter_GitHubActionsSuppressesText(t *testing.T) {
+	buf := &bytes.Buffer{}
+	formatter := NewFormatter(FormatGitHubActions, buf)
+
+	if err := formatter.PrintText("hello %s\n", "world"); err != nil {
+		t.Fatal(err)
+	}
+	if err := formatter.PrintData(map[string]string{"a": "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("output = %q, want none", buf.String())
+	}
+
+	// Annotations are only written in github-actions format
+	text := NewFormatter(FormatText, buf)
+	if err := text.PrintAnnotations([]Annotation{{Message: "x"}}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("text output = %q, want none", buf.String())
+	}
+}
+
+func TestFormatter_WriteStepSummary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.md")
+	t.Setenv("GITHUB_STEP_SUMMARY", path)
+
+	formatter := NewFormatter(FormatGitHubActions, &bytes.Buffer{})
+	for _, md := range []string{"### One\n", "### Two\n"} {
+		if err := formatter.WriteStepSummary(md); err != nil {
+			t.Fatalf("WriteStepSummary() error = %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), "### One\n### Two\n"; got != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+}
+
+func TestFormatter_PrintAnnotations_SARIF(t *testing.T) {
+	buf := &bytes.Buffer{}
+	formatter := NewFormatter(FormatSARIF, buf)
+	if !formatter.IsAnnotated() {
+		t.Fatal("IsAnnotated() = false for SARIF")
+	}
+
+	err := formatter.PrintAnnotations([]Annotation{
+		{Level: AnnotationError, Rule: "MISSING_ROOT", Title: "gateway a", Message: "no root path"},
+		{Level: AnnotationWarning, Rule: "NO_RESTRICT_PATHS", Message: "open", File: "gcs.yaml", Line: 3},
+		{Level: AnnotationError, Rule: "MISSING_ROOT", Message: "no root path"},
+	})
+	if err != nil {
+		t.Fatalf("PrintAnnotations() error = %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("output is not JSON: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("log = %+v, want one 2.1.0 run", log)
+	}
+	run := log.Runs[0]
+	if got := len(run.Tool.Driver.Rules); got != 2 {
+		t.Errorf("rules = %d, want 2 (deduplicated)", got)
+	}
+	if got := len(run.Results); got != 3 {
+		t.Fatalf("results = %d, want 3", got)
+	}
+	if r := run.Results[0]; r.RuleID != "MISSING_ROOT" || r.Level != "error" || r.Message.Text != "gateway a: no root path" {
+		t.Errorf("results[0] = %+v", r)
+	}
+	if r := run.Results[1]; len(r.Locations) != 1 || r.Locations[0].PhysicalLocation.Region.StartLine != 3 {
+		t.Errorf("results[1] locations = %+v, want gcs.yaml line 3", r.Locations)
+	}
+}
@@ -0,0 +1,63 @@
+package output
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// ANSI SGR sequences used in colored text output.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiDim    = "\x1b[2m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+)
+
+// WithColor turns colored text output on or off, overriding the default
+// (see ColorDefault).
+func WithColor(enabled bool) Option {
+	return func(f *Formatter) {
+		f.color = enabled
+	}
+}
+
+// ColorDefault reports whether text written to w is colored by default:
+// w must be a terminal, NO_COLOR (https://no-color.org) must be unset or
+// empty, and TERM must not be "dumb".
+func ColorDefault(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(file.Fd())) {
+		return false
+	}
+	return os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb"
+}
+
+// colorize wraps s in the SGR sequence code if the formatter writes
+// colored text.
+func (f *Formatter) colorize(code, s string) string {
+	if !f.color || !f.writesText() || s == "" {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+// Heading returns s styled as a heading: bold when color is on, otherwise
+// unchanged. The other styling helpers work the same way, so commands can
+// always pass their text through them.
+func (f *Formatter) Heading(s string) string { return f.colorize(ansiBold, s) }
+
+// Success returns s in green, for passed checks and completed operations.
+func (f *Formatter) Success(s string) string { return f.colorize(ansiGreen, s) }
+
+// Warning returns s in yellow, for warnings.
+func (f *Formatter) Warning(s string) string { return f.colorize(ansiYellow, s) }
+
+// Failure returns s in red, for errors and failed checks.
+func (f *Formatter) Failure(s string) string { return f.colorize(ansiRed, s) }
+
+// Dim returns s dimmed, for secondary fields such as IDs next to names.
+func (f *Formatter) Dim(s string) string { return f.colorize(ansiDim, s) }
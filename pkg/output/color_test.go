@@ -0,0 +1,45 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFormatter_Color(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	// A buffer is not a terminal, so color is off by default
+	if got := NewFormatter(FormatText, buf).Success("ok"); got != "ok" {
+		t.Errorf("Success() = %q without color, want plain text", got)
+	}
+
+	colored := NewFormatter(FormatText, buf, WithColor(true))
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"Heading", colored.Heading("Results"), "\x1b[1mResults\x1b[0m"},
+		{"Success", colored.Success("ok"), "\x1b[32mok\x1b[0m"},
+		{"Warning", colored.Warning("warn"), "\x1b[33mwarn\x1b[0m"},
+		{"Failure", colored.Failure("fail"), "\x1b[31mfail\x1b[0m"},
+		{"Dim", colored.Dim("id"), "\x1b[2mid\x1b[0m"},
+		{"empty", colored.Success(""), ""},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// Structured output is never colored
+	if got := NewFormatter(FormatJSON, buf, WithColor(true)).Failure("fail"); got != "fail" {
+		t.Errorf("Failure() in JSON format = %q, want plain text", got)
+	}
+}
+
+func TestColorDefault_NotTerminal(t *testing.T) {
+	if ColorDefault(&bytes.Buffer{}) {
+		t.Error("ColorDefault() = true for a buffer")
+	}
+}
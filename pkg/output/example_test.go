@@ -0,0 +1,49 @@
+package output_test
+
+import (
+	"log"
+	"os"
+
+	"github.com/scttfrdmn/globus-go-gcs/pkg/output"
+)
+
+func ExampleFormatter_PrintJSON() {
+	formatter := output.NewFormatter(output.FormatJSON, os.Stdout)
+
+	data := map[string]string{"id": "col-1", "display_name": "Project Data"}
+	if err := formatter.PrintJSON(data); err != nil {
+		log.Fatal(err)
+	}
+	// Output:
+	// {
+	//   "display_name": "Project Data",
+	//   "id": "col-1"
+	// }
+}
+
+func ExampleFormatter_PrintText() {
+	formatter := output.NewFormatter(output.FormatText, os.Stdout)
+
+	if err := formatter.PrintText("%-20s%s\n", "ID:", "col-1"); err != nil {
+		log.Fatal(err)
+	}
+	if err := formatter.PrintText("%-20s%s\n", "Display Name:", "Project Data"); err != nil {
+		log.Fatal(err)
+	}
+	// Output:
+	// ID:                 col-1
+	// Display Name:       Project Data
+}
+
+func ExampleWriterSink() {
+	sink := output.NewWriterSink(os.Stdout, output.FormatJSON)
+
+	for _, status := range []string{"ACTIVE", "SUCCEEDED"} {
+		if err := sink.Emit(map[string]string{"task_id": "task-1", "status": status}); err != nil {
+			log.Fatal(err)
+		}
+	}
+	// Output:
+	// {"status":"ACTIVE","task_id":"task-1"}
+	// {"status":"SUCCEEDED","task_id":"task-1"}
+}
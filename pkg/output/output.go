@@ -3,11 +3,28 @@
 // Supports multiple output formats:
 //   - text: Human-readable table format (default)
 //   - json: Machine-readable JSON format
+//   - yaml: The same document as json, written as YAML
+//   - table: Aligned columns with headers for list commands (see Table);
+//     other output is printed as text
+//   - wide: Tables with every column, including those table format
+//     leaves out
+//   - csv: The same tables as comma-separated values
+//   - template: Each item rendered through a text/template (see
+//     PrintTemplate)
+//   - github-actions: Check results as GitHub Actions workflow commands
+//     and a job summary (see Annotation)
+//   - sarif: Check results as a SARIF log for code-scanning dashboards
 //
 // Example usage:
 //
 //	formatter := output.NewFormatter(format, os.Stdout)
 //	formatter.PrintJSON(data)
+//
+// Long-running modes that emit a stream of records instead of a single
+// document write them to a Sink (see WriterSink, RotatingFileSink, and
+// SyslogSink).
+//
+// This package is a supported public API and follows semantic versioning.
 package output
 
 import (
@@ -25,12 +42,67 @@ const (
 
 	// FormatJSON is machine-readable JSON output.
 	FormatJSON Format = "json"
+
+	// FormatYAML is the JSON document written as YAML.
+	FormatYAML Format = "yaml"
+
+	// FormatTable is tabular output for lists. Commands without tabular
+	// output print text instead.
+	FormatTable Format = "table"
+
+	// FormatWide is table format with all columns, including the wide
+	// columns of a Table.
+	FormatWide Format = "wide"
+
+	// FormatCSV is tabular output for lists as comma-separated values.
+	FormatCSV Format = "csv"
+
+	// FormatTemplate renders the command's data through a text/template
+	// set with WithTemplate.
+	FormatTemplate Format = "template"
+
+	// FormatGitHubActions reports check results as GitHub Actions workflow
+	// commands. Commands without check results print nothing.
+	FormatGitHubActions Format = "github-actions"
+
+	// FormatSARIF reports check results as a SARIF 2.1.0 log. Commands
+	// without check results print nothing.
+	FormatSARIF Format = "sarif"
 )
 
+// IsTabular reports whether the format renders lists from a Table.
+func (f Format) IsTabular() bool {
+	return f == FormatTable || f == FormatWide || f == FormatCSV
+}
+
+// Option configures a Formatter.
+type Option func(*Formatter)
+
+// WithColumns limits tables to the named columns, in the given order. See
+// Formatter.PrintTable for how names are matched.
+func WithColumns(columns []string) Option {
+	return func(f *Formatter) {
+		f.columns = columns
+	}
+}
+
+// WithQuiet makes the formatter print only resource IDs, one per line,
+// for scripts (see PrintIDs). All other output is suppressed.
+func WithQuiet(quiet bool) Option {
+	return func(f *Formatter) {
+		f.quiet = quiet
+	}
+}
+
 // Formatter handles output formatting for different formats.
 type Formatter struct {
-	format Format
-	writer io.Writer
+	format   Format
+	writer   io.Writer
+	quiet    bool
+	columns  []string
+	template string
+	query    string
+	color    bool
 }
 
 // NewFormatter creates a new output formatter.
@@ -38,11 +110,18 @@ type Formatter struct {
 // Parameters:
 //   - format: Output format (text or json)
 //   - writer: Destination for output (typically os.Stdout)
-func NewFormatter(format Format, writer io.Writer) *Formatter {
-	return &Formatter{
+//   - opts: Optional settings such as WithColumns, WithQuiet,
+//     WithTemplate, WithQuery, and WithColor
+func NewFormatter(format Format, writer io.Writer, opts ...Option) *Formatter {
+	f := &Formatter{
 		format: format,
 		writer: writer,
+		color:  ColorDefault(writer),
+	}
+	for _, opt := range opts {
+		opt(f)
 	}
+	return f
 }
 
 // PrintJSON outputs data in JSON format.
@@ -66,10 +145,10 @@ func (f *Formatter) PrintJSON(data interface{}) error {
 
 // PrintText outputs a text message.
 //
-// If the formatter is set to text format, outputs the message.
+// If the formatter is set to text, table, or CSV format, outputs the message.
 // Otherwise, does nothing (JSON format should use PrintJSON instead).
 func (f *Formatter) PrintText(format string, args ...interface{}) error {
-	if f.format != FormatText {
+	if !f.writesText() {
 		return nil
 	}
 
@@ -82,10 +161,10 @@ func (f *Formatter) PrintText(format string, args ...interface{}) error {
 
 // Println outputs a text line.
 //
-// If the formatter is set to text format, outputs the line with newline.
-// Otherwise, does nothing (JSON format should use PrintJSON instead).
+// If the formatter is set to text, table, or CSV format, outputs the line
+// with newline. Otherwise, does nothing (JSON format should use PrintJSON instead).
 func (f *Formatter) Println(args ...interface{}) error {
-	if f.format != FormatText {
+	if !f.writesText() {
 		return nil
 	}
 
@@ -101,10 +180,20 @@ func (f *Formatter) Println(args ...interface{}) error {
 // This is a convenience method that automatically chooses PrintJSON or PrintText
 // based on the formatter's format setting.
 func (f *Formatter) Print(data interface{}) error {
+	if f.quiet {
+		return nil
+	}
+
 	switch f.format {
 	case FormatJSON:
 		return f.PrintJSON(data)
-	case FormatText:
+	case FormatYAML:
+		return f.PrintYAML(data)
+	case FormatTemplate:
+		return f.PrintTemplate(data)
+	case FormatGitHubActions, FormatSARIF:
+		return nil
+	case FormatText, FormatTable, FormatWide, FormatCSV:
 		// For text format, try to convert to string
 		return f.PrintText("%v\n", data)
 	default:
@@ -112,11 +201,82 @@ func (f *Formatter) Print(data interface{}) error {
 	}
 }
 
+// PrintIDs outputs resource IDs, one per line, skipping empty ones.
+//
+// If the formatter is quiet (see WithQuiet), writes the IDs. Otherwise,
+// does nothing.
+func (f *Formatter) PrintIDs(ids ...string) error {
+	if !f.quiet {
+		return nil
+	}
+
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		if _, err := fmt.Fprintln(f.writer, id); err != nil {
+			return fmt.Errorf("write ID: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // GetFormat returns the current output format.
 func (f *Formatter) GetFormat() Format {
 	return f.format
 }
 
+// PrintData outputs data as a JSON or YAML document, or through the
+// template, whichever the formatter is set to. It does nothing in text and
+// table formats. A query set with WithQuery filters JSON and YAML output;
+// it cannot be combined with a template.
+//
+// Commands print their structured output with PrintData when IsStructured
+// reports true.
+func (f *Formatter) PrintData(data interface{}) error {
+	if f.query != "" && f.IsStructured() {
+		if f.format == FormatTemplate {
+			return fmt.Errorf("--query cannot be combined with --template")
+		}
+		query, err := ParseQuery(f.query)
+		if err != nil {
+			return err
+		}
+		if data, err = query.Apply(data); err != nil {
+			return err
+		}
+	}
+
+	switch f.format {
+	case FormatJSON:
+		return f.PrintJSON(data)
+	case FormatYAML:
+		return f.PrintYAML(data)
+	case FormatTemplate:
+		return f.PrintTemplate(data)
+	default:
+		return nil
+	}
+}
+
+// IsStructured returns true if the formatter prints the command's data
+// (JSON, YAML, or template format) rather than text.
+func (f *Formatter) IsStructured() bool {
+	return !f.quiet && (f.format == FormatJSON || f.format == FormatYAML || f.format == FormatTemplate)
+}
+
+// IsQuiet returns true if the formatter prints only resource IDs. Commands
+// print their IDs with PrintIDs when it reports true.
+func (f *Formatter) IsQuiet() bool {
+	return f.quiet
+}
+
+// IsYAML returns true if the formatter is set to YAML format.
+func (f *Formatter) IsYAML() bool {
+	return f.format == FormatYAML
+}
+
 // IsJSON returns true if the formatter is set to JSON format.
 func (f *Formatter) IsJSON() bool {
 	return f.format == FormatJSON
@@ -126,3 +286,33 @@ func (f *Formatter) IsJSON() bool {
 func (f *Formatter) IsText() bool {
 	return f.format == FormatText
 }
+
+// IsTable returns true if the formatter is set to table format.
+func (f *Formatter) IsTable() bool {
+	return f.format == FormatTable
+}
+
+// IsGitHubActions returns true if the formatter is set to github-actions
+// format.
+func (f *Formatter) IsGitHubActions() bool {
+	return f.format == FormatGitHubActions
+}
+
+// IsAnnotated returns true if the formatter is set to a format that
+// reports findings (github-actions or SARIF). Check commands report their
+// findings with PrintAnnotations when it reports true.
+func (f *Formatter) IsAnnotated() bool {
+	return f.format == FormatGitHubActions || f.format == FormatSARIF
+}
+
+// IsTabular returns true if the formatter is set to table, wide, or CSV
+// format.
+// Commands print their lists with PrintTable when it reports true.
+func (f *Formatter) IsTabular() bool {
+	return !f.quiet && f.format.IsTabular()
+}
+
+// writesText reports whether PrintText and Println produce output.
+func (f *Formatter) writesText() bool {
+	return !f.quiet && (f.format == FormatText || f.format.IsTabular())
+}
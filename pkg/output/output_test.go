@@ -306,3 +306,29 @@ func TestFormatter_IsText(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatter_PrintIDs(t *testing.T) {
+	buf := &bytes.Buffer{}
+	formatter := NewFormatter(FormatText, buf, WithQuiet(true))
+
+	if !formatter.IsQuiet() || formatter.IsStructured() || formatter.IsTabular() {
+		t.Fatal("quiet formatter should only print IDs")
+	}
+	if err := formatter.Println("Roles (2):"); err != nil {
+		t.Fatal(err)
+	}
+	if err := formatter.PrintIDs("role-1", "", "role-2"); err != nil {
+		t.Fatalf("PrintIDs() error = %v", err)
+	}
+	if got, want := buf.String(), "role-1\nrole-2\n"; got != want {
+		t.Errorf("PrintIDs() output = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	if err := NewFormatter(FormatText, buf).PrintIDs("role-1"); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("PrintIDs() without WithQuiet wrote %q", buf.String())
+	}
+}
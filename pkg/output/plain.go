@@ -0,0 +1,112 @@
+package output
+
+import (
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Style is the presentation style of text output.
+type Style string
+
+const (
+	// StyleDefault is the standard aligned text layout.
+	StyleDefault Style = "default"
+
+	// StylePlain is a layout for screen readers and other assistive
+	// technology: no color, box-drawing characters, separator lines, or
+	// alignment padding. Values are written as "key: value" lines and
+	// table rows as markdown-style "- " list items.
+	StylePlain Style = "plain"
+)
+
+var (
+	// ansiEscape matches ANSI color and cursor escape sequences.
+	ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+	// paddedLabel matches a "Label:" followed by alignment padding.
+	paddedLabel = regexp.MustCompile(`^([^:]*\S):\s{2,}`)
+
+	// padding matches runs of spaces used to align columns.
+	padding = regexp.MustCompile(`\s{2,}`)
+)
+
+// boxDrawing replaces box-drawing characters with spaces.
+var boxDrawing = strings.NewReplacer(
+	"│", " ", "┃", " ", "║", " ",
+	"─", " ", "━", " ", "═", " ",
+	"┌", " ", "┐", " ", "└", " ", "┘", " ",
+	"├", " ", "┤", " ", "┬", " ", "┴", " ", "┼", " ",
+	"╔", " ", "╗", " ", "╚", " ", "╝", " ",
+)
+
+// plainWriter rewrites aligned text output in StylePlain.
+type plainWriter struct {
+	w io.Writer
+}
+
+// NewPlainWriter returns a writer that rewrites the text written to it in
+// StylePlain before passing it to w. Each Write is rewritten line by line,
+// so callers should write whole lines, as Formatter.PrintText and
+// Formatter.Println do. JSON output should not be written through it.
+func NewPlainWriter(w io.Writer) io.Writer {
+	return &plainWriter{w: w}
+}
+
+// Write rewrites p and writes it to the underlying writer. It reports
+// len(p) bytes written on success, since the rewritten text is usually
+// shorter than p.
+func (pw *plainWriter) Write(p []byte) (int, error) {
+	lines := strings.SplitAfter(string(p), "\n")
+
+	var b strings.Builder
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		text, newline := strings.CutSuffix(line, "\n")
+		text, keep := plainLine(text)
+		if !keep {
+			continue
+		}
+		b.WriteString(text)
+		if newline {
+			b.WriteByte('\n')
+		}
+	}
+
+	if _, err := io.WriteString(pw.w, b.String()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// plainLine rewrites one line of aligned text output in StylePlain. It
+// reports false for lines that carry no content, such as separator rules,
+// which should be dropped. Empty lines are kept.
+//
+//	"ID:                 col-1"        -> "ID: col-1"
+//	"  Display Name:     Project Data" -> "Display Name: Project Data"
+//	"  col-1    Project Data    mapped" -> "- col-1, Project Data, mapped"
+//	"================================" -> dropped
+func plainLine(line string) (string, bool) {
+	line = ansiEscape.ReplaceAllString(line, "")
+	if strings.TrimSpace(line) == "" {
+		return "", true
+	}
+
+	line = boxDrawing.Replace(line)
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.Trim(trimmed, "=-_*~+ ") == "" {
+		return "", false
+	}
+
+	indented := strings.HasPrefix(line, "  ")
+	if paddedLabel.MatchString(trimmed) {
+		return paddedLabel.ReplaceAllString(trimmed, "$1: "), true
+	}
+	if indented && padding.MatchString(trimmed) {
+		return "- " + padding.ReplaceAllString(trimmed, ", "), true
+	}
+	return padding.ReplaceAllString(trimmed, " "), true
+}
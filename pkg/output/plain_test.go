@@ -0,0 +1,47 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPlainLine(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		keep bool
+	}{
+		{"ID:                 col-1", "ID: col-1", true},
+		{"  Display Name:     Project Data", "Display Name: Project Data", true},
+		{"Time:               12:30:00", "Time: 12:30:00", true},
+		{"  col-1    Project Data    mapped", "- col-1, Project Data, mapped", true},
+		{"Collections (2):", "Collections (2):", true},
+		{"\x1b[31mfailed\x1b[0m", "failed", true},
+		{"│ a │ b │", "- a, b", true},
+		{"========================", "", false},
+		{"────────────", "", false},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, keep := plainLine(tt.in)
+		if got != tt.want || keep != tt.keep {
+			t.Errorf("plainLine(%q) = %q, %v; want %q, %v", tt.in, got, keep, tt.want, tt.keep)
+		}
+	}
+}
+
+func TestPlainWriter(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewFormatter(FormatText, NewPlainWriter(&buf))
+
+	_ = formatter.PrintText("%-20s%s\n", "ID:", "col-1")
+	_ = formatter.Println("=====")
+	_ = formatter.Println()
+	_ = formatter.PrintText("  %-10s %-10s %s\n", "gw-1", "POSIX", "ready")
+
+	want := "ID: col-1\n\n- gw-1, POSIX, ready\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
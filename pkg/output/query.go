@@ -0,0 +1,63 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jmespath/go-jmespath"
+)
+
+// WithQuery sets a JMESPath expression that JSON and YAML output is
+// filtered through (see Query).
+func WithQuery(expression string) Option {
+	return func(f *Formatter) {
+		f.query = expression
+	}
+}
+
+// Query is a compiled JMESPath expression (https://jmespath.org/specification.html),
+// evaluated with github.com/jmespath/go-jmespath. The whole specification
+// is supported, for example:
+//
+//   - fields and sub-expressions: data, foo.bar, "quoted-name"
+//   - indexes and slices: data[0], data[-1], data[:5]
+//   - projections: data[*].id, data[].roles[], *.id
+//   - filters: data[?public == `true` && contains(display_name, 'lab')]
+//   - multi-select: data[*].[id, display_name], data[*].{id: id, name: display_name}
+//   - pipes, @, literals (`json` and 'raw string'), !, ||, &&, and the
+//     comparison operators ==, !=, <, <=, >, >=
+//   - the built-in functions, such as length, sort_by, and to_string
+//
+// Multi-select hashes are objects, so their keys are printed in sorted
+// order. Function names are checked when the query is applied.
+type Query struct {
+	expr *jmespath.JMESPath
+}
+
+// ParseQuery compiles a JMESPath expression.
+func ParseQuery(expression string) (*Query, error) {
+	expr, err := jmespath.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("parse query: %w", err)
+	}
+	return &Query{expr: expr}, nil
+}
+
+// Apply evaluates the query against data, which is first converted to its
+// JSON form so that field names match the JSON output.
+func (q *Query) Apply(data interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("apply query: %w", err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(encoded, &value); err != nil {
+		return nil, fmt.Errorf("apply query: %w", err)
+	}
+
+	result, err := q.expr.Search(value)
+	if err != nil {
+		return nil, fmt.Errorf("apply query: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,119 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const queryTestDoc = `{
+  "data": [
+    {"id": "c1", "display_name": "Lab A", "public": true, "size": 10, "keywords": ["genomics", "lab"]},
+    {"id": "c2", "display_name": "Archive", "public": false, "size": 30, "keywords": []},
+    {"id": "c3", "display_name": "Lab B", "public": true, "size": 20, "keywords": ["lab"]}
+  ],
+  "has_next_page": false,
+  "marker": "m1"
+}`
+
+func TestQuery_Apply(t *testing.T) {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(queryTestDoc), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "marker", want: `"m1"`},
+		{query: "data[0].id", want: `"c1"`},
+		{query: "data[-1].id", want: `"c3"`},
+		{query: "data[*].id", want: `["c1","c2","c3"]`},
+		{query: "data[:2].id", want: `["c1","c2"]`},
+		{query: "data[::-1].id", want: `["c3","c2","c1"]`},
+		{query: "data[].keywords[]", want: `["genomics","lab","lab"]`},
+		{query: "data[?public].id", want: `["c1","c3"]`},
+		{query: "data[?public == `false`].display_name", want: `["Archive"]`},
+		{query: "data[?size > `15` && starts_with(display_name, 'Lab')].id", want: `["c3"]`},
+		{query: "data[?contains(keywords, 'genomics')].id", want: `["c1"]`},
+		{query: "data[?!public].id", want: `["c2"]`},
+		{query: "data[*].[id, size]", want: `[["c1",10],["c2",30],["c3",20]]`},
+		{query: "data[*].{name: display_name, id: id}", want: `[{"id":"c1","name":"Lab A"},{"id":"c2","name":"Archive"},{"id":"c3","name":"Lab B"}]`},
+		{query: "length(data)", want: `3`},
+		{query: "max(data[*].size)", want: `30`},
+		{query: "sort(data[*].display_name)", want: `["Archive","Lab A","Lab B"]`},
+		{query: "join(', ', data[*].id)", want: `"c1, c2, c3"`},
+		{query: "data[*].id | [0]", want: `"c1"`},
+		{query: "sort(keys(data[0]))", want: `["display_name","id","keywords","public","size"]`},
+		{query: `"has_next_page"`, want: `false`},
+		{query: "missing.field", want: `null`},
+		{query: "data[*].missing", want: `[]`},
+		{query: "marker || 'none'", want: `"m1"`},
+		{query: "data[?public] | length(@)", want: `2`},
+		{query: "sort_by(data, &size)[*].id", want: `["c1","c3","c2"]`},
+		{query: "max_by(data, &size).id", want: `"c2"`},
+		{query: "data[*].to_string(size)", want: `["10","30","20"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery() error = %v", err)
+			}
+			result, err := q.Apply(doc)
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			got, _ := json.Marshal(result)
+			if string(got) != tt.want {
+				t.Errorf("Apply() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	for _, query := range []string{"", "data[", "data[*", "data.", "data[?id == 'x'", "'open", "data[1:2:3:4]", "{id}"} {
+		if _, err := ParseQuery(query); err == nil {
+			t.Errorf("ParseQuery(%q) should fail", query)
+		}
+	}
+}
+
+func TestQuery_Apply_Errors(t *testing.T) {
+	for _, query := range []string{"foo(bar)", "length(`1`)", "abs(data)"} {
+		q, err := ParseQuery(query)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) error = %v", query, err)
+		}
+		if _, err := q.Apply(map[string]interface{}{"data": []interface{}{map[string]interface{}{"id": "c1"}}}); err == nil {
+			t.Errorf("Apply(%q) should fail", query)
+		}
+	}
+}
+
+func TestFormatter_PrintData_Query(t *testing.T) {
+	type collection struct {
+		ID          string `json:"id"`
+		DisplayName string `json:"display_name"`
+	}
+	data := struct {
+		Data []collection `json:"data"`
+	}{Data: []collection{{ID: "c1", DisplayName: "Lab"}}}
+
+	buf := &bytes.Buffer{}
+	if err := NewFormatter(FormatYAML, buf, WithQuery("data[*].{id: id, name: display_name}")).PrintData(data); err != nil {
+		t.Fatalf("PrintData() error = %v", err)
+	}
+	if got, want := buf.String(), "- id: c1\n  name: Lab\n"; got != want {
+		t.Errorf("PrintData() = %q, want %q", got, want)
+	}
+
+	err := NewFormatter(FormatTemplate, &bytes.Buffer{}, WithTemplate("{{.ID}}"), WithQuery("data")).PrintData(data)
+	if err == nil || !strings.Contains(err.Error(), "--query cannot be combined") {
+		t.Errorf("PrintData() with template and query error = %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Fixed values in the SARIF logs written by PrintAnnotations.
+const (
+	sarifVersion   = "2.1.0"
+	sarifSchema    = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolName  = "globus-connect-server"
+	sarifToolURI   = "https://github.com/scttfrdmn/globus-go-gcs"
+	sarifNoRuleID  = "finding"
+	sarifNoneLevel = "none"
+)
+
+// sarifLog is the subset of a SARIF 2.1.0 log written by PrintAnnotations.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// printSARIF writes annotations as a SARIF log with one run.
+func (f *Formatter) printSARIF(annotations []Annotation) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           sarifToolName,
+			InformationURI: sarifToolURI,
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	rules := map[string]bool{}
+	for _, a := range annotations {
+		ruleID := a.Rule
+		if ruleID == "" {
+			ruleID = sarifNoRuleID
+		}
+		rules[ruleID] = true
+
+		message := a.Message
+		if a.Title != "" {
+			message = a.Title + ": " + message
+		}
+		result := sarifResult{RuleID: ruleID, Level: sarifLevel(a.Level), Message: sarifMessage{Text: message}}
+		if a.File != "" {
+			location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: a.File},
+			}}
+			if a.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: a.Line}
+			}
+			result.Locations = []sarifLocation{location}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	ids := make([]string, 0, len(rules))
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: id})
+	}
+
+	encoder := json.NewEncoder(f.writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}}); err != nil {
+		return fmt.Errorf("encode SARIF: %w", err)
+	}
+
+	return nil
+}
+
+// sarifLevel maps an annotation level to a SARIF result level.
+func sarifLevel(level string) string {
+	switch level {
+	case AnnotationError, AnnotationWarning:
+		return level
+	case AnnotationNotice, "":
+		return "note"
+	default:
+		return sarifNoneLevel
+	}
+}
@@ -0,0 +1,179 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Sink receives structured records from long-running modes such as
+// watch, tail, and serve, which emit one record per event rather than a
+// single document.
+//
+// Records are usually API types or maps that encode to JSON. A Sink must
+// be safe for concurrent use. Sinks that hold resources also implement
+// io.Closer.
+type Sink interface {
+	Emit(record interface{}) error
+}
+
+// SinkFunc adapts a function to the Sink interface.
+type SinkFunc func(record interface{}) error
+
+// Emit calls f(record).
+func (f SinkFunc) Emit(record interface{}) error {
+	return f(record)
+}
+
+// WriterSink writes records to an io.Writer, one per line: as compact
+// JSON for FormatJSON (JSON Lines), or with fmt's %v formatting otherwise.
+type WriterSink struct {
+	mu     sync.Mutex
+	writer io.Writer
+	format Format
+}
+
+// NewWriterSink creates a sink that writes records to w in format.
+func NewWriterSink(w io.Writer, format Format) *WriterSink {
+	return &WriterSink{writer: w, format: format}
+}
+
+// Emit writes record as one line.
+func (s *WriterSink) Emit(record interface{}) error {
+	line, err := encodeRecord(record, s.format)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.writer.Write(line); err != nil {
+		return fmt.Errorf("write record: %w", err)
+	}
+	return nil
+}
+
+// RotatingFileSink appends JSON Lines records to a file and rotates it
+// when it would grow past a size limit. Rotated files are named path.1
+// (newest) through path.N (oldest); older files are removed.
+type RotatingFileSink struct {
+	mu         sync.Mutex
+	path       string
+	maxBytes   int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// NewRotatingFileSink opens path for appending, creating it with mode
+// 0600 if needed. The file is rotated before a record would take it past
+// maxBytes, keeping at most maxBackups rotated files. A maxBytes of zero
+// or less disables rotation.
+func NewRotatingFileSink(path string, maxBytes int64, maxBackups int) (*RotatingFileSink, error) {
+	s := &RotatingFileSink{path: path, maxBytes: maxBytes, maxBackups: maxBackups}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Emit appends record to the file as one JSON line.
+func (s *RotatingFileSink) Emit(record interface{}) error {
+	line, err := encodeRecord(record, FormatJSON)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return fmt.Errorf("write record: sink is closed")
+	}
+	if s.maxBytes > 0 && s.size > 0 && s.size+int64(len(line)) > s.maxBytes {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("write record: %w", err)
+	}
+	return nil
+}
+
+// Close closes the current file.
+func (s *RotatingFileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// open opens the current file for appending and records its size.
+func (s *RotatingFileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", s.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("stat %s: %w", s.path, err)
+	}
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+// rotate shifts the rotated files up by one, moves the current file to
+// path.1, and opens a new current file.
+func (s *RotatingFileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", s.path, err)
+	}
+	s.file = nil
+
+	if s.maxBackups > 0 {
+		_ = os.Remove(s.backupPath(s.maxBackups))
+		for i := s.maxBackups - 1; i >= 1; i-- {
+			if err := os.Rename(s.backupPath(i), s.backupPath(i+1)); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("rotate %s: %w", s.path, err)
+			}
+		}
+		if err := os.Rename(s.path, s.backupPath(1)); err != nil {
+			return fmt.Errorf("rotate %s: %w", s.path, err)
+		}
+	} else if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("rotate %s: %w", s.path, err)
+	}
+
+	return s.open()
+}
+
+// backupPath returns the name of the n-th rotated file.
+func (s *RotatingFileSink) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", s.path, n)
+}
+
+// encodeRecord returns record as one line in format.
+func encodeRecord(record interface{}, format Format) ([]byte, error) {
+	if format != FormatJSON {
+		return []byte(fmt.Sprintln(record)), nil
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("encode record: %w", err)
+	}
+	return append(data, '\n'), nil
+}
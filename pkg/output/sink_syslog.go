@@ -0,0 +1,42 @@
+//go:build !windows && !plan9
+
+package output
+
+import (
+	"fmt"
+	"log/syslog"
+)
+
+// SyslogSink sends records to the local syslog daemon as JSON messages
+// at informational priority. It is not available on Windows or Plan 9.
+type SyslogSink struct {
+	writer *syslog.Writer
+}
+
+// NewSyslogSink connects to the local syslog daemon. The tag identifies
+// the program in each message; facility is a syslog facility such as
+// syslog.LOG_LOCAL0.
+func NewSyslogSink(tag string, facility syslog.Priority) (*SyslogSink, error) {
+	writer, err := syslog.New(facility|syslog.LOG_INFO, tag)
+	if err != nil {
+		return nil, fmt.Errorf("connect to syslog: %w", err)
+	}
+	return &SyslogSink{writer: writer}, nil
+}
+
+// Emit sends record as one syslog message.
+func (s *SyslogSink) Emit(record interface{}) error {
+	line, err := encodeRecord(record, FormatJSON)
+	if err != nil {
+		return err
+	}
+	if err := s.writer.Info(string(line[:len(line)-1])); err != nil {
+		return fmt.Errorf("write syslog: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connection to the syslog daemon.
+func (s *SyslogSink) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,112 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewWriterSink(&buf, FormatJSON)
+
+	if err := sink.Emit(map[string]string{"id": "a"}); err != nil {
+		t.Fatalf("Emit() error = %v", err)
+	}
+	if err := sink.Emit(map[string]int{"n": 2}); err != nil {
+		t.Fatalf("Emit() error = %v", err)
+	}
+
+	if got, want := buf.String(), "{\"id\":\"a\"}\n{\"n\":2}\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestWriterSink_Text(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewWriterSink(&buf, FormatText).Emit("collection updated"); err != nil {
+		t.Fatalf("Emit() error = %v", err)
+	}
+	if got := buf.String(); got != "collection updated\n" {
+		t.Errorf("output = %q", got)
+	}
+}
+
+func TestWriterSink_EncodeError(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewWriterSink(&buf, FormatJSON).Emit(func() {}); err == nil {
+		t.Error("Emit() expected error for unencodable record")
+	}
+}
+
+func TestSinkFunc(t *testing.T) {
+	var got []interface{}
+	var sink Sink = SinkFunc(func(record interface{}) error {
+		got = append(got, record)
+		return nil
+	})
+	_ = sink.Emit("a")
+	if len(got) != 1 || got[0] != "a" {
+		t.Errorf("records = %v", got)
+	}
+}
+
+func TestRotatingFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sink, err := NewRotatingFileSink(path, 20, 2)
+	if err != nil {
+		t.Fatalf("NewRotatingFileSink() error = %v", err)
+	}
+
+	// Each record is 12 bytes, so every record after the first rotates.
+	for _, id := range []string{"1", "2", "3", "4"} {
+		if err := sink.Emit(map[string]string{"id": id}); err != nil {
+			t.Fatalf("Emit() error = %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	for file, want := range map[string]string{
+		path:        `{"id":"4"}`,
+		path + ".1": `{"id":"3"}`,
+		path + ".2": `{"id":"2"}`,
+	} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("read %s: %v", file, err)
+		}
+		if got := strings.TrimSpace(string(data)); got != want {
+			t.Errorf("%s = %q, want %q", filepath.Base(file), got, want)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected at most 2 rotated files, stat .3: %v", err)
+	}
+
+	if err := sink.Emit("late"); err == nil {
+		t.Error("Emit() after Close() expected error")
+	}
+}
+
+func TestRotatingFileSink_Appends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	if err := os.WriteFile(path, []byte("{\"id\":\"0\"}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	sink, err := NewRotatingFileSink(path, 0, 0)
+	if err != nil {
+		t.Fatalf("NewRotatingFileSink() error = %v", err)
+	}
+	_ = sink.Emit(map[string]string{"id": "1"})
+	_ = sink.Close()
+
+	data, _ := os.ReadFile(path)
+	if got := strings.Count(string(data), "\n"); got != 2 {
+		t.Errorf("file has %d lines, want 2", got)
+	}
+}
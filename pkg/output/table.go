@@ -0,0 +1,212 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultMaxColumnWidth is the width at which table cells are truncated
+// unless the table sets MaxColumnWidth. It fits a UUID with room to spare.
+const DefaultMaxColumnWidth = 48
+
+// Table is tabular output with a header row, rendered by PrintTable with
+// aligned columns:
+//
+//	ID       | Display Name | Type
+//	-------- | ------------ | ------
+//	abc123   | Project Data | mapped
+type Table struct {
+	Headers []string
+	Rows    [][]string
+
+	// MaxColumnWidth truncates longer cells with "…"; DefaultMaxColumnWidth
+	// if zero, no limit if negative.
+	MaxColumnWidth int
+
+	// Narrow is the number of leading columns shown in table format. The
+	// other columns are wide columns, shown in wide and CSV formats or when
+	// selected with WithColumns. All columns are shown if zero.
+	Narrow int
+}
+
+// NewTable creates a table with the given column headers.
+func NewTable(headers ...string) *Table {
+	return &Table{Headers: headers}
+}
+
+// AddWideColumns appends columns that table format leaves out (see
+// Narrow). Rows list their cells in header order, wide columns last.
+func (t *Table) AddWideColumns(headers ...string) {
+	if t.Narrow == 0 {
+		t.Narrow = len(t.Headers)
+	}
+	t.Headers = append(t.Headers, headers...)
+}
+
+// AddRow appends a row. Missing cells are left blank and extra cells are
+// ignored.
+func (t *Table) AddRow(cells ...string) {
+	row := make([]string, len(t.Headers))
+	copy(row, cells)
+	t.Rows = append(t.Rows, row)
+}
+
+// render returns the table as aligned lines.
+func (t *Table) render() string {
+	limit := t.MaxColumnWidth
+	if limit == 0 {
+		limit = DefaultMaxColumnWidth
+	}
+
+	cell := func(s string) string {
+		// Cells are single-line
+		s = strings.Join(strings.Fields(s), " ")
+		if limit > 0 && utf8.RuneCountInString(s) > limit {
+			runes := []rune(s)
+			s = string(runes[:limit-1]) + "…"
+		}
+		return s
+	}
+
+	headers := make([]string, len(t.Headers))
+	widths := make([]int, len(t.Headers))
+	for i, h := range t.Headers {
+		headers[i] = cell(h)
+		widths[i] = utf8.RuneCountInString(headers[i])
+	}
+	rows := make([][]string, len(t.Rows))
+	for r, row := range t.Rows {
+		rows[r] = make([]string, len(t.Headers))
+		for i := range t.Headers {
+			if i < len(row) {
+				rows[r][i] = cell(row[i])
+			}
+			if n := utf8.RuneCountInString(rows[r][i]); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	var b strings.Builder
+	line := func(cells []string) {
+		for i, c := range cells {
+			if i > 0 {
+				b.WriteString(" | ")
+			}
+			if i == len(cells)-1 {
+				// No trailing padding on the last column
+				b.WriteString(c)
+				continue
+			}
+			b.WriteString(c)
+			b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(c)))
+		}
+		b.WriteString("\n")
+	}
+
+	line(headers)
+	separators := make([]string, len(widths))
+	for i, w := range widths {
+		separators[i] = strings.Repeat("-", w)
+	}
+	line(separators)
+	for _, row := range rows {
+		line(row)
+	}
+	return b.String()
+}
+
+// ColumnName returns the name that selects a column with WithColumns: the
+// header in lower case with spaces replaced by underscores, e.g.
+// "display_name" for "Display Name".
+func ColumnName(header string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(header)), " ", "_")
+}
+
+// selectColumns returns a table with only the named columns, in the order
+// given. Names are matched with ColumnName.
+func (t *Table) selectColumns(columns []string) (*Table, error) {
+	index := make(map[string]int, len(t.Headers))
+	names := make([]string, len(t.Headers))
+	for i, h := range t.Headers {
+		names[i] = ColumnName(h)
+		index[names[i]] = i
+	}
+
+	picked := make([]int, 0, len(columns))
+	for _, c := range columns {
+		i, ok := index[ColumnName(c)]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q (available: %s)", c, strings.Join(names, ", "))
+		}
+		picked = append(picked, i)
+	}
+
+	selected := &Table{MaxColumnWidth: t.MaxColumnWidth}
+	for _, i := range picked {
+		selected.Headers = append(selected.Headers, t.Headers[i])
+	}
+	for _, row := range t.Rows {
+		cells := make([]string, len(picked))
+		for j, i := range picked {
+			if i < len(row) {
+				cells[j] = row[i]
+			}
+		}
+		selected.Rows = append(selected.Rows, cells)
+	}
+	return selected, nil
+}
+
+// PrintTable outputs a table.
+//
+// If the formatter is set to table format, outputs the table's narrow
+// columns aligned; in wide format, outputs all columns aligned; in CSV
+// format, outputs all columns as comma-separated values with a header row
+// and no truncation. Otherwise, does nothing (callers print text or JSON
+// instead). Columns chosen with WithColumns are selected instead, in
+// every format; an unknown column name is an error.
+func (f *Formatter) PrintTable(t *Table) error {
+	if !f.IsTabular() {
+		return nil
+	}
+
+	switch {
+	case len(f.columns) > 0:
+		var err error
+		if t, err = t.selectColumns(f.columns); err != nil {
+			return err
+		}
+	case f.format == FormatTable && t.Narrow > 0 && t.Narrow < len(t.Headers):
+		narrow := make([]string, t.Narrow)
+		for i := range narrow {
+			narrow[i] = ColumnName(t.Headers[i])
+		}
+		t, _ = t.selectColumns(narrow)
+	}
+
+	if f.format == FormatCSV {
+		w := csv.NewWriter(f.writer)
+		if err := w.Write(t.Headers); err != nil {
+			return fmt.Errorf("write CSV: %w", err)
+		}
+		for _, row := range t.Rows {
+			if err := w.Write(row); err != nil {
+				return fmt.Errorf("write CSV: %w", err)
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return fmt.Errorf("write CSV: %w", err)
+		}
+		return nil
+	}
+
+	if _, err := fmt.Fprint(f.writer, t.render()); err != nil {
+		return fmt.Errorf("write table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFormatter_PrintTable(t *testing.T) {
+	table := NewTable("ID", "Name", "Public")
+	table.AddRow("c1", "Project Data", "true")
+	table.AddRow("collection-2", "Scratch")
+
+	buf := &bytes.Buffer{}
+	if err := NewFormatter(FormatTable, buf).PrintTable(table); err != nil {
+		t.Fatalf("PrintTable() error = %v", err)
+	}
+
+	want := "ID           | Name         | Public\n" +
+		"------------ | ------------ | ------\n" +
+		"c1           | Project Data | true\n" +
+		"collection-2 | Scratch      | \n"
+	if got := buf.String(); got != want {
+		t.Errorf("PrintTable() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatter_PrintTable_Truncates(t *testing.T) {
+	table := NewTable("Description")
+	table.MaxColumnWidth = 10
+	table.AddRow("a description that is\ntoo long")
+
+	buf := &bytes.Buffer{}
+	if err := NewFormatter(FormatTable, buf).PrintTable(table); err != nil {
+		t.Fatalf("PrintTable() error = %v", err)
+	}
+
+	want := "Descripti…\n----------\na descrip…\n"
+	if got := buf.String(); got != want {
+		t.Errorf("PrintTable() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestFormatter_TableFormatPrintsText(t *testing.T) {
+	buf := &bytes.Buffer{}
+	formatter := NewFormatter(FormatTable, buf)
+
+	if err := formatter.PrintText("%s\n", "details"); err != nil {
+		t.Fatalf("PrintText() error = %v", err)
+	}
+	if err := formatter.PrintJSON(map[string]string{"k": "v"}); err != nil {
+		t.Fatalf("PrintJSON() error = %v", err)
+	}
+	if got := buf.String(); got != "details\n" {
+		t.Errorf("output = %q, want only the text", got)
+	}
+	if formatter.IsText() || !formatter.IsTable() {
+		t.Errorf("IsText() = %v, IsTable() = %v, want false, true", formatter.IsText(), formatter.IsTable())
+	}
+}
+
+func TestFormatter_PrintTable_NotTableFormat(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := NewFormatter(FormatText, buf).PrintTable(NewTable("ID")); err != nil {
+		t.Fatalf("PrintTable() error = %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("PrintTable() in text format wrote %q", buf.String())
+	}
+}
+
+func TestFormatter_PrintTable_CSV(t *testing.T) {
+	table := NewTable("ID", "Display Name", "Public")
+	table.MaxColumnWidth = 5
+	table.AddRow("c1", "Project, Alpha", "true")
+
+	buf := &bytes.Buffer{}
+	formatter := NewFormatter(FormatCSV, buf, WithColumns([]string{"display_name", "ID"}))
+	if !formatter.IsTabular() {
+		t.Fatal("IsTabular() = false for CSV")
+	}
+	if err := formatter.PrintTable(table); err != nil {
+		t.Fatalf("PrintTable() error = %v", err)
+	}
+
+	// CSV is not truncated and follows the --columns order
+	want := "Display Name,ID\n\"Project, Alpha\",c1\n"
+	if got := buf.String(); got != want {
+		t.Errorf("PrintTable() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestFormatter_PrintTable_UnknownColumn(t *testing.T) {
+	formatter := NewFormatter(FormatTable, &bytes.Buffer{}, WithColumns([]string{"owner"}))
+
+	err := formatter.PrintTable(NewTable("ID", "Display Name"))
+	if err == nil || err.Error() != `unknown column "owner" (available: id, display_name)` {
+		t.Errorf("PrintTable() error = %v, want unknown column", err)
+	}
+}
+
+func TestFormatter_PrintTable_WideColumns(t *testing.T) {
+	newTable := func() *Table {
+		table := NewTable("ID", "Name")
+		table.AddWideColumns("Base Path")
+		table.AddRow("c1", "Project Data", "/project")
+		return table
+	}
+
+	tests := []struct {
+		name    string
+		format  Format
+		columns []string
+		want    string
+	}{
+		{
+			name:   "table leaves out wide columns",
+			format: FormatTable,
+			want:   "ID | Name\n-- | ------------\nc1 | Project Data\n",
+		},
+		{
+			name:   "wide shows all columns",
+			format: FormatWide,
+			want:   "ID | Name         | Base Path\n-- | ------------ | ---------\nc1 | Project Data | /project\n",
+		},
+		{
+			name:   "CSV shows all columns",
+			format: FormatCSV,
+			want:   "ID,Name,Base Path\nc1,Project Data,/project\n",
+		},
+		{
+			name:    "columns select wide columns",
+			format:  FormatTable,
+			columns: []string{"id", "base_path"},
+			want:    "ID | Base Path\n-- | ---------\nc1 | /project\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			if err := NewFormatter(tt.format, buf, WithColumns(tt.columns)).PrintTable(newTable()); err != nil {
+				t.Fatalf("PrintTable() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("PrintTable() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
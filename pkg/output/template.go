@@ -0,0 +1,110 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// WithTemplate sets the text/template used in template format.
+func WithTemplate(text string) Option {
+	return func(f *Formatter) {
+		f.template = text
+	}
+}
+
+// templateFuncs are the functions available to output templates in
+// addition to the text/template builtins.
+var templateFuncs = template.FuncMap{
+	"join": func(sep string, items []string) string { return strings.Join(items, sep) },
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// ParseTemplate parses an output template, reporting syntax errors before
+// a command runs.
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// PrintTemplate outputs data through the formatter's template.
+//
+// If the formatter is set to template format, renders each item of data
+// on its own line: the elements of a slice, the elements of a struct's
+// Data field or a map's "data" key (as in the API's list responses), or
+// otherwise data itself.
+// Templates refer to Go field names, e.g. '{{.ID}} {{.DisplayName}}', and
+// may use the join, json, lower, and upper functions. Otherwise, does
+// nothing.
+func (f *Formatter) PrintTemplate(data interface{}) error {
+	if f.format != FormatTemplate {
+		return nil
+	}
+	if f.template == "" {
+		return fmt.Errorf("--format template requires --template")
+	}
+
+	tmpl, err := ParseTemplate(f.template)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range templateItems(data) {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, item); err != nil {
+			return fmt.Errorf("execute template: %w", err)
+		}
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		if _, err := f.writer.Write(buf.Bytes()); err != nil {
+			return fmt.Errorf("write template output: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// templateItems returns the items of data that a template renders.
+func templateItems(data interface{}) []interface{} {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return []interface{}{data}
+		}
+		v = v.Elem()
+	}
+
+	switch {
+	case v.Kind() == reflect.Struct:
+		v = v.FieldByName("Data")
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		v = v.MapIndex(reflect.ValueOf("data").Convert(v.Type().Key()))
+	}
+	for v.IsValid() && v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return []interface{}{data}
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return []interface{}{data}
+	}
+
+	items := make([]interface{}, v.Len())
+	for i := range items {
+		items[i] = v.Index(i).Interface()
+	}
+	return items
+}
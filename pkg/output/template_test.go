@@ -0,0 +1,84 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type templateItem struct {
+	ID          string
+	DisplayName string
+	Keywords    []string
+}
+
+func TestFormatter_PrintTemplate(t *testing.T) {
+	items := []templateItem{
+		{ID: "c1", DisplayName: "Alpha", Keywords: []string{"a", "b"}},
+		{ID: "c2", DisplayName: "Beta"},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		data     interface{}
+		want     string
+	}{
+		{
+			name:     "slice",
+			template: "{{.ID}} {{.DisplayName}}",
+			data:     items,
+			want:     "c1 Alpha\nc2 Beta\n",
+		},
+		{
+			name:     "list response",
+			template: "{{.ID}}\n",
+			data:     &struct{ Data []templateItem }{Data: items},
+			want:     "c1\nc2\n",
+		},
+		{
+			name:     "data map",
+			template: "{{.ID}}",
+			data:     map[string]interface{}{"data": items},
+			want:     "c1\nc2\n",
+		},
+		{
+			name:     "single item",
+			template: `{{upper .DisplayName}} {{join "," .Keywords}}`,
+			data:     &items[0],
+			want:     "ALPHA a,b\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			formatter := NewFormatter(FormatTemplate, buf, WithTemplate(tt.template))
+			if !formatter.IsStructured() {
+				t.Fatal("IsStructured() = false for template")
+			}
+			if err := formatter.PrintData(tt.data); err != nil {
+				t.Fatalf("PrintData() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("PrintData() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_PrintTemplate_Errors(t *testing.T) {
+	err := NewFormatter(FormatTemplate, &bytes.Buffer{}).PrintTemplate(templateItem{})
+	if err == nil || !strings.Contains(err.Error(), "requires --template") {
+		t.Errorf("PrintTemplate() without template error = %v", err)
+	}
+
+	err = NewFormatter(FormatTemplate, &bytes.Buffer{}, WithTemplate("{{.Owner}}")).PrintTemplate(templateItem{})
+	if err == nil || !strings.Contains(err.Error(), "execute template") {
+		t.Errorf("PrintTemplate() unknown field error = %v", err)
+	}
+
+	if _, err := ParseTemplate("{{.ID"); err == nil {
+		t.Error("ParseTemplate() should reject an unclosed action")
+	}
+}
@@ -0,0 +1,52 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// byteUnits are the IEC binary units used by FormatBytes.
+var byteUnits = []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// FormatBytes formats a byte count with IEC binary units and one decimal
+// place, e.g. "512 B", "1.2 GiB".
+func FormatBytes(n int64) string {
+	if n < 1024 && n > -1024 {
+		return strconv.FormatInt(n, 10) + " B"
+	}
+
+	value := float64(n)
+	unit := -1
+	for (value >= 1024 || value <= -1024) && unit < len(byteUnits)-1 {
+		value /= 1024
+		unit++
+	}
+	return fmt.Sprintf("%.1f %s", value, byteUnits[unit])
+}
+
+// FormatDuration formats a duration compactly, with precision suited to
+// its size: "250ms", "4.2s", "3m42s", "2h5m", "3d4h".
+func FormatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+
+	// Each branch rounds first so that values just below a unit boundary
+	// move to the next unit ("60.0s" becomes "1m0s").
+	if r := d.Round(time.Millisecond); r < time.Second {
+		return sign + r.String()
+	}
+	if r := d.Round(100 * time.Millisecond); r < time.Minute {
+		return sign + strconv.FormatFloat(r.Seconds(), 'f', 1, 64) + "s"
+	}
+	if r := d.Round(time.Second); r < time.Hour {
+		return fmt.Sprintf("%s%dm%ds", sign, int(r/time.Minute), int(r%time.Minute/time.Second))
+	}
+	if r := d.Round(time.Minute); r < 24*time.Hour {
+		return fmt.Sprintf("%s%dh%dm", sign, int(r/time.Hour), int(r%time.Hour/time.Minute))
+	}
+	r := d.Round(time.Hour)
+	return fmt.Sprintf("%s%dd%dh", sign, int(r/(24*time.Hour)), int(r%(24*time.Hour)/time.Hour))
+}
@@ -0,0 +1,48 @@
+package output
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{1288490189, "1.2 GiB"},
+		{5 << 40, "5.0 TiB"},
+		{-2048, "-2.0 KiB"},
+	}
+	for _, tt := range tests {
+		if got := FormatBytes(tt.n); got != tt.want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{250 * time.Millisecond, "250ms"},
+		{4200 * time.Millisecond, "4.2s"},
+		{59960 * time.Millisecond, "1m0s"},
+		{3*time.Minute + 42*time.Second, "3m42s"},
+		{2*time.Hour + 5*time.Minute + 10*time.Second, "2h5m"},
+		{76 * time.Hour, "3d4h"},
+		{-90 * time.Second, "-1m30s"},
+	}
+	for _, tt := range tests {
+		if got := FormatDuration(tt.d); got != tt.want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
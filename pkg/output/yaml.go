@@ -0,0 +1,63 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// PrintYAML outputs data in YAML format.
+//
+// If the formatter is set to YAML format, outputs data as a YAML document.
+// Otherwise, does nothing. Data is converted through its JSON encoding, so
+// field names and omitted fields match the JSON output and the order of
+// struct fields is kept.
+func (f *Formatter) PrintYAML(data interface{}) error {
+	if f.format != FormatYAML {
+		return nil
+	}
+
+	doc, err := toYAMLNode(data)
+	if err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(f.writer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("encode YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("encode YAML: %w", err)
+	}
+
+	return nil
+}
+
+// toYAMLNode converts data to a YAML node through its JSON encoding.
+func toYAMLNode(data interface{}) (*yaml.Node, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("encode YAML: %w", err)
+	}
+
+	// JSON is YAML in flow style, so decoding it keeps key order and
+	// scalar types.
+	var doc yaml.Node
+	if err := yaml.Unmarshal(encoded, &doc); err != nil {
+		return nil, fmt.Errorf("encode YAML: %w", err)
+	}
+	blockStyle(&doc)
+	return &doc, nil
+}
+
+// blockStyle clears the flow and quoting styles that decoding JSON leaves
+// on n and its children, so the document is written in block style. The
+// encoder still quotes strings that would otherwise read as another type.
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, child := range n.Content {
+		blockStyle(child)
+	}
+}
@@ -0,0 +1,59 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFormatter_PrintYAML(t *testing.T) {
+	type collection struct {
+		ID          string   `json:"id"`
+		DisplayName string   `json:"display_name"`
+		Public      bool     `json:"public"`
+		Version     string   `json:"version"`
+		Description string   `json:"description,omitempty"`
+		Keywords    []string `json:"keywords"`
+	}
+
+	buf := &bytes.Buffer{}
+	formatter := NewFormatter(FormatYAML, buf)
+	if !formatter.IsStructured() || !formatter.IsYAML() || formatter.IsJSON() {
+		t.Fatalf("IsStructured/IsYAML/IsJSON = %v/%v/%v, want true/true/false",
+			formatter.IsStructured(), formatter.IsYAML(), formatter.IsJSON())
+	}
+
+	err := formatter.PrintData(collection{
+		ID:          "c1",
+		DisplayName: "Project: Alpha",
+		Public:      true,
+		Version:     "1.0",
+		Keywords:    []string{"physics", "true"},
+	})
+	if err != nil {
+		t.Fatalf("PrintData() error = %v", err)
+	}
+
+	// Fields keep struct order and JSON names; strings that would read as
+	// another type stay quoted.
+	want := `id: c1
+display_name: 'Project: Alpha'
+public: true
+version: "1.0"
+keywords:
+  - physics
+  - "true"
+`
+	if got := buf.String(); got != want {
+		t.Errorf("PrintData() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatter_PrintData_Text(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := NewFormatter(FormatText, buf).PrintData(map[string]string{"k": "v"}); err != nil {
+		t.Fatalf("PrintData() error = %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("PrintData() in text format wrote %q", buf.String())
+	}
+}